@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/caarlos0/env"
 	"github.com/joho/godotenv"
@@ -38,6 +39,98 @@ type Config struct {
 		Password string `long:"db_password" description:"Password DB" env:"DB_PASS" required:"true" default:"dbpass"`
 		SSLMode  string `long:"db_sslmode" description:"SSLMode DB" env:"DB_SSLMODE" required:"true" default:"disable"`
 	}
+
+	Popularity struct {
+		PlayWeight       float64       `long:"popularity_play_weight" description:"Weight of a play in popularity score" env:"POPULARITY_PLAY_WEIGHT" envDefault:"1" default:"1"`
+		LikeWeight       float64       `long:"popularity_like_weight" description:"Weight of a like in popularity score" env:"POPULARITY_LIKE_WEIGHT" envDefault:"5" default:"5"`
+		TrendingHalfLife time.Duration `long:"trending_half_life" description:"Half-life of plays and likes in trending score" env:"TRENDING_HALF_LIFE" envDefault:"48h" default:"48h"`
+		RefreshInterval  time.Duration `long:"popularity_refresh_interval" description:"Popularity charts refresh interval" env:"POPULARITY_REFRESH_INTERVAL" envDefault:"5m" default:"5m"`
+		RefreshTimeout   time.Duration `long:"popularity_refresh_timeout" description:"Maximum duration of a popularity charts refresh" env:"POPULARITY_REFRESH_TIMEOUT" envDefault:"5m" default:"5m"`
+	}
+
+	Chart struct {
+		Size             int           `long:"chart_size" description:"Number of positions in weekly chart" env:"CHART_SIZE" envDefault:"100" default:"100"`
+		SnapshotInterval time.Duration `long:"chart_snapshot_interval" description:"Weekly chart snapshot check interval" env:"CHART_SNAPSHOT_INTERVAL" envDefault:"1h" default:"1h"`
+	}
+
+	Recommendations struct {
+		Neighbors       int           `long:"recommendations_neighbors" description:"Number of similar tracks stored per track" env:"RECOMMENDATIONS_NEIGHBORS" envDefault:"50" default:"50"`
+		MinCoLikes      int           `long:"recommendations_min_co_likes" description:"Minimum number of co-likes for tracks to be similar" env:"RECOMMENDATIONS_MIN_CO_LIKES" envDefault:"2" default:"2"`
+		RefreshInterval time.Duration `long:"recommendations_refresh_interval" description:"Track similarity recompute interval" env:"RECOMMENDATIONS_REFRESH_INTERVAL" envDefault:"1h" default:"1h"`
+		RefreshTimeout  time.Duration `long:"recommendations_refresh_timeout" description:"Maximum duration of a track similarity recompute" env:"RECOMMENDATIONS_REFRESH_TIMEOUT" envDefault:"15m" default:"15m"`
+	}
+
+	Comments struct {
+		RateLimit  int           `long:"comments_rate_limit" description:"Maximum number of comments per user within rate window" env:"COMMENTS_RATE_LIMIT" envDefault:"10" default:"10"`
+		RateWindow time.Duration `long:"comments_rate_window" description:"Comment rate limit window" env:"COMMENTS_RATE_WINDOW" envDefault:"1m" default:"1m"`
+	}
+
+	Trash struct {
+		Retention     time.Duration `long:"trash_retention" description:"How long deleted tracks and users are kept before purge" env:"TRASH_RETENTION" envDefault:"720h" default:"720h"`
+		PurgeInterval time.Duration `long:"trash_purge_interval" description:"Trash purge interval" env:"TRASH_PURGE_INTERVAL" envDefault:"1h" default:"1h"`
+	}
+	Releases struct {
+		PublishInterval time.Duration `long:"releases_publish_interval" description:"Scheduled releases publish interval" env:"RELEASES_PUBLISH_INTERVAL" envDefault:"1m" default:"1m"`
+	}
+
+	Notifications struct {
+		DeliveryInterval time.Duration `long:"notifications_delivery_interval" description:"Notifications fan-out and delivery interval" env:"NOTIFICATIONS_DELIVERY_INTERVAL" envDefault:"1m" default:"1m"`
+		BatchSize        int           `long:"notifications_batch_size" description:"Maximum number of notifications delivered per run" env:"NOTIFICATIONS_BATCH_SIZE" envDefault:"100" default:"100"`
+		SMTPAddr         string        `long:"notifications_smtp_addr" description:"SMTP server address for email notifications, empty disables email channel" env:"NOTIFICATIONS_SMTP_ADDR"`
+		SMTPFrom         string        `long:"notifications_smtp_from" description:"Sender address of email notifications" env:"NOTIFICATIONS_SMTP_FROM" envDefault:"noreply@music.local" default:"noreply@music.local"`
+		WebhookURL       string        `long:"notifications_webhook_url" description:"URL receiving notifications as JSON, empty disables webhook channel" env:"NOTIFICATIONS_WEBHOOK_URL"`
+	}
+
+	Stats struct {
+		TopSize         int           `long:"stats_top_size" description:"Number of top tracks and artists in listening stats" env:"STATS_TOP_SIZE" envDefault:"10" default:"10"`
+		ReviewInterval  time.Duration `long:"stats_review_interval" description:"Year-in-review reports generation interval" env:"STATS_REVIEW_INTERVAL" envDefault:"1h" default:"1h"`
+		ReviewBatchSize int           `long:"stats_review_batch_size" description:"Maximum number of year-in-review reports generated per run" env:"STATS_REVIEW_BATCH_SIZE" envDefault:"100" default:"100"`
+	}
+
+	Export struct {
+		BaseURL    string        `long:"export_base_url" description:"Public URL of the API used in exported track links" env:"EXPORT_BASE_URL" envDefault:"http://localhost:8000" default:"http://localhost:8000"`
+		SyncLimit  int           `long:"export_sync_limit" description:"Maximum number of items exported in the request, larger exports run as background jobs" env:"EXPORT_SYNC_LIMIT" envDefault:"5000" default:"5000"`
+		Retention  time.Duration `long:"export_retention" description:"How long finished export files are kept" env:"EXPORT_RETENTION" envDefault:"24h" default:"24h"`
+		Interval   time.Duration `long:"export_interval" description:"Export jobs processing interval" env:"EXPORT_INTERVAL" envDefault:"1m" default:"1m"`
+		BatchSize  int           `long:"export_batch_size" description:"Maximum number of export jobs processed per run" env:"EXPORT_BATCH_SIZE" envDefault:"5" default:"5"`
+		JobTimeout time.Duration `long:"export_job_timeout" description:"Time after which a running export job is considered stuck and restarted" env:"EXPORT_JOB_TIMEOUT" envDefault:"30m" default:"30m"`
+	}
+
+	Auth struct {
+		AccessTokenTTL  time.Duration `long:"auth_access_token_ttl" description:"Lifetime of an access token" env:"AUTH_ACCESS_TOKEN_TTL" envDefault:"15m" default:"15m"`
+		RefreshTokenTTL time.Duration `long:"auth_refresh_token_ttl" description:"Lifetime of a refresh token, renewed on every rotation" env:"AUTH_REFRESH_TOKEN_TTL" envDefault:"720h" default:"720h"`
+		CleanupInterval time.Duration `long:"auth_cleanup_interval" description:"Interval of removing expired refresh tokens" env:"AUTH_CLEANUP_INTERVAL" envDefault:"1h" default:"1h"`
+		SessionCacheTTL time.Duration `long:"auth_session_cache_ttl" description:"How long an active session check is cached, revocations on other instances apply after this delay" env:"AUTH_SESSION_CACHE_TTL" envDefault:"30s" default:"30s"`
+	}
+
+	Keys struct {
+		Algorithm        string        `long:"keys_algorithm" description:"Algorithm of new signing keys: RS256 or EdDSA" env:"KEYS_ALGORITHM" envDefault:"RS256" default:"RS256"`
+		RotationInterval time.Duration `long:"keys_rotation_interval" description:"How long a signing key signs new tokens before the next key takes over" env:"KEYS_ROTATION_INTERVAL" envDefault:"720h" default:"720h"`
+		Overlap          time.Duration `long:"keys_overlap" description:"How long a key is published before it signs and stays valid after it retires, not less than the access token lifetime" env:"KEYS_OVERLAP" envDefault:"24h" default:"24h"`
+		CheckInterval    time.Duration `long:"keys_check_interval" description:"Interval of checking whether the signing key must be rotated" env:"KEYS_CHECK_INTERVAL" envDefault:"1h" default:"1h"`
+		CacheTTL         time.Duration `long:"keys_cache_ttl" description:"How long the key set is cached before it is reloaded from the database" env:"KEYS_CACHE_TTL" envDefault:"1m" default:"1m"`
+		LegacyHS256      bool          `long:"keys_legacy_hs256" description:"Accept HS256 tokens without kid signed with api-key until they expire" env:"KEYS_LEGACY_HS256" envDefault:"false"`
+		LegacyCutoff     string        `long:"keys_legacy_cutoff" description:"RFC 3339 time of the switch to signing keys, only HS256 tokens issued before it are accepted" env:"KEYS_LEGACY_CUTOFF"`
+	}
+
+	OIDC struct {
+		Issuer          string        `long:"oidc_issuer" description:"OpenID Connect provider issuer URL, empty disables single sign-on" env:"OIDC_ISSUER"`
+		ClientID        string        `long:"oidc_client_id" description:"Client ID registered at the provider" env:"OIDC_CLIENT_ID"`
+		ClientSecret    string        `long:"oidc_client_secret" description:"Client secret, empty for a public client" env:"OIDC_CLIENT_SECRET"`
+		RedirectURL     string        `long:"oidc_redirect_url" description:"Callback URL registered at the provider" env:"OIDC_REDIRECT_URL" envDefault:"http://localhost:8000/auth/oidc/callback" default:"http://localhost:8000/auth/oidc/callback"`
+		Scopes          string        `long:"oidc_scopes" description:"Space-separated scopes requested from the provider" env:"OIDC_SCOPES" envDefault:"openid profile email" default:"openid profile email"`
+		GroupsClaim     string        `long:"oidc_groups_claim" description:"ID token claim with the user's groups" env:"OIDC_GROUPS_CLAIM" envDefault:"groups" default:"groups"`
+		AdminGroup      string        `long:"oidc_admin_group" description:"Provider group whose members get the ADMIN role, empty disables the mapping" env:"OIDC_ADMIN_GROUP"`
+		DefaultRole     string        `long:"oidc_default_role" description:"Role of accounts created on the first provider login" env:"OIDC_DEFAULT_ROLE" envDefault:"USER" default:"USER"`
+		StateTTL        time.Duration `long:"oidc_state_ttl" description:"How long a started provider login may be completed" env:"OIDC_STATE_TTL" envDefault:"10m" default:"10m"`
+		CleanupInterval time.Duration `long:"oidc_cleanup_interval" description:"Interval of removing expired provider logins" env:"OIDC_CLEANUP_INTERVAL" envDefault:"1h" default:"1h"`
+	}
+
+	Sync struct {
+		TokenTTL        time.Duration `long:"sync_token_ttl" description:"Lifetime of a sync token, older tokens require a full resync" env:"SYNC_TOKEN_TTL" envDefault:"720h" default:"720h"`
+		PageSize        int           `long:"sync_page_size" description:"Maximum number of changes returned by one sync request" env:"SYNC_PAGE_SIZE" envDefault:"1000" default:"1000"`
+		CleanupInterval time.Duration `long:"sync_cleanup_interval" description:"Interval of removing changes older than the token lifetime" env:"SYNC_CLEANUP_INTERVAL" envDefault:"1h" default:"1h"`
+	}
 }
 
 var (
@@ -51,8 +144,14 @@ func newConfig() (*Config, error) {
 		return nil, fmt.Errorf("error loading .env file: %v", err)
 	}
 
+	return ParseEnv()
+}
+
+// ParseEnv читает конфигурацию из переменных окружения. Вложенные секции разбираются отдельно:
+// env не заходит во вложенные структуры, и без своего env.Parse секция останется нулевой.
+func ParseEnv() (*Config, error) {
 	var cfg Config
-	err = env.Parse(&cfg)
+	err := env.Parse(&cfg)
 	if err != nil {
 		return nil, fmt.Errorf("error parse environment variables: %v", err)
 	}
@@ -68,6 +167,59 @@ func newConfig() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error parse environment variables: %v", err)
 	}
+	err = env.Parse(&cfg.Popularity)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+	err = env.Parse(&cfg.Chart)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+	err = env.Parse(&cfg.Recommendations)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+	err = env.Parse(&cfg.Comments)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+	err = env.Parse(&cfg.Trash)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+
+	err = env.Parse(&cfg.Releases)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+	err = env.Parse(&cfg.Notifications)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+	err = env.Parse(&cfg.Stats)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+	err = env.Parse(&cfg.Export)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+	err = env.Parse(&cfg.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+	err = env.Parse(&cfg.Sync)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+	err = env.Parse(&cfg.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
+	err = env.Parse(&cfg.OIDC)
+	if err != nil {
+		return nil, fmt.Errorf("error parse environment variables: %v", err)
+	}
 
 	return &cfg, nil
 }
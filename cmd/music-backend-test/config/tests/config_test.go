@@ -0,0 +1,68 @@
+package config
+
+import (
+	"music-backend-test/cmd/music-backend-test/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseEnv_Auth(t *testing.T) {
+	t.Setenv("AUTH_ACCESS_TOKEN_TTL", "5m")
+	t.Setenv("AUTH_REFRESH_TOKEN_TTL", "48h")
+	t.Setenv("AUTH_CLEANUP_INTERVAL", "30m")
+	t.Setenv("AUTH_SESSION_CACHE_TTL", "10s")
+
+	cfg, err := config.ParseEnv()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 5*time.Minute, cfg.Auth.AccessTokenTTL)
+		assert.Equal(t, 48*time.Hour, cfg.Auth.RefreshTokenTTL)
+		assert.Equal(t, 30*time.Minute, cfg.Auth.CleanupInterval)
+		assert.Equal(t, 10*time.Second, cfg.Auth.SessionCacheTTL)
+	}
+}
+
+func Test_ParseEnv_AuthDefaults(t *testing.T) {
+	cfg, err := config.ParseEnv()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 15*time.Minute, cfg.Auth.AccessTokenTTL)
+		assert.Equal(t, 720*time.Hour, cfg.Auth.RefreshTokenTTL)
+		assert.Equal(t, time.Hour, cfg.Auth.CleanupInterval)
+		assert.Equal(t, 30*time.Second, cfg.Auth.SessionCacheTTL)
+	}
+}
+
+func Test_ParseEnv_PopularityRefreshTimeout(t *testing.T) {
+	cfg, err := config.ParseEnv()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 5*time.Minute, cfg.Popularity.RefreshTimeout)
+	}
+
+	t.Setenv("POPULARITY_REFRESH_TIMEOUT", "15m")
+	cfg, err = config.ParseEnv()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 15*time.Minute, cfg.Popularity.RefreshTimeout)
+	}
+}
+
+func Test_ParseEnv_RecommendationsRefreshTimeout(t *testing.T) {
+	cfg, err := config.ParseEnv()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 15*time.Minute, cfg.Recommendations.RefreshTimeout)
+	}
+
+	t.Setenv("RECOMMENDATIONS_REFRESH_TIMEOUT", "1h")
+	cfg, err = config.ParseEnv()
+	if assert.NoError(t, err) {
+		assert.Equal(t, time.Hour, cfg.Recommendations.RefreshTimeout)
+	}
+}
+
+func Test_ParseEnv_KeysLegacyDisabledByDefault(t *testing.T) {
+	cfg, err := config.ParseEnv()
+	if assert.NoError(t, err) {
+		assert.False(t, cfg.Keys.LegacyHS256)
+		assert.Empty(t, cfg.Keys.LegacyCutoff)
+	}
+}
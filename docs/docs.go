@@ -19,55 +19,37 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/signin": {
-            "post": {
-                "description": "Авторизация пользователя с использованием имени пользователя и пароля.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Получение открытых ключей (JWKS), которыми проверяются access-токены. Ключ токена выбирается по заголовку kid. Следующий ключ публикуется заранее, а предыдущий остается в наборе, пока подписанные им токены действуют.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Вход пользователя",
-                "parameters": [
-                    {
-                        "description": "Данные пользователя для входа",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.UserCreate"
-                        }
-                    }
-                ],
+                "summary": "Открытые ключи подписи токенов",
                 "responses": {
                     "200": {
-                        "description": "Токен авторизации",
+                        "description": "Открытые ключи",
                         "schema": {
-                            "$ref": "#/definitions/view.TokenView"
+                            "$ref": "#/definitions/view.JWKSView"
                         }
                     },
-                    "400": {
-                        "description": "Некорректный запрос"
-                    },
-                    "401": {
-                        "description": "Ошибка авторизации"
-                    },
-                    "422": {
-                        "description": "Ошибка при обработке данных"
-                    },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
                     }
                 }
             }
         },
-        "/auth/signup": {
+        "/artists": {
             "post": {
-                "description": "Регистрация нового пользователя.",
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Добавление исполнителя в каталог",
                 "consumes": [
                     "application/json"
                 ],
@@ -75,32 +57,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Artists"
                 ],
-                "summary": "Регистрация пользователя",
+                "summary": "Создание исполнителя",
                 "parameters": [
                     {
-                        "description": "Данные пользователя для регистрации",
+                        "description": "Исполнитель",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.UserCreate"
+                            "$ref": "#/definitions/entity.ArtistCreate"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Токен авторизации",
+                        "description": "Созданный исполнитель",
                         "schema": {
-                            "$ref": "#/definitions/view.TokenView"
+                            "$ref": "#/definitions/view.ArtistView"
                         }
                     },
                     "400": {
                         "description": "Некорректный запрос"
                     },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
                     "422": {
-                        "description": "Ошибка при обработке данных"
+                        "description": "Некорректный исполнитель"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -108,32 +96,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/music/catalog": {
+        "/artists/{id}": {
             "get": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Получение всех треков",
+                "description": "Получение исполнителя по id",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Music"
+                    "Artists"
+                ],
+                "summary": "Исполнитель",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор исполнителя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получение всех треков",
                 "responses": {
                     "200": {
-                        "description": "Данные трека",
+                        "description": "Исполнитель",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/view.MusicView"
-                            }
+                            "$ref": "#/definitions/view.ArtistView"
                         }
                     },
                     "400": {
@@ -143,7 +137,10 @@ const docTemplate = `{
                         "description": "Неавторизованный запрос"
                     },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Исполнитель не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -151,36 +148,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/music/download/{id}": {
-            "get": {
+        "/artists/{id}/follow": {
+            "post": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Скачивание файла трека по id трека",
+                "description": "Подписка текущего пользователя на исполнителя. Новые релизы исполнителя попадают в ленту. Повторная подписка ничего не меняет.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Music"
+                    "Follows"
                 ],
-                "summary": "Скачивание файла трека",
+                "summary": "Подписка на исполнителя",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id трека",
+                        "description": "Идентификатор исполнителя",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Файл трека",
-                        "schema": {
-                            "type": "file"
-                        }
+                    "204": {
+                        "description": "Подписка оформлена"
                     },
                     "400": {
                         "description": "Некорректный запрос"
@@ -189,54 +186,45 @@ const docTemplate = `{
                         "description": "Неавторизованный запрос"
                     },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Исполнитель не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
                     }
                 }
-            }
-        },
-        "/music/new": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Создание нового трека",
+                "description": "Отмена подписки текущего пользователя на исполнителя",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Music"
+                    "Follows"
                 ],
-                "summary": "Создание трека",
+                "summary": "Отписка от исполнителя",
                 "parameters": [
                     {
                         "type": "string",
-                        "name": "name",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "name": "release",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Файл трека",
-                        "name": "file",
-                        "in": "formData",
+                        "description": "Идентификатор исполнителя",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Трек создан"
+                    "204": {
+                        "description": "Подписка отменена"
                     },
                     "400": {
                         "description": "Некорректный запрос"
@@ -245,7 +233,10 @@ const docTemplate = `{
                         "description": "Неавторизованный запрос"
                     },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Подписка не найдена"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -253,42 +244,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/music/popular": {
-            "get": {
-                "security": [
-                    {
-                        "JwtAuth": []
-                    }
-                ],
-                "description": "Получение треков отсортированных по популярности",
+        "/auth/logout": {
+            "post": {
+                "description": "Отзыв сессии, к которой относится refresh-токен: ее refresh-токены больше не обмениваются, access-токены перестают приниматься.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Music"
+                    "Auth"
                 ],
-                "summary": "Получение треков отсортированных по популярности",
-                "responses": {
-                    "200": {
-                        "description": "Список треков",
+                "summary": "Выход",
+                "parameters": [
+                    {
+                        "description": "Refresh-токен",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/view.MusicView"
-                            }
+                            "$ref": "#/definitions/entity.RefreshToken"
                         }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Токены отозваны"
                     },
                     "400": {
                         "description": "Некорректный запрос"
                     },
-                    "401": {
-                        "description": "Неавторизованный запрос"
-                    },
-                    "404": {
-                        "description": "Пользователь не найден"
+                    "422": {
+                        "description": "Ошибка при обработке данных"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -296,148 +284,164 @@ const docTemplate = `{
                 }
             }
         },
-        "/music/release": {
-            "get": {
+        "/auth/logout-all": {
+            "post": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Получение треков отсортированных по популярности",
+                "description": "Отзыв всех сессий пользователя, включая текущую.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Music"
+                    "Auth"
                 ],
-                "summary": "Получение треков отсортированных по популярности",
+                "summary": "Выход на всех устройствах",
                 "responses": {
-                    "200": {
-                        "description": "Список треков",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/view.MusicView"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Некорректный запрос"
+                    "204": {
+                        "description": "Токены отозваны"
                     },
                     "401": {
                         "description": "Неавторизованный запрос"
                     },
-                    "404": {
-                        "description": "Пользователь не найден"
-                    },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
                     }
                 }
             }
         },
-        "/music/{id}": {
-            "put": {
+        "/auth/oidc/callback": {
+            "get": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Обновление трека",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Обмен кода авторизации от провайдера на access- и refresh-токены. State должен совпадать с cookie oidc_state браузера, в котором вход начат. При первом входе создается пользователь с ролью по умолчанию, участники группы администраторов провайдера получают роль ADMIN. Если вход начат через POST /users/me/identities, учетная запись провайдера привязывается к пользователю; такой вход нужно завершать с access-токеном того же пользователя.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Music"
+                    "Auth"
                 ],
-                "summary": "Обновление трека",
+                "summary": "Завершение входа через SSO",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id трека",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Код авторизации",
+                        "name": "code",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "name": "name",
-                        "in": "formData"
+                        "description": "State входа",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "name": "release",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Файл трека",
-                        "name": "file",
-                        "in": "formData"
+                        "description": "Название устройства, под которым сессия показывается в списке сессий",
+                        "name": "X-Device-Name",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Трек обновлен"
-                    },
-                    "400": {
-                        "description": "Некорректный запрос"
+                        "description": "Access- и refresh-токены",
+                        "schema": {
+                            "$ref": "#/definitions/view.TokenView"
+                        }
                     },
                     "401": {
-                        "description": "Неавторизованный запрос"
+                        "description": "Вход отклонен провайдером, истек, начат в другом браузере или ID-токен недействителен"
+                    },
+                    "403": {
+                        "description": "Привязка начата другим пользователем"
                     },
                     "404": {
                         "description": "Пользователь не найден"
                     },
+                    "409": {
+                        "description": "Учетная запись провайдера привязана к другому пользователю"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtAuth": []
-                    }
+            }
+        },
+        "/auth/oidc/login": {
+            "get": {
+                "description": "Начало входа через OpenID Connect провайдера: перенаправление на страницу входа провайдера с кодом авторизации и PKCE. State входа сохраняется в HttpOnly cookie oidc_state. После входа провайдер возвращает пользователя на /auth/oidc/callback, который принимается только в том же браузере.",
+                "tags": [
+                    "Auth"
                 ],
-                "description": "Удаление трека",
+                "summary": "Вход через SSO",
+                "responses": {
+                    "302": {
+                        "description": "Перенаправление на страницу входа провайдера"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Обмен refresh-токена на новую пару токенов. Предъявленный refresh-токен становится недействительным. Повторное предъявление уже обмененного токена отзывает все токены, выданные после того же входа.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Music"
+                    "Auth"
                 ],
-                "summary": "Удаление трека",
+                "summary": "Обновление токенов",
                 "parameters": [
+                    {
+                        "description": "Refresh-токен",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.RefreshToken"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "id трека",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Новое название устройства сессии",
+                        "name": "X-Device-Name",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Трек удален"
+                    "200": {
+                        "description": "Новая пара токенов",
+                        "schema": {
+                            "$ref": "#/definitions/view.TokenView"
+                        }
                     },
                     "400": {
                         "description": "Некорректный запрос"
                     },
                     "401": {
-                        "description": "Неавторизованный запрос"
-                    },
-                    "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Refresh-токен недействителен, истек или отозван"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -445,45 +449,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/add-track/{id}": {
+        "/auth/signin": {
             "post": {
-                "security": [
-                    {
-                        "JwtAuth": []
-                    }
-                ],
-                "description": "Добавление трека в список понравившихся",
+                "description": "Авторизация пользователя с использованием имени пользователя и пароля. Пароль, сохраненный до перехода на Argon2id, при успешном входе заменяется хэшем.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Добавить трека в список понравившихся",
+                "summary": "Вход пользователя",
                 "parameters": [
+                    {
+                        "description": "Данные пользователя для входа",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserCreate"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор трека (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Название устройства, под которым сессия показывается в списке сессий",
+                        "name": "X-Device-Name",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Трек успешно добавлен в список понравившихся"
+                    "200": {
+                        "description": "Access- и refresh-токены",
+                        "schema": {
+                            "$ref": "#/definitions/view.TokenView"
+                        }
                     },
                     "400": {
                         "description": "Некорректный запрос"
                     },
                     "401": {
-                        "description": "Неавторизованный запрос"
+                        "description": "Неверное имя пользователя или пароль"
                     },
-                    "404": {
-                        "description": "Трек не найден"
+                    "422": {
+                        "description": "Ошибка при обработке данных"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -491,42 +501,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/get-liked-tracks": {
-            "get": {
-                "security": [
-                    {
-                        "JwtAuth": []
-                    }
-                ],
-                "description": "Получение списка понравившихся треков",
+        "/auth/signup": {
+            "post": {
+                "description": "Регистрация нового пользователя.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
+                ],
+                "summary": "Регистрация пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные пользователя для регистрации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserCreate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Название устройства, под которым сессия показывается в списке сессий",
+                        "name": "X-Device-Name",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Показать понравившиеся треки",
                 "responses": {
-                    "200": {
-                        "description": "Список понравившихся треков",
+                    "201": {
+                        "description": "Access- и refresh-токены",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/view.MusicView"
-                            }
+                            "$ref": "#/definitions/view.TokenView"
                         }
                     },
                     "400": {
                         "description": "Некорректный запрос"
                     },
-                    "401": {
-                        "description": "Неавторизованный запрос"
-                    },
-                    "404": {
-                        "description": "Трек не найден"
+                    "422": {
+                        "description": "Ошибка при обработке данных"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -534,14 +550,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/id/{id}": {
+        "/charts/weekly": {
             "get": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Получение информации о пользователе по его уникальному идентификатору.",
+                "description": "Получение сохраненного недельного чарта с изменением позиций относительно предыдущей недели, пиковой позицией и количеством недель в чарте. Без параметра week возвращается последний чарт. Треки с ненормативным контентом скрываются, если пользователь включил фильтр контента, позиции остальных треков не меняются.",
                 "consumes": [
                     "application/json"
                 ],
@@ -549,23 +565,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Charts"
                 ],
-                "summary": "Получение пользователя по ID",
+                "summary": "Получение недельного чарта",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор пользователя (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Любая дата недели чарта (2006-01-02)",
+                        "name": "week",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные пользователя",
+                        "description": "Недельный чарт",
                         "schema": {
-                            "$ref": "#/definitions/view.UserView"
+                            "$ref": "#/definitions/view.ChartView"
                         }
                     },
                     "400": {
@@ -575,7 +590,10 @@ const docTemplate = `{
                         "description": "Неавторизованный запрос"
                     },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Чарт не найден"
+                    },
+                    "422": {
+                        "description": "Некорректная дата"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -583,29 +601,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
+        "/comments/reports": {
             "get": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Получение пользователя по его уникальному идентификатору из JWT токена",
+                "description": "Получение комментариев с открытыми жалобами, начиная с комментариев с наибольшим количеством жалоб",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
+                ],
+                "summary": "Очередь модерации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество комментариев (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Получение пользователя по JWT токену",
                 "responses": {
                     "200": {
-                        "description": "Данные пользователя",
+                        "description": "Очередь модерации",
                         "schema": {
-                            "$ref": "#/definitions/view.UserView"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.ModerationItemView"
+                            }
                         }
                     },
                     "400": {
@@ -614,21 +649,26 @@ const docTemplate = `{
                     "401": {
                         "description": "Неавторизованный запрос"
                     },
-                    "404": {
-                        "description": "Пользователь не найден"
+                    "403": {
+                        "description": "Недостаточно прав"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
                     }
                 }
-            },
+            }
+        },
+        "/comments/{id}": {
             "put": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Обновление информации о пользователе по его уникальному идентификатору из JWT токена",
+                "description": "Изменение текста комментария его автором",
                 "consumes": [
                     "application/json"
                 ],
@@ -636,25 +676,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
                 ],
-                "summary": "Обновление пользователя по JWT токену",
+                "summary": "Редактирование комментария",
                 "parameters": [
                     {
-                        "description": "Данные пользователя для обновления",
+                        "type": "string",
+                        "description": "Идентификатор комментария",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый текст",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.UserCreate"
+                            "$ref": "#/definitions/entity.CommentUpdate"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Обновленные данные пользователя",
+                        "description": "Измененный комментарий",
                         "schema": {
-                            "$ref": "#/definitions/view.UserView"
+                            "$ref": "#/definitions/view.CommentView"
                         }
                     },
                     "400": {
@@ -663,11 +710,14 @@ const docTemplate = `{
                     "401": {
                         "description": "Неавторизованный запрос"
                     },
+                    "403": {
+                        "description": "Комментарий принадлежит другому пользователю"
+                    },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Комментарий не найден"
                     },
                     "422": {
-                        "description": "Ошибка при обработке данных"
+                        "description": "Некорректный комментарий"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -680,20 +730,29 @@ const docTemplate = `{
                         "JwtAuth": []
                     }
                 ],
-                "description": "Удаление пользователя по его уникальному идентификатору из JWT токена.",
+                "description": "Мягкое удаление комментария автором или администратором",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
+                ],
+                "summary": "Удаление комментария",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор комментария",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Удаление пользователя по JWT токену",
                 "responses": {
                     "204": {
-                        "description": "Пользователь успешно удален"
+                        "description": "Комментарий удален"
                     },
                     "400": {
                         "description": "Некорректный запрос"
@@ -701,8 +760,14 @@ const docTemplate = `{
                     "401": {
                         "description": "Неавторизованный запрос"
                     },
+                    "403": {
+                        "description": "Комментарий принадлежит другому пользователю"
+                    },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Комментарий не найден"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -710,28 +775,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/remove-track/{id}": {
-            "delete": {
+        "/comments/{id}/hide": {
+            "post": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Удаление трека из списка понравившихся",
+                "description": "Скрытие комментария администратором. Открытые жалобы на комментарий помечаются рассмотренными.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
                 ],
-                "summary": "Удалить трек из списка понравившихся",
+                "summary": "Скрытие комментария",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор трека (UUID)",
+                        "description": "Идентификатор комментария",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -739,7 +804,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "Трек успешно удален из списка понравившихся"
+                        "description": "Комментарий скрыт"
                     },
                     "400": {
                         "description": "Некорректный запрос"
@@ -747,8 +812,14 @@ const docTemplate = `{
                     "401": {
                         "description": "Неавторизованный запрос"
                     },
+                    "403": {
+                        "description": "Недостаточно прав"
+                    },
                     "404": {
-                        "description": "Трек не найден"
+                        "description": "Видимый комментарий не найден"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -756,14 +827,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/username/{username}": {
+        "/comments/{id}/replies": {
             "get": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Получение информации о пользователе по его уникальному идентификатору.",
+                "description": "Получение ответов на комментарий в хронологическом порядке с курсорной пагинацией",
                 "consumes": [
                     "application/json"
                 ],
@@ -771,23 +842,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
                 ],
-                "summary": "Получение пользователя по Username",
+                "summary": "Ответы на комментарий",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Username пользователя",
-                        "name": "username",
+                        "description": "Идентификатор комментария",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество ответов (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные пользователя",
+                        "description": "Страница ответов",
                         "schema": {
-                            "$ref": "#/definitions/view.UserView"
+                            "$ref": "#/definitions/view.CommentPageView"
                         }
                     },
                     "400": {
@@ -797,7 +880,10 @@ const docTemplate = `{
                         "description": "Неавторизованный запрос"
                     },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Комментарий не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный курсор"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
@@ -805,14 +891,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
-            "put": {
+        "/comments/{id}/reports": {
+            "post": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Обновление информации о пользователе по его уникальному идентификатору.",
+                "description": "Отправка жалобы на комментарий. На один комментарий пользователь может пожаловаться один раз.",
                 "consumes": [
                     "application/json"
                 ],
@@ -820,33 +906,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
                 ],
-                "summary": "Обновление пользователя по ID",
+                "summary": "Жалоба на комментарий",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор пользователя (UUID)",
+                        "description": "Идентификатор комментария",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные пользователя для обновления",
+                        "description": "Жалоба",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.UserCreate"
+                            "$ref": "#/definitions/entity.ReportCreate"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Обновленные данные пользователя",
-                        "schema": {
-                            "$ref": "#/definitions/view.UserView"
-                        }
+                    "201": {
+                        "description": "Жалоба принята"
                     },
                     "400": {
                         "description": "Некорректный запрос"
@@ -855,45 +938,53 @@ const docTemplate = `{
                         "description": "Неавторизованный запрос"
                     },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Комментарий не найден"
+                    },
+                    "409": {
+                        "description": "Жалоба уже отправлена"
                     },
                     "422": {
-                        "description": "Ошибка при обработке данных"
+                        "description": "Некорректная жалоба"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/comments/{id}/reports/dismiss": {
+            "post": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Удаление пользователя по его уникальному идентификатору.",
+                "description": "Отклонение всех открытых жалоб на комментарий администратором. Комментарий остается видимым.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Comments"
                 ],
-                "summary": "Удаление пользователя по ID",
+                "summary": "Отклонение жалоб",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор пользователя (UUID)",
+                        "description": "Идентификатор комментария",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Пользователь успешно удален"
+                    "200": {
+                        "description": "Количество отклоненных жалоб",
+                        "schema": {
+                            "$ref": "#/definitions/view.ReportsDismissedView"
+                        }
                     },
                     "400": {
                         "description": "Некорректный запрос"
@@ -901,55 +992,7364 @@ const docTemplate = `{
                     "401": {
                         "description": "Неавторизованный запрос"
                     },
+                    "403": {
+                        "description": "Недостаточно прав"
+                    },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Открытые жалобы не найдены"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.UserCreate": {
-            "type": "object",
-            "properties": {
-                "password": {
-                    "description": "Пароль",
-                    "type": "string"
-                },
-                "username": {
-                    "description": "Имя пользователя",
-                    "type": "string"
-                }
-            }
         },
-        "view.MusicView": {
-            "type": "object",
-            "properties": {
-                "duration": {
-                    "description": "продолжительность трека",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "id трека",
-                    "type": "string"
-                },
-                "name": {
-                    "description": "название трека",
+        "/comments/{id}/unhide": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Возврат скрытого комментария в выдачу администратором",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Восстановление скрытого комментария",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор комментария",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Комментарий восстановлен"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Недостаточно прав"
+                    },
+                    "404": {
+                        "description": "Скрытый комментарий не найден"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/catalog": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение всех треков. При sort=rating треки сортируются по байесовской средней оценке, чтобы треки с малым количеством оценок не обгоняли треки с большим. Треки с ненормативным контентом скрываются, если пользователь включил фильтр контента.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Music"
+                ],
+                "summary": "Получение всех треков",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Сортировка: rating",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные трека",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.MusicView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "422": {
+                        "description": "Неизвестная сортировка"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/download/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Скачивание файла трека по id трека. Неопубликованные и снятые с публикации треки доступны только администраторам. Пользователям с фильтром ненормативного контента скачивание таких треков запрещено.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Music"
+                ],
+                "summary": "Скачивание файла трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл трека",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Трек скрыт фильтром контента"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/new": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Создание нового трека. До времени публикации (по умолчанию — дата релиза) трек виден только администраторам. Загруженный трек сравнивается с каталогом по хэшу файла, нормализованному названию при близкой продолжительности и отпечатку звука; вероятные дубликаты возвращаются предупреждениями со ссылками на похожие треки и не мешают загрузке.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Music"
+                ],
+                "summary": "Создание трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "время публикации, по умолчанию — дата релиза",
+                        "name": "publishAt",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "name": "release",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл трека",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Время публикации в формате RFC3339",
+                        "name": "publish_at",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Трек создан",
+                        "schema": {
+                            "$ref": "#/definitions/view.MusicUploadView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/popular": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение треков отсортированных по популярности за окно времени. Рейтинг учитывает прослушивания и лайки с настраиваемыми весами и пересчитывается в фоне. Треки с ненормативным контентом скрываются, если пользователь включил фильтр контента.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Music"
+                ],
+                "summary": "Получение треков отсортированных по популярности",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Окно: day, week, month, all (по умолчанию all)",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество треков (по умолчанию 50, максимум 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список треков",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.PopularMusicView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Неизвестное окно"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/release": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение треков отсортированных по популярности. Треки с ненормативным контентом скрываются, если пользователь включил фильтр контента.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Music"
+                ],
+                "summary": "Получение треков отсортированных по популярности",
+                "responses": {
+                    "200": {
+                        "description": "Список треков",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.MusicView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/scheduled": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение треков, ожидающих публикации, и опубликованных треков с запланированным снятием, в порядке времени публикации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Releases"
+                ],
+                "summary": "Запланированные релизы",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество треков (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Запланированные релизы",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.ScheduledMusicView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "422": {
+                        "description": "Некорректные параметры"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/trending": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение треков, отсортированных по рейтингу с экспоненциальным затуханием: недавние прослушивания и лайки весят больше старых. Треки с ненормативным контентом скрываются, если пользователь включил фильтр контента.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Music"
+                ],
+                "summary": "Получение трендовых треков",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество треков (по умолчанию 50, максимум 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список треков",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.PopularMusicView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Обновление трека. Предыдущее состояние трека и замененный файл сохраняются в истории версий.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Music"
+                ],
+                "summary": "Обновление трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "время публикации, по умолчанию — дата релиза",
+                        "name": "publishAt",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "name": "release",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл трека",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Трек обновлен"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Перемещение трека в корзину. Трек можно восстановить, пока не истек срок хранения корзины.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Music"
+                ],
+                "summary": "Удаление трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Трек удален"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/artist": {
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Назначение треку исполнителя. Новые релизы исполнителя попадают в ленту его подписчиков.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Artists"
+                ],
+                "summary": "Исполнитель трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Исполнитель, null чтобы убрать исполнителя",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.MusicArtist"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Исполнитель назначен"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "404": {
+                        "description": "Трек или исполнитель не найден"
+                    },
+                    "422": {
+                        "description": "Некорректные данные"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/charts": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение всех недельных позиций трека, начиная с последней",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Charts"
+                ],
+                "summary": "Получение истории трека в чартах",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История позиций",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.ChartEntryView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение комментариев верхнего уровня к треку с курсорной пагинацией. Администраторы видят также скрытые и удаленные комментарии и комментарии неопубликованных треков. Комментарии пользователей в корзине не выдаются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Комментарии к треку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: newest (по умолчанию) или top",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество комментариев (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница комментариев",
+                        "schema": {
+                            "$ref": "#/definitions/view.CommentPageView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "422": {
+                        "description": "Некорректная сортировка или курсор"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Публикация комментария к треку или ответа на комментарий. Отвечать можно только на комментарии верхнего уровня. Количество комментариев пользователя за период ограничено.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Комментарий к треку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.CommentCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданный комментарий",
+                        "schema": {
+                            "$ref": "#/definitions/view.CommentView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Трек или комментарий не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный комментарий"
+                    },
+                    "429": {
+                        "description": "Превышен лимит комментариев"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Треки, похожие на трек по хэшу файла, названию и продолжительности или отпечатку звука. Дубликаты определяются при загрузке; трек из пары можно слить с другим.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Music"
+                ],
+                "summary": "Вероятные дубликаты трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Вероятные дубликаты",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.MusicDuplicateView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/explicit": {
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Изменение отметки о ненормативном контенте трека. При загрузке отметка берется из тега ID3 (ITUNESADVISORY), если он есть.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Music"
+                ],
+                "summary": "Отметка о ненормативном контенте",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Отметка о ненормативном контенте",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.MusicExplicit"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Отметка сохранена"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/lyrics": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение текста трека в JSON или синхронизированного текста в формате LRC. Без параметра lang возвращается первый добавленный текст, при отсутствии точного совпадения языка подходит текст с тем же основным языком. Тексты неопубликованных и снятых с публикации треков доступны только администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "Lyrics"
+                ],
+                "summary": "Текст трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык текста (ISO 639)",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат ответа: json (по умолчанию) или lrc",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Текст трека",
+                        "schema": {
+                            "$ref": "#/definitions/view.LyricsView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Текст не найден или у текста нет синхронизированной версии"
+                    },
+                    "422": {
+                        "description": "Некорректный язык или формат"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Создание или замена текста трека на заданном языке. Принимает JSON с полями plain и synced либо файл LRC в теле запроса с языком в параметре lang. Синхронизированный текст проверяется и приводится к каноничному виду.",
+                "consumes": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lyrics"
+                ],
+                "summary": "Загрузка текста трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык текста для загрузки файла LRC",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Текст трека",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.LyricsUpload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сохраненный текст",
+                        "schema": {
+                            "$ref": "#/definitions/view.LyricsView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный текст или LRC"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Удаление текста трека на заданном языке. Без параметра lang удаляется текст с неизвестным языком.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lyrics"
+                ],
+                "summary": "Удаление текста трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык текста (ISO 639)",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Текст удален"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "404": {
+                        "description": "Текст не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный язык"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Перенос лайков, записей плейлистов и истории прослушиваний трека на трек into. Если трек into уже есть в плейлисте или лайкнут, запись дубликата удаляется. После слияния дубликат перемещается в корзину.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Music"
+                ],
+                "summary": "Слияние дубликата",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id дубликата",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Трек, который остается в каталоге",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.MusicMerge"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат слияния",
+                        "schema": {
+                            "$ref": "#/definitions/view.MusicMergeView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "404": {
+                        "description": "Трек не найден или уже в корзине"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/rating": {
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Выставление или изменение оценки трека текущим пользователем по шкале от 1 до 5. Возвращает новую среднюю оценку трека.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ratings"
+                ],
+                "summary": "Оценка трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Оценка",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.RatingCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Оценка и средняя оценка трека",
+                        "schema": {
+                            "$ref": "#/definitions/view.RatingView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "422": {
+                        "description": "Некорректная оценка"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Снятие оценки трека текущим пользователем. Возвращает новую среднюю оценку трека.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ratings"
+                ],
+                "summary": "Снятие оценки трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Средняя оценка трека",
+                        "schema": {
+                            "$ref": "#/definitions/view.RatingView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Оценка не найдена"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение версий трека от новых к старым. Каждая версия хранит название, дату релиза и файл трека после изменения, автора и время изменения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Music revisions"
+                ],
+                "summary": "История изменений трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество версий (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Версии трека",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.MusicRevisionView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "422": {
+                        "description": "Некорректные параметры"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение полей, которые отличаются в двух версиях трека. Без параметра to сравнивается текущая версия, без параметра from — версия перед to. Замена файла с тем же именем отображается как изменение поля file.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Music revisions"
+                ],
+                "summary": "Сравнение версий трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Исходная версия",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Целевая версия",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Различия версий",
+                        "schema": {
+                            "$ref": "#/definitions/view.MusicRevisionDiffView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "404": {
+                        "description": "Версия не найдена"
+                    },
+                    "422": {
+                        "description": "Некорректный номер версии"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/revisions/{version}": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение версии трека по номеру",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Music revisions"
+                ],
+                "summary": "Версия трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Версия трека",
+                        "schema": {
+                            "$ref": "#/definitions/view.MusicRevisionView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "404": {
+                        "description": "Версия не найдена"
+                    },
+                    "422": {
+                        "description": "Некорректный номер версии"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/revisions/{version}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Возвращает название, дату релиза и файл трека к состоянию версии. Откат сохраняется как новая версия, история не переписывается.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Music revisions"
+                ],
+                "summary": "Откат трека к версии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Новая версия трека",
+                        "schema": {
+                            "$ref": "#/definitions/view.MusicRevisionView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "404": {
+                        "description": "Версия не найдена"
+                    },
+                    "409": {
+                        "description": "Версия уже является текущей"
+                    },
+                    "422": {
+                        "description": "Некорректный номер версии"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/schedule": {
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Изменение времени публикации и снятия трека с публикации. Перенос публикации в будущее скрывает трек от пользователей до нового времени публикации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Releases"
+                ],
+                "summary": "Расписание публикации трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Расписание публикации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.MusicSchedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Трек с новым расписанием",
+                        "schema": {
+                            "$ref": "#/definitions/view.ScheduledMusicView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "422": {
+                        "description": "Некорректное расписание"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/shares": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Создание ссылки, по которой трек можно послушать без аккаунта. Токен возвращается только в этом ответе.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Ссылка на трек",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Срок действия и лимит прослушиваний",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.ShareLinkCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданная ссылка",
+                        "schema": {
+                            "$ref": "#/definitions/view.ShareLinkCreatedView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "422": {
+                        "description": "Некорректные параметры ссылки"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/music/{id}/similar": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение треков, которые чаще всего лайкают вместе с заданным. Треки, уже лайкнутые текущим пользователем, исключаются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recommendations"
+                ],
+                "summary": "Похожие треки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество треков (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список похожих треков",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.SimilarMusicView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение уведомлений текущего пользователя с курсорной пагинацией, начиная с новых. Уведомления типов, отключенных для входящих, не показываются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Входящие уведомления",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только непрочитанные",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество уведомлений (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница уведомлений",
+                        "schema": {
+                            "$ref": "#/definitions/view.NotificationPageView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Некорректные параметры или курсор"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/notifications/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение адреса для email-канала и каналов доставки по всем типам уведомлений",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Настройки уведомлений",
+                "responses": {
+                    "200": {
+                        "description": "Настройки уведомлений",
+                        "schema": {
+                            "$ref": "#/definitions/view.NotificationSettingsView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Сохранение адреса для email-канала и каналов доставки переданных типов уведомлений. Настройки непереданных типов не меняются, пустой адрес отключает email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Изменение настроек уведомлений",
+                "parameters": [
+                    {
+                        "description": "Настройки уведомлений",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.NotificationSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сохраненные настройки",
+                        "schema": {
+                            "$ref": "#/definitions/view.NotificationSettingsView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Некорректный адрес или тип уведомления"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/notifications/read-all": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Отметка всех непрочитанных уведомлений текущего пользователя прочитанными",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Прочтение всех уведомлений",
+                "responses": {
+                    "200": {
+                        "description": "Количество отмеченных уведомлений",
+                        "schema": {
+                            "$ref": "#/definitions/view.NotificationsReadView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Отметка уведомления текущего пользователя прочитанным",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Прочтение уведомления",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор уведомления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Уведомление прочитано"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Уведомление не найдено"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/playlists": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Создание плейлиста текущего пользователя. Изменения публичного плейлиста попадают в ленту подписчиков.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Создание плейлиста",
+                "parameters": [
+                    {
+                        "description": "Плейлист",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlaylistCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданный плейлист",
+                        "schema": {
+                            "$ref": "#/definitions/view.PlaylistView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Некорректный плейлист"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/playlists/import": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Создание плейлиста текущего пользователя из файла M3U/M3U8 (с #EXTINF), XSPF или CSV. Записи сопоставляются с каталогом сначала по id трека из ссылки или идентификатора, затем по названию и исполнителю без учета регистра, знаков препинания и уточнений в скобках, с допуском по продолжительности. В плейлист попадают сопоставленные треки в порядке файла, повторы пропускаются. Неоднозначные записи возвращаются с подходящими треками, несопоставленные — с треками того же названия, чтобы пользователь добавил их вручную.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Импорт плейлиста",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Файл плейлиста",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "m3u",
+                            "m3u8",
+                            "xspf",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Формат файла, по умолчанию определяется по расширению",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Название плейлиста, по умолчанию из файла или по имени файла",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Плейлист виден другим пользователям",
+                        "name": "is_public",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Плейлист создан",
+                        "schema": {
+                            "$ref": "#/definitions/view.PlaylistImportReportView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "413": {
+                        "description": "Файл слишком большой"
+                    },
+                    "422": {
+                        "description": "Некорректный файл или параметры"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/playlists/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение публичного плейлиста или плейлиста текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Плейлист",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Плейлист",
+                        "schema": {
+                            "$ref": "#/definitions/view.PlaylistView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Изменение названия и видимости плейлиста его владельцем",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Изменение плейлиста",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые название и видимость",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlaylistCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Измененный плейлист",
+                        "schema": {
+                            "$ref": "#/definitions/view.PlaylistView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Плейлист принадлежит другому пользователю"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный плейлист"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Удаление плейлиста его владельцем",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Удаление плейлиста",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Плейлист удален"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Плейлист принадлежит другому пользователю"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/playlists/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение комментариев верхнего уровня к публичному или своему плейлисту с курсорной пагинацией. Администраторы видят также скрытые и удаленные комментарии.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Комментарии к плейлисту",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: newest (по умолчанию) или top",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество комментариев (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница комментариев",
+                        "schema": {
+                            "$ref": "#/definitions/view.CommentPageView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден"
+                    },
+                    "422": {
+                        "description": "Некорректная сортировка или курсор"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Публикация комментария к публичному или своему плейлисту или ответа на комментарий. Владелец плейлиста получает уведомление playlist_comment. Количество комментариев пользователя за период ограничено.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Комментарий к плейлисту",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.CommentCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданный комментарий",
+                        "schema": {
+                            "$ref": "#/definitions/view.CommentView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Плейлист или комментарий не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный комментарий"
+                    },
+                    "429": {
+                        "description": "Превышен лимит комментариев"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/playlists/{id}/shares": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Создание ссылки, по которой плейлист можно послушать без аккаунта. Делиться плейлистом может только владелец. Токен возвращается только в этом ответе.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Ссылка на плейлист",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Срок действия и лимит прослушиваний",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.ShareLinkCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданная ссылка",
+                        "schema": {
+                            "$ref": "#/definitions/view.ShareLinkCreatedView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Плейлист принадлежит другому пользователю"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден"
+                    },
+                    "422": {
+                        "description": "Некорректные параметры ссылки"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/playlists/{id}/tracks": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение треков плейлиста в порядке добавления. Недоступные треки и треки, скрытые фильтром контента, не показываются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Треки плейлиста",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Треки плейлиста",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.MusicView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Добавление трека в конец плейлиста его владельцем. Повторное добавление ничего не меняет.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Добавление трека в плейлист",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Трек",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlaylistTrack"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Трек добавлен"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Плейлист принадлежит другому пользователю"
+                    },
+                    "404": {
+                        "description": "Плейлист или трек не найден"
+                    },
+                    "422": {
+                        "description": "Некорректные данные"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/playlists/{id}/tracks/{music_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Удаление трека из плейлиста его владельцем",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Удаление трека из плейлиста",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "music_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Трек удален из плейлиста"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Плейлист принадлежит другому пользователю"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден или трека нет в плейлисте"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/plays": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Сохранение события начала, прогресса или завершения прослушивания трека. Повторная отправка события с тем же client_event_id игнорируется.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Plays"
+                ],
+                "summary": "Отправка события прослушивания",
+                "parameters": [
+                    {
+                        "description": "Событие прослушивания",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlayEventCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Событие уже было сохранено ранее"
+                    },
+                    "201": {
+                        "description": "Событие сохранено"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/plays/batch": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Сохранение пакета событий, накопленных клиентом в офлайне. Время событий берется из played_at, дубликаты по client_event_id отбрасываются. События по неизвестным трекам пропускаются, их id возвращаются в unknown_music_ids.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plays"
+                ],
+                "summary": "Пакетная отправка событий прослушивания",
+                "parameters": [
+                    {
+                        "description": "Пакет событий прослушивания",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlayEventBatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат обработки пакета",
+                        "schema": {
+                            "$ref": "#/definitions/view.PlayBatchResultView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/shared/{token}": {
+            "get": {
+                "description": "Трек или плейлист, открытый по ссылке. Не требует авторизации и не расходует лимит прослушиваний.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Содержимое ссылки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен ссылки",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Трек или плейлист",
+                        "schema": {
+                            "$ref": "#/definitions/view.SharedContentView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "404": {
+                        "description": "Ссылка или трек не найдены"
+                    },
+                    "410": {
+                        "description": "Ссылка отозвана, истекла или исчерпала лимит прослушиваний"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/shared/{token}/stream": {
+            "get": {
+                "description": "Файл трека, открытого по ссылке. Не требует авторизации. Каждое скачивание расходует одно прослушивание из лимита ссылки.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Прослушивание трека по ссылке",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен ссылки",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл трека",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "404": {
+                        "description": "Ссылка или трек не найдены"
+                    },
+                    "410": {
+                        "description": "Ссылка отозвана, истекла или исчерпала лимит прослушиваний"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/shared/{token}/stream/{id}": {
+            "get": {
+                "description": "Файл трека из плейлиста, открытого по ссылке. Не требует авторизации. Каждое скачивание расходует одно прослушивание из лимита ссылки.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Прослушивание трека плейлиста по ссылке",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен ссылки",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека из плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл трека",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "404": {
+                        "description": "Ссылка не найдена или трека нет в плейлисте"
+                    },
+                    "410": {
+                        "description": "Ссылка отозвана, истекла или исчерпала лимит прослушиваний"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/shares/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Отзыв ссылки ее владельцем. Отозванная ссылка перестает открываться сразу.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Отзыв ссылки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ссылки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Ссылка отозвана"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Ссылка не найдена"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/smart-playlists": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Создание плейлиста, состав которого вычисляется по правилам при каждом чтении. Правила — дерево групп and/or и условий field/operator/value. Поля: name, artist (eq, neq, contains, not_contains, starts_with); duration в секундах, rating_avg, rating_count, my_rating, play_count (eq, neq, lt, lte, gt, gte); release_date, published_at, last_played (eq, neq, lt, lte, gt, gte с датой 2006-01-02, in_last_days с количеством дней, in_current_year без значения); explicit, liked (eq, neq с true/false). Поля liked, my_rating, play_count и last_played вычисляются для читающего пользователя. Сортировка — по любому полю кроме explicit и liked или random.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Smart playlists"
+                ],
+                "summary": "Создание умного плейлиста",
+                "parameters": [
+                    {
+                        "description": "Умный плейлист",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SmartPlaylistCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданный плейлист",
+                        "schema": {
+                            "$ref": "#/definitions/view.SmartPlaylistView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Некорректный плейлист или правила"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/smart-playlists/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение правил публичного умного плейлиста или умного плейлиста текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Smart playlists"
+                ],
+                "summary": "Умный плейлист",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Плейлист",
+                        "schema": {
+                            "$ref": "#/definitions/view.SmartPlaylistView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Замена названия, видимости, правил и сортировки умного плейлиста его владельцем",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Smart playlists"
+                ],
+                "summary": "Изменение умного плейлиста",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый плейлист",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SmartPlaylistCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Измененный плейлист",
+                        "schema": {
+                            "$ref": "#/definitions/view.SmartPlaylistView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Плейлист принадлежит другому пользователю"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный плейлист или правила"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Удаление умного плейлиста его владельцем",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Smart playlists"
+                ],
+                "summary": "Удаление умного плейлиста",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Плейлист удален"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Плейлист принадлежит другому пользователю"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/smart-playlists/{id}/tracks": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Вычисление умного плейлиста на текущий момент для текущего пользователя с сортировкой и ограничением количества из правил. Недоступные треки и треки, скрытые фильтром контента, не попадают в плейлист.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Smart playlists"
+                ],
+                "summary": "Треки умного плейлиста",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Треки плейлиста",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.MusicView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/sync": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Изменения треков каталога, лайков и плейлистов текущего пользователя после токена since. Без токена возвращается полная синхронизация (full=true): клиент заменяет локальную копию, лайки и плейлисты приходят на первой странице, каталог — страницами. Удаленные и ставшие недоступными сущности возвращаются в списках deleted и removed. Пока has_more=true, следующую страницу нужно запросить сразу с новым токеном. Токен действует ограниченное время; на просроченный токен возвращается 410, после чего нужна полная синхронизация.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sync"
+                ],
+                "summary": "Синхронизация библиотеки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен из предыдущего ответа",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Изменения",
+                        "schema": {
+                            "$ref": "#/definitions/view.SyncView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "410": {
+                        "description": "Срок действия токена истек, нужна полная синхронизация"
+                    },
+                    "422": {
+                        "description": "Некорректный токен"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/trash/music": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение треков из корзины, начиная с удаленных последними. Треки удаляются окончательно по истечении срока хранения корзины.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Удаленные треки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество треков (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удаленные треки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.TrashedMusicView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "422": {
+                        "description": "Некорректные параметры"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/trash/music/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Возвращение трека из корзины вместе с лайками, оценками, комментариями и историей изменений",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Восстановление трека",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор трека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Трек восстановлен"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "404": {
+                        "description": "Трека нет в корзине"
+                    },
+                    "422": {
+                        "description": "Некорректный идентификатор"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/trash/users": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение пользователей из корзины, начиная с удаленных последними. Пользователи удаляются окончательно по истечении срока хранения корзины.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Удаленные пользователи",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество пользователей (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удаленные пользователи",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.TrashedUserView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "422": {
+                        "description": "Некорректные параметры"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/trash/users/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Возвращение пользователя из корзины вместе с лайками, оценками и историей прослушиваний. Пользователя нельзя восстановить, если его имя занял другой пользователь.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Восстановление пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Пользователь восстановлен"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "404": {
+                        "description": "Пользователя нет в корзине"
+                    },
+                    "409": {
+                        "description": "Имя пользователя занято"
+                    },
+                    "422": {
+                        "description": "Некорректный идентификатор"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/add-track/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Добавление трека в список понравившихся",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Добавить трека в список понравившихся",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор трека (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Трек успешно добавлен в список понравившихся"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/get-liked-tracks": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение списка понравившихся треков. Если пользователь скрыл ненормативный контент, такие треки не возвращаются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Показать понравившиеся треки",
+                "responses": {
+                    "200": {
+                        "description": "Список понравившихся треков",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.MusicView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/id/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение информации о пользователе по его уникальному идентификатору.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получение пользователя по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор пользователя (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение пользователя по его уникальному идентификатору из JWT токена",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получение пользователя по JWT токену",
+                "responses": {
+                    "200": {
+                        "description": "Данные пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Обновление информации о пользователе по его уникальному идентификатору из JWT токена",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Обновление пользователя по JWT токену",
+                "parameters": [
+                    {
+                        "description": "Данные пользователя для обновления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленные данные пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Перемещение пользователя из JWT токена в корзину. Лайки и оценки сохраняются до окончательного удаления по истечении срока хранения корзины.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Удаление пользователя по JWT токену",
+                "responses": {
+                    "204": {
+                        "description": "Пользователь успешно удален"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/content-filter": {
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Включение или отключение фильтра ненормативного контента для текущего пользователя. С включенным фильтром такие треки не попадают в каталог, подборки, чарты и библиотеку, а их скачивание запрещено.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Фильтр контента",
+                "parameters": [
+                    {
+                        "description": "Настройки фильтра",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.ContentFilter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Настройки сохранены"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/export": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Выгрузка лайков, плейлистов, оценок и истории прослушиваний текущего пользователя. Форматы json и csv содержат всю библиотеку, m3u8 и xspf — плейлист playlist_id или ZIP-архив со всеми плейлистами. Треки указываются постоянными ссылками на файлы. Небольшая выгрузка отдается сразу, большая или запрошенная с async=true выполняется фоновой задачей: в ответ возвращается задача, файл которой скачивается по download_url после готовности.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/zip"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Выгрузка библиотеки",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "m3u8",
+                            "xspf"
+                        ],
+                        "type": "string",
+                        "description": "Формат выгрузки",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор плейлиста для m3u8 и xspf",
+                        "name": "playlist_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Выгрузить фоновой задачей независимо от размера",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл выгрузки",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Задача выгрузки",
+                        "schema": {
+                            "$ref": "#/definitions/view.ExportJobView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Плейлист не найден"
+                    },
+                    "422": {
+                        "description": "Неизвестный формат или некорректные параметры"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/exports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Состояние фоновой выгрузки текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Задача выгрузки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Задача выгрузки",
+                        "schema": {
+                            "$ref": "#/definitions/view.ExportJobView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Задача не найдена"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/exports/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Файл готовой фоновой выгрузки. Файл хранится ограниченное время, после чего выгрузку нужно запросить заново.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Скачивание выгрузки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл выгрузки",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Задача не найдена"
+                    },
+                    "409": {
+                        "description": "Выгрузка еще выполняется или завершилась ошибкой"
+                    },
+                    "410": {
+                        "description": "Срок хранения файла истек"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/feed": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение ленты текущего пользователя с курсорной пагинацией: новые релизы исполнителей, лайки и изменения публичных плейлистов пользователей из подписок, начиная с последних событий",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Лента активности",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество событий (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница ленты",
+                        "schema": {
+                            "$ref": "#/definitions/view.FeedPageView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Некорректный курсор"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/following": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение исполнителей и пользователей, на которых подписан текущий пользователь",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Подписки текущего пользователя",
+                "responses": {
+                    "200": {
+                        "description": "Подписки",
+                        "schema": {
+                            "$ref": "#/definitions/view.FollowingView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/history": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение истории прослушиваний текущего пользователя, от новых к старым",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plays"
+                ],
+                "summary": "История прослушиваний",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода (RFC3339 или YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода (RFC3339 или YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 50, максимум 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История прослушиваний",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.PlayEventView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Удаление истории прослушиваний текущего пользователя за период. Без параметров удаляется вся история.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plays"
+                ],
+                "summary": "Очистка истории прослушиваний",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода (RFC3339 или YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода (RFC3339 или YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Количество удаленных записей",
+                        "schema": {
+                            "$ref": "#/definitions/view.DeletedView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/history/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Удаление одного события из истории прослушиваний текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Plays"
+                ],
+                "summary": "Удаление записи из истории прослушиваний",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id события",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Запись удалена"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Запись не найдена"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/identities": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение учетных записей OpenID Connect провайдера, привязанных к текущему пользователю",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Учетные записи SSO текущего пользователя",
+                "responses": {
+                    "200": {
+                        "description": "Учетные записи провайдера",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.IdentityView"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Начало входа через OpenID Connect провайдера, после которого учетная запись провайдера привязывается к текущему пользователю. State входа сохраняется в HttpOnly cookie oidc_state. Пользователя нужно перенаправить по полученному адресу в том же браузере, а /auth/oidc/callback вызвать с access-токеном текущего пользователя.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Привязка учетной записи SSO",
+                "responses": {
+                    "200": {
+                        "description": "Адрес страницы входа провайдера",
+                        "schema": {
+                            "$ref": "#/definitions/view.OIDCAuthorizationView"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/library": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение лайкнутых и оцененных текущим пользователем треков вместе с его оценками",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ratings"
+                ],
+                "summary": "Библиотека пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Сортировка: recent (по умолчанию), rating, name",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список треков",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.LibraryMusicView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Неизвестная сортировка"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/playlists": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение плейлистов текущего пользователя, начиная с последних измененных",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Плейлисты текущего пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество плейлистов (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Плейлисты",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.PlaylistView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Некорректные параметры"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/privacy": {
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Выбор событий, которые текущий пользователь показывает своим подписчикам: лайки и изменения публичных плейлистов. По умолчанию подписчикам не показывается ничего",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Настройки приватности ленты",
+                "parameters": [
+                    {
+                        "description": "Настройки приватности",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.FeedPrivacy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Настройки сохранены"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "422": {
+                        "description": "Некорректные данные"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/recommendations": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение треков, похожих на лайкнутые текущим пользователем. Для каждого трека указывается лайкнутый трек, на основе которого сделана рекомендация. Уже лайкнутые треки исключаются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recommendations"
+                ],
+                "summary": "Персональные рекомендации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество треков (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список рекомендаций",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.RecommendationView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение действующих сессий текущего пользователя с устройством, IP и временем последней активности. Сессия, в которой выполнен запрос, помечена полем current.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Сессии текущего пользователя",
+                "responses": {
+                    "200": {
+                        "description": "Сессии",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.SessionView"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Отзыв сессии текущего пользователя: ее refresh-токены больше не обмениваются, access-токены перестают приниматься.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Отзыв сессии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID сессии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Сессия отозвана"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Сессия не найдена"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/shares": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение созданных пользователем ссылок, начиная с последних, вместе с отозванными и истекшими",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Ссылки текущего пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество ссылок (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ссылки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.ShareLinkView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Некорректные параметры"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/smart-playlists": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение умных плейлистов текущего пользователя, начиная с последних измененных",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Smart playlists"
+                ],
+                "summary": "Умные плейлисты текущего пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество плейлистов (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Плейлисты",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/view.SmartPlaylistView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Некорректные параметры"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/stats": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Статистика текущего пользователя за период: топ треков и исполнителей, время прослушивания, самая длинная серия дней и распределение по часам. Дни и часы считаются по UTC.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Статистика прослушиваний",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода в формате RFC3339 или 2006-01-02 (по умолчанию 30 дней до конца периода)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода, не включая, в формате RFC3339 или 2006-01-02 (по умолчанию текущее время)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество треков и исполнителей в топах",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статистика",
+                        "schema": {
+                            "$ref": "#/definitions/view.ListeningStatsView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "422": {
+                        "description": "Некорректный период: from не раньше to или период длиннее 366 дней"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/me/year-in-review/{year}": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Итоги календарного года текущего пользователя. Итоги рассчитываются фоновой задачей после окончания года.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Итоги года",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Год",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Итоги года",
+                        "schema": {
+                            "$ref": "#/definitions/view.YearReviewView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Итоги года еще не рассчитаны или прослушиваний не было"
+                    },
+                    "422": {
+                        "description": "Некорректный год"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/remove-track/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Удаление трека из списка понравившихся",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Удалить трек из списка понравившихся",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор трека (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Трек успешно удален из списка понравившихся"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Трек не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/username/{username}": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение информации о пользователе по его уникальному идентификатору.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получение пользователя по Username",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username пользователя",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Обновление информации о пользователе по его уникальному идентификатору.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Обновление пользователя по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор пользователя (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные пользователя для обновления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленные данные пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Перемещение пользователя в корзину по его уникальному идентификатору. Лайки и оценки сохраняются до окончательного удаления по истечении срока хранения корзины.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Удаление пользователя по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор пользователя (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Пользователь успешно удален"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/{id}/follow": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Подписка текущего пользователя на другого пользователя. Лайки и публичные плейлисты пользователя попадают в ленту, если он ими делится.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Подписка на пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Подписка оформлена"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Пользователь не найден"
+                    },
+                    "422": {
+                        "description": "Некорректный id или подписка на себя"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Отмена подписки текущего пользователя на другого пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Отписка от пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Подписка отменена"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "404": {
+                        "description": "Подписка не найдена"
+                    },
+                    "422": {
+                        "description": "Некорректный id"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        },
+        "/users/{id}/sessions": {
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Отзыв всех сессий пользователя администратором",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Отзыв всех сессий пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Сессии отозваны"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос"
+                    },
+                    "403": {
+                        "description": "Нет доступа"
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных"
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.ArtistCreate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "имя исполнителя",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.CommentCreate": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "текст комментария",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "id комментария, на который дается ответ",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.CommentUpdate": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "новый текст комментария",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ContentFilter": {
+            "type": "object",
+            "properties": {
+                "hide_explicit": {
+                    "description": "скрывать треки с ненормативным контентом",
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.FeedPrivacy": {
+            "type": "object",
+            "properties": {
+                "share_likes": {
+                    "description": "показывать лайки",
+                    "type": "boolean"
+                },
+                "share_playlists": {
+                    "description": "показывать изменения публичных плейлистов",
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.LyricsUpload": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "description": "язык текста (ISO 639), по умолчанию und",
+                    "type": "string"
+                },
+                "plain": {
+                    "description": "текст без разметки времени",
+                    "type": "string"
+                },
+                "synced": {
+                    "description": "синхронизированный текст в формате LRC",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.MusicArtist": {
+            "type": "object",
+            "properties": {
+                "artist_id": {
+                    "description": "id исполнителя, null чтобы убрать исполнителя",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.MusicExplicit": {
+            "type": "object",
+            "properties": {
+                "explicit": {
+                    "description": "трек содержит ненормативный контент",
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.MusicMerge": {
+            "type": "object",
+            "properties": {
+                "into": {
+                    "description": "id трека, который остается в каталоге",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.MusicSchedule": {
+            "type": "object",
+            "properties": {
+                "publish_at": {
+                    "description": "время публикации",
+                    "type": "string"
+                },
+                "takedown_at": {
+                    "description": "время снятия с публикации, необязательно",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.NotificationPreference": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "отправлять на email",
+                    "type": "boolean"
+                },
+                "in_app": {
+                    "description": "показывать во входящих",
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "тип уведомления",
+                    "type": "string"
+                },
+                "webhook": {
+                    "description": "отправлять в webhook",
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.NotificationSettings": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "адрес для email-канала, null отключает email",
+                    "type": "string"
+                },
+                "preferences": {
+                    "description": "каналы по типам уведомлений",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.NotificationPreference"
+                    }
+                }
+            }
+        },
+        "entity.PlayEventBatch": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PlayEventCreate"
+                    }
+                }
+            }
+        },
+        "entity.PlayEventCreate": {
+            "type": "object",
+            "properties": {
+                "client_event_id": {
+                    "description": "ID события на стороне клиента",
+                    "type": "string"
+                },
+                "event_type": {
+                    "description": "Тип события (start, progress, complete)",
+                    "type": "string"
+                },
+                "music_id": {
+                    "description": "ID трека",
+                    "type": "string"
+                },
+                "played_at": {
+                    "description": "Время события по часам клиента",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Позиция воспроизведения в секундах",
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.PlaylistCreate": {
+            "type": "object",
+            "properties": {
+                "is_public": {
+                    "description": "плейлист виден другим пользователям",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "название плейлиста",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PlaylistTrack": {
+            "type": "object",
+            "properties": {
+                "music_id": {
+                    "description": "id трека",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.RatingCreate": {
+            "type": "object",
+            "properties": {
+                "rating": {
+                    "description": "оценка от 1 до 5",
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.RefreshToken": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "description": "refresh-токен",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ReportCreate": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "причина жалобы",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ShareLinkCreate": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "время истечения в формате RFC3339, null — бессрочно",
+                    "type": "string"
+                },
+                "max_plays": {
+                    "description": "лимит прослушиваний, null — без лимита",
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.SmartPlaylistCreate": {
+            "type": "object",
+            "properties": {
+                "is_public": {
+                    "description": "плейлист виден другим пользователям",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "максимальное количество треков (по умолчанию 100, максимум 500)",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "название плейлиста",
+                    "type": "string"
+                },
+                "order": {
+                    "description": "asc или desc, по умолчанию desc",
+                    "type": "string"
+                },
+                "rules": {
+                    "description": "дерево правил",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.SmartRule"
+                        }
+                    ]
+                },
+                "sort": {
+                    "description": "поле сортировки, по умолчанию published_at",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.SmartRule": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "поле трека для условия",
+                    "type": "string"
+                },
+                "group": {
+                    "description": "and или or для группы",
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "оператор условия",
+                    "type": "string"
+                },
+                "rules": {
+                    "description": "правила группы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.SmartRule"
+                    }
+                },
+                "value": {
+                    "description": "значение условия"
+                }
+            }
+        },
+        "entity.UserCreate": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "description": "Пароль",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "Имя пользователя",
+                    "type": "string"
+                }
+            }
+        },
+        "view.ArtistView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "время создания в формате RFC3339",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id исполнителя",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "имя исполнителя",
+                    "type": "string"
+                }
+            }
+        },
+        "view.ChartEntryView": {
+            "type": "object",
+            "properties": {
+                "likes": {
+                    "description": "количество лайков за неделю",
+                    "type": "integer"
+                },
+                "movement": {
+                    "description": "изменение позиции (new, up, down, same, re-entry)",
+                    "type": "string"
+                },
+                "music": {
+                    "description": "трек",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.MusicView"
+                        }
+                    ]
+                },
+                "peak_position": {
+                    "description": "лучшая позиция",
+                    "type": "integer"
+                },
+                "plays": {
+                    "description": "количество прослушиваний за неделю",
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "позиция в чарте",
+                    "type": "integer"
+                },
+                "previous_position": {
+                    "description": "позиция на предыдущей неделе",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "рейтинг за неделю",
+                    "type": "number"
+                },
+                "week": {
+                    "description": "неделя чарта (дата понедельника)",
+                    "type": "string"
+                },
+                "weeks_on_chart": {
+                    "description": "количество недель в чарте",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.ChartView": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "description": "позиции чарта",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.ChartEntryView"
+                    }
+                },
+                "week": {
+                    "description": "неделя чарта (дата понедельника)",
+                    "type": "string"
+                }
+            }
+        },
+        "view.CommentAuthorView": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "id пользователя",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "имя пользователя",
+                    "type": "string"
+                }
+            }
+        },
+        "view.CommentPageView": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "description": "комментарии страницы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.CommentView"
+                    }
+                },
+                "next_cursor": {
+                    "description": "курсор следующей страницы, пустой если страница последняя",
+                    "type": "string"
+                }
+            }
+        },
+        "view.CommentView": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "автор комментария",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.CommentAuthorView"
+                        }
+                    ]
+                },
+                "body": {
+                    "description": "текст комментария",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "время создания в формате RFC3339",
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "время последнего редактирования в формате RFC3339",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id комментария",
+                    "type": "string"
+                },
+                "music_id": {
+                    "description": "id трека, null для комментария к плейлисту",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "id комментария, на который дан ответ",
+                    "type": "string"
+                },
+                "playlist_id": {
+                    "description": "id плейлиста, null для комментария к треку",
+                    "type": "string"
+                },
+                "reply_count": {
+                    "description": "количество ответов",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "статус (visible, hidden, deleted)",
+                    "type": "string"
+                }
+            }
+        },
+        "view.DeletedView": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "количество удаленных записей",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.ExportJobView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "время создания в формате RFC3339",
+                    "type": "string"
+                },
+                "download_url": {
+                    "description": "ссылка на файл, пока выгрузка не готова — null",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "время удаления файла в формате RFC3339",
+                    "type": "string"
+                },
+                "finished_at": {
+                    "description": "время завершения в формате RFC3339",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "формат выгрузки",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id задачи",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "количество записей на момент создания задачи",
+                    "type": "integer"
+                },
+                "playlist_id": {
+                    "description": "id плейлиста для M3U8 и XSPF",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "размер файла в байтах",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending, running, done или failed",
+                    "type": "string"
+                }
+            }
+        },
+        "view.FeedItemView": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "description": "исполнитель для релиза, пользователь для лайка и плейлиста",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.FeedRefView"
+                        }
+                    ]
+                },
+                "kind": {
+                    "description": "тип события (release, like, playlist)",
+                    "type": "string"
+                },
+                "music": {
+                    "description": "трек для релиза и лайка",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.FeedRefView"
+                        }
+                    ]
+                },
+                "occurred_at": {
+                    "description": "время события в формате RFC3339",
+                    "type": "string"
+                },
+                "playlist": {
+                    "description": "плейлист",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.FeedRefView"
+                        }
+                    ]
+                }
+            }
+        },
+        "view.FeedPageView": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "события страницы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.FeedItemView"
+                    }
+                },
+                "next_cursor": {
+                    "description": "курсор следующей страницы, пустой если страница последняя",
+                    "type": "string"
+                }
+            }
+        },
+        "view.FeedRefView": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "id",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "название или имя",
+                    "type": "string"
+                }
+            }
+        },
+        "view.FollowedUserView": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "id пользователя",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "имя пользователя",
+                    "type": "string"
+                }
+            }
+        },
+        "view.FollowingView": {
+            "type": "object",
+            "properties": {
+                "artists": {
+                    "description": "исполнители",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.ArtistView"
+                    }
+                },
+                "users": {
+                    "description": "пользователи",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.FollowedUserView"
+                    }
+                }
+            }
+        },
+        "view.IdentityView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "время привязки в формате RFC3339",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id привязки",
+                    "type": "string"
+                },
+                "issuer": {
+                    "description": "издатель учетной записи",
+                    "type": "string"
+                },
+                "last_login_at": {
+                    "description": "время последнего входа через провайдера в формате RFC3339",
+                    "type": "string"
+                },
+                "subject": {
+                    "description": "id учетной записи у издателя",
+                    "type": "string"
+                }
+            }
+        },
+        "view.ImportCandidateView": {
+            "type": "object",
+            "properties": {
+                "artist": {
+                    "description": "имя исполнителя",
+                    "type": "string"
+                },
+                "duration_sec": {
+                    "description": "продолжительность в секундах",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "название трека",
+                    "type": "string"
+                }
+            }
+        },
+        "view.ImportEntryReportView": {
+            "type": "object",
+            "properties": {
+                "artist": {
+                    "description": "имя исполнителя из файла",
+                    "type": "string"
+                },
+                "candidates": {
+                    "description": "подходящие треки каталога",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.ImportCandidateView"
+                    }
+                },
+                "duration_sec": {
+                    "description": "продолжительность из файла, 0 — неизвестна",
+                    "type": "integer"
+                },
+                "line": {
+                    "description": "номер записи в файле",
+                    "type": "integer"
+                },
+                "location": {
+                    "description": "ссылка или путь к файлу из плейлиста",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "ambiguous или unmatched",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "название трека из файла",
+                    "type": "string"
+                }
+            }
+        },
+        "view.JWKSView": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "description": "ключи",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.JWKView"
+                    }
+                }
+            }
+        },
+        "view.JWKView": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "description": "алгоритм подписи: RS256 или EdDSA",
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "кривая OKP: Ed25519",
+                    "type": "string"
+                },
+                "e": {
+                    "description": "открытая экспонента RSA в base64url",
+                    "type": "string"
+                },
+                "kid": {
+                    "description": "id ключа, совпадает с заголовком kid токена",
+                    "type": "string"
+                },
+                "kty": {
+                    "description": "тип ключа: RSA или OKP",
+                    "type": "string"
+                },
+                "n": {
+                    "description": "модуль RSA в base64url",
+                    "type": "string"
+                },
+                "use": {
+                    "description": "назначение ключа: sig",
+                    "type": "string"
+                },
+                "x": {
+                    "description": "открытый ключ Ed25519 в base64url",
+                    "type": "string"
+                }
+            }
+        },
+        "view.LibraryMusicView": {
+            "type": "object",
+            "properties": {
+                "artist_id": {
+                    "description": "id исполнителя, null если исполнитель не указан",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "продолжительность трека",
+                    "type": "string"
+                },
+                "explicit": {
+                    "description": "трек содержит ненормативный контент",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "liked": {
+                    "description": "трек лайкнут пользователем",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "название трека",
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "средняя оценка трека",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "description": "количество оценок трека",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "размер файла трека (в удобном для чтения виде)",
+                    "type": "string"
+                },
+                "user_rating": {
+                    "description": "оценка пользователя",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.ListeningStatsView": {
+            "type": "object",
+            "properties": {
+                "active_days": {
+                    "description": "количество дней с прослушиваниями",
+                    "type": "integer"
+                },
+                "from": {
+                    "description": "начало периода в формате RFC3339",
+                    "type": "string"
+                },
+                "heatmap": {
+                    "description": "прослушивания 7×24: дни недели с понедельника по часам UTC",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "longest_streak": {
+                    "description": "самая длинная серия дней подряд, null если прослушиваний не было",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.ListeningStreakView"
+                        }
+                    ]
+                },
+                "minutes": {
+                    "description": "время прослушивания в минутах",
+                    "type": "integer"
+                },
+                "plays": {
+                    "description": "количество прослушиваний",
+                    "type": "integer"
+                },
+                "to": {
+                    "description": "конец периода в формате RFC3339, не включая",
+                    "type": "string"
+                },
+                "top_artists": {
+                    "description": "самые прослушиваемые исполнители",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.TopArtistView"
+                    }
+                },
+                "top_tracks": {
+                    "description": "самые прослушиваемые треки",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.TopTrackView"
+                    }
+                },
+                "tracks": {
+                    "description": "количество разных треков",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.ListeningStreakView": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "длина серии в днях",
+                    "type": "integer"
+                },
+                "end": {
+                    "description": "последний день серии в формате 2006-01-02",
+                    "type": "string"
+                },
+                "start": {
+                    "description": "первый день серии в формате 2006-01-02",
+                    "type": "string"
+                }
+            }
+        },
+        "view.LyricLineView": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "description": "текст строки",
+                    "type": "string"
+                },
+                "time_ms": {
+                    "description": "время начала строки в миллисекундах",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.LyricsView": {
+            "type": "object",
+            "properties": {
+                "available_languages": {
+                    "description": "все языки, на которых есть текст трека",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "language": {
+                    "description": "язык текста",
+                    "type": "string"
+                },
+                "music_id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "plain": {
+                    "description": "текст без разметки времени",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "источник текста (upload, id3)",
+                    "type": "string"
+                },
+                "synced": {
+                    "description": "строки синхронизированного текста, null если его нет",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.LyricLineView"
+                    }
+                },
+                "updated_at": {
+                    "description": "время последнего изменения в формате RFC3339",
+                    "type": "string"
+                }
+            }
+        },
+        "view.ModerationItemView": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "автор комментария",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.CommentAuthorView"
+                        }
+                    ]
+                },
+                "body": {
+                    "description": "текст комментария",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "время создания в формате RFC3339",
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "время последнего редактирования в формате RFC3339",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id комментария",
+                    "type": "string"
+                },
+                "last_reason": {
+                    "description": "причина последней жалобы",
+                    "type": "string"
+                },
+                "last_reported_at": {
+                    "description": "время последней жалобы в формате RFC3339",
+                    "type": "string"
+                },
+                "music_id": {
+                    "description": "id трека, null для комментария к плейлисту",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "id комментария, на который дан ответ",
+                    "type": "string"
+                },
+                "playlist_id": {
+                    "description": "id плейлиста, null для комментария к треку",
+                    "type": "string"
+                },
+                "reply_count": {
+                    "description": "количество ответов",
+                    "type": "integer"
+                },
+                "reports": {
+                    "description": "количество открытых жалоб",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "статус (visible, hidden, deleted)",
+                    "type": "string"
+                }
+            }
+        },
+        "view.MusicDuplicateView": {
+            "type": "object",
+            "properties": {
+                "detected_at": {
+                    "description": "время обнаружения в формате RFC3339",
+                    "type": "string"
+                },
+                "link": {
+                    "description": "ссылка на похожий трек",
+                    "type": "string"
+                },
+                "music_id": {
+                    "description": "id похожего трека",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "название похожего трека",
+                    "type": "string"
+                },
+                "same_hash": {
+                    "description": "файлы совпадают побайтно",
+                    "type": "boolean"
+                },
+                "same_title": {
+                    "description": "совпадают названия при близкой продолжительности",
+                    "type": "boolean"
+                },
+                "similarity": {
+                    "description": "сходство звука от 0 до 1, null если отпечатка нет",
+                    "type": "number"
+                }
+            }
+        },
+        "view.MusicFieldChangeView": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "название поля",
+                    "type": "string"
+                },
+                "from": {
+                    "description": "значение в исходной версии",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "значение в целевой версии",
+                    "type": "string"
+                }
+            }
+        },
+        "view.MusicMergeView": {
+            "type": "object",
+            "properties": {
+                "into_id": {
+                    "description": "id оставленного трека",
+                    "type": "string"
+                },
+                "likes": {
+                    "description": "перенесенные лайки",
+                    "type": "integer"
+                },
+                "music_id": {
+                    "description": "id дубликата, перемещенного в корзину",
+                    "type": "string"
+                },
+                "playlist_entries": {
+                    "description": "перенесенные записи плейлистов",
+                    "type": "integer"
+                },
+                "plays": {
+                    "description": "перенесенные события истории прослушиваний",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.MusicRevisionDiffView": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "измененные поля",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.MusicFieldChangeView"
+                    }
+                },
+                "from": {
+                    "description": "исходная версия",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.MusicRevisionView"
+                        }
+                    ]
+                },
+                "music_id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "целевая версия",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.MusicRevisionView"
+                        }
+                    ]
+                }
+            }
+        },
+        "view.MusicRevisionView": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "изменение (create, update, revert)",
+                    "type": "string"
+                },
+                "changed_by": {
+                    "description": "автор изменения, null если изменение сделано без пользователя",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.RevisionAuthorView"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "description": "время изменения в формате RFC3339",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "продолжительность трека",
+                    "type": "string"
+                },
+                "file_name": {
+                    "description": "имя файла",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "название трека",
+                    "type": "string"
+                },
+                "release": {
+                    "description": "дата релиза трека в формате 2006-01-02",
+                    "type": "string"
+                },
+                "reverted_from": {
+                    "description": "версия, к которой откатили трек",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "размер файла трека (в удобном для чтения виде)",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "номер версии",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.MusicUploadView": {
+            "type": "object",
+            "properties": {
+                "duplicates": {
+                    "description": "предупреждения о вероятных дубликатах",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.MusicDuplicateView"
+                    }
+                },
+                "music": {
+                    "description": "загруженный трек",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.MusicView"
+                        }
+                    ]
+                }
+            }
+        },
+        "view.MusicView": {
+            "type": "object",
+            "properties": {
+                "artist_id": {
+                    "description": "id исполнителя, null если исполнитель не указан",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "продолжительность трека",
+                    "type": "string"
+                },
+                "explicit": {
+                    "description": "трек содержит ненормативный контент",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "название трека",
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "средняя оценка трека",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "description": "количество оценок трека",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "размер файла трека (в удобном для чтения виде)",
+                    "type": "string"
+                }
+            }
+        },
+        "view.NotificationPageView": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "уведомления страницы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.NotificationView"
+                    }
+                },
+                "next_cursor": {
+                    "description": "курсор следующей страницы, пустой если страница последняя",
+                    "type": "string"
+                },
+                "unread_count": {
+                    "description": "всего непрочитанных уведомлений",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.NotificationPreferenceView": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "отправлять на email",
+                    "type": "boolean"
+                },
+                "in_app": {
+                    "description": "показывать во входящих",
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "тип уведомления",
+                    "type": "string"
+                },
+                "webhook": {
+                    "description": "отправлять в webhook",
+                    "type": "boolean"
+                }
+            }
+        },
+        "view.NotificationSettingsView": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "адрес для email-канала",
+                    "type": "string"
+                },
+                "preferences": {
+                    "description": "каналы по типам уведомлений",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.NotificationPreferenceView"
+                    }
+                }
+            }
+        },
+        "view.NotificationView": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "description": "исполнитель для релиза, автор комментария для остальных типов",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.FeedRefView"
+                        }
+                    ]
+                },
+                "comment_id": {
+                    "description": "id ответа или комментария к плейлисту",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "время создания в формате RFC3339",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id уведомления",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "текст уведомления",
+                    "type": "string"
+                },
+                "music": {
+                    "description": "трек",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.FeedRefView"
+                        }
+                    ]
+                },
+                "playlist": {
+                    "description": "плейлист",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.FeedRefView"
+                        }
+                    ]
+                },
+                "read_at": {
+                    "description": "время прочтения в формате RFC3339, null если не прочитано",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "тип уведомления (release, comment_reply, playlist_comment)",
+                    "type": "string"
+                }
+            }
+        },
+        "view.NotificationsReadView": {
+            "type": "object",
+            "properties": {
+                "read": {
+                    "description": "количество отмеченных прочитанными уведомлений",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.OIDCAuthorizationView": {
+            "type": "object",
+            "properties": {
+                "authorization_url": {
+                    "description": "адрес страницы входа провайдера, куда нужно перейти пользователю",
+                    "type": "string"
+                }
+            }
+        },
+        "view.PlayBatchResultView": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "description": "количество сохраненных событий",
+                    "type": "integer"
+                },
+                "duplicates": {
+                    "description": "количество отброшенных дубликатов",
+                    "type": "integer"
+                },
+                "unknown_music_ids": {
+                    "description": "id неизвестных треков, события по ним пропущены",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "view.PlayEventView": {
+            "type": "object",
+            "properties": {
+                "event_type": {
+                    "description": "тип события (start, progress, complete)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id события",
+                    "type": "string"
+                },
+                "music_id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "played_at": {
+                    "description": "время события в формате RFC3339",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "позиция воспроизведения в секундах",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "источник события (client, stream)",
+                    "type": "string"
+                }
+            }
+        },
+        "view.PlaylistImportReportView": {
+            "type": "object",
+            "properties": {
+                "duplicates": {
+                    "description": "сопоставленные записи с треком, уже добавленным в плейлист",
+                    "type": "integer"
+                },
+                "matched": {
+                    "description": "количество сопоставленных записей",
+                    "type": "integer"
+                },
+                "playlist": {
+                    "description": "созданный плейлист",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.PlaylistView"
+                        }
+                    ]
+                },
+                "total": {
+                    "description": "количество записей в файле",
+                    "type": "integer"
+                },
+                "unresolved": {
+                    "description": "неоднозначные и несопоставленные записи",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.ImportEntryReportView"
+                    }
+                }
+            }
+        },
+        "view.PlaylistView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "время создания в формате RFC3339",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id плейлиста",
+                    "type": "string"
+                },
+                "is_public": {
+                    "description": "плейлист виден другим пользователям",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "название плейлиста",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "время последнего изменения в формате RFC3339",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "id владельца",
+                    "type": "string"
+                }
+            }
+        },
+        "view.PopularMusicView": {
+            "type": "object",
+            "properties": {
+                "artist_id": {
+                    "description": "id исполнителя, null если исполнитель не указан",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "продолжительность трека",
+                    "type": "string"
+                },
+                "explicit": {
+                    "description": "трек содержит ненормативный контент",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "likes": {
+                    "description": "количество лайков за окно",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "название трека",
+                    "type": "string"
+                },
+                "plays": {
+                    "description": "количество прослушиваний за окно",
+                    "type": "integer"
+                },
+                "rating": {
+                    "description": "средняя оценка трека",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "description": "количество оценок трека",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "рейтинг трека",
+                    "type": "number"
+                },
+                "size": {
+                    "description": "размер файла трека (в удобном для чтения виде)",
+                    "type": "string"
+                }
+            }
+        },
+        "view.RatingView": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "description": "средняя оценка трека",
+                    "type": "number"
+                },
+                "music_id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "оценка пользователя, 0 если трек не оценен",
+                    "type": "integer"
+                },
+                "rating_count": {
+                    "description": "количество оценок трека",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.RecommendationView": {
+            "type": "object",
+            "properties": {
+                "artist_id": {
+                    "description": "id исполнителя, null если исполнитель не указан",
+                    "type": "string"
+                },
+                "because_id": {
+                    "description": "id лайкнутого трека, на основе которого сделана рекомендация",
+                    "type": "string"
+                },
+                "because_name": {
+                    "description": "название лайкнутого трека",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "продолжительность трека",
+                    "type": "string"
+                },
+                "explicit": {
+                    "description": "трек содержит ненормативный контент",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "название трека",
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "средняя оценка трека",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "description": "количество оценок трека",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "рейтинг рекомендации",
+                    "type": "number"
+                },
+                "size": {
+                    "description": "размер файла трека (в удобном для чтения виде)",
+                    "type": "string"
+                }
+            }
+        },
+        "view.ReportsDismissedView": {
+            "type": "object",
+            "properties": {
+                "dismissed": {
+                    "description": "количество отклоненных жалоб",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.RevisionAuthorView": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "id пользователя",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "имя пользователя, пустое если пользователь удален",
+                    "type": "string"
+                }
+            }
+        },
+        "view.ScheduledMusicView": {
+            "type": "object",
+            "properties": {
+                "artist_id": {
+                    "description": "id исполнителя, null если исполнитель не указан",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "продолжительность трека",
+                    "type": "string"
+                },
+                "explicit": {
+                    "description": "трек содержит ненормативный контент",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "название трека",
+                    "type": "string"
+                },
+                "publish_at": {
+                    "description": "запланированное время публикации в формате RFC3339",
+                    "type": "string"
+                },
+                "published_at": {
+                    "description": "время публикации, пока трек не опубликован — null",
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "средняя оценка трека",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "description": "количество оценок трека",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "размер файла трека (в удобном для чтения виде)",
+                    "type": "string"
+                },
+                "takedown_at": {
+                    "description": "время снятия с публикации",
+                    "type": "string"
+                }
+            }
+        },
+        "view.SessionView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "время входа в формате RFC3339",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "сессия, в которой выполнен запрос",
+                    "type": "boolean"
+                },
+                "device_name": {
+                    "description": "название устройства",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "время истечения сессии в формате RFC3339",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id сессии",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "IP-адрес последнего входа или обновления токенов",
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "description": "время последнего входа или обновления токенов в формате RFC3339",
+                    "type": "string"
+                },
+                "user_agent": {
+                    "description": "User-Agent последнего входа или обновления токенов",
+                    "type": "string"
+                }
+            }
+        },
+        "view.ShareLinkCreatedView": {
+            "type": "object",
+            "properties": {
+                "link": {
+                    "description": "созданная ссылка",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.ShareLinkView"
+                        }
+                    ]
+                },
+                "token": {
+                    "description": "токен ссылки, показывается только при создании",
+                    "type": "string"
+                }
+            }
+        },
+        "view.ShareLinkView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "время создания в формате RFC3339",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "время истечения в формате RFC3339, null — бессрочно",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id ссылки",
+                    "type": "string"
+                },
+                "max_plays": {
+                    "description": "лимит прослушиваний, null — без лимита",
+                    "type": "integer"
+                },
+                "music_id": {
+                    "description": "id трека, null для ссылки на плейлист",
+                    "type": "string"
+                },
+                "playlist_id": {
+                    "description": "id плейлиста, null для ссылки на трек",
+                    "type": "string"
+                },
+                "plays": {
+                    "description": "количество прослушиваний по ссылке",
+                    "type": "integer"
+                },
+                "revoked_at": {
+                    "description": "время отзыва в формате RFC3339, null если ссылка не отозвана",
+                    "type": "string"
+                }
+            }
+        },
+        "view.SharedContentView": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "время истечения ссылки в формате RFC3339",
+                    "type": "string"
+                },
+                "music": {
+                    "description": "трек, null для ссылки на плейлист",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.MusicView"
+                        }
+                    ]
+                },
+                "playlist": {
+                    "description": "плейлист, null для ссылки на трек",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.PlaylistView"
+                        }
+                    ]
+                },
+                "plays_left": {
+                    "description": "оставшиеся прослушивания, null — без лимита",
+                    "type": "integer"
+                },
+                "tracks": {
+                    "description": "доступные треки плейлиста",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.MusicView"
+                    }
+                }
+            }
+        },
+        "view.SimilarMusicView": {
+            "type": "object",
+            "properties": {
+                "artist_id": {
+                    "description": "id исполнителя, null если исполнитель не указан",
+                    "type": "string"
+                },
+                "co_likes": {
+                    "description": "количество пользователей, лайкнувших оба трека",
+                    "type": "integer"
+                },
+                "duration": {
+                    "description": "продолжительность трека",
+                    "type": "string"
+                },
+                "explicit": {
+                    "description": "трек содержит ненормативный контент",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "название трека",
                     "type": "string"
                 },
+                "rating": {
+                    "description": "средняя оценка трека",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "description": "количество оценок трека",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "мера схожести",
+                    "type": "number"
+                },
                 "size": {
                     "description": "размер файла трека (в удобном для чтения виде)",
                     "type": "string"
                 }
             }
         },
+        "view.SmartPlaylistView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "время создания в формате RFC3339",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id плейлиста",
+                    "type": "string"
+                },
+                "is_public": {
+                    "description": "плейлист виден другим пользователям",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "максимальное количество треков",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "название плейлиста",
+                    "type": "string"
+                },
+                "order": {
+                    "description": "направление сортировки",
+                    "type": "string"
+                },
+                "rules": {
+                    "description": "дерево правил",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.SmartRuleView"
+                        }
+                    ]
+                },
+                "sort": {
+                    "description": "поле сортировки",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "время последнего изменения в формате RFC3339",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "id владельца",
+                    "type": "string"
+                }
+            }
+        },
+        "view.SmartRuleView": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "поле трека для условия",
+                    "type": "string"
+                },
+                "group": {
+                    "description": "and или or для группы",
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "оператор условия",
+                    "type": "string"
+                },
+                "rules": {
+                    "description": "правила группы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.SmartRuleView"
+                    }
+                },
+                "value": {
+                    "description": "значение условия"
+                }
+            }
+        },
+        "view.SyncLikeView": {
+            "type": "object",
+            "properties": {
+                "liked_at": {
+                    "description": "время лайка в формате RFC3339",
+                    "type": "string"
+                },
+                "music_id": {
+                    "description": "id трека",
+                    "type": "string"
+                }
+            }
+        },
+        "view.SyncLikesView": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "поставленные лайки",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.SyncLikeView"
+                    }
+                },
+                "removed": {
+                    "description": "id треков, лайк которых снят",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "view.SyncPlaylistView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "время создания в формате RFC3339",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "id плейлиста",
+                    "type": "string"
+                },
+                "is_public": {
+                    "description": "плейлист виден другим пользователям",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "название плейлиста",
+                    "type": "string"
+                },
+                "track_ids": {
+                    "description": "id доступных треков в порядке плейлиста",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "description": "время последнего изменения в формате RFC3339",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "id владельца",
+                    "type": "string"
+                }
+            }
+        },
+        "view.SyncPlaylistsView": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "id удаленных плейлистов",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "upserted": {
+                    "description": "созданные и измененные плейлисты",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.SyncPlaylistView"
+                    }
+                }
+            }
+        },
+        "view.SyncTracksView": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "id удаленных или ставших недоступными треков",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "upserted": {
+                    "description": "созданные и измененные треки",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.MusicView"
+                    }
+                }
+            }
+        },
+        "view.SyncView": {
+            "type": "object",
+            "properties": {
+                "full": {
+                    "description": "полная синхронизация: локальную копию нужно заменить полученными данными",
+                    "type": "boolean"
+                },
+                "has_more": {
+                    "description": "есть следующая страница, ее нужно запросить с новым токеном",
+                    "type": "boolean"
+                },
+                "likes": {
+                    "description": "изменения лайков",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.SyncLikesView"
+                        }
+                    ]
+                },
+                "playlists": {
+                    "description": "изменения плейлистов",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.SyncPlaylistsView"
+                        }
+                    ]
+                },
+                "token": {
+                    "description": "токен для следующего запроса",
+                    "type": "string"
+                },
+                "tracks": {
+                    "description": "изменения треков",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.SyncTracksView"
+                        }
+                    ]
+                }
+            }
+        },
         "view.TokenView": {
             "type": "object",
             "properties": {
+                "expires_at": {
+                    "description": "время истечения access-токена в формате RFC3339",
+                    "type": "string"
+                },
+                "refresh_expires_at": {
+                    "description": "время истечения refresh-токена в формате RFC3339",
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "description": "refresh-токен, обменивается на новую пару в /auth/refresh",
+                    "type": "string"
+                },
+                "session_id": {
+                    "description": "id сессии, которой принадлежат токены",
+                    "type": "string"
+                },
                 "token": {
+                    "description": "access-токен",
+                    "type": "string"
+                }
+            }
+        },
+        "view.TopArtistView": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "id исполнителя",
+                    "type": "string"
+                },
+                "minutes": {
+                    "description": "время прослушивания в минутах",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "имя исполнителя",
+                    "type": "string"
+                },
+                "plays": {
+                    "description": "количество прослушиваний за период",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.TopTrackView": {
+            "type": "object",
+            "properties": {
+                "artist_id": {
+                    "description": "id исполнителя, null если исполнитель не указан",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "продолжительность трека",
+                    "type": "string"
+                },
+                "explicit": {
+                    "description": "трек содержит ненормативный контент",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "minutes": {
+                    "description": "время прослушивания в минутах",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "название трека",
+                    "type": "string"
+                },
+                "plays": {
+                    "description": "количество прослушиваний за период",
+                    "type": "integer"
+                },
+                "rating": {
+                    "description": "средняя оценка трека",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "description": "количество оценок трека",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "размер файла трека (в удобном для чтения виде)",
+                    "type": "string"
+                }
+            }
+        },
+        "view.TrashedMusicView": {
+            "type": "object",
+            "properties": {
+                "artist_id": {
+                    "description": "id исполнителя, null если исполнитель не указан",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "время перемещения в корзину",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "продолжительность трека",
+                    "type": "string"
+                },
+                "explicit": {
+                    "description": "трек содержит ненормативный контент",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "id трека",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "название трека",
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "средняя оценка трека",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "description": "количество оценок трека",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "размер файла трека (в удобном для чтения виде)",
+                    "type": "string"
+                }
+            }
+        },
+        "view.TrashedUserView": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "время перемещения в корзину",
+                    "type": "string"
+                },
+                "hide_explicit": {
+                    "description": "скрывать треки с ненормативным контентом",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "share_likes": {
+                    "description": "показывать лайки подписчикам",
+                    "type": "boolean"
+                },
+                "share_playlists": {
+                    "description": "показывать публичные плейлисты подписчикам",
+                    "type": "boolean"
+                },
+                "username": {
                     "type": "string"
                 }
             }
@@ -957,16 +8357,49 @@ const docTemplate = `{
         "view.UserView": {
             "type": "object",
             "properties": {
+                "hide_explicit": {
+                    "description": "скрывать треки с ненормативным контентом",
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string"
                 },
                 "role": {
                     "type": "string"
                 },
+                "share_likes": {
+                    "description": "показывать лайки подписчикам",
+                    "type": "boolean"
+                },
+                "share_playlists": {
+                    "description": "показывать публичные плейлисты подписчикам",
+                    "type": "boolean"
+                },
                 "username": {
                     "type": "string"
                 }
             }
+        },
+        "view.YearReviewView": {
+            "type": "object",
+            "properties": {
+                "generated_at": {
+                    "description": "время расчета в формате RFC3339",
+                    "type": "string"
+                },
+                "stats": {
+                    "description": "статистика за год",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.ListeningStatsView"
+                        }
+                    ]
+                },
+                "year": {
+                    "description": "год",
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
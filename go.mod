@@ -21,6 +21,7 @@ require (
 	github.com/swaggo/swag v1.16.2
 	github.com/tcolgate/mp3 v0.0.0-20170426193717-e79c5a46d300
 	go.uber.org/zap v1.26.0
+	golang.org/x/crypto v0.9.0
 )
 
 require (
@@ -55,7 +56,6 @@ require (
 	go.uber.org/atomic v1.7.0 // indirect
 	go.uber.org/multierr v1.10.0 // indirect
 	golang.org/x/arch v0.3.0 // indirect
-	golang.org/x/crypto v0.9.0 // indirect
 	golang.org/x/net v0.10.0 // indirect
 	golang.org/x/sys v0.8.0 // indirect
 	golang.org/x/text v0.9.0 // indirect
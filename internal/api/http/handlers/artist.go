@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type artistHandlers struct {
+	interactor usecase.ArtistInteractor
+	presenter  presenter.Presenter
+}
+
+func NewArtistHandlers(interactor usecase.ArtistInteractor, presenter presenter.Presenter) *artistHandlers {
+	return &artistHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// CreateHandler godoc
+// @Summary Создание исполнителя
+// @Description Добавление исполнителя в каталог
+// @Tags Artists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body entity.ArtistCreate true "Исполнитель"
+// @Success 201 {object} view.ArtistView "Созданный исполнитель"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 422 "Некорректный исполнитель"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /artists [post]
+func (h *artistHandlers) Create(c *gin.Context) {
+	ctx := context.Background()
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var artist entity.ArtistCreate
+	err = json.Unmarshal(body, &artist)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	created, err := h.interactor.Create(ctx, &artist)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidArtist) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/artist.Create: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.presenter.ToArtistView(created))
+}
+
+// GetHandler godoc
+// @Summary Исполнитель
+// @Description Получение исполнителя по id
+// @Tags Artists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор исполнителя"
+// @Success 200 {object} view.ArtistView "Исполнитель"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Исполнитель не найден"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /artists/{id} [get]
+func (h *artistHandlers) Get(c *gin.Context) {
+	ctx := context.Background()
+
+	artistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	artist, err := h.interactor.Get(ctx, artistId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("artist not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/artist.Get: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToArtistView(artist))
+}
+
+// SetMusicArtistHandler godoc
+// @Summary Исполнитель трека
+// @Description Назначение треку исполнителя. Новые релизы исполнителя попадают в ленту его подписчиков.
+// @Tags Artists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param request body entity.MusicArtist true "Исполнитель, null чтобы убрать исполнителя"
+// @Success 204 "Исполнитель назначен"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 404 "Трек или исполнитель не найден"
+// @Failure 422 "Некорректные данные"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/artist [put]
+func (h *artistHandlers) SetMusicArtist(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var artist entity.MusicArtist
+	err = json.Unmarshal(body, &artist)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	err = h.interactor.SetMusicArtist(ctx, musicId, &artist)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("music or artist not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/artist.SetMusicArtist: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"music-backend-test/internal/api/http/presenter"
 	_ "music-backend-test/internal/api/http/view"
@@ -11,6 +12,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type authHandlers struct {
@@ -32,7 +34,8 @@ func NewAuthHandlers(interactor usecase.UserInteractor, presenter presenter.Pres
 // @Accept json
 // @Produce json
 // @Param request body entity.UserCreate true "Данные пользователя для регистрации"
-// @Success 201 {object} view.TokenView "Токен авторизации"
+// @Param X-Device-Name header string false "Название устройства, под которым сессия показывается в списке сессий"
+// @Success 201 {object} view.TokenView "Access- и refresh-токены"
 // @Failure 400 "Некорректный запрос"
 // @Failure 422 "Ошибка при обработке данных"
 // @Failure 500 "Внутренняя ошибка сервера"
@@ -70,7 +73,13 @@ func (a *authHandlers) SignUp(c *gin.Context) {
 		return
 	}
 
-	token, err := a.presenter.ToTokenView(entity.GenerateToken(userId))
+	tokens, err := a.interactor.IssueTokens(ctx, userId, sessionDevice(c))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't sign up user: %v", err))
+		return
+	}
+
+	token, err := a.presenter.ToTokenView(tokens)
 	if err != nil {
 		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't sign up user: %v", err))
 		return
@@ -81,14 +90,15 @@ func (a *authHandlers) SignUp(c *gin.Context) {
 
 // SignIn godoc
 // @Summary Вход пользователя
-// @Description Авторизация пользователя с использованием имени пользователя и пароля.
+// @Description Авторизация пользователя с использованием имени пользователя и пароля. Пароль, сохраненный до перехода на Argon2id, при успешном входе заменяется хэшем.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body entity.UserCreate true "Данные пользователя для входа"
-// @Success 200 {object} view.TokenView "Токен авторизации"
+// @Param X-Device-Name header string false "Название устройства, под которым сессия показывается в списке сессий"
+// @Success 200 {object} view.TokenView "Access- и refresh-токены"
 // @Failure 400 "Некорректный запрос"
-// @Failure 401 "Ошибка авторизации"
+// @Failure 401 "Неверное имя пользователя или пароль"
 // @Failure 422 "Ошибка при обработке данных"
 // @Failure 500 "Внутренняя ошибка сервера"
 // @Router /auth/signin [post]
@@ -108,27 +118,152 @@ func (a *authHandlers) SignIn(c *gin.Context) {
 		return
 	}
 
-	user, err := a.interactor.GetByUsername(ctx, signinUser.Username)
+	user, err := a.interactor.SignIn(ctx, signinUser)
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't get user from interactor: %v", err))
+		if errors.Is(err, entity.ErrInvalidCredentials) {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't sign in user: %v", err))
 		return
 	}
 
-	if user == nil {
-		c.AbortWithStatus(http.StatusNotFound)
+	tokens, err := a.interactor.IssueTokens(ctx, user.ID, sessionDevice(c))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't sign in user: %v", err))
 		return
 	}
 
-	if user.Password != signinUser.Password {
-		c.AbortWithStatus(http.StatusUnauthorized)
+	token, err := a.presenter.ToTokenView(tokens)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't sign in user: %v", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// Refresh godoc
+// @Summary Обновление токенов
+// @Description Обмен refresh-токена на новую пару токенов. Предъявленный refresh-токен становится недействительным. Повторное предъявление уже обмененного токена отзывает все токены, выданные после того же входа.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body entity.RefreshToken true "Refresh-токен"
+// @Param X-Device-Name header string false "Новое название устройства сессии"
+// @Success 200 {object} view.TokenView "Новая пара токенов"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Refresh-токен недействителен, истек или отозван"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /auth/refresh [post]
+func (a *authHandlers) Refresh(c *gin.Context) {
+	ctx := context.Background()
+
+	refreshToken, err := bindRefreshToken(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't refresh token: %w", err))
 		return
 	}
 
-	token, err := a.presenter.ToTokenView(entity.GenerateToken(user.ID))
+	tokens, err := a.interactor.Refresh(ctx, refreshToken.RefreshToken, sessionDevice(c))
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't sign in user: %v", err))
+		if errors.Is(err, entity.ErrInvalidRefreshToken) || errors.Is(err, entity.ErrRefreshTokenReused) {
+			c.AbortWithError(http.StatusUnauthorized, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/user.Refresh: %w", err))
+		return
+	}
+
+	token, err := a.presenter.ToTokenView(tokens)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't refresh token: %w", err))
 		return
 	}
 
 	c.JSON(http.StatusOK, token)
 }
+
+// Logout godoc
+// @Summary Выход
+// @Description Отзыв сессии, к которой относится refresh-токен: ее refresh-токены больше не обмениваются, access-токены перестают приниматься.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body entity.RefreshToken true "Refresh-токен"
+// @Success 204 "Токены отозваны"
+// @Failure 400 "Некорректный запрос"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /auth/logout [post]
+func (a *authHandlers) Logout(c *gin.Context) {
+	ctx := context.Background()
+
+	refreshToken, err := bindRefreshToken(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't logout: %w", err))
+		return
+	}
+
+	err = a.interactor.Logout(ctx, refreshToken.RefreshToken)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidRefreshToken) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/user.Logout: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LogoutAll godoc
+// @Summary Выход на всех устройствах
+// @Description Отзыв всех сессий пользователя, включая текущую.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 204 "Токены отозваны"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /auth/logout-all [post]
+func (a *authHandlers) LogoutAll(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	err := a.interactor.LogoutAll(ctx, userId.(uuid.UUID))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/user.LogoutAll: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func bindRefreshToken(c *gin.Context) (*entity.RefreshToken, error) {
+	data, err := c.GetRawData()
+	if err != nil {
+		return nil, err
+	}
+
+	var refreshToken entity.RefreshToken
+	err = json.Unmarshal(data, &refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &refreshToken, nil
+}
+
+// sessionDevice возвращает устройство, с которого пришел запрос на вход или обновление токенов
+func sessionDevice(c *gin.Context) *entity.SessionDevice {
+	return entity.NewSessionDevice(c.GetHeader("X-Device-Name"), c.Request.UserAgent(), c.ClientIP())
+}
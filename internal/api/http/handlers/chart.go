@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type chartHandlers struct {
+	interactor usecase.ChartInteractor
+	presenter  presenter.Presenter
+}
+
+func NewChartHandlers(interactor usecase.ChartInteractor, presenter presenter.Presenter) *chartHandlers {
+	return &chartHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// GetWeeklyHandler godoc
+// @Summary Получение недельного чарта
+// @Description Получение сохраненного недельного чарта с изменением позиций относительно предыдущей недели, пиковой позицией и количеством недель в чарте. Без параметра week возвращается последний чарт. Треки с ненормативным контентом скрываются, если пользователь включил фильтр контента, позиции остальных треков не меняются.
+// @Tags Charts
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param week query string false "Любая дата недели чарта (2006-01-02)"
+// @Success 200 {object} view.ChartView "Недельный чарт"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Чарт не найден"
+// @Failure 422 "Некорректная дата"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /charts/weekly [get]
+func (h *chartHandlers) GetWeekly(c *gin.Context) {
+	ctx := context.Background()
+
+	week, err := parseTimeQuery(c, "week")
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	entries, err := h.interactor.GetWeekly(ctx, week, c.GetBool("hide-explicit"))
+	if err != nil {
+		if errors.Is(err, entity.ErrChartNotFound) {
+			c.AbortWithError(http.StatusNotFound, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/chart.GetWeekly: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToChartView(entries))
+}
+
+// GetMusicHistoryHandler godoc
+// @Summary Получение истории трека в чартах
+// @Description Получение всех недельных позиций трека, начиная с последней
+// @Tags Charts
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Success 200 {object} []view.ChartEntryView "История позиций"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/charts [get]
+func (h *chartHandlers) GetMusicHistory(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	entries, err := h.interactor.GetMusicHistory(ctx, musicId)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/chart.GetMusicHistory: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListChartEntryView(entries))
+}
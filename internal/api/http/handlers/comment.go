@@ -0,0 +1,650 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type commentHandlers struct {
+	interactor usecase.CommentInteractor
+	presenter  presenter.Presenter
+}
+
+func NewCommentHandlers(interactor usecase.CommentInteractor, presenter presenter.Presenter) *commentHandlers {
+	return &commentHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// CreateHandler godoc
+// @Summary Комментарий к треку
+// @Description Публикация комментария к треку или ответа на комментарий. Отвечать можно только на комментарии верхнего уровня. Количество комментариев пользователя за период ограничено.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param request body entity.CommentCreate true "Комментарий"
+// @Success 201 {object} view.CommentView "Созданный комментарий"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Трек или комментарий не найден"
+// @Failure 422 "Некорректный комментарий"
+// @Failure 429 "Превышен лимит комментариев"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/comments [post]
+func (h *commentHandlers) Create(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var comment entity.CommentCreate
+	err = json.Unmarshal(body, &comment)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	created, err := h.interactor.Create(ctx, userId.(uuid.UUID), musicId, &comment)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidComment), errors.Is(err, entity.ErrCommentReplyDepth):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, entity.ErrCommentRateLimited):
+			c.AbortWithError(http.StatusTooManyRequests, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("music or comment not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/comment.Create: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.presenter.ToCommentView(created))
+}
+
+// CreateForPlaylistHandler godoc
+// @Summary Комментарий к плейлисту
+// @Description Публикация комментария к публичному или своему плейлисту или ответа на комментарий. Владелец плейлиста получает уведомление playlist_comment. Количество комментариев пользователя за период ограничено.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Param request body entity.CommentCreate true "Комментарий"
+// @Success 201 {object} view.CommentView "Созданный комментарий"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Плейлист или комментарий не найден"
+// @Failure 422 "Некорректный комментарий"
+// @Failure 429 "Превышен лимит комментариев"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /playlists/{id}/comments [post]
+func (h *commentHandlers) CreateForPlaylist(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var comment entity.CommentCreate
+	err = json.Unmarshal(body, &comment)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	created, err := h.interactor.CreateForPlaylist(ctx, userId.(uuid.UUID), playlistId, &comment)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidComment), errors.Is(err, entity.ErrCommentReplyDepth):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, entity.ErrCommentRateLimited):
+			c.AbortWithError(http.StatusTooManyRequests, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("playlist or comment not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/comment.CreateForPlaylist: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.presenter.ToCommentView(created))
+}
+
+// GetByMusicHandler godoc
+// @Summary Комментарии к треку
+// @Description Получение комментариев верхнего уровня к треку с курсорной пагинацией. Администраторы видят также скрытые и удаленные комментарии и комментарии неопубликованных треков. Комментарии пользователей в корзине не выдаются.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param sort query string false "Сортировка: newest (по умолчанию) или top"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param limit query int false "Количество комментариев (по умолчанию 20, максимум 100)"
+// @Success 200 {object} view.CommentPageView "Страница комментариев"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Трек не найден"
+// @Failure 422 "Некорректная сортировка или курсор"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/comments [get]
+func (h *commentHandlers) GetByMusic(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	filter, err := parseCommentFilter(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	page, err := h.interactor.GetByMusic(ctx, musicId, filter)
+	if err != nil {
+		if errors.Is(err, entity.ErrUnknownCommentSort) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/comment.GetByMusic: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToCommentPageView(page))
+}
+
+// GetByPlaylistHandler godoc
+// @Summary Комментарии к плейлисту
+// @Description Получение комментариев верхнего уровня к публичному или своему плейлисту с курсорной пагинацией. Администраторы видят также скрытые и удаленные комментарии.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Param sort query string false "Сортировка: newest (по умолчанию) или top"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param limit query int false "Количество комментариев (по умолчанию 20, максимум 100)"
+// @Success 200 {object} view.CommentPageView "Страница комментариев"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Плейлист не найден"
+// @Failure 422 "Некорректная сортировка или курсор"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /playlists/{id}/comments [get]
+func (h *commentHandlers) GetByPlaylist(c *gin.Context) {
+	ctx := context.Background()
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	filter, err := parseCommentFilter(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	page, err := h.interactor.GetByPlaylist(ctx, playlistId, filter)
+	if err != nil {
+		if errors.Is(err, entity.ErrUnknownCommentSort) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/comment.GetByPlaylist: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToCommentPageView(page))
+}
+
+// GetRepliesHandler godoc
+// @Summary Ответы на комментарий
+// @Description Получение ответов на комментарий в хронологическом порядке с курсорной пагинацией
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор комментария"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param limit query int false "Количество ответов (по умолчанию 20, максимум 100)"
+// @Success 200 {object} view.CommentPageView "Страница ответов"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Комментарий не найден"
+// @Failure 422 "Некорректный курсор"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /comments/{id}/replies [get]
+func (h *commentHandlers) GetReplies(c *gin.Context) {
+	ctx := context.Background()
+
+	commentId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	filter, err := parseCommentFilter(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	page, err := h.interactor.GetReplies(ctx, commentId, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrUnknownCommentSort):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("comment not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/comment.GetReplies: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToCommentPageView(page))
+}
+
+// UpdateHandler godoc
+// @Summary Редактирование комментария
+// @Description Изменение текста комментария его автором
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор комментария"
+// @Param request body entity.CommentUpdate true "Новый текст"
+// @Success 200 {object} view.CommentView "Измененный комментарий"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Комментарий принадлежит другому пользователю"
+// @Failure 404 "Комментарий не найден"
+// @Failure 422 "Некорректный комментарий"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /comments/{id} [put]
+func (h *commentHandlers) Update(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	commentId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var comment entity.CommentUpdate
+	err = json.Unmarshal(body, &comment)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	updated, err := h.interactor.Update(ctx, userId.(uuid.UUID), commentId, &comment)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidComment):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, entity.ErrCommentForbidden):
+			c.AbortWithError(http.StatusForbidden, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("comment not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/comment.Update: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToCommentView(updated))
+}
+
+// DeleteHandler godoc
+// @Summary Удаление комментария
+// @Description Мягкое удаление комментария автором или администратором
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор комментария"
+// @Success 204 "Комментарий удален"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Комментарий принадлежит другому пользователю"
+// @Failure 404 "Комментарий не найден"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /comments/{id} [delete]
+func (h *commentHandlers) Delete(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	commentId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	isAdmin := c.GetString("user-role") == entity.AdminRole
+	err = h.interactor.Delete(ctx, userId.(uuid.UUID), commentId, isAdmin)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrCommentForbidden):
+			c.AbortWithError(http.StatusForbidden, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("comment not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/comment.Delete: %w", err))
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HideHandler godoc
+// @Summary Скрытие комментария
+// @Description Скрытие комментария администратором. Открытые жалобы на комментарий помечаются рассмотренными.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор комментария"
+// @Success 204 "Комментарий скрыт"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Недостаточно прав"
+// @Failure 404 "Видимый комментарий не найден"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /comments/{id}/hide [post]
+func (h *commentHandlers) Hide(c *gin.Context) {
+	h.moderate(c, h.interactor.Hide, "Hide")
+}
+
+// UnhideHandler godoc
+// @Summary Восстановление скрытого комментария
+// @Description Возврат скрытого комментария в выдачу администратором
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор комментария"
+// @Success 204 "Комментарий восстановлен"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Недостаточно прав"
+// @Failure 404 "Скрытый комментарий не найден"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /comments/{id}/unhide [post]
+func (h *commentHandlers) Unhide(c *gin.Context) {
+	h.moderate(c, h.interactor.Unhide, "Unhide")
+}
+
+func (h *commentHandlers) moderate(c *gin.Context, action func(context.Context, uuid.UUID, uuid.UUID) error, name string) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	commentId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = action(ctx, userId.(uuid.UUID), commentId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("comment not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/comment.%s: %w", name, err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReportHandler godoc
+// @Summary Жалоба на комментарий
+// @Description Отправка жалобы на комментарий. На один комментарий пользователь может пожаловаться один раз.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор комментария"
+// @Param request body entity.ReportCreate true "Жалоба"
+// @Success 201 "Жалоба принята"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Комментарий не найден"
+// @Failure 409 "Жалоба уже отправлена"
+// @Failure 422 "Некорректная жалоба"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /comments/{id}/reports [post]
+func (h *commentHandlers) Report(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	commentId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var report entity.ReportCreate
+	err = json.Unmarshal(body, &report)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	err = h.interactor.Report(ctx, userId.(uuid.UUID), commentId, &report)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidComment):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, entity.ErrDuplicateReport):
+			c.AbortWithError(http.StatusConflict, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("comment not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/comment.Report: %w", err))
+		}
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+// GetModerationQueueHandler godoc
+// @Summary Очередь модерации
+// @Description Получение комментариев с открытыми жалобами, начиная с комментариев с наибольшим количеством жалоб
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param limit query int false "Количество комментариев (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.ModerationItemView "Очередь модерации"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Недостаточно прав"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /comments/reports [get]
+func (h *commentHandlers) GetModerationQueue(c *gin.Context) {
+	ctx := context.Background()
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultCommentLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	items, err := h.interactor.GetModerationQueue(ctx, limit, offset)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/comment.GetModerationQueue: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListModerationItemView(items))
+}
+
+// DismissReportsHandler godoc
+// @Summary Отклонение жалоб
+// @Description Отклонение всех открытых жалоб на комментарий администратором. Комментарий остается видимым.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор комментария"
+// @Success 200 {object} view.ReportsDismissedView "Количество отклоненных жалоб"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Недостаточно прав"
+// @Failure 404 "Открытые жалобы не найдены"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /comments/{id}/reports/dismiss [post]
+func (h *commentHandlers) DismissReports(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	commentId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	dismissed, err := h.interactor.DismissReports(ctx, userId.(uuid.UUID), commentId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("open reports not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/comment.DismissReports: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, &view.ReportsDismissedView{Dismissed: dismissed})
+}
+
+// parseCommentFilter читает параметры выдачи комментариев. Скрытые комментарии показываются только администраторам.
+func parseCommentFilter(c *gin.Context) (*entity.CommentFilter, error) {
+	limit, err := parseIntQuery(c, "limit", entity.DefaultCommentLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &entity.CommentFilter{
+		Sort:            c.Query("sort"),
+		Limit:           limit,
+		IncludeHidden:   c.GetString("user-role") == entity.AdminRole,
+		ShowUnpublished: c.GetString("user-role") == entity.AdminRole,
+	}
+	if userId, exists := c.Get("user-id"); exists {
+		filter.ViewerID = userId.(uuid.UUID)
+	}
+
+	if value := c.Query("cursor"); value != "" {
+		filter.Cursor, err = entity.DecodeCommentCursor(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return filter, nil
+}
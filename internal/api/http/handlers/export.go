@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type exportHandlers struct {
+	interactor usecase.ExportInteractor
+	presenter  presenter.Presenter
+}
+
+func NewExportHandlers(interactor usecase.ExportInteractor, presenter presenter.Presenter) *exportHandlers {
+	return &exportHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// ExportHandler godoc
+// @Summary Выгрузка библиотеки
+// @Description Выгрузка лайков, плейлистов, оценок и истории прослушиваний текущего пользователя. Форматы json и csv содержат всю библиотеку, m3u8 и xspf — плейлист playlist_id или ZIP-архив со всеми плейлистами. Треки указываются постоянными ссылками на файлы. Небольшая выгрузка отдается сразу, большая или запрошенная с async=true выполняется фоновой задачей: в ответ возвращается задача, файл которой скачивается по download_url после готовности.
+// @Tags Export
+// @Produce json
+// @Produce text/csv
+// @Produce application/zip
+// @Security JwtAuth
+// @Param format query string true "Формат выгрузки" Enums(json, csv, m3u8, xspf)
+// @Param playlist_id query string false "Идентификатор плейлиста для m3u8 и xspf"
+// @Param async query bool false "Выгрузить фоновой задачей независимо от размера"
+// @Success 200 {file} file "Файл выгрузки"
+// @Success 202 {object} view.ExportJobView "Задача выгрузки"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Плейлист не найден"
+// @Failure 422 "Неизвестный формат или некорректные параметры"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/export [get]
+func (h *exportHandlers) Export(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	request, err := parseExportRequest(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	job, err := h.interactor.Start(ctx, userId.(uuid.UUID), request)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidExport):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, err)
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/export.Start: %w", err))
+		}
+		return
+	}
+	if job != nil {
+		c.JSON(http.StatusAccepted, h.presenter.ToExportJobView(job))
+		return
+	}
+
+	c.Header("Content-Type", request.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", request.FileName()))
+	c.Status(http.StatusOK)
+
+	// Статус уже отправлен, поэтому ошибка только прерывает выгрузку и попадает в лог
+	err = h.interactor.Write(ctx, userId.(uuid.UUID), request, c.Writer)
+	if err != nil {
+		c.Error(fmt.Errorf("/usecase/export.Write: %w", err))
+		c.Abort()
+	}
+}
+
+// GetJobHandler godoc
+// @Summary Задача выгрузки
+// @Description Состояние фоновой выгрузки текущего пользователя
+// @Tags Export
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор задачи"
+// @Success 200 {object} view.ExportJobView "Задача выгрузки"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Задача не найдена"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/exports/{id} [get]
+func (h *exportHandlers) GetJob(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	job, err := h.interactor.GetJob(ctx, userId.(uuid.UUID), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/export.GetJob: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToExportJobView(job))
+}
+
+// DownloadHandler godoc
+// @Summary Скачивание выгрузки
+// @Description Файл готовой фоновой выгрузки. Файл хранится ограниченное время, после чего выгрузку нужно запросить заново.
+// @Tags Export
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор задачи"
+// @Success 200 {file} file "Файл выгрузки"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Задача не найдена"
+// @Failure 409 "Выгрузка еще выполняется или завершилась ошибкой"
+// @Failure 410 "Срок хранения файла истек"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/exports/{id}/download [get]
+func (h *exportHandlers) Download(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	job, err := h.interactor.GetDownload(ctx, userId.(uuid.UUID), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, err)
+		case errors.Is(err, entity.ErrExportNotReady):
+			c.AbortWithError(http.StatusConflict, err)
+		case errors.Is(err, entity.ErrExportExpired):
+			c.AbortWithError(http.StatusGone, err)
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/export.GetDownload: %w", err))
+		}
+		return
+	}
+
+	request := job.Request()
+	c.Header("Content-Type", request.ContentType())
+	c.FileAttachment(job.FilePath(), request.FileName())
+}
+
+// parseExportRequest читает параметры выгрузки
+func parseExportRequest(c *gin.Context) (*entity.ExportRequest, error) {
+	request := &entity.ExportRequest{Format: c.Query("format")}
+
+	if value := c.Query("playlist_id"); value != "" {
+		playlistId, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse playlist_id: %w", err)
+		}
+		request.PlaylistID = &playlistId
+	}
+
+	if value := c.Query("async"); value != "" {
+		async, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid async: %w", err)
+		}
+		request.Async = async
+	}
+
+	return request, nil
+}
@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type followHandlers struct {
+	interactor usecase.FollowInteractor
+	presenter  presenter.Presenter
+}
+
+func NewFollowHandlers(interactor usecase.FollowInteractor, presenter presenter.Presenter) *followHandlers {
+	return &followHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// FollowArtistHandler godoc
+// @Summary Подписка на исполнителя
+// @Description Подписка текущего пользователя на исполнителя. Новые релизы исполнителя попадают в ленту. Повторная подписка ничего не меняет.
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор исполнителя"
+// @Success 204 "Подписка оформлена"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Исполнитель не найден"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /artists/{id}/follow [post]
+func (h *followHandlers) FollowArtist(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	artistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.FollowArtist(ctx, userId.(uuid.UUID), artistId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("artist not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/follow.FollowArtist: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnfollowArtistHandler godoc
+// @Summary Отписка от исполнителя
+// @Description Отмена подписки текущего пользователя на исполнителя
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор исполнителя"
+// @Success 204 "Подписка отменена"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Подписка не найдена"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /artists/{id}/follow [delete]
+func (h *followHandlers) UnfollowArtist(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	artistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.UnfollowArtist(ctx, userId.(uuid.UUID), artistId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("follow not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/follow.UnfollowArtist: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// FollowUserHandler godoc
+// @Summary Подписка на пользователя
+// @Description Подписка текущего пользователя на другого пользователя. Лайки и публичные плейлисты пользователя попадают в ленту, если он ими делится.
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор пользователя"
+// @Success 204 "Подписка оформлена"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Пользователь не найден"
+// @Failure 422 "Некорректный id или подписка на себя"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/{id}/follow [post]
+func (h *followHandlers) FollowUser(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	followeeId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.FollowUser(ctx, userId.(uuid.UUID), followeeId)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrSelfFollow):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("user not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/follow.FollowUser: %w", err))
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnfollowUserHandler godoc
+// @Summary Отписка от пользователя
+// @Description Отмена подписки текущего пользователя на другого пользователя
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор пользователя"
+// @Success 204 "Подписка отменена"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Подписка не найдена"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/{id}/follow [delete]
+func (h *followHandlers) UnfollowUser(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	followeeId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.UnfollowUser(ctx, userId.(uuid.UUID), followeeId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("follow not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/follow.UnfollowUser: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetFollowingHandler godoc
+// @Summary Подписки текущего пользователя
+// @Description Получение исполнителей и пользователей, на которых подписан текущий пользователь
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} view.FollowingView "Подписки"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/following [get]
+func (h *followHandlers) GetFollowing(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	following, err := h.interactor.GetFollowing(ctx, userId.(uuid.UUID))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/follow.GetFollowing: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToFollowingView(following))
+}
+
+// GetFeedHandler godoc
+// @Summary Лента активности
+// @Description Получение ленты текущего пользователя с курсорной пагинацией: новые релизы исполнителей, лайки и изменения публичных плейлистов пользователей из подписок, начиная с последних событий
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param limit query int false "Количество событий (по умолчанию 20, максимум 100)"
+// @Success 200 {object} view.FeedPageView "Страница ленты"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Некорректный курсор"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/feed [get]
+func (h *followHandlers) GetFeed(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	filter, err := parseFeedFilter(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	page, err := h.interactor.GetFeed(ctx, userId.(uuid.UUID), filter)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/follow.GetFeed: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToFeedPageView(page))
+}
+
+// SetPrivacyHandler godoc
+// @Summary Настройки приватности ленты
+// @Description Выбор событий, которые текущий пользователь показывает своим подписчикам: лайки и изменения публичных плейлистов. По умолчанию подписчикам не показывается ничего
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body entity.FeedPrivacy true "Настройки приватности"
+// @Success 204 "Настройки сохранены"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Пользователь не найден"
+// @Failure 422 "Некорректные данные"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/privacy [put]
+func (h *followHandlers) SetPrivacy(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var privacy entity.FeedPrivacy
+	err = json.Unmarshal(body, &privacy)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	err = h.interactor.SetPrivacy(ctx, userId.(uuid.UUID), &privacy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("user not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/follow.SetPrivacy: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseFeedFilter читает параметры выдачи ленты
+func parseFeedFilter(c *gin.Context) (*entity.FeedFilter, error) {
+	limit, err := parseIntQuery(c, "limit", entity.DefaultFeedLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &entity.FeedFilter{Limit: limit}
+
+	if value := c.Query("cursor"); value != "" {
+		filter.Cursor, err = entity.DecodeFeedCursor(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type identityHandlers struct {
+	interactor     usecase.IdentityInteractor
+	userInteractor usecase.UserInteractor
+	presenter      presenter.Presenter
+}
+
+func NewIdentityHandlers(interactor usecase.IdentityInteractor, userInteractor usecase.UserInteractor, presenter presenter.Presenter) *identityHandlers {
+	return &identityHandlers{
+		interactor:     interactor,
+		userInteractor: userInteractor,
+		presenter:      presenter,
+	}
+}
+
+// Login godoc
+// @Summary Вход через SSO
+// @Description Начало входа через OpenID Connect провайдера: перенаправление на страницу входа провайдера с кодом авторизации и PKCE. State входа сохраняется в HttpOnly cookie oidc_state. После входа провайдер возвращает пользователя на /auth/oidc/callback, который принимается только в том же браузере.
+// @Tags Auth
+// @Success 302 "Перенаправление на страницу входа провайдера"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /auth/oidc/login [get]
+func (h *identityHandlers) Login(c *gin.Context) {
+	ctx := context.Background()
+
+	auth, err := h.interactor.Begin(ctx, nil)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/identity.Begin: %w", err))
+		return
+	}
+
+	setStateCookie(c, auth)
+	c.Redirect(http.StatusFound, auth.URL)
+}
+
+// Callback godoc
+// @Summary Завершение входа через SSO
+// @Description Обмен кода авторизации от провайдера на access- и refresh-токены. State должен совпадать с cookie oidc_state браузера, в котором вход начат. При первом входе создается пользователь с ролью по умолчанию, участники группы администраторов провайдера получают роль ADMIN. Если вход начат через POST /users/me/identities, учетная запись провайдера привязывается к пользователю; такой вход нужно завершать с access-токеном того же пользователя.
+// @Tags Auth
+// @Produce json
+// @Security JwtAuth
+// @Param code query string true "Код авторизации"
+// @Param state query string true "State входа"
+// @Param X-Device-Name header string false "Название устройства, под которым сессия показывается в списке сессий"
+// @Success 200 {object} view.TokenView "Access- и refresh-токены"
+// @Failure 401 "Вход отклонен провайдером, истек, начат в другом браузере или ID-токен недействителен"
+// @Failure 403 "Привязка начата другим пользователем"
+// @Failure 404 "Пользователь не найден"
+// @Failure 409 "Учетная запись провайдера привязана к другому пользователю"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /auth/oidc/callback [get]
+func (h *identityHandlers) Callback(c *gin.Context) {
+	ctx := context.Background()
+
+	cookieState, cookieErr := c.Cookie(entity.OIDCStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(entity.OIDCStateCookie, "", -1, "/", "", secureRequest(c), true)
+
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("provider denied login: %s %s", providerErr, c.Query("error_description")))
+		return
+	}
+
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("code and state are required"))
+		return
+	}
+	if cookieErr != nil || subtle.ConstantTimeCompare([]byte(cookieState), []byte(state)) != 1 {
+		c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("login was started in another browser: %w", entity.ErrInvalidOIDCLogin))
+		return
+	}
+
+	var userId *uuid.UUID
+	if id, exists := c.Get("user-id"); exists {
+		uid := id.(uuid.UUID)
+		userId = &uid
+	}
+
+	user, err := h.interactor.Complete(ctx, state, code, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidOIDCLogin), errors.Is(err, entity.ErrOIDCCodeRejected), errors.Is(err, entity.ErrInvalidIDToken):
+			c.AbortWithError(http.StatusUnauthorized, err)
+		case errors.Is(err, entity.ErrOIDCLinkForbidden):
+			c.AbortWithError(http.StatusForbidden, err)
+		case errors.Is(err, entity.ErrIdentityLinked):
+			c.AbortWithError(http.StatusConflict, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithStatus(http.StatusNotFound)
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/identity.Complete: %w", err))
+		}
+		return
+	}
+
+	tokens, err := h.userInteractor.IssueTokens(ctx, user.ID, sessionDevice(c))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/user.IssueTokens: %w", err))
+		return
+	}
+
+	token, err := h.presenter.ToTokenView(tokens)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't sign in user: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// Link godoc
+// @Summary Привязка учетной записи SSO
+// @Description Начало входа через OpenID Connect провайдера, после которого учетная запись провайдера привязывается к текущему пользователю. State входа сохраняется в HttpOnly cookie oidc_state. Пользователя нужно перенаправить по полученному адресу в том же браузере, а /auth/oidc/callback вызвать с access-токеном текущего пользователя.
+// @Tags Auth
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} view.OIDCAuthorizationView "Адрес страницы входа провайдера"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/identities [post]
+func (h *identityHandlers) Link(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	id := userId.(uuid.UUID)
+
+	auth, err := h.interactor.Begin(ctx, &id)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/identity.Begin: %w", err))
+		return
+	}
+
+	setStateCookie(c, auth)
+	c.JSON(http.StatusOK, &view.OIDCAuthorizationView{AuthorizationURL: auth.URL})
+}
+
+// GetMine godoc
+// @Summary Учетные записи SSO текущего пользователя
+// @Description Получение учетных записей OpenID Connect провайдера, привязанных к текущему пользователю
+// @Tags Auth
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} []view.IdentityView "Учетные записи провайдера"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/identities [get]
+func (h *identityHandlers) GetMine(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	identities, err := h.interactor.GetByUser(ctx, userId.(uuid.UUID))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/identity.GetByUser: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListIdentityView(identities))
+}
+
+// setStateCookie сохраняет state начатого входа в браузере. SameSite=Lax: cookie приходит при возврате
+// со страницы провайдера, но не с запросами, которые отправляет чужой сайт.
+func setStateCookie(c *gin.Context, auth *entity.OIDCAuthorization) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(entity.OIDCStateCookie, auth.State, int(time.Until(auth.ExpiresAt).Seconds()), "/", "", secureRequest(c), true)
+}
+
+// secureRequest сообщает, пришел ли запрос по HTTPS, в том числе через прокси
+func secureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
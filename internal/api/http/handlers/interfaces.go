@@ -15,19 +15,184 @@ type UserHandlers interface {
 	LikeTrack(c *gin.Context)
 	DislikeTrack(c *gin.Context)
 	ShowLikedTracks(c *gin.Context)
+	SetContentFilterHandler(c *gin.Context)
 }
 
 type AuthHandlers interface {
 	SignUp(c *gin.Context)
 	SignIn(c *gin.Context)
+	Refresh(c *gin.Context)
+	Logout(c *gin.Context)
+	LogoutAll(c *gin.Context)
 }
 
 type MusicHandlers interface {
 	GetAll(c *gin.Context)
 	Get(c *gin.Context)
 	GetAllSortByTime(c *gin.Context)
-	GetAndSortByPopular(c *gin.Context)
 	Create(c *gin.Context)
 	Update(c *gin.Context)
+	SetExplicit(c *gin.Context)
 	Delete(c *gin.Context)
+	GetDuplicates(c *gin.Context)
+	Merge(c *gin.Context)
+}
+
+type PlayHandlers interface {
+	Record(c *gin.Context)
+	RecordBatch(c *gin.Context)
+	GetHistory(c *gin.Context)
+	DeleteHistory(c *gin.Context)
+	DeleteHistoryEvent(c *gin.Context)
+}
+
+type PopularityHandlers interface {
+	GetPopular(c *gin.Context)
+	GetTrending(c *gin.Context)
+}
+
+type ChartHandlers interface {
+	GetWeekly(c *gin.Context)
+	GetMusicHistory(c *gin.Context)
+}
+
+type RecommendationHandlers interface {
+	GetForUser(c *gin.Context)
+	GetSimilar(c *gin.Context)
+}
+
+type RatingHandlers interface {
+	Set(c *gin.Context)
+	Clear(c *gin.Context)
+	GetLibrary(c *gin.Context)
+}
+
+type CommentHandlers interface {
+	Create(c *gin.Context)
+	CreateForPlaylist(c *gin.Context)
+	GetByMusic(c *gin.Context)
+	GetByPlaylist(c *gin.Context)
+	GetReplies(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	Hide(c *gin.Context)
+	Unhide(c *gin.Context)
+	Report(c *gin.Context)
+	GetModerationQueue(c *gin.Context)
+	DismissReports(c *gin.Context)
+}
+
+type LyricsHandlers interface {
+	Get(c *gin.Context)
+	Set(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type MusicRevisionHandlers interface {
+	GetByMusic(c *gin.Context)
+	Get(c *gin.Context)
+	Diff(c *gin.Context)
+	Revert(c *gin.Context)
+}
+
+type TrashHandlers interface {
+	GetMusic(c *gin.Context)
+	GetUsers(c *gin.Context)
+	RestoreMusic(c *gin.Context)
+	RestoreUser(c *gin.Context)
+}
+
+type ReleaseHandlers interface {
+	GetScheduled(c *gin.Context)
+	SetSchedule(c *gin.Context)
+}
+
+type ArtistHandlers interface {
+	Create(c *gin.Context)
+	Get(c *gin.Context)
+	SetMusicArtist(c *gin.Context)
+}
+
+type PlaylistHandlers interface {
+	Create(c *gin.Context)
+	Get(c *gin.Context)
+	GetMine(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	GetTracks(c *gin.Context)
+	AddTrack(c *gin.Context)
+	RemoveTrack(c *gin.Context)
+}
+
+type FollowHandlers interface {
+	FollowArtist(c *gin.Context)
+	UnfollowArtist(c *gin.Context)
+	FollowUser(c *gin.Context)
+	UnfollowUser(c *gin.Context)
+	GetFollowing(c *gin.Context)
+	GetFeed(c *gin.Context)
+	SetPrivacy(c *gin.Context)
+}
+
+type NotificationHandlers interface {
+	Get(c *gin.Context)
+	MarkRead(c *gin.Context)
+	MarkAllRead(c *gin.Context)
+	GetSettings(c *gin.Context)
+	SetSettings(c *gin.Context)
+}
+
+type ShareHandlers interface {
+	CreateForMusic(c *gin.Context)
+	CreateForPlaylist(c *gin.Context)
+	GetMine(c *gin.Context)
+	Revoke(c *gin.Context)
+	GetShared(c *gin.Context)
+	StreamShared(c *gin.Context)
+	StreamSharedTrack(c *gin.Context)
+}
+
+type StatsHandlers interface {
+	Get(c *gin.Context)
+	GetYearReview(c *gin.Context)
+}
+
+type ExportHandlers interface {
+	Export(c *gin.Context)
+	GetJob(c *gin.Context)
+	Download(c *gin.Context)
+}
+
+type PlaylistImportHandlers interface {
+	Import(c *gin.Context)
+}
+
+type SmartPlaylistHandlers interface {
+	Create(c *gin.Context)
+	Get(c *gin.Context)
+	GetMine(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	GetTracks(c *gin.Context)
+}
+
+type SyncHandlers interface {
+	Sync(c *gin.Context)
+}
+
+type SessionHandlers interface {
+	GetMine(c *gin.Context)
+	RevokeMine(c *gin.Context)
+	RevokeAll(c *gin.Context)
+}
+
+type SigningKeyHandlers interface {
+	GetJWKS(c *gin.Context)
+}
+
+type IdentityHandlers interface {
+	Login(c *gin.Context)
+	Callback(c *gin.Context)
+	Link(c *gin.Context)
+	GetMine(c *gin.Context)
 }
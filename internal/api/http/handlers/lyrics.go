@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const lrcContentType = "application/x-lrc; charset=utf-8"
+
+type lyricsHandlers struct {
+	interactor usecase.LyricsInteractor
+	presenter  presenter.Presenter
+}
+
+func NewLyricsHandlers(interactor usecase.LyricsInteractor, presenter presenter.Presenter) *lyricsHandlers {
+	return &lyricsHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// GetHandler godoc
+// @Summary Текст трека
+// @Description Получение текста трека в JSON или синхронизированного текста в формате LRC. Без параметра lang возвращается первый добавленный текст, при отсутствии точного совпадения языка подходит текст с тем же основным языком. Тексты неопубликованных и снятых с публикации треков доступны только администраторам.
+// @Tags Lyrics
+// @Accept json
+// @Produce json
+// @Produce plain
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param lang query string false "Язык текста (ISO 639)"
+// @Param format query string false "Формат ответа: json (по умолчанию) или lrc"
+// @Success 200 {object} view.LyricsView "Текст трека"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Текст не найден или у текста нет синхронизированной версии"
+// @Failure 422 "Некорректный язык или формат"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/lyrics [get]
+func (h *lyricsHandlers) Get(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	format, err := entity.ValidateLyricsFormat(c.Query("format"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	lyrics, err := h.interactor.Get(ctx, musicId, c.Query("lang"), musicFilter(c).ShowUnpublished)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidLyrics):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("lyrics not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/lyrics.Get: %w", err))
+		}
+		return
+	}
+
+	if format == entity.LyricsFormatLRC {
+		lrc, err := lyrics.LRC()
+		if err != nil {
+			if errors.Is(err, entity.ErrNoSyncedLyrics) {
+				c.AbortWithError(http.StatusNotFound, err)
+				return
+			}
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't build lrc: %w", err))
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", musicId.String()+"."+lyrics.Language+".lrc"))
+		c.Data(http.StatusOK, lrcContentType, []byte(lrc))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToLyricsView(lyrics))
+}
+
+// SetHandler godoc
+// @Summary Загрузка текста трека
+// @Description Создание или замена текста трека на заданном языке. Принимает JSON с полями plain и synced либо файл LRC в теле запроса с языком в параметре lang. Синхронизированный текст проверяется и приводится к каноничному виду.
+// @Tags Lyrics
+// @Accept json
+// @Accept plain
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param lang query string false "Язык текста для загрузки файла LRC"
+// @Param request body entity.LyricsUpload true "Текст трека"
+// @Success 200 {object} view.LyricsView "Сохраненный текст"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 404 "Трек не найден"
+// @Failure 422 "Некорректный текст или LRC"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/lyrics [put]
+func (h *lyricsHandlers) Set(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var lyrics entity.LyricsUpload
+	if c.ContentType() == gin.MIMEJSON {
+		err = json.Unmarshal(body, &lyrics)
+		if err != nil {
+			c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+			return
+		}
+	} else {
+		lyrics = entity.LyricsUpload{
+			Language: c.Query("lang"),
+			Synced:   string(body),
+		}
+	}
+
+	saved, err := h.interactor.Set(ctx, musicId, &lyrics)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidLyrics), errors.Is(err, entity.ErrInvalidLRC):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("music not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/lyrics.Set: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToLyricsView(saved))
+}
+
+// DeleteHandler godoc
+// @Summary Удаление текста трека
+// @Description Удаление текста трека на заданном языке. Без параметра lang удаляется текст с неизвестным языком.
+// @Tags Lyrics
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param lang query string false "Язык текста (ISO 639)"
+// @Success 204 "Текст удален"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 404 "Текст не найден"
+// @Failure 422 "Некорректный язык"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/lyrics [delete]
+func (h *lyricsHandlers) Delete(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.Delete(ctx, musicId, c.Query("lang"))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidLyrics):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("lyrics not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/lyrics.Delete: %w", err))
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
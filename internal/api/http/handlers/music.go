@@ -2,6 +2,9 @@ package handlers
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"music-backend-test/internal/api/http/presenter"
 	"music-backend-test/internal/entity"
@@ -27,53 +30,50 @@ func NewMusicHandlers(interactor usecase.MusicInteractor, presenter presenter.Pr
 
 // GetAllHandler godoc
 // @Summary Получение всех треков
-// @Description Получение всех треков
+// @Description Получение всех треков. При sort=rating треки сортируются по байесовской средней оценке, чтобы треки с малым количеством оценок не обгоняли треки с большим. Треки с ненормативным контентом скрываются, если пользователь включил фильтр контента.
 // @Tags Music
 // @Accept json
 // @Produce plain
 // @Security JwtAuth
+// @Param sort query string false "Сортировка: rating"
 // @Success 200 {object} []view.MusicView "Данные трека"
 // @Failure 400 "Некорректный запрос"
 // @Failure 401 "Неавторизованный запрос"
 // @Failure 404 "Пользователь не найден"
+// @Failure 422 "Неизвестная сортировка"
 // @Failure 500 "Внутренняя ошибка сервера"
 // @Router /music/catalog [get]
 func (m *musicHandlers) GetAll(c *gin.Context) {
 	ctx := context.Background()
-	musics, err := m.interactor.GetAll(ctx)
-	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.GetAll: %w", err))
-		return
-	}
 
-	c.JSON(http.StatusOK, m.presenter.ToListMusicView(musics))
-}
+	filter := musicFilter(c)
 
-// GetAndSortByPopularHandler godoc
-// @Summary Получение треков отсортированных по популярности
-// @Description Получение треков отсортированных по популярности
-// @Tags Music
-// @Accept json
-// @Produce plain
-// @Security JwtAuth
-// @Success 200 {object} []view.MusicView "Список треков"
-// @Failure 400 "Некорректный запрос"
-// @Failure 401 "Неавторизованный запрос"
-// @Failure 404 "Пользователь не найден"
-// @Failure 500 "Внутренняя ошибка сервера"
-// @Router /music/popular [get]
-func (m *musicHandlers) GetAndSortByPopular(c *gin.Context) {
-	ctx := context.Background()
-	musics, err := m.interactor.GetAndSortByPopular(ctx)
-	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.GetAndSortByPopular: %w", err))
+	var musics []*entity.MusicDB
+	var err error
+	switch sort := c.Query("sort"); sort {
+	case "":
+		musics, err = m.interactor.GetAll(ctx, filter)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.GetAll: %w", err))
+			return
+		}
+	case entity.CatalogSortRating:
+		musics, err = m.interactor.GetAllSortByRating(ctx, filter)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.GetAllSortByRating: %w", err))
+			return
+		}
+	default:
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("unknown sort: %q", sort))
+		return
 	}
+
 	c.JSON(http.StatusOK, m.presenter.ToListMusicView(musics))
 }
 
 // GetAllSortByTimeHandler godoc
 // @Summary Получение треков отсортированных по популярности
-// @Description Получение треков отсортированных по популярности
+// @Description Получение треков отсортированных по популярности. Треки с ненормативным контентом скрываются, если пользователь включил фильтр контента.
 // @Tags Music
 // @Accept json
 // @Produce plain
@@ -86,7 +86,7 @@ func (m *musicHandlers) GetAndSortByPopular(c *gin.Context) {
 // @Router /music/release [get]
 func (m *musicHandlers) GetAllSortByTime(c *gin.Context) {
 	ctx := context.Background()
-	musics, err := m.interactor.GetAllSortByTime(ctx)
+	musics, err := m.interactor.GetAllSortByTime(ctx, musicFilter(c))
 	if err != nil {
 		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.GetAllSortByTime: %w", err))
 		return
@@ -96,7 +96,7 @@ func (m *musicHandlers) GetAllSortByTime(c *gin.Context) {
 
 // GetFileHandler godoc
 // @Summary Скачивание файла трека
-// @Description Скачивание файла трека по id трека
+// @Description Скачивание файла трека по id трека. Неопубликованные и снятые с публикации треки доступны только администраторам. Пользователям с фильтром ненормативного контента скачивание таких треков запрещено.
 // @Tags Music
 // @Produce json
 // @Param id path string true "id трека"
@@ -105,7 +105,8 @@ func (m *musicHandlers) GetAllSortByTime(c *gin.Context) {
 // @Success 200 {file} file "Файл трека"
 // @Failure 400 "Некорректный запрос"
 // @Failure 401 "Неавторизованный запрос"
-// @Failure 404 "Пользователь не найден"
+// @Failure 403 "Трек скрыт фильтром контента"
+// @Failure 404 "Трек не найден"
 // @Failure 500 "Внутренняя ошибка сервера"
 // @Router /music/download/{id} [get]
 func (m *musicHandlers) Get(c *gin.Context) {
@@ -117,8 +118,16 @@ func (m *musicHandlers) Get(c *gin.Context) {
 		return
 	}
 
-	music, err := m.interactor.Get(ctx, musicId)
+	music, err := m.interactor.Get(ctx, musicId, musicFilter(c))
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("music not found: %w", err))
+			return
+		}
+		if errors.Is(err, entity.ErrExplicitContent) {
+			c.AbortWithError(http.StatusForbidden, err)
+			return
+		}
 		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.Get: %w", err))
 		return
 	}
@@ -128,14 +137,15 @@ func (m *musicHandlers) Get(c *gin.Context) {
 
 // CreateHandler godoc
 // @Summary Создание трека
-// @Description Создание нового трека
+// @Description Создание нового трека. До времени публикации (по умолчанию — дата релиза) трек виден только администраторам. Загруженный трек сравнивается с каталогом по хэшу файла, нормализованному названию при близкой продолжительности и отпечатку звука; вероятные дубликаты возвращаются предупреждениями со ссылками на похожие треки и не мешают загрузке.
 // @Tags Music
 // @Accept json
-// @Produce plain
+// @Produce json
 // @Security JwtAuth
 // @Param request formData entity.MusicParse true "Данные трека"
 // @Param file formData file true "Файл трека"
-// @Success 201 "Трек создан"
+// @Param publish_at formData string false "Время публикации в формате RFC3339"
+// @Success 201 {object} view.MusicUploadView "Трек создан"
 // @Failure 400 "Некорректный запрос"
 // @Failure 401 "Неавторизованный запрос"
 // @Failure 404 "Пользователь не найден"
@@ -158,6 +168,15 @@ func (m *musicHandlers) Create(c *gin.Context) {
 		return
 	}
 
+	if publishAt := c.Request.FormValue("publish_at"); publishAt != "" {
+		parsed, err := time.Parse(time.RFC3339, publishAt)
+		if err != nil {
+			c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse publish_at: %w", err))
+			return
+		}
+		music.PublishAt = &parsed
+	}
+
 	music.File, music.FileHeader, err = c.Request.FormFile("file")
 	if err != nil {
 		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read file: %w", err))
@@ -165,16 +184,21 @@ func (m *musicHandlers) Create(c *gin.Context) {
 	}
 	fmt.Println("\nFILE_HEADER: ", *music.FileHeader)
 
-	err = m.interactor.Create(ctx, &music)
+	if userId, exists := c.Get("user-id"); exists {
+		music.EditorID = userId.(uuid.UUID)
+	}
+
+	report, err := m.interactor.Create(ctx, &music)
 	if err != nil {
 		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.Create: %w", err))
+		return
 	}
-	c.JSON(http.StatusCreated, nil)
+	c.JSON(http.StatusCreated, m.presenter.ToMusicUploadView(report))
 }
 
 // UpdateHandler godoc
 // @Summary Обновление трека
-// @Description Обновление трека
+// @Description Обновление трека. Предыдущее состояние трека и замененный файл сохраняются в истории версий.
 // @Tags Music
 // @Accept json
 // @Produce plain
@@ -220,6 +244,10 @@ func (m *musicHandlers) Update(c *gin.Context) {
 		}
 	}
 
+	if userId, exists := c.Get("user-id"); exists {
+		music.EditorID = userId.(uuid.UUID)
+	}
+
 	err = m.interactor.Update(ctx, musicId, &music)
 	if err != nil {
 		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.Update: %w", err))
@@ -228,9 +256,61 @@ func (m *musicHandlers) Update(c *gin.Context) {
 	c.JSON(http.StatusOK, nil)
 }
 
+// SetExplicitHandler godoc
+// @Summary Отметка о ненормативном контенте
+// @Description Изменение отметки о ненормативном контенте трека. При загрузке отметка берется из тега ID3 (ITUNESADVISORY), если он есть.
+// @Tags Music
+// @Accept json
+// @Produce plain
+// @Security JwtAuth
+// @Param id path string true "id трека"
+// @Param request body entity.MusicExplicit true "Отметка о ненормативном контенте"
+// @Success 204 "Отметка сохранена"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 404 "Трек не найден"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/explicit [put]
+func (m *musicHandlers) SetExplicit(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var explicit entity.MusicExplicit
+	err = json.Unmarshal(body, &explicit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	err = m.interactor.SetExplicit(ctx, musicId, explicit.Explicit)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("music not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.SetExplicit: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // DeleteHandler godoc
 // @Summary Удаление трека
-// @Description Удаление трека
+// @Description Перемещение трека в корзину. Трек можно восстановить, пока не истек срок хранения корзины.
 // @Tags Music
 // @Accept json
 // @Produce plain
@@ -239,7 +319,7 @@ func (m *musicHandlers) Update(c *gin.Context) {
 // @Success 204 "Трек удален"
 // @Failure 400 "Некорректный запрос"
 // @Failure 401 "Неавторизованный запрос"
-// @Failure 404 "Пользователь не найден"
+// @Failure 404 "Трек не найден"
 // @Failure 500 "Внутренняя ошибка сервера"
 // @Router /music/{id} [delete]
 func (m *musicHandlers) Delete(c *gin.Context) {
@@ -253,8 +333,109 @@ func (m *musicHandlers) Delete(c *gin.Context) {
 
 	err = m.interactor.Delete(ctx, musicId)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("music not found: %w", err))
+			return
+		}
 		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.Delete: %w", err))
+		return
 	}
 
 	c.JSON(http.StatusOK, nil)
 }
+
+// GetDuplicatesHandler godoc
+// @Summary Вероятные дубликаты трека
+// @Description Треки, похожие на трек по хэшу файла, названию и продолжительности или отпечатку звука. Дубликаты определяются при загрузке; трек из пары можно слить с другим.
+// @Tags Music
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "id трека"
+// @Success 200 {array} view.MusicDuplicateView "Вероятные дубликаты"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/duplicates [get]
+func (m *musicHandlers) GetDuplicates(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	duplicates, err := m.interactor.GetDuplicates(ctx, musicId)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.GetDuplicates: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, m.presenter.ToListMusicDuplicateView(duplicates))
+}
+
+// MergeHandler godoc
+// @Summary Слияние дубликата
+// @Description Перенос лайков, записей плейлистов и истории прослушиваний трека на трек into. Если трек into уже есть в плейлисте или лайкнут, запись дубликата удаляется. После слияния дубликат перемещается в корзину.
+// @Tags Music
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "id дубликата"
+// @Param request body entity.MusicMerge true "Трек, который остается в каталоге"
+// @Success 200 {object} view.MusicMergeView "Результат слияния"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 404 "Трек не найден или уже в корзине"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/merge [post]
+func (m *musicHandlers) Merge(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var merge entity.MusicMerge
+	err = json.Unmarshal(body, &merge)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	result, err := m.interactor.Merge(ctx, musicId, &merge)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidMerge):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("music not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music.Merge: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, m.presenter.ToMusicMergeView(result))
+}
+
+// musicFilter собирает ограничения выдачи из данных пользователя, сохраненных NewUserRoleMiddleware
+func musicFilter(c *gin.Context) entity.MusicFilter {
+	return entity.MusicFilter{
+		ShowUnpublished: c.GetString("user-role") == entity.AdminRole,
+		HideExplicit:    c.GetBool("hide-explicit"),
+	}
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type musicRevisionHandlers struct {
+	interactor usecase.MusicRevisionInteractor
+	presenter  presenter.Presenter
+}
+
+func NewMusicRevisionHandlers(interactor usecase.MusicRevisionInteractor, presenter presenter.Presenter) *musicRevisionHandlers {
+	return &musicRevisionHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// GetByMusicHandler godoc
+// @Summary История изменений трека
+// @Description Получение версий трека от новых к старым. Каждая версия хранит название, дату релиза и файл трека после изменения, автора и время изменения.
+// @Tags Music revisions
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param limit query int false "Количество версий (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.MusicRevisionView "Версии трека"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 422 "Некорректные параметры"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/revisions [get]
+func (h *musicRevisionHandlers) GetByMusic(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultMusicRevisionLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	revisions, err := h.interactor.GetByMusic(ctx, musicId, limit, offset)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music_revision.GetByMusic: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListMusicRevisionView(revisions))
+}
+
+// GetHandler godoc
+// @Summary Версия трека
+// @Description Получение версии трека по номеру
+// @Tags Music revisions
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param version path int true "Номер версии"
+// @Success 200 {object} view.MusicRevisionView "Версия трека"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 404 "Версия не найдена"
+// @Failure 422 "Некорректный номер версии"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/revisions/{version} [get]
+func (h *musicRevisionHandlers) Get(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, version, err := parseRevisionParams(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	revision, err := h.interactor.Get(ctx, musicId, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidMusicRevision):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("revision not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music_revision.Get: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToMusicRevisionView(revision))
+}
+
+// DiffHandler godoc
+// @Summary Сравнение версий трека
+// @Description Получение полей, которые отличаются в двух версиях трека. Без параметра to сравнивается текущая версия, без параметра from — версия перед to. Замена файла с тем же именем отображается как изменение поля file.
+// @Tags Music revisions
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param from query int false "Исходная версия"
+// @Param to query int false "Целевая версия"
+// @Success 200 {object} view.MusicRevisionDiffView "Различия версий"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 404 "Версия не найдена"
+// @Failure 422 "Некорректный номер версии"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/revisions/diff [get]
+func (h *musicRevisionHandlers) Diff(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	from, err := parseIntQuery(c, "from", 0)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+	to, err := parseIntQuery(c, "to", 0)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	diff, err := h.interactor.Diff(ctx, musicId, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidMusicRevision):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("revision not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music_revision.Diff: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToMusicRevisionDiffView(diff))
+}
+
+// RevertHandler godoc
+// @Summary Откат трека к версии
+// @Description Возвращает название, дату релиза и файл трека к состоянию версии. Откат сохраняется как новая версия, история не переписывается.
+// @Tags Music revisions
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param version path int true "Номер версии"
+// @Success 200 {object} view.MusicRevisionView "Новая версия трека"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 404 "Версия не найдена"
+// @Failure 409 "Версия уже является текущей"
+// @Failure 422 "Некорректный номер версии"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/revisions/{version}/revert [post]
+func (h *musicRevisionHandlers) Revert(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	musicId, version, err := parseRevisionParams(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	revision, err := h.interactor.Revert(ctx, userId.(uuid.UUID), musicId, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidMusicRevision):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, entity.ErrRevertToCurrent):
+			c.AbortWithError(http.StatusConflict, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("revision not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/music_revision.Revert: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToMusicRevisionView(revision))
+}
+
+func parseRevisionParams(c *gin.Context) (uuid.UUID, int, error) {
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("can't parse id: %w", err)
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("can't parse version: %w", err)
+	}
+
+	return musicId, version, nil
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type notificationHandlers struct {
+	interactor usecase.NotificationInteractor
+	presenter  presenter.Presenter
+}
+
+func NewNotificationHandlers(interactor usecase.NotificationInteractor, presenter presenter.Presenter) *notificationHandlers {
+	return &notificationHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// GetHandler godoc
+// @Summary Входящие уведомления
+// @Description Получение уведомлений текущего пользователя с курсорной пагинацией, начиная с новых. Уведомления типов, отключенных для входящих, не показываются.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param unread query bool false "Только непрочитанные"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param limit query int false "Количество уведомлений (по умолчанию 20, максимум 100)"
+// @Success 200 {object} view.NotificationPageView "Страница уведомлений"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Некорректные параметры или курсор"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /notifications [get]
+func (h *notificationHandlers) Get(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	filter, err := parseNotificationFilter(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	page, err := h.interactor.GetByUser(ctx, userId.(uuid.UUID), filter)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/notification.GetByUser: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToNotificationPageView(page))
+}
+
+// MarkReadHandler godoc
+// @Summary Прочтение уведомления
+// @Description Отметка уведомления текущего пользователя прочитанным
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор уведомления"
+// @Success 204 "Уведомление прочитано"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Уведомление не найдено"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /notifications/{id}/read [post]
+func (h *notificationHandlers) MarkRead(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	notificationId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.MarkRead(ctx, userId.(uuid.UUID), notificationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("notification not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/notification.MarkRead: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MarkAllReadHandler godoc
+// @Summary Прочтение всех уведомлений
+// @Description Отметка всех непрочитанных уведомлений текущего пользователя прочитанными
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} view.NotificationsReadView "Количество отмеченных уведомлений"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /notifications/read-all [post]
+func (h *notificationHandlers) MarkAllRead(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	read, err := h.interactor.MarkAllRead(ctx, userId.(uuid.UUID))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/notification.MarkAllRead: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, &view.NotificationsReadView{Read: read})
+}
+
+// GetSettingsHandler godoc
+// @Summary Настройки уведомлений
+// @Description Получение адреса для email-канала и каналов доставки по всем типам уведомлений
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} view.NotificationSettingsView "Настройки уведомлений"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /notifications/preferences [get]
+func (h *notificationHandlers) GetSettings(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	settings, err := h.interactor.GetSettings(ctx, userId.(uuid.UUID))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/notification.GetSettings: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToNotificationSettingsView(settings))
+}
+
+// SetSettingsHandler godoc
+// @Summary Изменение настроек уведомлений
+// @Description Сохранение адреса для email-канала и каналов доставки переданных типов уведомлений. Настройки непереданных типов не меняются, пустой адрес отключает email.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body entity.NotificationSettings true "Настройки уведомлений"
+// @Success 200 {object} view.NotificationSettingsView "Сохраненные настройки"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Некорректный адрес или тип уведомления"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /notifications/preferences [put]
+func (h *notificationHandlers) SetSettings(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var settings entity.NotificationSettings
+	err = json.Unmarshal(body, &settings)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	saved, err := h.interactor.SetSettings(ctx, userId.(uuid.UUID), &settings)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidNotificationSettings) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/notification.SetSettings: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToNotificationSettingsView(saved))
+}
+
+// parseNotificationFilter читает параметры выдачи уведомлений
+func parseNotificationFilter(c *gin.Context) (*entity.NotificationFilter, error) {
+	limit, err := parseIntQuery(c, "limit", entity.DefaultNotificationLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &entity.NotificationFilter{Limit: limit}
+
+	if value := c.Query("unread"); value != "" {
+		filter.UnreadOnly, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unread: %w", err)
+		}
+	}
+
+	if value := c.Query("cursor"); value != "" {
+		filter.Cursor, err = entity.DecodeNotificationCursor(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type playHandlers struct {
+	interactor usecase.PlayInteractor
+	presenter  presenter.Presenter
+}
+
+func NewPlayHandlers(interactor usecase.PlayInteractor, presenter presenter.Presenter) *playHandlers {
+	return &playHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// RecordHandler godoc
+// @Summary Отправка события прослушивания
+// @Description Сохранение события начала, прогресса или завершения прослушивания трека. Повторная отправка события с тем же client_event_id игнорируется.
+// @Tags Plays
+// @Accept json
+// @Produce plain
+// @Security JwtAuth
+// @Param request body entity.PlayEventCreate true "Событие прослушивания"
+// @Success 201 "Событие сохранено"
+// @Success 200 "Событие уже было сохранено ранее"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Трек не найден"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /plays [post]
+func (h *playHandlers) Record(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var event entity.PlayEventCreate
+	err = json.Unmarshal(body, &event)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	created, err := h.interactor.Record(ctx, userId.(uuid.UUID), &event)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidPlayEvent) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		if errors.Is(err, entity.ErrUnknownPlayMusic) {
+			c.AbortWithError(http.StatusNotFound, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/play.Record: %w", err))
+		return
+	}
+
+	if !created {
+		c.Status(http.StatusOK)
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+// RecordBatchHandler godoc
+// @Summary Пакетная отправка событий прослушивания
+// @Description Сохранение пакета событий, накопленных клиентом в офлайне. Время событий берется из played_at, дубликаты по client_event_id отбрасываются. События по неизвестным трекам пропускаются, их id возвращаются в unknown_music_ids.
+// @Tags Plays
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body entity.PlayEventBatch true "Пакет событий прослушивания"
+// @Success 200 {object} view.PlayBatchResultView "Результат обработки пакета"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /plays/batch [post]
+func (h *playHandlers) RecordBatch(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var batch entity.PlayEventBatch
+	err = json.Unmarshal(body, &batch)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	result, err := h.interactor.RecordBatch(ctx, userId.(uuid.UUID), batch.Events)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidPlayEvent) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/play.RecordBatch: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToPlayBatchResultView(result))
+}
+
+// GetHistoryHandler godoc
+// @Summary История прослушиваний
+// @Description Получение истории прослушиваний текущего пользователя, от новых к старым
+// @Tags Plays
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param from query string false "Начало периода (RFC3339 или YYYY-MM-DD)"
+// @Param to query string false "Конец периода (RFC3339 или YYYY-MM-DD)"
+// @Param limit query int false "Количество записей (по умолчанию 50, максимум 500)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.PlayEventView "История прослушиваний"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/history [get]
+func (h *playHandlers) GetHistory(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	filter, err := parsePlayHistoryFilter(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	events, err := h.interactor.GetHistory(ctx, userId.(uuid.UUID), filter)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/play.GetHistory: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListPlayEventView(events))
+}
+
+// DeleteHistoryHandler godoc
+// @Summary Очистка истории прослушиваний
+// @Description Удаление истории прослушиваний текущего пользователя за период. Без параметров удаляется вся история.
+// @Tags Plays
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param from query string false "Начало периода (RFC3339 или YYYY-MM-DD)"
+// @Param to query string false "Конец периода (RFC3339 или YYYY-MM-DD)"
+// @Success 200 {object} view.DeletedView "Количество удаленных записей"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/history [delete]
+func (h *playHandlers) DeleteHistory(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	filter, err := parsePlayHistoryFilter(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	deleted, err := h.interactor.DeleteHistory(ctx, userId.(uuid.UUID), filter)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/play.DeleteHistory: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, &view.DeletedView{Deleted: deleted})
+}
+
+// DeleteHistoryEventHandler godoc
+// @Summary Удаление записи из истории прослушиваний
+// @Description Удаление одного события из истории прослушиваний текущего пользователя
+// @Tags Plays
+// @Accept json
+// @Produce plain
+// @Security JwtAuth
+// @Param id path string true "id события"
+// @Success 204 "Запись удалена"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Запись не найдена"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/history/{id} [delete]
+func (h *playHandlers) DeleteHistoryEvent(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	eventId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.DeleteHistoryEvent(ctx, userId.(uuid.UUID), eventId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/play.DeleteHistoryEvent: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parsePlayHistoryFilter(c *gin.Context) (*entity.PlayHistoryFilter, error) {
+	var filter entity.PlayHistoryFilter
+	var err error
+
+	filter.From, err = parseTimeQuery(c, "from")
+	if err != nil {
+		return nil, err
+	}
+	filter.To, err = parseTimeQuery(c, "to")
+	if err != nil {
+		return nil, err
+	}
+	filter.Limit, err = parseIntQuery(c, "limit", entity.DefaultPlayHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	filter.Offset, err = parseIntQuery(c, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filter, nil
+}
@@ -0,0 +1,406 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type playlistHandlers struct {
+	interactor usecase.PlaylistInteractor
+	presenter  presenter.Presenter
+}
+
+func NewPlaylistHandlers(interactor usecase.PlaylistInteractor, presenter presenter.Presenter) *playlistHandlers {
+	return &playlistHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// CreateHandler godoc
+// @Summary Создание плейлиста
+// @Description Создание плейлиста текущего пользователя. Изменения публичного плейлиста попадают в ленту подписчиков.
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body entity.PlaylistCreate true "Плейлист"
+// @Success 201 {object} view.PlaylistView "Созданный плейлист"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Некорректный плейлист"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /playlists [post]
+func (h *playlistHandlers) Create(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var playlist entity.PlaylistCreate
+	err = json.Unmarshal(body, &playlist)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	created, err := h.interactor.Create(ctx, userId.(uuid.UUID), &playlist)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidPlaylist) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/playlist.Create: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.presenter.ToPlaylistView(created))
+}
+
+// GetHandler godoc
+// @Summary Плейлист
+// @Description Получение публичного плейлиста или плейлиста текущего пользователя
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Success 200 {object} view.PlaylistView "Плейлист"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Плейлист не найден"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /playlists/{id} [get]
+func (h *playlistHandlers) Get(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	playlist, err := h.interactor.Get(ctx, userId.(uuid.UUID), playlistId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("playlist not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/playlist.Get: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToPlaylistView(playlist))
+}
+
+// GetMineHandler godoc
+// @Summary Плейлисты текущего пользователя
+// @Description Получение плейлистов текущего пользователя, начиная с последних измененных
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param limit query int false "Количество плейлистов (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.PlaylistView "Плейлисты"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Некорректные параметры"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/playlists [get]
+func (h *playlistHandlers) GetMine(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultPlaylistLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	playlists, err := h.interactor.GetByUser(ctx, userId.(uuid.UUID), limit, offset)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/playlist.GetByUser: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListPlaylistView(playlists))
+}
+
+// UpdateHandler godoc
+// @Summary Изменение плейлиста
+// @Description Изменение названия и видимости плейлиста его владельцем
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Param request body entity.PlaylistCreate true "Новые название и видимость"
+// @Success 200 {object} view.PlaylistView "Измененный плейлист"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Плейлист принадлежит другому пользователю"
+// @Failure 404 "Плейлист не найден"
+// @Failure 422 "Некорректный плейлист"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /playlists/{id} [put]
+func (h *playlistHandlers) Update(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var playlist entity.PlaylistCreate
+	err = json.Unmarshal(body, &playlist)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	updated, err := h.interactor.Update(ctx, userId.(uuid.UUID), playlistId, &playlist)
+	if err != nil {
+		h.abortWithPlaylistError(c, err, "/usecase/playlist.Update")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToPlaylistView(updated))
+}
+
+// DeleteHandler godoc
+// @Summary Удаление плейлиста
+// @Description Удаление плейлиста его владельцем
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Success 204 "Плейлист удален"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Плейлист принадлежит другому пользователю"
+// @Failure 404 "Плейлист не найден"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /playlists/{id} [delete]
+func (h *playlistHandlers) Delete(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.Delete(ctx, userId.(uuid.UUID), playlistId)
+	if err != nil {
+		h.abortWithPlaylistError(c, err, "/usecase/playlist.Delete")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetTracksHandler godoc
+// @Summary Треки плейлиста
+// @Description Получение треков плейлиста в порядке добавления. Недоступные треки и треки, скрытые фильтром контента, не показываются.
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Success 200 {object} []view.MusicView "Треки плейлиста"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Плейлист не найден"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /playlists/{id}/tracks [get]
+func (h *playlistHandlers) GetTracks(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	music, err := h.interactor.GetTracks(ctx, userId.(uuid.UUID), playlistId)
+	if err != nil {
+		h.abortWithPlaylistError(c, err, "/usecase/playlist.GetTracks")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListMusicView(music))
+}
+
+// AddTrackHandler godoc
+// @Summary Добавление трека в плейлист
+// @Description Добавление трека в конец плейлиста его владельцем. Повторное добавление ничего не меняет.
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Param request body entity.PlaylistTrack true "Трек"
+// @Success 204 "Трек добавлен"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Плейлист принадлежит другому пользователю"
+// @Failure 404 "Плейлист или трек не найден"
+// @Failure 422 "Некорректные данные"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /playlists/{id}/tracks [post]
+func (h *playlistHandlers) AddTrack(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var track entity.PlaylistTrack
+	err = json.Unmarshal(body, &track)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	err = h.interactor.AddTrack(ctx, userId.(uuid.UUID), playlistId, track.MusicID)
+	if err != nil {
+		h.abortWithPlaylistError(c, err, "/usecase/playlist.AddTrack")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveTrackHandler godoc
+// @Summary Удаление трека из плейлиста
+// @Description Удаление трека из плейлиста его владельцем
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Param music_id path string true "Идентификатор трека"
+// @Success 204 "Трек удален из плейлиста"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Плейлист принадлежит другому пользователю"
+// @Failure 404 "Плейлист не найден или трека нет в плейлисте"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /playlists/{id}/tracks/{music_id} [delete]
+func (h *playlistHandlers) RemoveTrack(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	musicId, err := uuid.Parse(c.Param("music_id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse music id: %w", err))
+		return
+	}
+
+	err = h.interactor.RemoveTrack(ctx, userId.(uuid.UUID), playlistId, musicId)
+	if err != nil {
+		h.abortWithPlaylistError(c, err, "/usecase/playlist.RemoveTrack")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *playlistHandlers) abortWithPlaylistError(c *gin.Context, err error, method string) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidPlaylist):
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+	case errors.Is(err, entity.ErrPlaylistForbidden):
+		c.AbortWithError(http.StatusForbidden, err)
+	case errors.Is(err, sql.ErrNoRows):
+		c.AbortWithError(http.StatusNotFound, fmt.Errorf("playlist not found: %w", err))
+	default:
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("%s: %w", method, err))
+	}
+}
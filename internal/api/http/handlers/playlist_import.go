@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type playlistImportHandlers struct {
+	interactor usecase.PlaylistImportInteractor
+	presenter  presenter.Presenter
+}
+
+func NewPlaylistImportHandlers(interactor usecase.PlaylistImportInteractor, presenter presenter.Presenter) *playlistImportHandlers {
+	return &playlistImportHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// ImportHandler godoc
+// @Summary Импорт плейлиста
+// @Description Создание плейлиста текущего пользователя из файла M3U/M3U8 (с #EXTINF), XSPF или CSV. Записи сопоставляются с каталогом сначала по id трека из ссылки или идентификатора, затем по названию и исполнителю без учета регистра, знаков препинания и уточнений в скобках, с допуском по продолжительности. В плейлист попадают сопоставленные треки в порядке файла, повторы пропускаются. Неоднозначные записи возвращаются с подходящими треками, несопоставленные — с треками того же названия, чтобы пользователь добавил их вручную.
+// @Tags Playlists
+// @Accept mpfd
+// @Produce json
+// @Security JwtAuth
+// @Param file formData file true "Файл плейлиста"
+// @Param format formData string false "Формат файла, по умолчанию определяется по расширению" Enums(m3u, m3u8, xspf, csv)
+// @Param name formData string false "Название плейлиста, по умолчанию из файла или по имени файла"
+// @Param is_public formData bool false "Плейлист виден другим пользователям"
+// @Success 201 {object} view.PlaylistImportReportView "Плейлист создан"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 413 "Файл слишком большой"
+// @Failure 422 "Некорректный файл или параметры"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /playlists/import [post]
+func (h *playlistImportHandlers) Import(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	err := c.Request.ParseMultipartForm(64)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read form data: %w", err))
+		return
+	}
+
+	file, fileHeader, err := c.Request.FormFile("file")
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read file: %w", err))
+		return
+	}
+	defer file.Close()
+	if fileHeader.Size > entity.MaxImportFileSize {
+		c.AbortWithError(http.StatusRequestEntityTooLarge, fmt.Errorf("file is larger than %d bytes", entity.MaxImportFileSize))
+		return
+	}
+
+	request := &entity.PlaylistImport{
+		FileName: fileHeader.Filename,
+		Name:     c.Request.FormValue("name"),
+	}
+	request.Format, err = entity.DetectImportFormat(c.Request.FormValue("format"), fileHeader.Filename)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+	if value := c.Request.FormValue("is_public"); value != "" {
+		request.IsPublic, err = strconv.ParseBool(value)
+		if err != nil {
+			c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("invalid is_public: %w", err))
+			return
+		}
+	}
+
+	report, err := h.interactor.Import(ctx, userId.(uuid.UUID), request, file)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidImport) || errors.Is(err, entity.ErrInvalidPlaylist) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/playlist_import.Import: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.presenter.ToPlaylistImportReportView(report))
+}
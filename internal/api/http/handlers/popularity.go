@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type popularityHandlers struct {
+	interactor usecase.PopularityInteractor
+	presenter  presenter.Presenter
+}
+
+func NewPopularityHandlers(interactor usecase.PopularityInteractor, presenter presenter.Presenter) *popularityHandlers {
+	return &popularityHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// GetPopularHandler godoc
+// @Summary Получение треков отсортированных по популярности
+// @Description Получение треков отсортированных по популярности за окно времени. Рейтинг учитывает прослушивания и лайки с настраиваемыми весами и пересчитывается в фоне. Треки с ненормативным контентом скрываются, если пользователь включил фильтр контента.
+// @Tags Music
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param window query string false "Окно: day, week, month, all (по умолчанию all)"
+// @Param limit query int false "Количество треков (по умолчанию 50, максимум 500)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.PopularMusicView "Список треков"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Неизвестное окно"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/popular [get]
+func (h *popularityHandlers) GetPopular(c *gin.Context) {
+	ctx := context.Background()
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultChartLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	musics, err := h.interactor.GetPopular(ctx, c.Query("window"), limit, offset, c.GetBool("hide-explicit"))
+	if err != nil {
+		if errors.Is(err, entity.ErrUnknownPopularityWindow) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/popularity.GetPopular: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListPopularMusicView(musics))
+}
+
+// GetTrendingHandler godoc
+// @Summary Получение трендовых треков
+// @Description Получение треков, отсортированных по рейтингу с экспоненциальным затуханием: недавние прослушивания и лайки весят больше старых. Треки с ненормативным контентом скрываются, если пользователь включил фильтр контента.
+// @Tags Music
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param limit query int false "Количество треков (по умолчанию 50, максимум 500)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.PopularMusicView "Список треков"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/trending [get]
+func (h *popularityHandlers) GetTrending(c *gin.Context) {
+	ctx := context.Background()
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultChartLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	musics, err := h.interactor.GetTrending(ctx, limit, offset, c.GetBool("hide-explicit"))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/popularity.GetTrending: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListPopularMusicView(musics))
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseTimeQuery читает из query-параметра время в формате RFC3339 или дату 2006-01-02.
+// Пустой параметр возвращает нулевое время.
+func parseTimeQuery(c *gin.Context, key string) (time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return t, nil
+}
+
+// parseIntQuery читает из query-параметра целое число. Пустой параметр возвращает def.
+func parseIntQuery(c *gin.Context, key string, def int) (int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return n, nil
+}
+
+// parsePageQuery читает параметры пагинации limit и offset
+func parsePageQuery(c *gin.Context, defaultLimit int) (int, int, error) {
+	limit, err := parseIntQuery(c, "limit", defaultLimit)
+	if err != nil {
+		return 0, 0, err
+	}
+	offset, err := parseIntQuery(c, "offset", 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return limit, offset, nil
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ratingHandlers struct {
+	interactor usecase.RatingInteractor
+	presenter  presenter.Presenter
+}
+
+func NewRatingHandlers(interactor usecase.RatingInteractor, presenter presenter.Presenter) *ratingHandlers {
+	return &ratingHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// SetHandler godoc
+// @Summary Оценка трека
+// @Description Выставление или изменение оценки трека текущим пользователем по шкале от 1 до 5. Возвращает новую среднюю оценку трека.
+// @Tags Ratings
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param request body entity.RatingCreate true "Оценка"
+// @Success 200 {object} view.RatingView "Оценка и средняя оценка трека"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Трек не найден"
+// @Failure 422 "Некорректная оценка"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/rating [put]
+func (h *ratingHandlers) Set(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var rating entity.RatingCreate
+	err = json.Unmarshal(body, &rating)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	summary, err := h.interactor.Set(ctx, userId.(uuid.UUID), musicId, &rating)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidRating):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("music not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/rating.Set: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToRatingSummaryView(summary))
+}
+
+// ClearHandler godoc
+// @Summary Снятие оценки трека
+// @Description Снятие оценки трека текущим пользователем. Возвращает новую среднюю оценку трека.
+// @Tags Ratings
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Success 200 {object} view.RatingView "Средняя оценка трека"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Оценка не найдена"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/rating [delete]
+func (h *ratingHandlers) Clear(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	summary, err := h.interactor.Clear(ctx, userId.(uuid.UUID), musicId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("rating not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/rating.Clear: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToRatingSummaryView(summary))
+}
+
+// GetLibraryHandler godoc
+// @Summary Библиотека пользователя
+// @Description Получение лайкнутых и оцененных текущим пользователем треков вместе с его оценками
+// @Tags Ratings
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param sort query string false "Сортировка: recent (по умолчанию), rating, name"
+// @Success 200 {object} []view.LibraryMusicView "Список треков"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Неизвестная сортировка"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/library [get]
+func (h *ratingHandlers) GetLibrary(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	musics, err := h.interactor.GetLibrary(ctx, userId.(uuid.UUID), c.Query("sort"))
+	if err != nil {
+		if errors.Is(err, entity.ErrUnknownLibrarySort) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/rating.GetLibrary: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListLibraryMusicView(musics))
+}
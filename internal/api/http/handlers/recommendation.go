@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type recommendationHandlers struct {
+	interactor usecase.RecommendationInteractor
+	presenter  presenter.Presenter
+}
+
+func NewRecommendationHandlers(interactor usecase.RecommendationInteractor, presenter presenter.Presenter) *recommendationHandlers {
+	return &recommendationHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// GetForUserHandler godoc
+// @Summary Персональные рекомендации
+// @Description Получение треков, похожих на лайкнутые текущим пользователем. Для каждого трека указывается лайкнутый трек, на основе которого сделана рекомендация. Уже лайкнутые треки исключаются.
+// @Tags Recommendations
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param limit query int false "Количество треков (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.RecommendationView "Список рекомендаций"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/recommendations [get]
+func (h *recommendationHandlers) GetForUser(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultRecommendationLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	musics, err := h.interactor.GetForUser(ctx, userId.(uuid.UUID), limit, offset)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/recommendation.GetForUser: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListRecommendationView(musics))
+}
+
+// GetSimilarHandler godoc
+// @Summary Похожие треки
+// @Description Получение треков, которые чаще всего лайкают вместе с заданным. Треки, уже лайкнутые текущим пользователем, исключаются.
+// @Tags Recommendations
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param limit query int false "Количество треков (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.SimilarMusicView "Список похожих треков"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/similar [get]
+func (h *recommendationHandlers) GetSimilar(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultRecommendationLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	musics, err := h.interactor.GetSimilar(ctx, userId.(uuid.UUID), musicId, limit, offset)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/recommendation.GetSimilar: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListSimilarMusicView(musics))
+}
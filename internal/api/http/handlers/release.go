@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type releaseHandlers struct {
+	interactor usecase.ReleaseInteractor
+	presenter  presenter.Presenter
+}
+
+func NewReleaseHandlers(interactor usecase.ReleaseInteractor, presenter presenter.Presenter) *releaseHandlers {
+	return &releaseHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// GetScheduledHandler godoc
+// @Summary Запланированные релизы
+// @Description Получение треков, ожидающих публикации, и опубликованных треков с запланированным снятием, в порядке времени публикации
+// @Tags Releases
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param limit query int false "Количество треков (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.ScheduledMusicView "Запланированные релизы"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 422 "Некорректные параметры"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/scheduled [get]
+func (h *releaseHandlers) GetScheduled(c *gin.Context) {
+	ctx := context.Background()
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultScheduledMusicLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	music, err := h.interactor.GetScheduled(ctx, limit, offset)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/release.GetScheduled: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListScheduledMusicView(music))
+}
+
+// SetScheduleHandler godoc
+// @Summary Расписание публикации трека
+// @Description Изменение времени публикации и снятия трека с публикации. Перенос публикации в будущее скрывает трек от пользователей до нового времени публикации.
+// @Tags Releases
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param request body entity.MusicSchedule true "Расписание публикации"
+// @Success 200 {object} view.ScheduledMusicView "Трек с новым расписанием"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 404 "Трек не найден"
+// @Failure 422 "Некорректное расписание"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/schedule [put]
+func (h *releaseHandlers) SetSchedule(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var schedule entity.MusicSchedule
+	err = json.Unmarshal(body, &schedule)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	music, err := h.interactor.SetSchedule(ctx, musicId, &schedule)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidMusicSchedule):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("music not found: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/release.SetSchedule: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToScheduledMusicView(music))
+}
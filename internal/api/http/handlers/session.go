@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	_ "music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type sessionHandlers struct {
+	interactor usecase.SessionInteractor
+	presenter  presenter.Presenter
+}
+
+func NewSessionHandlers(interactor usecase.SessionInteractor, presenter presenter.Presenter) *sessionHandlers {
+	return &sessionHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// GetMine godoc
+// @Summary Сессии текущего пользователя
+// @Description Получение действующих сессий текущего пользователя с устройством, IP и временем последней активности. Сессия, в которой выполнен запрос, помечена полем current.
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} []view.SessionView "Сессии"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/sessions [get]
+func (h *sessionHandlers) GetMine(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := h.interactor.GetByUser(ctx, userId.(uuid.UUID))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/session.GetByUser: %w", err))
+		return
+	}
+
+	currentId := uuid.Nil
+	if sessionId, exists := c.Get("session-id"); exists {
+		currentId = sessionId.(uuid.UUID)
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListSessionView(sessions, currentId))
+}
+
+// RevokeMine godoc
+// @Summary Отзыв сессии
+// @Description Отзыв сессии текущего пользователя: ее refresh-токены больше не обмениваются, access-токены перестают приниматься.
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "ID сессии"
+// @Success 204 "Сессия отозвана"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Сессия не найдена"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/sessions/{id} [delete]
+func (h *sessionHandlers) RevokeMine(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	sessionId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.Revoke(ctx, userId.(uuid.UUID), sessionId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/session.Revoke: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeAll godoc
+// @Summary Отзыв всех сессий пользователя
+// @Description Отзыв всех сессий пользователя администратором
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "ID пользователя"
+// @Success 204 "Сессии отозваны"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/{id}/sessions [delete]
+func (h *sessionHandlers) RevokeAll(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.RevokeAll(ctx, userId)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/session.RevokeAll: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type shareHandlers struct {
+	interactor usecase.ShareInteractor
+	presenter  presenter.Presenter
+}
+
+func NewShareHandlers(interactor usecase.ShareInteractor, presenter presenter.Presenter) *shareHandlers {
+	return &shareHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// CreateForMusicHandler godoc
+// @Summary Ссылка на трек
+// @Description Создание ссылки, по которой трек можно послушать без аккаунта. Токен возвращается только в этом ответе.
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Param request body entity.ShareLinkCreate true "Срок действия и лимит прослушиваний"
+// @Success 201 {object} view.ShareLinkCreatedView "Созданная ссылка"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Трек не найден"
+// @Failure 422 "Некорректные параметры ссылки"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /music/{id}/shares [post]
+func (h *shareHandlers) CreateForMusic(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	share, err := parseShareLinkCreate(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	created, err := h.interactor.CreateForMusic(ctx, userId.(uuid.UUID), musicId, share)
+	if err != nil {
+		h.abortWithShareError(c, err, "/usecase/share.CreateForMusic")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.presenter.ToShareLinkCreatedView(created))
+}
+
+// CreateForPlaylistHandler godoc
+// @Summary Ссылка на плейлист
+// @Description Создание ссылки, по которой плейлист можно послушать без аккаунта. Делиться плейлистом может только владелец. Токен возвращается только в этом ответе.
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Param request body entity.ShareLinkCreate true "Срок действия и лимит прослушиваний"
+// @Success 201 {object} view.ShareLinkCreatedView "Созданная ссылка"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Плейлист принадлежит другому пользователю"
+// @Failure 404 "Плейлист не найден"
+// @Failure 422 "Некорректные параметры ссылки"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /playlists/{id}/shares [post]
+func (h *shareHandlers) CreateForPlaylist(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	share, err := parseShareLinkCreate(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	created, err := h.interactor.CreateForPlaylist(ctx, userId.(uuid.UUID), playlistId, share)
+	if err != nil {
+		h.abortWithShareError(c, err, "/usecase/share.CreateForPlaylist")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.presenter.ToShareLinkCreatedView(created))
+}
+
+// GetMineHandler godoc
+// @Summary Ссылки текущего пользователя
+// @Description Получение созданных пользователем ссылок, начиная с последних, вместе с отозванными и истекшими
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param limit query int false "Количество ссылок (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.ShareLinkView "Ссылки"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Некорректные параметры"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/shares [get]
+func (h *shareHandlers) GetMine(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultShareLinkLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	links, err := h.interactor.GetByUser(ctx, userId.(uuid.UUID), limit, offset)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/share.GetByUser: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListShareLinkView(links))
+}
+
+// RevokeHandler godoc
+// @Summary Отзыв ссылки
+// @Description Отзыв ссылки ее владельцем. Отозванная ссылка перестает открываться сразу.
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор ссылки"
+// @Success 204 "Ссылка отозвана"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Ссылка не найдена"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /shares/{id} [delete]
+func (h *shareHandlers) Revoke(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	linkId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.Revoke(ctx, userId.(uuid.UUID), linkId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("share link not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/share.Revoke: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSharedHandler godoc
+// @Summary Содержимое ссылки
+// @Description Трек или плейлист, открытый по ссылке. Не требует авторизации и не расходует лимит прослушиваний.
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param token path string true "Токен ссылки"
+// @Success 200 {object} view.SharedContentView "Трек или плейлист"
+// @Failure 400 "Некорректный запрос"
+// @Failure 404 "Ссылка или трек не найдены"
+// @Failure 410 "Ссылка отозвана, истекла или исчерпала лимит прослушиваний"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /shared/{token} [get]
+func (h *shareHandlers) GetShared(c *gin.Context) {
+	ctx := context.Background()
+
+	link, exists := c.Get("share-link")
+	if !exists {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	content, err := h.interactor.GetContent(ctx, link.(*entity.ShareLinkDB))
+	if err != nil {
+		h.abortWithShareError(c, err, "/usecase/share.GetContent")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToSharedContentView(content))
+}
+
+// StreamSharedHandler godoc
+// @Summary Прослушивание трека по ссылке
+// @Description Файл трека, открытого по ссылке. Не требует авторизации. Каждое скачивание расходует одно прослушивание из лимита ссылки.
+// @Tags Shares
+// @Produce json
+// @Param token path string true "Токен ссылки"
+// @Success 200 {file} file "Файл трека"
+// @Failure 400 "Некорректный запрос"
+// @Failure 404 "Ссылка или трек не найдены"
+// @Failure 410 "Ссылка отозвана, истекла или исчерпала лимит прослушиваний"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /shared/{token}/stream [get]
+func (h *shareHandlers) StreamShared(c *gin.Context) {
+	h.stream(c, nil)
+}
+
+// StreamSharedTrackHandler godoc
+// @Summary Прослушивание трека плейлиста по ссылке
+// @Description Файл трека из плейлиста, открытого по ссылке. Не требует авторизации. Каждое скачивание расходует одно прослушивание из лимита ссылки.
+// @Tags Shares
+// @Produce json
+// @Param token path string true "Токен ссылки"
+// @Param id path string true "Идентификатор трека из плейлиста"
+// @Success 200 {file} file "Файл трека"
+// @Failure 400 "Некорректный запрос"
+// @Failure 404 "Ссылка не найдена или трека нет в плейлисте"
+// @Failure 410 "Ссылка отозвана, истекла или исчерпала лимит прослушиваний"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /shared/{token}/stream/{id} [get]
+func (h *shareHandlers) StreamSharedTrack(c *gin.Context) {
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	h.stream(c, &musicId)
+}
+
+func (h *shareHandlers) stream(c *gin.Context, musicId *uuid.UUID) {
+	ctx := context.Background()
+
+	link, exists := c.Get("share-link")
+	if !exists {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	music, err := h.interactor.Stream(ctx, link.(*entity.ShareLinkDB), musicId)
+	if err != nil {
+		h.abortWithShareError(c, err, "/usecase/share.Stream")
+		return
+	}
+
+	// Отданный трек попадает в журнал обращений
+	c.Set("share-music-id", music.Id)
+	c.FileAttachment(music.FilePath(), music.FileName)
+}
+
+func (h *shareHandlers) abortWithShareError(c *gin.Context, err error, method string) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidShareLink):
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+	case errors.Is(err, entity.ErrShareLinkUnavailable):
+		c.AbortWithError(http.StatusGone, err)
+	case errors.Is(err, entity.ErrPlaylistForbidden):
+		c.AbortWithError(http.StatusForbidden, err)
+	case errors.Is(err, sql.ErrNoRows):
+		c.AbortWithError(http.StatusNotFound, fmt.Errorf("not found: %w", err))
+	default:
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("%s: %w", method, err))
+	}
+}
+
+// parseShareLinkCreate читает параметры ссылки. Пустое тело создает бессрочную ссылку без лимита.
+func parseShareLinkCreate(c *gin.Context) (*entity.ShareLinkCreate, error) {
+	body, err := c.GetRawData()
+	if err != nil {
+		return nil, fmt.Errorf("can't read body: %w", err)
+	}
+
+	var share entity.ShareLinkCreate
+	if len(body) == 0 {
+		return &share, nil
+	}
+	if err := json.Unmarshal(body, &share); err != nil {
+		return nil, fmt.Errorf("can't unmarshal body: %w", err)
+	}
+
+	return &share, nil
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	_ "music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type signingKeyHandlers struct {
+	interactor usecase.SigningKeyInteractor
+	presenter  presenter.Presenter
+}
+
+func NewSigningKeyHandlers(interactor usecase.SigningKeyInteractor, presenter presenter.Presenter) *signingKeyHandlers {
+	return &signingKeyHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// GetJWKS godoc
+// @Summary Открытые ключи подписи токенов
+// @Description Получение открытых ключей (JWKS), которыми проверяются access-токены. Ключ токена выбирается по заголовку kid. Следующий ключ публикуется заранее, а предыдущий остается в наборе, пока подписанные им токены действуют.
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} view.JWKSView "Открытые ключи"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /.well-known/jwks.json [get]
+func (h *signingKeyHandlers) GetJWKS(c *gin.Context) {
+	ctx := context.Background()
+
+	keys, err := h.interactor.GetKeys(ctx)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/signing_key.GetKeys: %w", err))
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(entity.JWKSMaxAge.Seconds())))
+	c.JSON(http.StatusOK, h.presenter.ToJWKSView(keys))
+}
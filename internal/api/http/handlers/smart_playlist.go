@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type smartPlaylistHandlers struct {
+	interactor usecase.SmartPlaylistInteractor
+	presenter  presenter.Presenter
+}
+
+func NewSmartPlaylistHandlers(interactor usecase.SmartPlaylistInteractor, presenter presenter.Presenter) *smartPlaylistHandlers {
+	return &smartPlaylistHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// CreateHandler godoc
+// @Summary Создание умного плейлиста
+// @Description Создание плейлиста, состав которого вычисляется по правилам при каждом чтении. Правила — дерево групп and/or и условий field/operator/value. Поля: name, artist (eq, neq, contains, not_contains, starts_with); duration в секундах, rating_avg, rating_count, my_rating, play_count (eq, neq, lt, lte, gt, gte); release_date, published_at, last_played (eq, neq, lt, lte, gt, gte с датой 2006-01-02, in_last_days с количеством дней, in_current_year без значения); explicit, liked (eq, neq с true/false). Поля liked, my_rating, play_count и last_played вычисляются для читающего пользователя. Сортировка — по любому полю кроме explicit и liked или random.
+// @Tags Smart playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param request body entity.SmartPlaylistCreate true "Умный плейлист"
+// @Success 201 {object} view.SmartPlaylistView "Созданный плейлист"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Некорректный плейлист или правила"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /smart-playlists [post]
+func (h *smartPlaylistHandlers) Create(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var playlist entity.SmartPlaylistCreate
+	err = json.Unmarshal(body, &playlist)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	created, err := h.interactor.Create(ctx, userId.(uuid.UUID), &playlist)
+	if err != nil {
+		h.abortWithSmartPlaylistError(c, err, "/usecase/smart_playlist.Create")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.presenter.ToSmartPlaylistView(created))
+}
+
+// GetHandler godoc
+// @Summary Умный плейлист
+// @Description Получение правил публичного умного плейлиста или умного плейлиста текущего пользователя
+// @Tags Smart playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Success 200 {object} view.SmartPlaylistView "Плейлист"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Плейлист не найден"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /smart-playlists/{id} [get]
+func (h *smartPlaylistHandlers) Get(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	playlist, err := h.interactor.Get(ctx, userId.(uuid.UUID), playlistId)
+	if err != nil {
+		h.abortWithSmartPlaylistError(c, err, "/usecase/smart_playlist.Get")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToSmartPlaylistView(playlist))
+}
+
+// GetMineHandler godoc
+// @Summary Умные плейлисты текущего пользователя
+// @Description Получение умных плейлистов текущего пользователя, начиная с последних измененных
+// @Tags Smart playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param limit query int false "Количество плейлистов (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.SmartPlaylistView "Плейлисты"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Некорректные параметры"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/smart-playlists [get]
+func (h *smartPlaylistHandlers) GetMine(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultPlaylistLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	playlists, err := h.interactor.GetByUser(ctx, userId.(uuid.UUID), limit, offset)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/smart_playlist.GetByUser: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListSmartPlaylistView(playlists))
+}
+
+// UpdateHandler godoc
+// @Summary Изменение умного плейлиста
+// @Description Замена названия, видимости, правил и сортировки умного плейлиста его владельцем
+// @Tags Smart playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Param request body entity.SmartPlaylistCreate true "Новый плейлист"
+// @Success 200 {object} view.SmartPlaylistView "Измененный плейлист"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Плейлист принадлежит другому пользователю"
+// @Failure 404 "Плейлист не найден"
+// @Failure 422 "Некорректный плейлист или правила"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /smart-playlists/{id} [put]
+func (h *smartPlaylistHandlers) Update(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var playlist entity.SmartPlaylistCreate
+	err = json.Unmarshal(body, &playlist)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	updated, err := h.interactor.Update(ctx, userId.(uuid.UUID), playlistId, &playlist)
+	if err != nil {
+		h.abortWithSmartPlaylistError(c, err, "/usecase/smart_playlist.Update")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToSmartPlaylistView(updated))
+}
+
+// DeleteHandler godoc
+// @Summary Удаление умного плейлиста
+// @Description Удаление умного плейлиста его владельцем
+// @Tags Smart playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Success 204 "Плейлист удален"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Плейлист принадлежит другому пользователю"
+// @Failure 404 "Плейлист не найден"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /smart-playlists/{id} [delete]
+func (h *smartPlaylistHandlers) Delete(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.Delete(ctx, userId.(uuid.UUID), playlistId)
+	if err != nil {
+		h.abortWithSmartPlaylistError(c, err, "/usecase/smart_playlist.Delete")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetTracksHandler godoc
+// @Summary Треки умного плейлиста
+// @Description Вычисление умного плейлиста на текущий момент для текущего пользователя с сортировкой и ограничением количества из правил. Недоступные треки и треки, скрытые фильтром контента, не попадают в плейлист.
+// @Tags Smart playlists
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param id path string true "Идентификатор плейлиста"
+// @Success 200 {object} []view.MusicView "Треки плейлиста"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Плейлист не найден"
+// @Failure 422 "Некорректный id"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /smart-playlists/{id}/tracks [get]
+func (h *smartPlaylistHandlers) GetTracks(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	playlistId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	music, err := h.interactor.GetTracks(ctx, userId.(uuid.UUID), playlistId)
+	if err != nil {
+		h.abortWithSmartPlaylistError(c, err, "/usecase/smart_playlist.GetTracks")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListMusicView(music))
+}
+
+func (h *smartPlaylistHandlers) abortWithSmartPlaylistError(c *gin.Context, err error, method string) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidSmartPlaylist):
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+	case errors.Is(err, entity.ErrPlaylistForbidden):
+		c.AbortWithError(http.StatusForbidden, err)
+	case errors.Is(err, sql.ErrNoRows):
+		c.AbortWithError(http.StatusNotFound, fmt.Errorf("smart playlist not found: %w", err))
+	default:
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("%s: %w", method, err))
+	}
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type statsHandlers struct {
+	interactor usecase.StatsInteractor
+	presenter  presenter.Presenter
+}
+
+func NewStatsHandlers(interactor usecase.StatsInteractor, presenter presenter.Presenter) *statsHandlers {
+	return &statsHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// GetHandler godoc
+// @Summary Статистика прослушиваний
+// @Description Статистика текущего пользователя за период: топ треков и исполнителей, время прослушивания, самая длинная серия дней и распределение по часам. Дни и часы считаются по UTC.
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param from query string false "Начало периода в формате RFC3339 или 2006-01-02 (по умолчанию 30 дней до конца периода)"
+// @Param to query string false "Конец периода, не включая, в формате RFC3339 или 2006-01-02 (по умолчанию текущее время)"
+// @Param limit query int false "Количество треков и исполнителей в топах"
+// @Success 200 {object} view.ListeningStatsView "Статистика"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 422 "Некорректный период: from не раньше to или период длиннее 366 дней"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/stats [get]
+func (h *statsHandlers) Get(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	filter, err := parseStatsFilter(c)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	stats, err := h.interactor.GetStats(ctx, userId.(uuid.UUID), filter)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidStatsPeriod) {
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/stats.GetStats: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListeningStatsView(stats))
+}
+
+// GetYearReviewHandler godoc
+// @Summary Итоги года
+// @Description Итоги календарного года текущего пользователя. Итоги рассчитываются фоновой задачей после окончания года.
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param year path int true "Год"
+// @Success 200 {object} view.YearReviewView "Итоги года"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Итоги года еще не рассчитаны или прослушиваний не было"
+// @Failure 422 "Некорректный год"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/year-in-review/{year} [get]
+func (h *statsHandlers) GetYearReview(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse year: %w", err))
+		return
+	}
+
+	review, err := h.interactor.GetYearReview(ctx, userId.(uuid.UUID), year)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("year review not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/stats.GetYearReview: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToYearReviewView(review))
+}
+
+// parseStatsFilter читает период статистики и размер топов
+func parseStatsFilter(c *gin.Context) (*entity.StatsFilter, error) {
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		return nil, err
+	}
+	limit, err := parseIntQuery(c, "limit", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.StatsFilter{From: from, To: to, Limit: limit}, nil
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type syncHandlers struct {
+	interactor usecase.SyncInteractor
+	presenter  presenter.Presenter
+}
+
+func NewSyncHandlers(interactor usecase.SyncInteractor, presenter presenter.Presenter) *syncHandlers {
+	return &syncHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// SyncHandler godoc
+// @Summary Синхронизация библиотеки
+// @Description Изменения треков каталога, лайков и плейлистов текущего пользователя после токена since. Без токена возвращается полная синхронизация (full=true): клиент заменяет локальную копию, лайки и плейлисты приходят на первой странице, каталог — страницами. Удаленные и ставшие недоступными сущности возвращаются в списках deleted и removed. Пока has_more=true, следующую страницу нужно запросить сразу с новым токеном. Токен действует ограниченное время; на просроченный токен возвращается 410, после чего нужна полная синхронизация.
+// @Tags Sync
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param since query string false "Токен из предыдущего ответа"
+// @Success 200 {object} view.SyncView "Изменения"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 410 "Срок действия токена истек, нужна полная синхронизация"
+// @Failure 422 "Некорректный токен"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /sync [get]
+func (h *syncHandlers) Sync(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	delta, err := h.interactor.Sync(ctx, userId.(uuid.UUID), c.Query("since"))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidSyncToken):
+			c.AbortWithError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, entity.ErrSyncTokenExpired):
+			c.AbortWithError(http.StatusGone, err)
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/sync.Sync: %w", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToSyncView(delta))
+}
@@ -13,18 +13,27 @@ import (
 	"net/http/httptest"
 	reflect "reflect"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	gomock "github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 )
 
-func MustMadeString(token *entity.Token) string {
-	t, err := token.Token.SignedString([]byte("secret"))
-	if err != nil {
-		panic(err)
+var (
+	testTokenIssuedAt = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	testSessionId     = uuid.MustParse("0b7e3c1a-4a1f-4d5e-9c55-6f2f8c3e1d20")
+)
+
+// testTokens возвращает пару токенов пользователя id с фиксированным временем выдачи
+func testTokens(id uuid.UUID) *entity.TokenPair {
+	return &entity.TokenPair{
+		SessionID:        testSessionId,
+		Access:           "access-token-" + id.String(),
+		AccessExpiresAt:  testTokenIssuedAt.Add(15 * time.Minute),
+		Refresh:          "refresh-token",
+		RefreshExpiresAt: testTokenIssuedAt.Add(30 * 24 * time.Hour),
 	}
-	return t
 }
 
 func Test_authHandlers_SignUp(t *testing.T) {
@@ -55,22 +64,25 @@ func Test_authHandlers_SignUp(t *testing.T) {
 			},
 			setup: func(a args, f fields, c *gin.Context) {
 				userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
-				token := entity.GenerateToken(userId)
+				tokens := testTokens(userId)
 
 				tokenView := &view.TokenView{
-					Token: MustMadeString(token),
+					Token:        tokens.Access,
+					RefreshToken: tokens.Refresh,
 				}
 
 				f.interactor.EXPECT().GetByUsername(a.ctx, a.user.Username).Return(nil, nil)
 				f.interactor.EXPECT().Create(a.ctx, a.user).Return(userId, nil)
-				f.presenter.EXPECT().ToTokenView(token).Return(tokenView, nil)
+				f.interactor.EXPECT().IssueTokens(a.ctx, userId, gomock.Any()).Return(tokens, nil)
+				f.presenter.EXPECT().ToTokenView(tokens).Return(tokenView, nil)
 
 				body, _ := json.Marshal(a.user)
 				c.Request = httptest.NewRequest("POST", "/signup", bytes.NewBuffer(body))
 			},
 			expectedStatus: 201,
 			expectedBody: &view.TokenView{
-				Token: MustMadeString(entity.GenerateToken(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"))),
+				Token:        testTokens(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).Access,
+				RefreshToken: "refresh-token",
 			},
 		},
 		{
@@ -198,26 +210,29 @@ func Test_authHandlers_SignIn(t *testing.T) {
 					Password: "password",
 					Role:     "USER",
 				}
-				token := entity.GenerateToken(userDB.ID)
+				tokens := testTokens(userDB.ID)
 
 				tokenView := &view.TokenView{
-					Token: MustMadeString(token),
+					Token:        tokens.Access,
+					RefreshToken: tokens.Refresh,
 				}
 
 				// Исправленные вызовы методов моков
-				f.interactor.EXPECT().GetByUsername(gomock.Any(), a.user.Username).Return(userDB, nil)
-				f.presenter.EXPECT().ToTokenView(gomock.Any()).Return(tokenView, nil)
+				f.interactor.EXPECT().SignIn(gomock.Any(), a.user).Return(userDB, nil)
+				f.interactor.EXPECT().IssueTokens(gomock.Any(), userDB.ID, gomock.Any()).Return(tokens, nil)
+				f.presenter.EXPECT().ToTokenView(tokens).Return(tokenView, nil)
 
 				body, _ := json.Marshal(a.user)
 				c.Request = httptest.NewRequest("POST", "/signin", bytes.NewBuffer(body))
 			},
 			expectedStatus: 200,
 			expectedBody: &view.TokenView{
-				Token: MustMadeString(entity.GenerateToken(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"))),
+				Token:        testTokens(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).Access,
+				RefreshToken: "refresh-token",
 			},
 		},
 		{
-			name: "SignIn: 404",
+			name: "SignIn: 401 unknown user",
 			args: args{
 				ctx: context.Background(),
 				user: &entity.UserCreate{
@@ -226,12 +241,12 @@ func Test_authHandlers_SignIn(t *testing.T) {
 				},
 			},
 			setup: func(a args, f fields, c *gin.Context) {
-				f.interactor.EXPECT().GetByUsername(a.ctx, a.user.Username).Return(nil, nil)
+				f.interactor.EXPECT().SignIn(a.ctx, a.user).Return(nil, fmt.Errorf("/repository/user.Authenticate: %w", entity.ErrInvalidCredentials))
 
 				body, _ := json.Marshal(a.user)
 				c.Request = httptest.NewRequest("POST", "/signin", bytes.NewBuffer(body))
 			},
-			expectedStatus: 404,
+			expectedStatus: 401,
 			expectedBody:   nil,
 		},
 		{
@@ -244,14 +259,7 @@ func Test_authHandlers_SignIn(t *testing.T) {
 				},
 			},
 			setup: func(a args, f fields, c *gin.Context) {
-				userDB := &entity.UserDB{
-					ID:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-					Username: "John",
-					Password: "password",
-					Role:     "USER",
-				}
-
-				f.interactor.EXPECT().GetByUsername(a.ctx, a.user.Username).Return(userDB, nil)
+				f.interactor.EXPECT().SignIn(a.ctx, a.user).Return(nil, fmt.Errorf("/repository/user.Authenticate: %w", entity.ErrInvalidCredentials))
 
 				body, _ := json.Marshal(a.user)
 				c.Request = httptest.NewRequest("POST", "/signin", bytes.NewBuffer(body))
@@ -269,7 +277,7 @@ func Test_authHandlers_SignIn(t *testing.T) {
 				},
 			},
 			setup: func(a args, f fields, c *gin.Context) {
-				f.interactor.EXPECT().GetByUsername(a.ctx, a.user.Username).Return(nil, fmt.Errorf("can't get user"))
+				f.interactor.EXPECT().SignIn(a.ctx, a.user).Return(nil, fmt.Errorf("can't get user"))
 
 				body, _ := json.Marshal(a.user)
 				c.Request = httptest.NewRequest("POST", "/signin", bytes.NewBuffer(body))
@@ -327,3 +335,141 @@ func Test_authHandlers_SignIn(t *testing.T) {
 		})
 	}
 }
+
+func Test_authHandlers_Refresh(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockUserInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		body           string
+		setup          func(f fields)
+		expectedStatus int
+	}
+	ctx := context.Background()
+	tokens := testTokens(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"))
+
+	cases := []testCase{
+		{
+			name: "Refresh: 200",
+			body: `{"refresh_token":"old-token"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Refresh(ctx, "old-token", entity.NewSessionDevice("Pixel 8", "okhttp/4.12", "192.0.2.1")).Return(tokens, nil)
+				f.presenter.EXPECT().ToTokenView(tokens).Return(&view.TokenView{Token: tokens.Access, RefreshToken: tokens.Refresh}, nil)
+			},
+			expectedStatus: 200,
+		},
+		{
+			name: "Refresh: 401 on reused token",
+			body: `{"refresh_token":"rotated"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Refresh(ctx, "rotated", gomock.Any()).Return(nil, fmt.Errorf("/repository/user.RotateRefreshToken: %w", entity.ErrRefreshTokenReused))
+			},
+			expectedStatus: 401,
+		},
+		{
+			name: "Refresh: 401 on invalid token",
+			body: `{"refresh_token":"unknown"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Refresh(ctx, "unknown", gomock.Any()).Return(nil, entity.ErrInvalidRefreshToken)
+			},
+			expectedStatus: 401,
+		},
+		{
+			name:           "Refresh: 422",
+			body:           `{"refresh_token":`,
+			setup:          func(f fields) {},
+			expectedStatus: 422,
+		},
+		{
+			name: "Refresh: 500",
+			body: `{"refresh_token":"old-token"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Refresh(ctx, "old-token", gomock.Any()).Return(nil, fmt.Errorf("can't rotate token"))
+			},
+			expectedStatus: 500,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockUserInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewAuthHandlers(f.interactor, f.presenter)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/auth/refresh", bytes.NewBufferString(tc.body))
+			c.Request.Header.Set("X-Device-Name", "Pixel 8")
+			c.Request.Header.Set("User-Agent", "okhttp/4.12")
+
+			tc.setup(f)
+
+			h.Refresh(c)
+
+			if w.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_authHandlers_Logout(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	t.Run("Logout: 204", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		interactor := usecase.NewMockUserInteractor(ctrl)
+		interactor.EXPECT().Logout(ctx, "old-token").Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/auth/logout", bytes.NewBufferString(`{"refresh_token":"old-token"}`))
+
+		handlers.NewAuthHandlers(interactor, presenter.NewMockPresenter(ctrl)).Logout(c)
+
+		if c.Writer.Status() != 204 {
+			t.Errorf("expected status %d, got %d", 204, c.Writer.Status())
+		}
+	})
+
+	t.Run("Logout: 422 without token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		interactor := usecase.NewMockUserInteractor(ctrl)
+		interactor.EXPECT().Logout(ctx, "").Return(entity.ErrInvalidRefreshToken)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/auth/logout", bytes.NewBufferString(`{}`))
+
+		handlers.NewAuthHandlers(interactor, presenter.NewMockPresenter(ctrl)).Logout(c)
+
+		if c.Writer.Status() != 422 {
+			t.Errorf("expected status %d, got %d", 422, c.Writer.Status())
+		}
+	})
+
+	t.Run("LogoutAll: 204", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		interactor := usecase.NewMockUserInteractor(ctrl)
+		interactor.EXPECT().LogoutAll(ctx, userId).Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/auth/logout-all", nil)
+		c.Set("user-id", userId)
+
+		handlers.NewAuthHandlers(interactor, presenter.NewMockPresenter(ctrl)).LogoutAll(c)
+
+		if c.Writer.Status() != 204 {
+			t.Errorf("expected status %d, got %d", 204, c.Writer.Status())
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_chartHandlers_GetWeekly(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockChartInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		query          string
+		setup          func(f fields)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	week := time.Date(2023, time.March, 20, 0, 0, 0, 0, time.UTC)
+	entries := []*entity.ChartEntryDB{{Week: week, Position: 1}}
+
+	cases := []testCase{
+		{
+			name:  "GetWeekly: 200",
+			query: "?week=2023-03-20",
+			setup: func(f fields) {
+				f.interactor.EXPECT().GetWeekly(ctx, week, false).Return(entries, nil)
+				f.presenter.EXPECT().ToChartView(entries).Return(&view.ChartView{Week: "2023-03-20"})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "GetWeekly: 404",
+			query: "",
+			setup: func(f fields) {
+				f.interactor.EXPECT().GetWeekly(ctx, time.Time{}, false).Return(nil, entity.ErrChartNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "GetWeekly: 422",
+			query:          "?week=last",
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "GetWeekly: 500",
+			query: "?week=2023-03-20",
+			setup: func(f fields) {
+				f.interactor.EXPECT().GetWeekly(ctx, week, false).Return(nil, fmt.Errorf("can't get chart"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockChartInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewChartHandlers(f.interactor, f.presenter)
+
+			tc.setup(f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/charts/weekly"+tc.query, nil)
+
+			h.GetWeekly(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_chartHandlers_GetMusicHistory(t *testing.T) {
+	type testCase struct {
+		name           string
+		musicId        string
+		setup          func(i *usecase.MockChartInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}
+
+	musicId := "4a6e104d-9d7f-45ff-8de6-37993d709522"
+	entries := []*entity.ChartEntryDB{{Position: 3}}
+
+	cases := []testCase{
+		{
+			name:    "GetMusicHistory: 200",
+			musicId: musicId,
+			setup: func(i *usecase.MockChartInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().GetMusicHistory(context.Background(), uuid.MustParse(musicId)).Return(entries, nil)
+				p.EXPECT().ToListChartEntryView(entries).Return([]*view.ChartEntryView{{Position: 3}})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "GetMusicHistory: 422",
+			musicId:        "not-uuid",
+			setup:          func(i *usecase.MockChartInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockChartInteractor(ctrl)
+			mockPresenter := presenter.NewMockPresenter(ctrl)
+			h := handlers.NewChartHandlers(interactor, mockPresenter)
+
+			tc.setup(interactor, mockPresenter)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: tc.musicId}}
+
+			h.GetMusicHistory(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
@@ -0,0 +1,454 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_commentHandlers_Create(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockCommentInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		body           string
+		setup          func(f fields)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	comment := &entity.CommentDB{MusicID: &musicId, UserID: userId, Body: "nice"}
+
+	cases := []testCase{
+		{
+			name: "Create: 201",
+			body: `{"body":"nice"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Create(ctx, userId, musicId, &entity.CommentCreate{Body: "nice"}).Return(comment, nil)
+				f.presenter.EXPECT().ToCommentView(comment).Return(&view.CommentView{Body: "nice"})
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Create: 422 on reply to reply",
+			body: `{"body":"nice","parent_id":"8b1c5c1e-2f0a-4d61-9c55-4b1e3f1a2d7e"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Create(ctx, userId, musicId, gomock.Any()).Return(nil, entity.ErrCommentReplyDepth)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Create: 429",
+			body: `{"body":"nice"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Create(ctx, userId, musicId, gomock.Any()).
+					Return(nil, fmt.Errorf("%w: 10 comments per 1m0s", entity.ErrCommentRateLimited))
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name: "Create: 404",
+			body: `{"body":"nice"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Create(ctx, userId, musicId, gomock.Any()).
+					Return(nil, fmt.Errorf("/repository/comment.Create: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Create: 422 on malformed body",
+			body:           `{"body":`,
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Create: 500",
+			body: `{"body":"nice"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Create(ctx, userId, musicId, gomock.Any()).Return(nil, fmt.Errorf("can't create comment"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockCommentInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewCommentHandlers(f.interactor, f.presenter)
+
+			tc.setup(f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/music/"+musicId.String()+"/comments", bytes.NewBufferString(tc.body))
+			c.Params = gin.Params{{Key: "id", Value: musicId.String()}}
+			c.Set("user-id", userId)
+
+			h.Create(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_commentHandlers_CreateForPlaylist(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockCommentInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		setup          func(f fields)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	playlistId := uuid.MustParse("5b7c3a1e-9d2f-4e8b-a6c4-1f0e2d3c4b5a")
+	comment := &entity.CommentDB{PlaylistID: &playlistId, UserID: userId, Body: "nice"}
+
+	cases := []testCase{
+		{
+			name: "CreateForPlaylist: 201",
+			setup: func(f fields) {
+				f.interactor.EXPECT().CreateForPlaylist(ctx, userId, playlistId, &entity.CommentCreate{Body: "nice"}).Return(comment, nil)
+				f.presenter.EXPECT().ToCommentView(comment).Return(&view.CommentView{Body: "nice"})
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "CreateForPlaylist: 404 on private playlist",
+			setup: func(f fields) {
+				f.interactor.EXPECT().CreateForPlaylist(ctx, userId, playlistId, gomock.Any()).
+					Return(nil, fmt.Errorf("playlist is private: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "CreateForPlaylist: 429",
+			setup: func(f fields) {
+				f.interactor.EXPECT().CreateForPlaylist(ctx, userId, playlistId, gomock.Any()).
+					Return(nil, fmt.Errorf("%w: 10 comments per 1m0s", entity.ErrCommentRateLimited))
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockCommentInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewCommentHandlers(f.interactor, f.presenter)
+
+			tc.setup(f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/playlists/"+playlistId.String()+"/comments", bytes.NewBufferString(`{"body":"nice"}`))
+			c.Params = gin.Params{{Key: "id", Value: playlistId.String()}}
+			c.Set("user-id", userId)
+
+			h.CreateForPlaylist(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_commentHandlers_GetByMusic(t *testing.T) {
+	type testCase struct {
+		name           string
+		query          string
+		role           string
+		setup          func(i *usecase.MockCommentInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	page := &entity.CommentPage{}
+	cursor := &entity.CommentCursor{ID: uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11"), ReplyCount: 2}
+
+	cases := []testCase{
+		{
+			name:  "GetByMusic: 200",
+			query: "?sort=top&limit=10",
+			role:  entity.UserRole,
+			setup: func(i *usecase.MockCommentInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().GetByMusic(ctx, musicId, &entity.CommentFilter{Sort: entity.CommentSortTop, Limit: 10}).Return(page, nil)
+				p.EXPECT().ToCommentPageView(page).Return(&view.CommentPageView{})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "GetByMusic: 200 with cursor for admin",
+			query: "?cursor=" + cursor.Encode(),
+			role:  entity.AdminRole,
+			setup: func(i *usecase.MockCommentInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().GetByMusic(ctx, musicId, &entity.CommentFilter{Cursor: cursor, Limit: entity.DefaultCommentLimit, IncludeHidden: true, ShowUnpublished: true}).
+					Return(page, nil)
+				p.EXPECT().ToCommentPageView(page).Return(&view.CommentPageView{})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "GetByMusic: 404 on hidden track",
+			query: "",
+			role:  entity.UserRole,
+			setup: func(i *usecase.MockCommentInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().GetByMusic(ctx, musicId, gomock.Any()).Return(nil, fmt.Errorf("music not found: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "GetByMusic: 422 on invalid cursor",
+			query:          "?cursor=bm90LWpzb24",
+			setup:          func(i *usecase.MockCommentInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "GetByMusic: 422 on unknown sort",
+			query: "?sort=oldest",
+			setup: func(i *usecase.MockCommentInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().GetByMusic(ctx, musicId, gomock.Any()).Return(nil, fmt.Errorf("%w: \"oldest\"", entity.ErrUnknownCommentSort))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockCommentInteractor(ctrl)
+			pres := presenter.NewMockPresenter(ctrl)
+			h := handlers.NewCommentHandlers(interactor, pres)
+
+			tc.setup(interactor, pres)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/music/"+musicId.String()+"/comments"+tc.query, nil)
+			c.Params = gin.Params{{Key: "id", Value: musicId.String()}}
+			if tc.role != "" {
+				c.Set("user-role", tc.role)
+			}
+
+			h.GetByMusic(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_commentHandlers_Delete(t *testing.T) {
+	type testCase struct {
+		name           string
+		role           string
+		setup          func(i *usecase.MockCommentInteractor)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+
+	cases := []testCase{
+		{
+			name: "Delete: 204",
+			role: entity.UserRole,
+			setup: func(i *usecase.MockCommentInteractor) {
+				i.EXPECT().Delete(ctx, userId, commentId, false).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "Delete: 204 by admin",
+			role: entity.AdminRole,
+			setup: func(i *usecase.MockCommentInteractor) {
+				i.EXPECT().Delete(ctx, userId, commentId, true).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "Delete: 403",
+			role: entity.UserRole,
+			setup: func(i *usecase.MockCommentInteractor) {
+				i.EXPECT().Delete(ctx, userId, commentId, false).Return(entity.ErrCommentForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "Delete: 404",
+			role: entity.UserRole,
+			setup: func(i *usecase.MockCommentInteractor) {
+				i.EXPECT().Delete(ctx, userId, commentId, false).Return(fmt.Errorf("/repository/comment.Get: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockCommentInteractor(ctrl)
+			h := handlers.NewCommentHandlers(interactor, presenter.NewMockPresenter(ctrl))
+
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/comments/"+commentId.String(), nil)
+			c.Params = gin.Params{{Key: "id", Value: commentId.String()}}
+			c.Set("user-id", userId)
+			c.Set("user-role", tc.role)
+
+			h.Delete(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_commentHandlers_Report(t *testing.T) {
+	type testCase struct {
+		name           string
+		body           string
+		setup          func(i *usecase.MockCommentInteractor)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+
+	cases := []testCase{
+		{
+			name: "Report: 201",
+			body: `{"reason":"spam"}`,
+			setup: func(i *usecase.MockCommentInteractor) {
+				i.EXPECT().Report(ctx, userId, commentId, &entity.ReportCreate{Reason: "spam"}).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Report: 409",
+			body: `{"reason":"spam"}`,
+			setup: func(i *usecase.MockCommentInteractor) {
+				i.EXPECT().Report(ctx, userId, commentId, gomock.Any()).Return(entity.ErrDuplicateReport)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "Report: 404",
+			body: `{"reason":"spam"}`,
+			setup: func(i *usecase.MockCommentInteractor) {
+				i.EXPECT().Report(ctx, userId, commentId, gomock.Any()).Return(fmt.Errorf("comment is hidden: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockCommentInteractor(ctrl)
+			h := handlers.NewCommentHandlers(interactor, presenter.NewMockPresenter(ctrl))
+
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/comments/"+commentId.String()+"/reports", bytes.NewBufferString(tc.body))
+			c.Params = gin.Params{{Key: "id", Value: commentId.String()}}
+			c.Set("user-id", userId)
+
+			h.Report(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_commentHandlers_Hide(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interactor := usecase.NewMockCommentInteractor(ctrl)
+	h := handlers.NewCommentHandlers(interactor, presenter.NewMockPresenter(ctrl))
+
+	ctx := context.Background()
+	moderatorId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+
+	interactor.EXPECT().Hide(ctx, moderatorId, commentId).Return(nil)
+	interactor.EXPECT().Hide(ctx, moderatorId, commentId).Return(fmt.Errorf("comment is hidden: %w", sql.ErrNoRows))
+
+	for _, expectedStatus := range []int{http.StatusNoContent, http.StatusNotFound} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/comments/"+commentId.String()+"/hide", nil)
+		c.Params = gin.Params{{Key: "id", Value: commentId.String()}}
+		c.Set("user-id", moderatorId)
+
+		h.Hide(c)
+
+		assert.Equal(t, expectedStatus, c.Writer.Status())
+	}
+}
+
+func Test_commentHandlers_DismissReports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interactor := usecase.NewMockCommentInteractor(ctrl)
+	h := handlers.NewCommentHandlers(interactor, presenter.NewMockPresenter(ctrl))
+
+	ctx := context.Background()
+	moderatorId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+
+	interactor.EXPECT().DismissReports(ctx, moderatorId, commentId).Return(int64(2), nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/comments/"+commentId.String()+"/reports/dismiss", nil)
+	c.Params = gin.Params{{Key: "id", Value: commentId.String()}}
+	c.Set("user-id", moderatorId)
+
+	h.DismissReports(c)
+
+	assert.Equal(t, http.StatusOK, c.Writer.Status())
+	assert.JSONEq(t, `{"dismissed":2}`, w.Body.String())
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_exportHandlers_Export(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	job := &entity.ExportJobDB{ID: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"), UserID: userId}
+
+	cases := []struct {
+		name            string
+		query           string
+		setup           func(interactor *usecase.MockExportInteractor, p *presenter.MockPresenter)
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			name:  "Export: 200 streamed",
+			query: "?format=csv",
+			setup: func(interactor *usecase.MockExportInteractor, p *presenter.MockPresenter) {
+				request := &entity.ExportRequest{Format: entity.ExportFormatCSV}
+				interactor.EXPECT().Start(ctx, userId, request).Return(nil, nil)
+				interactor.EXPECT().Write(ctx, userId, request, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ *entity.ExportRequest, w io.Writer) error {
+						_, err := io.WriteString(w, "section\n")
+						return err
+					})
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Content-Type":        "text/csv; charset=utf-8",
+				"Content-Disposition": `attachment; filename="library.csv"`,
+			},
+		},
+		{
+			name:  "Export: 202 job",
+			query: "?format=json&async=true",
+			setup: func(interactor *usecase.MockExportInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().Start(ctx, userId, &entity.ExportRequest{Format: entity.ExportFormatJSON, Async: true}).Return(job, nil)
+				p.EXPECT().ToExportJobView(job).Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:  "Export: 404 on unknown playlist",
+			query: "?format=m3u8&playlist_id=4a6e104d-9d7f-45ff-8de6-37993d709522",
+			setup: func(interactor *usecase.MockExportInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().Start(ctx, userId, gomock.Any()).Return(nil, fmt.Errorf("/repository/export.CountItems: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:  "Export: 422 on unknown format",
+			query: "?format=xml",
+			setup: func(interactor *usecase.MockExportInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().Start(ctx, userId, gomock.Any()).Return(nil, entity.ErrInvalidExport)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Export: 422 on bad playlist_id",
+			query:          "?format=m3u8&playlist_id=not-a-uuid",
+			setup:          func(interactor *usecase.MockExportInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockExportInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/users/me/export"+tc.query, nil)
+			c.Set("user-id", userId)
+
+			handlers.NewExportHandlers(interactor, p).Export(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+			for key, value := range tc.expectedHeaders {
+				assert.Equal(t, value, w.Header().Get(key))
+			}
+		})
+	}
+}
+
+func Test_exportHandlers_Download(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	jobId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	cases := []struct {
+		name           string
+		id             string
+		setup          func(interactor *usecase.MockExportInteractor)
+		expectedStatus int
+	}{
+		{
+			name: "Download: 409 while running",
+			id:   jobId.String(),
+			setup: func(interactor *usecase.MockExportInteractor) {
+				interactor.EXPECT().GetDownload(ctx, userId, jobId).Return(nil, entity.ErrExportNotReady)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "Download: 410 after expiry",
+			id:   jobId.String(),
+			setup: func(interactor *usecase.MockExportInteractor) {
+				interactor.EXPECT().GetDownload(ctx, userId, jobId).Return(nil, entity.ErrExportExpired)
+			},
+			expectedStatus: http.StatusGone,
+		},
+		{
+			name: "Download: 404 for another user's job",
+			id:   jobId.String(),
+			setup: func(interactor *usecase.MockExportInteractor) {
+				interactor.EXPECT().GetDownload(ctx, userId, jobId).Return(nil, fmt.Errorf("/repository/export.GetJob: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Download: 422 on bad id",
+			id:             "not-a-uuid",
+			setup:          func(interactor *usecase.MockExportInteractor) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockExportInteractor(ctrl)
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/users/me/exports/"+tc.id+"/download", nil)
+			c.Params = gin.Params{{Key: "id", Value: tc.id}}
+			c.Set("user-id", userId)
+
+			handlers.NewExportHandlers(interactor, presenter.NewMockPresenter(ctrl)).Download(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_followHandlers_FollowUser(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	followeeId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+
+	cases := []struct {
+		name           string
+		id             string
+		setup          func(interactor *usecase.MockFollowInteractor)
+		expectedStatus int
+	}{
+		{
+			name: "FollowUser: 204",
+			id:   followeeId.String(),
+			setup: func(interactor *usecase.MockFollowInteractor) {
+				interactor.EXPECT().FollowUser(ctx, userId, followeeId).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "FollowUser: 422 on self follow",
+			id:   userId.String(),
+			setup: func(interactor *usecase.MockFollowInteractor) {
+				interactor.EXPECT().FollowUser(ctx, userId, userId).Return(entity.ErrSelfFollow)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "FollowUser: 404",
+			id:   followeeId.String(),
+			setup: func(interactor *usecase.MockFollowInteractor) {
+				interactor.EXPECT().FollowUser(ctx, userId, followeeId).Return(fmt.Errorf("/repository/follow.FollowUser: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "FollowUser: 422 on bad id",
+			id:             "not-a-uuid",
+			setup:          func(interactor *usecase.MockFollowInteractor) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockFollowInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/users/"+tc.id+"/follow", nil)
+			c.Params = gin.Params{{Key: "id", Value: tc.id}}
+			c.Set("user-id", userId)
+
+			handlers.NewFollowHandlers(interactor, p).FollowUser(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_followHandlers_GetFeed(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	cursor := &entity.FeedCursor{OccurredAt: time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC), ID: "like:x"}
+	page := &entity.FeedPage{}
+
+	cases := []struct {
+		name           string
+		query          string
+		setup          func(interactor *usecase.MockFollowInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}{
+		{
+			name:  "GetFeed: 200 first page",
+			query: "",
+			setup: func(interactor *usecase.MockFollowInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().GetFeed(ctx, userId, &entity.FeedFilter{Limit: entity.DefaultFeedLimit}).Return(page, nil)
+				p.EXPECT().ToFeedPageView(page).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "GetFeed: 200 with cursor",
+			query: "?limit=5&cursor=" + cursor.Encode(),
+			setup: func(interactor *usecase.MockFollowInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().GetFeed(ctx, userId, &entity.FeedFilter{Cursor: cursor, Limit: 5}).Return(page, nil)
+				p.EXPECT().ToFeedPageView(page).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "GetFeed: 422 on invalid cursor",
+			query:          "?cursor=not-a-cursor",
+			setup:          func(interactor *usecase.MockFollowInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockFollowInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/users/me/feed"+tc.query, nil)
+			c.Set("user-id", userId)
+
+			handlers.NewFollowHandlers(interactor, p).GetFeed(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_identityHandlers_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interactor := usecase.NewMockIdentityInteractor(ctrl)
+	h := handlers.NewIdentityHandlers(interactor, usecase.NewMockUserInteractor(ctrl), presenter.NewMockPresenter(ctrl))
+
+	interactor.EXPECT().Begin(context.Background(), nil).Return(&entity.OIDCAuthorization{
+		URL:       "http://oidc:8080/default/authorize?state=state",
+		State:     "state",
+		ExpiresAt: time.Now().Add(10 * time.Minute),
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/auth/oidc/login", nil)
+
+	h.Login(c)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "http://oidc:8080/default/authorize?state=state", w.Header().Get("Location"))
+	cookies := w.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, entity.OIDCStateCookie, cookies[0].Name)
+		assert.Equal(t, "state", cookies[0].Value)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+		assert.Greater(t, cookies[0].MaxAge, 0)
+	}
+}
+
+func Test_identityHandlers_Callback(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	attackerId := uuid.MustParse("0b7e3c1a-4a1f-4d5e-9c55-6f2f8c3e1d20")
+	user := &entity.UserDB{ID: userId, Username: "jdoe", Role: entity.UserRole}
+	tokens := &entity.TokenPair{Access: "access-token", Refresh: "refresh-token"}
+
+	type testCase struct {
+		name           string
+		query          string
+		cookie         string
+		userId         *uuid.UUID
+		setup          func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}
+	tests := []testCase{
+		{
+			name:   "success",
+			query:  "?code=code&state=state",
+			cookie: "state",
+			setup: func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().Complete(context.Background(), "state", "code", nil).Return(user, nil)
+				u.EXPECT().IssueTokens(context.Background(), userId, gomock.Any()).Return(tokens, nil)
+				p.EXPECT().ToTokenView(tokens).Return(&view.TokenView{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error: provider denied login",
+			query:          "?error=access_denied&state=state",
+			setup:          func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "error: missing code",
+			query:          "?state=state",
+			cookie:         "state",
+			setup:          func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "error: login started in another browser",
+			query:          "?code=code&state=state",
+			cookie:         "victim-state",
+			setup:          func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "error: no state cookie",
+			query:          "?code=code&state=state",
+			setup:          func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "error: link started by another user",
+			query:  "?code=code&state=state",
+			cookie: "state",
+			userId: &attackerId,
+			setup: func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().Complete(context.Background(), "state", "code", &attackerId).Return(nil, entity.ErrOIDCLinkForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "error: unknown state",
+			query:  "?code=code&state=state",
+			cookie: "state",
+			setup: func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().Complete(context.Background(), "state", "code", nil).Return(nil, entity.ErrInvalidOIDCLogin)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "error: invalid id token",
+			query:  "?code=code&state=state",
+			cookie: "state",
+			setup: func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().Complete(context.Background(), "state", "code", nil).Return(nil, fmt.Errorf("/repository/identity.Exchange: %w", entity.ErrInvalidIDToken))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "error: identity linked to another user",
+			query:  "?code=code&state=state",
+			cookie: "state",
+			setup: func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().Complete(context.Background(), "state", "code", nil).Return(nil, fmt.Errorf("/repository/identity.Login: %w", entity.ErrIdentityLinked))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "error: user in trash",
+			query:  "?code=code&state=state",
+			cookie: "state",
+			setup: func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().Complete(context.Background(), "state", "code", nil).Return(nil, fmt.Errorf("/repository/identity.Login: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "error: provider unavailable",
+			query:  "?code=code&state=state",
+			cookie: "state",
+			setup: func(i *usecase.MockIdentityInteractor, u *usecase.MockUserInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().Complete(context.Background(), "state", "code", nil).Return(nil, fmt.Errorf("can't reach provider"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockIdentityInteractor(ctrl)
+			userInteractor := usecase.NewMockUserInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			h := handlers.NewIdentityHandlers(interactor, userInteractor, p)
+			tt.setup(interactor, userInteractor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/auth/oidc/callback"+tt.query, nil)
+			if tt.cookie != "" {
+				c.Request.AddCookie(&http.Cookie{Name: entity.OIDCStateCookie, Value: tt.cookie})
+			}
+			if tt.userId != nil {
+				c.Set("user-id", *tt.userId)
+			}
+
+			h.Callback(c)
+
+			assert.Equal(t, tt.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_identityHandlers_Link(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	interactor := usecase.NewMockIdentityInteractor(ctrl)
+	h := handlers.NewIdentityHandlers(interactor, usecase.NewMockUserInteractor(ctrl), presenter.NewMockPresenter(ctrl))
+
+	interactor.EXPECT().Begin(context.Background(), &userId).Return(&entity.OIDCAuthorization{
+		URL:       "http://oidc:8080/default/authorize?state=state",
+		State:     "state",
+		ExpiresAt: time.Now().Add(10 * time.Minute),
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/users/me/identities", nil)
+	c.Set("user-id", userId)
+
+	h.Link(c)
+
+	assert.Equal(t, http.StatusOK, c.Writer.Status())
+	assert.Contains(t, w.Body.String(), `"authorization_url":"http://oidc:8080/default/authorize?state=state"`)
+	// Привязку можно завершить только в браузере, где она начата
+	cookies := w.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, "state", cookies[0].Value)
+	}
+}
@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lyricsHandlers_Get(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockLyricsInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		query          string
+		role           string
+		setup          func(f fields)
+		expectedStatus int
+		expectedBody   string
+		expectedType   string
+	}
+
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	synced := "[00:01.50]Hello\n"
+	lyrics := &entity.Lyrics{LyricsDB: entity.LyricsDB{MusicID: musicId, Language: "en", Plain: "Hello", Synced: &synced}}
+	plain := &entity.Lyrics{LyricsDB: entity.LyricsDB{MusicID: musicId, Language: "en", Plain: "Hello"}}
+
+	cases := []testCase{
+		{
+			name:  "Get: 200 json",
+			query: "?lang=en",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Get(ctx, musicId, "en", false).Return(lyrics, nil)
+				f.presenter.EXPECT().ToLyricsView(lyrics).Return(&view.LyricsView{Language: "en"})
+			},
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json; charset=utf-8",
+		},
+		{
+			name:  "Get: 200 lrc",
+			query: "?lang=en&format=lrc",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Get(ctx, musicId, "en", false).Return(lyrics, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "[la:en]\n[00:01.50]Hello\n",
+			expectedType:   "application/x-lrc; charset=utf-8",
+		},
+		{
+			name:  "Get: 404 lrc without synced text",
+			query: "?format=lrc",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Get(ctx, musicId, "", false).Return(plain, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:  "Get: 404",
+			query: "?lang=de",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Get(ctx, musicId, "de", false).Return(nil, fmt.Errorf("lyrics not found: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:  "Get: 200 unpublished track for admin",
+			query: "?lang=en",
+			role:  entity.AdminRole,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Get(ctx, musicId, "en", true).Return(lyrics, nil)
+				f.presenter.EXPECT().ToLyricsView(lyrics).Return(&view.LyricsView{Language: "en"})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Get: 422 on unknown format",
+			query:          "?format=srt",
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Get: 422 on invalid language",
+			query: "?lang=!",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Get(ctx, musicId, "!", false).Return(nil, entity.ErrInvalidLyrics)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Get: 500",
+			query: "",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Get(ctx, musicId, "", false).Return(nil, fmt.Errorf("can't get lyrics"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockLyricsInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewLyricsHandlers(f.interactor, f.presenter)
+
+			tc.setup(f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/music/"+musicId.String()+"/lyrics"+tc.query, nil)
+			c.Params = gin.Params{{Key: "id", Value: musicId.String()}}
+			if tc.role != "" {
+				c.Set("user-role", tc.role)
+			}
+
+			h.Get(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+			if tc.expectedType != "" {
+				assert.Equal(t, tc.expectedType, w.Header().Get("Content-Type"))
+			}
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func Test_lyricsHandlers_Set(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockLyricsInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		query          string
+		contentType    string
+		body           string
+		setup          func(f fields)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	saved := &entity.Lyrics{LyricsDB: entity.LyricsDB{MusicID: musicId, Language: "en", Plain: "Hello"}}
+
+	cases := []testCase{
+		{
+			name:        "Set: 200 json",
+			contentType: "application/json",
+			body:        `{"language":"en","plain":"Hello"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Set(ctx, musicId, &entity.LyricsUpload{Language: "en", Plain: "Hello"}).Return(saved, nil)
+				f.presenter.EXPECT().ToLyricsView(saved).Return(&view.LyricsView{Language: "en"})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Set: 200 raw lrc",
+			query:       "?lang=en",
+			contentType: "application/x-lrc",
+			body:        "[00:01.50]Hello",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Set(ctx, musicId, &entity.LyricsUpload{Language: "en", Synced: "[00:01.50]Hello"}).Return(saved, nil)
+				f.presenter.EXPECT().ToLyricsView(saved).Return(&view.LyricsView{Language: "en"})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Set: 422 on invalid lrc",
+			contentType: "text/plain",
+			body:        "Hello",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Set(ctx, musicId, gomock.Any()).Return(nil, fmt.Errorf("%w: line 1: expected timestamp or tag", entity.ErrInvalidLRC))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Set: 422 on malformed json",
+			contentType:    "application/json",
+			body:           `{"plain":`,
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Set: 404",
+			contentType: "application/json",
+			body:        `{"plain":"Hello"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Set(ctx, musicId, gomock.Any()).Return(nil, fmt.Errorf("/repository/lyrics.Upsert: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "Set: 500",
+			contentType: "application/json",
+			body:        `{"plain":"Hello"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Set(ctx, musicId, gomock.Any()).Return(nil, fmt.Errorf("can't save lyrics"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockLyricsInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewLyricsHandlers(f.interactor, f.presenter)
+
+			tc.setup(f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/music/"+musicId.String()+"/lyrics"+tc.query, bytes.NewBufferString(tc.body))
+			c.Request.Header.Set("Content-Type", tc.contentType)
+			c.Params = gin.Params{{Key: "id", Value: musicId.String()}}
+
+			h.Set(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_lyricsHandlers_Delete(t *testing.T) {
+	type testCase struct {
+		name           string
+		setup          func(interactor *usecase.MockLyricsInteractor)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	cases := []testCase{
+		{
+			name: "Delete: 204",
+			setup: func(interactor *usecase.MockLyricsInteractor) {
+				interactor.EXPECT().Delete(ctx, musicId, "en").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "Delete: 404",
+			setup: func(interactor *usecase.MockLyricsInteractor) {
+				interactor.EXPECT().Delete(ctx, musicId, "en").Return(fmt.Errorf("/repository/lyrics.Delete: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Delete: 500",
+			setup: func(interactor *usecase.MockLyricsInteractor) {
+				interactor.EXPECT().Delete(ctx, musicId, "en").Return(fmt.Errorf("can't delete lyrics"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockLyricsInteractor(ctrl)
+			h := handlers.NewLyricsHandlers(interactor, presenter.NewMockPresenter(ctrl))
+
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/music/"+musicId.String()+"/lyrics?lang=en", nil)
+			c.Params = gin.Params{{Key: "id", Value: musicId.String()}}
+
+			h.Delete(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
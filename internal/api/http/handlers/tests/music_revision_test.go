@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_musicRevisionHandlers_Diff(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockMusicRevisionInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		query          string
+		setup          func(f fields)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	diff := &entity.MusicRevisionDiff{MusicID: musicId}
+
+	cases := []testCase{
+		{
+			name:  "Diff: 200",
+			query: "?from=1&to=3",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Diff(ctx, musicId, 1, 3).Return(diff, nil)
+				f.presenter.EXPECT().ToMusicRevisionDiffView(diff).Return(&view.MusicRevisionDiffView{MusicID: musicId.String()})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Diff: 200 with defaults",
+			query: "",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Diff(ctx, musicId, 0, 0).Return(diff, nil)
+				f.presenter.EXPECT().ToMusicRevisionDiffView(diff).Return(&view.MusicRevisionDiffView{MusicID: musicId.String()})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Diff: 422 on malformed version",
+			query:          "?from=abc",
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Diff: 422 on first version",
+			query: "?to=1",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Diff(ctx, musicId, 0, 1).Return(nil, entity.ErrInvalidMusicRevision)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Diff: 404",
+			query: "?from=7",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Diff(ctx, musicId, 7, 0).Return(nil, fmt.Errorf("/repository/music_revision.Get: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockMusicRevisionInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewMusicRevisionHandlers(f.interactor, f.presenter)
+
+			tc.setup(f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/music/"+musicId.String()+"/revisions/diff"+tc.query, nil)
+			c.Params = gin.Params{{Key: "id", Value: musicId.String()}}
+
+			h.Diff(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_musicRevisionHandlers_Revert(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockMusicRevisionInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		version        string
+		setup          func(f fields)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	revision := &entity.MusicRevisionDB{MusicID: musicId, Version: 4, Action: entity.MusicRevisionRevert}
+
+	cases := []testCase{
+		{
+			name:    "Revert: 200",
+			version: "2",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Revert(ctx, userId, musicId, 2).Return(revision, nil)
+				f.presenter.EXPECT().ToMusicRevisionView(revision).Return(&view.MusicRevisionView{Version: 4})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Revert: 409 on current version",
+			version: "3",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Revert(ctx, userId, musicId, 3).Return(nil, entity.ErrRevertToCurrent)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:    "Revert: 404",
+			version: "9",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Revert(ctx, userId, musicId, 9).Return(nil, fmt.Errorf("/repository/music_revision.Get: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Revert: 422 on malformed version",
+			version:        "last",
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "Revert: 500",
+			version: "1",
+			setup: func(f fields) {
+				f.interactor.EXPECT().Revert(ctx, userId, musicId, 1).Return(nil, fmt.Errorf("can't move file"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockMusicRevisionInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewMusicRevisionHandlers(f.interactor, f.presenter)
+
+			tc.setup(f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/music/"+musicId.String()+"/revisions/"+tc.version+"/revert", nil)
+			c.Params = gin.Params{{Key: "id", Value: musicId.String()}, {Key: "version", Value: tc.version}}
+			c.Set("user-id", userId)
+
+			h.Revert(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_musicRevisionHandlers_GetByMusic(t *testing.T) {
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	revisions := []*entity.MusicRevisionDB{{MusicID: musicId, Version: 1}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interactor := usecase.NewMockMusicRevisionInteractor(ctrl)
+	p := presenter.NewMockPresenter(ctrl)
+	interactor.EXPECT().GetByMusic(ctx, musicId, 10, 5).Return(revisions, nil)
+	p.EXPECT().ToListMusicRevisionView(revisions).Return([]*view.MusicRevisionView{{Version: 1}})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/music/"+musicId.String()+"/revisions?limit=10&offset=5", nil)
+	c.Params = gin.Params{{Key: "id", Value: musicId.String()}}
+
+	handlers.NewMusicRevisionHandlers(interactor, p).GetByMusic(c)
+
+	assert.Equal(t, http.StatusOK, c.Writer.Status())
+	assert.JSONEq(t, `[{"version":1,"action":"","name":"","release":"","file_name":"","size":"","duration":"","reverted_from":null,"changed_by":null,"created_at":""}]`, w.Body.String())
+}
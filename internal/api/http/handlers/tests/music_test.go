@@ -3,16 +3,19 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"music-backend-test/internal/api/http/handlers"
 	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
 	"music-backend-test/internal/entity"
 	"music-backend-test/internal/usecase"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -38,7 +41,7 @@ func Test_GetAll(t *testing.T) {
 		{
 			name: "GetAll",
 			setup: func(ctx context.Context, f fields) {
-				f.usecase.EXPECT().GetAll(ctx).Return([]*entity.MusicDB{
+				f.usecase.EXPECT().GetAll(ctx, entity.MusicFilter{}).Return([]*entity.MusicDB{
 					{
 						Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
 						Name:     "Song1",
@@ -58,12 +61,12 @@ func Test_GetAll(t *testing.T) {
 				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantBody:   `[{"id":"4a6e104d-9d7f-45ff-8de6-37993d709522","name":"Song1","size":"500 B","duration":"2:47"},{"id":"ff578289-cdca-406e-9a57-f8c773f0cd15","name":"Song2","size":"900 B","duration":"3:23"}]`,
+			wantBody:   `[{"id":"4a6e104d-9d7f-45ff-8de6-37993d709522","name":"Song1","size":"500 B","duration":"2:47","rating":0,"rating_count":0,"explicit":false,"artist_id":null},{"id":"ff578289-cdca-406e-9a57-f8c773f0cd15","name":"Song2","size":"900 B","duration":"3:23","rating":0,"rating_count":0,"explicit":false,"artist_id":null}]`,
 		},
 		{
 			name: "Error in usecase GetAll",
 			setup: func(ctx context.Context, f fields) {
-				f.usecase.EXPECT().GetAll(ctx).Return(nil, fmt.Errorf("Error in usecase GetAll"))
+				f.usecase.EXPECT().GetAll(ctx, entity.MusicFilter{}).Return(nil, fmt.Errorf("Error in usecase GetAll"))
 			},
 			wantStatus: http.StatusInternalServerError,
 			wantBody:   "",
@@ -111,7 +114,7 @@ func Test_Get(t *testing.T) { //Очень нужен код ревью
 		{
 			name: "GetAll",
 			setup: func(ctx context.Context, musicId uuid.UUID, f fields) {
-				f.usecase.EXPECT().Get(ctx, musicId).Return(&entity.MusicDB{
+				f.usecase.EXPECT().Get(ctx, musicId, entity.MusicFilter{}).Return(&entity.MusicDB{
 					Id:       uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
 					Name:     "Song2",
 					Release:  time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
@@ -132,11 +135,27 @@ func Test_Get(t *testing.T) { //Очень нужен код ревью
 		{
 			name: "Error in usecase GetAll",
 			setup: func(ctx context.Context, musicId uuid.UUID, f fields) {
-				f.usecase.EXPECT().Get(ctx, musicId).Return(nil, fmt.Errorf("Error in usecase Get"))
+				f.usecase.EXPECT().Get(ctx, musicId, entity.MusicFilter{}).Return(nil, fmt.Errorf("Error in usecase Get"))
 			},
 			id:         "ff578289-cdca-406e-9a57-f8c773f0cd15",
 			wantStatus: http.StatusInternalServerError,
 		},
+		{
+			name: "Unpublished music",
+			setup: func(ctx context.Context, musicId uuid.UUID, f fields) {
+				f.usecase.EXPECT().Get(ctx, musicId, entity.MusicFilter{}).Return(nil, fmt.Errorf("/usecase/music.Get: %w", sql.ErrNoRows))
+			},
+			id:         "ff578289-cdca-406e-9a57-f8c773f0cd15",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Explicit music hidden",
+			setup: func(ctx context.Context, musicId uuid.UUID, f fields) {
+				f.usecase.EXPECT().Get(ctx, musicId, entity.MusicFilter{}).Return(nil, fmt.Errorf("/usecase/music.Get: %w", entity.ErrExplicitContent))
+			},
+			id:         "ff578289-cdca-406e-9a57-f8c773f0cd15",
+			wantStatus: http.StatusForbidden,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -184,7 +203,7 @@ func Test_GetAllSortByTime(t *testing.T) {
 		{
 			name: "GetAllSortByTime",
 			setup: func(ctx context.Context, f fields) {
-				f.usecase.EXPECT().GetAllSortByTime(ctx).Return([]*entity.MusicDB{
+				f.usecase.EXPECT().GetAllSortByTime(ctx, entity.MusicFilter{}).Return([]*entity.MusicDB{
 					{
 						Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
 						Name:     "Song1",
@@ -204,12 +223,12 @@ func Test_GetAllSortByTime(t *testing.T) {
 				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantBody:   `[{"id":"4a6e104d-9d7f-45ff-8de6-37993d709522","name":"Song1","size":"500 B","duration":"2:47"},{"id":"ff578289-cdca-406e-9a57-f8c773f0cd15","name":"Song2","size":"900 B","duration":"3:23"}]`,
+			wantBody:   `[{"id":"4a6e104d-9d7f-45ff-8de6-37993d709522","name":"Song1","size":"500 B","duration":"2:47","rating":0,"rating_count":0,"explicit":false,"artist_id":null},{"id":"ff578289-cdca-406e-9a57-f8c773f0cd15","name":"Song2","size":"900 B","duration":"3:23","rating":0,"rating_count":0,"explicit":false,"artist_id":null}]`,
 		},
 		{
 			name: "Error in usecase GetAllSortByTime",
 			setup: func(ctx context.Context, f fields) {
-				f.usecase.EXPECT().GetAllSortByTime(ctx).Return(nil, fmt.Errorf("Error in usecase GetAllSortByTime"))
+				f.usecase.EXPECT().GetAllSortByTime(ctx, entity.MusicFilter{}).Return(nil, fmt.Errorf("Error in usecase GetAllSortByTime"))
 			},
 			wantStatus: http.StatusInternalServerError,
 			wantBody:   "",
@@ -241,7 +260,7 @@ func Test_GetAllSortByTime(t *testing.T) {
 	}
 }
 
-func Test_GetAndSortByPopular(t *testing.T) {
+func Test_GetAllSortByRating(t *testing.T) {
 	type fields struct {
 		usecase *usecase.MockMusicInteractor
 	}
@@ -249,42 +268,46 @@ func Test_GetAndSortByPopular(t *testing.T) {
 
 	tests := []struct {
 		name       string
+		query      string
 		setup      func(ctx context.Context, f fields)
 		wantStatus int
 		wantBody   string
 	}{
 		{
-			name: "GetAndSortByPopular",
+			name:  "GetAll sorted by rating",
+			query: "?sort=rating",
 			setup: func(ctx context.Context, f fields) {
-				f.usecase.EXPECT().GetAndSortByPopular(ctx).Return([]*entity.MusicDB{
+				f.usecase.EXPECT().GetAllSortByRating(ctx, entity.MusicFilter{}).Return([]*entity.MusicDB{
 					{
-						Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-						Name:     "Song1",
-						Release:  time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
-						FileName: "Song1.mp3",
-						Size:     uint64(500),
-						Duration: "2:47",
-					},
-					{
-						Id:       uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
-						Name:     "Song2",
-						Release:  time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
-						FileName: "Song2.mp3",
-						Size:     uint64(900),
-						Duration: "3:23",
+						Id:          uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+						Name:        "Song1",
+						Release:     time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
+						FileName:    "Song1.mp3",
+						Size:        uint64(500),
+						Duration:    "2:47",
+						RatingAvg:   4.5,
+						RatingCount: 120,
 					},
 				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantBody:   `[{"id":"4a6e104d-9d7f-45ff-8de6-37993d709522","name":"Song1","size":"500 B","duration":"2:47"},{"id":"ff578289-cdca-406e-9a57-f8c773f0cd15","name":"Song2","size":"900 B","duration":"3:23"}]`,
+			wantBody:   `[{"id":"4a6e104d-9d7f-45ff-8de6-37993d709522","name":"Song1","size":"500 B","duration":"2:47","rating":4.5,"rating_count":120,"explicit":false,"artist_id":null}]`,
 		},
 		{
-			name: "Error in usecase GetAndSortByPopular",
+			name:  "Error in usecase GetAllSortByRating",
+			query: "?sort=rating",
 			setup: func(ctx context.Context, f fields) {
-				f.usecase.EXPECT().GetAndSortByPopular(ctx).Return(nil, fmt.Errorf("Error in usecase GetAndSortByPopular"))
+				f.usecase.EXPECT().GetAllSortByRating(ctx, entity.MusicFilter{}).Return(nil, fmt.Errorf("Error in usecase GetAllSortByRating"))
 			},
 			wantStatus: http.StatusInternalServerError,
-			wantBody:   "[]", //КАК?!?!
+			wantBody:   "",
+		},
+		{
+			name:       "Unknown sort",
+			query:      "?sort=plays",
+			setup:      func(ctx context.Context, f fields) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "",
 		},
 	}
 
@@ -300,10 +323,10 @@ func Test_GetAndSortByPopular(t *testing.T) {
 			musicHandler := handlers.NewMusicHandlers(f.usecase, presenter.NewPresenter())
 
 			r := gin.New()
-			r.POST("/getSortByPopular", musicHandler.GetAndSortByPopular)
+			r.GET("/catalog", musicHandler.GetAll)
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest(http.MethodPost, "/getSortByPopular", nil)
+			req := httptest.NewRequest(http.MethodGet, "/catalog"+tt.query, nil)
 
 			r.ServeHTTP(w, req)
 
@@ -327,7 +350,7 @@ func Test_Create(t *testing.T) {
 		{
 			name: "Create",
 			setup: func(ctx context.Context, musicCreate *entity.MusicParse, f fields) {
-				f.usecase.EXPECT().Create(ctx, musicCreate).Return(nil)
+				f.usecase.EXPECT().Create(ctx, musicCreate).Return(&entity.MusicUploadReport{Music: &entity.MusicDB{}}, nil)
 			},
 			inputBody: func(w *multipart.Writer) {
 				name, err := w.CreateFormField("name")
@@ -354,7 +377,7 @@ func Test_Create(t *testing.T) {
 		{
 			name: "Error in usecase create",
 			setup: func(ctx context.Context, musicCreate *entity.MusicParse, f fields) {
-				f.usecase.EXPECT().Create(ctx, musicCreate).Return(fmt.Errorf("Error in usecase create"))
+				f.usecase.EXPECT().Create(ctx, musicCreate).Return(nil, fmt.Errorf("Error in usecase create"))
 			},
 			inputBody: func(w *multipart.Writer) {
 				name, err := w.CreateFormField("name")
@@ -562,6 +585,14 @@ func Test_Delete(t *testing.T) {
 			id:         "0",
 			wantStatus: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Music not found or already in trash",
+			setup: func(ctx context.Context, musicId uuid.UUID, f fields) {
+				f.usecase.EXPECT().Delete(ctx, musicId).Return(fmt.Errorf("/repository/music.Delete: %w", sql.ErrNoRows))
+			},
+			id:         "ff578289-cdca-406e-9a57-f8c773f0cd15",
+			wantStatus: http.StatusNotFound,
+		},
 		{
 			name: "Error in usecase Delete",
 			setup: func(ctx context.Context, musicId uuid.UUID, f fields) {
@@ -596,3 +627,165 @@ func Test_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_SetExplicit(t *testing.T) {
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		setup      func(interactor *usecase.MockMusicInteractor)
+		wantStatus int
+	}{
+		{
+			name: "SetExplicit: 204",
+			id:   musicId.String(),
+			body: `{"explicit":true}`,
+			setup: func(interactor *usecase.MockMusicInteractor) {
+				interactor.EXPECT().SetExplicit(ctx, musicId, true).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "SetExplicit: 404",
+			id:   musicId.String(),
+			body: `{"explicit":false,"artist_id":null}`,
+			setup: func(interactor *usecase.MockMusicInteractor) {
+				interactor.EXPECT().SetExplicit(ctx, musicId, false).Return(fmt.Errorf("/repository/music.SetExplicit: %w", sql.ErrNoRows))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "SetExplicit: 422 on bad id",
+			id:         "0",
+			body:       `{"explicit":true}`,
+			setup:      func(interactor *usecase.MockMusicInteractor) {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "SetExplicit: 422 on bad body",
+			id:         musicId.String(),
+			body:       `{"explicit":"yes"}`,
+			setup:      func(interactor *usecase.MockMusicInteractor) {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			defer cntr.Finish()
+
+			interactor := usecase.NewMockMusicInteractor(cntr)
+			tt.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/music/"+tt.id+"/explicit", strings.NewReader(tt.body))
+			c.Params = gin.Params{{Key: "id", Value: tt.id}}
+
+			handlers.NewMusicHandlers(interactor, presenter.NewPresenter()).SetExplicit(c)
+
+			assert.Equal(t, tt.wantStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_musicHandlers_Merge(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockMusicInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		id             string
+		body           string
+		setup          func(f fields)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	intoId := uuid.MustParse("3f1a2b2c-7f7e-4c1e-9a57-2b9fd9a0c111")
+	merge := &entity.MusicMerge{Into: intoId}
+	result := &entity.MusicMergeResult{MusicID: musicId, IntoID: intoId, Likes: 1}
+
+	cases := []testCase{
+		{
+			name: "Merge: 200",
+			id:   musicId.String(),
+			body: `{"into":"` + intoId.String() + `"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Merge(ctx, musicId, merge).Return(result, nil)
+				f.presenter.EXPECT().ToMusicMergeView(result).Return(&view.MusicMergeView{MusicID: musicId.String(), IntoID: intoId.String(), Likes: 1})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Merge: 422 on malformed id",
+			id:             "abc",
+			body:           `{"into":"` + intoId.String() + `"}`,
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Merge: 422 on malformed body",
+			id:             musicId.String(),
+			body:           `{"into":1}`,
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Merge: 422 on merge into itself",
+			id:   musicId.String(),
+			body: `{"into":"` + musicId.String() + `"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Merge(ctx, musicId, &entity.MusicMerge{Into: musicId}).Return(nil, entity.ErrInvalidMerge)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Merge: 404",
+			id:   musicId.String(),
+			body: `{"into":"` + intoId.String() + `"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Merge(ctx, musicId, merge).Return(nil, fmt.Errorf("/repository/music.Merge: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Merge: 500",
+			id:   musicId.String(),
+			body: `{"into":"` + intoId.String() + `"}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Merge(ctx, musicId, merge).Return(nil, fmt.Errorf("Error in usecase merge"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockMusicInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewMusicHandlers(f.interactor, f.presenter)
+
+			tc.setup(f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/music/"+tc.id+"/merge", strings.NewReader(tc.body))
+			c.Params = gin.Params{{Key: "id", Value: tc.id}}
+
+			h.Merge(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_notificationHandlers_Get(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	cursor := &entity.NotificationCursor{CreatedAt: time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC), ID: uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")}
+	page := &entity.NotificationPage{}
+
+	cases := []struct {
+		name           string
+		query          string
+		setup          func(interactor *usecase.MockNotificationInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}{
+		{
+			name:  "Get: 200 first page",
+			query: "",
+			setup: func(interactor *usecase.MockNotificationInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().GetByUser(ctx, userId, &entity.NotificationFilter{Limit: entity.DefaultNotificationLimit}).Return(page, nil)
+				p.EXPECT().ToNotificationPageView(page).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Get: 200 unread with cursor",
+			query: "?unread=true&limit=5&cursor=" + cursor.Encode(),
+			setup: func(interactor *usecase.MockNotificationInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().GetByUser(ctx, userId, &entity.NotificationFilter{Cursor: cursor, Limit: 5, UnreadOnly: true}).Return(page, nil)
+				p.EXPECT().ToNotificationPageView(page).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Get: 422 on invalid cursor",
+			query:          "?cursor=not-a-cursor",
+			setup:          func(interactor *usecase.MockNotificationInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Get: 422 on invalid unread",
+			query:          "?unread=maybe",
+			setup:          func(interactor *usecase.MockNotificationInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockNotificationInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/notifications"+tc.query, nil)
+			c.Set("user-id", userId)
+
+			handlers.NewNotificationHandlers(interactor, p).Get(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_notificationHandlers_MarkRead(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	notificationId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+
+	cases := []struct {
+		name           string
+		id             string
+		setup          func(interactor *usecase.MockNotificationInteractor)
+		expectedStatus int
+	}{
+		{
+			name: "MarkRead: 204",
+			id:   notificationId.String(),
+			setup: func(interactor *usecase.MockNotificationInteractor) {
+				interactor.EXPECT().MarkRead(ctx, userId, notificationId).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "MarkRead: 404",
+			id:   notificationId.String(),
+			setup: func(interactor *usecase.MockNotificationInteractor) {
+				interactor.EXPECT().MarkRead(ctx, userId, notificationId).Return(fmt.Errorf("/repository/notification.MarkRead: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "MarkRead: 422 on bad id",
+			id:             "not-a-uuid",
+			setup:          func(interactor *usecase.MockNotificationInteractor) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockNotificationInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/notifications/"+tc.id+"/read", nil)
+			c.Params = gin.Params{{Key: "id", Value: tc.id}}
+			c.Set("user-id", userId)
+
+			handlers.NewNotificationHandlers(interactor, p).MarkRead(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_notificationHandlers_SetSettings(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	saved := &entity.NotificationSettings{}
+
+	cases := []struct {
+		name           string
+		body           string
+		setup          func(interactor *usecase.MockNotificationInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}{
+		{
+			name: "SetSettings: 200",
+			body: `{"email":null,"preferences":[{"type":"release","in_app":true,"email":false,"webhook":true}]}`,
+			setup: func(interactor *usecase.MockNotificationInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().SetSettings(ctx, userId, &entity.NotificationSettings{Preferences: []*entity.NotificationPreference{
+					{Type: entity.NotificationRelease, InApp: true, Webhook: true},
+				}}).Return(saved, nil)
+				p.EXPECT().ToNotificationSettingsView(saved).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "SetSettings: 422 on invalid settings",
+			body: `{"email":"not an email"}`,
+			setup: func(interactor *usecase.MockNotificationInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().SetSettings(ctx, userId, gomock.Any()).Return(nil, entity.ErrInvalidNotificationSettings)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "SetSettings: 422 on bad body",
+			body:           `{"preferences":`,
+			setup:          func(interactor *usecase.MockNotificationInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockNotificationInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/notifications/preferences", bytes.NewBufferString(tc.body))
+			c.Set("user-id", userId)
+
+			handlers.NewNotificationHandlers(interactor, p).SetSettings(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
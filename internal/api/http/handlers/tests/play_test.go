@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_playHandlers_Record(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockPlayInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type args struct {
+		ctx    context.Context
+		userId uuid.UUID
+		body   string
+	}
+	type testCase struct {
+		name           string
+		args           args
+		setup          func(a args, f fields)
+		expectedStatus int
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	body := `{"client_event_id":"6cfc3a4d-28ae-4b13-9a11-d2c335ad8658","music_id":"ff578289-cdca-406e-9a57-f8c773f0cd15","event_type":"start"}`
+
+	cases := []testCase{
+		{
+			name: "Record: 201",
+			args: args{ctx: context.Background(), userId: userId, body: body},
+			setup: func(a args, f fields) {
+				f.interactor.EXPECT().Record(a.ctx, a.userId, gomock.Any()).Return(true, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Record: 200 on duplicate",
+			args: args{ctx: context.Background(), userId: userId, body: body},
+			setup: func(a args, f fields) {
+				f.interactor.EXPECT().Record(a.ctx, a.userId, gomock.Any()).Return(false, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Record: 422 on invalid event",
+			args: args{ctx: context.Background(), userId: userId, body: body},
+			setup: func(a args, f fields) {
+				f.interactor.EXPECT().Record(a.ctx, a.userId, gomock.Any()).
+					Return(false, fmt.Errorf("%w: unknown event type", entity.ErrInvalidPlayEvent))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Record: 404 on unknown music",
+			args: args{ctx: context.Background(), userId: userId, body: body},
+			setup: func(a args, f fields) {
+				f.interactor.EXPECT().Record(a.ctx, a.userId, gomock.Any()).
+					Return(false, fmt.Errorf("%w: ff578289-cdca-406e-9a57-f8c773f0cd15", entity.ErrUnknownPlayMusic))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Record: 422 on malformed body",
+			args:           args{ctx: context.Background(), userId: userId, body: "{"},
+			setup:          func(a args, f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Record: 500",
+			args: args{ctx: context.Background(), userId: userId, body: body},
+			setup: func(a args, f fields) {
+				f.interactor.EXPECT().Record(a.ctx, a.userId, gomock.Any()).Return(false, fmt.Errorf("can't record"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "Record: 401",
+			args:           args{ctx: context.Background(), userId: uuid.Nil, body: body},
+			setup:          func(a args, f fields) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockPlayInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewPlayHandlers(f.interactor, f.presenter)
+
+			tc.setup(tc.args, f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/plays", bytes.NewBufferString(tc.args.body))
+			if tc.args.userId != uuid.Nil {
+				c.Set("user-id", tc.args.userId)
+			}
+
+			h.Record(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_playHandlers_RecordBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interactor := usecase.NewMockPlayInteractor(ctrl)
+	mockPresenter := presenter.NewMockPresenter(ctrl)
+	h := handlers.NewPlayHandlers(interactor, mockPresenter)
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	unknownId := uuid.MustParse("0d5e7a8c-3f41-4b6e-9c2d-7a1b8e4f6c30")
+	result := &entity.PlayBatchResult{Accepted: 1, Duplicates: 1, UnknownMusicIDs: []uuid.UUID{unknownId}}
+	resultView := &view.PlayBatchResultView{Accepted: 1, Duplicates: 1, UnknownMusicIDs: []string{unknownId.String()}}
+
+	interactor.EXPECT().RecordBatch(context.Background(), userId, gomock.Len(2)).Return(result, nil)
+	mockPresenter.EXPECT().ToPlayBatchResultView(result).Return(resultView)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/plays/batch", bytes.NewBufferString(
+		`{"events":[{"music_id":"ff578289-cdca-406e-9a57-f8c773f0cd15","event_type":"start"},`+
+			`{"music_id":"ff578289-cdca-406e-9a57-f8c773f0cd15","event_type":"complete","position":180}]}`,
+	))
+	c.Set("user-id", userId)
+
+	h.RecordBatch(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, MustMarshal(resultView), w.Body.String())
+}
+
+func Test_playHandlers_GetHistory(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockPlayInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type args struct {
+		ctx    context.Context
+		userId uuid.UUID
+		query  string
+	}
+	type testCase struct {
+		name           string
+		args           args
+		setup          func(a args, f fields)
+		expectedStatus int
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	cases := []testCase{
+		{
+			name: "GetHistory: 200",
+			args: args{ctx: context.Background(), userId: userId, query: "?from=2023-01-01&limit=10"},
+			setup: func(a args, f fields) {
+				events := []*entity.PlayEventDB{{ID: uuid.MustParse("31313131-3131-4131-b131-313131313131")}}
+				f.interactor.EXPECT().GetHistory(a.ctx, a.userId, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uuid.UUID, filter *entity.PlayHistoryFilter) ([]*entity.PlayEventDB, error) {
+						assert.Equal(t, 10, filter.Limit)
+						assert.Equal(t, 2023, filter.From.Year())
+						return events, nil
+					})
+				f.presenter.EXPECT().ToListPlayEventView(events).Return([]*view.PlayEventView{})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "GetHistory: 422 on invalid date",
+			args:           args{ctx: context.Background(), userId: userId, query: "?from=yesterday"},
+			setup:          func(a args, f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "GetHistory: 500",
+			args: args{ctx: context.Background(), userId: userId},
+			setup: func(a args, f fields) {
+				f.interactor.EXPECT().GetHistory(a.ctx, a.userId, gomock.Any()).Return(nil, fmt.Errorf("can't get history"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockPlayInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewPlayHandlers(f.interactor, f.presenter)
+
+			tc.setup(tc.args, f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/users/me/history"+tc.args.query, nil)
+			c.Set("user-id", tc.args.userId)
+
+			h.GetHistory(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_playHandlers_DeleteHistoryEvent(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		userId  uuid.UUID
+		eventId string
+	}
+	type testCase struct {
+		name           string
+		args           args
+		setup          func(a args, i *usecase.MockPlayInteractor)
+		expectedStatus int
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	eventId := "31313131-3131-4131-b131-313131313131"
+
+	cases := []testCase{
+		{
+			name: "DeleteHistoryEvent: 204",
+			args: args{ctx: context.Background(), userId: userId, eventId: eventId},
+			setup: func(a args, i *usecase.MockPlayInteractor) {
+				i.EXPECT().DeleteHistoryEvent(a.ctx, a.userId, uuid.MustParse(a.eventId)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "DeleteHistoryEvent: 404",
+			args: args{ctx: context.Background(), userId: userId, eventId: eventId},
+			setup: func(a args, i *usecase.MockPlayInteractor) {
+				i.EXPECT().DeleteHistoryEvent(a.ctx, a.userId, uuid.MustParse(a.eventId)).
+					Return(fmt.Errorf("/repository/play.Delete: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "DeleteHistoryEvent: 422",
+			args:           args{ctx: context.Background(), userId: userId, eventId: "not-uuid"},
+			setup:          func(a args, i *usecase.MockPlayInteractor) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockPlayInteractor(ctrl)
+			h := handlers.NewPlayHandlers(interactor, presenter.NewMockPresenter(ctrl))
+
+			tc.setup(tc.args, interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: tc.args.eventId}}
+			c.Set("user-id", tc.args.userId)
+
+			h.DeleteHistoryEvent(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
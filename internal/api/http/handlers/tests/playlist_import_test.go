@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_playlistImportHandlers_Import(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	report := &entity.PlaylistImportReport{Total: 1, Matched: 1}
+
+	cases := []struct {
+		name           string
+		fileName       string
+		fields         map[string]string
+		setup          func(interactor *usecase.MockPlaylistImportInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}{
+		{
+			name:     "Import: 201",
+			fileName: "road trip.m3u8",
+			fields:   map[string]string{"is_public": "true"},
+			setup: func(interactor *usecase.MockPlaylistImportInteractor, p *presenter.MockPresenter) {
+				request := &entity.PlaylistImport{Format: entity.ImportFormatM3U, FileName: "road trip.m3u8", IsPublic: true}
+				interactor.EXPECT().Import(ctx, userId, request, gomock.Any()).Return(report, nil)
+				p.EXPECT().ToPlaylistImportReportView(report).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:     "Import: 201 with explicit format",
+			fileName: "export.txt",
+			fields:   map[string]string{"format": "csv", "name": "Favourites"},
+			setup: func(interactor *usecase.MockPlaylistImportInteractor, p *presenter.MockPresenter) {
+				request := &entity.PlaylistImport{Format: entity.ImportFormatCSV, FileName: "export.txt", Name: "Favourites"}
+				interactor.EXPECT().Import(ctx, userId, request, gomock.Any()).Return(report, nil)
+				p.EXPECT().ToPlaylistImportReportView(report).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Import: 422 on unknown extension",
+			fileName:       "playlist.pls",
+			setup:          func(interactor *usecase.MockPlaylistImportInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Import: 422 on malformed file",
+			fileName: "playlist.xspf",
+			setup: func(interactor *usecase.MockPlaylistImportInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().Import(ctx, userId, gomock.Any(), gomock.Any()).Return(nil, entity.ErrInvalidImport)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Import: 422 on invalid name",
+			fileName: "playlist.csv",
+			setup: func(interactor *usecase.MockPlaylistImportInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().Import(ctx, userId, gomock.Any(), gomock.Any()).Return(nil, entity.ErrInvalidPlaylist)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockPlaylistImportInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			for key, value := range tc.fields {
+				assert.NoError(t, writer.WriteField(key, value))
+			}
+			part, err := writer.CreateFormFile("file", tc.fileName)
+			assert.NoError(t, err)
+			_, err = part.Write([]byte("#EXTM3U\n"))
+			assert.NoError(t, err)
+			assert.NoError(t, writer.Close())
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/playlists/import", body)
+			c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+			c.Set("user-id", userId)
+
+			handlers.NewPlaylistImportHandlers(interactor, p).Import(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_popularityHandlers_GetPopular(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockPopularityInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		query          string
+		setup          func(f fields)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	musics := []*entity.MusicPopularityDB{{Plays: 3, Likes: 1, Score: 8}}
+	musicViews := []*view.PopularMusicView{{Plays: 3, Likes: 1, Score: 8}}
+
+	cases := []testCase{
+		{
+			name:  "GetPopular: 200",
+			query: "?window=week&limit=10",
+			setup: func(f fields) {
+				f.interactor.EXPECT().GetPopular(ctx, entity.PopularityWeek, 10, 0, false).Return(musics, nil)
+				f.presenter.EXPECT().ToListPopularMusicView(musics).Return(musicViews)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "GetPopular: 422 on unknown window",
+			query: "?window=year",
+			setup: func(f fields) {
+				f.interactor.EXPECT().GetPopular(ctx, "year", entity.DefaultChartLimit, 0, false).
+					Return(nil, fmt.Errorf("%w: year", entity.ErrUnknownPopularityWindow))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "GetPopular: 422 on invalid limit",
+			query:          "?limit=many",
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "GetPopular: 500",
+			query: "",
+			setup: func(f fields) {
+				f.interactor.EXPECT().GetPopular(ctx, "", entity.DefaultChartLimit, 0, false).Return(nil, fmt.Errorf("can't get popular"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockPopularityInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewPopularityHandlers(f.interactor, f.presenter)
+
+			tc.setup(f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/music/popular"+tc.query, nil)
+
+			h.GetPopular(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+			if tc.expectedStatus == http.StatusOK {
+				assert.JSONEq(t, MustMarshal(musicViews), w.Body.String())
+			}
+		})
+	}
+}
+
+func Test_popularityHandlers_GetTrending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interactor := usecase.NewMockPopularityInteractor(ctrl)
+	mockPresenter := presenter.NewMockPresenter(ctrl)
+	h := handlers.NewPopularityHandlers(interactor, mockPresenter)
+
+	musics := []*entity.MusicPopularityDB{{Score: 1.5}}
+	interactor.EXPECT().GetTrending(context.Background(), 5, 10, false).Return(musics, nil)
+	mockPresenter.EXPECT().ToListPopularMusicView(musics).Return([]*view.PopularMusicView{{Score: 1.5}})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/music/trending?limit=5&offset=10", nil)
+
+	h.GetTrending(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ratingHandlers_Set(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockRatingInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		musicId        string
+		body           string
+		setup          func(f fields)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := "ff578289-cdca-406e-9a57-f8c773f0cd15"
+	summary := &entity.RatingSummary{MusicID: uuid.MustParse(musicId), Rating: 4, Average: 4, Count: 1}
+
+	cases := []testCase{
+		{
+			name:    "Set: 200",
+			musicId: musicId,
+			body:    `{"rating":4}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Set(ctx, userId, uuid.MustParse(musicId), &entity.RatingCreate{Rating: 4}).Return(summary, nil)
+				f.presenter.EXPECT().ToRatingSummaryView(summary).Return(&view.RatingView{MusicID: musicId, Rating: 4, Average: 4, Count: 1})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Set: 422 on invalid rating",
+			musicId: musicId,
+			body:    `{"rating":10}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Set(ctx, userId, uuid.MustParse(musicId), &entity.RatingCreate{Rating: 10}).
+					Return(nil, fmt.Errorf("%w: must be between 1 and 5", entity.ErrInvalidRating))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "Set: 404",
+			musicId: musicId,
+			body:    `{"rating":4}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Set(ctx, userId, uuid.MustParse(musicId), &entity.RatingCreate{Rating: 4}).
+					Return(nil, fmt.Errorf("/repository/rating.Set: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Set: 422 on malformed body",
+			musicId:        musicId,
+			body:           `{"rating":`,
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Set: 422 on invalid id",
+			musicId:        "not-uuid",
+			body:           `{"rating":4}`,
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "Set: 500",
+			musicId: musicId,
+			body:    `{"rating":4}`,
+			setup: func(f fields) {
+				f.interactor.EXPECT().Set(ctx, userId, uuid.MustParse(musicId), &entity.RatingCreate{Rating: 4}).
+					Return(nil, fmt.Errorf("can't set rating"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockRatingInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewRatingHandlers(f.interactor, f.presenter)
+
+			tc.setup(f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/music/"+tc.musicId+"/rating", bytes.NewBufferString(tc.body))
+			c.Params = gin.Params{{Key: "id", Value: tc.musicId}}
+			c.Set("user-id", userId)
+
+			h.Set(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_ratingHandlers_Clear(t *testing.T) {
+	type testCase struct {
+		name           string
+		setup          func(i *usecase.MockRatingInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	summary := &entity.RatingSummary{MusicID: musicId}
+
+	cases := []testCase{
+		{
+			name: "Clear: 200",
+			setup: func(i *usecase.MockRatingInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().Clear(ctx, userId, musicId).Return(summary, nil)
+				p.EXPECT().ToRatingSummaryView(summary).Return(&view.RatingView{MusicID: musicId.String()})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Clear: 404",
+			setup: func(i *usecase.MockRatingInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().Clear(ctx, userId, musicId).Return(nil, fmt.Errorf("/repository/rating.Clear: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockRatingInteractor(ctrl)
+			mockPresenter := presenter.NewMockPresenter(ctrl)
+			h := handlers.NewRatingHandlers(interactor, mockPresenter)
+
+			tc.setup(interactor, mockPresenter)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: musicId.String()}}
+			c.Set("user-id", userId)
+
+			h.Clear(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_ratingHandlers_GetLibrary(t *testing.T) {
+	type testCase struct {
+		name           string
+		query          string
+		setup          func(i *usecase.MockRatingInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musics := []*entity.LibraryMusicDB{{Liked: true}}
+
+	cases := []testCase{
+		{
+			name:  "GetLibrary: 200",
+			query: "?sort=rating",
+			setup: func(i *usecase.MockRatingInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().GetLibrary(ctx, userId, "rating").Return(musics, nil)
+				p.EXPECT().ToListLibraryMusicView(musics).Return([]*view.LibraryMusicView{{Liked: true}})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "GetLibrary: 422",
+			query: "?sort=plays",
+			setup: func(i *usecase.MockRatingInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().GetLibrary(ctx, userId, "plays").Return(nil, entity.ErrUnknownLibrarySort)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "GetLibrary: 500",
+			query: "",
+			setup: func(i *usecase.MockRatingInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().GetLibrary(ctx, userId, "").Return(nil, fmt.Errorf("can't get library"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockRatingInteractor(ctrl)
+			mockPresenter := presenter.NewMockPresenter(ctrl)
+			h := handlers.NewRatingHandlers(interactor, mockPresenter)
+
+			tc.setup(interactor, mockPresenter)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/users/me/library"+tc.query, nil)
+			c.Set("user-id", userId)
+
+			h.GetLibrary(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_recommendationHandlers_GetForUser(t *testing.T) {
+	type fields struct {
+		interactor *usecase.MockRecommendationInteractor
+		presenter  *presenter.MockPresenter
+	}
+	type testCase struct {
+		name           string
+		userId         uuid.UUID
+		query          string
+		setup          func(f fields)
+		expectedStatus int
+	}
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musics := []*entity.RecommendationDB{{Score: 2, BecauseName: "Song1"}}
+
+	cases := []testCase{
+		{
+			name:   "GetForUser: 200",
+			userId: userId,
+			query:  "?limit=5",
+			setup: func(f fields) {
+				f.interactor.EXPECT().GetForUser(ctx, userId, 5, 0).Return(musics, nil)
+				f.presenter.EXPECT().ToListRecommendationView(musics).Return([]*view.RecommendationView{{Score: 2, BecauseName: "Song1"}})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "GetForUser: 401",
+			userId:         uuid.Nil,
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "GetForUser: 422",
+			userId:         userId,
+			query:          "?offset=abc",
+			setup:          func(f fields) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "GetForUser: 500",
+			userId: userId,
+			setup: func(f fields) {
+				f.interactor.EXPECT().GetForUser(ctx, userId, entity.DefaultRecommendationLimit, 0).Return(nil, fmt.Errorf("can't get recommendations"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				interactor: usecase.NewMockRecommendationInteractor(ctrl),
+				presenter:  presenter.NewMockPresenter(ctrl),
+			}
+
+			h := handlers.NewRecommendationHandlers(f.interactor, f.presenter)
+
+			tc.setup(f)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/users/me/recommendations"+tc.query, nil)
+			if tc.userId != uuid.Nil {
+				c.Set("user-id", tc.userId)
+			}
+
+			h.GetForUser(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_recommendationHandlers_GetSimilar(t *testing.T) {
+	type testCase struct {
+		name           string
+		musicId        string
+		setup          func(i *usecase.MockRecommendationInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := "ff578289-cdca-406e-9a57-f8c773f0cd15"
+	musics := []*entity.SimilarMusicDB{{CoLikes: 4, Score: 0.8}}
+
+	cases := []testCase{
+		{
+			name:    "GetSimilar: 200",
+			musicId: musicId,
+			setup: func(i *usecase.MockRecommendationInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().GetSimilar(context.Background(), userId, uuid.MustParse(musicId), entity.DefaultRecommendationLimit, 0).Return(musics, nil)
+				p.EXPECT().ToListSimilarMusicView(musics).Return([]*view.SimilarMusicView{{CoLikes: 4, Score: 0.8}})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "GetSimilar: 422",
+			musicId:        "not-uuid",
+			setup:          func(i *usecase.MockRecommendationInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockRecommendationInteractor(ctrl)
+			mockPresenter := presenter.NewMockPresenter(ctrl)
+			h := handlers.NewRecommendationHandlers(interactor, mockPresenter)
+
+			tc.setup(interactor, mockPresenter)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/music/"+tc.musicId+"/similar", nil)
+			c.Params = gin.Params{{Key: "id", Value: tc.musicId}}
+			c.Set("user-id", userId)
+
+			h.GetSimilar(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
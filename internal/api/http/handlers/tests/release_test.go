@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_releaseHandlers_SetSchedule(t *testing.T) {
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	publishAt := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+	music := &entity.MusicDB{Id: musicId, Name: "Song1", PublishAt: publishAt}
+
+	cases := []struct {
+		name           string
+		id             string
+		body           string
+		setup          func(interactor *usecase.MockReleaseInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}{
+		{
+			name: "SetSchedule: 200",
+			id:   musicId.String(),
+			body: `{"publish_at":"2023-04-01T00:00:00Z"}`,
+			setup: func(interactor *usecase.MockReleaseInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().SetSchedule(ctx, musicId, &entity.MusicSchedule{PublishAt: publishAt}).Return(music, nil)
+				p.EXPECT().ToScheduledMusicView(music).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "SetSchedule: 422 on invalid schedule",
+			id:   musicId.String(),
+			body: `{"publish_at":"2023-04-01T00:00:00Z","takedown_at":"2023-03-01T00:00:00Z"}`,
+			setup: func(interactor *usecase.MockReleaseInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().SetSchedule(ctx, musicId, gomock.Any()).Return(nil, entity.ErrInvalidMusicSchedule)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "SetSchedule: 404",
+			id:   musicId.String(),
+			body: `{"publish_at":"2023-04-01T00:00:00Z"}`,
+			setup: func(interactor *usecase.MockReleaseInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().SetSchedule(ctx, musicId, gomock.Any()).Return(nil, fmt.Errorf("/repository/release.SetSchedule: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "SetSchedule: 422 on bad body",
+			id:             musicId.String(),
+			body:           `{"publish_at":"tomorrow"}`,
+			setup:          func(interactor *usecase.MockReleaseInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockReleaseInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/music/"+tc.id+"/schedule", strings.NewReader(tc.body))
+			c.Params = gin.Params{{Key: "id", Value: tc.id}}
+
+			handlers.NewReleaseHandlers(interactor, p).SetSchedule(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_releaseHandlers_GetScheduled(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interactor := usecase.NewMockReleaseInteractor(ctrl)
+	p := presenter.NewMockPresenter(ctrl)
+	interactor.EXPECT().GetScheduled(ctx, 10, 0).Return(nil, nil)
+	p.EXPECT().ToListScheduledMusicView(gomock.Nil()).Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/music/scheduled?limit=10", nil)
+
+	handlers.NewReleaseHandlers(interactor, p).GetScheduled(c)
+
+	assert.Equal(t, http.StatusOK, c.Writer.Status())
+}
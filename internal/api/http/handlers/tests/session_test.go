@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sessionHandlers_GetMine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	sessionId := uuid.MustParse("0b7e3c1a-4a1f-4d5e-9c55-6f2f8c3e1d20")
+	sessions := []*entity.SessionDB{{ID: sessionId, UserID: userId, DeviceName: "Pixel 8"}}
+
+	interactor := usecase.NewMockSessionInteractor(ctrl)
+	p := presenter.NewMockPresenter(ctrl)
+	h := handlers.NewSessionHandlers(interactor, p)
+
+	interactor.EXPECT().GetByUser(context.Background(), userId).Return(sessions, nil)
+	p.EXPECT().ToListSessionView(sessions, sessionId).Return([]*view.SessionView{{ID: sessionId.String(), Current: true}})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user-id", userId)
+	c.Set("session-id", sessionId)
+
+	h.GetMine(c)
+
+	assert.Equal(t, http.StatusOK, c.Writer.Status())
+	assert.Contains(t, w.Body.String(), `"current":true`)
+}
+
+func Test_sessionHandlers_RevokeMine(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		userId    uuid.UUID
+		sessionId string
+	}
+	type testCase struct {
+		name           string
+		args           args
+		setup          func(a args, i *usecase.MockSessionInteractor)
+		expectedStatus int
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	sessionId := "0b7e3c1a-4a1f-4d5e-9c55-6f2f8c3e1d20"
+
+	cases := []testCase{
+		{
+			name: "RevokeMine: 204",
+			args: args{ctx: context.Background(), userId: userId, sessionId: sessionId},
+			setup: func(a args, i *usecase.MockSessionInteractor) {
+				i.EXPECT().Revoke(a.ctx, a.userId, uuid.MustParse(a.sessionId)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "RevokeMine: 404",
+			args: args{ctx: context.Background(), userId: userId, sessionId: sessionId},
+			setup: func(a args, i *usecase.MockSessionInteractor) {
+				i.EXPECT().Revoke(a.ctx, a.userId, uuid.MustParse(a.sessionId)).
+					Return(fmt.Errorf("/repository/session.Revoke: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "RevokeMine: 422",
+			args:           args{ctx: context.Background(), userId: userId, sessionId: "not-uuid"},
+			setup:          func(a args, i *usecase.MockSessionInteractor) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "RevokeMine: 500",
+			args: args{ctx: context.Background(), userId: userId, sessionId: sessionId},
+			setup: func(a args, i *usecase.MockSessionInteractor) {
+				i.EXPECT().Revoke(a.ctx, a.userId, uuid.MustParse(a.sessionId)).Return(fmt.Errorf("connection refused"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockSessionInteractor(ctrl)
+			h := handlers.NewSessionHandlers(interactor, presenter.NewMockPresenter(ctrl))
+
+			tc.setup(tc.args, interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: tc.args.sessionId}}
+			c.Set("user-id", tc.args.userId)
+
+			h.RevokeMine(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_shareHandlers_CreateForPlaylist(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	playlistId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	maxPlays := 5
+	created := &entity.ShareLinkCreated{Link: &entity.ShareLinkDB{}, Token: "token"}
+
+	cases := []struct {
+		name           string
+		id             string
+		body           string
+		setup          func(interactor *usecase.MockShareInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}{
+		{
+			name: "CreateForPlaylist: 201 with empty body",
+			id:   playlistId.String(),
+			body: "",
+			setup: func(interactor *usecase.MockShareInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().CreateForPlaylist(ctx, userId, playlistId, &entity.ShareLinkCreate{}).Return(created, nil)
+				p.EXPECT().ToShareLinkCreatedView(created).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "CreateForPlaylist: 201 with play limit",
+			id:   playlistId.String(),
+			body: `{"max_plays":5}`,
+			setup: func(interactor *usecase.MockShareInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().CreateForPlaylist(ctx, userId, playlistId, &entity.ShareLinkCreate{MaxPlays: &maxPlays}).Return(created, nil)
+				p.EXPECT().ToShareLinkCreatedView(created).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "CreateForPlaylist: 403",
+			id:   playlistId.String(),
+			body: "",
+			setup: func(interactor *usecase.MockShareInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().CreateForPlaylist(ctx, userId, playlistId, gomock.Any()).Return(nil, entity.ErrPlaylistForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "CreateForPlaylist: 422 on invalid link",
+			id:   playlistId.String(),
+			body: `{"max_plays":0}`,
+			setup: func(interactor *usecase.MockShareInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().CreateForPlaylist(ctx, userId, playlistId, gomock.Any()).Return(nil, entity.ErrInvalidShareLink)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "CreateForPlaylist: 422 on bad body",
+			id:             playlistId.String(),
+			body:           `{"max_plays":`,
+			setup:          func(interactor *usecase.MockShareInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "CreateForPlaylist: 422 on bad id",
+			id:             "not-a-uuid",
+			body:           "",
+			setup:          func(interactor *usecase.MockShareInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockShareInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/playlists/"+tc.id+"/shares", bytes.NewBufferString(tc.body))
+			c.Params = gin.Params{{Key: "id", Value: tc.id}}
+			c.Set("user-id", userId)
+
+			handlers.NewShareHandlers(interactor, p).CreateForPlaylist(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_shareHandlers_Revoke(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	linkId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+
+	cases := []struct {
+		name           string
+		setup          func(interactor *usecase.MockShareInteractor)
+		expectedStatus int
+	}{
+		{
+			name: "Revoke: 204",
+			setup: func(interactor *usecase.MockShareInteractor) {
+				interactor.EXPECT().Revoke(ctx, userId, linkId).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "Revoke: 404 on link of another user",
+			setup: func(interactor *usecase.MockShareInteractor) {
+				interactor.EXPECT().Revoke(ctx, userId, linkId).Return(fmt.Errorf("/repository/share.Revoke: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockShareInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/shares/"+linkId.String(), nil)
+			c.Params = gin.Params{{Key: "id", Value: linkId.String()}}
+			c.Set("user-id", userId)
+
+			handlers.NewShareHandlers(interactor, p).Revoke(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_shareHandlers_StreamSharedTrack(t *testing.T) {
+	ctx := context.Background()
+	musicId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	link := &entity.ShareLinkDB{ID: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")}
+
+	cases := []struct {
+		name           string
+		id             string
+		link           *entity.ShareLinkDB
+		setup          func(interactor *usecase.MockShareInteractor)
+		expectedStatus int
+	}{
+		{
+			name: "StreamSharedTrack: 410 on exhausted link",
+			id:   musicId.String(),
+			link: link,
+			setup: func(interactor *usecase.MockShareInteractor) {
+				interactor.EXPECT().Stream(ctx, link, &musicId).Return(nil, entity.ErrShareLinkUnavailable)
+			},
+			expectedStatus: http.StatusGone,
+		},
+		{
+			name: "StreamSharedTrack: 404 on track outside playlist",
+			id:   musicId.String(),
+			link: link,
+			setup: func(interactor *usecase.MockShareInteractor) {
+				interactor.EXPECT().Stream(ctx, link, &musicId).Return(nil, fmt.Errorf("music is not in shared playlist: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "StreamSharedTrack: 404 without resolved link",
+			id:             musicId.String(),
+			setup:          func(interactor *usecase.MockShareInteractor) {},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "StreamSharedTrack: 422 on bad id",
+			id:             "not-a-uuid",
+			link:           link,
+			setup:          func(interactor *usecase.MockShareInteractor) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockShareInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/shared/token/stream/"+tc.id, nil)
+			c.Params = gin.Params{{Key: "token", Value: "token"}, {Key: "id", Value: tc.id}}
+			if tc.link != nil {
+				c.Set("share-link", tc.link)
+			}
+
+			handlers.NewShareHandlers(interactor, p).StreamSharedTrack(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_signingKeyHandlers_GetJWKS(t *testing.T) {
+	type testCase struct {
+		name           string
+		setup          func(i *usecase.MockSigningKeyInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}
+
+	keys := []*entity.SigningKey{{SigningKeyDB: &entity.SigningKeyDB{ID: "current-kid", Algorithm: entity.SigningAlgorithmEdDSA}}}
+
+	tests := []testCase{
+		{
+			name: "success",
+			setup: func(i *usecase.MockSigningKeyInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().GetKeys(context.Background()).Return(keys, nil)
+				p.EXPECT().ToJWKSView(keys).Return(&view.JWKSView{Keys: []*view.JWKView{{Kty: "OKP", Kid: "current-kid"}}})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "error: internal",
+			setup: func(i *usecase.MockSigningKeyInteractor, p *presenter.MockPresenter) {
+				i.EXPECT().GetKeys(context.Background()).Return(nil, errors.New("db is down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockSigningKeyInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tt.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			handlers.NewSigningKeyHandlers(interactor, p).GetJWKS(c)
+
+			assert.Equal(t, tt.expectedStatus, c.Writer.Status())
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+				assert.Contains(t, w.Body.String(), `"kid":"current-kid"`)
+			}
+		})
+	}
+}
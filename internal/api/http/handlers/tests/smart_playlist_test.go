@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_smartPlaylistHandlers_Create(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	created := &entity.SmartPlaylistDB{ID: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"), UserID: userId}
+
+	cases := []struct {
+		name           string
+		body           string
+		setup          func(interactor *usecase.MockSmartPlaylistInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}{
+		{
+			name: "Create: 201",
+			body: `{"name":"Fresh","rules":{"group":"and","rules":[{"field":"duration","operator":"lt","value":240},{"field":"liked","operator":"eq","value":false}]},"limit":50}`,
+			setup: func(interactor *usecase.MockSmartPlaylistInteractor, p *presenter.MockPresenter) {
+				playlist := &entity.SmartPlaylistCreate{
+					Name: "Fresh",
+					Rules: &entity.SmartRule{Group: entity.SmartGroupAnd, Rules: []*entity.SmartRule{
+						{Field: entity.SmartFieldDuration, Operator: entity.SmartOpLt, Value: float64(240)},
+						{Field: entity.SmartFieldLiked, Operator: entity.SmartOpEq, Value: false},
+					}},
+					Limit: 50,
+				}
+				interactor.EXPECT().Create(ctx, userId, playlist).Return(created, nil)
+				p.EXPECT().ToSmartPlaylistView(created).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Create: 422 on invalid rules",
+			body: `{"name":"Fresh","rules":{"field":"genre","operator":"eq","value":"rock"}}`,
+			setup: func(interactor *usecase.MockSmartPlaylistInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().Create(ctx, userId, gomock.Any()).Return(nil, fmt.Errorf("%w: unknown field", entity.ErrInvalidSmartPlaylist))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Create: 422 on malformed body",
+			body:           `{"name":`,
+			setup:          func(interactor *usecase.MockSmartPlaylistInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockSmartPlaylistInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/smart-playlists", strings.NewReader(tc.body))
+			c.Set("user-id", userId)
+
+			handlers.NewSmartPlaylistHandlers(interactor, p).Create(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_smartPlaylistHandlers_GetTracks(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	playlistId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	music := []*entity.MusicDB{{Name: "Track"}}
+
+	cases := []struct {
+		name           string
+		id             string
+		setup          func(interactor *usecase.MockSmartPlaylistInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}{
+		{
+			name: "GetTracks: 200",
+			id:   playlistId.String(),
+			setup: func(interactor *usecase.MockSmartPlaylistInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().GetTracks(ctx, userId, playlistId).Return(music, nil)
+				p.EXPECT().ToListMusicView(music).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "GetTracks: 404",
+			id:   playlistId.String(),
+			setup: func(interactor *usecase.MockSmartPlaylistInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().GetTracks(ctx, userId, playlistId).Return(nil, fmt.Errorf("smart playlist is private: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "GetTracks: 422",
+			id:             "not-a-uuid",
+			setup:          func(interactor *usecase.MockSmartPlaylistInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockSmartPlaylistInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/smart-playlists/"+tc.id+"/tracks", nil)
+			c.Params = gin.Params{{Key: "id", Value: tc.id}}
+			c.Set("user-id", userId)
+
+			handlers.NewSmartPlaylistHandlers(interactor, p).GetTracks(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_statsHandlers_Get(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	from := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+	stats := &entity.ListeningStats{}
+
+	cases := []struct {
+		name           string
+		query          string
+		setup          func(interactor *usecase.MockStatsInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}{
+		{
+			name:  "Get: 200",
+			query: "?from=2023-01-01&to=2023-02-01",
+			setup: func(interactor *usecase.MockStatsInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().GetStats(ctx, userId, &entity.StatsFilter{From: from, To: to}).Return(stats, nil)
+				p.EXPECT().ToListeningStatsView(stats).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Get: 422 on invalid period",
+			query: "?from=2023-02-01&to=2023-01-01",
+			setup: func(interactor *usecase.MockStatsInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().GetStats(ctx, userId, gomock.Any()).Return(nil, entity.ErrInvalidStatsPeriod)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Get: 422 on bad from",
+			query:          "?from=yesterday",
+			setup:          func(interactor *usecase.MockStatsInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockStatsInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/users/me/stats"+tc.query, nil)
+			c.Set("user-id", userId)
+
+			handlers.NewStatsHandlers(interactor, p).Get(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_statsHandlers_GetYearReview(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	review := &entity.YearReviewDB{}
+
+	cases := []struct {
+		name           string
+		year           string
+		setup          func(interactor *usecase.MockStatsInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}{
+		{
+			name: "GetYearReview: 200",
+			year: "2023",
+			setup: func(interactor *usecase.MockStatsInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().GetYearReview(ctx, userId, 2023).Return(review, nil)
+				p.EXPECT().ToYearReviewView(review).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "GetYearReview: 404 before generation",
+			year: "2023",
+			setup: func(interactor *usecase.MockStatsInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().GetYearReview(ctx, userId, 2023).Return(nil, fmt.Errorf("/repository/stats.GetReview: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "GetYearReview: 422 on bad year",
+			year:           "last",
+			setup:          func(interactor *usecase.MockStatsInteractor, p *presenter.MockPresenter) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockStatsInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/users/me/year-in-review/"+tc.year, nil)
+			c.Params = gin.Params{{Key: "year", Value: tc.year}}
+			c.Set("user-id", userId)
+
+			handlers.NewStatsHandlers(interactor, p).GetYearReview(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
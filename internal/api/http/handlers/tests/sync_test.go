@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/api/http/view"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_syncHandlers_Sync(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	delta := &entity.SyncDelta{Cursor: &entity.SyncCursor{TxID: 100}}
+
+	cases := []struct {
+		name           string
+		query          string
+		setup          func(interactor *usecase.MockSyncInteractor, p *presenter.MockPresenter)
+		expectedStatus int
+	}{
+		{
+			name:  "Sync: 200 full",
+			query: "",
+			setup: func(interactor *usecase.MockSyncInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().Sync(ctx, userId, "").Return(delta, nil)
+				p.EXPECT().ToSyncView(delta).Return(&view.SyncView{})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Sync: 200 changes",
+			query: "?since=abc",
+			setup: func(interactor *usecase.MockSyncInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().Sync(ctx, userId, "abc").Return(delta, nil)
+				p.EXPECT().ToSyncView(delta).Return(&view.SyncView{})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Sync: 410 on expired token",
+			query: "?since=abc",
+			setup: func(interactor *usecase.MockSyncInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().Sync(ctx, userId, "abc").Return(nil, entity.ErrSyncTokenExpired)
+			},
+			expectedStatus: http.StatusGone,
+		},
+		{
+			name:  "Sync: 422 on invalid token",
+			query: "?since=abc",
+			setup: func(interactor *usecase.MockSyncInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().Sync(ctx, userId, "abc").Return(nil, entity.ErrInvalidSyncToken)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Sync: 500",
+			query: "?since=abc",
+			setup: func(interactor *usecase.MockSyncInteractor, p *presenter.MockPresenter) {
+				interactor.EXPECT().Sync(ctx, userId, "abc").Return(nil, errors.New("db is down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockSyncInteractor(ctrl)
+			p := presenter.NewMockPresenter(ctrl)
+			tc.setup(interactor, p)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/sync"+tc.query, nil)
+			c.Set("user-id", userId)
+
+			handlers.NewSyncHandlers(interactor, p).Sync(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/api/http/handlers"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_trashHandlers_RestoreUser(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	cases := []struct {
+		name           string
+		id             string
+		setup          func(interactor *usecase.MockTrashInteractor)
+		expectedStatus int
+	}{
+		{
+			name: "RestoreUser: 204",
+			id:   userId.String(),
+			setup: func(interactor *usecase.MockTrashInteractor) {
+				interactor.EXPECT().RestoreUser(ctx, userId).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "RestoreUser: 404",
+			id:   userId.String(),
+			setup: func(interactor *usecase.MockTrashInteractor) {
+				interactor.EXPECT().RestoreUser(ctx, userId).Return(fmt.Errorf("/repository/trash.RestoreUser: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "RestoreUser: 409 on taken username",
+			id:   userId.String(),
+			setup: func(interactor *usecase.MockTrashInteractor) {
+				interactor.EXPECT().RestoreUser(ctx, userId).Return(fmt.Errorf("/repository/trash.RestoreUser: %w", entity.ErrUsernameTaken))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "RestoreUser: 422",
+			id:             "abc",
+			setup:          func(interactor *usecase.MockTrashInteractor) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockTrashInteractor(ctrl)
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/trash/users/"+tc.id+"/restore", nil)
+			c.Params = gin.Params{{Key: "id", Value: tc.id}}
+
+			handlers.NewTrashHandlers(interactor, presenter.NewMockPresenter(ctrl)).RestoreUser(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+		})
+	}
+}
+
+func Test_trashHandlers_GetMusic(t *testing.T) {
+	ctx := context.Background()
+	deletedAt := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	music := []*entity.MusicDB{{
+		Id:        uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
+		Name:      "Song1",
+		Size:      500,
+		Duration:  "2:47",
+		DeletedAt: &deletedAt,
+	}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interactor := usecase.NewMockTrashInteractor(ctrl)
+	interactor.EXPECT().GetMusic(ctx, 10, 0).Return(music, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/trash/music?limit=10", nil)
+
+	handlers.NewTrashHandlers(interactor, presenter.NewPresenter()).GetMusic(c)
+
+	assert.Equal(t, http.StatusOK, c.Writer.Status())
+	assert.JSONEq(t, `[{"id":"ff578289-cdca-406e-9a57-f8c773f0cd15","name":"Song1","size":"500 B","duration":"2:47",`+
+		`"rating":0,"rating_count":0,"explicit":false,"artist_id":null,"deleted_at":"2023-03-24T09:00:00Z"}]`, w.Body.String())
+}
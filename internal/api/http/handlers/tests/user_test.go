@@ -1209,3 +1209,70 @@ func Test_userHandlers_ShowLikedTracks(t *testing.T) {
 		})
 	}
 }
+
+func Test_userHandlers_SetContentFilterHandler(t *testing.T) {
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	cases := []struct {
+		name           string
+		id             uuid.UUID
+		body           string
+		setup          func(interactor *usecase.MockUserInteractor)
+		expectedStatus int
+	}{
+		{
+			name: "SetContentFilterHandler: 204",
+			id:   userId,
+			body: `{"hide_explicit":true}`,
+			setup: func(interactor *usecase.MockUserInteractor) {
+				interactor.EXPECT().SetContentFilter(ctx, userId, &entity.ContentFilter{HideExplicit: true}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "SetContentFilterHandler: 404",
+			id:   userId,
+			body: `{"hide_explicit":false}`,
+			setup: func(interactor *usecase.MockUserInteractor) {
+				interactor.EXPECT().SetContentFilter(ctx, userId, &entity.ContentFilter{}).Return(fmt.Errorf("/repository/user.SetContentFilter: %w", sql.ErrNoRows))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "SetContentFilterHandler: 422",
+			id:             userId,
+			body:           `{"hide_explicit":1}`,
+			setup:          func(interactor *usecase.MockUserInteractor) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "SetContentFilterHandler: 401",
+			id:             uuid.Nil,
+			setup:          func(interactor *usecase.MockUserInteractor) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockUserInteractor(ctrl)
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/users/me/content-filter", bytes.NewBufferString(tc.body))
+			if tc.id != uuid.Nil {
+				c.Set("user-id", tc.id)
+			}
+
+			handlers.NewUserHandlers(interactor, presenter.NewMockPresenter(ctrl)).SetContentFilterHandler(c)
+
+			if c.Writer.Status() != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, c.Writer.Status())
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/api/http/presenter"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type trashHandlers struct {
+	interactor usecase.TrashInteractor
+	presenter  presenter.Presenter
+}
+
+func NewTrashHandlers(interactor usecase.TrashInteractor, presenter presenter.Presenter) *trashHandlers {
+	return &trashHandlers{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+// GetMusicHandler godoc
+// @Summary Удаленные треки
+// @Description Получение треков из корзины, начиная с удаленных последними. Треки удаляются окончательно по истечении срока хранения корзины.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param limit query int false "Количество треков (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.TrashedMusicView "Удаленные треки"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 422 "Некорректные параметры"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /trash/music [get]
+func (h *trashHandlers) GetMusic(c *gin.Context) {
+	ctx := context.Background()
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultTrashLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	music, err := h.interactor.GetMusic(ctx, limit, offset)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/trash.GetMusic: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListTrashedMusicView(music))
+}
+
+// GetUsersHandler godoc
+// @Summary Удаленные пользователи
+// @Description Получение пользователей из корзины, начиная с удаленных последними. Пользователи удаляются окончательно по истечении срока хранения корзины.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Param limit query int false "Количество пользователей (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} []view.TrashedUserView "Удаленные пользователи"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 422 "Некорректные параметры"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /trash/users [get]
+func (h *trashHandlers) GetUsers(c *gin.Context) {
+	ctx := context.Background()
+
+	limit, offset, err := parsePageQuery(c, entity.DefaultTrashLimit)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	users, err := h.interactor.GetUsers(ctx, limit, offset)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/trash.GetUsers: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToListTrashedUserView(users))
+}
+
+// RestoreMusicHandler godoc
+// @Summary Восстановление трека
+// @Description Возвращение трека из корзины вместе с лайками, оценками, комментариями и историей изменений
+// @Tags Trash
+// @Accept json
+// @Produce plain
+// @Security JwtAuth
+// @Param id path string true "Идентификатор трека"
+// @Success 204 "Трек восстановлен"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 404 "Трека нет в корзине"
+// @Failure 422 "Некорректный идентификатор"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /trash/music/{id}/restore [post]
+func (h *trashHandlers) RestoreMusic(c *gin.Context) {
+	ctx := context.Background()
+
+	musicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.RestoreMusic(ctx, musicId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("music not found in trash: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/trash.RestoreMusic: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreUserHandler godoc
+// @Summary Восстановление пользователя
+// @Description Возвращение пользователя из корзины вместе с лайками, оценками и историей прослушиваний. Пользователя нельзя восстановить, если его имя занял другой пользователь.
+// @Tags Trash
+// @Accept json
+// @Produce plain
+// @Security JwtAuth
+// @Param id path string true "Идентификатор пользователя"
+// @Success 204 "Пользователь восстановлен"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 403 "Нет доступа"
+// @Failure 404 "Пользователя нет в корзине"
+// @Failure 409 "Имя пользователя занято"
+// @Failure 422 "Некорректный идентификатор"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /trash/users/{id}/restore [post]
+func (h *trashHandlers) RestoreUser(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't parse id: %w", err))
+		return
+	}
+
+	err = h.interactor.RestoreUser(ctx, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrUsernameTaken):
+			c.AbortWithError(http.StatusConflict, err)
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("user not found in trash: %w", err))
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/trash.RestoreUser: %w", err))
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
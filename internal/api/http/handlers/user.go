@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"music-backend-test/internal/api/http/presenter"
 	"music-backend-test/internal/entity"
@@ -115,7 +116,7 @@ func (h *userHandlers) UpdateMeHandler(c *gin.Context) {
 
 // DeleteMeHandler godoc
 // @Summary Удаление пользователя по JWT токену
-// @Description Удаление пользователя по его уникальному идентификатору из JWT токена.
+// @Description Перемещение пользователя из JWT токена в корзину. Лайки и оценки сохраняются до окончательного удаления по истечении срока хранения корзины.
 // @Tags Users
 // @Accept json
 // @Produce plain
@@ -266,7 +267,7 @@ func (h *userHandlers) UpdateHandler(c *gin.Context) {
 
 // DeleteHandler godoc
 // @Summary Удаление пользователя по ID
-// @Description Удаление пользователя по его уникальному идентификатору.
+// @Description Перемещение пользователя в корзину по его уникальному идентификатору. Лайки и оценки сохраняются до окончательного удаления по истечении срока хранения корзины.
 // @Tags Users
 // @Accept json
 // @Produce plain
@@ -374,7 +375,7 @@ func (h *userHandlers) DislikeTrack(c *gin.Context) {
 
 // ShowLikedTracksHandler godoc
 // @Summary Показать понравившиеся треки
-// @Description Получение списка понравившихся треков
+// @Description Получение списка понравившихся треков. Если пользователь скрыл ненормативный контент, такие треки не возвращаются.
 // @Tags Users
 // @Accept json
 // @Produce plain
@@ -402,3 +403,53 @@ func (h *userHandlers) ShowLikedTracks(c *gin.Context) {
 
 	c.JSON(http.StatusOK, h.presenter.ToListMusicView(data))
 }
+
+// SetContentFilterHandler godoc
+// @Summary Фильтр контента
+// @Description Включение или отключение фильтра ненормативного контента для текущего пользователя. С включенным фильтром такие треки не попадают в каталог, подборки, чарты и библиотеку, а их скачивание запрещено.
+// @Tags Users
+// @Accept json
+// @Produce plain
+// @Security JwtAuth
+// @Param request body entity.ContentFilter true "Настройки фильтра"
+// @Success 204 "Настройки сохранены"
+// @Failure 400 "Некорректный запрос"
+// @Failure 401 "Неавторизованный запрос"
+// @Failure 404 "Пользователь не найден"
+// @Failure 422 "Ошибка при обработке данных"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /users/me/content-filter [put]
+func (h *userHandlers) SetContentFilterHandler(c *gin.Context) {
+	ctx := context.Background()
+
+	userId, exists := c.Get("user-id")
+	if !exists {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+
+	var filter entity.ContentFilter
+	err = json.Unmarshal(body, &filter)
+	if err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		return
+	}
+
+	err = h.interactor.SetContentFilter(ctx, userId.(uuid.UUID), &filter)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("user not found: %w", err))
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/user.SetContentFilter: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
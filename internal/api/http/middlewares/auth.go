@@ -1,16 +1,23 @@
 package middlewares
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"music-backend-test/cmd/music-backend-test/config"
 	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
-func NewAuthMiddleware() gin.HandlerFunc {
+// NewAuthMiddleware проверяет подпись access-токена и сохраняет id пользователя в контексте под ключом "user-id",
+// а id сессии — под ключом "session-id". Токены отозванных сессий отклоняются; проверка отзыва кэшируется.
+// Токены, выданные до появления сессий, принимаются до истечения.
+func NewAuthMiddleware(keyInteractor usecase.SigningKeyInteractor, sessionInteractor usecase.SessionInteractor) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		cfg, err := config.GetAppConfig()
 		if err != nil {
@@ -34,13 +41,43 @@ func NewAuthMiddleware() gin.HandlerFunc {
 			c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("invalid token format"))
 		}
 
-		id, err := entity.ParseToken(tokenString)
+		id, sessionId, err := keyInteractor.ParseAccessToken(context.Background(), tokenString)
 		if err != nil {
-			c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("invalid token: %v", err))
+			if errors.Is(err, entity.ErrInvalidToken) {
+				c.AbortWithError(http.StatusUnauthorized, err)
+				return
+			}
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/signing_key.ParseAccessToken: %w", err))
 			return
 		}
 
+		if sessionId != uuid.Nil {
+			revoked, err := sessionInteractor.IsRevoked(context.Background(), id, sessionId)
+			if err != nil {
+				c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/session.IsRevoked: %w", err))
+				return
+			}
+			if revoked {
+				c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("session revoked"))
+				return
+			}
+			c.Set("session-id", sessionId)
+		}
+
 		c.Set("user-id", id)
 		c.Next()
 	}
 }
+
+// NewOptionalAuthMiddleware проверяет access-токен так же, как NewAuthMiddleware, если он передан,
+// а запрос без заголовка Authorization пропускает без id пользователя в контексте
+func NewOptionalAuthMiddleware(keyInteractor usecase.SigningKeyInteractor, sessionInteractor usecase.SessionInteractor) gin.HandlerFunc {
+	auth := NewAuthMiddleware(keyInteractor, sessionInteractor)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		auth(c)
+	}
+}
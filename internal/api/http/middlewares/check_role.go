@@ -24,12 +24,47 @@ func NewCheckRoleMiddleware(roles []string, userInteractor usecase.UserInteracto
 			c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("can't get user: %w", err))
 			return
 		}
+		// Для пользователя в корзине GetById возвращает nil без ошибки, а его токен еще может быть действителен
+		if user == nil {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
 
 		if !contains(roles, user.Role) {
 			c.AbortWithStatus(http.StatusForbidden)
 			return
 		}
 
+		c.Set("user-role", user.Role)
+		c.Next()
+	}
+}
+
+// NewUserRoleMiddleware сохраняет роль пользователя в контексте под ключом "user-role", не ограничивая доступ.
+// Используется там, где права или выдача зависят от пользователя, но доступ есть у всех пользователей.
+// Настройка фильтра ненормативного контента сохраняется под ключом "hide-explicit".
+func NewUserRoleMiddleware(userInteractor usecase.UserInteractor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId, exists := c.Get("user-id")
+		if !exists {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.Background()
+		user, err := userInteractor.GetById(ctx, userId.(uuid.UUID))
+		if err != nil {
+			c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("can't get user: %w", err))
+			return
+		}
+		// Для пользователя в корзине GetById возвращает nil без ошибки, а его токен еще может быть действителен
+		if user == nil {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		c.Set("user-role", user.Role)
+		c.Set("hide-explicit", user.HideExplicit)
 		c.Next()
 	}
 }
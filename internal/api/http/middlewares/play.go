@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// NewPlayTrackingMiddleware записывает прослушивание, если файл трека был успешно отдан пользователю
+func NewPlayTrackingMiddleware(playInteractor usecase.PlayInteractor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		status := c.Writer.Status()
+		if status != http.StatusOK && status != http.StatusPartialContent {
+			return
+		}
+
+		userId, exists := c.Get("user-id")
+		if !exists {
+			return
+		}
+
+		musicId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return
+		}
+
+		err = playInteractor.RecordStream(context.Background(), userId.(uuid.UUID), musicId)
+		if err != nil {
+			c.Error(fmt.Errorf("can't record stream play: %w", err))
+		}
+	}
+}
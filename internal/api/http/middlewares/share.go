@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// NewShareTokenMiddleware открывает доступ по токену ссылки из параметра пути "token" без авторизации пользователя.
+// Действующая ссылка сохраняется в контексте под ключом "share-link". Каждое обращение, в том числе
+// с неизвестным или недействующим токеном, записывается в журнал вместе с отданным треком из ключа "share-music-id".
+func NewShareTokenMiddleware(shareInteractor usecase.ShareInteractor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := context.Background()
+
+		link, err := shareInteractor.Resolve(ctx, c.Param("token"))
+		switch {
+		case err == nil:
+			c.Set("share-link", link)
+			c.Next()
+		case errors.Is(err, sql.ErrNoRows):
+			c.AbortWithError(http.StatusNotFound, fmt.Errorf("share link not found: %w", err))
+		case errors.Is(err, entity.ErrShareLinkUnavailable):
+			c.AbortWithError(http.StatusGone, err)
+		default:
+			c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("/usecase/share.Resolve: %w", err))
+		}
+
+		var musicId *uuid.UUID
+		if value, exists := c.Get("share-music-id"); exists {
+			id := value.(uuid.UUID)
+			musicId = &id
+		}
+
+		access := entity.NewShareAccess(link, musicId, c.FullPath(), c.Writer.Status(), c.ClientIP(), c.Request.UserAgent(), time.Now())
+		err = shareInteractor.RecordAccess(ctx, access)
+		if err != nil {
+			c.Error(fmt.Errorf("can't record share access: %w", err))
+		}
+	}
+}
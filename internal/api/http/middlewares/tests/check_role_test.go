@@ -0,0 +1,129 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/api/http/middlewares"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewCheckRoleMiddleware(t *testing.T) {
+	type testCase struct {
+		name           string
+		setup          func(i *usecase.MockUserInteractor)
+		expectedStatus int
+		expectedRole   string
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	cases := []testCase{
+		{
+			name: "admin: passed",
+			setup: func(i *usecase.MockUserInteractor) {
+				i.EXPECT().GetById(context.Background(), userId).Return(&entity.UserDB{ID: userId, Role: entity.AdminRole}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedRole:   entity.AdminRole,
+		},
+		{
+			name: "user: 403",
+			setup: func(i *usecase.MockUserInteractor) {
+				i.EXPECT().GetById(context.Background(), userId).Return(&entity.UserDB{ID: userId, Role: entity.UserRole}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "user in trash: 401",
+			setup: func(i *usecase.MockUserInteractor) {
+				i.EXPECT().GetById(context.Background(), userId).Return(nil, nil)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "error: 401",
+			setup: func(i *usecase.MockUserInteractor) {
+				i.EXPECT().GetById(context.Background(), userId).Return(nil, fmt.Errorf("can't get user"))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockUserInteractor(ctrl)
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/comments/reports", nil)
+			c.Set("user-id", userId)
+
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, interactor)(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+			assert.Equal(t, tc.expectedRole, c.GetString("user-role"))
+		})
+	}
+}
+
+func Test_NewUserRoleMiddleware(t *testing.T) {
+	type testCase struct {
+		name           string
+		setup          func(i *usecase.MockUserInteractor)
+		expectedStatus int
+		expectedRole   string
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	cases := []testCase{
+		{
+			name: "user: role and explicit filter saved",
+			setup: func(i *usecase.MockUserInteractor) {
+				i.EXPECT().GetById(context.Background(), userId).
+					Return(&entity.UserDB{ID: userId, Role: entity.UserRole, HideExplicit: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedRole:   entity.UserRole,
+		},
+		{
+			name: "user in trash: 401",
+			setup: func(i *usecase.MockUserInteractor) {
+				i.EXPECT().GetById(context.Background(), userId).Return(nil, nil)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockUserInteractor(ctrl)
+			tc.setup(interactor)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/music/popular", nil)
+			c.Set("user-id", userId)
+
+			middlewares.NewUserRoleMiddleware(interactor)(c)
+
+			assert.Equal(t, tc.expectedStatus, c.Writer.Status())
+			assert.Equal(t, tc.expectedRole, c.GetString("user-role"))
+			assert.Equal(t, tc.expectedStatus == http.StatusOK, c.GetBool("hide-explicit"))
+		})
+	}
+}
@@ -3,6 +3,8 @@ package presenter
 import (
 	"music-backend-test/internal/api/http/view"
 	"music-backend-test/internal/entity"
+
+	"github.com/google/uuid"
 )
 
 //go:generate mockgen -source=./interfaces.go -destination=./presenter_mock.go -package=presenter
@@ -12,5 +14,55 @@ type Presenter interface {
 	ToListUserView(users []*entity.UserDB) []*view.UserView
 	ToMusicView(*entity.MusicDB) *view.MusicView
 	ToListMusicView([]*entity.MusicDB) []*view.MusicView
-	ToTokenView(token *entity.Token) (*view.TokenView, error)
+	ToListPopularMusicView(musics []*entity.MusicPopularityDB) []*view.PopularMusicView
+	ToTokenView(tokens *entity.TokenPair) (*view.TokenView, error)
+	ToPlayEventView(event *entity.PlayEventDB) *view.PlayEventView
+	ToListPlayEventView(events []*entity.PlayEventDB) []*view.PlayEventView
+	ToPlayBatchResultView(result *entity.PlayBatchResult) *view.PlayBatchResultView
+	ToChartEntryView(entry *entity.ChartEntryDB) *view.ChartEntryView
+	ToListChartEntryView(entries []*entity.ChartEntryDB) []*view.ChartEntryView
+	ToChartView(entries []*entity.ChartEntryDB) *view.ChartView
+	ToListSimilarMusicView(musics []*entity.SimilarMusicDB) []*view.SimilarMusicView
+	ToListRecommendationView(musics []*entity.RecommendationDB) []*view.RecommendationView
+	ToRatingSummaryView(summary *entity.RatingSummary) *view.RatingView
+	ToListLibraryMusicView(musics []*entity.LibraryMusicDB) []*view.LibraryMusicView
+	ToCommentView(comment *entity.CommentDB) *view.CommentView
+	ToCommentPageView(page *entity.CommentPage) *view.CommentPageView
+	ToListModerationItemView(items []*entity.ModerationItemDB) []*view.ModerationItemView
+	ToLyricsView(lyrics *entity.Lyrics) *view.LyricsView
+	ToMusicRevisionView(revision *entity.MusicRevisionDB) *view.MusicRevisionView
+	ToListMusicRevisionView(revisions []*entity.MusicRevisionDB) []*view.MusicRevisionView
+	ToMusicRevisionDiffView(diff *entity.MusicRevisionDiff) *view.MusicRevisionDiffView
+	ToListTrashedMusicView(musics []*entity.MusicDB) []*view.TrashedMusicView
+	ToListTrashedUserView(users []*entity.UserDB) []*view.TrashedUserView
+	ToScheduledMusicView(music *entity.MusicDB) *view.ScheduledMusicView
+	ToListScheduledMusicView(musics []*entity.MusicDB) []*view.ScheduledMusicView
+	ToArtistView(artist *entity.ArtistDB) *view.ArtistView
+	ToPlaylistView(playlist *entity.PlaylistDB) *view.PlaylistView
+	ToListPlaylistView(playlists []*entity.PlaylistDB) []*view.PlaylistView
+	ToFollowingView(following *entity.Following) *view.FollowingView
+	ToFeedPageView(page *entity.FeedPage) *view.FeedPageView
+	ToNotificationView(notification *entity.NotificationDB) *view.NotificationView
+	ToNotificationPageView(page *entity.NotificationPage) *view.NotificationPageView
+	ToNotificationSettingsView(settings *entity.NotificationSettings) *view.NotificationSettingsView
+	ToShareLinkView(link *entity.ShareLinkDB) *view.ShareLinkView
+	ToListShareLinkView(links []*entity.ShareLinkDB) []*view.ShareLinkView
+	ToShareLinkCreatedView(created *entity.ShareLinkCreated) *view.ShareLinkCreatedView
+	ToSharedContentView(content *entity.SharedContent) *view.SharedContentView
+	ToListeningStatsView(stats *entity.ListeningStats) *view.ListeningStatsView
+	ToYearReviewView(review *entity.YearReviewDB) *view.YearReviewView
+	ToExportJobView(job *entity.ExportJobDB) *view.ExportJobView
+	ToPlaylistImportReportView(report *entity.PlaylistImportReport) *view.PlaylistImportReportView
+	ToSmartPlaylistView(playlist *entity.SmartPlaylistDB) *view.SmartPlaylistView
+	ToListSmartPlaylistView(playlists []*entity.SmartPlaylistDB) []*view.SmartPlaylistView
+	ToSyncView(delta *entity.SyncDelta) *view.SyncView
+	ToMusicUploadView(report *entity.MusicUploadReport) *view.MusicUploadView
+	ToListMusicDuplicateView(duplicates []*entity.MusicDuplicateDB) []*view.MusicDuplicateView
+	ToMusicMergeView(result *entity.MusicMergeResult) *view.MusicMergeView
+	ToSessionView(session *entity.SessionDB, currentId uuid.UUID) *view.SessionView
+	ToListSessionView(sessions []*entity.SessionDB, currentId uuid.UUID) []*view.SessionView
+	ToJWKView(key *entity.SigningKey) *view.JWKView
+	ToJWKSView(keys []*entity.SigningKey) *view.JWKSView
+	ToIdentityView(identity *entity.UserIdentityDB) *view.IdentityView
+	ToListIdentityView(identities []*entity.UserIdentityDB) []*view.IdentityView
 }
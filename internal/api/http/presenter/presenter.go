@@ -1,9 +1,16 @@
 package presenter
 
 import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
 	"fmt"
+	"math/big"
 	"music-backend-test/internal/api/http/view"
 	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type presenter struct{}
@@ -13,12 +20,20 @@ func NewPresenter() *presenter {
 }
 
 func (p *presenter) ToMusicView(music *entity.MusicDB) *view.MusicView {
-	return &view.MusicView{
-		ID:       music.Id.String(),
-		Name:     music.Name,
-		Size:     p.formatBytes(music.Size),
-		Duration: music.Duration,
+	musicView := &view.MusicView{
+		ID:          music.Id.String(),
+		Name:        music.Name,
+		Size:        p.formatBytes(music.Size),
+		Duration:    music.Duration,
+		Rating:      music.RatingAvg,
+		RatingCount: music.RatingCount,
+		Explicit:    music.Explicit,
+	}
+	if music.ArtistID != nil {
+		artistId := music.ArtistID.String()
+		musicView.ArtistID = &artistId
 	}
+	return musicView
 }
 
 func (p *presenter) formatBytes(bytes uint64) string {
@@ -57,22 +72,37 @@ func (p *presenter) ToListMusicView(musics []*entity.MusicDB) []*view.MusicView
 	return view
 }
 
-func (p *presenter) ToTokenView(token *entity.Token) (*view.TokenView, error) {
-	token_string, err := token.String()
-	if err != nil {
-		return nil, err
+func (p *presenter) ToListPopularMusicView(musics []*entity.MusicPopularityDB) []*view.PopularMusicView {
+	views := make([]*view.PopularMusicView, len(musics))
+	for i, music := range musics {
+		views[i] = &view.PopularMusicView{
+			MusicView: *p.ToMusicView(&music.MusicDB),
+			Plays:     music.Plays,
+			Likes:     music.Likes,
+			Score:     music.Score,
+		}
 	}
+	return views
+}
 
+func (p *presenter) ToTokenView(tokens *entity.TokenPair) (*view.TokenView, error) {
 	return &view.TokenView{
-		Token: token_string,
+		SessionID:        tokens.SessionID.String(),
+		Token:            tokens.Access,
+		ExpiresAt:        tokens.AccessExpiresAt.UTC().Format(time.RFC3339),
+		RefreshToken:     tokens.Refresh,
+		RefreshExpiresAt: tokens.RefreshExpiresAt.UTC().Format(time.RFC3339),
 	}, nil
 }
 
 func (p *presenter) ToUserView(user *entity.UserDB) *view.UserView {
 	return &view.UserView{
-		Id:       user.ID.String(),
-		Username: user.Username,
-		Role:     user.Role,
+		Id:             user.ID.String(),
+		Username:       user.Username,
+		Role:           user.Role,
+		HideExplicit:   user.HideExplicit,
+		ShareLikes:     user.ShareLikes,
+		SharePlaylists: user.SharePlaylists,
 	}
 }
 
@@ -83,3 +113,765 @@ func (p *presenter) ToListUserView(users []*entity.UserDB) []*view.UserView {
 	}
 	return view
 }
+
+func (p *presenter) ToPlayEventView(event *entity.PlayEventDB) *view.PlayEventView {
+	return &view.PlayEventView{
+		ID:        event.ID.String(),
+		MusicID:   event.MusicID.String(),
+		EventType: event.EventType,
+		Position:  event.Position,
+		Source:    event.Source,
+		PlayedAt:  event.PlayedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func (p *presenter) ToListPlayEventView(events []*entity.PlayEventDB) []*view.PlayEventView {
+	view := make([]*view.PlayEventView, len(events))
+	for i, event := range events {
+		view[i] = p.ToPlayEventView(event)
+	}
+	return view
+}
+
+func (p *presenter) ToPlayBatchResultView(result *entity.PlayBatchResult) *view.PlayBatchResultView {
+	unknown := make([]string, len(result.UnknownMusicIDs))
+	for i, id := range result.UnknownMusicIDs {
+		unknown[i] = id.String()
+	}
+	return &view.PlayBatchResultView{
+		Accepted:        result.Accepted,
+		Duplicates:      result.Duplicates,
+		UnknownMusicIDs: unknown,
+	}
+}
+
+func (p *presenter) ToChartEntryView(entry *entity.ChartEntryDB) *view.ChartEntryView {
+	return &view.ChartEntryView{
+		Week:             entry.Week.Format("2006-01-02"),
+		Position:         entry.Position,
+		PreviousPosition: entry.PreviousPosition,
+		PeakPosition:     entry.PeakPosition,
+		WeeksOnChart:     entry.WeeksOnChart,
+		Movement:         entry.Movement(),
+		Plays:            entry.Plays,
+		Likes:            entry.Likes,
+		Score:            entry.Score,
+		Music:            *p.ToMusicView(&entry.MusicDB),
+	}
+}
+
+func (p *presenter) ToListChartEntryView(entries []*entity.ChartEntryDB) []*view.ChartEntryView {
+	views := make([]*view.ChartEntryView, len(entries))
+	for i, entry := range entries {
+		views[i] = p.ToChartEntryView(entry)
+	}
+	return views
+}
+
+func (p *presenter) ToChartView(entries []*entity.ChartEntryDB) *view.ChartView {
+	chart := &view.ChartView{
+		Entries: p.ToListChartEntryView(entries),
+	}
+	if len(entries) > 0 {
+		chart.Week = entries[0].Week.Format("2006-01-02")
+	}
+	return chart
+}
+
+func (p *presenter) ToListSimilarMusicView(musics []*entity.SimilarMusicDB) []*view.SimilarMusicView {
+	views := make([]*view.SimilarMusicView, len(musics))
+	for i, music := range musics {
+		views[i] = &view.SimilarMusicView{
+			MusicView: *p.ToMusicView(&music.MusicDB),
+			CoLikes:   music.CoLikes,
+			Score:     music.Score,
+		}
+	}
+	return views
+}
+
+func (p *presenter) ToListRecommendationView(musics []*entity.RecommendationDB) []*view.RecommendationView {
+	views := make([]*view.RecommendationView, len(musics))
+	for i, music := range musics {
+		views[i] = &view.RecommendationView{
+			MusicView:   *p.ToMusicView(&music.MusicDB),
+			Score:       music.Score,
+			BecauseID:   music.BecauseID.String(),
+			BecauseName: music.BecauseName,
+		}
+	}
+	return views
+}
+
+func (p *presenter) ToRatingSummaryView(summary *entity.RatingSummary) *view.RatingView {
+	return &view.RatingView{
+		MusicID: summary.MusicID.String(),
+		Rating:  summary.Rating,
+		Average: summary.Average,
+		Count:   summary.Count,
+	}
+}
+
+func (p *presenter) ToListLibraryMusicView(musics []*entity.LibraryMusicDB) []*view.LibraryMusicView {
+	views := make([]*view.LibraryMusicView, len(musics))
+	for i, music := range musics {
+		views[i] = &view.LibraryMusicView{
+			MusicView:  *p.ToMusicView(&music.MusicDB),
+			UserRating: music.UserRating,
+			Liked:      music.Liked,
+		}
+	}
+	return views
+}
+
+func (p *presenter) ToCommentView(comment *entity.CommentDB) *view.CommentView {
+	commentView := &view.CommentView{
+		ID: comment.ID.String(),
+		Author: view.CommentAuthorView{
+			ID:       comment.UserID.String(),
+			Username: comment.Username,
+		},
+		Body:       comment.Body,
+		Status:     comment.Status,
+		ReplyCount: comment.ReplyCount,
+		CreatedAt:  comment.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if comment.MusicID != nil {
+		musicId := comment.MusicID.String()
+		commentView.MusicID = &musicId
+	}
+	if comment.PlaylistID != nil {
+		playlistId := comment.PlaylistID.String()
+		commentView.PlaylistID = &playlistId
+	}
+	if comment.ParentID != nil {
+		parentId := comment.ParentID.String()
+		commentView.ParentID = &parentId
+	}
+	if comment.EditedAt != nil {
+		editedAt := comment.EditedAt.UTC().Format(time.RFC3339)
+		commentView.EditedAt = &editedAt
+	}
+	return commentView
+}
+
+func (p *presenter) ToCommentPageView(page *entity.CommentPage) *view.CommentPageView {
+	views := make([]*view.CommentView, len(page.Comments))
+	for i, comment := range page.Comments {
+		views[i] = p.ToCommentView(comment)
+	}
+	return &view.CommentPageView{
+		Comments:   views,
+		NextCursor: page.NextCursor,
+	}
+}
+
+func (p *presenter) ToListModerationItemView(items []*entity.ModerationItemDB) []*view.ModerationItemView {
+	views := make([]*view.ModerationItemView, len(items))
+	for i, item := range items {
+		views[i] = &view.ModerationItemView{
+			CommentView:    *p.ToCommentView(&item.CommentDB),
+			Reports:        item.Reports,
+			LastReason:     item.LastReason,
+			LastReportedAt: item.LastReportedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return views
+}
+
+func (p *presenter) ToLyricsView(lyrics *entity.Lyrics) *view.LyricsView {
+	lyricsView := &view.LyricsView{
+		MusicID:            lyrics.MusicID.String(),
+		Language:           lyrics.Language,
+		AvailableLanguages: lyrics.Languages,
+		Plain:              lyrics.Plain,
+		Source:             lyrics.Source,
+		UpdatedAt:          lyrics.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	if lyrics.Synced != nil {
+		views := make([]*view.LyricLineView, len(lyrics.Lines))
+		for i, line := range lyrics.Lines {
+			views[i] = &view.LyricLineView{
+				TimeMs: line.Time.Milliseconds(),
+				Text:   line.Text,
+			}
+		}
+		lyricsView.Synced = views
+	}
+	return lyricsView
+}
+
+func (p *presenter) ToMusicRevisionView(revision *entity.MusicRevisionDB) *view.MusicRevisionView {
+	revisionView := &view.MusicRevisionView{
+		Version:      revision.Version,
+		Action:       revision.Action,
+		Name:         revision.Name,
+		Release:      revision.Release.Format("2006-01-02"),
+		FileName:     revision.FileName,
+		Size:         p.formatBytes(revision.Size),
+		Duration:     revision.Duration,
+		RevertedFrom: revision.RevertedFrom,
+		CreatedAt:    revision.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if revision.ChangedBy != nil {
+		revisionView.ChangedBy = &view.RevisionAuthorView{ID: revision.ChangedBy.String()}
+		if revision.Username != nil {
+			revisionView.ChangedBy.Username = *revision.Username
+		}
+	}
+	return revisionView
+}
+
+func (p *presenter) ToListMusicRevisionView(revisions []*entity.MusicRevisionDB) []*view.MusicRevisionView {
+	views := make([]*view.MusicRevisionView, len(revisions))
+	for i, revision := range revisions {
+		views[i] = p.ToMusicRevisionView(revision)
+	}
+	return views
+}
+
+func (p *presenter) ToMusicRevisionDiffView(diff *entity.MusicRevisionDiff) *view.MusicRevisionDiffView {
+	changes := make([]*view.MusicFieldChangeView, len(diff.Changes))
+	for i, change := range diff.Changes {
+		changes[i] = &view.MusicFieldChangeView{
+			Field: change.Field,
+			From:  change.From,
+			To:    change.To,
+		}
+	}
+	return &view.MusicRevisionDiffView{
+		MusicID: diff.MusicID.String(),
+		From:    p.ToMusicRevisionView(diff.From),
+		To:      p.ToMusicRevisionView(diff.To),
+		Changes: changes,
+	}
+}
+
+func (p *presenter) ToListTrashedMusicView(musics []*entity.MusicDB) []*view.TrashedMusicView {
+	views := make([]*view.TrashedMusicView, len(musics))
+	for i, music := range musics {
+		views[i] = &view.TrashedMusicView{
+			MusicView: *p.ToMusicView(music),
+			DeletedAt: p.formatDeletedAt(music.DeletedAt),
+		}
+	}
+	return views
+}
+
+func (p *presenter) ToListTrashedUserView(users []*entity.UserDB) []*view.TrashedUserView {
+	views := make([]*view.TrashedUserView, len(users))
+	for i, user := range users {
+		views[i] = &view.TrashedUserView{
+			UserView:  *p.ToUserView(user),
+			DeletedAt: p.formatDeletedAt(user.DeletedAt),
+		}
+	}
+	return views
+}
+
+func (p *presenter) ToScheduledMusicView(music *entity.MusicDB) *view.ScheduledMusicView {
+	scheduledView := &view.ScheduledMusicView{
+		MusicView: *p.ToMusicView(music),
+		PublishAt: music.PublishAt.UTC().Format(time.RFC3339),
+	}
+	if music.PublishedAt != nil {
+		publishedAt := music.PublishedAt.UTC().Format(time.RFC3339)
+		scheduledView.PublishedAt = &publishedAt
+	}
+	if music.TakedownAt != nil {
+		takedownAt := music.TakedownAt.UTC().Format(time.RFC3339)
+		scheduledView.TakedownAt = &takedownAt
+	}
+	return scheduledView
+}
+
+func (p *presenter) ToListScheduledMusicView(musics []*entity.MusicDB) []*view.ScheduledMusicView {
+	views := make([]*view.ScheduledMusicView, len(musics))
+	for i, music := range musics {
+		views[i] = p.ToScheduledMusicView(music)
+	}
+	return views
+}
+
+func (p *presenter) formatDeletedAt(deletedAt *time.Time) string {
+	if deletedAt == nil {
+		return ""
+	}
+	return deletedAt.UTC().Format(time.RFC3339)
+}
+
+func (p *presenter) ToArtistView(artist *entity.ArtistDB) *view.ArtistView {
+	return &view.ArtistView{
+		ID:        artist.ID.String(),
+		Name:      artist.Name,
+		CreatedAt: artist.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func (p *presenter) ToPlaylistView(playlist *entity.PlaylistDB) *view.PlaylistView {
+	return &view.PlaylistView{
+		ID:        playlist.ID.String(),
+		UserID:    playlist.UserID.String(),
+		Name:      playlist.Name,
+		IsPublic:  playlist.IsPublic,
+		CreatedAt: playlist.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: playlist.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func (p *presenter) ToListPlaylistView(playlists []*entity.PlaylistDB) []*view.PlaylistView {
+	views := make([]*view.PlaylistView, len(playlists))
+	for i, playlist := range playlists {
+		views[i] = p.ToPlaylistView(playlist)
+	}
+	return views
+}
+
+func (p *presenter) ToFollowingView(following *entity.Following) *view.FollowingView {
+	artists := make([]*view.ArtistView, len(following.Artists))
+	for i, artist := range following.Artists {
+		artists[i] = p.ToArtistView(artist)
+	}
+	users := make([]*view.FollowedUserView, len(following.Users))
+	for i, user := range following.Users {
+		users[i] = &view.FollowedUserView{
+			ID:       user.ID.String(),
+			Username: user.Username,
+		}
+	}
+	return &view.FollowingView{
+		Artists: artists,
+		Users:   users,
+	}
+}
+
+func (p *presenter) ToFeedPageView(page *entity.FeedPage) *view.FeedPageView {
+	views := make([]*view.FeedItemView, len(page.Items))
+	for i, item := range page.Items {
+		itemView := &view.FeedItemView{
+			Kind:       item.Kind,
+			OccurredAt: item.OccurredAt.UTC().Format(time.RFC3339),
+			Actor: view.FeedRefView{
+				ID:   item.ActorID.String(),
+				Name: item.ActorName,
+			},
+		}
+		if item.MusicID != nil && item.MusicName != nil {
+			itemView.Music = &view.FeedRefView{ID: item.MusicID.String(), Name: *item.MusicName}
+		}
+		if item.PlaylistID != nil && item.PlaylistName != nil {
+			itemView.Playlist = &view.FeedRefView{ID: item.PlaylistID.String(), Name: *item.PlaylistName}
+		}
+		views[i] = itemView
+	}
+	return &view.FeedPageView{
+		Items:      views,
+		NextCursor: page.NextCursor,
+	}
+}
+
+func (p *presenter) ToNotificationView(notification *entity.NotificationDB) *view.NotificationView {
+	notificationView := &view.NotificationView{
+		ID:        notification.ID.String(),
+		Type:      notification.Type,
+		Message:   notification.Message(),
+		CreatedAt: notification.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if notification.ActorID != nil && notification.ActorName != nil {
+		notificationView.Actor = &view.FeedRefView{ID: notification.ActorID.String(), Name: *notification.ActorName}
+	}
+	if notification.MusicID != nil && notification.MusicName != nil {
+		notificationView.Music = &view.FeedRefView{ID: notification.MusicID.String(), Name: *notification.MusicName}
+	}
+	if notification.PlaylistID != nil && notification.PlaylistName != nil {
+		notificationView.Playlist = &view.FeedRefView{ID: notification.PlaylistID.String(), Name: *notification.PlaylistName}
+	}
+	if notification.CommentID != nil {
+		commentId := notification.CommentID.String()
+		notificationView.CommentID = &commentId
+	}
+	if notification.ReadAt != nil {
+		readAt := notification.ReadAt.UTC().Format(time.RFC3339)
+		notificationView.ReadAt = &readAt
+	}
+	return notificationView
+}
+
+func (p *presenter) ToNotificationPageView(page *entity.NotificationPage) *view.NotificationPageView {
+	views := make([]*view.NotificationView, len(page.Items))
+	for i, notification := range page.Items {
+		views[i] = p.ToNotificationView(notification)
+	}
+	return &view.NotificationPageView{
+		Items:       views,
+		UnreadCount: page.UnreadCount,
+		NextCursor:  page.NextCursor,
+	}
+}
+
+func (p *presenter) ToNotificationSettingsView(settings *entity.NotificationSettings) *view.NotificationSettingsView {
+	views := make([]*view.NotificationPreferenceView, len(settings.Preferences))
+	for i, preference := range settings.Preferences {
+		views[i] = &view.NotificationPreferenceView{
+			Type:    preference.Type,
+			InApp:   preference.InApp,
+			Email:   preference.Email,
+			Webhook: preference.Webhook,
+		}
+	}
+	return &view.NotificationSettingsView{
+		Email:       settings.Email,
+		Preferences: views,
+	}
+}
+
+func (p *presenter) ToShareLinkView(link *entity.ShareLinkDB) *view.ShareLinkView {
+	linkView := &view.ShareLinkView{
+		ID:        link.ID.String(),
+		MaxPlays:  link.MaxPlays,
+		Plays:     link.Plays,
+		CreatedAt: link.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if link.MusicID != nil {
+		musicId := link.MusicID.String()
+		linkView.MusicID = &musicId
+	}
+	if link.PlaylistID != nil {
+		playlistId := link.PlaylistID.String()
+		linkView.PlaylistID = &playlistId
+	}
+	if link.ExpiresAt != nil {
+		expiresAt := link.ExpiresAt.UTC().Format(time.RFC3339)
+		linkView.ExpiresAt = &expiresAt
+	}
+	if link.RevokedAt != nil {
+		revokedAt := link.RevokedAt.UTC().Format(time.RFC3339)
+		linkView.RevokedAt = &revokedAt
+	}
+	return linkView
+}
+
+func (p *presenter) ToListShareLinkView(links []*entity.ShareLinkDB) []*view.ShareLinkView {
+	views := make([]*view.ShareLinkView, len(links))
+	for i, link := range links {
+		views[i] = p.ToShareLinkView(link)
+	}
+	return views
+}
+
+func (p *presenter) ToShareLinkCreatedView(created *entity.ShareLinkCreated) *view.ShareLinkCreatedView {
+	return &view.ShareLinkCreatedView{
+		Token: created.Token,
+		Link:  p.ToShareLinkView(created.Link),
+	}
+}
+
+func (p *presenter) ToSharedContentView(content *entity.SharedContent) *view.SharedContentView {
+	contentView := &view.SharedContentView{
+		Tracks:    p.ToListMusicView(content.Tracks),
+		PlaysLeft: content.Link.PlaysLeft(),
+	}
+	if content.Music != nil {
+		contentView.Music = p.ToMusicView(content.Music)
+	}
+	if content.Playlist != nil {
+		contentView.Playlist = p.ToPlaylistView(content.Playlist)
+	}
+	if content.Link.ExpiresAt != nil {
+		expiresAt := content.Link.ExpiresAt.UTC().Format(time.RFC3339)
+		contentView.ExpiresAt = &expiresAt
+	}
+	return contentView
+}
+
+func (p *presenter) ToListeningStatsView(stats *entity.ListeningStats) *view.ListeningStatsView {
+	topTracks := make([]*view.TopTrackView, len(stats.TopTracks))
+	for i, track := range stats.TopTracks {
+		topTracks[i] = &view.TopTrackView{
+			MusicView: *p.ToMusicView(&track.MusicDB),
+			Plays:     track.Plays,
+			Minutes:   track.Seconds / 60,
+		}
+	}
+	topArtists := make([]*view.TopArtistView, len(stats.TopArtists))
+	for i, artist := range stats.TopArtists {
+		topArtists[i] = &view.TopArtistView{
+			ID:      artist.ArtistID.String(),
+			Name:    artist.Name,
+			Plays:   artist.Plays,
+			Minutes: artist.Seconds / 60,
+		}
+	}
+
+	statsView := &view.ListeningStatsView{
+		From:       stats.From.UTC().Format(time.RFC3339),
+		To:         stats.To.UTC().Format(time.RFC3339),
+		Plays:      stats.Totals.Plays,
+		Minutes:    stats.Totals.Seconds / 60,
+		Tracks:     stats.Totals.Tracks,
+		ActiveDays: stats.Totals.ActiveDays,
+		TopTracks:  topTracks,
+		TopArtists: topArtists,
+		Heatmap:    stats.HeatmapMatrix(),
+	}
+	if stats.LongestStreak != nil {
+		statsView.LongestStreak = &view.ListeningStreakView{
+			Start: stats.LongestStreak.Start.Format("2006-01-02"),
+			End:   stats.LongestStreak.End.Format("2006-01-02"),
+			Days:  stats.LongestStreak.Days,
+		}
+	}
+	return statsView
+}
+
+func (p *presenter) ToYearReviewView(review *entity.YearReviewDB) *view.YearReviewView {
+	return &view.YearReviewView{
+		Year:        review.Year,
+		GeneratedAt: review.GeneratedAt.UTC().Format(time.RFC3339),
+		Stats:       p.ToListeningStatsView(review.Stats),
+	}
+}
+
+func (p *presenter) ToExportJobView(job *entity.ExportJobDB) *view.ExportJobView {
+	jobView := &view.ExportJobView{
+		ID:        job.ID.String(),
+		Format:    job.Format,
+		Status:    job.Status,
+		Items:     job.Items,
+		Size:      job.Size,
+		CreatedAt: job.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if job.PlaylistID != nil {
+		playlistId := job.PlaylistID.String()
+		jobView.PlaylistID = &playlistId
+	}
+	if job.FinishedAt != nil {
+		finishedAt := job.FinishedAt.UTC().Format(time.RFC3339)
+		jobView.FinishedAt = &finishedAt
+	}
+	if job.ExpiresAt != nil {
+		expiresAt := job.ExpiresAt.UTC().Format(time.RFC3339)
+		jobView.ExpiresAt = &expiresAt
+	}
+	if job.Status == entity.ExportStatusDone {
+		downloadURL := fmt.Sprintf("/users/me/exports/%s/download", job.ID)
+		jobView.DownloadURL = &downloadURL
+	}
+	return jobView
+}
+
+func (p *presenter) ToPlaylistImportReportView(report *entity.PlaylistImportReport) *view.PlaylistImportReportView {
+	reportView := &view.PlaylistImportReportView{
+		Playlist:   p.ToPlaylistView(report.Playlist),
+		Total:      report.Total,
+		Matched:    report.Matched,
+		Duplicates: report.Duplicates,
+		Unresolved: make([]*view.ImportEntryReportView, len(report.Unresolved)),
+	}
+	for i, match := range report.Unresolved {
+		entryView := &view.ImportEntryReportView{
+			Line:        match.Entry.Line,
+			Status:      match.Status,
+			Title:       match.Entry.Title,
+			Artist:      match.Entry.Artist,
+			DurationSec: match.Entry.Duration,
+			Location:    match.Entry.Location,
+			Candidates:  make([]*view.ImportCandidateView, len(match.Candidates)),
+		}
+		for j, candidate := range match.Candidates {
+			entryView.Candidates[j] = &view.ImportCandidateView{
+				ID:          candidate.MusicID.String(),
+				Name:        candidate.Name,
+				Artist:      candidate.Artist,
+				DurationSec: candidate.Duration,
+			}
+		}
+		reportView.Unresolved[i] = entryView
+	}
+	return reportView
+}
+
+func (p *presenter) ToSmartPlaylistView(playlist *entity.SmartPlaylistDB) *view.SmartPlaylistView {
+	return &view.SmartPlaylistView{
+		ID:        playlist.ID.String(),
+		UserID:    playlist.UserID.String(),
+		Name:      playlist.Name,
+		IsPublic:  playlist.IsPublic,
+		Rules:     toSmartRuleView(playlist.Rules),
+		Sort:      playlist.Sort,
+		Order:     playlist.Order,
+		Limit:     playlist.Limit,
+		CreatedAt: playlist.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: playlist.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func (p *presenter) ToListSmartPlaylistView(playlists []*entity.SmartPlaylistDB) []*view.SmartPlaylistView {
+	views := make([]*view.SmartPlaylistView, len(playlists))
+	for i, playlist := range playlists {
+		views[i] = p.ToSmartPlaylistView(playlist)
+	}
+	return views
+}
+
+func toSmartRuleView(rule *entity.SmartRule) *view.SmartRuleView {
+	if rule == nil {
+		return nil
+	}
+	ruleView := &view.SmartRuleView{
+		Group:    rule.Group,
+		Field:    rule.Field,
+		Operator: rule.Operator,
+		Value:    rule.Value,
+	}
+	for _, child := range rule.Rules {
+		ruleView.Rules = append(ruleView.Rules, toSmartRuleView(child))
+	}
+	return ruleView
+}
+
+func (p *presenter) ToSyncView(delta *entity.SyncDelta) *view.SyncView {
+	likes := make([]*view.SyncLikeView, len(delta.Likes))
+	for i, like := range delta.Likes {
+		likes[i] = &view.SyncLikeView{
+			MusicID: like.MusicID.String(),
+			LikedAt: like.LikedAt.UTC().Format(time.RFC3339),
+		}
+	}
+
+	playlists := make([]*view.SyncPlaylistView, len(delta.Playlists))
+	for i, playlist := range delta.Playlists {
+		playlists[i] = &view.SyncPlaylistView{
+			PlaylistView: *p.ToPlaylistView(playlist.Playlist),
+			TrackIDs:     toIdStrings(playlist.TrackIDs),
+		}
+	}
+
+	return &view.SyncView{
+		Token:   delta.Cursor.Encode(),
+		Full:    delta.Full,
+		HasMore: delta.HasMore,
+		Tracks: &view.SyncTracksView{
+			Upserted: p.ToListMusicView(delta.Tracks),
+			Deleted:  toIdStrings(delta.DeletedTracks),
+		},
+		Likes: &view.SyncLikesView{
+			Added:   likes,
+			Removed: toIdStrings(delta.RemovedLikes),
+		},
+		Playlists: &view.SyncPlaylistsView{
+			Upserted: playlists,
+			Deleted:  toIdStrings(delta.DeletedPlaylists),
+		},
+	}
+}
+
+func toIdStrings(ids []uuid.UUID) []string {
+	data := make([]string, len(ids))
+	for i, id := range ids {
+		data[i] = id.String()
+	}
+	return data
+}
+
+func (p *presenter) ToMusicUploadView(report *entity.MusicUploadReport) *view.MusicUploadView {
+	return &view.MusicUploadView{
+		Music:      p.ToMusicView(report.Music),
+		Duplicates: p.ToListMusicDuplicateView(report.Duplicates),
+	}
+}
+
+func (p *presenter) ToListMusicDuplicateView(duplicates []*entity.MusicDuplicateDB) []*view.MusicDuplicateView {
+	views := make([]*view.MusicDuplicateView, len(duplicates))
+	for i, duplicate := range duplicates {
+		views[i] = &view.MusicDuplicateView{
+			MusicID:    duplicate.CandidateID.String(),
+			Name:       duplicate.CandidateName,
+			Link:       fmt.Sprintf("/music/download/%s", duplicate.CandidateID),
+			SameHash:   duplicate.SameHash,
+			SameTitle:  duplicate.SameTitle,
+			Similarity: duplicate.Similarity,
+			DetectedAt: duplicate.DetectedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return views
+}
+
+func (p *presenter) ToMusicMergeView(result *entity.MusicMergeResult) *view.MusicMergeView {
+	return &view.MusicMergeView{
+		MusicID:         result.MusicID.String(),
+		IntoID:          result.IntoID.String(),
+		Likes:           result.Likes,
+		PlaylistEntries: result.PlaylistEntries,
+		Plays:           result.Plays,
+	}
+}
+
+func (p *presenter) ToSessionView(session *entity.SessionDB, currentId uuid.UUID) *view.SessionView {
+	return &view.SessionView{
+		ID:         session.ID.String(),
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt.UTC().Format(time.RFC3339),
+		LastSeenAt: session.LastSeenAt.UTC().Format(time.RFC3339),
+		ExpiresAt:  session.ExpiresAt.UTC().Format(time.RFC3339),
+		Current:    session.ID == currentId,
+	}
+}
+
+func (p *presenter) ToListSessionView(sessions []*entity.SessionDB, currentId uuid.UUID) []*view.SessionView {
+	views := make([]*view.SessionView, len(sessions))
+	for i, session := range sessions {
+		views[i] = p.ToSessionView(session, currentId)
+	}
+	return views
+}
+
+func (p *presenter) ToJWKView(key *entity.SigningKey) *view.JWKView {
+	jwk := &view.JWKView{
+		Use: "sig",
+		Alg: key.Algorithm,
+		Kid: key.ID,
+	}
+	switch public := key.Public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+
+	return jwk
+}
+
+func (p *presenter) ToJWKSView(keys []*entity.SigningKey) *view.JWKSView {
+	jwks := &view.JWKSView{Keys: make([]*view.JWKView, len(keys))}
+	for i, key := range keys {
+		jwks.Keys[i] = p.ToJWKView(key)
+	}
+
+	return jwks
+}
+
+func (p *presenter) ToIdentityView(identity *entity.UserIdentityDB) *view.IdentityView {
+	return &view.IdentityView{
+		ID:          identity.ID.String(),
+		Issuer:      identity.Issuer,
+		Subject:     identity.Subject,
+		CreatedAt:   identity.CreatedAt.UTC().Format(time.RFC3339),
+		LastLoginAt: identity.LastLoginAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func (p *presenter) ToListIdentityView(identities []*entity.UserIdentityDB) []*view.IdentityView {
+	views := make([]*view.IdentityView, len(identities))
+	for i, identity := range identities {
+		views[i] = p.ToIdentityView(identity)
+	}
+
+	return views
+}
@@ -10,6 +10,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockPresenter is a mock of Presenter interface.
@@ -35,6 +36,244 @@ func (m *MockPresenter) EXPECT() *MockPresenterMockRecorder {
 	return m.recorder
 }
 
+// ToArtistView mocks base method.
+func (m *MockPresenter) ToArtistView(artist *entity.ArtistDB) *view.ArtistView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToArtistView", artist)
+	ret0, _ := ret[0].(*view.ArtistView)
+	return ret0
+}
+
+// ToArtistView indicates an expected call of ToArtistView.
+func (mr *MockPresenterMockRecorder) ToArtistView(artist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToArtistView", reflect.TypeOf((*MockPresenter)(nil).ToArtistView), artist)
+}
+
+// ToChartEntryView mocks base method.
+func (m *MockPresenter) ToChartEntryView(entry *entity.ChartEntryDB) *view.ChartEntryView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToChartEntryView", entry)
+	ret0, _ := ret[0].(*view.ChartEntryView)
+	return ret0
+}
+
+// ToChartEntryView indicates an expected call of ToChartEntryView.
+func (mr *MockPresenterMockRecorder) ToChartEntryView(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToChartEntryView", reflect.TypeOf((*MockPresenter)(nil).ToChartEntryView), entry)
+}
+
+// ToChartView mocks base method.
+func (m *MockPresenter) ToChartView(entries []*entity.ChartEntryDB) *view.ChartView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToChartView", entries)
+	ret0, _ := ret[0].(*view.ChartView)
+	return ret0
+}
+
+// ToChartView indicates an expected call of ToChartView.
+func (mr *MockPresenterMockRecorder) ToChartView(entries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToChartView", reflect.TypeOf((*MockPresenter)(nil).ToChartView), entries)
+}
+
+// ToCommentPageView mocks base method.
+func (m *MockPresenter) ToCommentPageView(page *entity.CommentPage) *view.CommentPageView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToCommentPageView", page)
+	ret0, _ := ret[0].(*view.CommentPageView)
+	return ret0
+}
+
+// ToCommentPageView indicates an expected call of ToCommentPageView.
+func (mr *MockPresenterMockRecorder) ToCommentPageView(page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToCommentPageView", reflect.TypeOf((*MockPresenter)(nil).ToCommentPageView), page)
+}
+
+// ToCommentView mocks base method.
+func (m *MockPresenter) ToCommentView(comment *entity.CommentDB) *view.CommentView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToCommentView", comment)
+	ret0, _ := ret[0].(*view.CommentView)
+	return ret0
+}
+
+// ToCommentView indicates an expected call of ToCommentView.
+func (mr *MockPresenterMockRecorder) ToCommentView(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToCommentView", reflect.TypeOf((*MockPresenter)(nil).ToCommentView), comment)
+}
+
+// ToExportJobView mocks base method.
+func (m *MockPresenter) ToExportJobView(job *entity.ExportJobDB) *view.ExportJobView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToExportJobView", job)
+	ret0, _ := ret[0].(*view.ExportJobView)
+	return ret0
+}
+
+// ToExportJobView indicates an expected call of ToExportJobView.
+func (mr *MockPresenterMockRecorder) ToExportJobView(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToExportJobView", reflect.TypeOf((*MockPresenter)(nil).ToExportJobView), job)
+}
+
+// ToFeedPageView mocks base method.
+func (m *MockPresenter) ToFeedPageView(page *entity.FeedPage) *view.FeedPageView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToFeedPageView", page)
+	ret0, _ := ret[0].(*view.FeedPageView)
+	return ret0
+}
+
+// ToFeedPageView indicates an expected call of ToFeedPageView.
+func (mr *MockPresenterMockRecorder) ToFeedPageView(page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToFeedPageView", reflect.TypeOf((*MockPresenter)(nil).ToFeedPageView), page)
+}
+
+// ToFollowingView mocks base method.
+func (m *MockPresenter) ToFollowingView(following *entity.Following) *view.FollowingView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToFollowingView", following)
+	ret0, _ := ret[0].(*view.FollowingView)
+	return ret0
+}
+
+// ToFollowingView indicates an expected call of ToFollowingView.
+func (mr *MockPresenterMockRecorder) ToFollowingView(following interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToFollowingView", reflect.TypeOf((*MockPresenter)(nil).ToFollowingView), following)
+}
+
+// ToIdentityView mocks base method.
+func (m *MockPresenter) ToIdentityView(identity *entity.UserIdentityDB) *view.IdentityView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToIdentityView", identity)
+	ret0, _ := ret[0].(*view.IdentityView)
+	return ret0
+}
+
+// ToIdentityView indicates an expected call of ToIdentityView.
+func (mr *MockPresenterMockRecorder) ToIdentityView(identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToIdentityView", reflect.TypeOf((*MockPresenter)(nil).ToIdentityView), identity)
+}
+
+// ToJWKSView mocks base method.
+func (m *MockPresenter) ToJWKSView(keys []*entity.SigningKey) *view.JWKSView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToJWKSView", keys)
+	ret0, _ := ret[0].(*view.JWKSView)
+	return ret0
+}
+
+// ToJWKSView indicates an expected call of ToJWKSView.
+func (mr *MockPresenterMockRecorder) ToJWKSView(keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToJWKSView", reflect.TypeOf((*MockPresenter)(nil).ToJWKSView), keys)
+}
+
+// ToJWKView mocks base method.
+func (m *MockPresenter) ToJWKView(key *entity.SigningKey) *view.JWKView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToJWKView", key)
+	ret0, _ := ret[0].(*view.JWKView)
+	return ret0
+}
+
+// ToJWKView indicates an expected call of ToJWKView.
+func (mr *MockPresenterMockRecorder) ToJWKView(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToJWKView", reflect.TypeOf((*MockPresenter)(nil).ToJWKView), key)
+}
+
+// ToListChartEntryView mocks base method.
+func (m *MockPresenter) ToListChartEntryView(entries []*entity.ChartEntryDB) []*view.ChartEntryView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListChartEntryView", entries)
+	ret0, _ := ret[0].([]*view.ChartEntryView)
+	return ret0
+}
+
+// ToListChartEntryView indicates an expected call of ToListChartEntryView.
+func (mr *MockPresenterMockRecorder) ToListChartEntryView(entries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListChartEntryView", reflect.TypeOf((*MockPresenter)(nil).ToListChartEntryView), entries)
+}
+
+// ToListIdentityView mocks base method.
+func (m *MockPresenter) ToListIdentityView(identities []*entity.UserIdentityDB) []*view.IdentityView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListIdentityView", identities)
+	ret0, _ := ret[0].([]*view.IdentityView)
+	return ret0
+}
+
+// ToListIdentityView indicates an expected call of ToListIdentityView.
+func (mr *MockPresenterMockRecorder) ToListIdentityView(identities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListIdentityView", reflect.TypeOf((*MockPresenter)(nil).ToListIdentityView), identities)
+}
+
+// ToListLibraryMusicView mocks base method.
+func (m *MockPresenter) ToListLibraryMusicView(musics []*entity.LibraryMusicDB) []*view.LibraryMusicView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListLibraryMusicView", musics)
+	ret0, _ := ret[0].([]*view.LibraryMusicView)
+	return ret0
+}
+
+// ToListLibraryMusicView indicates an expected call of ToListLibraryMusicView.
+func (mr *MockPresenterMockRecorder) ToListLibraryMusicView(musics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListLibraryMusicView", reflect.TypeOf((*MockPresenter)(nil).ToListLibraryMusicView), musics)
+}
+
+// ToListModerationItemView mocks base method.
+func (m *MockPresenter) ToListModerationItemView(items []*entity.ModerationItemDB) []*view.ModerationItemView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListModerationItemView", items)
+	ret0, _ := ret[0].([]*view.ModerationItemView)
+	return ret0
+}
+
+// ToListModerationItemView indicates an expected call of ToListModerationItemView.
+func (mr *MockPresenterMockRecorder) ToListModerationItemView(items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListModerationItemView", reflect.TypeOf((*MockPresenter)(nil).ToListModerationItemView), items)
+}
+
+// ToListMusicDuplicateView mocks base method.
+func (m *MockPresenter) ToListMusicDuplicateView(duplicates []*entity.MusicDuplicateDB) []*view.MusicDuplicateView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListMusicDuplicateView", duplicates)
+	ret0, _ := ret[0].([]*view.MusicDuplicateView)
+	return ret0
+}
+
+// ToListMusicDuplicateView indicates an expected call of ToListMusicDuplicateView.
+func (mr *MockPresenterMockRecorder) ToListMusicDuplicateView(duplicates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListMusicDuplicateView", reflect.TypeOf((*MockPresenter)(nil).ToListMusicDuplicateView), duplicates)
+}
+
+// ToListMusicRevisionView mocks base method.
+func (m *MockPresenter) ToListMusicRevisionView(revisions []*entity.MusicRevisionDB) []*view.MusicRevisionView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListMusicRevisionView", revisions)
+	ret0, _ := ret[0].([]*view.MusicRevisionView)
+	return ret0
+}
+
+// ToListMusicRevisionView indicates an expected call of ToListMusicRevisionView.
+func (mr *MockPresenterMockRecorder) ToListMusicRevisionView(revisions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListMusicRevisionView", reflect.TypeOf((*MockPresenter)(nil).ToListMusicRevisionView), revisions)
+}
+
 // ToListMusicView mocks base method.
 func (m *MockPresenter) ToListMusicView(arg0 []*entity.MusicDB) []*view.MusicView {
 	m.ctrl.T.Helper()
@@ -49,6 +288,160 @@ func (mr *MockPresenterMockRecorder) ToListMusicView(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListMusicView", reflect.TypeOf((*MockPresenter)(nil).ToListMusicView), arg0)
 }
 
+// ToListPlayEventView mocks base method.
+func (m *MockPresenter) ToListPlayEventView(events []*entity.PlayEventDB) []*view.PlayEventView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListPlayEventView", events)
+	ret0, _ := ret[0].([]*view.PlayEventView)
+	return ret0
+}
+
+// ToListPlayEventView indicates an expected call of ToListPlayEventView.
+func (mr *MockPresenterMockRecorder) ToListPlayEventView(events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListPlayEventView", reflect.TypeOf((*MockPresenter)(nil).ToListPlayEventView), events)
+}
+
+// ToListPlaylistView mocks base method.
+func (m *MockPresenter) ToListPlaylistView(playlists []*entity.PlaylistDB) []*view.PlaylistView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListPlaylistView", playlists)
+	ret0, _ := ret[0].([]*view.PlaylistView)
+	return ret0
+}
+
+// ToListPlaylistView indicates an expected call of ToListPlaylistView.
+func (mr *MockPresenterMockRecorder) ToListPlaylistView(playlists interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListPlaylistView", reflect.TypeOf((*MockPresenter)(nil).ToListPlaylistView), playlists)
+}
+
+// ToListPopularMusicView mocks base method.
+func (m *MockPresenter) ToListPopularMusicView(musics []*entity.MusicPopularityDB) []*view.PopularMusicView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListPopularMusicView", musics)
+	ret0, _ := ret[0].([]*view.PopularMusicView)
+	return ret0
+}
+
+// ToListPopularMusicView indicates an expected call of ToListPopularMusicView.
+func (mr *MockPresenterMockRecorder) ToListPopularMusicView(musics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListPopularMusicView", reflect.TypeOf((*MockPresenter)(nil).ToListPopularMusicView), musics)
+}
+
+// ToListRecommendationView mocks base method.
+func (m *MockPresenter) ToListRecommendationView(musics []*entity.RecommendationDB) []*view.RecommendationView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListRecommendationView", musics)
+	ret0, _ := ret[0].([]*view.RecommendationView)
+	return ret0
+}
+
+// ToListRecommendationView indicates an expected call of ToListRecommendationView.
+func (mr *MockPresenterMockRecorder) ToListRecommendationView(musics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListRecommendationView", reflect.TypeOf((*MockPresenter)(nil).ToListRecommendationView), musics)
+}
+
+// ToListScheduledMusicView mocks base method.
+func (m *MockPresenter) ToListScheduledMusicView(musics []*entity.MusicDB) []*view.ScheduledMusicView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListScheduledMusicView", musics)
+	ret0, _ := ret[0].([]*view.ScheduledMusicView)
+	return ret0
+}
+
+// ToListScheduledMusicView indicates an expected call of ToListScheduledMusicView.
+func (mr *MockPresenterMockRecorder) ToListScheduledMusicView(musics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListScheduledMusicView", reflect.TypeOf((*MockPresenter)(nil).ToListScheduledMusicView), musics)
+}
+
+// ToListSessionView mocks base method.
+func (m *MockPresenter) ToListSessionView(sessions []*entity.SessionDB, currentId uuid.UUID) []*view.SessionView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListSessionView", sessions, currentId)
+	ret0, _ := ret[0].([]*view.SessionView)
+	return ret0
+}
+
+// ToListSessionView indicates an expected call of ToListSessionView.
+func (mr *MockPresenterMockRecorder) ToListSessionView(sessions, currentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListSessionView", reflect.TypeOf((*MockPresenter)(nil).ToListSessionView), sessions, currentId)
+}
+
+// ToListShareLinkView mocks base method.
+func (m *MockPresenter) ToListShareLinkView(links []*entity.ShareLinkDB) []*view.ShareLinkView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListShareLinkView", links)
+	ret0, _ := ret[0].([]*view.ShareLinkView)
+	return ret0
+}
+
+// ToListShareLinkView indicates an expected call of ToListShareLinkView.
+func (mr *MockPresenterMockRecorder) ToListShareLinkView(links interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListShareLinkView", reflect.TypeOf((*MockPresenter)(nil).ToListShareLinkView), links)
+}
+
+// ToListSimilarMusicView mocks base method.
+func (m *MockPresenter) ToListSimilarMusicView(musics []*entity.SimilarMusicDB) []*view.SimilarMusicView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListSimilarMusicView", musics)
+	ret0, _ := ret[0].([]*view.SimilarMusicView)
+	return ret0
+}
+
+// ToListSimilarMusicView indicates an expected call of ToListSimilarMusicView.
+func (mr *MockPresenterMockRecorder) ToListSimilarMusicView(musics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListSimilarMusicView", reflect.TypeOf((*MockPresenter)(nil).ToListSimilarMusicView), musics)
+}
+
+// ToListSmartPlaylistView mocks base method.
+func (m *MockPresenter) ToListSmartPlaylistView(playlists []*entity.SmartPlaylistDB) []*view.SmartPlaylistView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListSmartPlaylistView", playlists)
+	ret0, _ := ret[0].([]*view.SmartPlaylistView)
+	return ret0
+}
+
+// ToListSmartPlaylistView indicates an expected call of ToListSmartPlaylistView.
+func (mr *MockPresenterMockRecorder) ToListSmartPlaylistView(playlists interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListSmartPlaylistView", reflect.TypeOf((*MockPresenter)(nil).ToListSmartPlaylistView), playlists)
+}
+
+// ToListTrashedMusicView mocks base method.
+func (m *MockPresenter) ToListTrashedMusicView(musics []*entity.MusicDB) []*view.TrashedMusicView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListTrashedMusicView", musics)
+	ret0, _ := ret[0].([]*view.TrashedMusicView)
+	return ret0
+}
+
+// ToListTrashedMusicView indicates an expected call of ToListTrashedMusicView.
+func (mr *MockPresenterMockRecorder) ToListTrashedMusicView(musics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListTrashedMusicView", reflect.TypeOf((*MockPresenter)(nil).ToListTrashedMusicView), musics)
+}
+
+// ToListTrashedUserView mocks base method.
+func (m *MockPresenter) ToListTrashedUserView(users []*entity.UserDB) []*view.TrashedUserView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListTrashedUserView", users)
+	ret0, _ := ret[0].([]*view.TrashedUserView)
+	return ret0
+}
+
+// ToListTrashedUserView indicates an expected call of ToListTrashedUserView.
+func (mr *MockPresenterMockRecorder) ToListTrashedUserView(users interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListTrashedUserView", reflect.TypeOf((*MockPresenter)(nil).ToListTrashedUserView), users)
+}
+
 // ToListUserView mocks base method.
 func (m *MockPresenter) ToListUserView(users []*entity.UserDB) []*view.UserView {
 	m.ctrl.T.Helper()
@@ -63,6 +456,90 @@ func (mr *MockPresenterMockRecorder) ToListUserView(users interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListUserView", reflect.TypeOf((*MockPresenter)(nil).ToListUserView), users)
 }
 
+// ToListeningStatsView mocks base method.
+func (m *MockPresenter) ToListeningStatsView(stats *entity.ListeningStats) *view.ListeningStatsView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToListeningStatsView", stats)
+	ret0, _ := ret[0].(*view.ListeningStatsView)
+	return ret0
+}
+
+// ToListeningStatsView indicates an expected call of ToListeningStatsView.
+func (mr *MockPresenterMockRecorder) ToListeningStatsView(stats interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToListeningStatsView", reflect.TypeOf((*MockPresenter)(nil).ToListeningStatsView), stats)
+}
+
+// ToLyricsView mocks base method.
+func (m *MockPresenter) ToLyricsView(lyrics *entity.Lyrics) *view.LyricsView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToLyricsView", lyrics)
+	ret0, _ := ret[0].(*view.LyricsView)
+	return ret0
+}
+
+// ToLyricsView indicates an expected call of ToLyricsView.
+func (mr *MockPresenterMockRecorder) ToLyricsView(lyrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToLyricsView", reflect.TypeOf((*MockPresenter)(nil).ToLyricsView), lyrics)
+}
+
+// ToMusicMergeView mocks base method.
+func (m *MockPresenter) ToMusicMergeView(result *entity.MusicMergeResult) *view.MusicMergeView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToMusicMergeView", result)
+	ret0, _ := ret[0].(*view.MusicMergeView)
+	return ret0
+}
+
+// ToMusicMergeView indicates an expected call of ToMusicMergeView.
+func (mr *MockPresenterMockRecorder) ToMusicMergeView(result interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToMusicMergeView", reflect.TypeOf((*MockPresenter)(nil).ToMusicMergeView), result)
+}
+
+// ToMusicRevisionDiffView mocks base method.
+func (m *MockPresenter) ToMusicRevisionDiffView(diff *entity.MusicRevisionDiff) *view.MusicRevisionDiffView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToMusicRevisionDiffView", diff)
+	ret0, _ := ret[0].(*view.MusicRevisionDiffView)
+	return ret0
+}
+
+// ToMusicRevisionDiffView indicates an expected call of ToMusicRevisionDiffView.
+func (mr *MockPresenterMockRecorder) ToMusicRevisionDiffView(diff interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToMusicRevisionDiffView", reflect.TypeOf((*MockPresenter)(nil).ToMusicRevisionDiffView), diff)
+}
+
+// ToMusicRevisionView mocks base method.
+func (m *MockPresenter) ToMusicRevisionView(revision *entity.MusicRevisionDB) *view.MusicRevisionView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToMusicRevisionView", revision)
+	ret0, _ := ret[0].(*view.MusicRevisionView)
+	return ret0
+}
+
+// ToMusicRevisionView indicates an expected call of ToMusicRevisionView.
+func (mr *MockPresenterMockRecorder) ToMusicRevisionView(revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToMusicRevisionView", reflect.TypeOf((*MockPresenter)(nil).ToMusicRevisionView), revision)
+}
+
+// ToMusicUploadView mocks base method.
+func (m *MockPresenter) ToMusicUploadView(report *entity.MusicUploadReport) *view.MusicUploadView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToMusicUploadView", report)
+	ret0, _ := ret[0].(*view.MusicUploadView)
+	return ret0
+}
+
+// ToMusicUploadView indicates an expected call of ToMusicUploadView.
+func (mr *MockPresenterMockRecorder) ToMusicUploadView(report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToMusicUploadView", reflect.TypeOf((*MockPresenter)(nil).ToMusicUploadView), report)
+}
+
 // ToMusicView mocks base method.
 func (m *MockPresenter) ToMusicView(arg0 *entity.MusicDB) *view.MusicView {
 	m.ctrl.T.Helper()
@@ -77,19 +554,229 @@ func (mr *MockPresenterMockRecorder) ToMusicView(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToMusicView", reflect.TypeOf((*MockPresenter)(nil).ToMusicView), arg0)
 }
 
+// ToNotificationPageView mocks base method.
+func (m *MockPresenter) ToNotificationPageView(page *entity.NotificationPage) *view.NotificationPageView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToNotificationPageView", page)
+	ret0, _ := ret[0].(*view.NotificationPageView)
+	return ret0
+}
+
+// ToNotificationPageView indicates an expected call of ToNotificationPageView.
+func (mr *MockPresenterMockRecorder) ToNotificationPageView(page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToNotificationPageView", reflect.TypeOf((*MockPresenter)(nil).ToNotificationPageView), page)
+}
+
+// ToNotificationSettingsView mocks base method.
+func (m *MockPresenter) ToNotificationSettingsView(settings *entity.NotificationSettings) *view.NotificationSettingsView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToNotificationSettingsView", settings)
+	ret0, _ := ret[0].(*view.NotificationSettingsView)
+	return ret0
+}
+
+// ToNotificationSettingsView indicates an expected call of ToNotificationSettingsView.
+func (mr *MockPresenterMockRecorder) ToNotificationSettingsView(settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToNotificationSettingsView", reflect.TypeOf((*MockPresenter)(nil).ToNotificationSettingsView), settings)
+}
+
+// ToNotificationView mocks base method.
+func (m *MockPresenter) ToNotificationView(notification *entity.NotificationDB) *view.NotificationView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToNotificationView", notification)
+	ret0, _ := ret[0].(*view.NotificationView)
+	return ret0
+}
+
+// ToNotificationView indicates an expected call of ToNotificationView.
+func (mr *MockPresenterMockRecorder) ToNotificationView(notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToNotificationView", reflect.TypeOf((*MockPresenter)(nil).ToNotificationView), notification)
+}
+
+// ToPlayBatchResultView mocks base method.
+func (m *MockPresenter) ToPlayBatchResultView(result *entity.PlayBatchResult) *view.PlayBatchResultView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToPlayBatchResultView", result)
+	ret0, _ := ret[0].(*view.PlayBatchResultView)
+	return ret0
+}
+
+// ToPlayBatchResultView indicates an expected call of ToPlayBatchResultView.
+func (mr *MockPresenterMockRecorder) ToPlayBatchResultView(result interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToPlayBatchResultView", reflect.TypeOf((*MockPresenter)(nil).ToPlayBatchResultView), result)
+}
+
+// ToPlayEventView mocks base method.
+func (m *MockPresenter) ToPlayEventView(event *entity.PlayEventDB) *view.PlayEventView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToPlayEventView", event)
+	ret0, _ := ret[0].(*view.PlayEventView)
+	return ret0
+}
+
+// ToPlayEventView indicates an expected call of ToPlayEventView.
+func (mr *MockPresenterMockRecorder) ToPlayEventView(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToPlayEventView", reflect.TypeOf((*MockPresenter)(nil).ToPlayEventView), event)
+}
+
+// ToPlaylistImportReportView mocks base method.
+func (m *MockPresenter) ToPlaylistImportReportView(report *entity.PlaylistImportReport) *view.PlaylistImportReportView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToPlaylistImportReportView", report)
+	ret0, _ := ret[0].(*view.PlaylistImportReportView)
+	return ret0
+}
+
+// ToPlaylistImportReportView indicates an expected call of ToPlaylistImportReportView.
+func (mr *MockPresenterMockRecorder) ToPlaylistImportReportView(report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToPlaylistImportReportView", reflect.TypeOf((*MockPresenter)(nil).ToPlaylistImportReportView), report)
+}
+
+// ToPlaylistView mocks base method.
+func (m *MockPresenter) ToPlaylistView(playlist *entity.PlaylistDB) *view.PlaylistView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToPlaylistView", playlist)
+	ret0, _ := ret[0].(*view.PlaylistView)
+	return ret0
+}
+
+// ToPlaylistView indicates an expected call of ToPlaylistView.
+func (mr *MockPresenterMockRecorder) ToPlaylistView(playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToPlaylistView", reflect.TypeOf((*MockPresenter)(nil).ToPlaylistView), playlist)
+}
+
+// ToRatingSummaryView mocks base method.
+func (m *MockPresenter) ToRatingSummaryView(summary *entity.RatingSummary) *view.RatingView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToRatingSummaryView", summary)
+	ret0, _ := ret[0].(*view.RatingView)
+	return ret0
+}
+
+// ToRatingSummaryView indicates an expected call of ToRatingSummaryView.
+func (mr *MockPresenterMockRecorder) ToRatingSummaryView(summary interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToRatingSummaryView", reflect.TypeOf((*MockPresenter)(nil).ToRatingSummaryView), summary)
+}
+
+// ToScheduledMusicView mocks base method.
+func (m *MockPresenter) ToScheduledMusicView(music *entity.MusicDB) *view.ScheduledMusicView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToScheduledMusicView", music)
+	ret0, _ := ret[0].(*view.ScheduledMusicView)
+	return ret0
+}
+
+// ToScheduledMusicView indicates an expected call of ToScheduledMusicView.
+func (mr *MockPresenterMockRecorder) ToScheduledMusicView(music interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToScheduledMusicView", reflect.TypeOf((*MockPresenter)(nil).ToScheduledMusicView), music)
+}
+
+// ToSessionView mocks base method.
+func (m *MockPresenter) ToSessionView(session *entity.SessionDB, currentId uuid.UUID) *view.SessionView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToSessionView", session, currentId)
+	ret0, _ := ret[0].(*view.SessionView)
+	return ret0
+}
+
+// ToSessionView indicates an expected call of ToSessionView.
+func (mr *MockPresenterMockRecorder) ToSessionView(session, currentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToSessionView", reflect.TypeOf((*MockPresenter)(nil).ToSessionView), session, currentId)
+}
+
+// ToShareLinkCreatedView mocks base method.
+func (m *MockPresenter) ToShareLinkCreatedView(created *entity.ShareLinkCreated) *view.ShareLinkCreatedView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToShareLinkCreatedView", created)
+	ret0, _ := ret[0].(*view.ShareLinkCreatedView)
+	return ret0
+}
+
+// ToShareLinkCreatedView indicates an expected call of ToShareLinkCreatedView.
+func (mr *MockPresenterMockRecorder) ToShareLinkCreatedView(created interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToShareLinkCreatedView", reflect.TypeOf((*MockPresenter)(nil).ToShareLinkCreatedView), created)
+}
+
+// ToShareLinkView mocks base method.
+func (m *MockPresenter) ToShareLinkView(link *entity.ShareLinkDB) *view.ShareLinkView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToShareLinkView", link)
+	ret0, _ := ret[0].(*view.ShareLinkView)
+	return ret0
+}
+
+// ToShareLinkView indicates an expected call of ToShareLinkView.
+func (mr *MockPresenterMockRecorder) ToShareLinkView(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToShareLinkView", reflect.TypeOf((*MockPresenter)(nil).ToShareLinkView), link)
+}
+
+// ToSharedContentView mocks base method.
+func (m *MockPresenter) ToSharedContentView(content *entity.SharedContent) *view.SharedContentView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToSharedContentView", content)
+	ret0, _ := ret[0].(*view.SharedContentView)
+	return ret0
+}
+
+// ToSharedContentView indicates an expected call of ToSharedContentView.
+func (mr *MockPresenterMockRecorder) ToSharedContentView(content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToSharedContentView", reflect.TypeOf((*MockPresenter)(nil).ToSharedContentView), content)
+}
+
+// ToSmartPlaylistView mocks base method.
+func (m *MockPresenter) ToSmartPlaylistView(playlist *entity.SmartPlaylistDB) *view.SmartPlaylistView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToSmartPlaylistView", playlist)
+	ret0, _ := ret[0].(*view.SmartPlaylistView)
+	return ret0
+}
+
+// ToSmartPlaylistView indicates an expected call of ToSmartPlaylistView.
+func (mr *MockPresenterMockRecorder) ToSmartPlaylistView(playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToSmartPlaylistView", reflect.TypeOf((*MockPresenter)(nil).ToSmartPlaylistView), playlist)
+}
+
+// ToSyncView mocks base method.
+func (m *MockPresenter) ToSyncView(delta *entity.SyncDelta) *view.SyncView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToSyncView", delta)
+	ret0, _ := ret[0].(*view.SyncView)
+	return ret0
+}
+
+// ToSyncView indicates an expected call of ToSyncView.
+func (mr *MockPresenterMockRecorder) ToSyncView(delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToSyncView", reflect.TypeOf((*MockPresenter)(nil).ToSyncView), delta)
+}
+
 // ToTokenView mocks base method.
-func (m *MockPresenter) ToTokenView(token *entity.Token) (*view.TokenView, error) {
+func (m *MockPresenter) ToTokenView(tokens *entity.TokenPair) (*view.TokenView, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ToTokenView", token)
+	ret := m.ctrl.Call(m, "ToTokenView", tokens)
 	ret0, _ := ret[0].(*view.TokenView)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ToTokenView indicates an expected call of ToTokenView.
-func (mr *MockPresenterMockRecorder) ToTokenView(token interface{}) *gomock.Call {
+func (mr *MockPresenterMockRecorder) ToTokenView(tokens interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToTokenView", reflect.TypeOf((*MockPresenter)(nil).ToTokenView), token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToTokenView", reflect.TypeOf((*MockPresenter)(nil).ToTokenView), tokens)
 }
 
 // ToUserView mocks base method.
@@ -105,3 +792,17 @@ func (mr *MockPresenterMockRecorder) ToUserView(user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToUserView", reflect.TypeOf((*MockPresenter)(nil).ToUserView), user)
 }
+
+// ToYearReviewView mocks base method.
+func (m *MockPresenter) ToYearReviewView(review *entity.YearReviewDB) *view.YearReviewView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToYearReviewView", review)
+	ret0, _ := ret[0].(*view.YearReviewView)
+	return ret0
+}
+
+// ToYearReviewView indicates an expected call of ToYearReviewView.
+func (mr *MockPresenterMockRecorder) ToYearReviewView(review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToYearReviewView", reflect.TypeOf((*MockPresenter)(nil).ToYearReviewView), review)
+}
@@ -1,6 +1,9 @@
 package presenter
 
 import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"math/big"
 	"music-backend-test/internal/api/http/view"
 	"music-backend-test/internal/entity"
 	reflect "reflect"
@@ -198,3 +201,89 @@ func Test_presenter_ToListUserView(t *testing.T) {
 		})
 	}
 }
+
+func Test_presenter_ToChartEntryView(t *testing.T) {
+	position := func(n int) *int { return &n }
+	week := MustParseTime("2006-01-02", "2023-03-20")
+
+	tests := []struct {
+		name  string
+		entry *entity.ChartEntryDB
+		want  string
+	}{
+		{
+			name:  "new entry",
+			entry: &entity.ChartEntryDB{Week: week, Position: 5, WeeksOnChart: 1, PeakPosition: 5},
+			want:  entity.ChartMovementNew,
+		},
+		{
+			name:  "re-entry",
+			entry: &entity.ChartEntryDB{Week: week, Position: 5, WeeksOnChart: 3, PeakPosition: 2},
+			want:  entity.ChartMovementReEntry,
+		},
+		{
+			name:  "moved up",
+			entry: &entity.ChartEntryDB{Week: week, Position: 2, PreviousPosition: position(7), WeeksOnChart: 2, PeakPosition: 2},
+			want:  entity.ChartMovementUp,
+		},
+		{
+			name:  "moved down",
+			entry: &entity.ChartEntryDB{Week: week, Position: 9, PreviousPosition: position(7), WeeksOnChart: 2, PeakPosition: 7},
+			want:  entity.ChartMovementDown,
+		},
+		{
+			name:  "same position",
+			entry: &entity.ChartEntryDB{Week: week, Position: 7, PreviousPosition: position(7), WeeksOnChart: 2, PeakPosition: 7},
+			want:  entity.ChartMovementSame,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &presenter{}
+			got := p.ToChartEntryView(tt.entry)
+			if got.Movement != tt.want {
+				t.Errorf("presenter.ToChartEntryView().Movement = %v, want %v", got.Movement, tt.want)
+			}
+			if got.Week != "2023-03-20" {
+				t.Errorf("presenter.ToChartEntryView().Week = %v, want 2023-03-20", got.Week)
+			}
+		})
+	}
+}
+
+func Test_presenter_ToJWKView(t *testing.T) {
+	edPublic := ed25519.PublicKey(make([]byte, ed25519.PublicKeySize))
+	edPublic[0] = 0xfb
+
+	tests := []struct {
+		name string
+		key  *entity.SigningKey
+		want *view.JWKView
+	}{
+		{
+			name: "success RS256",
+			key: &entity.SigningKey{
+				SigningKeyDB: &entity.SigningKeyDB{ID: "rsa-kid", Algorithm: entity.SigningAlgorithmRS256},
+				Public:       &rsa.PublicKey{N: big.NewInt(0xfbff), E: 65537},
+			},
+			want: &view.JWKView{Kty: "RSA", Use: "sig", Alg: "RS256", Kid: "rsa-kid", N: "-_8", E: "AQAB"},
+		},
+		{
+			name: "success EdDSA",
+			key: &entity.SigningKey{
+				SigningKeyDB: &entity.SigningKeyDB{ID: "ed-kid", Algorithm: entity.SigningAlgorithmEdDSA},
+				Public:       edPublic,
+			},
+			want: &view.JWKView{Kty: "OKP", Use: "sig", Alg: "EdDSA", Kid: "ed-kid", Crv: "Ed25519", X: "-wAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &presenter{}
+			got := p.ToJWKView(tt.key)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("presenter.ToJWKView() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
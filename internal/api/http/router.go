@@ -2,12 +2,15 @@ package http
 
 import (
 	"fmt"
+	"music-backend-test/cmd/music-backend-test/config"
 	"music-backend-test/docs"
 	"music-backend-test/internal/api/http/handlers"
 	"music-backend-test/internal/api/http/middlewares"
 	"music-backend-test/internal/api/http/presenter"
 	"music-backend-test/internal/db"
 	"music-backend-test/internal/entity"
+	"music-backend-test/internal/notify"
+	"music-backend-test/internal/oidc"
 	"music-backend-test/internal/repository"
 	"music-backend-test/internal/usecase"
 	"music-backend-test/internal/utils"
@@ -24,21 +27,46 @@ import (
 )
 
 type routerHandlers struct {
-	userHandlers  handlers.UserHandlers
-	authHandlers  handlers.AuthHandlers
-	musicHandlers handlers.MusicHandlers
+	userHandlers           handlers.UserHandlers
+	authHandlers           handlers.AuthHandlers
+	musicHandlers          handlers.MusicHandlers
+	playHandlers           handlers.PlayHandlers
+	popularityHandlers     handlers.PopularityHandlers
+	chartHandlers          handlers.ChartHandlers
+	recommendationHandlers handlers.RecommendationHandlers
+	ratingHandlers         handlers.RatingHandlers
+	commentHandlers        handlers.CommentHandlers
+	lyricsHandlers         handlers.LyricsHandlers
+	musicRevisionHandlers  handlers.MusicRevisionHandlers
+	trashHandlers          handlers.TrashHandlers
+	releaseHandlers        handlers.ReleaseHandlers
+	artistHandlers         handlers.ArtistHandlers
+	playlistHandlers       handlers.PlaylistHandlers
+	followHandlers         handlers.FollowHandlers
+	notificationHandlers   handlers.NotificationHandlers
+	shareHandlers          handlers.ShareHandlers
+	statsHandlers          handlers.StatsHandlers
+	exportHandlers         handlers.ExportHandlers
+	playlistImportHandlers handlers.PlaylistImportHandlers
+	smartPlaylistHandlers  handlers.SmartPlaylistHandlers
+	syncHandlers           handlers.SyncHandlers
+	sessionHandlers        handlers.SessionHandlers
+	signingKeyHandlers     handlers.SigningKeyHandlers
+	identityHandlers       handlers.IdentityHandlers
 }
 
 type router struct {
 	router   *gin.Engine
+	config   *config.Config
 	db       *sqlx.DB
 	handlers routerHandlers
 	logger   *zap.Logger
 }
 
-func NewRouter(db *sqlx.DB, logger *zap.Logger) *router {
+func NewRouter(cfg *config.Config, db *sqlx.DB, logger *zap.Logger) *router {
 	return &router{
 		router: gin.New(),
+		config: cfg,
 		db:     db,
 		logger: logger,
 	}
@@ -73,7 +101,7 @@ func (r *router) registerRoutes() error {
 	corsMiddleware := cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},
-		AllowHeaders:     []string{"Authorization", "Content-Type"},
+		AllowHeaders:     []string{"Authorization", "Content-Type", "X-Device-Name"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	})
@@ -98,31 +126,127 @@ func (r *router) registerRoutes() error {
 	pgSource := db.NewSource(r.db)
 	userSource := db.NewUserSourсe(pgSource)
 	musicSource := db.NewMusicSource(pgSource)
+	playSource := db.NewPlaySource(pgSource)
+	popularitySource := db.NewPopularitySource(pgSource)
+	chartSource := db.NewChartSource(pgSource)
+	recommendationSource := db.NewRecommendationSource(pgSource)
+	ratingSource := db.NewRatingSource(pgSource)
+	commentSource := db.NewCommentSource(pgSource)
+	lyricsSource := db.NewLyricsSource(pgSource)
+	musicRevisionSource := db.NewMusicRevisionSource(pgSource)
+	trashSource := db.NewTrashSource(pgSource)
+	releaseSource := db.NewReleaseSource(pgSource)
+	artistSource := db.NewArtistSource(pgSource)
+	playlistSource := db.NewPlaylistSource(pgSource)
+	followSource := db.NewFollowSource(pgSource)
+	notificationSource := db.NewNotificationSource(pgSource)
+	shareSource := db.NewShareSource(pgSource)
+	statsSource := db.NewStatsSource(pgSource)
+	exportSource := db.NewExportSource(pgSource)
+	playlistImportSource := db.NewPlaylistImportSource(pgSource)
+	smartPlaylistSource := db.NewSmartPlaylistSource(pgSource)
+	syncSource := db.NewSyncSource(pgSource)
+	refreshTokenSource := db.NewRefreshTokenSource(pgSource)
+	duplicateSource := db.NewDuplicateSource(pgSource)
+	sessionSource := db.NewSessionSource(pgSource)
+	signingKeySource := db.NewSigningKeySource(pgSource)
 
-	userRepository := repository.NewUserRepository(userSource)
+	authConfig := entity.NewAuthConfig(r.config)
+	keysConfig := entity.NewKeysConfig(r.config)
+	sessionCache := repository.NewSessionCache(authConfig.SessionCacheTTL)
+	keyManager := repository.NewKeyManager(signingKeySource, keysConfig.CacheTTL)
+	userRepository := repository.NewUserRepository(userSource, refreshTokenSource, sessionCache, keyManager, utils.NewPasswordHasher())
 	osBackup := utils.NewFileSystem()
 	musicUtils := utils.NewmusicUtils()
-	musicRepository := repository.NewMusicRepository(musicSource, musicUtils, osBackup)
+	musicRepository := repository.NewMusicRepository(musicSource, musicRevisionSource, lyricsSource, duplicateSource, musicUtils, osBackup)
+	playRepository := repository.NewPlayRepository(playSource)
+	popularityRepository := repository.NewPopularityRepository(popularitySource)
+	chartRepository := repository.NewChartRepository(chartSource)
+	recommendationRepository := repository.NewRecommendationRepository(recommendationSource)
+	ratingRepository := repository.NewRatingRepository(ratingSource)
+	commentRepository := repository.NewCommentRepository(commentSource, notificationSource)
+	lyricsRepository := repository.NewLyricsRepository(lyricsSource)
+	musicRevisionRepository := repository.NewMusicRevisionRepository(musicRevisionSource, osBackup)
+	trashRepository := repository.NewTrashRepository(trashSource, musicRevisionSource, osBackup)
+	releaseRepository := repository.NewReleaseRepository(releaseSource)
+	artistRepository := repository.NewArtistRepository(artistSource)
+	playlistRepository := repository.NewPlaylistRepository(playlistSource)
+	followRepository := repository.NewFollowRepository(followSource)
+	notificationRepository := repository.NewNotificationRepository(notificationSource, notify.NewChannels(r.config))
+	shareRepository := repository.NewShareRepository(shareSource, musicSource, playlistSource)
+	statsRepository := repository.NewStatsRepository(statsSource)
+	exportRepository := repository.NewExportRepository(exportSource, osBackup)
+	playlistImportRepository := repository.NewPlaylistImportRepository(playlistImportSource)
+	smartPlaylistRepository := repository.NewSmartPlaylistRepository(smartPlaylistSource)
+	syncRepository := repository.NewSyncRepository(syncSource)
+	sessionRepository := repository.NewSessionRepository(sessionSource, sessionCache)
+	signingKeyRepository := repository.NewSigningKeyRepository(signingKeySource, keyManager)
 
-	userInteractor := usecase.NewUserInteractor(userRepository)
+	userInteractor := usecase.NewUserInteractor(userRepository, authConfig)
 	musicInteractor := usecase.NewMusicInteractor(musicRepository)
+	playInteractor := usecase.NewPlayInteractor(playRepository)
+	popularityInteractor := usecase.NewPopularityInteractor(popularityRepository, entity.NewPopularityConfig(r.config))
+	chartInteractor := usecase.NewChartInteractor(chartRepository, entity.NewChartConfig(r.config))
+	recommendationInteractor := usecase.NewRecommendationInteractor(recommendationRepository, entity.NewSimilarityConfig(r.config))
+	ratingInteractor := usecase.NewRatingInteractor(ratingRepository)
+	commentInteractor := usecase.NewCommentInteractor(commentRepository, entity.NewCommentConfig(r.config))
+	lyricsInteractor := usecase.NewLyricsInteractor(lyricsRepository)
+	musicRevisionInteractor := usecase.NewMusicRevisionInteractor(musicRevisionRepository)
+	trashInteractor := usecase.NewTrashInteractor(trashRepository, entity.NewTrashConfig(r.config))
+	releaseInteractor := usecase.NewReleaseInteractor(releaseRepository)
+	artistInteractor := usecase.NewArtistInteractor(artistRepository)
+	playlistInteractor := usecase.NewPlaylistInteractor(playlistRepository)
+	followInteractor := usecase.NewFollowInteractor(followRepository)
+	notificationInteractor := usecase.NewNotificationInteractor(notificationRepository, entity.NewNotificationConfig(r.config))
+	shareInteractor := usecase.NewShareInteractor(shareRepository)
+	statsInteractor := usecase.NewStatsInteractor(statsRepository, entity.NewStatsConfig(r.config))
+	exportInteractor := usecase.NewExportInteractor(exportRepository, entity.NewExportConfig(r.config))
+	playlistImportInteractor := usecase.NewPlaylistImportInteractor(playlistImportRepository)
+	smartPlaylistInteractor := usecase.NewSmartPlaylistInteractor(smartPlaylistRepository)
+	syncInteractor := usecase.NewSyncInteractor(syncRepository, entity.NewSyncConfig(r.config))
+	sessionInteractor := usecase.NewSessionInteractor(sessionRepository)
+	signingKeyInteractor := usecase.NewSigningKeyInteractor(signingKeyRepository, keysConfig)
+
+	// Вход через OpenID Connect провайдера доступен, только если провайдер настроен
+	oidcConfig := entity.NewOIDCConfig(r.config)
+	var identityInteractor usecase.IdentityInteractor
+	if oidcConfig.Enabled() {
+		identityRepository := repository.NewIdentityRepository(db.NewIdentitySource(pgSource), oidc.NewProvider(oidcConfig), utils.NewPasswordHasher())
+		identityInteractor = usecase.NewIdentityInteractor(identityRepository, oidcConfig)
+	}
 
 	presenter := presenter.NewPresenter()
 
 	r.handlers.authHandlers = handlers.NewAuthHandlers(userInteractor, presenter)
 
+	r.handlers.signingKeyHandlers = handlers.NewSigningKeyHandlers(signingKeyInteractor, presenter)
+	basePath.GET("/.well-known/jwks.json", r.handlers.signingKeyHandlers.GetJWKS)
+
 	authGroup := basePath.Group("/auth")
 	authGroup.POST("/signup", r.handlers.authHandlers.SignUp)
 	authGroup.POST("/signin", r.handlers.authHandlers.SignIn)
+	authGroup.POST("/refresh", r.handlers.authHandlers.Refresh)
+	authGroup.POST("/logout", r.handlers.authHandlers.Logout)
+	authGroup.POST("/logout-all", middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor), r.handlers.authHandlers.LogoutAll)
+	if identityInteractor != nil {
+		r.handlers.identityHandlers = handlers.NewIdentityHandlers(identityInteractor, userInteractor, presenter)
+		authGroup.GET("/oidc/login", r.handlers.identityHandlers.Login)
+		authGroup.GET(
+			"/oidc/callback",
+			middlewares.NewOptionalAuthMiddleware(signingKeyInteractor, sessionInteractor),
+			r.handlers.identityHandlers.Callback,
+		)
+	}
 
 	userGroup := basePath.Group("/users")
 	{
-		userGroup.Use(middlewares.NewAuthMiddleware())
+		userGroup.Use(middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor))
 
 		r.handlers.userHandlers = handlers.NewUserHandlers(userInteractor, presenter)
 		userGroup.GET("/me", r.handlers.userHandlers.GetMeHandler)
 		userGroup.PUT("/me", r.handlers.userHandlers.UpdateMeHandler)
 		userGroup.DELETE("/me", r.handlers.userHandlers.DeleteMeHandler)
+		userGroup.PUT("/me/content-filter", r.handlers.userHandlers.SetContentFilterHandler)
 		userGroup.GET(
 			"/id/:id",
 			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
@@ -150,17 +274,179 @@ func (r *router) registerRoutes() error {
 			r.handlers.userHandlers.DislikeTrack,
 		)
 		userGroup.GET("/get-liked-tracks", r.handlers.userHandlers.ShowLikedTracks)
+
+		r.handlers.playHandlers = handlers.NewPlayHandlers(playInteractor, presenter)
+		userGroup.GET("/me/history", r.handlers.playHandlers.GetHistory)
+		userGroup.DELETE("/me/history", r.handlers.playHandlers.DeleteHistory)
+		userGroup.DELETE("/me/history/:id", r.handlers.playHandlers.DeleteHistoryEvent)
+
+		r.handlers.statsHandlers = handlers.NewStatsHandlers(statsInteractor, presenter)
+		userGroup.GET("/me/stats", r.handlers.statsHandlers.Get)
+		userGroup.GET("/me/year-in-review/:year", r.handlers.statsHandlers.GetYearReview)
+
+		r.handlers.exportHandlers = handlers.NewExportHandlers(exportInteractor, presenter)
+		userGroup.GET("/me/export", r.handlers.exportHandlers.Export)
+		userGroup.GET("/me/exports/:id", r.handlers.exportHandlers.GetJob)
+		userGroup.GET("/me/exports/:id/download", r.handlers.exportHandlers.Download)
+
+		r.handlers.recommendationHandlers = handlers.NewRecommendationHandlers(recommendationInteractor, presenter)
+		userGroup.GET("/me/recommendations", r.handlers.recommendationHandlers.GetForUser)
+
+		r.handlers.ratingHandlers = handlers.NewRatingHandlers(ratingInteractor, presenter)
+		userGroup.GET("/me/library", r.handlers.ratingHandlers.GetLibrary)
+
+		r.handlers.playlistHandlers = handlers.NewPlaylistHandlers(playlistInteractor, presenter)
+		userGroup.GET("/me/playlists", r.handlers.playlistHandlers.GetMine)
+
+		r.handlers.smartPlaylistHandlers = handlers.NewSmartPlaylistHandlers(smartPlaylistInteractor, presenter)
+		userGroup.GET("/me/smart-playlists", r.handlers.smartPlaylistHandlers.GetMine)
+
+		r.handlers.shareHandlers = handlers.NewShareHandlers(shareInteractor, presenter)
+		userGroup.GET("/me/shares", r.handlers.shareHandlers.GetMine)
+
+		r.handlers.followHandlers = handlers.NewFollowHandlers(followInteractor, presenter)
+		userGroup.GET("/me/feed", r.handlers.followHandlers.GetFeed)
+		userGroup.GET("/me/following", r.handlers.followHandlers.GetFollowing)
+		userGroup.PUT("/me/privacy", r.handlers.followHandlers.SetPrivacy)
+		userGroup.POST("/:id/follow", r.handlers.followHandlers.FollowUser)
+		userGroup.DELETE("/:id/follow", r.handlers.followHandlers.UnfollowUser)
+
+		r.handlers.sessionHandlers = handlers.NewSessionHandlers(sessionInteractor, presenter)
+		userGroup.GET("/me/sessions", r.handlers.sessionHandlers.GetMine)
+		userGroup.DELETE("/me/sessions/:id", r.handlers.sessionHandlers.RevokeMine)
+		if r.handlers.identityHandlers != nil {
+			userGroup.GET("/me/identities", r.handlers.identityHandlers.GetMine)
+			userGroup.POST("/me/identities", r.handlers.identityHandlers.Link)
+		}
+		userGroup.DELETE(
+			"/:id/sessions",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.sessionHandlers.RevokeAll,
+		)
+	}
+
+	playGroup := basePath.Group("/plays")
+	{
+		playGroup.Use(middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor))
+
+		playGroup.POST("", r.handlers.playHandlers.Record)
+		playGroup.POST("/batch", r.handlers.playHandlers.RecordBatch)
 	}
 
 	r.handlers.musicHandlers = handlers.NewMusicHandlers(musicInteractor, presenter)
+	r.handlers.popularityHandlers = handlers.NewPopularityHandlers(popularityInteractor, presenter)
+	r.handlers.chartHandlers = handlers.NewChartHandlers(chartInteractor, presenter)
+	r.handlers.commentHandlers = handlers.NewCommentHandlers(commentInteractor, presenter)
+	r.handlers.lyricsHandlers = handlers.NewLyricsHandlers(lyricsInteractor, presenter)
+	r.handlers.musicRevisionHandlers = handlers.NewMusicRevisionHandlers(musicRevisionInteractor, presenter)
+	r.handlers.releaseHandlers = handlers.NewReleaseHandlers(releaseInteractor, presenter)
+	r.handlers.artistHandlers = handlers.NewArtistHandlers(artistInteractor, presenter)
 	musicGroup := basePath.Group("/music")
 	{
-		musicGroup.Use(middlewares.NewAuthMiddleware())
+		musicGroup.Use(middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor))
 
-		musicGroup.GET("/catalog", r.handlers.musicHandlers.GetAll)
-		musicGroup.GET("/download/:id", r.handlers.musicHandlers.Get)
-		musicGroup.GET("/release", r.handlers.musicHandlers.GetAllSortByTime)
-		musicGroup.GET("/popular", r.handlers.musicHandlers.GetAndSortByPopular)
+		musicGroup.GET(
+			"/catalog",
+			middlewares.NewUserRoleMiddleware(userInteractor),
+			r.handlers.musicHandlers.GetAll,
+		)
+		musicGroup.GET(
+			"/download/:id",
+			middlewares.NewUserRoleMiddleware(userInteractor),
+			middlewares.NewPlayTrackingMiddleware(playInteractor),
+			r.handlers.musicHandlers.Get,
+		)
+		musicGroup.GET(
+			"/release",
+			middlewares.NewUserRoleMiddleware(userInteractor),
+			r.handlers.musicHandlers.GetAllSortByTime,
+		)
+		musicGroup.GET(
+			"/scheduled",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.releaseHandlers.GetScheduled,
+		)
+		musicGroup.PUT(
+			"/:id/schedule",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.releaseHandlers.SetSchedule,
+		)
+		musicGroup.PUT(
+			"/:id/explicit",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.musicHandlers.SetExplicit,
+		)
+		musicGroup.PUT(
+			"/:id/artist",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.artistHandlers.SetMusicArtist,
+		)
+		musicGroup.GET(
+			"/popular",
+			middlewares.NewUserRoleMiddleware(userInteractor),
+			r.handlers.popularityHandlers.GetPopular,
+		)
+		musicGroup.GET(
+			"/trending",
+			middlewares.NewUserRoleMiddleware(userInteractor),
+			r.handlers.popularityHandlers.GetTrending,
+		)
+		musicGroup.GET("/:id/charts", r.handlers.chartHandlers.GetMusicHistory)
+		musicGroup.GET("/:id/similar", r.handlers.recommendationHandlers.GetSimilar)
+		musicGroup.PUT("/:id/rating", r.handlers.ratingHandlers.Set)
+		musicGroup.DELETE("/:id/rating", r.handlers.ratingHandlers.Clear)
+		musicGroup.GET(
+			"/:id/comments",
+			middlewares.NewUserRoleMiddleware(userInteractor),
+			r.handlers.commentHandlers.GetByMusic,
+		)
+		musicGroup.POST("/:id/comments", r.handlers.commentHandlers.Create)
+		musicGroup.POST("/:id/shares", r.handlers.shareHandlers.CreateForMusic)
+		musicGroup.GET(
+			"/:id/lyrics",
+			middlewares.NewUserRoleMiddleware(userInteractor),
+			r.handlers.lyricsHandlers.Get,
+		)
+		musicGroup.PUT(
+			"/:id/lyrics",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.lyricsHandlers.Set,
+		)
+		musicGroup.DELETE(
+			"/:id/lyrics",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.lyricsHandlers.Delete,
+		)
+		musicGroup.GET(
+			"/:id/revisions",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.musicRevisionHandlers.GetByMusic,
+		)
+		musicGroup.GET(
+			"/:id/revisions/diff",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.musicRevisionHandlers.Diff,
+		)
+		musicGroup.GET(
+			"/:id/revisions/:version",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.musicRevisionHandlers.Get,
+		)
+		musicGroup.POST(
+			"/:id/revisions/:version/revert",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.musicRevisionHandlers.Revert,
+		)
+		musicGroup.GET(
+			"/:id/duplicates",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.musicHandlers.GetDuplicates,
+		)
+		musicGroup.POST(
+			"/:id/merge",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.musicHandlers.Merge,
+		)
 		musicGroup.POST(
 			"/new",
 			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
@@ -178,5 +464,152 @@ func (r *router) registerRoutes() error {
 		)
 	}
 
+	chartGroup := basePath.Group("/charts")
+	{
+		chartGroup.Use(middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor))
+
+		chartGroup.GET(
+			"/weekly",
+			middlewares.NewUserRoleMiddleware(userInteractor),
+			r.handlers.chartHandlers.GetWeekly,
+		)
+	}
+
+	commentGroup := basePath.Group("/comments")
+	{
+		commentGroup.Use(middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor))
+
+		commentGroup.GET(
+			"/reports",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.commentHandlers.GetModerationQueue,
+		)
+		commentGroup.GET(
+			"/:id/replies",
+			middlewares.NewUserRoleMiddleware(userInteractor),
+			r.handlers.commentHandlers.GetReplies,
+		)
+		commentGroup.PUT("/:id", r.handlers.commentHandlers.Update)
+		commentGroup.DELETE(
+			"/:id",
+			middlewares.NewUserRoleMiddleware(userInteractor),
+			r.handlers.commentHandlers.Delete,
+		)
+		commentGroup.POST("/:id/reports", r.handlers.commentHandlers.Report)
+		commentGroup.POST(
+			"/:id/hide",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.commentHandlers.Hide,
+		)
+		commentGroup.POST(
+			"/:id/unhide",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.commentHandlers.Unhide,
+		)
+		commentGroup.POST(
+			"/:id/reports/dismiss",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.commentHandlers.DismissReports,
+		)
+	}
+
+	artistGroup := basePath.Group("/artists")
+	{
+		artistGroup.Use(middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor))
+
+		artistGroup.POST(
+			"",
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+			r.handlers.artistHandlers.Create,
+		)
+		artistGroup.GET("/:id", r.handlers.artistHandlers.Get)
+		artistGroup.POST("/:id/follow", r.handlers.followHandlers.FollowArtist)
+		artistGroup.DELETE("/:id/follow", r.handlers.followHandlers.UnfollowArtist)
+	}
+
+	playlistGroup := basePath.Group("/playlists")
+	{
+		playlistGroup.Use(middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor))
+
+		r.handlers.playlistImportHandlers = handlers.NewPlaylistImportHandlers(playlistImportInteractor, presenter)
+		playlistGroup.POST("", r.handlers.playlistHandlers.Create)
+		playlistGroup.POST("/import", r.handlers.playlistImportHandlers.Import)
+		playlistGroup.GET("/:id", r.handlers.playlistHandlers.Get)
+		playlistGroup.PUT("/:id", r.handlers.playlistHandlers.Update)
+		playlistGroup.DELETE("/:id", r.handlers.playlistHandlers.Delete)
+		playlistGroup.GET("/:id/tracks", r.handlers.playlistHandlers.GetTracks)
+		playlistGroup.POST("/:id/tracks", r.handlers.playlistHandlers.AddTrack)
+		playlistGroup.DELETE("/:id/tracks/:music_id", r.handlers.playlistHandlers.RemoveTrack)
+		playlistGroup.POST("/:id/shares", r.handlers.shareHandlers.CreateForPlaylist)
+		playlistGroup.GET(
+			"/:id/comments",
+			middlewares.NewUserRoleMiddleware(userInteractor),
+			r.handlers.commentHandlers.GetByPlaylist,
+		)
+		playlistGroup.POST("/:id/comments", r.handlers.commentHandlers.CreateForPlaylist)
+	}
+
+	smartPlaylistGroup := basePath.Group("/smart-playlists")
+	{
+		smartPlaylistGroup.Use(middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor))
+
+		smartPlaylistGroup.POST("", r.handlers.smartPlaylistHandlers.Create)
+		smartPlaylistGroup.GET("/:id", r.handlers.smartPlaylistHandlers.Get)
+		smartPlaylistGroup.PUT("/:id", r.handlers.smartPlaylistHandlers.Update)
+		smartPlaylistGroup.DELETE("/:id", r.handlers.smartPlaylistHandlers.Delete)
+		smartPlaylistGroup.GET("/:id/tracks", r.handlers.smartPlaylistHandlers.GetTracks)
+	}
+
+	syncGroup := basePath.Group("/sync")
+	{
+		syncGroup.Use(middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor))
+
+		r.handlers.syncHandlers = handlers.NewSyncHandlers(syncInteractor, presenter)
+		syncGroup.GET("", r.handlers.syncHandlers.Sync)
+	}
+
+	shareGroup := basePath.Group("/shares")
+	{
+		shareGroup.Use(middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor))
+
+		shareGroup.DELETE("/:id", r.handlers.shareHandlers.Revoke)
+	}
+
+	// Ссылки открываются без аккаунта: доступ проверяется по токену, а не NewAuthMiddleware
+	sharedGroup := basePath.Group("/shared/:token")
+	{
+		sharedGroup.Use(middlewares.NewShareTokenMiddleware(shareInteractor))
+
+		sharedGroup.GET("", r.handlers.shareHandlers.GetShared)
+		sharedGroup.GET("/stream", r.handlers.shareHandlers.StreamShared)
+		sharedGroup.GET("/stream/:id", r.handlers.shareHandlers.StreamSharedTrack)
+	}
+
+	r.handlers.notificationHandlers = handlers.NewNotificationHandlers(notificationInteractor, presenter)
+	notificationGroup := basePath.Group("/notifications")
+	{
+		notificationGroup.Use(middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor))
+
+		notificationGroup.GET("", r.handlers.notificationHandlers.Get)
+		notificationGroup.POST("/read-all", r.handlers.notificationHandlers.MarkAllRead)
+		notificationGroup.POST("/:id/read", r.handlers.notificationHandlers.MarkRead)
+		notificationGroup.GET("/preferences", r.handlers.notificationHandlers.GetSettings)
+		notificationGroup.PUT("/preferences", r.handlers.notificationHandlers.SetSettings)
+	}
+
+	r.handlers.trashHandlers = handlers.NewTrashHandlers(trashInteractor, presenter)
+	trashGroup := basePath.Group("/trash")
+	{
+		trashGroup.Use(
+			middlewares.NewAuthMiddleware(signingKeyInteractor, sessionInteractor),
+			middlewares.NewCheckRoleMiddleware([]string{entity.AdminRole}, userInteractor),
+		)
+
+		trashGroup.GET("/music", r.handlers.trashHandlers.GetMusic)
+		trashGroup.GET("/users", r.handlers.trashHandlers.GetUsers)
+		trashGroup.POST("/music/:id/restore", r.handlers.trashHandlers.RestoreMusic)
+		trashGroup.POST("/users/:id/restore", r.handlers.trashHandlers.RestoreUser)
+	}
+
 	return nil
 }
@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"fmt"
+	"music-backend-test/cmd/music-backend-test/config"
 	"net/http"
 	"time"
 
@@ -27,6 +28,7 @@ type server struct {
 
 func NewServer(
 	addr string,
+	cfg *config.Config,
 	db *sqlx.DB,
 	logger *zap.Logger,
 ) *server {
@@ -35,7 +37,7 @@ func NewServer(
 		logger: logger,
 	}
 
-	r := NewRouter(db, logger)
+	r := NewRouter(cfg, db, logger)
 	err := r.Init()
 	if err != nil {
 		s.logger.Error("can't init router:", zap.Error(err))
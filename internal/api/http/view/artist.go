@@ -0,0 +1,7 @@
+package view
+
+type ArtistView struct {
+	ID        string `json:"id"`         // id исполнителя
+	Name      string `json:"name"`       // имя исполнителя
+	CreatedAt string `json:"created_at"` // время создания в формате RFC3339
+}
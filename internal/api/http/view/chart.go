@@ -0,0 +1,19 @@
+package view
+
+type ChartEntryView struct {
+	Week             string    `json:"week"`              // неделя чарта (дата понедельника)
+	Position         int       `json:"position"`          // позиция в чарте
+	PreviousPosition *int      `json:"previous_position"` // позиция на предыдущей неделе
+	PeakPosition     int       `json:"peak_position"`     // лучшая позиция
+	WeeksOnChart     int       `json:"weeks_on_chart"`    // количество недель в чарте
+	Movement         string    `json:"movement"`          // изменение позиции (new, up, down, same, re-entry)
+	Plays            int64     `json:"plays"`             // количество прослушиваний за неделю
+	Likes            int64     `json:"likes"`             // количество лайков за неделю
+	Score            float64   `json:"score"`             // рейтинг за неделю
+	Music            MusicView `json:"music"`             // трек
+}
+
+type ChartView struct {
+	Week    string            `json:"week"`    // неделя чарта (дата понедельника)
+	Entries []*ChartEntryView `json:"entries"` // позиции чарта
+}
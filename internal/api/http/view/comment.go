@@ -0,0 +1,35 @@
+package view
+
+type CommentAuthorView struct {
+	ID       string `json:"id"`       // id пользователя
+	Username string `json:"username"` // имя пользователя
+}
+
+type CommentView struct {
+	ID         string            `json:"id"`          // id комментария
+	MusicID    *string           `json:"music_id"`    // id трека, null для комментария к плейлисту
+	PlaylistID *string           `json:"playlist_id"` // id плейлиста, null для комментария к треку
+	ParentID   *string           `json:"parent_id"`   // id комментария, на который дан ответ
+	Author     CommentAuthorView `json:"author"`      // автор комментария
+	Body       string            `json:"body"`        // текст комментария
+	Status     string            `json:"status"`      // статус (visible, hidden, deleted)
+	ReplyCount int               `json:"reply_count"` // количество ответов
+	CreatedAt  string            `json:"created_at"`  // время создания в формате RFC3339
+	EditedAt   *string           `json:"edited_at"`   // время последнего редактирования в формате RFC3339
+}
+
+type CommentPageView struct {
+	Comments   []*CommentView `json:"comments"`    // комментарии страницы
+	NextCursor string         `json:"next_cursor"` // курсор следующей страницы, пустой если страница последняя
+}
+
+type ModerationItemView struct {
+	CommentView
+	Reports        int    `json:"reports"`          // количество открытых жалоб
+	LastReason     string `json:"last_reason"`      // причина последней жалобы
+	LastReportedAt string `json:"last_reported_at"` // время последней жалобы в формате RFC3339
+}
+
+type ReportsDismissedView struct {
+	Dismissed int64 `json:"dismissed"` // количество отклоненных жалоб
+}
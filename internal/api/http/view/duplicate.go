@@ -0,0 +1,24 @@
+package view
+
+type MusicUploadView struct {
+	Music      *MusicView            `json:"music"`      // загруженный трек
+	Duplicates []*MusicDuplicateView `json:"duplicates"` // предупреждения о вероятных дубликатах
+}
+
+type MusicDuplicateView struct {
+	MusicID    string   `json:"music_id"`    // id похожего трека
+	Name       string   `json:"name"`        // название похожего трека
+	Link       string   `json:"link"`        // ссылка на похожий трек
+	SameHash   bool     `json:"same_hash"`   // файлы совпадают побайтно
+	SameTitle  bool     `json:"same_title"`  // совпадают названия при близкой продолжительности
+	Similarity *float64 `json:"similarity"`  // сходство звука от 0 до 1, null если отпечатка нет
+	DetectedAt string   `json:"detected_at"` // время обнаружения в формате RFC3339
+}
+
+type MusicMergeView struct {
+	MusicID         string `json:"music_id"`         // id дубликата, перемещенного в корзину
+	IntoID          string `json:"into_id"`          // id оставленного трека
+	Likes           int64  `json:"likes"`            // перенесенные лайки
+	PlaylistEntries int64  `json:"playlist_entries"` // перенесенные записи плейлистов
+	Plays           int64  `json:"plays"`            // перенесенные события истории прослушиваний
+}
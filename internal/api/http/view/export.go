@@ -0,0 +1,14 @@
+package view
+
+type ExportJobView struct {
+	ID          string  `json:"id"`           // id задачи
+	Format      string  `json:"format"`       // формат выгрузки
+	PlaylistID  *string `json:"playlist_id"`  // id плейлиста для M3U8 и XSPF
+	Status      string  `json:"status"`       // pending, running, done или failed
+	Items       int64   `json:"items"`        // количество записей на момент создания задачи
+	Size        int64   `json:"size"`         // размер файла в байтах
+	CreatedAt   string  `json:"created_at"`   // время создания в формате RFC3339
+	FinishedAt  *string `json:"finished_at"`  // время завершения в формате RFC3339
+	ExpiresAt   *string `json:"expires_at"`   // время удаления файла в формате RFC3339
+	DownloadURL *string `json:"download_url"` // ссылка на файл, пока выгрузка не готова — null
+}
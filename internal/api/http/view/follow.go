@@ -0,0 +1,29 @@
+package view
+
+type FollowedUserView struct {
+	ID       string `json:"id"`       // id пользователя
+	Username string `json:"username"` // имя пользователя
+}
+
+type FollowingView struct {
+	Artists []*ArtistView       `json:"artists"` // исполнители
+	Users   []*FollowedUserView `json:"users"`   // пользователи
+}
+
+type FeedRefView struct {
+	ID   string `json:"id"`   // id
+	Name string `json:"name"` // название или имя
+}
+
+type FeedItemView struct {
+	Kind       string       `json:"kind"`        // тип события (release, like, playlist)
+	OccurredAt string       `json:"occurred_at"` // время события в формате RFC3339
+	Actor      FeedRefView  `json:"actor"`       // исполнитель для релиза, пользователь для лайка и плейлиста
+	Music      *FeedRefView `json:"music"`       // трек для релиза и лайка
+	Playlist   *FeedRefView `json:"playlist"`    // плейлист
+}
+
+type FeedPageView struct {
+	Items      []*FeedItemView `json:"items"`       // события страницы
+	NextCursor string          `json:"next_cursor"` // курсор следующей страницы, пустой если страница последняя
+}
@@ -0,0 +1,13 @@
+package view
+
+type IdentityView struct {
+	ID          string `json:"id"`            // id привязки
+	Issuer      string `json:"issuer"`        // издатель учетной записи
+	Subject     string `json:"subject"`       // id учетной записи у издателя
+	CreatedAt   string `json:"created_at"`    // время привязки в формате RFC3339
+	LastLoginAt string `json:"last_login_at"` // время последнего входа через провайдера в формате RFC3339
+}
+
+type OIDCAuthorizationView struct {
+	AuthorizationURL string `json:"authorization_url"` // адрес страницы входа провайдера, куда нужно перейти пользователю
+}
@@ -0,0 +1,16 @@
+package view
+
+type LyricLineView struct {
+	TimeMs int64  `json:"time_ms"` // время начала строки в миллисекундах
+	Text   string `json:"text"`    // текст строки
+}
+
+type LyricsView struct {
+	MusicID            string           `json:"music_id"`            // id трека
+	Language           string           `json:"language"`            // язык текста
+	AvailableLanguages []string         `json:"available_languages"` // все языки, на которых есть текст трека
+	Plain              string           `json:"plain"`               // текст без разметки времени
+	Synced             []*LyricLineView `json:"synced"`              // строки синхронизированного текста, null если его нет
+	Source             string           `json:"source"`              // источник текста (upload, id3)
+	UpdatedAt          string           `json:"updated_at"`          // время последнего изменения в формате RFC3339
+}
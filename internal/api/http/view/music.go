@@ -1,8 +1,19 @@
 package view
 
 type MusicView struct {
-	ID       string `json:"id"`       // id трека
-	Name     string `json:"name"`     // название трека
-	Size     string `json:"size"`     // размер файла трека (в удобном для чтения виде)
-	Duration string `json:"duration"` // продолжительность трека
+	ID          string  `json:"id"`           // id трека
+	Name        string  `json:"name"`         // название трека
+	Size        string  `json:"size"`         // размер файла трека (в удобном для чтения виде)
+	Duration    string  `json:"duration"`     // продолжительность трека
+	Rating      float64 `json:"rating"`       // средняя оценка трека
+	RatingCount int64   `json:"rating_count"` // количество оценок трека
+	Explicit    bool    `json:"explicit"`     // трек содержит ненормативный контент
+	ArtistID    *string `json:"artist_id"`    // id исполнителя, null если исполнитель не указан
+}
+
+type PopularMusicView struct {
+	MusicView
+	Plays int64   `json:"plays"` // количество прослушиваний за окно
+	Likes int64   `json:"likes"` // количество лайков за окно
+	Score float64 `json:"score"` // рейтинг трека
 }
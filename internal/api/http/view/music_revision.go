@@ -0,0 +1,32 @@
+package view
+
+type RevisionAuthorView struct {
+	ID       string `json:"id"`       // id пользователя
+	Username string `json:"username"` // имя пользователя, пустое если пользователь удален
+}
+
+type MusicRevisionView struct {
+	Version      int                 `json:"version"`       // номер версии
+	Action       string              `json:"action"`        // изменение (create, update, revert)
+	Name         string              `json:"name"`          // название трека
+	Release      string              `json:"release"`       // дата релиза трека в формате 2006-01-02
+	FileName     string              `json:"file_name"`     // имя файла
+	Size         string              `json:"size"`          // размер файла трека (в удобном для чтения виде)
+	Duration     string              `json:"duration"`      // продолжительность трека
+	RevertedFrom *int                `json:"reverted_from"` // версия, к которой откатили трек
+	ChangedBy    *RevisionAuthorView `json:"changed_by"`    // автор изменения, null если изменение сделано без пользователя
+	CreatedAt    string              `json:"created_at"`    // время изменения в формате RFC3339
+}
+
+type MusicFieldChangeView struct {
+	Field string `json:"field"` // название поля
+	From  string `json:"from"`  // значение в исходной версии
+	To    string `json:"to"`    // значение в целевой версии
+}
+
+type MusicRevisionDiffView struct {
+	MusicID string                  `json:"music_id"` // id трека
+	From    *MusicRevisionView      `json:"from"`     // исходная версия
+	To      *MusicRevisionView      `json:"to"`       // целевая версия
+	Changes []*MusicFieldChangeView `json:"changes"`  // измененные поля
+}
@@ -0,0 +1,35 @@
+package view
+
+type NotificationView struct {
+	ID        string       `json:"id"`         // id уведомления
+	Type      string       `json:"type"`       // тип уведомления (release, comment_reply, playlist_comment)
+	Message   string       `json:"message"`    // текст уведомления
+	Actor     *FeedRefView `json:"actor"`      // исполнитель для релиза, автор комментария для остальных типов
+	Music     *FeedRefView `json:"music"`      // трек
+	Playlist  *FeedRefView `json:"playlist"`   // плейлист
+	CommentID *string      `json:"comment_id"` // id ответа или комментария к плейлисту
+	CreatedAt string       `json:"created_at"` // время создания в формате RFC3339
+	ReadAt    *string      `json:"read_at"`    // время прочтения в формате RFC3339, null если не прочитано
+}
+
+type NotificationPageView struct {
+	Items       []*NotificationView `json:"items"`        // уведомления страницы
+	UnreadCount int                 `json:"unread_count"` // всего непрочитанных уведомлений
+	NextCursor  string              `json:"next_cursor"`  // курсор следующей страницы, пустой если страница последняя
+}
+
+type NotificationsReadView struct {
+	Read int64 `json:"read"` // количество отмеченных прочитанными уведомлений
+}
+
+type NotificationPreferenceView struct {
+	Type    string `json:"type"`    // тип уведомления
+	InApp   bool   `json:"in_app"`  // показывать во входящих
+	Email   bool   `json:"email"`   // отправлять на email
+	Webhook bool   `json:"webhook"` // отправлять в webhook
+}
+
+type NotificationSettingsView struct {
+	Email       *string                       `json:"email"`       // адрес для email-канала
+	Preferences []*NotificationPreferenceView `json:"preferences"` // каналы по типам уведомлений
+}
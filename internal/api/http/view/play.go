@@ -0,0 +1,20 @@
+package view
+
+type PlayEventView struct {
+	ID        string `json:"id"`         // id события
+	MusicID   string `json:"music_id"`   // id трека
+	EventType string `json:"event_type"` // тип события (start, progress, complete)
+	Position  int    `json:"position"`   // позиция воспроизведения в секундах
+	Source    string `json:"source"`     // источник события (client, stream)
+	PlayedAt  string `json:"played_at"`  // время события в формате RFC3339
+}
+
+type PlayBatchResultView struct {
+	Accepted        int      `json:"accepted"`          // количество сохраненных событий
+	Duplicates      int      `json:"duplicates"`        // количество отброшенных дубликатов
+	UnknownMusicIDs []string `json:"unknown_music_ids"` // id неизвестных треков, события по ним пропущены
+}
+
+type DeletedView struct {
+	Deleted int64 `json:"deleted"` // количество удаленных записей
+}
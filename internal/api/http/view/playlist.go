@@ -0,0 +1,10 @@
+package view
+
+type PlaylistView struct {
+	ID        string `json:"id"`         // id плейлиста
+	UserID    string `json:"user_id"`    // id владельца
+	Name      string `json:"name"`       // название плейлиста
+	IsPublic  bool   `json:"is_public"`  // плейлист виден другим пользователям
+	CreatedAt string `json:"created_at"` // время создания в формате RFC3339
+	UpdatedAt string `json:"updated_at"` // время последнего изменения в формате RFC3339
+}
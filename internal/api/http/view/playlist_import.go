@@ -0,0 +1,26 @@
+package view
+
+type PlaylistImportReportView struct {
+	Playlist   *PlaylistView            `json:"playlist"`   // созданный плейлист
+	Total      int                      `json:"total"`      // количество записей в файле
+	Matched    int                      `json:"matched"`    // количество сопоставленных записей
+	Duplicates int                      `json:"duplicates"` // сопоставленные записи с треком, уже добавленным в плейлист
+	Unresolved []*ImportEntryReportView `json:"unresolved"` // неоднозначные и несопоставленные записи
+}
+
+type ImportEntryReportView struct {
+	Line        int                    `json:"line"`         // номер записи в файле
+	Status      string                 `json:"status"`       // ambiguous или unmatched
+	Title       string                 `json:"title"`        // название трека из файла
+	Artist      string                 `json:"artist"`       // имя исполнителя из файла
+	DurationSec int64                  `json:"duration_sec"` // продолжительность из файла, 0 — неизвестна
+	Location    string                 `json:"location"`     // ссылка или путь к файлу из плейлиста
+	Candidates  []*ImportCandidateView `json:"candidates"`   // подходящие треки каталога
+}
+
+type ImportCandidateView struct {
+	ID          string  `json:"id"`           // id трека
+	Name        string  `json:"name"`         // название трека
+	Artist      *string `json:"artist"`       // имя исполнителя
+	DurationSec int64   `json:"duration_sec"` // продолжительность в секундах
+}
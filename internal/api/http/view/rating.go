@@ -0,0 +1,14 @@
+package view
+
+type RatingView struct {
+	MusicID string  `json:"music_id"`     // id трека
+	Rating  int     `json:"rating"`       // оценка пользователя, 0 если трек не оценен
+	Average float64 `json:"average"`      // средняя оценка трека
+	Count   int64   `json:"rating_count"` // количество оценок трека
+}
+
+type LibraryMusicView struct {
+	MusicView
+	UserRating *int `json:"user_rating"` // оценка пользователя
+	Liked      bool `json:"liked"`       // трек лайкнут пользователем
+}
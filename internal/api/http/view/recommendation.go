@@ -0,0 +1,14 @@
+package view
+
+type SimilarMusicView struct {
+	MusicView
+	CoLikes int64   `json:"co_likes"` // количество пользователей, лайкнувших оба трека
+	Score   float64 `json:"score"`    // мера схожести
+}
+
+type RecommendationView struct {
+	MusicView
+	Score       float64 `json:"score"`        // рейтинг рекомендации
+	BecauseID   string  `json:"because_id"`   // id лайкнутого трека, на основе которого сделана рекомендация
+	BecauseName string  `json:"because_name"` // название лайкнутого трека
+}
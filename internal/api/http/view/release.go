@@ -0,0 +1,8 @@
+package view
+
+type ScheduledMusicView struct {
+	MusicView
+	PublishAt   string  `json:"publish_at"`   // запланированное время публикации в формате RFC3339
+	PublishedAt *string `json:"published_at"` // время публикации, пока трек не опубликован — null
+	TakedownAt  *string `json:"takedown_at"`  // время снятия с публикации
+}
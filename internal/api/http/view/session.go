@@ -0,0 +1,12 @@
+package view
+
+type SessionView struct {
+	ID         string `json:"id"`           // id сессии
+	DeviceName string `json:"device_name"`  // название устройства
+	UserAgent  string `json:"user_agent"`   // User-Agent последнего входа или обновления токенов
+	IP         string `json:"ip"`           // IP-адрес последнего входа или обновления токенов
+	CreatedAt  string `json:"created_at"`   // время входа в формате RFC3339
+	LastSeenAt string `json:"last_seen_at"` // время последнего входа или обновления токенов в формате RFC3339
+	ExpiresAt  string `json:"expires_at"`   // время истечения сессии в формате RFC3339
+	Current    bool   `json:"current"`      // сессия, в которой выполнен запрос
+}
@@ -0,0 +1,25 @@
+package view
+
+type ShareLinkView struct {
+	ID         string  `json:"id"`          // id ссылки
+	MusicID    *string `json:"music_id"`    // id трека, null для ссылки на плейлист
+	PlaylistID *string `json:"playlist_id"` // id плейлиста, null для ссылки на трек
+	ExpiresAt  *string `json:"expires_at"`  // время истечения в формате RFC3339, null — бессрочно
+	MaxPlays   *int    `json:"max_plays"`   // лимит прослушиваний, null — без лимита
+	Plays      int     `json:"plays"`       // количество прослушиваний по ссылке
+	CreatedAt  string  `json:"created_at"`  // время создания в формате RFC3339
+	RevokedAt  *string `json:"revoked_at"`  // время отзыва в формате RFC3339, null если ссылка не отозвана
+}
+
+type ShareLinkCreatedView struct {
+	Token string         `json:"token"` // токен ссылки, показывается только при создании
+	Link  *ShareLinkView `json:"link"`  // созданная ссылка
+}
+
+type SharedContentView struct {
+	Music     *MusicView    `json:"music"`      // трек, null для ссылки на плейлист
+	Playlist  *PlaylistView `json:"playlist"`   // плейлист, null для ссылки на трек
+	Tracks    []*MusicView  `json:"tracks"`     // доступные треки плейлиста
+	ExpiresAt *string       `json:"expires_at"` // время истечения ссылки в формате RFC3339
+	PlaysLeft *int          `json:"plays_left"` // оставшиеся прослушивания, null — без лимита
+}
@@ -0,0 +1,18 @@
+package view
+
+// Открытый ключ в формате JWK (RFC 7517)
+type JWKView struct {
+	Kty string `json:"kty"`           // тип ключа: RSA или OKP
+	Use string `json:"use"`           // назначение ключа: sig
+	Alg string `json:"alg"`           // алгоритм подписи: RS256 или EdDSA
+	Kid string `json:"kid"`           // id ключа, совпадает с заголовком kid токена
+	N   string `json:"n,omitempty"`   // модуль RSA в base64url
+	E   string `json:"e,omitempty"`   // открытая экспонента RSA в base64url
+	Crv string `json:"crv,omitempty"` // кривая OKP: Ed25519
+	X   string `json:"x,omitempty"`   // открытый ключ Ed25519 в base64url
+}
+
+// Набор открытых ключей (JWKS)
+type JWKSView struct {
+	Keys []*JWKView `json:"keys"` // ключи
+}
@@ -0,0 +1,22 @@
+package view
+
+type SmartPlaylistView struct {
+	ID        string         `json:"id"`         // id плейлиста
+	UserID    string         `json:"user_id"`    // id владельца
+	Name      string         `json:"name"`       // название плейлиста
+	IsPublic  bool           `json:"is_public"`  // плейлист виден другим пользователям
+	Rules     *SmartRuleView `json:"rules"`      // дерево правил
+	Sort      string         `json:"sort"`       // поле сортировки
+	Order     string         `json:"order"`      // направление сортировки
+	Limit     int            `json:"limit"`      // максимальное количество треков
+	CreatedAt string         `json:"created_at"` // время создания в формате RFC3339
+	UpdatedAt string         `json:"updated_at"` // время последнего изменения в формате RFC3339
+}
+
+type SmartRuleView struct {
+	Group    string           `json:"group,omitempty"`    // and или or для группы
+	Rules    []*SmartRuleView `json:"rules,omitempty"`    // правила группы
+	Field    string           `json:"field,omitempty"`    // поле трека для условия
+	Operator string           `json:"operator,omitempty"` // оператор условия
+	Value    interface{}      `json:"value,omitempty"`    // значение условия
+}
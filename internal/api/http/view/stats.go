@@ -0,0 +1,39 @@
+package view
+
+type TopTrackView struct {
+	MusicView
+	Plays   int64 `json:"plays"`   // количество прослушиваний за период
+	Minutes int64 `json:"minutes"` // время прослушивания в минутах
+}
+
+type TopArtistView struct {
+	ID      string `json:"id"`      // id исполнителя
+	Name    string `json:"name"`    // имя исполнителя
+	Plays   int64  `json:"plays"`   // количество прослушиваний за период
+	Minutes int64  `json:"minutes"` // время прослушивания в минутах
+}
+
+type ListeningStreakView struct {
+	Start string `json:"start"` // первый день серии в формате 2006-01-02
+	End   string `json:"end"`   // последний день серии в формате 2006-01-02
+	Days  int    `json:"days"`  // длина серии в днях
+}
+
+type ListeningStatsView struct {
+	From          string               `json:"from"`           // начало периода в формате RFC3339
+	To            string               `json:"to"`             // конец периода в формате RFC3339, не включая
+	Plays         int64                `json:"plays"`          // количество прослушиваний
+	Minutes       int64                `json:"minutes"`        // время прослушивания в минутах
+	Tracks        int64                `json:"tracks"`         // количество разных треков
+	ActiveDays    int64                `json:"active_days"`    // количество дней с прослушиваниями
+	TopTracks     []*TopTrackView      `json:"top_tracks"`     // самые прослушиваемые треки
+	TopArtists    []*TopArtistView     `json:"top_artists"`    // самые прослушиваемые исполнители
+	Heatmap       [][]int64            `json:"heatmap"`        // прослушивания 7×24: дни недели с понедельника по часам UTC
+	LongestStreak *ListeningStreakView `json:"longest_streak"` // самая длинная серия дней подряд, null если прослушиваний не было
+}
+
+type YearReviewView struct {
+	Year        int                 `json:"year"`         // год
+	GeneratedAt string              `json:"generated_at"` // время расчета в формате RFC3339
+	Stats       *ListeningStatsView `json:"stats"`        // статистика за год
+}
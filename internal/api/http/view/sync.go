@@ -0,0 +1,35 @@
+package view
+
+type SyncView struct {
+	Token     string             `json:"token"`     // токен для следующего запроса
+	Full      bool               `json:"full"`      // полная синхронизация: локальную копию нужно заменить полученными данными
+	HasMore   bool               `json:"has_more"`  // есть следующая страница, ее нужно запросить с новым токеном
+	Tracks    *SyncTracksView    `json:"tracks"`    // изменения треков
+	Likes     *SyncLikesView     `json:"likes"`     // изменения лайков
+	Playlists *SyncPlaylistsView `json:"playlists"` // изменения плейлистов
+}
+
+type SyncTracksView struct {
+	Upserted []*MusicView `json:"upserted"` // созданные и измененные треки
+	Deleted  []string     `json:"deleted"`  // id удаленных или ставших недоступными треков
+}
+
+type SyncLikesView struct {
+	Added   []*SyncLikeView `json:"added"`   // поставленные лайки
+	Removed []string        `json:"removed"` // id треков, лайк которых снят
+}
+
+type SyncLikeView struct {
+	MusicID string `json:"music_id"` // id трека
+	LikedAt string `json:"liked_at"` // время лайка в формате RFC3339
+}
+
+type SyncPlaylistsView struct {
+	Upserted []*SyncPlaylistView `json:"upserted"` // созданные и измененные плейлисты
+	Deleted  []string            `json:"deleted"`  // id удаленных плейлистов
+}
+
+type SyncPlaylistView struct {
+	PlaylistView
+	TrackIDs []string `json:"track_ids"` // id доступных треков в порядке плейлиста
+}
@@ -1,5 +1,9 @@
 package view
 
 type TokenView struct {
-	Token string `json:"token"`
+	SessionID        string `json:"session_id"`         // id сессии, которой принадлежат токены
+	Token            string `json:"token"`              // access-токен
+	ExpiresAt        string `json:"expires_at"`         // время истечения access-токена в формате RFC3339
+	RefreshToken     string `json:"refresh_token"`      // refresh-токен, обменивается на новую пару в /auth/refresh
+	RefreshExpiresAt string `json:"refresh_expires_at"` // время истечения refresh-токена в формате RFC3339
 }
@@ -0,0 +1,11 @@
+package view
+
+type TrashedMusicView struct {
+	MusicView
+	DeletedAt string `json:"deleted_at"` // время перемещения в корзину
+}
+
+type TrashedUserView struct {
+	UserView
+	DeletedAt string `json:"deleted_at"` // время перемещения в корзину
+}
@@ -1,7 +1,10 @@
 package view
 
 type UserView struct {
-	Id       string `json:"id"`
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	Id             string `json:"id"`
+	Username       string `json:"username"`
+	Role           string `json:"role"`
+	HideExplicit   bool   `json:"hide_explicit"`   // скрывать треки с ненормативным контентом
+	ShareLikes     bool   `json:"share_likes"`     // показывать лайки подписчикам
+	SharePlaylists bool   `json:"share_playlists"` // показывать публичные плейлисты подписчикам
 }
@@ -5,6 +5,14 @@ import (
 	"fmt"
 	"music-backend-test/cmd/music-backend-test/config"
 	"music-backend-test/internal/api/http"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/notify"
+	"music-backend-test/internal/oidc"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/scheduler"
+	"music-backend-test/internal/usecase"
+	"music-backend-test/internal/utils"
 
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
@@ -15,6 +23,7 @@ type app struct {
 	dbConn     *sqlx.DB
 	logger     *zap.Logger
 	httpServer http.Server
+	scheduler  scheduler.Scheduler
 }
 
 func NewApp(cfg *config.Config, logger *zap.Logger) *app {
@@ -53,6 +62,10 @@ func (a *app) Start(ctx context.Context) {
 		logger.Error("db migration error", zap.Error(err))
 	}
 
+	// Запуск фоновых задач
+	a.scheduler = a.initScheduler()
+	a.scheduler.Run(appCtx)
+
 	defer func() {
 		if e := recover(); e != nil {
 			logger.Panic("http start panic", zap.Error(fmt.Errorf("%s", e)))
@@ -60,7 +73,7 @@ func (a *app) Start(ctx context.Context) {
 	}()
 
 	addr := fmt.Sprintf("%s:%d", a.config.HttpServer.Host, a.config.HttpServer.Port)
-	a.httpServer = http.NewServer(addr, a.dbConn, logger)
+	a.httpServer = http.NewServer(addr, a.config, a.dbConn, logger)
 	if a.httpServer == nil {
 		cancelApp()
 		logger.Fatal("can't create http server")
@@ -109,3 +122,63 @@ func (a *app) initDb(
 
 	return db, nil
 }
+
+// initScheduler регистрация фоновых задач
+func (a *app) initScheduler() scheduler.Scheduler {
+	pgSource := db.NewSource(a.dbConn)
+
+	popularityRepository := repository.NewPopularityRepository(db.NewPopularitySource(pgSource))
+	popularityInteractor := usecase.NewPopularityInteractor(popularityRepository, entity.NewPopularityConfig(a.config))
+
+	chartRepository := repository.NewChartRepository(db.NewChartSource(pgSource))
+	chartInteractor := usecase.NewChartInteractor(chartRepository, entity.NewChartConfig(a.config))
+
+	recommendationRepository := repository.NewRecommendationRepository(db.NewRecommendationSource(pgSource))
+	recommendationInteractor := usecase.NewRecommendationInteractor(recommendationRepository, entity.NewSimilarityConfig(a.config))
+
+	trashRepository := repository.NewTrashRepository(db.NewTrashSource(pgSource), db.NewMusicRevisionSource(pgSource), utils.NewFileSystem())
+	trashInteractor := usecase.NewTrashInteractor(trashRepository, entity.NewTrashConfig(a.config))
+
+	releaseInteractor := usecase.NewReleaseInteractor(repository.NewReleaseRepository(db.NewReleaseSource(pgSource)))
+
+	notificationRepository := repository.NewNotificationRepository(db.NewNotificationSource(pgSource), notify.NewChannels(a.config))
+	notificationInteractor := usecase.NewNotificationInteractor(notificationRepository, entity.NewNotificationConfig(a.config))
+
+	statsInteractor := usecase.NewStatsInteractor(repository.NewStatsRepository(db.NewStatsSource(pgSource)), entity.NewStatsConfig(a.config))
+
+	exportRepository := repository.NewExportRepository(db.NewExportSource(pgSource), utils.NewFileSystem())
+	exportInteractor := usecase.NewExportInteractor(exportRepository, entity.NewExportConfig(a.config))
+
+	syncInteractor := usecase.NewSyncInteractor(repository.NewSyncRepository(db.NewSyncSource(pgSource)), entity.NewSyncConfig(a.config))
+
+	authConfig := entity.NewAuthConfig(a.config)
+	keysConfig := entity.NewKeysConfig(a.config)
+	signingKeySource := db.NewSigningKeySource(pgSource)
+	keyManager := repository.NewKeyManager(signingKeySource, keysConfig.CacheTTL)
+	sessionCache := repository.NewSessionCache(authConfig.SessionCacheTTL)
+	userRepository := repository.NewUserRepository(db.NewUserSourсe(pgSource), db.NewRefreshTokenSource(pgSource), sessionCache, keyManager, utils.NewPasswordHasher())
+	userInteractor := usecase.NewUserInteractor(userRepository, authConfig)
+
+	signingKeyInteractor := usecase.NewSigningKeyInteractor(repository.NewSigningKeyRepository(signingKeySource, keyManager), keysConfig)
+
+	oidcConfig := entity.NewOIDCConfig(a.config)
+
+	s := scheduler.NewScheduler(a.logger)
+	s.Add("popularity", a.config.Popularity.RefreshInterval, popularityInteractor.Refresh)
+	s.Add("charts", a.config.Chart.SnapshotInterval, chartInteractor.Snapshot)
+	s.Add("recommendations", a.config.Recommendations.RefreshInterval, recommendationInteractor.Refresh)
+	s.Add("trash", a.config.Trash.PurgeInterval, trashInteractor.Purge)
+	s.Add("releases", a.config.Releases.PublishInterval, releaseInteractor.Publish)
+	s.Add("notifications", a.config.Notifications.DeliveryInterval, notificationInteractor.Deliver)
+	s.Add("year-reviews", a.config.Stats.ReviewInterval, statsInteractor.GenerateYearReviews)
+	s.Add("exports", a.config.Export.Interval, exportInteractor.ProcessJobs)
+	s.Add("sync-changes", a.config.Sync.CleanupInterval, syncInteractor.CleanupChanges)
+	s.Add("refresh-tokens", authConfig.CleanupInterval, userInteractor.CleanupRefreshTokens)
+	s.Add("signing-keys", keysConfig.CheckInterval, signingKeyInteractor.Rotate)
+	if oidcConfig.Enabled() {
+		identityRepository := repository.NewIdentityRepository(db.NewIdentitySource(pgSource), oidc.NewProvider(oidcConfig), utils.NewPasswordHasher())
+		s.Add("oidc-logins", oidcConfig.CleanupInterval, usecase.NewIdentityInteractor(identityRepository, oidcConfig).CleanupLogins)
+	}
+
+	return s
+}
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS play_events (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    music_id UUID NOT NULL,
+    event_type VARCHAR(16) NOT NULL,
+    position_sec INTEGER NOT NULL DEFAULT 0,
+    source VARCHAR(16) NOT NULL DEFAULT 'client',
+    client_event_id UUID NOT NULL,
+    played_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE,
+    UNIQUE (user_id, client_event_id)
+);
+
+CREATE INDEX IF NOT EXISTS play_events_user_played_at_idx ON play_events (user_id, played_at DESC);
+CREATE INDEX IF NOT EXISTS play_events_music_played_at_idx ON play_events (music_id, played_at DESC);
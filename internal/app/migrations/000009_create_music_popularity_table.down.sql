@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS music_popularity;
+
+DROP INDEX IF EXISTS play_events_played_at_idx;
+DROP INDEX IF EXISTS user_music_created_at_idx;
+
+ALTER TABLE user_music
+    DROP COLUMN IF EXISTS created_at;
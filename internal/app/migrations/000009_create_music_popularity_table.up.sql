@@ -0,0 +1,23 @@
+-- Время старых лайков неизвестно: помечаем их эпохой, чтобы они не попадали в окна по времени
+ALTER TABLE user_music
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+UPDATE user_music SET created_at = 'epoch' WHERE created_at IS NULL;
+ALTER TABLE user_music
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS user_music_created_at_idx ON user_music (created_at);
+CREATE INDEX IF NOT EXISTS play_events_played_at_idx ON play_events (played_at) WHERE event_type = 'start';
+
+CREATE TABLE IF NOT EXISTS music_popularity (
+    period VARCHAR(16) NOT NULL,
+    music_id UUID NOT NULL,
+    plays BIGINT NOT NULL DEFAULT 0,
+    likes BIGINT NOT NULL DEFAULT 0,
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE,
+    PRIMARY KEY (period, music_id)
+);
+
+CREATE INDEX IF NOT EXISTS music_popularity_rank_idx ON music_popularity (period, score DESC);
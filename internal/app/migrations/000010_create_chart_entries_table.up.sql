@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS chart_entries (
+    week DATE NOT NULL,
+    position INT NOT NULL,
+    music_id UUID NOT NULL,
+    plays BIGINT NOT NULL DEFAULT 0,
+    likes BIGINT NOT NULL DEFAULT 0,
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE,
+    PRIMARY KEY (week, position),
+    UNIQUE (week, music_id)
+);
+
+CREATE INDEX IF NOT EXISTS chart_entries_music_id_idx ON chart_entries (music_id, week);
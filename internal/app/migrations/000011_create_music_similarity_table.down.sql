@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS music_similarity;
+
+DROP INDEX IF EXISTS user_music_music_id_idx;
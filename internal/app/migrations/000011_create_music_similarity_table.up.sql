@@ -0,0 +1,14 @@
+CREATE INDEX IF NOT EXISTS user_music_music_id_idx ON user_music (music_id);
+
+CREATE TABLE IF NOT EXISTS music_similarity (
+    music_id UUID NOT NULL,
+    similar_id UUID NOT NULL,
+    co_likes BIGINT NOT NULL DEFAULT 0,
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE,
+    FOREIGN KEY (similar_id) REFERENCES music (id) ON DELETE CASCADE,
+    PRIMARY KEY (music_id, similar_id)
+);
+
+CREATE INDEX IF NOT EXISTS music_similarity_rank_idx ON music_similarity (music_id, score DESC);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS music_ratings (
+    user_id UUID NOT NULL,
+    music_id UUID NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, music_id)
+);
+
+CREATE INDEX IF NOT EXISTS music_ratings_music_id_idx ON music_ratings (music_id);
+
+ALTER TABLE music
+    ADD COLUMN IF NOT EXISTS rating_avg DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_count BIGINT NOT NULL DEFAULT 0;
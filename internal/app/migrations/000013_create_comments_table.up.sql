@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS comments (
+    id UUID PRIMARY KEY,
+    music_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    parent_id UUID,
+    body TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'visible' CHECK (status IN ('visible', 'hidden', 'deleted')),
+    reply_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    edited_at TIMESTAMPTZ,
+    moderated_by UUID,
+    moderated_at TIMESTAMPTZ,
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES comments (id) ON DELETE CASCADE,
+    FOREIGN KEY (moderated_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS comments_music_newest_idx ON comments (music_id, created_at DESC, id DESC) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS comments_music_top_idx ON comments (music_id, reply_count DESC, created_at DESC, id DESC) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS comments_parent_id_idx ON comments (parent_id, created_at, id);
+CREATE INDEX IF NOT EXISTS comments_user_id_created_at_idx ON comments (user_id, created_at);
+
+CREATE TABLE IF NOT EXISTS comment_reports (
+    id UUID PRIMARY KEY,
+    comment_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved', 'dismissed')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved_by UUID,
+    resolved_at TIMESTAMPTZ,
+    FOREIGN KEY (comment_id) REFERENCES comments (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (resolved_by) REFERENCES users (id) ON DELETE SET NULL,
+    UNIQUE (comment_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS comment_reports_open_idx ON comment_reports (comment_id) WHERE status = 'open';
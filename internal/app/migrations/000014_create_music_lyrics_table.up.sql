@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS music_lyrics (
+    music_id UUID NOT NULL,
+    language VARCHAR(35) NOT NULL DEFAULT 'und',
+    plain TEXT NOT NULL DEFAULT '',
+    synced TEXT,
+    source VARCHAR(16) NOT NULL DEFAULT 'upload' CHECK (source IN ('upload', 'id3')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE,
+    PRIMARY KEY (music_id, language)
+);
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS music_revisions (
+    id UUID PRIMARY KEY,
+    music_id UUID NOT NULL,
+    version INTEGER NOT NULL CHECK (version > 0),
+    action VARCHAR(16) NOT NULL CHECK (action IN ('create', 'update', 'revert')),
+    name VARCHAR NOT NULL,
+    release_date DATE,
+    file_name VARCHAR(255) NOT NULL,
+    size NUMERIC,
+    duration INTERVAL,
+    file_path TEXT NOT NULL,
+    reverted_from INTEGER,
+    changed_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE,
+    FOREIGN KEY (changed_by) REFERENCES users (id) ON DELETE SET NULL,
+    UNIQUE (music_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS music_revisions_file_path_idx ON music_revisions (music_id, file_path);
+
+-- Текущее состояние существующих треков становится их первой версией
+INSERT INTO music_revisions (id, music_id, version, action, name, release_date, file_name, size, duration, file_path)
+SELECT md5(id::text || ':1')::uuid, id, 1, 'create', name, release_date, file_name, size, duration,
+    './internal/storage/music_storage/' || file_name
+FROM music
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+ALTER TABLE music ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- Корзина и очистка выбирают только удаленные строки
+CREATE INDEX IF NOT EXISTS music_deleted_at_idx ON music (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
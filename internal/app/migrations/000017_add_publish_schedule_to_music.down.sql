@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS music_publish_at_idx;
+
+ALTER TABLE music
+    DROP CONSTRAINT IF EXISTS music_takedown_after_publish,
+    DROP COLUMN IF EXISTS takedown_at,
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS publish_at;
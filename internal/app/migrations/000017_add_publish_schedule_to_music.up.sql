@@ -0,0 +1,16 @@
+ALTER TABLE music
+    ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS takedown_at TIMESTAMPTZ;
+
+-- Треки публикуются в день релиза; треки с датой релиза в будущем становятся запланированными
+UPDATE music SET publish_at = COALESCE(release_date::timestamp AT TIME ZONE 'UTC', now());
+UPDATE music SET published_at = publish_at WHERE publish_at <= now();
+
+ALTER TABLE music
+    ALTER COLUMN publish_at SET NOT NULL,
+    ALTER COLUMN publish_at SET DEFAULT now(),
+    ADD CONSTRAINT music_takedown_after_publish CHECK (takedown_at IS NULL OR takedown_at > publish_at);
+
+-- Таймер публикации выбирает только еще не опубликованные треки
+CREATE INDEX IF NOT EXISTS music_publish_at_idx ON music (publish_at) WHERE published_at IS NULL;
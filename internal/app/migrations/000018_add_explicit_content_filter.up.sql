@@ -0,0 +1,2 @@
+ALTER TABLE music ADD COLUMN IF NOT EXISTS explicit BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS hide_explicit BOOLEAN NOT NULL DEFAULT false;
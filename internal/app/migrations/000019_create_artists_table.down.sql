@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS music_artist_id_published_at_idx;
+ALTER TABLE music DROP COLUMN IF EXISTS artist_id;
+DROP TABLE IF EXISTS artists;
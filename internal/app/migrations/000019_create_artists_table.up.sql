@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS artists (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE music ADD COLUMN IF NOT EXISTS artist_id UUID REFERENCES artists (id) ON DELETE SET NULL;
+
+-- Лента выбирает новые релизы исполнителя
+CREATE INDEX IF NOT EXISTS music_artist_id_published_at_idx ON music (artist_id, published_at DESC) WHERE artist_id IS NOT NULL;
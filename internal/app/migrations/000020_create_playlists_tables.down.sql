@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS playlist_music;
+DROP TABLE IF EXISTS playlists;
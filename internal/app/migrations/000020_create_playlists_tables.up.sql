@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS playlists (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    is_public BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS playlists_user_id_updated_at_idx ON playlists (user_id, updated_at DESC);
+
+CREATE TABLE IF NOT EXISTS playlist_music (
+    playlist_id UUID NOT NULL,
+    music_id UUID NOT NULL,
+    position INTEGER NOT NULL,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (playlist_id, music_id),
+    FOREIGN KEY (playlist_id) REFERENCES playlists (id) ON DELETE CASCADE,
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS playlist_music_position_idx ON playlist_music (playlist_id, position);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS user_music_user_id_created_at_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS share_likes, DROP COLUMN IF EXISTS share_playlists;
+DROP TABLE IF EXISTS user_follows;
+DROP TABLE IF EXISTS artist_follows;
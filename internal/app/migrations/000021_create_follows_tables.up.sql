@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS artist_follows (
+    user_id UUID NOT NULL,
+    artist_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, artist_id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (artist_id) REFERENCES artists (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS user_follows (
+    follower_id UUID NOT NULL,
+    followee_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (follower_id, followee_id),
+    FOREIGN KEY (follower_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (followee_id) REFERENCES users (id) ON DELETE CASCADE,
+    CHECK (follower_id <> followee_id)
+);
+
+-- Что пользователь показывает подписчикам в ленте: по умолчанию ничего, включается в настройках приватности
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS share_likes BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS share_playlists BOOLEAN NOT NULL DEFAULT false;
+
+-- Лента выбирает последние лайки пользователей, на которых подписан читатель
+CREATE INDEX IF NOT EXISTS user_music_user_id_created_at_idx ON user_music (user_id, created_at DESC);
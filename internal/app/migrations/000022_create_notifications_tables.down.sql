@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS release_notifications;
+DROP TABLE IF EXISTS notification_settings;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
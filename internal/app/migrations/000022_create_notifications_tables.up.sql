@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    actor_id UUID,
+    music_id UUID,
+    comment_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    read_at TIMESTAMPTZ,
+    delivered_at TIMESTAMPTZ,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id) REFERENCES comments (id) ON DELETE CASCADE
+);
+
+-- Входящие пользователя выбираются от новых к старым
+CREATE INDEX IF NOT EXISTS notifications_user_id_created_at_idx ON notifications (user_id, created_at DESC, id DESC);
+-- Фоновая доставка выбирает еще не отправленные уведомления
+CREATE INDEX IF NOT EXISTS notifications_undelivered_idx ON notifications (created_at) WHERE delivered_at IS NULL;
+
+-- Каналы доставки по типам уведомлений. Без строки действуют значения по умолчанию.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    in_app BOOLEAN NOT NULL DEFAULT true,
+    email BOOLEAN NOT NULL DEFAULT false,
+    webhook BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (user_id, type),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS notification_settings (
+    user_id UUID PRIMARY KEY,
+    email VARCHAR(255),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Треки, о выходе которых подписчики исполнителя уже уведомлены. Уже опубликованные треки считаются разосланными.
+CREATE TABLE IF NOT EXISTS release_notifications (
+    music_id UUID PRIMARY KEY,
+    notified_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE
+);
+
+INSERT INTO release_notifications (music_id, notified_at)
+SELECT id, published_at FROM music WHERE published_at IS NOT NULL
+ON CONFLICT (music_id) DO NOTHING;
@@ -0,0 +1,36 @@
+-- Ссылки для доступа без аккаунта к одному треку или плейлисту. Хранится только хэш токена.
+CREATE TABLE IF NOT EXISTS share_links (
+    id UUID PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    user_id UUID NOT NULL,
+    music_id UUID,
+    playlist_id UUID,
+    expires_at TIMESTAMPTZ,
+    max_plays INTEGER,
+    plays INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revoked_at TIMESTAMPTZ,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE,
+    FOREIGN KEY (playlist_id) REFERENCES playlists (id) ON DELETE CASCADE,
+    CHECK ((music_id IS NULL) <> (playlist_id IS NULL)),
+    CHECK (max_plays IS NULL OR max_plays > 0)
+);
+
+CREATE INDEX IF NOT EXISTS share_links_user_id_created_at_idx ON share_links (user_id, created_at DESC);
+
+-- Журнал обращений по ссылкам. Обращения с неизвестным токеном записываются без ссылки.
+CREATE TABLE IF NOT EXISTS share_link_accesses (
+    id BIGSERIAL PRIMARY KEY,
+    link_id UUID,
+    music_id UUID,
+    route VARCHAR(255) NOT NULL,
+    status INTEGER NOT NULL,
+    ip VARCHAR(45) NOT NULL,
+    user_agent VARCHAR(255) NOT NULL,
+    accessed_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (link_id) REFERENCES share_links (id) ON DELETE CASCADE,
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS share_link_accesses_link_id_accessed_at_idx ON share_link_accesses (link_id, accessed_at DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS play_events_user_start_played_at_idx;
+DROP TABLE IF EXISTS year_reviews;
@@ -0,0 +1,12 @@
+-- Итоги года, заранее рассчитанные фоновой задачей. Отчет хранится снимком на момент расчета.
+CREATE TABLE IF NOT EXISTS year_reviews (
+    user_id UUID NOT NULL,
+    year INTEGER NOT NULL,
+    report JSONB NOT NULL,
+    generated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, year),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Статистика выбирает начала прослушиваний пользователя за период
+CREATE INDEX IF NOT EXISTS play_events_user_start_played_at_idx ON play_events (user_id, played_at) WHERE event_type = 'start';
@@ -0,0 +1,21 @@
+-- Фоновые выгрузки библиотеки. Готовый файл хранится до expires_at и удаляется вместе с записью.
+CREATE TABLE IF NOT EXISTS export_jobs (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    format VARCHAR(8) NOT NULL,
+    playlist_id UUID,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    items BIGINT NOT NULL DEFAULT 0,
+    size BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (playlist_id) REFERENCES playlists (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS export_jobs_queue_idx ON export_jobs (created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS export_jobs_expires_at_idx ON export_jobs (expires_at) WHERE expires_at IS NOT NULL;
+
@@ -0,0 +1,16 @@
+-- Умные плейлисты хранят только правила, состав вычисляется при чтении для текущего пользователя
+CREATE TABLE IF NOT EXISTS smart_playlists (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    is_public BOOLEAN NOT NULL DEFAULT false,
+    rules JSONB NOT NULL,
+    sort VARCHAR(32) NOT NULL,
+    sort_order VARCHAR(4) NOT NULL,
+    track_limit INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS smart_playlists_user_id_updated_at_idx ON smart_playlists (user_id, updated_at DESC);
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS users_sync_changes ON users;
+DROP TRIGGER IF EXISTS playlist_music_sync_changes ON playlist_music;
+DROP TRIGGER IF EXISTS playlists_sync_changes ON playlists;
+DROP TRIGGER IF EXISTS user_music_sync_changes ON user_music;
+DROP TRIGGER IF EXISTS music_sync_changes ON music;
+
+DROP FUNCTION IF EXISTS sync_log_reset();
+DROP FUNCTION IF EXISTS sync_log_playlist_track();
+DROP FUNCTION IF EXISTS sync_log_playlist();
+DROP FUNCTION IF EXISTS sync_log_like();
+DROP FUNCTION IF EXISTS sync_log_track();
+
+DROP TABLE IF EXISTS sync_changes;
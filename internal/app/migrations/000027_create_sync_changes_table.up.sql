@@ -0,0 +1,91 @@
+-- Журнал изменений для синхронизации клиентов. Записи о треках общие (user_id IS NULL), о лайках
+-- и плейлистах — пользовательские. Запись об удаленной сущности остается в журнале и служит надгробием.
+-- txid — транзакция, в которой произошло изменение: клиент читает журнал только по завершенным транзакциям,
+-- поэтому изменение из транзакции, зафиксированной позже следующей по порядку, не пропускается.
+CREATE TABLE IF NOT EXISTS sync_changes (
+    id BIGSERIAL PRIMARY KEY,
+    txid XID8 NOT NULL DEFAULT pg_current_xact_id(),
+    user_id UUID,
+    kind VARCHAR(16) NOT NULL,
+    entity_id UUID NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX IF NOT EXISTS sync_changes_txid_id_idx ON sync_changes (txid, id);
+CREATE INDEX IF NOT EXISTS sync_changes_changed_at_idx ON sync_changes (changed_at);
+
+CREATE OR REPLACE FUNCTION sync_log_track() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO sync_changes (kind, entity_id) VALUES ('track', OLD.id);
+    ELSE
+        INSERT INTO sync_changes (kind, entity_id) VALUES ('track', NEW.id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sync_log_like() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO sync_changes (user_id, kind, entity_id) VALUES (OLD.user_id, 'like', OLD.music_id);
+    ELSE
+        INSERT INTO sync_changes (user_id, kind, entity_id) VALUES (NEW.user_id, 'like', NEW.music_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sync_log_playlist() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO sync_changes (user_id, kind, entity_id) VALUES (OLD.user_id, 'playlist', OLD.id);
+    ELSE
+        INSERT INTO sync_changes (user_id, kind, entity_id) VALUES (NEW.user_id, 'playlist', NEW.id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Изменение состава — изменение плейлиста. При удалении плейлиста его треки удаляются каскадно
+-- уже после плейлиста, владельца не находится и запись не нужна.
+CREATE OR REPLACE FUNCTION sync_log_playlist_track() RETURNS trigger AS $$
+DECLARE
+    playlist_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        playlist_id := OLD.playlist_id;
+    ELSE
+        playlist_id := NEW.playlist_id;
+    END IF;
+    INSERT INTO sync_changes (user_id, kind, entity_id)
+        SELECT p.user_id, 'playlist', p.id FROM playlists p WHERE p.id = playlist_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Смена фильтра контента меняет видимый каталог целиком, клиенту нужна полная синхронизация
+CREATE OR REPLACE FUNCTION sync_log_reset() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO sync_changes (user_id, kind, entity_id) VALUES (NEW.id, 'reset', NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Средняя оценка трека меняется при каждой оценке и в синхронизацию не попадает
+CREATE TRIGGER music_sync_changes
+    AFTER INSERT OR DELETE OR UPDATE OF name, release_date, file_name, size, duration, deleted_at, published_at, takedown_at, explicit, artist_id
+    ON music FOR EACH ROW EXECUTE FUNCTION sync_log_track();
+
+CREATE TRIGGER user_music_sync_changes
+    AFTER INSERT OR DELETE ON user_music FOR EACH ROW EXECUTE FUNCTION sync_log_like();
+
+CREATE TRIGGER playlists_sync_changes
+    AFTER INSERT OR UPDATE OR DELETE ON playlists FOR EACH ROW EXECUTE FUNCTION sync_log_playlist();
+
+CREATE TRIGGER playlist_music_sync_changes
+    AFTER INSERT OR UPDATE OR DELETE ON playlist_music FOR EACH ROW EXECUTE FUNCTION sync_log_playlist_track();
+
+CREATE TRIGGER users_sync_changes
+    AFTER UPDATE OF hide_explicit ON users FOR EACH ROW
+    WHEN (OLD.hide_explicit IS DISTINCT FROM NEW.hide_explicit) EXECUTE FUNCTION sync_log_reset();
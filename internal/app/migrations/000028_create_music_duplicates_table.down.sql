@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS music_duplicates;
+DROP TABLE IF EXISTS music_fingerprints;
@@ -0,0 +1,25 @@
+-- Признаки файла трека для поиска повторных загрузок
+CREATE TABLE IF NOT EXISTS music_fingerprints (
+    music_id UUID PRIMARY KEY,
+    content_hash VARCHAR(64) NOT NULL,
+    fingerprint BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS music_fingerprints_content_hash_idx ON music_fingerprints (content_hash);
+
+-- Вероятные дубликаты: загруженный трек music_id похож на ранее загруженный candidate_id
+CREATE TABLE IF NOT EXISTS music_duplicates (
+    music_id UUID NOT NULL,
+    candidate_id UUID NOT NULL,
+    same_hash BOOLEAN NOT NULL DEFAULT false,
+    same_title BOOLEAN NOT NULL DEFAULT false,
+    similarity DOUBLE PRECISION,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (music_id, candidate_id),
+    FOREIGN KEY (music_id) REFERENCES music (id) ON DELETE CASCADE,
+    FOREIGN KEY (candidate_id) REFERENCES music (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS music_duplicates_candidate_id_idx ON music_duplicates (candidate_id);
@@ -0,0 +1,14 @@
+-- Ключи подписи access-токенов. Ключ публикуется в JWKS с момента создания, подписывает токены
+-- с activates_at до retires_at и принимается при проверке до expires_at.
+-- Закрытый ключ хранится в PEM (PKCS #8), доступ к таблице должен быть только у сервиса.
+CREATE TABLE IF NOT EXISTS signing_keys (
+    kid VARCHAR(64) PRIMARY KEY,
+    algorithm VARCHAR(16) NOT NULL,
+    private_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    activates_at TIMESTAMPTZ NOT NULL,
+    retires_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS signing_keys_expires_at_idx ON signing_keys (expires_at);
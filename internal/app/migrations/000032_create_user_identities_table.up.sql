@@ -0,0 +1,29 @@
+-- Учетные записи пользователей у OpenID Connect провайдера. Учетная запись определяется издателем и sub
+-- и привязана к одному пользователю. admin — роль ADMIN выдана по группе провайдера и снимается вместе с группой.
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    issuer VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    admin BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_login_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (issuer, subject),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS user_identities_user_id_idx ON user_identities (user_id);
+
+-- Начатые входы через провайдера: state из запроса авторизации, nonce ID-токена и PKCE code_verifier.
+-- user_id задан, если вход начат для привязки учетной записи к пользователю.
+CREATE TABLE IF NOT EXISTS oidc_logins (
+    state VARCHAR(64) PRIMARY KEY,
+    nonce VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    user_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS oidc_logins_expires_at_idx ON oidc_logins (expires_at);
@@ -0,0 +1,10 @@
+ALTER TABLE notifications DROP COLUMN IF EXISTS playlist_id;
+
+DROP INDEX IF EXISTS comments_playlist_top_idx;
+DROP INDEX IF EXISTS comments_playlist_newest_idx;
+
+DELETE FROM comments WHERE playlist_id IS NOT NULL;
+ALTER TABLE comments
+    DROP CONSTRAINT IF EXISTS comments_target_check,
+    DROP COLUMN IF EXISTS playlist_id,
+    ALTER COLUMN music_id SET NOT NULL;
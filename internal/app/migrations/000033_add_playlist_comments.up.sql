@@ -0,0 +1,12 @@
+-- Комментарий относится ровно к одной цели: треку или плейлисту
+ALTER TABLE comments
+    ALTER COLUMN music_id DROP NOT NULL,
+    ADD COLUMN IF NOT EXISTS playlist_id UUID REFERENCES playlists (id) ON DELETE CASCADE,
+    ADD CONSTRAINT comments_target_check CHECK ((music_id IS NULL) <> (playlist_id IS NULL));
+
+CREATE INDEX IF NOT EXISTS comments_playlist_newest_idx ON comments (playlist_id, created_at DESC, id DESC) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS comments_playlist_top_idx ON comments (playlist_id, reply_count DESC, created_at DESC, id DESC) WHERE parent_id IS NULL;
+
+-- Плейлист, к которому оставлен комментарий, для уведомлений playlist_comment и ответов в плейлистах
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS playlist_id UUID REFERENCES playlists (id) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS music_import_title_idx;
+
+DROP FUNCTION IF EXISTS normalize_import_title(TEXT);
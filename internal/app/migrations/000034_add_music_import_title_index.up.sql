@@ -0,0 +1,10 @@
+-- Название трека в виде для сопоставления при импорте плейлистов, как entity.NormalizeImportTitle:
+-- нижний регистр, без уточнений в скобках, знаков препинания и лишних пробелов
+CREATE OR REPLACE FUNCTION normalize_import_title(title TEXT) RETURNS TEXT AS $$
+    SELECT btrim(regexp_replace(
+        regexp_replace(lower(title), '[\(\[][^\)\]]*[\)\]]', ' ', 'g'),
+        '[^[:alnum:]]+', ' ', 'g'
+    ))
+$$ LANGUAGE SQL IMMUTABLE STRICT PARALLEL SAFE;
+
+CREATE INDEX IF NOT EXISTS music_import_title_idx ON music (normalize_import_title(name));
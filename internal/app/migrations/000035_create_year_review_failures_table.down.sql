@@ -0,0 +1 @@
+DROP TABLE IF EXISTS year_review_failures;
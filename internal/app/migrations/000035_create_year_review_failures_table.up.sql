@@ -0,0 +1,12 @@
+-- Неудачные попытки расчета итогов года. До retry_at пользователь пропускается фоновой задачей,
+-- интервал повтора растет с каждой попыткой.
+CREATE TABLE IF NOT EXISTS year_review_failures (
+    user_id UUID NOT NULL,
+    year INTEGER NOT NULL,
+    attempts INTEGER NOT NULL,
+    last_error TEXT NOT NULL,
+    failed_at TIMESTAMPTZ NOT NULL,
+    retry_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, year),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
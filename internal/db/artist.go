@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Исполнитель назначается только существующему исполнителю, иначе обновляется 0 строк
+const setMusicArtistQuery = "UPDATE music SET artist_id = $2 WHERE id = $1 AND deleted_at IS NULL " +
+	"AND ($2::uuid IS NULL OR EXISTS (SELECT 1 FROM artists WHERE id = $2))"
+
+type artistSource struct {
+	db *sqlx.DB
+}
+
+func NewArtistSource(source *source) *artistSource {
+	return &artistSource{
+		db: source.db,
+	}
+}
+
+func (a *artistSource) Create(ctx context.Context, artist *entity.ArtistDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := a.db.ExecContext(dbCtx, "INSERT INTO artists (id, name, created_at) VALUES ($1, $2, $3)",
+		artist.ID, artist.Name, artist.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (a *artistSource) Get(ctx context.Context, id uuid.UUID) (*entity.ArtistDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data entity.ArtistDB
+	err := a.db.QueryRowxContext(dbCtx, "SELECT * FROM artists WHERE id = $1", id).StructScan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("can't scan artist: %w", err)
+	}
+
+	return &data, nil
+}
+
+// SetMusicArtist назначает треку исполнителя, nil убирает исполнителя.
+// Если трека или исполнителя нет, возвращается sql.ErrNoRows.
+func (a *artistSource) SetMusicArtist(ctx context.Context, musicId uuid.UUID, artistId *uuid.UUID) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := a.db.ExecContext(dbCtx, setMusicArtistQuery, musicId, artistId)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Снимок строится по событиям недели [$2, $3) и не перезаписывает уже сохраненный чарт
+var snapshotChartQuery = "INSERT INTO chart_entries (week, position, music_id, plays, likes, score) " +
+	"SELECT $1::date, ROW_NUMBER() OVER (ORDER BY s.score DESC, s.name, s.id), s.id, s.plays, s.likes, s.score " +
+	"FROM (SELECT m.id, m.name, COALESCE(p.plays, 0) AS plays, COALESCE(l.likes, 0) AS likes, " +
+	"$4::float8 * COALESCE(p.plays, 0) + $5::float8 * COALESCE(l.likes, 0) AS score " +
+	"FROM music m " +
+	"LEFT JOIN (SELECT music_id, COUNT(*) AS plays FROM play_events WHERE event_type = 'start' " +
+	"AND played_at >= $2::timestamptz AND played_at < $3::timestamptz GROUP BY music_id) p ON p.music_id = m.id " +
+	"LEFT JOIN (SELECT music_id, COUNT(*) AS likes FROM user_music " +
+	"WHERE created_at >= $2::timestamptz AND created_at < $3::timestamptz AND " + knownLikeTime("") + " GROUP BY music_id) l ON l.music_id = m.id " +
+	"WHERE " + availableMusic("m") + ") s " +
+	"WHERE s.score > 0 AND NOT EXISTS (SELECT 1 FROM chart_entries WHERE week = $1::date) " +
+	"ORDER BY s.score DESC, s.name, s.id LIMIT $6 " +
+	"ON CONFLICT DO NOTHING"
+
+// Позиция дополняется позицией на предыдущей неделе, пиком и количеством недель в чарте на момент этой недели
+var selectChartEntriesQuery = "SELECT m.*, ce.week, ce.position, ce.plays, ce.likes, ce.score, " +
+	"prev.position AS previous_position, " +
+	"(SELECT MIN(h.position) FROM chart_entries h WHERE h.music_id = ce.music_id AND h.week <= ce.week) AS peak_position, " +
+	"(SELECT COUNT(*) FROM chart_entries h WHERE h.music_id = ce.music_id AND h.week <= ce.week) AS weeks_on_chart " +
+	"FROM chart_entries ce JOIN music m ON m.id = ce.music_id " +
+	"LEFT JOIN chart_entries prev ON prev.music_id = ce.music_id AND prev.week = ce.week - 7 " +
+	"WHERE " + availableMusic("m") + " "
+
+type chartSource struct {
+	db *sqlx.DB
+}
+
+func NewChartSource(source *source) *chartSource {
+	return &chartSource{
+		db: source.db,
+	}
+}
+
+// Snapshot сохраняет чарт недели, начинающейся с week. Возвращает количество сохраненных позиций,
+// 0 если чарт этой недели уже был сохранен.
+func (c *chartSource) Snapshot(ctx context.Context, cfg *entity.ChartConfig, week time.Time) (int64, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	result, err := c.db.ExecContext(dbCtx, snapshotChartQuery,
+		week, week, week.AddDate(0, 0, 7), cfg.PlayWeight, cfg.LikeWeight, cfg.Size,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't get rows affected: %w", err)
+	}
+
+	return inserted, nil
+}
+
+func (c *chartSource) LatestWeek(ctx context.Context) (time.Time, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var week sql.NullTime
+	err := c.db.QueryRowxContext(dbCtx, "SELECT MAX(week) FROM chart_entries").Scan(&week)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can't exec query: %w", err)
+	}
+	if !week.Valid {
+		return time.Time{}, sql.ErrNoRows
+	}
+
+	return week.Time, nil
+}
+
+func (c *chartSource) GetByWeek(ctx context.Context, week time.Time) ([]*entity.ChartEntryDB, error) {
+	return c.query(ctx, selectChartEntriesQuery+"AND ce.week = $1::date ORDER BY ce.position", week)
+}
+
+func (c *chartSource) GetByMusic(ctx context.Context, musicId uuid.UUID) ([]*entity.ChartEntryDB, error) {
+	return c.query(ctx, selectChartEntriesQuery+"AND ce.music_id = $1 ORDER BY ce.week DESC", musicId)
+}
+
+func (c *chartSource) query(ctx context.Context, query string, args ...any) ([]*entity.ChartEntryDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := c.db.QueryxContext(dbCtx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.ChartEntryDB
+	for rows.Next() {
+		var scanEntity entity.ChartEntryDB
+		err := rows.StructScan(&scanEntity)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan chart entry: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,375 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Комментарии пользователей в корзине не выдаются
+const selectCommentQuery = "SELECT c.id, c.music_id, c.playlist_id, c.user_id, u.username, c.parent_id, c.body, c.status, c.reply_count, " +
+	"c.created_at, c.edited_at, c.moderated_by, c.moderated_at FROM comments c JOIN users u ON u.id = c.user_id AND u.deleted_at IS NULL "
+
+// commentRateLock — первый ключ advisory-блокировки, под которой проверяется лимит комментариев пользователя.
+// Второй ключ — хеш id пользователя.
+const commentRateLock = 0x636d6e74
+
+// Комментарий создается только для доступного трека или плейлиста, видимого автору, иначе вставляется 0 строк
+var insertCommentQuery = "INSERT INTO comments (id, music_id, playlist_id, user_id, parent_id, body, status, created_at) " +
+	"SELECT $1, $2, $3, $4, $5, $6, $7, $8 WHERE EXISTS (SELECT 1 FROM music WHERE id = $2 AND " + availableMusic("") + ") " +
+	"OR EXISTS (SELECT 1 FROM playlists WHERE id = $3 AND (is_public OR user_id = $4))"
+
+// В reply_count учитываются только видимые ответы
+const updateReplyCountQuery = "UPDATE comments SET reply_count = " +
+	"(SELECT COUNT(*) FROM comments r WHERE r.parent_id = $1 AND r.status = 'visible') WHERE id = $1"
+
+var (
+	commentSortQueries         = commentTargetQueries("c.music_id")
+	playlistCommentSortQueries = commentTargetQueries("c.playlist_id")
+)
+
+// commentTargetQueries возвращает выборки комментариев верхнего уровня к цели column по сортировкам.
+// Курсор передается как NULL для первой страницы. Скрытые и удаленные комментарии видны только при $2 = true.
+func commentTargetQueries(column string) map[string]string {
+	return map[string]string{
+		entity.CommentSortNewest: selectCommentQuery +
+			"WHERE " + column + " = $1 AND c.parent_id IS NULL AND ($2::boolean OR c.status = 'visible') " +
+			"AND ($3::timestamptz IS NULL OR (c.created_at, c.id) < ($3::timestamptz, $4::uuid)) " +
+			"ORDER BY c.created_at DESC, c.id DESC LIMIT $5",
+		entity.CommentSortTop: selectCommentQuery +
+			"WHERE " + column + " = $1 AND c.parent_id IS NULL AND ($2::boolean OR c.status = 'visible') " +
+			"AND ($3::timestamptz IS NULL OR (c.reply_count, c.created_at, c.id) < ($6::integer, $3::timestamptz, $4::uuid)) " +
+			"ORDER BY c.reply_count DESC, c.created_at DESC, c.id DESC LIMIT $5",
+	}
+}
+
+// Ответы выдаются в хронологическом порядке
+const selectRepliesQuery = selectCommentQuery +
+	"WHERE c.parent_id = $1 AND ($2::boolean OR c.status = 'visible') " +
+	"AND ($3::timestamptz IS NULL OR (c.created_at, c.id) > ($3::timestamptz, $4::uuid)) " +
+	"ORDER BY c.created_at, c.id LIMIT $5"
+
+// Очередь модерации: комментарии с открытыми жалобами, сначала с наибольшим количеством жалоб
+const selectModerationQueueQuery = "SELECT c.id, c.music_id, c.playlist_id, c.user_id, u.username, c.parent_id, c.body, c.status, c.reply_count, " +
+	"c.created_at, c.edited_at, c.moderated_by, c.moderated_at, r.reports, r.last_reason, r.last_reported_at FROM (" +
+	"SELECT comment_id, COUNT(*) AS reports, MAX(created_at) AS last_reported_at, " +
+	"(ARRAY_AGG(reason ORDER BY created_at DESC))[1] AS last_reason " +
+	"FROM comment_reports WHERE status = 'open' GROUP BY comment_id) r " +
+	"JOIN comments c ON c.id = r.comment_id JOIN users u ON u.id = c.user_id " +
+	"ORDER BY r.reports DESC, r.last_reported_at DESC LIMIT $1 OFFSET $2"
+
+type commentSource struct {
+	db *sqlx.DB
+}
+
+func NewCommentSource(source *source) *commentSource {
+	return &commentSource{
+		db: source.db,
+	}
+}
+
+// Create сохраняет комментарий и для ответа пересчитывает количество ответов родителя.
+// Если у автора уже rateLimit комментариев не раньше since, возвращается entity.ErrCommentRateLimited:
+// проверка и вставка идут под блокировкой автора, поэтому параллельные запросы не превышают лимит.
+// Если трека или плейлиста нет, возвращается sql.ErrNoRows.
+func (s *commentSource) Create(ctx context.Context, comment *entity.CommentDB, rateLimit int, since time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(dbCtx, "SELECT pg_advisory_xact_lock($1, hashtext($2::text))", commentRateLock, comment.UserID)
+	if err != nil {
+		return fmt.Errorf("can't lock comment rate: %w", err)
+	}
+
+	var count int
+	err = tx.QueryRowxContext(dbCtx,
+		"SELECT COUNT(*) FROM comments WHERE user_id = $1 AND created_at >= $2", comment.UserID, since,
+	).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("can't count comments: %w", err)
+	}
+	if count >= rateLimit {
+		return entity.ErrCommentRateLimited
+	}
+
+	res, err := tx.ExecContext(dbCtx, insertCommentQuery,
+		comment.ID, comment.MusicID, comment.PlaylistID, comment.UserID, comment.ParentID, comment.Body, comment.Status, comment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if comment.ParentID != nil {
+		_, err = tx.ExecContext(dbCtx, updateReplyCountQuery, *comment.ParentID)
+		if err != nil {
+			return fmt.Errorf("can't update reply count: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (s *commentSource) Get(ctx context.Context, id uuid.UUID) (*entity.CommentDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var comment entity.CommentDB
+	err := s.db.QueryRowxContext(dbCtx, selectCommentQuery+"WHERE c.id = $1", id).StructScan(&comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// GetByMusic возвращает до filter.Limit+1 комментариев верхнего уровня, чтобы определить наличие следующей страницы
+func (s *commentSource) GetByMusic(ctx context.Context, musicId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	return s.getByTarget(ctx, commentSortQueries, musicId, filter)
+}
+
+// GetByPlaylist возвращает до filter.Limit+1 комментариев верхнего уровня к плейлисту
+func (s *commentSource) GetByPlaylist(ctx context.Context, playlistId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	return s.getByTarget(ctx, playlistCommentSortQueries, playlistId, filter)
+}
+
+func (s *commentSource) getByTarget(ctx context.Context, queries map[string]string, targetId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	query, ok := queries[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", entity.ErrUnknownCommentSort, filter.Sort)
+	}
+
+	cursorTime, cursorId, cursorReplies := commentCursorArgs(filter.Cursor)
+	args := []any{targetId, filter.IncludeHidden, cursorTime, cursorId, filter.Limit + 1}
+	if filter.Sort == entity.CommentSortTop {
+		args = append(args, cursorReplies)
+	}
+
+	return s.query(dbCtx, query, args...)
+}
+
+// GetReplies возвращает до filter.Limit+1 ответов на комментарий
+func (s *commentSource) GetReplies(ctx context.Context, parentId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	cursorTime, cursorId, _ := commentCursorArgs(filter.Cursor)
+	return s.query(dbCtx, selectRepliesQuery, parentId, filter.IncludeHidden, cursorTime, cursorId, filter.Limit+1)
+}
+
+// MusicVisible сообщает, доступен ли трек для просмотра комментариев. Треки в корзине недоступны,
+// неопубликованные и снятые с публикации — только при showUnpublished.
+func (s *commentSource) MusicVisible(ctx context.Context, musicId uuid.UUID, showUnpublished bool) (bool, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var visible bool
+	err := s.db.QueryRowxContext(dbCtx,
+		"SELECT EXISTS (SELECT 1 FROM music WHERE id = $1 AND "+musicFilterCondition("", entity.MusicFilter{ShowUnpublished: showUnpublished})+")",
+		musicId,
+	).Scan(&visible)
+	if err != nil {
+		return false, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return visible, nil
+}
+
+// GetPlaylist возвращает плейлист, к которому оставляются комментарии
+func (s *commentSource) GetPlaylist(ctx context.Context, playlistId uuid.UUID) (*entity.PlaylistDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var playlist entity.PlaylistDB
+	err := s.db.QueryRowxContext(dbCtx, "SELECT * FROM playlists WHERE id = $1", playlistId).StructScan(&playlist)
+	if err != nil {
+		return nil, err
+	}
+
+	return &playlist, nil
+}
+
+// Update изменяет текст видимого комментария. Если комментария нет или он скрыт, возвращается sql.ErrNoRows.
+func (s *commentSource) Update(ctx context.Context, id uuid.UUID, body string, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := s.db.ExecContext(dbCtx,
+		"UPDATE comments SET body = $2, edited_at = $3 WHERE id = $1 AND status = 'visible'", id, body, now)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetStatus меняет статус комментария и пересчитывает количество ответов родителя.
+// Когда комментарий перестает быть видимым, открытые жалобы на него считаются рассмотренными.
+func (s *commentSource) SetStatus(ctx context.Context, id uuid.UUID, status string, moderatorId *uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var parentId *uuid.UUID
+	err = tx.QueryRowxContext(dbCtx,
+		"UPDATE comments SET status = $2, moderated_by = $3, moderated_at = $4 WHERE id = $1 RETURNING parent_id",
+		id, status, moderatorId, now,
+	).Scan(&parentId)
+	if err != nil {
+		return err
+	}
+
+	if parentId != nil {
+		_, err = tx.ExecContext(dbCtx, updateReplyCountQuery, *parentId)
+		if err != nil {
+			return fmt.Errorf("can't update reply count: %w", err)
+		}
+	}
+
+	if status != entity.CommentStatusVisible {
+		_, err = tx.ExecContext(dbCtx,
+			"UPDATE comment_reports SET status = $2, resolved_by = $3, resolved_at = $4 WHERE comment_id = $1 AND status = 'open'",
+			id, entity.ReportStatusResolved, moderatorId, now,
+		)
+		if err != nil {
+			return fmt.Errorf("can't resolve reports: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CreateReport сохраняет жалобу. Возвращает false, если пользователь уже жаловался на комментарий.
+func (s *commentSource) CreateReport(ctx context.Context, report *entity.CommentReportDB) (bool, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := s.db.ExecContext(dbCtx,
+		"INSERT INTO comment_reports (id, comment_id, user_id, reason, status, created_at) VALUES ($1, $2, $3, $4, $5, $6) "+
+			"ON CONFLICT (comment_id, user_id) DO NOTHING",
+		report.ID, report.CommentID, report.UserID, report.Reason, report.Status, report.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("can't get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+func (s *commentSource) GetModerationQueue(ctx context.Context, limit int, offset int) ([]*entity.ModerationItemDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := s.db.QueryxContext(dbCtx, selectModerationQueueQuery, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.ModerationItemDB
+	for rows.Next() {
+		var scanEntity entity.ModerationItemDB
+		err := rows.StructScan(&scanEntity)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan moderation item: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+// DismissReports отклоняет открытые жалобы на комментарий и возвращает их количество
+func (s *commentSource) DismissReports(ctx context.Context, commentId uuid.UUID, moderatorId uuid.UUID, now time.Time) (int64, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := s.db.ExecContext(dbCtx,
+		"UPDATE comment_reports SET status = $2, resolved_by = $3, resolved_at = $4 WHERE comment_id = $1 AND status = 'open'",
+		commentId, entity.ReportStatusDismissed, moderatorId, now,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't get affected rows: %w", err)
+	}
+
+	return affected, nil
+}
+
+func (s *commentSource) query(ctx context.Context, query string, args ...any) ([]*entity.CommentDB, error) {
+	rows, err := s.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.CommentDB
+	for rows.Next() {
+		var scanEntity entity.CommentDB
+		err := rows.StructScan(&scanEntity)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan comment: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+// commentCursorArgs возвращает параметры курсора для запроса, NULL для первой страницы
+func commentCursorArgs(cursor *entity.CommentCursor) (*time.Time, *uuid.UUID, int) {
+	if cursor == nil {
+		return nil, nil, 0
+	}
+	return &cursor.CreatedAt, &cursor.ID, cursor.ReplyCount
+}
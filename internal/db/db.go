@@ -1,6 +1,8 @@
 package db
 
 import (
+	"fmt"
+	"music-backend-test/internal/entity"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -10,6 +12,14 @@ const (
 	QueryTimeout = 10 * time.Second
 )
 
+// jobTimeout возвращает таймаут фоновой задачи. Без настроенного таймаута задача ограничивается QueryTimeout.
+func jobTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return QueryTimeout
+	}
+	return timeout
+}
+
 type source struct {
 	db *sqlx.DB
 }
@@ -19,3 +29,47 @@ func NewSource(db *sqlx.DB) *source {
 		db: db,
 	}
 }
+
+// availableMusic возвращает условие, при котором трек таблицы music с псевдонимом alias виден пользователям
+// без роли администратора: трек не в корзине, опубликован и не снят с публикации. Условие совпадает
+// с entity.MusicDB.IsAvailable; все выборки треков для пользователей должны строить его только здесь.
+func availableMusic(alias string) string {
+	return availableMusicAt(alias, "now()")
+}
+
+// availableMusicAt — условие availableMusic на момент at, выражение SQL или параметр запроса
+func availableMusicAt(alias string, at string) string {
+	prefix := ""
+	if alias != "" {
+		prefix = alias + "."
+	}
+	return fmt.Sprintf("%[1]sdeleted_at IS NULL AND %[1]spublished_at IS NOT NULL AND (%[1]stakedown_at IS NULL OR %[1]stakedown_at > %[2]s)", prefix, at)
+}
+
+// knownLikeTime возвращает условие, исключающее лайки user_music с псевдонимом alias с неизвестным временем.
+// Лайкам, поставленным до появления created_at, миграция 000009 проставила эпоху.
+func knownLikeTime(alias string) string {
+	prefix := ""
+	if alias != "" {
+		prefix = alias + "."
+	}
+	return prefix + "created_at > 'epoch'::timestamptz"
+}
+
+// musicFilterCondition возвращает условие выдачи треков с псевдонимом alias с ограничениями filter.
+// Треки в корзине не выдаются никогда, неопубликованные — только администраторам.
+func musicFilterCondition(alias string, filter entity.MusicFilter) string {
+	prefix := ""
+	if alias != "" {
+		prefix = alias + "."
+	}
+
+	condition := prefix + "deleted_at IS NULL"
+	if !filter.ShowUnpublished {
+		condition = availableMusic(alias)
+	}
+	if filter.HideExplicit {
+		condition += " AND NOT " + prefix + "explicit"
+	}
+	return condition
+}
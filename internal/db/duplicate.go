@@ -0,0 +1,196 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Треки каталога, похожие на трек $1: с тем же хэшем файла или с разницей продолжительности не больше $3 секунд
+const selectDuplicateCandidatesQuery = "WITH t AS (SELECT EXTRACT(EPOCH FROM duration) AS seconds FROM music WHERE id = $1) " +
+	"SELECT m.id AS music_id, m.name, COALESCE(ABS(EXTRACT(EPOCH FROM m.duration) - t.seconds), 0)::bigint AS duration_diff, " +
+	"f.content_hash, f.fingerprint FROM music m CROSS JOIN t " +
+	"LEFT JOIN music_fingerprints f ON f.music_id = m.id " +
+	"WHERE m.id <> $1 AND m.deleted_at IS NULL " +
+	"AND (f.content_hash = $2 OR ABS(EXTRACT(EPOCH FROM m.duration) - t.seconds) <= $3) " +
+	"ORDER BY m.id LIMIT $4"
+
+// Дубликаты трека $1 в обе стороны: треки, на которые он похож, и треки, которые похожи на него
+const selectMusicDuplicatesQuery = "SELECT d.music_id, d.candidate_id, m.name AS candidate_name, d.same_hash, d.same_title, d.similarity, d.detected_at " +
+	"FROM music_duplicates d JOIN music m ON m.id = d.candidate_id WHERE d.music_id = $1 AND m.deleted_at IS NULL " +
+	"UNION ALL " +
+	"SELECT d.candidate_id, d.music_id, m.name, d.same_hash, d.same_title, d.similarity, d.detected_at " +
+	"FROM music_duplicates d JOIN music m ON m.id = d.music_id WHERE d.candidate_id = $1 AND m.deleted_at IS NULL " +
+	"ORDER BY same_hash DESC, similarity DESC NULLS LAST, detected_at"
+
+// Лайки дубликата $1 переносятся на трек $2. Если трек уже лайкнут, остается более раннее время лайка.
+const mergeLikesQuery = "INSERT INTO user_music (user_id, music_id, created_at) SELECT user_id, $2, created_at FROM user_music WHERE music_id = $1 " +
+	"ON CONFLICT (user_id, music_id) DO UPDATE SET created_at = LEAST(user_music.created_at, EXCLUDED.created_at)"
+
+// Записи плейлистов переносятся на месте дубликата, если трека $2 в плейлисте еще нет; остальные удаляются ниже
+const mergePlaylistEntriesQuery = "UPDATE playlist_music pm SET music_id = $2 WHERE pm.music_id = $1 " +
+	"AND NOT EXISTS (SELECT 1 FROM playlist_music o WHERE o.playlist_id = pm.playlist_id AND o.music_id = $2)"
+
+type duplicateSource struct {
+	db *sqlx.DB
+}
+
+func NewDuplicateSource(source *source) *duplicateSource {
+	return &duplicateSource{
+		db: source.db,
+	}
+}
+
+func (d *duplicateSource) SaveFingerprint(ctx context.Context, fingerprint *entity.MusicFingerprintDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := d.db.ExecContext(dbCtx,
+		"INSERT INTO music_fingerprints (music_id, content_hash, fingerprint, created_at) VALUES ($1, $2, $3, $4) "+
+			"ON CONFLICT (music_id) DO UPDATE SET content_hash = EXCLUDED.content_hash, fingerprint = EXCLUDED.fingerprint, created_at = EXCLUDED.created_at",
+		fingerprint.MusicID, fingerprint.ContentHash, fingerprint.Fingerprint, fingerprint.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// GetCandidates возвращает до limit треков, которые могут быть дубликатами трека musicId
+func (d *duplicateSource) GetCandidates(ctx context.Context, musicId uuid.UUID, contentHash string, tolerance int, limit int) ([]*entity.DuplicateCandidateDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.DuplicateCandidateDB
+	err := d.db.SelectContext(dbCtx, &data, selectDuplicateCandidatesQuery, musicId, contentHash, tolerance, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't select duplicate candidates: %w", err)
+	}
+
+	return data, nil
+}
+
+func (d *duplicateSource) SaveDuplicates(ctx context.Context, duplicates []*entity.MusicDuplicateDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := d.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, duplicate := range duplicates {
+		_, err := tx.ExecContext(dbCtx,
+			"INSERT INTO music_duplicates (music_id, candidate_id, same_hash, same_title, similarity, detected_at) VALUES ($1, $2, $3, $4, $5, $6) "+
+				"ON CONFLICT (music_id, candidate_id) DO UPDATE SET same_hash = EXCLUDED.same_hash, same_title = EXCLUDED.same_title, "+
+				"similarity = EXCLUDED.similarity, detected_at = EXCLUDED.detected_at",
+			duplicate.MusicID, duplicate.CandidateID, duplicate.SameHash, duplicate.SameTitle, duplicate.Similarity, duplicate.DetectedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("can't insert duplicate: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByMusic возвращает вероятные дубликаты трека, не перемещенные в корзину. В CandidateID — id другого трека пары.
+func (d *duplicateSource) GetByMusic(ctx context.Context, musicId uuid.UUID) ([]*entity.MusicDuplicateDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.MusicDuplicateDB
+	err := d.db.SelectContext(dbCtx, &data, selectMusicDuplicatesQuery, musicId)
+	if err != nil {
+		return nil, fmt.Errorf("can't select duplicates: %w", err)
+	}
+
+	return data, nil
+}
+
+// Merge переносит лайки, записи плейлистов и историю прослушиваний трека musicId на трек intoId и перемещает
+// musicId в корзину. Если один из треков не найден или уже в корзине, возвращается sql.ErrNoRows.
+func (d *duplicateSource) Merge(ctx context.Context, musicId uuid.UUID, intoId uuid.UUID, now time.Time) (*entity.MusicMergeResult, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := d.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked []uuid.UUID
+	err = tx.SelectContext(dbCtx, &locked,
+		"SELECT id FROM music WHERE id IN ($1, $2) AND deleted_at IS NULL ORDER BY id FOR UPDATE", musicId, intoId)
+	if err != nil {
+		return nil, fmt.Errorf("can't lock music: %w", err)
+	}
+	if len(locked) != 2 {
+		return nil, sql.ErrNoRows
+	}
+
+	result := &entity.MusicMergeResult{MusicID: musicId, IntoID: intoId}
+
+	result.Likes, err = execAffected(dbCtx, tx, mergeLikesQuery, musicId, intoId)
+	if err != nil {
+		return nil, fmt.Errorf("can't merge likes: %w", err)
+	}
+	_, err = tx.ExecContext(dbCtx, "DELETE FROM user_music WHERE music_id = $1", musicId)
+	if err != nil {
+		return nil, fmt.Errorf("can't delete likes: %w", err)
+	}
+
+	_, err = tx.ExecContext(dbCtx,
+		"UPDATE playlists SET updated_at = $2 WHERE id IN (SELECT playlist_id FROM playlist_music WHERE music_id = $1)", musicId, now)
+	if err != nil {
+		return nil, fmt.Errorf("can't update playlists: %w", err)
+	}
+	result.PlaylistEntries, err = execAffected(dbCtx, tx, mergePlaylistEntriesQuery, musicId, intoId)
+	if err != nil {
+		return nil, fmt.Errorf("can't merge playlist entries: %w", err)
+	}
+	_, err = tx.ExecContext(dbCtx, "DELETE FROM playlist_music WHERE music_id = $1", musicId)
+	if err != nil {
+		return nil, fmt.Errorf("can't delete playlist entries: %w", err)
+	}
+
+	result.Plays, err = execAffected(dbCtx, tx, "UPDATE play_events SET music_id = $2 WHERE music_id = $1", musicId, intoId)
+	if err != nil {
+		return nil, fmt.Errorf("can't merge history: %w", err)
+	}
+
+	_, err = tx.ExecContext(dbCtx, "DELETE FROM music_duplicates WHERE music_id = $1 OR candidate_id = $1", musicId)
+	if err != nil {
+		return nil, fmt.Errorf("can't delete duplicates: %w", err)
+	}
+	_, err = tx.ExecContext(dbCtx, "UPDATE music SET deleted_at = $2 WHERE id = $1", musicId, now)
+	if err != nil {
+		return nil, fmt.Errorf("can't move music to trash: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+func execAffected(ctx context.Context, tx *sqlx.Tx, query string, args ...interface{}) (int64, error) {
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
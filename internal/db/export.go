@@ -0,0 +1,237 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Поля трека для выгрузки из music m с исполнителем a. Треки из корзины не выгружаются.
+const exportTrackColumns = "m.id AS music_id, m.name, a.name AS artist, COALESCE(EXTRACT(EPOCH FROM m.duration), 0)::bigint AS seconds"
+
+const exportTrackJoin = "JOIN music m ON m.id = t.music_id AND m.deleted_at IS NULL LEFT JOIN artists a ON a.id = m.artist_id "
+
+// Количество записей полной выгрузки библиотеки пользователя $1
+const countLibraryExportQuery = "SELECT " +
+	"(SELECT COUNT(*) FROM user_music WHERE user_id = $1) + " +
+	"(SELECT COUNT(*) FROM music_ratings WHERE user_id = $1) + " +
+	"(SELECT COUNT(*) FROM play_events WHERE user_id = $1) + " +
+	"(SELECT COUNT(*) FROM playlists p LEFT JOIN playlist_music pm ON pm.playlist_id = p.id WHERE p.user_id = $1)"
+
+// Количество треков во всех плейлистах пользователя $1
+const countPlaylistsExportQuery = "SELECT COUNT(*) FROM playlists p JOIN playlist_music pm ON pm.playlist_id = p.id WHERE p.user_id = $1"
+
+// Количество треков в плейлисте $2. Если плейлист не принадлежит пользователю $1, строк нет.
+const countPlaylistExportQuery = "SELECT COUNT(pm.music_id) FROM playlists p LEFT JOIN playlist_music pm ON pm.playlist_id = p.id " +
+	"WHERE p.user_id = $1 AND p.id = $2 GROUP BY p.id"
+
+// Задачи, ожидающие обработки, и задачи, выполнение которых началось раньше $2 и, видимо, прервалось.
+// Задача, взятая одним экземпляром приложения, пропускается остальными.
+const claimExportJobsQuery = "UPDATE export_jobs SET status = 'running', started_at = $1 WHERE id IN (" +
+	"SELECT id FROM export_jobs WHERE status = 'pending' OR (status = 'running' AND started_at < $2) " +
+	"ORDER BY created_at LIMIT $3 FOR UPDATE SKIP LOCKED) RETURNING *"
+
+type exportSource struct {
+	db *sqlx.DB
+}
+
+func NewExportSource(source *source) *exportSource {
+	return &exportSource{
+		db: source.db,
+	}
+}
+
+// CountItems возвращает количество записей, которые попадут в выгрузку.
+// Для выгрузки чужого или несуществующего плейлиста возвращается sql.ErrNoRows.
+func (e *exportSource) CountItems(ctx context.Context, userId uuid.UUID, request *entity.ExportRequest) (int64, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var count int64
+	var err error
+	switch {
+	case request.IsArchive():
+		err = e.db.GetContext(dbCtx, &count, countPlaylistsExportQuery, userId)
+	case request.IsPlaylistFormat():
+		err = e.db.GetContext(dbCtx, &count, countPlaylistExportQuery, userId, request.PlaylistID)
+	default:
+		err = e.db.GetContext(dbCtx, &count, countLibraryExportQuery, userId)
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, err
+		}
+		return 0, fmt.Errorf("can't count export items: %w", err)
+	}
+
+	return count, nil
+}
+
+func (e *exportSource) GetLikes(ctx context.Context, userId uuid.UUID) ([]*entity.ExportLikeDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.ExportLikeDB
+	err := e.db.SelectContext(dbCtx, &data,
+		"SELECT "+exportTrackColumns+", t.created_at AS liked_at FROM user_music t "+exportTrackJoin+
+			"WHERE t.user_id = $1 ORDER BY t.created_at, m.id",
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select likes: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetPlaylists возвращает плейлисты пользователя в порядке создания, а если указан playlistId — только этот плейлист
+func (e *exportSource) GetPlaylists(ctx context.Context, userId uuid.UUID, playlistId *uuid.UUID) ([]*entity.PlaylistDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.PlaylistDB
+	err := e.db.SelectContext(dbCtx, &data,
+		"SELECT * FROM playlists WHERE user_id = $1 AND ($2::uuid IS NULL OR id = $2) ORDER BY created_at, id",
+		userId, playlistId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select playlists: %w", err)
+	}
+
+	return data, nil
+}
+
+func (e *exportSource) GetPlaylistTracks(ctx context.Context, playlistId uuid.UUID) ([]*entity.ExportPlaylistTrackDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.ExportPlaylistTrackDB
+	err := e.db.SelectContext(dbCtx, &data,
+		"SELECT "+exportTrackColumns+", t.position, t.added_at FROM playlist_music t "+exportTrackJoin+
+			"WHERE t.playlist_id = $1 ORDER BY t.position",
+		playlistId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select playlist tracks: %w", err)
+	}
+
+	return data, nil
+}
+
+func (e *exportSource) GetRatings(ctx context.Context, userId uuid.UUID) ([]*entity.ExportRatingDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.ExportRatingDB
+	err := e.db.SelectContext(dbCtx, &data,
+		"SELECT "+exportTrackColumns+", t.rating, t.updated_at FROM music_ratings t "+exportTrackJoin+
+			"WHERE t.user_id = $1 ORDER BY t.updated_at, m.id",
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select ratings: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetHistory возвращает страницу истории прослушиваний по возрастанию (played_at, id) после события (afterAt, afterId).
+// Для первой страницы передаются нулевые значения.
+func (e *exportSource) GetHistory(ctx context.Context, userId uuid.UUID, afterAt time.Time, afterId uuid.UUID, limit int) ([]*entity.ExportPlayDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.ExportPlayDB
+	err := e.db.SelectContext(dbCtx, &data,
+		"SELECT t.id, "+exportTrackColumns+", t.event_type, t.position_sec, t.source, t.played_at FROM play_events t "+exportTrackJoin+
+			"WHERE t.user_id = $1 AND (t.played_at, t.id) > ($2, $3) ORDER BY t.played_at, t.id LIMIT $4",
+		userId, afterAt, afterId, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select history: %w", err)
+	}
+
+	return data, nil
+}
+
+func (e *exportSource) CreateJob(ctx context.Context, job *entity.ExportJobDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := e.db.ExecContext(dbCtx,
+		"INSERT INTO export_jobs (id, user_id, format, playlist_id, status, items, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		job.ID, job.UserID, job.Format, job.PlaylistID, job.Status, job.Items, job.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (e *exportSource) GetJob(ctx context.Context, id uuid.UUID) (*entity.ExportJobDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data entity.ExportJobDB
+	err := e.db.QueryRowxContext(dbCtx, "SELECT * FROM export_jobs WHERE id = $1", id).StructScan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("can't scan export job: %w", err)
+	}
+
+	return &data, nil
+}
+
+// ClaimJobs отмечает выполняющимися и возвращает до limit задач, включая зависшие с started_at раньше staleBefore
+func (e *exportSource) ClaimJobs(ctx context.Context, now time.Time, staleBefore time.Time, limit int) ([]*entity.ExportJobDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.ExportJobDB
+	err := e.db.SelectContext(dbCtx, &data, claimExportJobsQuery, now, staleBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't claim export jobs: %w", err)
+	}
+
+	return data, nil
+}
+
+func (e *exportSource) FinishJob(ctx context.Context, job *entity.ExportJobDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := e.db.ExecContext(dbCtx,
+		"UPDATE export_jobs SET status = $2, size = $3, error = $4, finished_at = $5, expires_at = $6 WHERE id = $1",
+		job.ID, job.Status, job.Size, job.Error, job.FinishedAt, job.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredJobs удаляет до limit задач, срок хранения файлов которых истек, и возвращает их для удаления файлов
+func (e *exportSource) DeleteExpiredJobs(ctx context.Context, now time.Time, limit int) ([]*entity.ExportJobDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.ExportJobDB
+	err := e.db.SelectContext(dbCtx, &data,
+		"DELETE FROM export_jobs WHERE id IN (SELECT id FROM export_jobs WHERE expires_at <= $1 ORDER BY expires_at LIMIT $2) RETURNING *",
+		now, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't delete expired export jobs: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,245 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Лента собирается при чтении из релизов исполнителей, лайков и изменений публичных плейлистов пользователей,
+// на которых подписан читатель $1. Курсор передается как NULL для первой страницы.
+var selectFeedQuery = "SELECT * FROM (" +
+	"SELECT 'release:' || m.id AS id, 'release' AS kind, m.published_at AS occurred_at, a.id AS actor_id, a.name AS actor_name, " +
+	"m.id AS music_id, m.name AS music_name, NULL::uuid AS playlist_id, NULL::varchar AS playlist_name " +
+	"FROM artist_follows af JOIN artists a ON a.id = af.artist_id JOIN music m ON m.artist_id = a.id " +
+	"WHERE af.user_id = $1 AND " + availableMusic("m") + " " +
+	"AND NOT (m.explicit AND EXISTS (SELECT 1 FROM users v WHERE v.id = $1 AND v.hide_explicit)) " +
+	"UNION ALL " +
+	"SELECT 'like:' || um.user_id || ':' || um.music_id, 'like', um.created_at, u.id, u.username, m.id, m.name, NULL, NULL " +
+	"FROM user_follows uf JOIN users u ON u.id = uf.followee_id JOIN user_music um ON um.user_id = u.id JOIN music m ON m.id = um.music_id " +
+	"WHERE uf.follower_id = $1 AND u.deleted_at IS NULL AND u.share_likes AND " + knownLikeTime("um") + " " +
+	"AND " + availableMusic("m") + " " +
+	"AND NOT (m.explicit AND EXISTS (SELECT 1 FROM users v WHERE v.id = $1 AND v.hide_explicit)) " +
+	"UNION ALL " +
+	"SELECT 'playlist:' || p.id, 'playlist', p.updated_at, u.id, u.username, NULL, NULL, p.id, p.name " +
+	"FROM user_follows uf JOIN users u ON u.id = uf.followee_id JOIN playlists p ON p.user_id = u.id " +
+	"WHERE uf.follower_id = $1 AND u.deleted_at IS NULL AND u.share_playlists AND p.is_public" +
+	") feed WHERE ($2::timestamptz IS NULL OR (feed.occurred_at, feed.id) < ($2::timestamptz, $3::text)) " +
+	"ORDER BY feed.occurred_at DESC, feed.id DESC LIMIT $4"
+
+type followSource struct {
+	db *sqlx.DB
+}
+
+func NewFollowSource(source *source) *followSource {
+	return &followSource{
+		db: source.db,
+	}
+}
+
+// FollowArtist подписывает пользователя на исполнителя, повторная подписка ничего не меняет.
+// Если исполнителя нет, возвращается sql.ErrNoRows.
+func (f *followSource) FollowArtist(ctx context.Context, userId uuid.UUID, artistId uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var exists bool
+	err := f.db.QueryRowxContext(dbCtx, "SELECT EXISTS (SELECT 1 FROM artists WHERE id = $1)", artistId).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("can't check artist: %w", err)
+	}
+	if !exists {
+		return sql.ErrNoRows
+	}
+
+	_, err = f.db.ExecContext(dbCtx,
+		"INSERT INTO artist_follows (user_id, artist_id, created_at) VALUES ($1, $2, $3) ON CONFLICT (user_id, artist_id) DO NOTHING",
+		userId, artistId, now,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// UnfollowArtist отписывает пользователя от исполнителя. Если подписки нет, возвращается sql.ErrNoRows.
+func (f *followSource) UnfollowArtist(ctx context.Context, userId uuid.UUID, artistId uuid.UUID) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := f.db.ExecContext(dbCtx, "DELETE FROM artist_follows WHERE user_id = $1 AND artist_id = $2", userId, artistId)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// FollowUser подписывает пользователя на другого пользователя, повторная подписка ничего не меняет.
+// Если пользователя нет или он в корзине, возвращается sql.ErrNoRows.
+func (f *followSource) FollowUser(ctx context.Context, followerId uuid.UUID, followeeId uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var exists bool
+	err := f.db.QueryRowxContext(dbCtx, "SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)", followeeId).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("can't check user: %w", err)
+	}
+	if !exists {
+		return sql.ErrNoRows
+	}
+
+	_, err = f.db.ExecContext(dbCtx,
+		"INSERT INTO user_follows (follower_id, followee_id, created_at) VALUES ($1, $2, $3) ON CONFLICT (follower_id, followee_id) DO NOTHING",
+		followerId, followeeId, now,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// UnfollowUser отписывает пользователя от другого пользователя. Если подписки нет, возвращается sql.ErrNoRows.
+func (f *followSource) UnfollowUser(ctx context.Context, followerId uuid.UUID, followeeId uuid.UUID) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := f.db.ExecContext(dbCtx, "DELETE FROM user_follows WHERE follower_id = $1 AND followee_id = $2", followerId, followeeId)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetFollowedArtists возвращает исполнителей, на которых подписан пользователь, начиная с последней подписки
+func (f *followSource) GetFollowedArtists(ctx context.Context, userId uuid.UUID) ([]*entity.ArtistDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := f.db.QueryxContext(dbCtx,
+		"SELECT a.* FROM artist_follows af JOIN artists a ON a.id = af.artist_id WHERE af.user_id = $1 ORDER BY af.created_at DESC",
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.ArtistDB
+	for rows.Next() {
+		var scanEntity entity.ArtistDB
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan artist: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+// GetFollowedUsers возвращает пользователей, на которых подписан пользователь, начиная с последней подписки
+func (f *followSource) GetFollowedUsers(ctx context.Context, userId uuid.UUID) ([]*entity.UserDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := f.db.QueryxContext(dbCtx,
+		"SELECT u.* FROM user_follows uf JOIN users u ON u.id = uf.followee_id "+
+			"WHERE uf.follower_id = $1 AND u.deleted_at IS NULL ORDER BY uf.created_at DESC",
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.UserDB
+	for rows.Next() {
+		var scanEntity entity.UserDB
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan user: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+// GetFeed возвращает до filter.Limit+1 событий ленты, чтобы определить наличие следующей страницы
+func (f *followSource) GetFeed(ctx context.Context, userId uuid.UUID, filter *entity.FeedFilter) ([]*entity.FeedItemDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	cursorTime, cursorId := feedCursorArgs(filter.Cursor)
+	rows, err := f.db.QueryxContext(dbCtx, selectFeedQuery, userId, cursorTime, cursorId, filter.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.FeedItemDB
+	for rows.Next() {
+		var scanEntity entity.FeedItemDB
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan feed item: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+// SetPrivacy меняет то, что пользователь показывает подписчикам. Если пользователя нет, возвращается sql.ErrNoRows.
+func (f *followSource) SetPrivacy(ctx context.Context, userId uuid.UUID, privacy *entity.FeedPrivacy) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := f.db.ExecContext(dbCtx,
+		"UPDATE users SET share_likes = $2, share_playlists = $3 WHERE id = $1 AND deleted_at IS NULL",
+		userId, privacy.ShareLikes, privacy.SharePlaylists,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func feedCursorArgs(cursor *entity.FeedCursor) (*time.Time, *string) {
+	if cursor == nil {
+		return nil, nil
+	}
+	return &cursor.OccurredAt, &cursor.ID
+}
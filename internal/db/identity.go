@@ -0,0 +1,214 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type identitySource struct {
+	db *sqlx.DB
+}
+
+func NewIdentitySource(source *source) *identitySource {
+	return &identitySource{
+		db: source.db,
+	}
+}
+
+func (s *identitySource) CreateLogin(ctx context.Context, login *entity.OIDCLoginDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := s.db.NamedExecContext(dbCtx,
+		"INSERT INTO oidc_logins (state, nonce, code_verifier, user_id, created_at, expires_at) "+
+			"VALUES (:state, :nonce, :code_verifier, :user_id, :created_at, :expires_at)", login)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeLogin удаляет начатый вход и возвращает его. Вход можно завершить только один раз.
+// Если вход не найден или истек, возвращается sql.ErrNoRows.
+func (s *identitySource) ConsumeLogin(ctx context.Context, state string, now time.Time) (*entity.OIDCLoginDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var login entity.OIDCLoginDB
+	err := s.db.GetContext(dbCtx, &login,
+		"DELETE FROM oidc_logins WHERE state = $1 RETURNING state, nonce, code_verifier, user_id, created_at, expires_at", state)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	if !login.ExpiresAt.After(now) {
+		return nil, sql.ErrNoRows
+	}
+
+	return &login, nil
+}
+
+func (s *identitySource) DeleteExpiredLogins(ctx context.Context, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := s.db.ExecContext(dbCtx, "DELETE FROM oidc_logins WHERE expires_at <= $1", now)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUser возвращает учетные записи провайдеров, привязанные к пользователю, начиная с первой
+func (s *identitySource) GetByUser(ctx context.Context, userId uuid.UUID) ([]*entity.UserIdentityDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var identities []*entity.UserIdentityDB
+	err := s.db.SelectContext(dbCtx, &identities,
+		"SELECT id, user_id, issuer, subject, admin, created_at, last_login_at FROM user_identities "+
+			"WHERE user_id = $1 ORDER BY created_at", userId)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return identities, nil
+}
+
+// Login находит пользователя учетной записи провайдера и обновляет его роль по группе администраторов.
+// Учетная запись без пользователя привязывается к login.LinkUserID, а если он не задан — к новому пользователю
+// login.NewUser с ролью login.Role; занятое имя дополняется началом id пользователя.
+// Учетная запись, привязанная к другому пользователю, возвращает entity.ErrIdentityLinked,
+// пользователь в корзине — sql.ErrNoRows.
+func (s *identitySource) Login(ctx context.Context, login *entity.IdentityLogin, now time.Time) (*entity.UserDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var identity entity.UserIdentityDB
+	err = tx.GetContext(dbCtx, &identity,
+		"SELECT id, user_id, issuer, subject, admin, created_at, last_login_at FROM user_identities "+
+			"WHERE issuer = $1 AND subject = $2 FOR UPDATE", login.Issuer, login.Subject)
+	found := err == nil
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("can't get identity: %w", err)
+	}
+
+	if found {
+		if login.LinkUserID != nil && *login.LinkUserID != identity.UserID {
+			return nil, entity.ErrIdentityLinked
+		}
+	} else {
+		identity = entity.UserIdentityDB{
+			ID:        uuid.New(),
+			Issuer:    login.Issuer,
+			Subject:   login.Subject,
+			CreatedAt: now,
+		}
+		if login.LinkUserID != nil {
+			identity.UserID = *login.LinkUserID
+		} else {
+			identity.UserID, err = createIdentityUser(dbCtx, tx, login)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	identity.Admin, err = syncIdentityRole(dbCtx, tx, identity.UserID, identity.Admin, login)
+	if err != nil {
+		return nil, err
+	}
+	identity.LastLoginAt = now
+
+	if found {
+		_, err = tx.ExecContext(dbCtx, "UPDATE user_identities SET admin = $2, last_login_at = $3 WHERE id = $1",
+			identity.ID, identity.Admin, identity.LastLoginAt)
+	} else {
+		_, err = tx.NamedExecContext(dbCtx,
+			"INSERT INTO user_identities (id, user_id, issuer, subject, admin, created_at, last_login_at) "+
+				"VALUES (:id, :user_id, :issuer, :subject, :admin, :created_at, :last_login_at)", &identity)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't save identity: %w", err)
+	}
+
+	var user entity.UserDB
+	err = tx.GetContext(dbCtx, &user, "SELECT * FROM users WHERE id = $1 AND deleted_at IS NULL", identity.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("can't get user: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return &user, nil
+}
+
+// createIdentityUser создает пользователя при первом входе через провайдера
+func createIdentityUser(ctx context.Context, tx *sqlx.Tx, login *entity.IdentityLogin) (uuid.UUID, error) {
+	userId := uuid.New()
+	username := login.NewUser.Username
+
+	var taken bool
+	err := tx.GetContext(ctx, &taken, "SELECT EXISTS (SELECT 1 FROM users WHERE username = $1)", username)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("can't check username: %w", err)
+	}
+	if taken {
+		username = fmt.Sprintf("%s-%s", username, userId.String()[:8])
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO users (id, username, password, role) VALUES ($1, $2, $3, $4)",
+		userId, username, login.NewUser.Password, login.Role)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("can't insert user: %w", err)
+	}
+
+	return userId, nil
+}
+
+// syncIdentityRole выдает пользователю роль ADMIN, если учетная запись состоит в группе администраторов,
+// и возвращает роль login.Role, если учетная запись вышла из группы, а роль была выдана по ней.
+// Роль ADMIN, выданная не по группе, не снимается. Возвращает, выдана ли текущая роль ADMIN по группе.
+func syncIdentityRole(ctx context.Context, tx *sqlx.Tx, userId uuid.UUID, admin bool, login *entity.IdentityLogin) (bool, error) {
+	if login.Admin {
+		res, err := tx.ExecContext(ctx, "UPDATE users SET role = $2 WHERE id = $1 AND role <> $2", userId, entity.AdminRole)
+		if err != nil {
+			return false, fmt.Errorf("can't grant admin role: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return false, fmt.Errorf("can't get affected rows: %w", err)
+		}
+		return admin || affected > 0, nil
+	}
+
+	if admin {
+		_, err := tx.ExecContext(ctx, "UPDATE users SET role = $2 WHERE id = $1 AND role = $3", userId, login.Role, entity.AdminRole)
+		if err != nil {
+			return false, fmt.Errorf("can't revoke admin role: %w", err)
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Текст сохраняется только для существующего трека, иначе вставляется 0 строк
+const upsertLyricsQuery = "INSERT INTO music_lyrics (music_id, language, plain, synced, source, created_at, updated_at) " +
+	"SELECT $1, $2, $3, $4, $5, $6, $7 WHERE EXISTS (SELECT 1 FROM music WHERE id = $1 AND deleted_at IS NULL) " +
+	"ON CONFLICT (music_id, language) DO UPDATE SET plain = EXCLUDED.plain, synced = EXCLUDED.synced, " +
+	"source = EXCLUDED.source, updated_at = EXCLUDED.updated_at"
+
+type lyricsSource struct {
+	db *sqlx.DB
+}
+
+func NewLyricsSource(source *source) *lyricsSource {
+	return &lyricsSource{
+		db: source.db,
+	}
+}
+
+// Upsert создает или заменяет текст трека на заданном языке. Если трека нет, возвращается sql.ErrNoRows.
+func (l *lyricsSource) Upsert(ctx context.Context, lyrics *entity.LyricsDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := l.db.ExecContext(dbCtx, upsertLyricsQuery,
+		lyrics.MusicID, lyrics.Language, lyrics.Plain, lyrics.Synced, lyrics.Source, lyrics.CreatedAt, lyrics.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetByMusic возвращает тексты трека на всех языках в порядке добавления. Тексты трека в корзине не возвращаются,
+// неопубликованного или снятого с публикации — только при showUnpublished
+func (l *lyricsSource) GetByMusic(ctx context.Context, musicId uuid.UUID, showUnpublished bool) ([]*entity.LyricsDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := l.db.QueryxContext(dbCtx,
+		"SELECT l.* FROM music_lyrics l JOIN music m ON m.id = l.music_id "+
+			"WHERE l.music_id = $1 AND "+musicFilterCondition("m", entity.MusicFilter{ShowUnpublished: showUnpublished})+
+			" ORDER BY l.created_at, l.language", musicId)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.LyricsDB
+	for rows.Next() {
+		var scanEntity entity.LyricsDB
+		err := rows.StructScan(&scanEntity)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan lyrics: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+func (l *lyricsSource) Delete(ctx context.Context, musicId uuid.UUID, language string) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := l.db.ExecContext(dbCtx, "DELETE FROM music_lyrics WHERE music_id = $1 AND language = $2", musicId, language)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
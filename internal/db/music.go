@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"music-backend-test/internal/entity"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -20,11 +21,12 @@ func NewMusicSource(source *source) *musicSource {
 	}
 }
 
-func (m *musicSource) GetAll(ctx context.Context) ([]*entity.MusicDB, error) {
+// GetAll возвращает треки каталога, которые разрешает filter
+func (m *musicSource) GetAll(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	rows, err := m.db.QueryxContext(dbCtx, "SELECT * FROM music")
+	rows, err := m.db.QueryxContext(dbCtx, "SELECT * FROM music WHERE "+musicFilterCondition("", filter))
 	if err != nil {
 		return nil, fmt.Errorf("can't exec query: %w", err)
 	}
@@ -45,7 +47,7 @@ func (m *musicSource) Get(ctx context.Context, musicId uuid.UUID) (*entity.Music
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	row := m.db.QueryRowxContext(dbCtx, "SELECT * FROM music WHERE id = $1", musicId)
+	row := m.db.QueryRowxContext(dbCtx, "SELECT * FROM music WHERE id = $1 AND deleted_at IS NULL", musicId)
 	if row.Err() != nil {
 		return nil, fmt.Errorf("can't exec query: %w", row.Err())
 	}
@@ -61,17 +63,11 @@ func (m *musicSource) Get(ctx context.Context, musicId uuid.UUID) (*entity.Music
 	return &data, nil
 }
 
-func (m *musicSource) GetAndSortByPopular(ctx context.Context) ([]*entity.MusicDB, error) {
+func (m *musicSource) GetAllSortByTime(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	rows, err := m.db.QueryxContext(dbCtx,
-		"SELECT m.id AS id, m.name AS name "+
-			"FROM music m "+
-			"LEFT JOIN user_music um ON um.music_id = m.id "+
-			"GROUP BY m.id, m.name "+
-			"ORDER BY COALESCE(COUNT(um.music_id), 0) DESC;",
-	)
+	rows, err := m.db.QueryxContext(dbCtx, "SELECT * FROM music WHERE "+musicFilterCondition("", filter)+" ORDER BY release_date")
 	if err != nil {
 		return nil, fmt.Errorf("can't exec query: %w", err)
 	}
@@ -89,17 +85,28 @@ func (m *musicSource) GetAndSortByPopular(ctx context.Context) ([]*entity.MusicD
 	return data, nil
 }
 
-func (m *musicSource) GetAllSortByTime(ctx context.Context) ([]*entity.MusicDB, error) {
+// GetAllSortByRating сортирует треки по байесовской средней оценке:
+// (C * m + avg * n) / (C + n), где m — средняя оценка по всем трекам, а C — среднее количество оценок у оцененного трека.
+// Треки с малым количеством оценок притягиваются к средней по каталогу. Выдаются треки, которые разрешает filter.
+func (m *musicSource) GetAllSortByRating(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	rows, err := m.db.QueryxContext(dbCtx, "SELECT * FROM music ORDER BY release_date")
+	rows, err := m.db.QueryxContext(dbCtx,
+		"SELECT music.* FROM music CROSS JOIN ("+
+			"SELECT COALESCE(AVG(rating), 0)::float8 AS mean, "+
+			"COALESCE(COUNT(*)::float8 / NULLIF(COUNT(DISTINCT music_id), 0), 0) AS prior FROM music_ratings) g "+
+			"WHERE "+musicFilterCondition("music", filter)+" "+
+			"ORDER BY (g.prior * g.mean + music.rating_avg * music.rating_count) / NULLIF(g.prior + music.rating_count, 0) DESC NULLS LAST, "+
+			"music.rating_count DESC, music.name",
+	)
 	if err != nil {
 		return nil, fmt.Errorf("can't exec query: %w", err)
 	}
+	defer rows.Close()
 
 	var data []*entity.MusicDB
-	for i := 0; rows.Next(); i++ {
+	for rows.Next() {
 		var scanEntity entity.MusicDB
 		err := rows.StructScan(&scanEntity)
 		if err != nil {
@@ -116,8 +123,8 @@ func (m *musicSource) Create(ctx context.Context, musicDb *entity.MusicDB) error
 	defer dbCancel()
 
 	musicDb.Id = uuid.New()
-	_, err := m.db.ExecContext(dbCtx, "INSERT INTO music (id, name, release_date, file_name, size, duration) VALUES ($1, $2, $3, $4, $5, $6)",
-		musicDb.Id, musicDb.Name, musicDb.Release, musicDb.FileName, musicDb.Size, musicDb.Duration)
+	_, err := m.db.ExecContext(dbCtx, "INSERT INTO music (id, name, release_date, file_name, size, duration, publish_at, published_at, explicit) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		musicDb.Id, musicDb.Name, musicDb.Release, musicDb.FileName, musicDb.Size, musicDb.Duration, musicDb.PublishAt, musicDb.PublishedAt, musicDb.Explicit)
 	if err != nil {
 		return fmt.Errorf("can't exec query: %w", err)
 	}
@@ -129,13 +136,13 @@ func (m *musicSource) Update(ctx context.Context, musicDb *entity.MusicDB) error
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 	if musicDb.FileName != "" {
-		_, err := m.db.ExecContext(dbCtx, "UPDATE music SET name = $2, release_date = $3, file_name = $4, size = $5, duration = $6 WHERE id = $1",
+		_, err := m.db.ExecContext(dbCtx, "UPDATE music SET name = $2, release_date = $3, file_name = $4, size = $5, duration = $6 WHERE id = $1 AND deleted_at IS NULL",
 			musicDb.Id, musicDb.Name, musicDb.Release, musicDb.FileName, musicDb.Size, musicDb.Duration)
 		if err != nil {
 			return fmt.Errorf("can't exec query: %w", err)
 		}
 	} else {
-		_, err := m.db.ExecContext(dbCtx, "UPDATE music SET name = $2, release_date = $3 WHERE id = $1",
+		_, err := m.db.ExecContext(dbCtx, "UPDATE music SET name = $2, release_date = $3 WHERE id = $1 AND deleted_at IS NULL",
 			musicDb.Id, musicDb.Name, musicDb.Release)
 		if err != nil {
 			return fmt.Errorf("can't exec query: %w", err)
@@ -145,13 +152,43 @@ func (m *musicSource) Update(ctx context.Context, musicDb *entity.MusicDB) error
 	return nil
 }
 
-func (m *musicSource) Delete(ctx context.Context, id uuid.UUID) error {
+// SetExplicit меняет отметку о ненормативном контенте. Если трека нет, возвращается sql.ErrNoRows.
+func (m *musicSource) SetExplicit(ctx context.Context, id uuid.UUID, explicit bool) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+	res, err := m.db.ExecContext(dbCtx, "UPDATE music SET explicit = $2 WHERE id = $1 AND deleted_at IS NULL", id, explicit)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Delete перемещает трек в корзину. Строка и файл удаляются окончательно при очистке корзины.
+// Если трека нет или он уже удален, возвращается sql.ErrNoRows.
+func (m *musicSource) Delete(ctx context.Context, id uuid.UUID, now time.Time) error {
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
-	_, err := m.db.ExecContext(dbCtx, "DELETE FROM music WHERE id = $1", id)
+	res, err := m.db.ExecContext(dbCtx, "UPDATE music SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL", id, now)
 	if err != nil {
 		return fmt.Errorf("can't exec query: %w", err)
 	}
 
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
 	return nil
 }
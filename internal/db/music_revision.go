@@ -0,0 +1,185 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const selectMusicRevisionQuery = "SELECT r.id, r.music_id, r.version, r.action, r.name, r.release_date, r.file_name, r.size, " +
+	"r.duration, r.file_path, r.reverted_from, r.changed_by, u.username, r.created_at " +
+	"FROM music_revisions r LEFT JOIN users u ON u.id = r.changed_by "
+
+// Номер версии вычисляется в том же запросе, что и вставка
+const insertMusicRevisionQuery = "INSERT INTO music_revisions (id, music_id, version, action, name, release_date, file_name, " +
+	"size, duration, file_path, reverted_from, changed_by, created_at) " +
+	"SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12 " +
+	"FROM music_revisions WHERE music_id = $2 RETURNING version"
+
+type musicRevisionSource struct {
+	db *sqlx.DB
+}
+
+func NewMusicRevisionSource(source *source) *musicRevisionSource {
+	return &musicRevisionSource{
+		db: source.db,
+	}
+}
+
+// Create сохраняет версию трека и проставляет ей следующий номер
+func (s *musicRevisionSource) Create(ctx context.Context, revision *entity.MusicRevisionDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	err := insertMusicRevision(dbCtx, s.db, revision)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Apply в одной транзакции обновляет трек, переносит пути к файлам версий и сохраняет новую версию.
+// Если трека нет, возвращается sql.ErrNoRows.
+func (s *musicRevisionSource) Apply(ctx context.Context, music *entity.MusicDB, revision *entity.MusicRevisionDB, moves []entity.MusicFileMove) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокировка строки трека упорядочивает конкурентные изменения и номера версий
+	res, err := tx.ExecContext(dbCtx,
+		"UPDATE music SET name = $2, release_date = $3, file_name = $4, size = $5, duration = $6 WHERE id = $1 AND deleted_at IS NULL",
+		music.Id, music.Name, music.Release, music.FileName, music.Size, music.Duration)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	for _, move := range moves {
+		_, err = tx.ExecContext(dbCtx,
+			"UPDATE music_revisions SET file_path = $3 WHERE music_id = $1 AND file_path = $2",
+			music.Id, move.From, move.To)
+		if err != nil {
+			return fmt.Errorf("can't move revision files: %w", err)
+		}
+	}
+
+	err = insertMusicRevision(dbCtx, tx, revision)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func insertMusicRevision(ctx context.Context, q sqlx.QueryerContext, revision *entity.MusicRevisionDB) error {
+	err := q.QueryRowxContext(ctx, insertMusicRevisionQuery,
+		revision.ID, revision.MusicID, revision.Action, revision.Name, revision.Release, revision.FileName,
+		revision.Size, revision.Duration, revision.FilePath, revision.RevertedFrom, revision.ChangedBy, revision.CreatedAt,
+	).Scan(&revision.Version)
+	if err != nil {
+		return fmt.Errorf("can't insert revision: %w", err)
+	}
+
+	return nil
+}
+
+// GetByMusic возвращает версии трека от новых к старым
+func (s *musicRevisionSource) GetByMusic(ctx context.Context, musicId uuid.UUID, limit int, offset int) ([]*entity.MusicRevisionDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := s.db.QueryxContext(dbCtx,
+		selectMusicRevisionQuery+"WHERE r.music_id = $1 ORDER BY r.version DESC LIMIT $2 OFFSET $3",
+		musicId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.MusicRevisionDB
+	for rows.Next() {
+		var scanEntity entity.MusicRevisionDB
+		err := rows.StructScan(&scanEntity)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan revision: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+func (s *musicRevisionSource) Get(ctx context.Context, musicId uuid.UUID, version int) (*entity.MusicRevisionDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var revision entity.MusicRevisionDB
+	err := s.db.QueryRowxContext(dbCtx,
+		selectMusicRevisionQuery+"WHERE r.music_id = $1 AND r.version = $2", musicId, version).StructScan(&revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
+
+// GetLatest возвращает последнюю версию трека, которая совпадает с его текущим состоянием
+func (s *musicRevisionSource) GetLatest(ctx context.Context, musicId uuid.UUID) (*entity.MusicRevisionDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var revision entity.MusicRevisionDB
+	err := s.db.QueryRowxContext(dbCtx,
+		selectMusicRevisionQuery+"WHERE r.music_id = $1 ORDER BY r.version DESC LIMIT 1", musicId).StructScan(&revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
+
+// GetFilePaths возвращает пути ко всем файлам версий трека
+func (s *musicRevisionSource) GetFilePaths(ctx context.Context, musicId uuid.UUID) ([]string, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := s.db.QueryxContext(dbCtx,
+		"SELECT DISTINCT file_path FROM music_revisions WHERE music_id = $1 ORDER BY file_path", musicId)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		err := rows.Scan(&path)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan file path: %w", err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
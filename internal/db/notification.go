@@ -0,0 +1,280 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Уведомления вместе с именем автора, названием трека и плейлиста. Автор релиза — исполнитель, остальных уведомлений — пользователь.
+const selectNotificationsFrom = "SELECT n.*, COALESCE(a.name, u.username) AS actor_name, m.name AS music_name, pl.name AS playlist_name%s " +
+	"FROM notifications n " +
+	"LEFT JOIN artists a ON n.type = 'release' AND a.id = n.actor_id " +
+	"LEFT JOIN users u ON n.type <> 'release' AND u.id = n.actor_id " +
+	"LEFT JOIN music m ON m.id = n.music_id " +
+	"LEFT JOIN playlists pl ON pl.id = n.playlist_id "
+
+// Уведомления типов, отключенных пользователем для входящих, не показываются
+const notificationInAppCondition = "NOT EXISTS (SELECT 1 FROM notification_preferences np " +
+	"WHERE np.user_id = n.user_id AND np.type = n.type AND NOT np.in_app)"
+
+// Курсор передается как NULL для первой страницы
+var selectNotificationsQuery = fmt.Sprintf(selectNotificationsFrom, "") +
+	"WHERE n.user_id = $1 AND " + notificationInAppCondition + " AND (NOT $2::boolean OR n.read_at IS NULL) " +
+	"AND ($3::timestamptz IS NULL OR (n.created_at, n.id) < ($3::timestamptz, $4::uuid)) " +
+	"ORDER BY n.created_at DESC, n.id DESC LIMIT $5"
+
+var selectUndeliveredNotificationsQuery = fmt.Sprintf(selectNotificationsFrom,
+	", s.email, COALESCE(p.email, false) AS email_enabled, COALESCE(p.webhook, false) AS webhook_enabled") +
+	"LEFT JOIN notification_settings s ON s.user_id = n.user_id " +
+	"LEFT JOIN notification_preferences p ON p.user_id = n.user_id AND p.type = n.type " +
+	"WHERE n.delivered_at IS NULL ORDER BY n.created_at, n.id LIMIT $1"
+
+// Опубликованные треки, о которых еще не рассылались уведомления, отмечаются разосланными,
+// а подписчики их исполнителей получают уведомление о релизе. Треки без исполнителя только отмечаются.
+var insertReleaseNotificationsQuery = "WITH released AS (" +
+	"INSERT INTO release_notifications (music_id, notified_at) " +
+	"SELECT id, $1 FROM music WHERE " + availableMusicAt("", "$1") + " AND published_at <= $1 " +
+	"ON CONFLICT (music_id) DO NOTHING RETURNING music_id" +
+	") INSERT INTO notifications (id, user_id, type, actor_id, music_id, created_at) " +
+	"SELECT gen_random_uuid(), af.user_id, $2, m.artist_id, m.id, $1 " +
+	"FROM released r JOIN music m ON m.id = r.music_id " +
+	"JOIN artist_follows af ON af.artist_id = m.artist_id JOIN users u ON u.id = af.user_id " +
+	"WHERE u.deleted_at IS NULL AND NOT (m.explicit AND u.hide_explicit)"
+
+type notificationSource struct {
+	db *sqlx.DB
+}
+
+func NewNotificationSource(source *source) *notificationSource {
+	return &notificationSource{
+		db: source.db,
+	}
+}
+
+func (s *notificationSource) Create(ctx context.Context, notification *entity.NotificationDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := s.db.ExecContext(dbCtx,
+		"INSERT INTO notifications (id, user_id, type, actor_id, music_id, playlist_id, comment_id, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		notification.ID, notification.UserID, notification.Type, notification.ActorID, notification.MusicID, notification.PlaylistID, notification.CommentID, notification.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// CreateReleases рассылает уведомления о треках, опубликованных к моменту now, и возвращает количество созданных уведомлений
+func (s *notificationSource) CreateReleases(ctx context.Context, now time.Time) (int64, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := s.db.ExecContext(dbCtx, insertReleaseNotificationsQuery, now, entity.NotificationRelease)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	created, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't get affected rows: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *notificationSource) GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.NotificationFilter) ([]*entity.NotificationDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	cursorTime, cursorId := notificationCursorArgs(filter.Cursor)
+	rows, err := s.db.QueryxContext(dbCtx, selectNotificationsQuery, userId, filter.UnreadOnly, cursorTime, cursorId, filter.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.NotificationDB
+	for rows.Next() {
+		var scanEntity entity.NotificationDB
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan notification: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+func (s *notificationSource) CountUnread(ctx context.Context, userId uuid.UUID) (int, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var count int
+	err := s.db.GetContext(dbCtx, &count,
+		"SELECT COUNT(*) FROM notifications n WHERE n.user_id = $1 AND n.read_at IS NULL AND "+notificationInAppCondition,
+		userId,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkRead отмечает уведомление пользователя прочитанным. Повторная отметка не меняет время прочтения.
+func (s *notificationSource) MarkRead(ctx context.Context, userId uuid.UUID, id uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := s.db.ExecContext(dbCtx,
+		"UPDATE notifications SET read_at = COALESCE(read_at, $3) WHERE id = $1 AND user_id = $2",
+		id, userId, now,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (s *notificationSource) MarkAllRead(ctx context.Context, userId uuid.UUID, now time.Time) (int64, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := s.db.ExecContext(dbCtx, "UPDATE notifications SET read_at = $2 WHERE user_id = $1 AND read_at IS NULL", userId, now)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	read, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't get affected rows: %w", err)
+	}
+
+	return read, nil
+}
+
+// GetSettings возвращает настройки пользователя, дополненные значениями по умолчанию
+func (s *notificationSource) GetSettings(ctx context.Context, userId uuid.UUID) (*entity.NotificationSettings, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var email *string
+	err := s.db.GetContext(dbCtx, &email, "SELECT email FROM notification_settings WHERE user_id = $1", userId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("can't get email: %w", err)
+	}
+
+	rows, err := s.db.QueryxContext(dbCtx, "SELECT type, in_app, email, webhook FROM notification_preferences WHERE user_id = $1", userId)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var stored []*entity.NotificationPreference
+	for rows.Next() {
+		var scanEntity entity.NotificationPreference
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan notification preference: %w", err)
+		}
+		stored = append(stored, &scanEntity)
+	}
+
+	return entity.NewNotificationSettings(email, stored), nil
+}
+
+// SetSettings сохраняет адрес и настройки переданных типов. Настройки остальных типов не меняются.
+func (s *notificationSource) SetSettings(ctx context.Context, userId uuid.UUID, settings *entity.NotificationSettings) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(dbCtx,
+		"INSERT INTO notification_settings (user_id, email) VALUES ($1, $2) ON CONFLICT (user_id) DO UPDATE SET email = EXCLUDED.email",
+		userId, settings.Email,
+	)
+	if err != nil {
+		return fmt.Errorf("can't save email: %w", err)
+	}
+
+	for _, preference := range settings.Preferences {
+		_, err = tx.ExecContext(dbCtx,
+			"INSERT INTO notification_preferences (user_id, type, in_app, email, webhook) VALUES ($1, $2, $3, $4, $5) "+
+				"ON CONFLICT (user_id, type) DO UPDATE SET in_app = EXCLUDED.in_app, email = EXCLUDED.email, webhook = EXCLUDED.webhook",
+			userId, preference.Type, preference.InApp, preference.Email, preference.Webhook,
+		)
+		if err != nil {
+			return fmt.Errorf("can't save notification preference: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetUndelivered возвращает самые старые уведомления, еще не обработанные фоновой доставкой
+func (s *notificationSource) GetUndelivered(ctx context.Context, limit int) ([]*entity.NotificationDelivery, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := s.db.QueryxContext(dbCtx, selectUndeliveredNotificationsQuery, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.NotificationDelivery
+	for rows.Next() {
+		var scanEntity entity.NotificationDelivery
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan notification: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+func (s *notificationSource) MarkDelivered(ctx context.Context, id uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := s.db.ExecContext(dbCtx, "UPDATE notifications SET delivered_at = $2 WHERE id = $1", id, now)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func notificationCursorArgs(cursor *entity.NotificationCursor) (*time.Time, *uuid.UUID) {
+	if cursor == nil {
+		return nil, nil
+	}
+	return &cursor.CreatedAt, &cursor.ID
+}
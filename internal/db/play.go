@@ -0,0 +1,163 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const insertPlayEventQuery = "INSERT INTO play_events (id, user_id, music_id, event_type, position_sec, source, client_event_id, played_at, created_at) " +
+	"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (user_id, client_event_id) DO NOTHING"
+
+type playSource struct {
+	db *sqlx.DB
+}
+
+func NewPlaySource(source *source) *playSource {
+	return &playSource{
+		db: source.db,
+	}
+}
+
+// Create сохраняет событие прослушивания. Возвращает false, если событие с таким
+// client_event_id уже было сохранено ранее.
+func (p *playSource) Create(ctx context.Context, event *entity.PlayEventDB) (bool, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := p.db.ExecContext(dbCtx, insertPlayEventQuery,
+		event.ID, event.UserID, event.MusicID, event.EventType, event.Position, event.Source, event.ClientEventID, event.PlayedAt, event.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("can't get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// CreateBatch сохраняет пакет событий в одной транзакции и возвращает количество
+// новых событий. Дубликаты пропускаются.
+func (p *playSource) CreateBatch(ctx context.Context, events []*entity.PlayEventDB) (int, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := p.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	inserted := 0
+	for _, event := range events {
+		res, err := tx.ExecContext(dbCtx, insertPlayEventQuery,
+			event.ID, event.UserID, event.MusicID, event.EventType, event.Position, event.Source, event.ClientEventID, event.PlayedAt, event.CreatedAt)
+		if err != nil {
+			return 0, fmt.Errorf("can't exec query: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("can't get affected rows: %w", err)
+		}
+		inserted += int(affected)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return inserted, nil
+}
+
+// GetKnownMusicIDs возвращает id из ids, которым соответствуют треки вне корзины.
+// События по остальным id нарушили бы внешний ключ play_events.
+func (p *playSource) GetKnownMusicIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []uuid.UUID
+	err := p.db.SelectContext(dbCtx, &data,
+		"SELECT id FROM music WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL",
+		pq.Array(uuidStrings(ids)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select music: %w", err)
+	}
+
+	return data, nil
+}
+
+func (p *playSource) GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) ([]*entity.PlayEventDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := p.db.QueryxContext(dbCtx,
+		"SELECT * FROM play_events WHERE user_id = $1 AND played_at >= $2 AND played_at < $3 ORDER BY played_at DESC LIMIT $4 OFFSET $5",
+		userId, filter.From, filter.To, filter.Limit, filter.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.PlayEventDB
+	for rows.Next() {
+		var scanEntity entity.PlayEventDB
+		err := rows.StructScan(&scanEntity)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan play event: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+func (p *playSource) Delete(ctx context.Context, userId uuid.UUID, eventId uuid.UUID) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := p.db.ExecContext(dbCtx, "DELETE FROM play_events WHERE id = $1 AND user_id = $2", eventId, userId)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (p *playSource) DeleteByUser(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) (int64, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := p.db.ExecContext(dbCtx,
+		"DELETE FROM play_events WHERE user_id = $1 AND played_at >= $2 AND played_at < $3",
+		userId, filter.From, filter.To,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't get affected rows: %w", err)
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,237 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// В плейлисте показываются только доступные треки, с учетом фильтра контента читателя $2
+var selectPlaylistTracksQuery = "SELECT m.* FROM playlist_music pm JOIN music m ON m.id = pm.music_id " +
+	"WHERE pm.playlist_id = $1 AND " + availableMusic("m") + " " +
+	"AND NOT (m.explicit AND EXISTS (SELECT 1 FROM users u WHERE u.id = $2 AND u.hide_explicit)) " +
+	"ORDER BY pm.position"
+
+// Трек добавляется в конец плейлиста; повторное добавление не меняет плейлист
+const insertPlaylistTrackQuery = "INSERT INTO playlist_music (playlist_id, music_id, position, added_at) " +
+	"SELECT $1, $2, COALESCE(MAX(position), 0) + 1, $3 FROM playlist_music WHERE playlist_id = $1 " +
+	"ON CONFLICT (playlist_id, music_id) DO NOTHING"
+
+type playlistSource struct {
+	db *sqlx.DB
+}
+
+func NewPlaylistSource(source *source) *playlistSource {
+	return &playlistSource{
+		db: source.db,
+	}
+}
+
+func (p *playlistSource) Create(ctx context.Context, playlist *entity.PlaylistDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := p.db.ExecContext(dbCtx,
+		"INSERT INTO playlists (id, user_id, name, is_public, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		playlist.ID, playlist.UserID, playlist.Name, playlist.IsPublic, playlist.CreatedAt, playlist.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (p *playlistSource) Get(ctx context.Context, id uuid.UUID) (*entity.PlaylistDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data entity.PlaylistDB
+	err := p.db.QueryRowxContext(dbCtx, "SELECT * FROM playlists WHERE id = $1", id).StructScan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("can't scan playlist: %w", err)
+	}
+
+	return &data, nil
+}
+
+func (p *playlistSource) GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.PlaylistDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := p.db.QueryxContext(dbCtx,
+		"SELECT * FROM playlists WHERE user_id = $1 ORDER BY updated_at DESC, id LIMIT $2 OFFSET $3", userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.PlaylistDB
+	for rows.Next() {
+		var scanEntity entity.PlaylistDB
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan playlist: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+// Update меняет название и видимость плейлиста. Если плейлиста нет, возвращается sql.ErrNoRows.
+func (p *playlistSource) Update(ctx context.Context, playlist *entity.PlaylistDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := p.db.ExecContext(dbCtx, "UPDATE playlists SET name = $2, is_public = $3, updated_at = $4 WHERE id = $1",
+		playlist.ID, playlist.Name, playlist.IsPublic, playlist.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (p *playlistSource) Delete(ctx context.Context, id uuid.UUID) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := p.db.ExecContext(dbCtx, "DELETE FROM playlists WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetTracks возвращает треки плейлиста в порядке добавления, скрывая недоступные читателю
+func (p *playlistSource) GetTracks(ctx context.Context, id uuid.UUID, viewerId uuid.UUID) ([]*entity.MusicDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := p.db.QueryxContext(dbCtx, selectPlaylistTracksQuery, id, viewerId)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.MusicDB
+	for rows.Next() {
+		var scanEntity entity.MusicDB
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan music: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+// AddTrack добавляет трек в конец плейлиста и обновляет время изменения плейлиста.
+// Если трек недоступен, возвращается sql.ErrNoRows.
+func (p *playlistSource) AddTrack(ctx context.Context, id uuid.UUID, musicId uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := p.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var available bool
+	err = tx.QueryRowxContext(dbCtx,
+		"SELECT EXISTS (SELECT 1 FROM music WHERE id = $1 AND "+availableMusic("")+")",
+		musicId,
+	).Scan(&available)
+	if err != nil {
+		return fmt.Errorf("can't check music: %w", err)
+	}
+	if !available {
+		return sql.ErrNoRows
+	}
+
+	res, err := tx.ExecContext(dbCtx, insertPlaylistTrackQuery, id, musicId, now)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+
+	if affected > 0 {
+		_, err = tx.ExecContext(dbCtx, "UPDATE playlists SET updated_at = $2 WHERE id = $1", id, now)
+		if err != nil {
+			return fmt.Errorf("can't update playlist: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveTrack удаляет трек из плейлиста и обновляет время изменения плейлиста.
+// Если трека нет в плейлисте, возвращается sql.ErrNoRows.
+func (p *playlistSource) RemoveTrack(ctx context.Context, id uuid.UUID, musicId uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := p.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(dbCtx, "DELETE FROM playlist_music WHERE playlist_id = $1 AND music_id = $2", id, musicId)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	_, err = tx.ExecContext(dbCtx, "UPDATE playlists SET updated_at = $2 WHERE id = $1", id, now)
+	if err != nil {
+		return fmt.Errorf("can't update playlist: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Поля трека каталога для сопоставления из music m с исполнителем a
+const importCandidateColumns = "m.id AS music_id, m.name, a.name AS artist, COALESCE(EXTRACT(EPOCH FROM m.duration), 0)::bigint AS seconds"
+
+// Треки добавляются в порядке массива $2, позиции начинаются с 1
+const insertImportedTracksQuery = "INSERT INTO playlist_music (playlist_id, music_id, position, added_at) " +
+	"SELECT $1, t.id, t.position, $3 FROM unnest($2::uuid[]) WITH ORDINALITY AS t(id, position)"
+
+type playlistImportSource struct {
+	db *sqlx.DB
+}
+
+func NewPlaylistImportSource(source *source) *playlistImportSource {
+	return &playlistImportSource{
+		db: source.db,
+	}
+}
+
+// GetByIDs возвращает доступные треки из ids. Отсутствующие и недоступные треки пропускаются.
+func (p *playlistImportSource) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ImportCandidateDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.ImportCandidateDB
+	err := p.db.SelectContext(dbCtx, &data,
+		"SELECT "+importCandidateColumns+" FROM music m LEFT JOIN artists a ON a.id = m.artist_id "+
+			"WHERE m.id = ANY($1::uuid[]) AND "+availableMusic("m"),
+		pq.Array(uuidStrings(ids)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select music: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetByTitles возвращает доступные треки, нормализованные названия которых есть среди titles.
+// Названия сравниваются через normalize_import_title по индексу music_import_title_idx.
+func (p *playlistImportSource) GetByTitles(ctx context.Context, titles []string) ([]*entity.ImportCandidateDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.ImportCandidateDB
+	err := p.db.SelectContext(dbCtx, &data,
+		"SELECT "+importCandidateColumns+" FROM music m LEFT JOIN artists a ON a.id = m.artist_id "+
+			"WHERE normalize_import_title(m.name) = ANY($1::text[]) AND "+availableMusic("m")+" ORDER BY m.id",
+		pq.Array(titles),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select music: %w", err)
+	}
+
+	return data, nil
+}
+
+// CreatePlaylist создает плейлист с треками musicIds в одной транзакции
+func (p *playlistImportSource) CreatePlaylist(ctx context.Context, playlist *entity.PlaylistDB, musicIds []uuid.UUID) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := p.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(dbCtx,
+		"INSERT INTO playlists (id, user_id, name, is_public, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		playlist.ID, playlist.UserID, playlist.Name, playlist.IsPublic, playlist.CreatedAt, playlist.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("can't create playlist: %w", err)
+	}
+
+	if len(musicIds) > 0 {
+		_, err = tx.ExecContext(dbCtx, insertImportedTracksQuery, playlist.ID, pq.Array(uuidStrings(musicIds)), playlist.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("can't add playlist tracks: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	data := make([]string, 0, len(ids))
+	for _, id := range ids {
+		data = append(data, id.String())
+	}
+	return data
+}
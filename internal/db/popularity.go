@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Лайки с неизвестным временем отсекает граница окна $4 и учитываются только за все время
+const refreshPopularityQuery = "INSERT INTO music_popularity (period, music_id, plays, likes, score, updated_at) " +
+	"SELECT $1::varchar, m.id, COALESCE(p.plays, 0), COALESCE(l.likes, 0), $2::float8 * COALESCE(p.plays, 0) + $3::float8 * COALESCE(l.likes, 0), $5::timestamptz " +
+	"FROM music m " +
+	"LEFT JOIN (SELECT music_id, COUNT(*) AS plays FROM play_events WHERE event_type = 'start' AND played_at >= $4::timestamptz GROUP BY music_id) p ON p.music_id = m.id " +
+	"LEFT JOIN (SELECT music_id, COUNT(*) AS likes FROM user_music WHERE created_at >= $4::timestamptz GROUP BY music_id) l ON l.music_id = m.id " +
+	"WHERE m.deleted_at IS NULL " +
+	"ON CONFLICT (period, music_id) DO UPDATE SET plays = EXCLUDED.plays, likes = EXCLUDED.likes, score = EXCLUDED.score, updated_at = EXCLUDED.updated_at"
+
+// Вклад каждого события затухает экспоненциально: weight * 2^(-age / halfLife)
+var refreshTrendingQuery = "INSERT INTO music_popularity (period, music_id, plays, likes, score, updated_at) " +
+	"SELECT $1::varchar, m.id, COALESCE(p.plays, 0), COALESCE(l.likes, 0), $2::float8 * COALESCE(p.score, 0) + $3::float8 * COALESCE(l.score, 0), $5::timestamptz " +
+	"FROM music m " +
+	"LEFT JOIN (SELECT music_id, COUNT(*) AS plays, SUM(POWER(2, -EXTRACT(EPOCH FROM ($5::timestamptz - played_at)) / $6::float8)) AS score " +
+	"FROM play_events WHERE event_type = 'start' AND played_at >= $4::timestamptz GROUP BY music_id) p ON p.music_id = m.id " +
+	"LEFT JOIN (SELECT music_id, COUNT(*) AS likes, SUM(POWER(2, -EXTRACT(EPOCH FROM ($5::timestamptz - created_at)) / $6::float8)) AS score " +
+	"FROM user_music WHERE created_at >= $4::timestamptz AND " + knownLikeTime("") + " GROUP BY music_id) l ON l.music_id = m.id " +
+	"WHERE m.deleted_at IS NULL " +
+	"ON CONFLICT (period, music_id) DO UPDATE SET plays = EXCLUDED.plays, likes = EXCLUDED.likes, score = EXCLUDED.score, updated_at = EXCLUDED.updated_at"
+
+type popularitySource struct {
+	db *sqlx.DB
+}
+
+func NewPopularitySource(source *source) *popularitySource {
+	return &popularitySource{
+		db: source.db,
+	}
+}
+
+// Refresh пересчитывает рейтинги всех окон и рейтинг "в тренде" в одной транзакции.
+// Пересчет обходит всю историю прослушиваний, поэтому ограничивается cfg.RefreshTimeout, а не QueryTimeout.
+func (p *popularitySource) Refresh(ctx context.Context, cfg *entity.PopularityConfig, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, jobTimeout(cfg.RefreshTimeout))
+	defer dbCancel()
+
+	tx, err := p.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, window := range entity.PopularityWindows {
+		since, err := entity.PopularityWindowStart(window, now)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(dbCtx, refreshPopularityQuery, window, cfg.PlayWeight, cfg.LikeWeight, since, now)
+		if err != nil {
+			return fmt.Errorf("can't refresh %s popularity: %w", window, err)
+		}
+	}
+
+	halfLife := cfg.TrendingHalfLife.Seconds()
+	since := now.Add(-cfg.TrendingHalfLife * entity.TrendingHorizon)
+	_, err = tx.ExecContext(dbCtx, refreshTrendingQuery, entity.PopularityTrending, cfg.PlayWeight, cfg.LikeWeight, since, now, halfLife)
+	if err != nil {
+		return fmt.Errorf("can't refresh trending: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (p *popularitySource) Get(ctx context.Context, window string, limit int, offset int, hideExplicit bool) ([]*entity.MusicPopularityDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := p.db.QueryxContext(dbCtx,
+		"SELECT m.*, mp.plays, mp.likes, mp.score FROM music_popularity mp JOIN music m ON m.id = mp.music_id "+
+			"WHERE mp.period = $1 AND "+availableMusic("m")+" "+
+			"AND NOT (m.explicit AND $4) ORDER BY mp.score DESC, m.name LIMIT $2 OFFSET $3",
+		window, limit, offset, hideExplicit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.MusicPopularityDB
+	for rows.Next() {
+		var scanEntity entity.MusicPopularityDB
+		err := rows.StructScan(&scanEntity)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan music popularity: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Строка трека блокируется, чтобы параллельные оценки не перезаписали агрегаты друг друга
+var lockMusicQuery = "SELECT id FROM music WHERE id = $1 AND " + availableMusic("") + " FOR UPDATE"
+
+const updateRatingAggregateQuery = "UPDATE music SET rating_avg = r.avg, rating_count = r.count " +
+	"FROM (SELECT COALESCE(AVG(rating), 0)::float8 AS avg, COUNT(*) AS count FROM music_ratings WHERE music_id = $1) r " +
+	"WHERE music.id = $1 RETURNING music.rating_avg, music.rating_count"
+
+var selectLibraryQuery = "SELECT m.*, r.rating AS user_rating, (um.user_id IS NOT NULL) AS liked FROM music m " +
+	"LEFT JOIN user_music um ON um.music_id = m.id AND um.user_id = $1 " +
+	"LEFT JOIN music_ratings r ON r.music_id = m.id AND r.user_id = $1 " +
+	"WHERE (um.user_id IS NOT NULL OR r.user_id IS NOT NULL) AND " + availableMusic("m") + " " +
+	"AND NOT (m.explicit AND EXISTS (SELECT 1 FROM users u WHERE u.id = $1 AND u.hide_explicit)) "
+
+var librarySortOrders = map[string]string{
+	entity.LibrarySortRecent: "ORDER BY GREATEST(um.created_at, r.updated_at) DESC, m.name",
+	entity.LibrarySortRating: "ORDER BY r.rating DESC NULLS LAST, m.rating_avg DESC, m.name",
+	entity.LibrarySortName:   "ORDER BY m.name",
+}
+
+type ratingSource struct {
+	db *sqlx.DB
+}
+
+func NewRatingSource(source *source) *ratingSource {
+	return &ratingSource{
+		db: source.db,
+	}
+}
+
+// Set создает или изменяет оценку и пересчитывает среднюю оценку трека.
+// Если трека нет, возвращается sql.ErrNoRows.
+func (r *ratingSource) Set(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, rating int, now time.Time) (*entity.RatingSummary, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := r.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.lockMusic(dbCtx, tx, musicId); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(dbCtx,
+		"INSERT INTO music_ratings (user_id, music_id, rating, created_at, updated_at) VALUES ($1, $2, $3, $4, $4) "+
+			"ON CONFLICT (user_id, music_id) DO UPDATE SET rating = EXCLUDED.rating, updated_at = EXCLUDED.updated_at",
+		userId, musicId, rating, now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	summary := &entity.RatingSummary{MusicID: musicId, Rating: rating}
+	if err := r.updateAggregate(dbCtx, tx, summary); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return summary, nil
+}
+
+// Clear снимает оценку пользователя и пересчитывает среднюю оценку трека.
+// Если оценки нет, возвращается sql.ErrNoRows.
+func (r *ratingSource) Clear(ctx context.Context, userId uuid.UUID, musicId uuid.UUID) (*entity.RatingSummary, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := r.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.lockMusic(dbCtx, tx, musicId); err != nil {
+		return nil, err
+	}
+
+	result, err := tx.ExecContext(dbCtx, "DELETE FROM music_ratings WHERE user_id = $1 AND music_id = $2", userId, musicId)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("can't get rows affected: %w", err)
+	}
+	if deleted == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	summary := &entity.RatingSummary{MusicID: musicId}
+	if err := r.updateAggregate(dbCtx, tx, summary); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (r *ratingSource) GetLibrary(ctx context.Context, userId uuid.UUID, sort string) ([]*entity.LibraryMusicDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	order, ok := librarySortOrders[sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", entity.ErrUnknownLibrarySort, sort)
+	}
+
+	rows, err := r.db.QueryxContext(dbCtx, selectLibraryQuery+order, userId)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.LibraryMusicDB
+	for rows.Next() {
+		var scanEntity entity.LibraryMusicDB
+		err := rows.StructScan(&scanEntity)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan library music: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+func (r *ratingSource) lockMusic(ctx context.Context, tx *sqlx.Tx, musicId uuid.UUID) error {
+	var id uuid.UUID
+	err := tx.QueryRowxContext(ctx, lockMusicQuery, musicId).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		return fmt.Errorf("can't lock music: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ratingSource) updateAggregate(ctx context.Context, tx *sqlx.Tx, summary *entity.RatingSummary) error {
+	err := tx.QueryRowxContext(ctx, updateRatingAggregateQuery, summary.MusicID).Scan(&summary.Average, &summary.Count)
+	if err != nil {
+		return fmt.Errorf("can't update rating aggregate: %w", err)
+	}
+
+	return nil
+}
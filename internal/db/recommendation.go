@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Схожесть треков — косинусная мера по лайкам: co_likes / sqrt(likes_a * likes_b).
+// Для каждого трека сохраняются $2 наиболее похожих.
+const refreshSimilarityQuery = "INSERT INTO music_similarity (music_id, similar_id, co_likes, score, updated_at) " +
+	"SELECT s.music_id, s.similar_id, s.co_likes, s.score, $3::timestamptz FROM (" +
+	"SELECT a.music_id, b.music_id AS similar_id, COUNT(*) AS co_likes, " +
+	"COUNT(*)::float8 / SQRT((ca.likes * cb.likes)::float8) AS score, " +
+	"ROW_NUMBER() OVER (PARTITION BY a.music_id ORDER BY COUNT(*)::float8 / SQRT((ca.likes * cb.likes)::float8) DESC, b.music_id) AS rank " +
+	"FROM user_music a " +
+	"JOIN user_music b ON b.user_id = a.user_id AND b.music_id <> a.music_id " +
+	"JOIN (SELECT music_id, COUNT(*) AS likes FROM user_music GROUP BY music_id) ca ON ca.music_id = a.music_id " +
+	"JOIN (SELECT music_id, COUNT(*) AS likes FROM user_music GROUP BY music_id) cb ON cb.music_id = b.music_id " +
+	"GROUP BY a.music_id, b.music_id, ca.likes, cb.likes " +
+	"HAVING COUNT(*) >= $1) s " +
+	"WHERE s.rank <= $2"
+
+// Кандидаты набирают схожесть со всеми лайкнутыми треками, в качестве причины берется трек с наибольшим вкладом
+var selectRecommendationsQuery = "SELECT m.*, r.score, r.because_id, bm.name AS because_name FROM (" +
+	"SELECT s.similar_id, SUM(s.score) AS score, (ARRAY_AGG(s.music_id ORDER BY s.score DESC))[1] AS because_id " +
+	"FROM user_music um JOIN music_similarity s ON s.music_id = um.music_id " +
+	"WHERE um.user_id = $1 AND NOT EXISTS (SELECT 1 FROM user_music l WHERE l.user_id = $1 AND l.music_id = s.similar_id) " +
+	"GROUP BY s.similar_id) r " +
+	"JOIN music m ON m.id = r.similar_id " +
+	"JOIN music bm ON bm.id = r.because_id " +
+	"WHERE " + availableMusic("m") + " AND bm.deleted_at IS NULL " +
+	"AND NOT (m.explicit AND EXISTS (SELECT 1 FROM users u WHERE u.id = $1 AND u.hide_explicit)) " +
+	"ORDER BY r.score DESC, m.name LIMIT $2 OFFSET $3"
+
+var selectSimilarQuery = "SELECT m.*, s.co_likes, s.score FROM music_similarity s JOIN music m ON m.id = s.similar_id " +
+	"WHERE s.music_id = $1 AND " + availableMusic("m") + " AND NOT EXISTS (SELECT 1 FROM user_music l WHERE l.user_id = $2 AND l.music_id = s.similar_id) " +
+	"AND NOT (m.explicit AND EXISTS (SELECT 1 FROM users u WHERE u.id = $2 AND u.hide_explicit)) " +
+	"ORDER BY s.score DESC, m.name LIMIT $3 OFFSET $4"
+
+type recommendationSource struct {
+	db *sqlx.DB
+}
+
+func NewRecommendationSource(source *source) *recommendationSource {
+	return &recommendationSource{
+		db: source.db,
+	}
+}
+
+// Refresh полностью пересчитывает таблицу схожести треков в одной транзакции.
+// Пересчет сравнивает лайки всех пар треков, поэтому ограничивается cfg.RefreshTimeout, а не QueryTimeout.
+func (r *recommendationSource) Refresh(ctx context.Context, cfg *entity.SimilarityConfig, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, jobTimeout(cfg.RefreshTimeout))
+	defer dbCancel()
+
+	tx, err := r.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(dbCtx, "DELETE FROM music_similarity")
+	if err != nil {
+		return fmt.Errorf("can't clear similarity: %w", err)
+	}
+
+	_, err = tx.ExecContext(dbCtx, refreshSimilarityQuery, cfg.MinCoLikes, cfg.Neighbors, now)
+	if err != nil {
+		return fmt.Errorf("can't refresh similarity: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *recommendationSource) GetForUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.RecommendationDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := r.db.QueryxContext(dbCtx, selectRecommendationsQuery, userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.RecommendationDB
+	for rows.Next() {
+		var scanEntity entity.RecommendationDB
+		err := rows.StructScan(&scanEntity)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan recommendation: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+func (r *recommendationSource) GetSimilar(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, limit int, offset int) ([]*entity.SimilarMusicDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := r.db.QueryxContext(dbCtx, selectSimilarQuery, musicId, userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.SimilarMusicDB
+	for rows.Next() {
+		var scanEntity entity.SimilarMusicDB
+		err := rows.StructScan(&scanEntity)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan similar music: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
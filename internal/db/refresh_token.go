@@ -0,0 +1,198 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const insertRefreshTokenQuery = "INSERT INTO refresh_tokens (id, family_id, user_id, token_hash, created_at, expires_at) " +
+	"VALUES (:id, :family_id, :user_id, :token_hash, :created_at, :expires_at)"
+
+type refreshTokenSource struct {
+	db *sqlx.DB
+}
+
+func NewRefreshTokenSource(source *source) *refreshTokenSource {
+	return &refreshTokenSource{
+		db: source.db,
+	}
+}
+
+// Create сохраняет новую сессию и первый refresh-токен ее семейства
+func (r *refreshTokenSource) Create(ctx context.Context, session *entity.SessionDB, token *entity.RefreshTokenDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := r.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.NamedExecContext(dbCtx, insertSessionQuery, session)
+	if err != nil {
+		return fmt.Errorf("can't insert session: %w", err)
+	}
+
+	_, err = tx.NamedExecContext(dbCtx, insertRefreshTokenQuery, token)
+	if err != nil {
+		return fmt.Errorf("can't insert refresh token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Rotate помечает токен с хэшем tokenHash использованным и сохраняет next в том же семействе.
+// Если токен уже использован, отзывается все семейство и возвращается entity.ErrRefreshTokenReused
+// вместе с предъявленным токеном: его FamilyID — id отозванной сессии.
+// Если токен отозван или истек, возвращается entity.ErrInvalidRefreshToken, если не найден — sql.ErrNoRows.
+// Сессия продлевается до истечения next, ее устройство обновляется данными device.
+func (r *refreshTokenSource) Rotate(ctx context.Context, tokenHash string, next *entity.RefreshTokenDB, device *entity.SessionDevice, now time.Time) (*entity.RefreshTokenDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := r.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current entity.RefreshTokenDB
+	err = tx.GetContext(dbCtx, &current,
+		"SELECT t.id, t.family_id, t.user_id, t.token_hash, t.created_at, t.expires_at, t.used_at, t.revoked_at "+
+			"FROM refresh_tokens t JOIN users u ON u.id = t.user_id AND u.deleted_at IS NULL "+
+			"WHERE t.token_hash = $1 FOR UPDATE OF t", tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("can't get refresh token: %w", err)
+	}
+
+	if current.RevokedAt != nil {
+		return nil, entity.ErrInvalidRefreshToken
+	}
+
+	if current.UsedAt != nil {
+		// Использованный токен предъявлен повторно: один из его владельцев — не клиент, которому он был выдан
+		err = revokeSessions(dbCtx, tx, now, false, "id = $1", current.FamilyID)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("can't commit transaction: %w", err)
+		}
+		return &current, entity.ErrRefreshTokenReused
+	}
+
+	if !current.ExpiresAt.After(now) {
+		return nil, entity.ErrInvalidRefreshToken
+	}
+
+	_, err = tx.ExecContext(dbCtx, "UPDATE refresh_tokens SET used_at = $2 WHERE id = $1", current.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("can't mark refresh token used: %w", err)
+	}
+
+	rotated := *next
+	rotated.FamilyID = current.FamilyID
+	rotated.UserID = current.UserID
+	_, err = tx.NamedExecContext(dbCtx, insertRefreshTokenQuery, &rotated)
+	if err != nil {
+		return nil, fmt.Errorf("can't insert refresh token: %w", err)
+	}
+
+	// Название устройства меняется, только если клиент передал новое
+	_, err = tx.ExecContext(dbCtx,
+		"UPDATE sessions SET last_seen_at = $2, expires_at = $3, device_name = COALESCE(NULLIF($4, ''), device_name), "+
+			"user_agent = $5, ip = $6 WHERE id = $1", current.FamilyID, now, rotated.ExpiresAt, device.Name, device.UserAgent, device.IP)
+	if err != nil {
+		return nil, fmt.Errorf("can't update session: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return &rotated, nil
+}
+
+// RevokeFamily отзывает сессию, к которой относится токен с хэшем tokenHash, и возвращает ее id.
+// Если токен не найден, возвращается uuid.Nil.
+func (r *refreshTokenSource) RevokeFamily(ctx context.Context, tokenHash string, now time.Time) (uuid.UUID, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := r.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var familyId uuid.UUID
+	err = tx.GetContext(dbCtx, &familyId, "SELECT family_id FROM refresh_tokens WHERE token_hash = $1", tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, fmt.Errorf("can't get refresh token: %w", err)
+	}
+
+	err = revokeSessions(dbCtx, tx, now, false, "id = $1", familyId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return familyId, nil
+}
+
+// RevokeUser отзывает все сессии пользователя вместе с их refresh-токенами
+func (r *refreshTokenSource) RevokeUser(ctx context.Context, userId uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := r.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = revokeSessions(dbCtx, tx, now, false, "user_id = $1", userId)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired удаляет сессии, в которых истекли все токены, вместе с токенами. Использованные токены хранятся,
+// пока действует последний токен сессии, чтобы их повторное предъявление распознавалось.
+func (r *refreshTokenSource) DeleteExpired(ctx context.Context, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := r.db.ExecContext(dbCtx, "DELETE FROM sessions WHERE expires_at <= $1", now)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// В расписание попадают треки, ожидающие публикации, и опубликованные треки с предстоящим снятием
+const selectScheduledMusicQuery = "SELECT * FROM music WHERE deleted_at IS NULL " +
+	"AND (published_at IS NULL OR takedown_at > now()) " +
+	"ORDER BY COALESCE(published_at, publish_at), takedown_at, id LIMIT $1 OFFSET $2"
+
+// Перенос публикации в будущее снимает трек с публикации, а уже опубликованный трек сохраняет исходное время публикации
+const setMusicScheduleQuery = "UPDATE music SET publish_at = $2, takedown_at = $3, " +
+	"published_at = CASE WHEN $2 <= $4 THEN COALESCE(published_at, $2) ELSE NULL END " +
+	"WHERE id = $1 AND deleted_at IS NULL RETURNING *"
+
+type releaseSource struct {
+	db *sqlx.DB
+}
+
+func NewReleaseSource(source *source) *releaseSource {
+	return &releaseSource{
+		db: source.db,
+	}
+}
+
+func (r *releaseSource) GetScheduled(ctx context.Context, limit int, offset int) ([]*entity.MusicDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := r.db.QueryxContext(dbCtx, selectScheduledMusicQuery, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.MusicDB
+	for rows.Next() {
+		var scanEntity entity.MusicDB
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan music: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+// SetSchedule меняет расписание публикации трека. Если трека нет или он в корзине, возвращается sql.ErrNoRows.
+func (r *releaseSource) SetSchedule(ctx context.Context, id uuid.UUID, schedule *entity.MusicSchedule, now time.Time) (*entity.MusicDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data entity.MusicDB
+	err := r.db.QueryRowxContext(dbCtx, setMusicScheduleQuery, id, schedule.PublishAt, schedule.TakedownAt, now).StructScan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return &data, nil
+}
+
+// PublishDue публикует треки, время публикации которых наступило, и возвращает их количество
+func (r *releaseSource) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := r.db.ExecContext(dbCtx,
+		"UPDATE music SET published_at = publish_at WHERE published_at IS NULL AND publish_at <= $1 AND deleted_at IS NULL", now)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	published, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't get affected rows: %w", err)
+	}
+
+	return published, nil
+}
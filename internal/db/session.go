@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const insertSessionQuery = "INSERT INTO sessions (id, user_id, device_name, user_agent, ip, created_at, last_seen_at, expires_at) " +
+	"VALUES (:id, :user_id, :device_name, :user_agent, :ip, :created_at, :last_seen_at, :expires_at)"
+
+type sessionSource struct {
+	db *sqlx.DB
+}
+
+func NewSessionSource(source *source) *sessionSource {
+	return &sessionSource{
+		db: source.db,
+	}
+}
+
+// GetByUser возвращает действующие сессии пользователя, начиная с последней активной
+func (s *sessionSource) GetByUser(ctx context.Context, userId uuid.UUID, now time.Time) ([]*entity.SessionDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var sessions []*entity.SessionDB
+	err := s.db.SelectContext(dbCtx, &sessions,
+		"SELECT id, user_id, device_name, user_agent, ip, created_at, last_seen_at, expires_at, revoked_at FROM sessions "+
+			"WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2 ORDER BY last_seen_at DESC", userId, now)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Revoke отзывает сессию sessionId пользователя userId вместе с ее refresh-токенами.
+// Если у пользователя нет такой сессии, возвращается sql.ErrNoRows. Повторный отзыв не считается ошибкой.
+func (s *sessionSource) Revoke(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = revokeSessions(dbCtx, tx, now, true, "id = $1 AND user_id = $2", sessionId, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeUser отзывает все сессии пользователя вместе с их refresh-токенами
+func (s *sessionSource) RevokeUser(ctx context.Context, userId uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = revokeSessions(dbCtx, tx, now, false, "user_id = $1", userId)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// IsActive проверяет, что сессия существует, не отозвана, не истекла и ее пользователь не удален
+func (s *sessionSource) IsActive(ctx context.Context, sessionId uuid.UUID, now time.Time) (bool, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var active bool
+	err := s.db.GetContext(dbCtx, &active,
+		"SELECT EXISTS (SELECT 1 FROM sessions s JOIN users u ON u.id = s.user_id AND u.deleted_at IS NULL "+
+			"WHERE s.id = $1 AND s.revoked_at IS NULL AND s.expires_at > $2)", sessionId, now)
+	if err != nil {
+		return false, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return active, nil
+}
+
+// revokeSessions отзывает сессии, подходящие под условие condition, и все их refresh-токены.
+// Условие записывается по столбцам sessions с параметрами $1..$len(args), время отзыва передается следующим параметром.
+// Уже отозванные сессии сохраняют исходное время отзыва. При mustExist возвращается sql.ErrNoRows, если сессий не нашлось.
+func revokeSessions(ctx context.Context, tx *sqlx.Tx, now time.Time, mustExist bool, condition string, args ...interface{}) error {
+	nowParam := fmt.Sprintf("$%d", len(args)+1)
+	args = append(args, now)
+
+	res, err := tx.ExecContext(ctx,
+		"UPDATE sessions SET revoked_at = COALESCE(revoked_at, "+nowParam+") WHERE "+condition, args...)
+	if err != nil {
+		return fmt.Errorf("can't revoke sessions: %w", err)
+	}
+
+	if mustExist {
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("can't get affected rows: %w", err)
+		}
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE refresh_tokens SET revoked_at = "+nowParam+" WHERE family_id IN (SELECT id FROM sessions WHERE "+condition+") "+
+			"AND revoked_at IS NULL", args...)
+	if err != nil {
+		return fmt.Errorf("can't revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
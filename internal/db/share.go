@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Прослушивание засчитывается, только если ссылка все еще действует и лимит не исчерпан
+const consumeSharePlayQuery = "UPDATE share_links SET plays = plays + 1 WHERE id = $1 AND revoked_at IS NULL " +
+	"AND (expires_at IS NULL OR expires_at > $2) AND (max_plays IS NULL OR plays < max_plays)"
+
+type shareSource struct {
+	db *sqlx.DB
+}
+
+func NewShareSource(source *source) *shareSource {
+	return &shareSource{
+		db: source.db,
+	}
+}
+
+func (s *shareSource) Create(ctx context.Context, link *entity.ShareLinkDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := s.db.ExecContext(dbCtx,
+		"INSERT INTO share_links (id, token_hash, user_id, music_id, playlist_id, expires_at, max_plays, created_at) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		link.ID, link.TokenHash, link.UserID, link.MusicID, link.PlaylistID, link.ExpiresAt, link.MaxPlays, link.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTokenHash возвращает ссылку по хэшу токена. Ссылки удаленных пользователей не находятся.
+func (s *shareSource) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.ShareLinkDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data entity.ShareLinkDB
+	err := s.db.QueryRowxContext(dbCtx,
+		"SELECT l.* FROM share_links l JOIN users u ON u.id = l.user_id WHERE l.token_hash = $1 AND u.deleted_at IS NULL",
+		tokenHash,
+	).StructScan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("can't scan share link: %w", err)
+	}
+
+	return &data, nil
+}
+
+func (s *shareSource) GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.ShareLinkDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := s.db.QueryxContext(dbCtx,
+		"SELECT * FROM share_links WHERE user_id = $1 ORDER BY created_at DESC, id LIMIT $2 OFFSET $3", userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.ShareLinkDB
+	for rows.Next() {
+		var scanEntity entity.ShareLinkDB
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan share link: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+// Revoke отзывает ссылку владельца. Повторный отзыв не меняет время отзыва.
+// Если у пользователя нет такой ссылки, возвращается sql.ErrNoRows.
+func (s *shareSource) Revoke(ctx context.Context, userId uuid.UUID, id uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := s.db.ExecContext(dbCtx,
+		"UPDATE share_links SET revoked_at = COALESCE(revoked_at, $3) WHERE id = $1 AND user_id = $2",
+		id, userId, now,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ConsumePlay засчитывает прослушивание по ссылке. Если ссылка перестала действовать
+// между проверкой и прослушиванием, возвращается entity.ErrShareLinkUnavailable.
+func (s *shareSource) ConsumePlay(ctx context.Context, id uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := s.db.ExecContext(dbCtx, consumeSharePlayQuery, id, now)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrShareLinkUnavailable
+	}
+
+	return nil
+}
+
+func (s *shareSource) RecordAccess(ctx context.Context, access *entity.ShareAccessDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := s.db.ExecContext(dbCtx,
+		"INSERT INTO share_link_accesses (link_id, music_id, route, status, ip, user_agent, accessed_at) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		access.LinkID, access.MusicID, access.Route, access.Status, access.IP, access.UserAgent, access.AccessedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// signingKeyLock — ключ advisory-блокировки, под которой создаются ключи подписи
+const signingKeyLock = 0x6a776b73
+
+type signingKeySource struct {
+	db *sqlx.DB
+}
+
+func NewSigningKeySource(source *source) *signingKeySource {
+	return &signingKeySource{
+		db: source.db,
+	}
+}
+
+// GetValid возвращает ключи, которые еще принимаются при проверке, начиная с последнего по времени начала подписи
+func (s *signingKeySource) GetValid(ctx context.Context, now time.Time) ([]*entity.SigningKeyDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var keys []*entity.SigningKeyDB
+	err := s.db.SelectContext(dbCtx, &keys,
+		"SELECT kid, algorithm, private_key, created_at, activates_at, retires_at, expires_at FROM signing_keys "+
+			"WHERE expires_at > $1 ORDER BY activates_at DESC", now)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Create сохраняет ключ, если среди действующих ключей нет ключа, начинающего подпись позже after.
+// Так из экземпляров сервиса, одновременно решивших сменить ключ, следующий ключ создает только один.
+// Возвращает false, если ключ не сохранен.
+func (s *signingKeySource) Create(ctx context.Context, key *entity.SigningKeyDB, after time.Time, now time.Time) (bool, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return false, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Без блокировки проверка NOT EXISTS в параллельных транзакциях не видит вставки друг друга
+	_, err = tx.ExecContext(dbCtx, "SELECT pg_advisory_xact_lock($1)", signingKeyLock)
+	if err != nil {
+		return false, fmt.Errorf("can't lock signing keys: %w", err)
+	}
+
+	res, err := tx.ExecContext(dbCtx,
+		"INSERT INTO signing_keys (kid, algorithm, private_key, created_at, activates_at, retires_at, expires_at) "+
+			"SELECT $1, $2, $3, $4, $5, $6, $7 WHERE NOT EXISTS "+
+			"(SELECT 1 FROM signing_keys WHERE activates_at > $8 AND expires_at > $9)",
+		key.ID, key.Algorithm, key.PrivateKey, key.CreatedAt, key.ActivatesAt, key.RetiresAt, key.ExpiresAt, after, now)
+	if err != nil {
+		return false, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("can't get affected rows: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+func (s *signingKeySource) DeleteExpired(ctx context.Context, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := s.db.ExecContext(dbCtx, "DELETE FROM signing_keys WHERE expires_at <= $1", now)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
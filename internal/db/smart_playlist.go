@@ -0,0 +1,335 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const smartPlaylistColumns = "id, user_id, name, is_public, rules, sort, sort_order, track_limit, created_at, updated_at"
+
+// Треки умного плейлиста для пользователя $1: доступные треки с учетом фильтра контента пользователя,
+// к которым присоединены его лайки, оценки и прослушивания. Условия и сортировка подставляются из правил.
+var selectSmartTracksQuery = "WITH plays AS (" +
+	"SELECT music_id, COUNT(*) AS play_count, MAX(played_at) AS last_played FROM play_events " +
+	"WHERE user_id = $1 AND event_type = 'start' GROUP BY music_id) " +
+	"SELECT m.* FROM music m " +
+	"LEFT JOIN artists a ON a.id = m.artist_id " +
+	"LEFT JOIN user_music um ON um.user_id = $1 AND um.music_id = m.id " +
+	"LEFT JOIN music_ratings r ON r.user_id = $1 AND r.music_id = m.id " +
+	"LEFT JOIN plays p ON p.music_id = m.id " +
+	"WHERE " + availableMusic("m") + " " +
+	"AND NOT (m.explicit AND EXISTS (SELECT 1 FROM users u WHERE u.id = $1 AND u.hide_explicit)) "
+
+// Выражения полей правил в selectSmartTracksQuery
+var smartFieldExpressions = map[string]string{
+	entity.SmartFieldName:        "m.name",
+	entity.SmartFieldArtist:      "a.name",
+	entity.SmartFieldReleaseDate: "m.release_date",
+	entity.SmartFieldPublishedAt: "m.published_at",
+	entity.SmartFieldDuration:    "EXTRACT(EPOCH FROM m.duration)",
+	entity.SmartFieldExplicit:    "m.explicit",
+	entity.SmartFieldRatingAvg:   "m.rating_avg",
+	entity.SmartFieldRatingCount: "m.rating_count",
+	entity.SmartFieldLiked:       "(um.music_id IS NOT NULL)",
+	entity.SmartFieldMyRating:    "COALESCE(r.rating, 0)",
+	entity.SmartFieldPlayCount:   "COALESCE(p.play_count, 0)",
+	entity.SmartFieldLastPlayed:  "p.last_played",
+	entity.SmartFieldRandom:      "random()",
+}
+
+var smartComparisons = map[string]string{
+	entity.SmartOpEq:  "=",
+	entity.SmartOpNeq: "IS DISTINCT FROM",
+	entity.SmartOpLt:  "<",
+	entity.SmartOpLte: "<=",
+	entity.SmartOpGt:  ">",
+	entity.SmartOpGte: ">=",
+}
+
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type smartPlaylistSource struct {
+	db *sqlx.DB
+}
+
+func NewSmartPlaylistSource(source *source) *smartPlaylistSource {
+	return &smartPlaylistSource{
+		db: source.db,
+	}
+}
+
+func (s *smartPlaylistSource) Create(ctx context.Context, playlist *entity.SmartPlaylistDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rules, err := json.Marshal(playlist.Rules)
+	if err != nil {
+		return fmt.Errorf("can't marshal rules: %w", err)
+	}
+
+	_, err = s.db.ExecContext(dbCtx,
+		"INSERT INTO smart_playlists ("+smartPlaylistColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+		playlist.ID, playlist.UserID, playlist.Name, playlist.IsPublic, rules, playlist.Sort, playlist.Order, playlist.Limit,
+		playlist.CreatedAt, playlist.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (s *smartPlaylistSource) Get(ctx context.Context, id uuid.UUID) (*entity.SmartPlaylistDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	row := s.db.QueryRowxContext(dbCtx, "SELECT "+smartPlaylistColumns+" FROM smart_playlists WHERE id = $1", id)
+	playlist, err := scanSmartPlaylist(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("can't scan smart playlist: %w", err)
+	}
+
+	return playlist, nil
+}
+
+func (s *smartPlaylistSource) GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.SmartPlaylistDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := s.db.QueryxContext(dbCtx,
+		"SELECT "+smartPlaylistColumns+" FROM smart_playlists WHERE user_id = $1 ORDER BY updated_at DESC, id LIMIT $2 OFFSET $3",
+		userId, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.SmartPlaylistDB
+	for rows.Next() {
+		playlist, err := scanSmartPlaylist(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan smart playlist: %w", err)
+		}
+		data = append(data, playlist)
+	}
+
+	return data, nil
+}
+
+// Update меняет плейлист целиком. Если плейлиста нет, возвращается sql.ErrNoRows.
+func (s *smartPlaylistSource) Update(ctx context.Context, playlist *entity.SmartPlaylistDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rules, err := json.Marshal(playlist.Rules)
+	if err != nil {
+		return fmt.Errorf("can't marshal rules: %w", err)
+	}
+
+	res, err := s.db.ExecContext(dbCtx,
+		"UPDATE smart_playlists SET name = $2, is_public = $3, rules = $4, sort = $5, sort_order = $6, track_limit = $7, updated_at = $8 WHERE id = $1",
+		playlist.ID, playlist.Name, playlist.IsPublic, rules, playlist.Sort, playlist.Order, playlist.Limit, playlist.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (s *smartPlaylistSource) Delete(ctx context.Context, id uuid.UUID) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := s.db.ExecContext(dbCtx, "DELETE FROM smart_playlists WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetTracks вычисляет плейлист для пользователя viewerId на момент now
+func (s *smartPlaylistSource) GetTracks(ctx context.Context, playlist *entity.SmartPlaylistDB, viewerId uuid.UUID, now time.Time) ([]*entity.MusicDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	query, args, err := buildSmartTracksQuery(playlist, viewerId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []*entity.MusicDB
+	err = s.db.SelectContext(dbCtx, &data, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't select music: %w", err)
+	}
+
+	return data, nil
+}
+
+func scanSmartPlaylist(row interface{ Scan(dest ...any) error }) (*entity.SmartPlaylistDB, error) {
+	var playlist entity.SmartPlaylistDB
+	var rules []byte
+	err := row.Scan(&playlist.ID, &playlist.UserID, &playlist.Name, &playlist.IsPublic, &rules,
+		&playlist.Sort, &playlist.Order, &playlist.Limit, &playlist.CreatedAt, &playlist.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rules, &playlist.Rules); err != nil {
+		return nil, fmt.Errorf("can't unmarshal rules: %w", err)
+	}
+	return &playlist, nil
+}
+
+// smartQueryBuilder переводит дерево правил в условие SQL. Значения передаются параметрами запроса.
+type smartQueryBuilder struct {
+	args []any
+	now  time.Time
+}
+
+func buildSmartTracksQuery(playlist *entity.SmartPlaylistDB, viewerId uuid.UUID, now time.Time) (string, []any, error) {
+	b := &smartQueryBuilder{args: []any{viewerId}, now: now}
+
+	condition, err := b.rule(playlist.Rules)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sort, ok := smartFieldExpressions[playlist.Sort]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown sort %q", playlist.Sort)
+	}
+	order := "ASC"
+	if playlist.Order == entity.SmartOrderDesc {
+		order = "DESC"
+	}
+
+	query := selectSmartTracksQuery + "AND " + condition +
+		" ORDER BY " + sort + " " + order + " NULLS LAST, m.id LIMIT " + b.arg(playlist.Limit)
+	return query, b.args, nil
+}
+
+func (b *smartQueryBuilder) arg(value any) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *smartQueryBuilder) rule(rule *entity.SmartRule) (string, error) {
+	if rule == nil {
+		return "", fmt.Errorf("empty rule")
+	}
+	if !rule.IsGroup() {
+		return b.condition(rule)
+	}
+
+	separator := " AND "
+	if rule.Group == entity.SmartGroupOr {
+		separator = " OR "
+	}
+	parts := make([]string, 0, len(rule.Rules))
+	for _, child := range rule.Rules {
+		part, err := b.rule(child)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part)
+	}
+	return "(" + strings.Join(parts, separator) + ")", nil
+}
+
+func (b *smartQueryBuilder) condition(rule *entity.SmartRule) (string, error) {
+	expr, ok := smartFieldExpressions[rule.Field]
+	if !ok || rule.Field == entity.SmartFieldRandom {
+		return "", fmt.Errorf("unknown field %q", rule.Field)
+	}
+
+	switch rule.Operator {
+	case entity.SmartOpContains, entity.SmartOpNotContains, entity.SmartOpStartsWith:
+		value, _ := rule.Value.(string)
+		pattern := "%" + likePatternEscaper.Replace(value) + "%"
+		if rule.Operator == entity.SmartOpStartsWith {
+			pattern = likePatternEscaper.Replace(value) + "%"
+		}
+		if rule.Operator == entity.SmartOpNotContains {
+			// Трек без исполнителя не содержит подстроку в имени исполнителя
+			return "COALESCE(" + expr + " NOT ILIKE " + b.arg(pattern) + ", true)", nil
+		}
+		return expr + " ILIKE " + b.arg(pattern), nil
+
+	case entity.SmartOpInLastDays, entity.SmartOpInCurrentYear:
+		from, to := rule.Period(b.now)
+		return b.between(expr, from, to), nil
+	}
+
+	comparison, ok := smartComparisons[rule.Operator]
+	if !ok {
+		return "", fmt.Errorf("unknown operator %q", rule.Operator)
+	}
+
+	switch entity.SmartFieldType(rule.Field) {
+	case entity.SmartTypeString:
+		// Текстовые поля сравниваются без учета регистра
+		return "lower(" + expr + ") " + comparison + " lower(" + b.arg(rule.Value) + ")", nil
+
+	case entity.SmartTypeNumber:
+		// Значение из JSON может быть дробным, поэтому сравнивается и с целочисленными полями как число с плавающей точкой
+		return expr + " " + comparison + " " + b.arg(rule.Value) + "::double precision", nil
+
+	case entity.SmartTypeDate:
+		// Дата сравнивается с днем целиком: eq — в течение дня, lte — до конца дня, gt — после конца дня
+		day, err := rule.DateValue()
+		if err != nil {
+			return "", err
+		}
+		next := day.AddDate(0, 0, 1)
+		switch rule.Operator {
+		case entity.SmartOpEq:
+			return b.between(expr, day, next), nil
+		case entity.SmartOpNeq:
+			return "NOT COALESCE(" + b.between(expr, day, next) + ", false)", nil
+		case entity.SmartOpLt:
+			return expr + " < " + b.arg(day), nil
+		case entity.SmartOpLte:
+			return expr + " < " + b.arg(next), nil
+		case entity.SmartOpGt:
+			return expr + " >= " + b.arg(next), nil
+		case entity.SmartOpGte:
+			return expr + " >= " + b.arg(day), nil
+		}
+	}
+
+	return expr + " " + comparison + " " + b.arg(rule.Value), nil
+}
+
+func (b *smartQueryBuilder) between(expr string, from time.Time, to time.Time) string {
+	return "(" + expr + " >= " + b.arg(from) + " AND " + expr + " < " + b.arg(to) + ")"
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	entity "music-backend-test/internal/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -52,17 +53,17 @@ func (mr *MockUserSourceMockRecorder) CreateUser(ctx, user interface{}) *gomock.
 }
 
 // DeleteUser mocks base method.
-func (m *MockUserSource) DeleteUser(ctx context.Context, id uuid.UUID) error {
+func (m *MockUserSource) DeleteUser(ctx context.Context, id uuid.UUID, now time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id, now)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserSourceMockRecorder) DeleteUser(ctx, id interface{}) *gomock.Call {
+func (mr *MockUserSourceMockRecorder) DeleteUser(ctx, id, now interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserSource)(nil).DeleteUser), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserSource)(nil).DeleteUser), ctx, id, now)
 }
 
 // DislikeTrack mocks base method.
@@ -123,6 +124,20 @@ func (mr *MockUserSourceMockRecorder) LikeTrack(ctx, userId, trackId interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikeTrack", reflect.TypeOf((*MockUserSource)(nil).LikeTrack), ctx, userId, trackId)
 }
 
+// SetContentFilter mocks base method.
+func (m *MockUserSource) SetContentFilter(ctx context.Context, id uuid.UUID, filter *entity.ContentFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetContentFilter", ctx, id, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetContentFilter indicates an expected call of SetContentFilter.
+func (mr *MockUserSourceMockRecorder) SetContentFilter(ctx, id, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContentFilter", reflect.TypeOf((*MockUserSource)(nil).SetContentFilter), ctx, id, filter)
+}
+
 // ShowLikedTracks mocks base method.
 func (m *MockUserSource) ShowLikedTracks(ctx context.Context, id uuid.UUID) ([]*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +153,20 @@ func (mr *MockUserSourceMockRecorder) ShowLikedTracks(ctx, id interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowLikedTracks", reflect.TypeOf((*MockUserSource)(nil).ShowLikedTracks), ctx, id)
 }
 
+// UpdatePassword mocks base method.
+func (m *MockUserSource) UpdatePassword(ctx context.Context, id uuid.UUID, previous, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, previous, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserSourceMockRecorder) UpdatePassword(ctx, id, previous, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserSource)(nil).UpdatePassword), ctx, id, previous, password)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserSource) UpdateUser(ctx context.Context, userDB *entity.UserDB, user *entity.UserCreate) (*entity.UserDB, error) {
 	m.ctrl.T.Helper()
@@ -191,17 +220,17 @@ func (mr *MockMusicSourceMockRecorder) Create(ctx, musicDb interface{}) *gomock.
 }
 
 // Delete mocks base method.
-func (m *MockMusicSource) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockMusicSource) Delete(ctx context.Context, id uuid.UUID, now time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, now)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockMusicSourceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockMusicSourceMockRecorder) Delete(ctx, id, now interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMusicSource)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMusicSource)(nil).Delete), ctx, id, now)
 }
 
 // Get mocks base method.
@@ -220,48 +249,62 @@ func (mr *MockMusicSourceMockRecorder) Get(ctx, musicId interface{}) *gomock.Cal
 }
 
 // GetAll mocks base method.
-func (m *MockMusicSource) GetAll(ctx context.Context) ([]*entity.MusicDB, error) {
+func (m *MockMusicSource) GetAll(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
 	ret0, _ := ret[0].([]*entity.MusicDB)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockMusicSourceMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+func (mr *MockMusicSourceMockRecorder) GetAll(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockMusicSource)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockMusicSource)(nil).GetAll), ctx, filter)
 }
 
-// GetAllSortByTime mocks base method.
-func (m *MockMusicSource) GetAllSortByTime(ctx context.Context) ([]*entity.MusicDB, error) {
+// GetAllSortByRating mocks base method.
+func (m *MockMusicSource) GetAllSortByRating(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllSortByTime", ctx)
+	ret := m.ctrl.Call(m, "GetAllSortByRating", ctx, filter)
 	ret0, _ := ret[0].([]*entity.MusicDB)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllSortByTime indicates an expected call of GetAllSortByTime.
-func (mr *MockMusicSourceMockRecorder) GetAllSortByTime(ctx interface{}) *gomock.Call {
+// GetAllSortByRating indicates an expected call of GetAllSortByRating.
+func (mr *MockMusicSourceMockRecorder) GetAllSortByRating(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSortByTime", reflect.TypeOf((*MockMusicSource)(nil).GetAllSortByTime), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSortByRating", reflect.TypeOf((*MockMusicSource)(nil).GetAllSortByRating), ctx, filter)
 }
 
-// GetAndSortByPopular mocks base method.
-func (m *MockMusicSource) GetAndSortByPopular(ctx context.Context) ([]*entity.MusicDB, error) {
+// GetAllSortByTime mocks base method.
+func (m *MockMusicSource) GetAllSortByTime(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAndSortByPopular", ctx)
+	ret := m.ctrl.Call(m, "GetAllSortByTime", ctx, filter)
 	ret0, _ := ret[0].([]*entity.MusicDB)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAndSortByPopular indicates an expected call of GetAndSortByPopular.
-func (mr *MockMusicSourceMockRecorder) GetAndSortByPopular(ctx interface{}) *gomock.Call {
+// GetAllSortByTime indicates an expected call of GetAllSortByTime.
+func (mr *MockMusicSourceMockRecorder) GetAllSortByTime(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSortByTime", reflect.TypeOf((*MockMusicSource)(nil).GetAllSortByTime), ctx, filter)
+}
+
+// SetExplicit mocks base method.
+func (m *MockMusicSource) SetExplicit(ctx context.Context, id uuid.UUID, explicit bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExplicit", ctx, id, explicit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExplicit indicates an expected call of SetExplicit.
+func (mr *MockMusicSourceMockRecorder) SetExplicit(ctx, id, explicit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAndSortByPopular", reflect.TypeOf((*MockMusicSource)(nil).GetAndSortByPopular), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExplicit", reflect.TypeOf((*MockMusicSource)(nil).SetExplicit), ctx, id, explicit)
 }
 
 // Update mocks base method.
@@ -277,3 +320,2658 @@ func (mr *MockMusicSourceMockRecorder) Update(ctx, musicDb interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMusicSource)(nil).Update), ctx, musicDb)
 }
+
+// MockPlaySource is a mock of PlaySource interface.
+type MockPlaySource struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaySourceMockRecorder
+}
+
+// MockPlaySourceMockRecorder is the mock recorder for MockPlaySource.
+type MockPlaySourceMockRecorder struct {
+	mock *MockPlaySource
+}
+
+// NewMockPlaySource creates a new mock instance.
+func NewMockPlaySource(ctrl *gomock.Controller) *MockPlaySource {
+	mock := &MockPlaySource{ctrl: ctrl}
+	mock.recorder = &MockPlaySourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaySource) EXPECT() *MockPlaySourceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPlaySource) Create(ctx context.Context, event *entity.PlayEventDB) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPlaySourceMockRecorder) Create(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPlaySource)(nil).Create), ctx, event)
+}
+
+// CreateBatch mocks base method.
+func (m *MockPlaySource) CreateBatch(ctx context.Context, events []*entity.PlayEventDB) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, events)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockPlaySourceMockRecorder) CreateBatch(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockPlaySource)(nil).CreateBatch), ctx, events)
+}
+
+// Delete mocks base method.
+func (m *MockPlaySource) Delete(ctx context.Context, userId, eventId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, eventId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPlaySourceMockRecorder) Delete(ctx, userId, eventId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPlaySource)(nil).Delete), ctx, userId, eventId)
+}
+
+// DeleteByUser mocks base method.
+func (m *MockPlaySource) DeleteByUser(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUser", ctx, userId, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUser indicates an expected call of DeleteByUser.
+func (mr *MockPlaySourceMockRecorder) DeleteByUser(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUser", reflect.TypeOf((*MockPlaySource)(nil).DeleteByUser), ctx, userId, filter)
+}
+
+// GetByUser mocks base method.
+func (m *MockPlaySource) GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) ([]*entity.PlayEventDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, filter)
+	ret0, _ := ret[0].([]*entity.PlayEventDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockPlaySourceMockRecorder) GetByUser(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockPlaySource)(nil).GetByUser), ctx, userId, filter)
+}
+
+// GetKnownMusicIDs mocks base method.
+func (m *MockPlaySource) GetKnownMusicIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKnownMusicIDs", ctx, ids)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKnownMusicIDs indicates an expected call of GetKnownMusicIDs.
+func (mr *MockPlaySourceMockRecorder) GetKnownMusicIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKnownMusicIDs", reflect.TypeOf((*MockPlaySource)(nil).GetKnownMusicIDs), ctx, ids)
+}
+
+// MockPopularitySource is a mock of PopularitySource interface.
+type MockPopularitySource struct {
+	ctrl     *gomock.Controller
+	recorder *MockPopularitySourceMockRecorder
+}
+
+// MockPopularitySourceMockRecorder is the mock recorder for MockPopularitySource.
+type MockPopularitySourceMockRecorder struct {
+	mock *MockPopularitySource
+}
+
+// NewMockPopularitySource creates a new mock instance.
+func NewMockPopularitySource(ctrl *gomock.Controller) *MockPopularitySource {
+	mock := &MockPopularitySource{ctrl: ctrl}
+	mock.recorder = &MockPopularitySourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPopularitySource) EXPECT() *MockPopularitySourceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPopularitySource) Get(ctx context.Context, window string, limit, offset int, hideExplicit bool) ([]*entity.MusicPopularityDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, window, limit, offset, hideExplicit)
+	ret0, _ := ret[0].([]*entity.MusicPopularityDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPopularitySourceMockRecorder) Get(ctx, window, limit, offset, hideExplicit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPopularitySource)(nil).Get), ctx, window, limit, offset, hideExplicit)
+}
+
+// Refresh mocks base method.
+func (m *MockPopularitySource) Refresh(ctx context.Context, cfg *entity.PopularityConfig, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, cfg, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockPopularitySourceMockRecorder) Refresh(ctx, cfg, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockPopularitySource)(nil).Refresh), ctx, cfg, now)
+}
+
+// MockChartSource is a mock of ChartSource interface.
+type MockChartSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockChartSourceMockRecorder
+}
+
+// MockChartSourceMockRecorder is the mock recorder for MockChartSource.
+type MockChartSourceMockRecorder struct {
+	mock *MockChartSource
+}
+
+// NewMockChartSource creates a new mock instance.
+func NewMockChartSource(ctrl *gomock.Controller) *MockChartSource {
+	mock := &MockChartSource{ctrl: ctrl}
+	mock.recorder = &MockChartSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChartSource) EXPECT() *MockChartSourceMockRecorder {
+	return m.recorder
+}
+
+// GetByMusic mocks base method.
+func (m *MockChartSource) GetByMusic(ctx context.Context, musicId uuid.UUID) ([]*entity.ChartEntryDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMusic", ctx, musicId)
+	ret0, _ := ret[0].([]*entity.ChartEntryDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMusic indicates an expected call of GetByMusic.
+func (mr *MockChartSourceMockRecorder) GetByMusic(ctx, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMusic", reflect.TypeOf((*MockChartSource)(nil).GetByMusic), ctx, musicId)
+}
+
+// GetByWeek mocks base method.
+func (m *MockChartSource) GetByWeek(ctx context.Context, week time.Time) ([]*entity.ChartEntryDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByWeek", ctx, week)
+	ret0, _ := ret[0].([]*entity.ChartEntryDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByWeek indicates an expected call of GetByWeek.
+func (mr *MockChartSourceMockRecorder) GetByWeek(ctx, week interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByWeek", reflect.TypeOf((*MockChartSource)(nil).GetByWeek), ctx, week)
+}
+
+// LatestWeek mocks base method.
+func (m *MockChartSource) LatestWeek(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestWeek", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestWeek indicates an expected call of LatestWeek.
+func (mr *MockChartSourceMockRecorder) LatestWeek(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestWeek", reflect.TypeOf((*MockChartSource)(nil).LatestWeek), ctx)
+}
+
+// Snapshot mocks base method.
+func (m *MockChartSource) Snapshot(ctx context.Context, cfg *entity.ChartConfig, week time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, cfg, week)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockChartSourceMockRecorder) Snapshot(ctx, cfg, week interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockChartSource)(nil).Snapshot), ctx, cfg, week)
+}
+
+// MockRecommendationSource is a mock of RecommendationSource interface.
+type MockRecommendationSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationSourceMockRecorder
+}
+
+// MockRecommendationSourceMockRecorder is the mock recorder for MockRecommendationSource.
+type MockRecommendationSourceMockRecorder struct {
+	mock *MockRecommendationSource
+}
+
+// NewMockRecommendationSource creates a new mock instance.
+func NewMockRecommendationSource(ctrl *gomock.Controller) *MockRecommendationSource {
+	mock := &MockRecommendationSource{ctrl: ctrl}
+	mock.recorder = &MockRecommendationSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationSource) EXPECT() *MockRecommendationSourceMockRecorder {
+	return m.recorder
+}
+
+// GetForUser mocks base method.
+func (m *MockRecommendationSource) GetForUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.RecommendationDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.RecommendationDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUser indicates an expected call of GetForUser.
+func (mr *MockRecommendationSourceMockRecorder) GetForUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUser", reflect.TypeOf((*MockRecommendationSource)(nil).GetForUser), ctx, userId, limit, offset)
+}
+
+// GetSimilar mocks base method.
+func (m *MockRecommendationSource) GetSimilar(ctx context.Context, userId, musicId uuid.UUID, limit, offset int) ([]*entity.SimilarMusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSimilar", ctx, userId, musicId, limit, offset)
+	ret0, _ := ret[0].([]*entity.SimilarMusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimilar indicates an expected call of GetSimilar.
+func (mr *MockRecommendationSourceMockRecorder) GetSimilar(ctx, userId, musicId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimilar", reflect.TypeOf((*MockRecommendationSource)(nil).GetSimilar), ctx, userId, musicId, limit, offset)
+}
+
+// Refresh mocks base method.
+func (m *MockRecommendationSource) Refresh(ctx context.Context, cfg *entity.SimilarityConfig, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, cfg, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockRecommendationSourceMockRecorder) Refresh(ctx, cfg, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockRecommendationSource)(nil).Refresh), ctx, cfg, now)
+}
+
+// MockRatingSource is a mock of RatingSource interface.
+type MockRatingSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingSourceMockRecorder
+}
+
+// MockRatingSourceMockRecorder is the mock recorder for MockRatingSource.
+type MockRatingSourceMockRecorder struct {
+	mock *MockRatingSource
+}
+
+// NewMockRatingSource creates a new mock instance.
+func NewMockRatingSource(ctrl *gomock.Controller) *MockRatingSource {
+	mock := &MockRatingSource{ctrl: ctrl}
+	mock.recorder = &MockRatingSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingSource) EXPECT() *MockRatingSourceMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockRatingSource) Clear(ctx context.Context, userId, musicId uuid.UUID) (*entity.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, userId, musicId)
+	ret0, _ := ret[0].(*entity.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockRatingSourceMockRecorder) Clear(ctx, userId, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockRatingSource)(nil).Clear), ctx, userId, musicId)
+}
+
+// GetLibrary mocks base method.
+func (m *MockRatingSource) GetLibrary(ctx context.Context, userId uuid.UUID, sort string) ([]*entity.LibraryMusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLibrary", ctx, userId, sort)
+	ret0, _ := ret[0].([]*entity.LibraryMusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLibrary indicates an expected call of GetLibrary.
+func (mr *MockRatingSourceMockRecorder) GetLibrary(ctx, userId, sort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLibrary", reflect.TypeOf((*MockRatingSource)(nil).GetLibrary), ctx, userId, sort)
+}
+
+// Set mocks base method.
+func (m *MockRatingSource) Set(ctx context.Context, userId, musicId uuid.UUID, rating int, now time.Time) (*entity.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, userId, musicId, rating, now)
+	ret0, _ := ret[0].(*entity.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRatingSourceMockRecorder) Set(ctx, userId, musicId, rating, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRatingSource)(nil).Set), ctx, userId, musicId, rating, now)
+}
+
+// MockCommentSource is a mock of CommentSource interface.
+type MockCommentSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentSourceMockRecorder
+}
+
+// MockCommentSourceMockRecorder is the mock recorder for MockCommentSource.
+type MockCommentSourceMockRecorder struct {
+	mock *MockCommentSource
+}
+
+// NewMockCommentSource creates a new mock instance.
+func NewMockCommentSource(ctrl *gomock.Controller) *MockCommentSource {
+	mock := &MockCommentSource{ctrl: ctrl}
+	mock.recorder = &MockCommentSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentSource) EXPECT() *MockCommentSourceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentSource) Create(ctx context.Context, comment *entity.CommentDB, rateLimit int, since time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comment, rateLimit, since)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentSourceMockRecorder) Create(ctx, comment, rateLimit, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentSource)(nil).Create), ctx, comment, rateLimit, since)
+}
+
+// CreateReport mocks base method.
+func (m *MockCommentSource) CreateReport(ctx context.Context, report *entity.CommentReportDB) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReport", ctx, report)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReport indicates an expected call of CreateReport.
+func (mr *MockCommentSourceMockRecorder) CreateReport(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReport", reflect.TypeOf((*MockCommentSource)(nil).CreateReport), ctx, report)
+}
+
+// DismissReports mocks base method.
+func (m *MockCommentSource) DismissReports(ctx context.Context, commentId, moderatorId uuid.UUID, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissReports", ctx, commentId, moderatorId, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DismissReports indicates an expected call of DismissReports.
+func (mr *MockCommentSourceMockRecorder) DismissReports(ctx, commentId, moderatorId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissReports", reflect.TypeOf((*MockCommentSource)(nil).DismissReports), ctx, commentId, moderatorId, now)
+}
+
+// Get mocks base method.
+func (m *MockCommentSource) Get(ctx context.Context, id uuid.UUID) (*entity.CommentDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.CommentDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCommentSourceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCommentSource)(nil).Get), ctx, id)
+}
+
+// GetByMusic mocks base method.
+func (m *MockCommentSource) GetByMusic(ctx context.Context, musicId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMusic", ctx, musicId, filter)
+	ret0, _ := ret[0].([]*entity.CommentDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMusic indicates an expected call of GetByMusic.
+func (mr *MockCommentSourceMockRecorder) GetByMusic(ctx, musicId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMusic", reflect.TypeOf((*MockCommentSource)(nil).GetByMusic), ctx, musicId, filter)
+}
+
+// GetByPlaylist mocks base method.
+func (m *MockCommentSource) GetByPlaylist(ctx context.Context, playlistId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPlaylist", ctx, playlistId, filter)
+	ret0, _ := ret[0].([]*entity.CommentDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPlaylist indicates an expected call of GetByPlaylist.
+func (mr *MockCommentSourceMockRecorder) GetByPlaylist(ctx, playlistId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPlaylist", reflect.TypeOf((*MockCommentSource)(nil).GetByPlaylist), ctx, playlistId, filter)
+}
+
+// GetModerationQueue mocks base method.
+func (m *MockCommentSource) GetModerationQueue(ctx context.Context, limit, offset int) ([]*entity.ModerationItemDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationQueue", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.ModerationItemDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationQueue indicates an expected call of GetModerationQueue.
+func (mr *MockCommentSourceMockRecorder) GetModerationQueue(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationQueue", reflect.TypeOf((*MockCommentSource)(nil).GetModerationQueue), ctx, limit, offset)
+}
+
+// GetPlaylist mocks base method.
+func (m *MockCommentSource) GetPlaylist(ctx context.Context, playlistId uuid.UUID) (*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylist", ctx, playlistId)
+	ret0, _ := ret[0].(*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylist indicates an expected call of GetPlaylist.
+func (mr *MockCommentSourceMockRecorder) GetPlaylist(ctx, playlistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylist", reflect.TypeOf((*MockCommentSource)(nil).GetPlaylist), ctx, playlistId)
+}
+
+// GetReplies mocks base method.
+func (m *MockCommentSource) GetReplies(ctx context.Context, parentId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplies", ctx, parentId, filter)
+	ret0, _ := ret[0].([]*entity.CommentDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplies indicates an expected call of GetReplies.
+func (mr *MockCommentSourceMockRecorder) GetReplies(ctx, parentId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplies", reflect.TypeOf((*MockCommentSource)(nil).GetReplies), ctx, parentId, filter)
+}
+
+// MusicVisible mocks base method.
+func (m *MockCommentSource) MusicVisible(ctx context.Context, musicId uuid.UUID, showUnpublished bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MusicVisible", ctx, musicId, showUnpublished)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MusicVisible indicates an expected call of MusicVisible.
+func (mr *MockCommentSourceMockRecorder) MusicVisible(ctx, musicId, showUnpublished interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MusicVisible", reflect.TypeOf((*MockCommentSource)(nil).MusicVisible), ctx, musicId, showUnpublished)
+}
+
+// SetStatus mocks base method.
+func (m *MockCommentSource) SetStatus(ctx context.Context, id uuid.UUID, status string, moderatorId *uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, status, moderatorId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockCommentSourceMockRecorder) SetStatus(ctx, id, status, moderatorId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockCommentSource)(nil).SetStatus), ctx, id, status, moderatorId, now)
+}
+
+// Update mocks base method.
+func (m *MockCommentSource) Update(ctx context.Context, id uuid.UUID, body string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, body, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentSourceMockRecorder) Update(ctx, id, body, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommentSource)(nil).Update), ctx, id, body, now)
+}
+
+// MockLyricsSource is a mock of LyricsSource interface.
+type MockLyricsSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockLyricsSourceMockRecorder
+}
+
+// MockLyricsSourceMockRecorder is the mock recorder for MockLyricsSource.
+type MockLyricsSourceMockRecorder struct {
+	mock *MockLyricsSource
+}
+
+// NewMockLyricsSource creates a new mock instance.
+func NewMockLyricsSource(ctrl *gomock.Controller) *MockLyricsSource {
+	mock := &MockLyricsSource{ctrl: ctrl}
+	mock.recorder = &MockLyricsSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLyricsSource) EXPECT() *MockLyricsSourceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockLyricsSource) Delete(ctx context.Context, musicId uuid.UUID, language string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, musicId, language)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLyricsSourceMockRecorder) Delete(ctx, musicId, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLyricsSource)(nil).Delete), ctx, musicId, language)
+}
+
+// GetByMusic mocks base method.
+func (m *MockLyricsSource) GetByMusic(ctx context.Context, musicId uuid.UUID, showUnpublished bool) ([]*entity.LyricsDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMusic", ctx, musicId, showUnpublished)
+	ret0, _ := ret[0].([]*entity.LyricsDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMusic indicates an expected call of GetByMusic.
+func (mr *MockLyricsSourceMockRecorder) GetByMusic(ctx, musicId, showUnpublished interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMusic", reflect.TypeOf((*MockLyricsSource)(nil).GetByMusic), ctx, musicId, showUnpublished)
+}
+
+// Upsert mocks base method.
+func (m *MockLyricsSource) Upsert(ctx context.Context, lyrics *entity.LyricsDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, lyrics)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockLyricsSourceMockRecorder) Upsert(ctx, lyrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockLyricsSource)(nil).Upsert), ctx, lyrics)
+}
+
+// MockMusicRevisionSource is a mock of MusicRevisionSource interface.
+type MockMusicRevisionSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockMusicRevisionSourceMockRecorder
+}
+
+// MockMusicRevisionSourceMockRecorder is the mock recorder for MockMusicRevisionSource.
+type MockMusicRevisionSourceMockRecorder struct {
+	mock *MockMusicRevisionSource
+}
+
+// NewMockMusicRevisionSource creates a new mock instance.
+func NewMockMusicRevisionSource(ctrl *gomock.Controller) *MockMusicRevisionSource {
+	mock := &MockMusicRevisionSource{ctrl: ctrl}
+	mock.recorder = &MockMusicRevisionSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMusicRevisionSource) EXPECT() *MockMusicRevisionSourceMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockMusicRevisionSource) Apply(ctx context.Context, music *entity.MusicDB, revision *entity.MusicRevisionDB, moves []entity.MusicFileMove) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, music, revision, moves)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockMusicRevisionSourceMockRecorder) Apply(ctx, music, revision, moves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockMusicRevisionSource)(nil).Apply), ctx, music, revision, moves)
+}
+
+// Create mocks base method.
+func (m *MockMusicRevisionSource) Create(ctx context.Context, revision *entity.MusicRevisionDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMusicRevisionSourceMockRecorder) Create(ctx, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMusicRevisionSource)(nil).Create), ctx, revision)
+}
+
+// Get mocks base method.
+func (m *MockMusicRevisionSource) Get(ctx context.Context, musicId uuid.UUID, version int) (*entity.MusicRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, musicId, version)
+	ret0, _ := ret[0].(*entity.MusicRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMusicRevisionSourceMockRecorder) Get(ctx, musicId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMusicRevisionSource)(nil).Get), ctx, musicId, version)
+}
+
+// GetByMusic mocks base method.
+func (m *MockMusicRevisionSource) GetByMusic(ctx context.Context, musicId uuid.UUID, limit, offset int) ([]*entity.MusicRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMusic", ctx, musicId, limit, offset)
+	ret0, _ := ret[0].([]*entity.MusicRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMusic indicates an expected call of GetByMusic.
+func (mr *MockMusicRevisionSourceMockRecorder) GetByMusic(ctx, musicId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMusic", reflect.TypeOf((*MockMusicRevisionSource)(nil).GetByMusic), ctx, musicId, limit, offset)
+}
+
+// GetFilePaths mocks base method.
+func (m *MockMusicRevisionSource) GetFilePaths(ctx context.Context, musicId uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilePaths", ctx, musicId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilePaths indicates an expected call of GetFilePaths.
+func (mr *MockMusicRevisionSourceMockRecorder) GetFilePaths(ctx, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilePaths", reflect.TypeOf((*MockMusicRevisionSource)(nil).GetFilePaths), ctx, musicId)
+}
+
+// GetLatest mocks base method.
+func (m *MockMusicRevisionSource) GetLatest(ctx context.Context, musicId uuid.UUID) (*entity.MusicRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatest", ctx, musicId)
+	ret0, _ := ret[0].(*entity.MusicRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockMusicRevisionSourceMockRecorder) GetLatest(ctx, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockMusicRevisionSource)(nil).GetLatest), ctx, musicId)
+}
+
+// MockTrashSource is a mock of TrashSource interface.
+type MockTrashSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashSourceMockRecorder
+}
+
+// MockTrashSourceMockRecorder is the mock recorder for MockTrashSource.
+type MockTrashSourceMockRecorder struct {
+	mock *MockTrashSource
+}
+
+// NewMockTrashSource creates a new mock instance.
+func NewMockTrashSource(ctrl *gomock.Controller) *MockTrashSource {
+	mock := &MockTrashSource{ctrl: ctrl}
+	mock.recorder = &MockTrashSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashSource) EXPECT() *MockTrashSourceMockRecorder {
+	return m.recorder
+}
+
+// GetExpiredMusic mocks base method.
+func (m *MockTrashSource) GetExpiredMusic(ctx context.Context, before time.Time) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredMusic", ctx, before)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredMusic indicates an expected call of GetExpiredMusic.
+func (mr *MockTrashSourceMockRecorder) GetExpiredMusic(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredMusic", reflect.TypeOf((*MockTrashSource)(nil).GetExpiredMusic), ctx, before)
+}
+
+// GetMusic mocks base method.
+func (m *MockTrashSource) GetMusic(ctx context.Context, limit, offset int) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMusic", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMusic indicates an expected call of GetMusic.
+func (mr *MockTrashSourceMockRecorder) GetMusic(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMusic", reflect.TypeOf((*MockTrashSource)(nil).GetMusic), ctx, limit, offset)
+}
+
+// GetUsers mocks base method.
+func (m *MockTrashSource) GetUsers(ctx context.Context, limit, offset int) ([]*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockTrashSourceMockRecorder) GetUsers(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockTrashSource)(nil).GetUsers), ctx, limit, offset)
+}
+
+// PurgeMusic mocks base method.
+func (m *MockTrashSource) PurgeMusic(ctx context.Context, id uuid.UUID, before time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeMusic", ctx, id, before)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeMusic indicates an expected call of PurgeMusic.
+func (mr *MockTrashSourceMockRecorder) PurgeMusic(ctx, id, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeMusic", reflect.TypeOf((*MockTrashSource)(nil).PurgeMusic), ctx, id, before)
+}
+
+// PurgeUsers mocks base method.
+func (m *MockTrashSource) PurgeUsers(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUsers", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeUsers indicates an expected call of PurgeUsers.
+func (mr *MockTrashSourceMockRecorder) PurgeUsers(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUsers", reflect.TypeOf((*MockTrashSource)(nil).PurgeUsers), ctx, before)
+}
+
+// RestoreMusic mocks base method.
+func (m *MockTrashSource) RestoreMusic(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMusic", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMusic indicates an expected call of RestoreMusic.
+func (mr *MockTrashSourceMockRecorder) RestoreMusic(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMusic", reflect.TypeOf((*MockTrashSource)(nil).RestoreMusic), ctx, id)
+}
+
+// RestoreUser mocks base method.
+func (m *MockTrashSource) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockTrashSourceMockRecorder) RestoreUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockTrashSource)(nil).RestoreUser), ctx, id)
+}
+
+// MockReleaseSource is a mock of ReleaseSource interface.
+type MockReleaseSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockReleaseSourceMockRecorder
+}
+
+// MockReleaseSourceMockRecorder is the mock recorder for MockReleaseSource.
+type MockReleaseSourceMockRecorder struct {
+	mock *MockReleaseSource
+}
+
+// NewMockReleaseSource creates a new mock instance.
+func NewMockReleaseSource(ctrl *gomock.Controller) *MockReleaseSource {
+	mock := &MockReleaseSource{ctrl: ctrl}
+	mock.recorder = &MockReleaseSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReleaseSource) EXPECT() *MockReleaseSourceMockRecorder {
+	return m.recorder
+}
+
+// GetScheduled mocks base method.
+func (m *MockReleaseSource) GetScheduled(ctx context.Context, limit, offset int) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduled", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduled indicates an expected call of GetScheduled.
+func (mr *MockReleaseSourceMockRecorder) GetScheduled(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduled", reflect.TypeOf((*MockReleaseSource)(nil).GetScheduled), ctx, limit, offset)
+}
+
+// PublishDue mocks base method.
+func (m *MockReleaseSource) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDue", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDue indicates an expected call of PublishDue.
+func (mr *MockReleaseSourceMockRecorder) PublishDue(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDue", reflect.TypeOf((*MockReleaseSource)(nil).PublishDue), ctx, now)
+}
+
+// SetSchedule mocks base method.
+func (m *MockReleaseSource) SetSchedule(ctx context.Context, id uuid.UUID, schedule *entity.MusicSchedule, now time.Time) (*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchedule", ctx, id, schedule, now)
+	ret0, _ := ret[0].(*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSchedule indicates an expected call of SetSchedule.
+func (mr *MockReleaseSourceMockRecorder) SetSchedule(ctx, id, schedule, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedule", reflect.TypeOf((*MockReleaseSource)(nil).SetSchedule), ctx, id, schedule, now)
+}
+
+// MockArtistSource is a mock of ArtistSource interface.
+type MockArtistSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtistSourceMockRecorder
+}
+
+// MockArtistSourceMockRecorder is the mock recorder for MockArtistSource.
+type MockArtistSourceMockRecorder struct {
+	mock *MockArtistSource
+}
+
+// NewMockArtistSource creates a new mock instance.
+func NewMockArtistSource(ctrl *gomock.Controller) *MockArtistSource {
+	mock := &MockArtistSource{ctrl: ctrl}
+	mock.recorder = &MockArtistSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtistSource) EXPECT() *MockArtistSourceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockArtistSource) Create(ctx context.Context, artist *entity.ArtistDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, artist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArtistSourceMockRecorder) Create(ctx, artist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArtistSource)(nil).Create), ctx, artist)
+}
+
+// Get mocks base method.
+func (m *MockArtistSource) Get(ctx context.Context, id uuid.UUID) (*entity.ArtistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.ArtistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockArtistSourceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArtistSource)(nil).Get), ctx, id)
+}
+
+// SetMusicArtist mocks base method.
+func (m *MockArtistSource) SetMusicArtist(ctx context.Context, musicId uuid.UUID, artistId *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMusicArtist", ctx, musicId, artistId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMusicArtist indicates an expected call of SetMusicArtist.
+func (mr *MockArtistSourceMockRecorder) SetMusicArtist(ctx, musicId, artistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMusicArtist", reflect.TypeOf((*MockArtistSource)(nil).SetMusicArtist), ctx, musicId, artistId)
+}
+
+// MockPlaylistSource is a mock of PlaylistSource interface.
+type MockPlaylistSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaylistSourceMockRecorder
+}
+
+// MockPlaylistSourceMockRecorder is the mock recorder for MockPlaylistSource.
+type MockPlaylistSourceMockRecorder struct {
+	mock *MockPlaylistSource
+}
+
+// NewMockPlaylistSource creates a new mock instance.
+func NewMockPlaylistSource(ctrl *gomock.Controller) *MockPlaylistSource {
+	mock := &MockPlaylistSource{ctrl: ctrl}
+	mock.recorder = &MockPlaylistSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaylistSource) EXPECT() *MockPlaylistSourceMockRecorder {
+	return m.recorder
+}
+
+// AddTrack mocks base method.
+func (m *MockPlaylistSource) AddTrack(ctx context.Context, id, musicId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTrack", ctx, id, musicId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTrack indicates an expected call of AddTrack.
+func (mr *MockPlaylistSourceMockRecorder) AddTrack(ctx, id, musicId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrack", reflect.TypeOf((*MockPlaylistSource)(nil).AddTrack), ctx, id, musicId, now)
+}
+
+// Create mocks base method.
+func (m *MockPlaylistSource) Create(ctx context.Context, playlist *entity.PlaylistDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, playlist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPlaylistSourceMockRecorder) Create(ctx, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPlaylistSource)(nil).Create), ctx, playlist)
+}
+
+// Delete mocks base method.
+func (m *MockPlaylistSource) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPlaylistSourceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPlaylistSource)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockPlaylistSource) Get(ctx context.Context, id uuid.UUID) (*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPlaylistSourceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPlaylistSource)(nil).Get), ctx, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockPlaylistSource) GetByUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockPlaylistSourceMockRecorder) GetByUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockPlaylistSource)(nil).GetByUser), ctx, userId, limit, offset)
+}
+
+// GetTracks mocks base method.
+func (m *MockPlaylistSource) GetTracks(ctx context.Context, id, viewerId uuid.UUID) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracks", ctx, id, viewerId)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTracks indicates an expected call of GetTracks.
+func (mr *MockPlaylistSourceMockRecorder) GetTracks(ctx, id, viewerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracks", reflect.TypeOf((*MockPlaylistSource)(nil).GetTracks), ctx, id, viewerId)
+}
+
+// RemoveTrack mocks base method.
+func (m *MockPlaylistSource) RemoveTrack(ctx context.Context, id, musicId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTrack", ctx, id, musicId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTrack indicates an expected call of RemoveTrack.
+func (mr *MockPlaylistSourceMockRecorder) RemoveTrack(ctx, id, musicId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrack", reflect.TypeOf((*MockPlaylistSource)(nil).RemoveTrack), ctx, id, musicId, now)
+}
+
+// Update mocks base method.
+func (m *MockPlaylistSource) Update(ctx context.Context, playlist *entity.PlaylistDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, playlist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPlaylistSourceMockRecorder) Update(ctx, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPlaylistSource)(nil).Update), ctx, playlist)
+}
+
+// MockFollowSource is a mock of FollowSource interface.
+type MockFollowSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockFollowSourceMockRecorder
+}
+
+// MockFollowSourceMockRecorder is the mock recorder for MockFollowSource.
+type MockFollowSourceMockRecorder struct {
+	mock *MockFollowSource
+}
+
+// NewMockFollowSource creates a new mock instance.
+func NewMockFollowSource(ctrl *gomock.Controller) *MockFollowSource {
+	mock := &MockFollowSource{ctrl: ctrl}
+	mock.recorder = &MockFollowSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFollowSource) EXPECT() *MockFollowSourceMockRecorder {
+	return m.recorder
+}
+
+// FollowArtist mocks base method.
+func (m *MockFollowSource) FollowArtist(ctx context.Context, userId, artistId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowArtist", ctx, userId, artistId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowArtist indicates an expected call of FollowArtist.
+func (mr *MockFollowSourceMockRecorder) FollowArtist(ctx, userId, artistId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowArtist", reflect.TypeOf((*MockFollowSource)(nil).FollowArtist), ctx, userId, artistId, now)
+}
+
+// FollowUser mocks base method.
+func (m *MockFollowSource) FollowUser(ctx context.Context, followerId, followeeId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowUser", ctx, followerId, followeeId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowUser indicates an expected call of FollowUser.
+func (mr *MockFollowSourceMockRecorder) FollowUser(ctx, followerId, followeeId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockFollowSource)(nil).FollowUser), ctx, followerId, followeeId, now)
+}
+
+// GetFeed mocks base method.
+func (m *MockFollowSource) GetFeed(ctx context.Context, userId uuid.UUID, filter *entity.FeedFilter) ([]*entity.FeedItemDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, userId, filter)
+	ret0, _ := ret[0].([]*entity.FeedItemDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockFollowSourceMockRecorder) GetFeed(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockFollowSource)(nil).GetFeed), ctx, userId, filter)
+}
+
+// GetFollowedArtists mocks base method.
+func (m *MockFollowSource) GetFollowedArtists(ctx context.Context, userId uuid.UUID) ([]*entity.ArtistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowedArtists", ctx, userId)
+	ret0, _ := ret[0].([]*entity.ArtistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowedArtists indicates an expected call of GetFollowedArtists.
+func (mr *MockFollowSourceMockRecorder) GetFollowedArtists(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowedArtists", reflect.TypeOf((*MockFollowSource)(nil).GetFollowedArtists), ctx, userId)
+}
+
+// GetFollowedUsers mocks base method.
+func (m *MockFollowSource) GetFollowedUsers(ctx context.Context, userId uuid.UUID) ([]*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowedUsers", ctx, userId)
+	ret0, _ := ret[0].([]*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowedUsers indicates an expected call of GetFollowedUsers.
+func (mr *MockFollowSourceMockRecorder) GetFollowedUsers(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowedUsers", reflect.TypeOf((*MockFollowSource)(nil).GetFollowedUsers), ctx, userId)
+}
+
+// SetPrivacy mocks base method.
+func (m *MockFollowSource) SetPrivacy(ctx context.Context, userId uuid.UUID, privacy *entity.FeedPrivacy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrivacy", ctx, userId, privacy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrivacy indicates an expected call of SetPrivacy.
+func (mr *MockFollowSourceMockRecorder) SetPrivacy(ctx, userId, privacy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrivacy", reflect.TypeOf((*MockFollowSource)(nil).SetPrivacy), ctx, userId, privacy)
+}
+
+// UnfollowArtist mocks base method.
+func (m *MockFollowSource) UnfollowArtist(ctx context.Context, userId, artistId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowArtist", ctx, userId, artistId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowArtist indicates an expected call of UnfollowArtist.
+func (mr *MockFollowSourceMockRecorder) UnfollowArtist(ctx, userId, artistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowArtist", reflect.TypeOf((*MockFollowSource)(nil).UnfollowArtist), ctx, userId, artistId)
+}
+
+// UnfollowUser mocks base method.
+func (m *MockFollowSource) UnfollowUser(ctx context.Context, followerId, followeeId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowUser", ctx, followerId, followeeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowUser indicates an expected call of UnfollowUser.
+func (mr *MockFollowSourceMockRecorder) UnfollowUser(ctx, followerId, followeeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowUser", reflect.TypeOf((*MockFollowSource)(nil).UnfollowUser), ctx, followerId, followeeId)
+}
+
+// MockNotificationSource is a mock of NotificationSource interface.
+type MockNotificationSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationSourceMockRecorder
+}
+
+// MockNotificationSourceMockRecorder is the mock recorder for MockNotificationSource.
+type MockNotificationSourceMockRecorder struct {
+	mock *MockNotificationSource
+}
+
+// NewMockNotificationSource creates a new mock instance.
+func NewMockNotificationSource(ctrl *gomock.Controller) *MockNotificationSource {
+	mock := &MockNotificationSource{ctrl: ctrl}
+	mock.recorder = &MockNotificationSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationSource) EXPECT() *MockNotificationSourceMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockNotificationSource) CountUnread(ctx context.Context, userId uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", ctx, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationSourceMockRecorder) CountUnread(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotificationSource)(nil).CountUnread), ctx, userId)
+}
+
+// Create mocks base method.
+func (m *MockNotificationSource) Create(ctx context.Context, notification *entity.NotificationDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNotificationSourceMockRecorder) Create(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNotificationSource)(nil).Create), ctx, notification)
+}
+
+// CreateReleases mocks base method.
+func (m *MockNotificationSource) CreateReleases(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReleases", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReleases indicates an expected call of CreateReleases.
+func (mr *MockNotificationSourceMockRecorder) CreateReleases(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReleases", reflect.TypeOf((*MockNotificationSource)(nil).CreateReleases), ctx, now)
+}
+
+// GetByUser mocks base method.
+func (m *MockNotificationSource) GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.NotificationFilter) ([]*entity.NotificationDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, filter)
+	ret0, _ := ret[0].([]*entity.NotificationDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockNotificationSourceMockRecorder) GetByUser(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockNotificationSource)(nil).GetByUser), ctx, userId, filter)
+}
+
+// GetSettings mocks base method.
+func (m *MockNotificationSource) GetSettings(ctx context.Context, userId uuid.UUID) (*entity.NotificationSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, userId)
+	ret0, _ := ret[0].(*entity.NotificationSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockNotificationSourceMockRecorder) GetSettings(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockNotificationSource)(nil).GetSettings), ctx, userId)
+}
+
+// GetUndelivered mocks base method.
+func (m *MockNotificationSource) GetUndelivered(ctx context.Context, limit int) ([]*entity.NotificationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUndelivered", ctx, limit)
+	ret0, _ := ret[0].([]*entity.NotificationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUndelivered indicates an expected call of GetUndelivered.
+func (mr *MockNotificationSourceMockRecorder) GetUndelivered(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUndelivered", reflect.TypeOf((*MockNotificationSource)(nil).GetUndelivered), ctx, limit)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotificationSource) MarkAllRead(ctx context.Context, userId uuid.UUID, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx, userId, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationSourceMockRecorder) MarkAllRead(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotificationSource)(nil).MarkAllRead), ctx, userId, now)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockNotificationSource) MarkDelivered(ctx context.Context, id uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockNotificationSourceMockRecorder) MarkDelivered(ctx, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockNotificationSource)(nil).MarkDelivered), ctx, id, now)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationSource) MarkRead(ctx context.Context, userId, id uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userId, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationSourceMockRecorder) MarkRead(ctx, userId, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationSource)(nil).MarkRead), ctx, userId, id, now)
+}
+
+// SetSettings mocks base method.
+func (m *MockNotificationSource) SetSettings(ctx context.Context, userId uuid.UUID, settings *entity.NotificationSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSettings", ctx, userId, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSettings indicates an expected call of SetSettings.
+func (mr *MockNotificationSourceMockRecorder) SetSettings(ctx, userId, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSettings", reflect.TypeOf((*MockNotificationSource)(nil).SetSettings), ctx, userId, settings)
+}
+
+// MockShareSource is a mock of ShareSource interface.
+type MockShareSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareSourceMockRecorder
+}
+
+// MockShareSourceMockRecorder is the mock recorder for MockShareSource.
+type MockShareSourceMockRecorder struct {
+	mock *MockShareSource
+}
+
+// NewMockShareSource creates a new mock instance.
+func NewMockShareSource(ctrl *gomock.Controller) *MockShareSource {
+	mock := &MockShareSource{ctrl: ctrl}
+	mock.recorder = &MockShareSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareSource) EXPECT() *MockShareSourceMockRecorder {
+	return m.recorder
+}
+
+// ConsumePlay mocks base method.
+func (m *MockShareSource) ConsumePlay(ctx context.Context, id uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumePlay", ctx, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumePlay indicates an expected call of ConsumePlay.
+func (mr *MockShareSourceMockRecorder) ConsumePlay(ctx, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumePlay", reflect.TypeOf((*MockShareSource)(nil).ConsumePlay), ctx, id, now)
+}
+
+// Create mocks base method.
+func (m *MockShareSource) Create(ctx context.Context, link *entity.ShareLinkDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShareSourceMockRecorder) Create(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShareSource)(nil).Create), ctx, link)
+}
+
+// GetByTokenHash mocks base method.
+func (m *MockShareSource) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.ShareLinkDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.ShareLinkDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTokenHash indicates an expected call of GetByTokenHash.
+func (mr *MockShareSourceMockRecorder) GetByTokenHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTokenHash", reflect.TypeOf((*MockShareSource)(nil).GetByTokenHash), ctx, tokenHash)
+}
+
+// GetByUser mocks base method.
+func (m *MockShareSource) GetByUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.ShareLinkDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.ShareLinkDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockShareSourceMockRecorder) GetByUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockShareSource)(nil).GetByUser), ctx, userId, limit, offset)
+}
+
+// RecordAccess mocks base method.
+func (m *MockShareSource) RecordAccess(ctx context.Context, access *entity.ShareAccessDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAccess", ctx, access)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAccess indicates an expected call of RecordAccess.
+func (mr *MockShareSourceMockRecorder) RecordAccess(ctx, access interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAccess", reflect.TypeOf((*MockShareSource)(nil).RecordAccess), ctx, access)
+}
+
+// Revoke mocks base method.
+func (m *MockShareSource) Revoke(ctx context.Context, userId, id uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userId, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockShareSourceMockRecorder) Revoke(ctx, userId, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockShareSource)(nil).Revoke), ctx, userId, id, now)
+}
+
+// MockStatsSource is a mock of StatsSource interface.
+type MockStatsSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsSourceMockRecorder
+}
+
+// MockStatsSourceMockRecorder is the mock recorder for MockStatsSource.
+type MockStatsSourceMockRecorder struct {
+	mock *MockStatsSource
+}
+
+// NewMockStatsSource creates a new mock instance.
+func NewMockStatsSource(ctrl *gomock.Controller) *MockStatsSource {
+	mock := &MockStatsSource{ctrl: ctrl}
+	mock.recorder = &MockStatsSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsSource) EXPECT() *MockStatsSourceMockRecorder {
+	return m.recorder
+}
+
+// GetReview mocks base method.
+func (m *MockStatsSource) GetReview(ctx context.Context, userId uuid.UUID, year int) (*entity.YearReviewDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", ctx, userId, year)
+	ret0, _ := ret[0].(*entity.YearReviewDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockStatsSourceMockRecorder) GetReview(ctx, userId, year interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockStatsSource)(nil).GetReview), ctx, userId, year)
+}
+
+// GetStats mocks base method.
+func (m *MockStatsSource) GetStats(ctx context.Context, userId uuid.UUID, filter *entity.StatsFilter) (*entity.ListeningStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, userId, filter)
+	ret0, _ := ret[0].(*entity.ListeningStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockStatsSourceMockRecorder) GetStats(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockStatsSource)(nil).GetStats), ctx, userId, filter)
+}
+
+// GetUsersWithoutReview mocks base method.
+func (m *MockStatsSource) GetUsersWithoutReview(ctx context.Context, year int, afterId uuid.UUID, now time.Time, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersWithoutReview", ctx, year, afterId, now, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersWithoutReview indicates an expected call of GetUsersWithoutReview.
+func (mr *MockStatsSourceMockRecorder) GetUsersWithoutReview(ctx, year, afterId, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithoutReview", reflect.TypeOf((*MockStatsSource)(nil).GetUsersWithoutReview), ctx, year, afterId, now, limit)
+}
+
+// RecordReviewFailure mocks base method.
+func (m *MockStatsSource) RecordReviewFailure(ctx context.Context, failure *entity.YearReviewFailure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordReviewFailure", ctx, failure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordReviewFailure indicates an expected call of RecordReviewFailure.
+func (mr *MockStatsSourceMockRecorder) RecordReviewFailure(ctx, failure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordReviewFailure", reflect.TypeOf((*MockStatsSource)(nil).RecordReviewFailure), ctx, failure)
+}
+
+// SaveReview mocks base method.
+func (m *MockStatsSource) SaveReview(ctx context.Context, review *entity.YearReviewDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReview", ctx, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveReview indicates an expected call of SaveReview.
+func (mr *MockStatsSourceMockRecorder) SaveReview(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReview", reflect.TypeOf((*MockStatsSource)(nil).SaveReview), ctx, review)
+}
+
+// MockExportSource is a mock of ExportSource interface.
+type MockExportSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportSourceMockRecorder
+}
+
+// MockExportSourceMockRecorder is the mock recorder for MockExportSource.
+type MockExportSourceMockRecorder struct {
+	mock *MockExportSource
+}
+
+// NewMockExportSource creates a new mock instance.
+func NewMockExportSource(ctrl *gomock.Controller) *MockExportSource {
+	mock := &MockExportSource{ctrl: ctrl}
+	mock.recorder = &MockExportSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportSource) EXPECT() *MockExportSourceMockRecorder {
+	return m.recorder
+}
+
+// ClaimJobs mocks base method.
+func (m *MockExportSource) ClaimJobs(ctx context.Context, now, staleBefore time.Time, limit int) ([]*entity.ExportJobDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJobs", ctx, now, staleBefore, limit)
+	ret0, _ := ret[0].([]*entity.ExportJobDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJobs indicates an expected call of ClaimJobs.
+func (mr *MockExportSourceMockRecorder) ClaimJobs(ctx, now, staleBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJobs", reflect.TypeOf((*MockExportSource)(nil).ClaimJobs), ctx, now, staleBefore, limit)
+}
+
+// CountItems mocks base method.
+func (m *MockExportSource) CountItems(ctx context.Context, userId uuid.UUID, request *entity.ExportRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountItems", ctx, userId, request)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountItems indicates an expected call of CountItems.
+func (mr *MockExportSourceMockRecorder) CountItems(ctx, userId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountItems", reflect.TypeOf((*MockExportSource)(nil).CountItems), ctx, userId, request)
+}
+
+// CreateJob mocks base method.
+func (m *MockExportSource) CreateJob(ctx context.Context, job *entity.ExportJobDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockExportSourceMockRecorder) CreateJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockExportSource)(nil).CreateJob), ctx, job)
+}
+
+// DeleteExpiredJobs mocks base method.
+func (m *MockExportSource) DeleteExpiredJobs(ctx context.Context, now time.Time, limit int) ([]*entity.ExportJobDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredJobs", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.ExportJobDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredJobs indicates an expected call of DeleteExpiredJobs.
+func (mr *MockExportSourceMockRecorder) DeleteExpiredJobs(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredJobs", reflect.TypeOf((*MockExportSource)(nil).DeleteExpiredJobs), ctx, now, limit)
+}
+
+// FinishJob mocks base method.
+func (m *MockExportSource) FinishJob(ctx context.Context, job *entity.ExportJobDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishJob indicates an expected call of FinishJob.
+func (mr *MockExportSourceMockRecorder) FinishJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishJob", reflect.TypeOf((*MockExportSource)(nil).FinishJob), ctx, job)
+}
+
+// GetHistory mocks base method.
+func (m *MockExportSource) GetHistory(ctx context.Context, userId uuid.UUID, afterAt time.Time, afterId uuid.UUID, limit int) ([]*entity.ExportPlayDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, userId, afterAt, afterId, limit)
+	ret0, _ := ret[0].([]*entity.ExportPlayDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockExportSourceMockRecorder) GetHistory(ctx, userId, afterAt, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockExportSource)(nil).GetHistory), ctx, userId, afterAt, afterId, limit)
+}
+
+// GetJob mocks base method.
+func (m *MockExportSource) GetJob(ctx context.Context, id uuid.UUID) (*entity.ExportJobDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, id)
+	ret0, _ := ret[0].(*entity.ExportJobDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockExportSourceMockRecorder) GetJob(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockExportSource)(nil).GetJob), ctx, id)
+}
+
+// GetLikes mocks base method.
+func (m *MockExportSource) GetLikes(ctx context.Context, userId uuid.UUID) ([]*entity.ExportLikeDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikes", ctx, userId)
+	ret0, _ := ret[0].([]*entity.ExportLikeDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikes indicates an expected call of GetLikes.
+func (mr *MockExportSourceMockRecorder) GetLikes(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikes", reflect.TypeOf((*MockExportSource)(nil).GetLikes), ctx, userId)
+}
+
+// GetPlaylistTracks mocks base method.
+func (m *MockExportSource) GetPlaylistTracks(ctx context.Context, playlistId uuid.UUID) ([]*entity.ExportPlaylistTrackDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylistTracks", ctx, playlistId)
+	ret0, _ := ret[0].([]*entity.ExportPlaylistTrackDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylistTracks indicates an expected call of GetPlaylistTracks.
+func (mr *MockExportSourceMockRecorder) GetPlaylistTracks(ctx, playlistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylistTracks", reflect.TypeOf((*MockExportSource)(nil).GetPlaylistTracks), ctx, playlistId)
+}
+
+// GetPlaylists mocks base method.
+func (m *MockExportSource) GetPlaylists(ctx context.Context, userId uuid.UUID, playlistId *uuid.UUID) ([]*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylists", ctx, userId, playlistId)
+	ret0, _ := ret[0].([]*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylists indicates an expected call of GetPlaylists.
+func (mr *MockExportSourceMockRecorder) GetPlaylists(ctx, userId, playlistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylists", reflect.TypeOf((*MockExportSource)(nil).GetPlaylists), ctx, userId, playlistId)
+}
+
+// GetRatings mocks base method.
+func (m *MockExportSource) GetRatings(ctx context.Context, userId uuid.UUID) ([]*entity.ExportRatingDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatings", ctx, userId)
+	ret0, _ := ret[0].([]*entity.ExportRatingDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatings indicates an expected call of GetRatings.
+func (mr *MockExportSourceMockRecorder) GetRatings(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatings", reflect.TypeOf((*MockExportSource)(nil).GetRatings), ctx, userId)
+}
+
+// MockPlaylistImportSource is a mock of PlaylistImportSource interface.
+type MockPlaylistImportSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaylistImportSourceMockRecorder
+}
+
+// MockPlaylistImportSourceMockRecorder is the mock recorder for MockPlaylistImportSource.
+type MockPlaylistImportSourceMockRecorder struct {
+	mock *MockPlaylistImportSource
+}
+
+// NewMockPlaylistImportSource creates a new mock instance.
+func NewMockPlaylistImportSource(ctrl *gomock.Controller) *MockPlaylistImportSource {
+	mock := &MockPlaylistImportSource{ctrl: ctrl}
+	mock.recorder = &MockPlaylistImportSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaylistImportSource) EXPECT() *MockPlaylistImportSourceMockRecorder {
+	return m.recorder
+}
+
+// CreatePlaylist mocks base method.
+func (m *MockPlaylistImportSource) CreatePlaylist(ctx context.Context, playlist *entity.PlaylistDB, musicIds []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlaylist", ctx, playlist, musicIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePlaylist indicates an expected call of CreatePlaylist.
+func (mr *MockPlaylistImportSourceMockRecorder) CreatePlaylist(ctx, playlist, musicIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaylist", reflect.TypeOf((*MockPlaylistImportSource)(nil).CreatePlaylist), ctx, playlist, musicIds)
+}
+
+// GetByIDs mocks base method.
+func (m *MockPlaylistImportSource) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ImportCandidateDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*entity.ImportCandidateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockPlaylistImportSourceMockRecorder) GetByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockPlaylistImportSource)(nil).GetByIDs), ctx, ids)
+}
+
+// GetByTitles mocks base method.
+func (m *MockPlaylistImportSource) GetByTitles(ctx context.Context, titles []string) ([]*entity.ImportCandidateDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTitles", ctx, titles)
+	ret0, _ := ret[0].([]*entity.ImportCandidateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTitles indicates an expected call of GetByTitles.
+func (mr *MockPlaylistImportSourceMockRecorder) GetByTitles(ctx, titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTitles", reflect.TypeOf((*MockPlaylistImportSource)(nil).GetByTitles), ctx, titles)
+}
+
+// MockSmartPlaylistSource is a mock of SmartPlaylistSource interface.
+type MockSmartPlaylistSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmartPlaylistSourceMockRecorder
+}
+
+// MockSmartPlaylistSourceMockRecorder is the mock recorder for MockSmartPlaylistSource.
+type MockSmartPlaylistSourceMockRecorder struct {
+	mock *MockSmartPlaylistSource
+}
+
+// NewMockSmartPlaylistSource creates a new mock instance.
+func NewMockSmartPlaylistSource(ctrl *gomock.Controller) *MockSmartPlaylistSource {
+	mock := &MockSmartPlaylistSource{ctrl: ctrl}
+	mock.recorder = &MockSmartPlaylistSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmartPlaylistSource) EXPECT() *MockSmartPlaylistSourceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSmartPlaylistSource) Create(ctx context.Context, playlist *entity.SmartPlaylistDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, playlist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSmartPlaylistSourceMockRecorder) Create(ctx, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSmartPlaylistSource)(nil).Create), ctx, playlist)
+}
+
+// Delete mocks base method.
+func (m *MockSmartPlaylistSource) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSmartPlaylistSourceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSmartPlaylistSource)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockSmartPlaylistSource) Get(ctx context.Context, id uuid.UUID) (*entity.SmartPlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.SmartPlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSmartPlaylistSourceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSmartPlaylistSource)(nil).Get), ctx, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockSmartPlaylistSource) GetByUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.SmartPlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.SmartPlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockSmartPlaylistSourceMockRecorder) GetByUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockSmartPlaylistSource)(nil).GetByUser), ctx, userId, limit, offset)
+}
+
+// GetTracks mocks base method.
+func (m *MockSmartPlaylistSource) GetTracks(ctx context.Context, playlist *entity.SmartPlaylistDB, viewerId uuid.UUID, now time.Time) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracks", ctx, playlist, viewerId, now)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTracks indicates an expected call of GetTracks.
+func (mr *MockSmartPlaylistSourceMockRecorder) GetTracks(ctx, playlist, viewerId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracks", reflect.TypeOf((*MockSmartPlaylistSource)(nil).GetTracks), ctx, playlist, viewerId, now)
+}
+
+// Update mocks base method.
+func (m *MockSmartPlaylistSource) Update(ctx context.Context, playlist *entity.SmartPlaylistDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, playlist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSmartPlaylistSourceMockRecorder) Update(ctx, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSmartPlaylistSource)(nil).Update), ctx, playlist)
+}
+
+// MockSyncSource is a mock of SyncSource interface.
+type MockSyncSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncSourceMockRecorder
+}
+
+// MockSyncSourceMockRecorder is the mock recorder for MockSyncSource.
+type MockSyncSourceMockRecorder struct {
+	mock *MockSyncSource
+}
+
+// NewMockSyncSource creates a new mock instance.
+func NewMockSyncSource(ctrl *gomock.Controller) *MockSyncSource {
+	mock := &MockSyncSource{ctrl: ctrl}
+	mock.recorder = &MockSyncSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncSource) EXPECT() *MockSyncSourceMockRecorder {
+	return m.recorder
+}
+
+// DeleteChanges mocks base method.
+func (m *MockSyncSource) DeleteChanges(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChanges", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChanges indicates an expected call of DeleteChanges.
+func (mr *MockSyncSourceMockRecorder) DeleteChanges(ctx, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChanges", reflect.TypeOf((*MockSyncSource)(nil).DeleteChanges), ctx, before, limit)
+}
+
+// GetAllLikes mocks base method.
+func (m *MockSyncSource) GetAllLikes(ctx context.Context, userId uuid.UUID) ([]*entity.SyncLikeDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllLikes", ctx, userId)
+	ret0, _ := ret[0].([]*entity.SyncLikeDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllLikes indicates an expected call of GetAllLikes.
+func (mr *MockSyncSourceMockRecorder) GetAllLikes(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllLikes", reflect.TypeOf((*MockSyncSource)(nil).GetAllLikes), ctx, userId)
+}
+
+// GetAllPlaylists mocks base method.
+func (m *MockSyncSource) GetAllPlaylists(ctx context.Context, userId uuid.UUID) ([]*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPlaylists", ctx, userId)
+	ret0, _ := ret[0].([]*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPlaylists indicates an expected call of GetAllPlaylists.
+func (mr *MockSyncSourceMockRecorder) GetAllPlaylists(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPlaylists", reflect.TypeOf((*MockSyncSource)(nil).GetAllPlaylists), ctx, userId)
+}
+
+// GetChanges mocks base method.
+func (m *MockSyncSource) GetChanges(ctx context.Context, userId uuid.UUID, cursor *entity.SyncCursor, xmin uint64, limit int) ([]*entity.SyncChangeDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", ctx, userId, cursor, xmin, limit)
+	ret0, _ := ret[0].([]*entity.SyncChangeDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockSyncSourceMockRecorder) GetChanges(ctx, userId, cursor, xmin, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockSyncSource)(nil).GetChanges), ctx, userId, cursor, xmin, limit)
+}
+
+// GetLikes mocks base method.
+func (m *MockSyncSource) GetLikes(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.SyncLikeDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikes", ctx, userId, ids)
+	ret0, _ := ret[0].([]*entity.SyncLikeDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikes indicates an expected call of GetLikes.
+func (mr *MockSyncSourceMockRecorder) GetLikes(ctx, userId, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikes", reflect.TypeOf((*MockSyncSource)(nil).GetLikes), ctx, userId, ids)
+}
+
+// GetPlaylistTracks mocks base method.
+func (m *MockSyncSource) GetPlaylistTracks(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.SyncPlaylistTrackDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylistTracks", ctx, userId, ids)
+	ret0, _ := ret[0].([]*entity.SyncPlaylistTrackDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylistTracks indicates an expected call of GetPlaylistTracks.
+func (mr *MockSyncSourceMockRecorder) GetPlaylistTracks(ctx, userId, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylistTracks", reflect.TypeOf((*MockSyncSource)(nil).GetPlaylistTracks), ctx, userId, ids)
+}
+
+// GetPlaylists mocks base method.
+func (m *MockSyncSource) GetPlaylists(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylists", ctx, userId, ids)
+	ret0, _ := ret[0].([]*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylists indicates an expected call of GetPlaylists.
+func (mr *MockSyncSourceMockRecorder) GetPlaylists(ctx, userId, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylists", reflect.TypeOf((*MockSyncSource)(nil).GetPlaylists), ctx, userId, ids)
+}
+
+// GetTakenDown mocks base method.
+func (m *MockSyncSource) GetTakenDown(ctx context.Context, from, to time.Time) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTakenDown", ctx, from, to)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTakenDown indicates an expected call of GetTakenDown.
+func (mr *MockSyncSourceMockRecorder) GetTakenDown(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTakenDown", reflect.TypeOf((*MockSyncSource)(nil).GetTakenDown), ctx, from, to)
+}
+
+// GetTrackPage mocks base method.
+func (m *MockSyncSource) GetTrackPage(ctx context.Context, userId, after uuid.UUID, limit int) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrackPage", ctx, userId, after, limit)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrackPage indicates an expected call of GetTrackPage.
+func (mr *MockSyncSourceMockRecorder) GetTrackPage(ctx, userId, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrackPage", reflect.TypeOf((*MockSyncSource)(nil).GetTrackPage), ctx, userId, after, limit)
+}
+
+// GetTracks mocks base method.
+func (m *MockSyncSource) GetTracks(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracks", ctx, userId, ids)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTracks indicates an expected call of GetTracks.
+func (mr *MockSyncSourceMockRecorder) GetTracks(ctx, userId, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracks", reflect.TypeOf((*MockSyncSource)(nil).GetTracks), ctx, userId, ids)
+}
+
+// GetXmin mocks base method.
+func (m *MockSyncSource) GetXmin(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXmin", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetXmin indicates an expected call of GetXmin.
+func (mr *MockSyncSourceMockRecorder) GetXmin(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXmin", reflect.TypeOf((*MockSyncSource)(nil).GetXmin), ctx)
+}
+
+// MockDuplicateSource is a mock of DuplicateSource interface.
+type MockDuplicateSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockDuplicateSourceMockRecorder
+}
+
+// MockDuplicateSourceMockRecorder is the mock recorder for MockDuplicateSource.
+type MockDuplicateSourceMockRecorder struct {
+	mock *MockDuplicateSource
+}
+
+// NewMockDuplicateSource creates a new mock instance.
+func NewMockDuplicateSource(ctrl *gomock.Controller) *MockDuplicateSource {
+	mock := &MockDuplicateSource{ctrl: ctrl}
+	mock.recorder = &MockDuplicateSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDuplicateSource) EXPECT() *MockDuplicateSourceMockRecorder {
+	return m.recorder
+}
+
+// GetByMusic mocks base method.
+func (m *MockDuplicateSource) GetByMusic(ctx context.Context, musicId uuid.UUID) ([]*entity.MusicDuplicateDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMusic", ctx, musicId)
+	ret0, _ := ret[0].([]*entity.MusicDuplicateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMusic indicates an expected call of GetByMusic.
+func (mr *MockDuplicateSourceMockRecorder) GetByMusic(ctx, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMusic", reflect.TypeOf((*MockDuplicateSource)(nil).GetByMusic), ctx, musicId)
+}
+
+// GetCandidates mocks base method.
+func (m *MockDuplicateSource) GetCandidates(ctx context.Context, musicId uuid.UUID, contentHash string, tolerance, limit int) ([]*entity.DuplicateCandidateDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCandidates", ctx, musicId, contentHash, tolerance, limit)
+	ret0, _ := ret[0].([]*entity.DuplicateCandidateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandidates indicates an expected call of GetCandidates.
+func (mr *MockDuplicateSourceMockRecorder) GetCandidates(ctx, musicId, contentHash, tolerance, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidates", reflect.TypeOf((*MockDuplicateSource)(nil).GetCandidates), ctx, musicId, contentHash, tolerance, limit)
+}
+
+// Merge mocks base method.
+func (m *MockDuplicateSource) Merge(ctx context.Context, musicId, intoId uuid.UUID, now time.Time) (*entity.MusicMergeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, musicId, intoId, now)
+	ret0, _ := ret[0].(*entity.MusicMergeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockDuplicateSourceMockRecorder) Merge(ctx, musicId, intoId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockDuplicateSource)(nil).Merge), ctx, musicId, intoId, now)
+}
+
+// SaveDuplicates mocks base method.
+func (m *MockDuplicateSource) SaveDuplicates(ctx context.Context, duplicates []*entity.MusicDuplicateDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDuplicates", ctx, duplicates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDuplicates indicates an expected call of SaveDuplicates.
+func (mr *MockDuplicateSourceMockRecorder) SaveDuplicates(ctx, duplicates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDuplicates", reflect.TypeOf((*MockDuplicateSource)(nil).SaveDuplicates), ctx, duplicates)
+}
+
+// SaveFingerprint mocks base method.
+func (m *MockDuplicateSource) SaveFingerprint(ctx context.Context, fingerprint *entity.MusicFingerprintDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFingerprint", ctx, fingerprint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFingerprint indicates an expected call of SaveFingerprint.
+func (mr *MockDuplicateSourceMockRecorder) SaveFingerprint(ctx, fingerprint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFingerprint", reflect.TypeOf((*MockDuplicateSource)(nil).SaveFingerprint), ctx, fingerprint)
+}
+
+// MockRefreshTokenSource is a mock of RefreshTokenSource interface.
+type MockRefreshTokenSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenSourceMockRecorder
+}
+
+// MockRefreshTokenSourceMockRecorder is the mock recorder for MockRefreshTokenSource.
+type MockRefreshTokenSourceMockRecorder struct {
+	mock *MockRefreshTokenSource
+}
+
+// NewMockRefreshTokenSource creates a new mock instance.
+func NewMockRefreshTokenSource(ctrl *gomock.Controller) *MockRefreshTokenSource {
+	mock := &MockRefreshTokenSource{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenSource) EXPECT() *MockRefreshTokenSourceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefreshTokenSource) Create(ctx context.Context, session *entity.SessionDB, token *entity.RefreshTokenDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefreshTokenSourceMockRecorder) Create(ctx, session, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefreshTokenSource)(nil).Create), ctx, session, token)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockRefreshTokenSource) DeleteExpired(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockRefreshTokenSourceMockRecorder) DeleteExpired(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockRefreshTokenSource)(nil).DeleteExpired), ctx, now)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockRefreshTokenSource) RevokeFamily(ctx context.Context, tokenHash string, now time.Time) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, tokenHash, now)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockRefreshTokenSourceMockRecorder) RevokeFamily(ctx, tokenHash, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockRefreshTokenSource)(nil).RevokeFamily), ctx, tokenHash, now)
+}
+
+// RevokeUser mocks base method.
+func (m *MockRefreshTokenSource) RevokeUser(ctx context.Context, userId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", ctx, userId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MockRefreshTokenSourceMockRecorder) RevokeUser(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MockRefreshTokenSource)(nil).RevokeUser), ctx, userId, now)
+}
+
+// Rotate mocks base method.
+func (m *MockRefreshTokenSource) Rotate(ctx context.Context, tokenHash string, next *entity.RefreshTokenDB, device *entity.SessionDevice, now time.Time) (*entity.RefreshTokenDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, tokenHash, next, device, now)
+	ret0, _ := ret[0].(*entity.RefreshTokenDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockRefreshTokenSourceMockRecorder) Rotate(ctx, tokenHash, next, device, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockRefreshTokenSource)(nil).Rotate), ctx, tokenHash, next, device, now)
+}
+
+// MockSessionSource is a mock of SessionSource interface.
+type MockSessionSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionSourceMockRecorder
+}
+
+// MockSessionSourceMockRecorder is the mock recorder for MockSessionSource.
+type MockSessionSourceMockRecorder struct {
+	mock *MockSessionSource
+}
+
+// NewMockSessionSource creates a new mock instance.
+func NewMockSessionSource(ctrl *gomock.Controller) *MockSessionSource {
+	mock := &MockSessionSource{ctrl: ctrl}
+	mock.recorder = &MockSessionSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionSource) EXPECT() *MockSessionSourceMockRecorder {
+	return m.recorder
+}
+
+// GetByUser mocks base method.
+func (m *MockSessionSource) GetByUser(ctx context.Context, userId uuid.UUID, now time.Time) ([]*entity.SessionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, now)
+	ret0, _ := ret[0].([]*entity.SessionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockSessionSourceMockRecorder) GetByUser(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockSessionSource)(nil).GetByUser), ctx, userId, now)
+}
+
+// IsActive mocks base method.
+func (m *MockSessionSource) IsActive(ctx context.Context, sessionId uuid.UUID, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsActive", ctx, sessionId, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsActive indicates an expected call of IsActive.
+func (mr *MockSessionSourceMockRecorder) IsActive(ctx, sessionId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsActive", reflect.TypeOf((*MockSessionSource)(nil).IsActive), ctx, sessionId, now)
+}
+
+// Revoke mocks base method.
+func (m *MockSessionSource) Revoke(ctx context.Context, userId, sessionId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userId, sessionId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionSourceMockRecorder) Revoke(ctx, userId, sessionId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSessionSource)(nil).Revoke), ctx, userId, sessionId, now)
+}
+
+// RevokeUser mocks base method.
+func (m *MockSessionSource) RevokeUser(ctx context.Context, userId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", ctx, userId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MockSessionSourceMockRecorder) RevokeUser(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MockSessionSource)(nil).RevokeUser), ctx, userId, now)
+}
+
+// MockSigningKeySource is a mock of SigningKeySource interface.
+type MockSigningKeySource struct {
+	ctrl     *gomock.Controller
+	recorder *MockSigningKeySourceMockRecorder
+}
+
+// MockSigningKeySourceMockRecorder is the mock recorder for MockSigningKeySource.
+type MockSigningKeySourceMockRecorder struct {
+	mock *MockSigningKeySource
+}
+
+// NewMockSigningKeySource creates a new mock instance.
+func NewMockSigningKeySource(ctrl *gomock.Controller) *MockSigningKeySource {
+	mock := &MockSigningKeySource{ctrl: ctrl}
+	mock.recorder = &MockSigningKeySourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigningKeySource) EXPECT() *MockSigningKeySourceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSigningKeySource) Create(ctx context.Context, key *entity.SigningKeyDB, after, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key, after, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSigningKeySourceMockRecorder) Create(ctx, key, after, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSigningKeySource)(nil).Create), ctx, key, after, now)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockSigningKeySource) DeleteExpired(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockSigningKeySourceMockRecorder) DeleteExpired(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockSigningKeySource)(nil).DeleteExpired), ctx, now)
+}
+
+// GetValid mocks base method.
+func (m *MockSigningKeySource) GetValid(ctx context.Context, now time.Time) ([]*entity.SigningKeyDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValid", ctx, now)
+	ret0, _ := ret[0].([]*entity.SigningKeyDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValid indicates an expected call of GetValid.
+func (mr *MockSigningKeySourceMockRecorder) GetValid(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValid", reflect.TypeOf((*MockSigningKeySource)(nil).GetValid), ctx, now)
+}
+
+// MockIdentitySource is a mock of IdentitySource interface.
+type MockIdentitySource struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentitySourceMockRecorder
+}
+
+// MockIdentitySourceMockRecorder is the mock recorder for MockIdentitySource.
+type MockIdentitySourceMockRecorder struct {
+	mock *MockIdentitySource
+}
+
+// NewMockIdentitySource creates a new mock instance.
+func NewMockIdentitySource(ctrl *gomock.Controller) *MockIdentitySource {
+	mock := &MockIdentitySource{ctrl: ctrl}
+	mock.recorder = &MockIdentitySourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentitySource) EXPECT() *MockIdentitySourceMockRecorder {
+	return m.recorder
+}
+
+// ConsumeLogin mocks base method.
+func (m *MockIdentitySource) ConsumeLogin(ctx context.Context, state string, now time.Time) (*entity.OIDCLoginDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeLogin", ctx, state, now)
+	ret0, _ := ret[0].(*entity.OIDCLoginDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeLogin indicates an expected call of ConsumeLogin.
+func (mr *MockIdentitySourceMockRecorder) ConsumeLogin(ctx, state, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeLogin", reflect.TypeOf((*MockIdentitySource)(nil).ConsumeLogin), ctx, state, now)
+}
+
+// CreateLogin mocks base method.
+func (m *MockIdentitySource) CreateLogin(ctx context.Context, login *entity.OIDCLoginDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLogin", ctx, login)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLogin indicates an expected call of CreateLogin.
+func (mr *MockIdentitySourceMockRecorder) CreateLogin(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLogin", reflect.TypeOf((*MockIdentitySource)(nil).CreateLogin), ctx, login)
+}
+
+// DeleteExpiredLogins mocks base method.
+func (m *MockIdentitySource) DeleteExpiredLogins(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredLogins", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredLogins indicates an expected call of DeleteExpiredLogins.
+func (mr *MockIdentitySourceMockRecorder) DeleteExpiredLogins(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredLogins", reflect.TypeOf((*MockIdentitySource)(nil).DeleteExpiredLogins), ctx, now)
+}
+
+// GetByUser mocks base method.
+func (m *MockIdentitySource) GetByUser(ctx context.Context, userId uuid.UUID) ([]*entity.UserIdentityDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId)
+	ret0, _ := ret[0].([]*entity.UserIdentityDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockIdentitySourceMockRecorder) GetByUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockIdentitySource)(nil).GetByUser), ctx, userId)
+}
+
+// Login mocks base method.
+func (m *MockIdentitySource) Login(ctx context.Context, login *entity.IdentityLogin, now time.Time) (*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, login, now)
+	ret0, _ := ret[0].(*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockIdentitySourceMockRecorder) Login(ctx, login, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIdentitySource)(nil).Login), ctx, login, now)
+}
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Прослушивания пользователя $1 за период [$2, $3). Прослушивание — событие start. Время прослушивания —
+// наибольшая позиция из событий этого трека до следующего прослушивания, для стрима без позиций — длительность трека.
+// Время не превышает длительность трека.
+const listenedPlaysCTE = "WITH starts AS (" +
+	"SELECT music_id, source, played_at, LEAD(played_at) OVER (ORDER BY played_at) AS next_at " +
+	"FROM play_events WHERE user_id = $1 AND event_type = 'start' AND played_at >= $2 AND played_at < $3" +
+	"), listened AS (" +
+	"SELECT s.music_id, s.played_at, LEAST(COALESCE(" +
+	"(SELECT MAX(e.position_sec) FROM play_events e WHERE e.user_id = $1 AND e.music_id = s.music_id AND e.event_type <> 'start' " +
+	"AND e.played_at >= s.played_at AND (s.next_at IS NULL OR e.played_at < s.next_at)), " +
+	"CASE WHEN s.source = 'stream' THEN EXTRACT(EPOCH FROM m.duration)::bigint END, 0), " +
+	"EXTRACT(EPOCH FROM m.duration)::bigint) AS seconds " +
+	"FROM starts s JOIN music m ON m.id = s.music_id" +
+	") "
+
+var selectListeningTotalsQuery = listenedPlaysCTE +
+	"SELECT COUNT(*) AS plays, COALESCE(SUM(seconds), 0)::bigint AS seconds, COUNT(DISTINCT music_id) AS tracks, " +
+	"COUNT(DISTINCT (played_at AT TIME ZONE 'UTC')::date) AS active_days FROM listened"
+
+// Треки из корзины в топ не попадают, но учитываются в общих показателях
+var selectTopTracksQuery = listenedPlaysCTE +
+	"SELECT m.*, t.plays, t.seconds FROM (" +
+	"SELECT music_id, COUNT(*) AS plays, SUM(seconds)::bigint AS seconds FROM listened GROUP BY music_id" +
+	") t JOIN music m ON m.id = t.music_id WHERE m.deleted_at IS NULL " +
+	"ORDER BY t.plays DESC, t.seconds DESC, m.id LIMIT $4"
+
+var selectTopArtistsQuery = listenedPlaysCTE +
+	"SELECT a.id AS artist_id, a.name, COUNT(*) AS plays, SUM(l.seconds)::bigint AS seconds " +
+	"FROM listened l JOIN music m ON m.id = l.music_id JOIN artists a ON a.id = m.artist_id " +
+	"GROUP BY a.id ORDER BY plays DESC, seconds DESC, a.id LIMIT $4"
+
+var selectListeningHeatmapQuery = listenedPlaysCTE +
+	"SELECT EXTRACT(ISODOW FROM played_at AT TIME ZONE 'UTC')::int AS weekday, EXTRACT(HOUR FROM played_at AT TIME ZONE 'UTC')::int AS hour, " +
+	"COUNT(*) AS plays FROM listened GROUP BY 1, 2 ORDER BY 1, 2"
+
+// Дни одной серии дают одинаковую разность между датой и ее номером по порядку
+var selectLongestStreakQuery = listenedPlaysCTE +
+	"SELECT MIN(day) AS start_day, MAX(day) AS end_day, COUNT(*) AS days FROM (" +
+	"SELECT day, day - (ROW_NUMBER() OVER (ORDER BY day))::int AS grp FROM (" +
+	"SELECT DISTINCT (played_at AT TIME ZONE 'UTC')::date AS day FROM listened" +
+	") d) g GROUP BY grp ORDER BY days DESC, start_day DESC LIMIT 1"
+
+// Пользователи, слушавшие музыку в году, для которых итоги года еще не рассчитаны, по возрастанию id после $4.
+// Пользователи, время повтора которых после неудачной попытки еще не наступило, пропускаются.
+const selectUsersWithoutReviewQuery = "SELECT DISTINCT e.user_id FROM play_events e JOIN users u ON u.id = e.user_id " +
+	"WHERE e.event_type = 'start' AND e.played_at >= $2 AND e.played_at < $3 AND u.deleted_at IS NULL AND e.user_id > $4 " +
+	"AND NOT EXISTS (SELECT 1 FROM year_reviews r WHERE r.user_id = e.user_id AND r.year = $1) " +
+	"AND NOT EXISTS (SELECT 1 FROM year_review_failures f WHERE f.user_id = e.user_id AND f.year = $1 AND f.retry_at > $5) " +
+	"ORDER BY e.user_id LIMIT $6"
+
+// Задержка повтора удваивается с каждой попыткой: $5 секунд после первой, не больше $6 секунд
+const recordReviewFailureQuery = "INSERT INTO year_review_failures AS f (user_id, year, attempts, last_error, failed_at, retry_at) " +
+	"VALUES ($1, $2, 1, $3, $4, $4 + make_interval(secs => $5)) " +
+	"ON CONFLICT (user_id, year) DO UPDATE SET attempts = f.attempts + 1, last_error = EXCLUDED.last_error, " +
+	"failed_at = EXCLUDED.failed_at, retry_at = EXCLUDED.failed_at + make_interval(secs => LEAST($5 * power(2, f.attempts), $6))"
+
+type statsSource struct {
+	db *sqlx.DB
+}
+
+func NewStatsSource(source *source) *statsSource {
+	return &statsSource{
+		db: source.db,
+	}
+}
+
+// GetStats считает статистику прослушиваний за период. Все показатели читаются из одного снимка данных.
+func (s *statsSource) GetStats(ctx context.Context, userId uuid.UUID, filter *entity.StatsFilter) (*entity.ListeningStats, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := s.db.BeginTxx(dbCtx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stats := &entity.ListeningStats{From: filter.From, To: filter.To}
+
+	err = tx.GetContext(dbCtx, &stats.Totals, selectListeningTotalsQuery, userId, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("can't get totals: %w", err)
+	}
+
+	err = tx.SelectContext(dbCtx, &stats.TopTracks, selectTopTracksQuery, userId, filter.From, filter.To, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't get top tracks: %w", err)
+	}
+
+	err = tx.SelectContext(dbCtx, &stats.TopArtists, selectTopArtistsQuery, userId, filter.From, filter.To, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't get top artists: %w", err)
+	}
+
+	err = tx.SelectContext(dbCtx, &stats.Heatmap, selectListeningHeatmapQuery, userId, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("can't get heatmap: %w", err)
+	}
+
+	var streak entity.ListeningStreak
+	err = tx.GetContext(dbCtx, &streak, selectLongestStreakQuery, userId, filter.From, filter.To)
+	switch {
+	case err == nil:
+		stats.LongestStreak = &streak
+	case err != sql.ErrNoRows:
+		return nil, fmt.Errorf("can't get streak: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetUsersWithoutReview возвращает страницу пользователей без итогов года по возрастанию id после afterId.
+// Для первой страницы передается нулевой id.
+func (s *statsSource) GetUsersWithoutReview(ctx context.Context, year int, afterId uuid.UUID, now time.Time, limit int) ([]uuid.UUID, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	period := entity.YearStatsFilter(year, 0)
+
+	var data []uuid.UUID
+	err := s.db.SelectContext(dbCtx, &data, selectUsersWithoutReviewQuery, year, period.From, period.To, afterId, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return data, nil
+}
+
+// SaveReview сохраняет итоги года. Уже рассчитанные итоги не перезаписываются.
+func (s *statsSource) SaveReview(ctx context.Context, review *entity.YearReviewDB) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	report, err := json.Marshal(review.Stats)
+	if err != nil {
+		return fmt.Errorf("can't marshal report: %w", err)
+	}
+
+	_, err = s.db.ExecContext(dbCtx,
+		"INSERT INTO year_reviews (user_id, year, report, generated_at) VALUES ($1, $2, $3, $4) ON CONFLICT (user_id, year) DO NOTHING",
+		review.UserID, review.Year, report, review.GeneratedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// RecordReviewFailure сохраняет неудачную попытку расчета итогов года и назначает время следующей попытки
+func (s *statsSource) RecordReviewFailure(ctx context.Context, failure *entity.YearReviewFailure) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	_, err := s.db.ExecContext(dbCtx, recordReviewFailureQuery,
+		failure.UserID, failure.Year, failure.Error, failure.FailedAt,
+		entity.YearReviewRetryDelay.Seconds(), entity.MaxYearReviewRetryDelay.Seconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (s *statsSource) GetReview(ctx context.Context, userId uuid.UUID, year int) (*entity.YearReviewDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var (
+		report      []byte
+		generatedAt time.Time
+	)
+	err := s.db.QueryRowxContext(dbCtx,
+		"SELECT report, generated_at FROM year_reviews WHERE user_id = $1 AND year = $2", userId, year,
+	).Scan(&report, &generatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("can't scan year review: %w", err)
+	}
+
+	var stats entity.ListeningStats
+	if err := json.Unmarshal(report, &stats); err != nil {
+		return nil, fmt.Errorf("can't unmarshal report: %w", err)
+	}
+
+	return &entity.YearReviewDB{
+		UserID:      userId,
+		Year:        year,
+		Stats:       &stats,
+		GeneratedAt: generatedAt,
+	}, nil
+}
@@ -0,0 +1,217 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Треки, доступные пользователю $1: опубликованные, не снятые с публикации и не скрытые фильтром контента
+var syncAvailableMusicCondition = availableMusic("m") + " " +
+	"AND NOT (m.explicit AND EXISTS (SELECT 1 FROM users u WHERE u.id = $1 AND u.hide_explicit)) "
+
+// Изменения для пользователя $1 после позиции ($2, $3) из транзакций, завершенных до $4.
+// Транзакции с номером меньше $4 на момент запроса уже зафиксированы или отменены,
+// поэтому новые записи с позицией до выданной клиенту появиться не могут.
+const selectSyncChangesQuery = "SELECT id, txid::text AS txid, kind, entity_id FROM sync_changes " +
+	"WHERE (user_id IS NULL OR user_id = $1) AND (txid, id) > ($2::xid8, $3) AND txid < $4::xid8 " +
+	"ORDER BY txid, id LIMIT $5"
+
+type syncSource struct {
+	db *sqlx.DB
+}
+
+func NewSyncSource(source *source) *syncSource {
+	return &syncSource{
+		db: source.db,
+	}
+}
+
+// GetXmin возвращает номер самой старой незавершенной транзакции. Все транзакции с меньшими номерами завершены.
+func (s *syncSource) GetXmin(ctx context.Context) (uint64, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var xmin uint64
+	err := s.db.GetContext(dbCtx, &xmin, "SELECT pg_snapshot_xmin(pg_current_snapshot())::text")
+	if err != nil {
+		return 0, fmt.Errorf("can't get snapshot xmin: %w", err)
+	}
+
+	return xmin, nil
+}
+
+// GetChanges возвращает до limit изменений для пользователя после позиции cursor из транзакций с номером меньше xmin
+func (s *syncSource) GetChanges(ctx context.Context, userId uuid.UUID, cursor *entity.SyncCursor, xmin uint64, limit int) ([]*entity.SyncChangeDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.SyncChangeDB
+	err := s.db.SelectContext(dbCtx, &data, selectSyncChangesQuery, userId, cursor.TxID, cursor.ID, xmin, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't select sync changes: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetTracks возвращает доступные пользователю треки из ids. Отсутствующие в ответе треки удалены или недоступны.
+func (s *syncSource) GetTracks(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.MusicDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.MusicDB
+	err := s.db.SelectContext(dbCtx, &data,
+		"SELECT m.* FROM music m WHERE m.id = ANY($2::uuid[]) AND "+syncAvailableMusicCondition+"ORDER BY m.id",
+		userId, pq.Array(uuidStrings(ids)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select tracks: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetTrackPage возвращает до limit доступных пользователю треков по возрастанию id после трека after
+func (s *syncSource) GetTrackPage(ctx context.Context, userId uuid.UUID, after uuid.UUID, limit int) ([]*entity.MusicDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.MusicDB
+	err := s.db.SelectContext(dbCtx, &data,
+		"SELECT m.* FROM music m WHERE m.id > $2 AND "+syncAvailableMusicCondition+"ORDER BY m.id LIMIT $3",
+		userId, after, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select tracks: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetTakenDown возвращает опубликованные треки, снятые с публикации в промежутке (from, to].
+// Снятие наступает по времени без изменения строки трека, поэтому в журнал оно не попадает.
+func (s *syncSource) GetTakenDown(ctx context.Context, from time.Time, to time.Time) ([]uuid.UUID, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []uuid.UUID
+	err := s.db.SelectContext(dbCtx, &data,
+		"SELECT id FROM music WHERE takedown_at > $1 AND takedown_at <= $2 AND deleted_at IS NULL AND published_at IS NOT NULL ORDER BY id",
+		from, to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select taken down tracks: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetLikes возвращает лайки пользователя на треки из ids
+func (s *syncSource) GetLikes(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.SyncLikeDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.SyncLikeDB
+	err := s.db.SelectContext(dbCtx, &data,
+		"SELECT music_id, created_at FROM user_music WHERE user_id = $1 AND music_id = ANY($2::uuid[]) ORDER BY created_at, music_id",
+		userId, pq.Array(uuidStrings(ids)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select likes: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *syncSource) GetAllLikes(ctx context.Context, userId uuid.UUID) ([]*entity.SyncLikeDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.SyncLikeDB
+	err := s.db.SelectContext(dbCtx, &data,
+		"SELECT music_id, created_at FROM user_music WHERE user_id = $1 ORDER BY created_at, music_id",
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select likes: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetPlaylists возвращает плейлисты пользователя из ids
+func (s *syncSource) GetPlaylists(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.PlaylistDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.PlaylistDB
+	err := s.db.SelectContext(dbCtx, &data,
+		"SELECT * FROM playlists WHERE user_id = $1 AND id = ANY($2::uuid[]) ORDER BY created_at, id",
+		userId, pq.Array(uuidStrings(ids)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select playlists: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *syncSource) GetAllPlaylists(ctx context.Context, userId uuid.UUID) ([]*entity.PlaylistDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.PlaylistDB
+	err := s.db.SelectContext(dbCtx, &data,
+		"SELECT * FROM playlists WHERE user_id = $1 ORDER BY created_at, id",
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select playlists: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetPlaylistTracks возвращает доступные пользователю треки плейлистов из ids в порядке плейлистов
+func (s *syncSource) GetPlaylistTracks(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.SyncPlaylistTrackDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var data []*entity.SyncPlaylistTrackDB
+	err := s.db.SelectContext(dbCtx, &data,
+		"SELECT pm.playlist_id, pm.music_id FROM playlist_music pm JOIN music m ON m.id = pm.music_id "+
+			"WHERE pm.playlist_id = ANY($2::uuid[]) AND "+syncAvailableMusicCondition+"ORDER BY pm.playlist_id, pm.position",
+		userId, pq.Array(uuidStrings(ids)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't select playlist tracks: %w", err)
+	}
+
+	return data, nil
+}
+
+// DeleteChanges удаляет до limit записей журнала, сделанных раньше before, и возвращает их количество
+func (s *syncSource) DeleteChanges(ctx context.Context, before time.Time, limit int) (int64, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := s.db.ExecContext(dbCtx,
+		"DELETE FROM sync_changes WHERE id IN (SELECT id FROM sync_changes WHERE changed_at < $1 ORDER BY changed_at LIMIT $2)",
+		before, limit,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't get affected rows: %w", err)
+	}
+
+	return deleted, nil
+}
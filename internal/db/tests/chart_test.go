@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_chartSource_Snapshot(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+
+	cfg := &entity.ChartConfig{Size: 100, PlayWeight: 1, LikeWeight: 5}
+	week := time.Date(2023, time.March, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setup   func(f fields)
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "success: chart saved",
+			setup: func(f fields) {
+				f.db.ExpectExec("INSERT INTO chart_entries").
+					WithArgs(week, week, week.AddDate(0, 0, 7), cfg.PlayWeight, cfg.LikeWeight, cfg.Size).
+					WillReturnResult(sqlmock.NewResult(0, 42))
+			},
+			want: 42,
+		},
+		{
+			name: "success: chart already saved",
+			setup: func(f fields) {
+				f.db.ExpectExec("INSERT INTO chart_entries").
+					WithArgs(week, week, week.AddDate(0, 0, 7), cfg.PlayWeight, cfg.LikeWeight, cfg.Size).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			want: 0,
+		},
+		{
+			name: "error: exec failed",
+			setup: func(f fields) {
+				f.db.ExpectExec("INSERT INTO chart_entries").WillReturnError(fmt.Errorf("can't exec query"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			chartSource := db.NewChartSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(f)
+
+			got, err := chartSource.Snapshot(context.Background(), cfg, week)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_chartSource_LatestWeek(t *testing.T) {
+	week := time.Date(2023, time.March, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name: "success",
+			rows: sqlmock.NewRows([]string{"max"}).AddRow(week),
+			want: week,
+		},
+		{
+			name:    "error: no charts",
+			rows:    sqlmock.NewRows([]string{"max"}).AddRow(nil),
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectQuery("SELECT MAX(week) FROM chart_entries").WillReturnRows(tt.rows)
+
+			chartSource := db.NewChartSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := chartSource.LatestWeek(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_chartSource_GetByWeek(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	week := time.Date(2023, time.March, 20, 0, 0, 0, 0, time.UTC)
+	musicId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	rows := sqlmock.NewRows([]string{
+		"id", "name", "release_date", "file_name", "size", "duration",
+		"week", "position", "plays", "likes", "score", "previous_position", "peak_position", "weeks_on_chart",
+	}).
+		AddRow(musicId, "Song1", week, "Song1.mp3", uint64(500), "2:47", week, 1, int64(10), int64(2), float64(20), 3, 1, 4).
+		AddRow(uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"), "Song2", week, "Song2.mp3", uint64(500), "3:01", week, 2, int64(5), int64(0), float64(5), nil, 2, 1)
+	mock.ExpectQuery("FROM chart_entries ce JOIN music m ON m.id = ce.music_id").
+		WithArgs(week).
+		WillReturnRows(rows)
+
+	chartSource := db.NewChartSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := chartSource.GetByWeek(context.Background(), week)
+	if assert.NoError(t, err) && assert.Len(t, got, 2) {
+		assert.Equal(t, musicId, got[0].Id)
+		assert.Equal(t, 1, got[0].Position)
+		assert.Equal(t, 3, *got[0].PreviousPosition)
+		assert.Equal(t, 4, got[0].WeeksOnChart)
+		assert.Nil(t, got[1].PreviousPosition)
+		assert.Equal(t, entity.ChartMovementNew, got[1].Movement())
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_chartSource_GetByMusic(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	musicId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	mock.ExpectQuery("WHERE m.deleted_at IS NULL AND m.published_at IS NOT NULL .* AND ce.music_id = \\$1 ORDER BY ce.week DESC").
+		WithArgs(musicId).
+		WillReturnError(fmt.Errorf("can't exec query"))
+
+	chartSource := db.NewChartSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	_, err = chartSource.GetByMusic(context.Background(), musicId)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
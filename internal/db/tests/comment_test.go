@@ -0,0 +1,481 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var commentColumns = []string{
+	"id", "music_id", "playlist_id", "user_id", "username", "parent_id", "body", "status", "reply_count",
+	"created_at", "edited_at", "moderated_by", "moderated_at",
+}
+
+func Test_commentSource_Create(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	playlistId := uuid.MustParse("5b7c3a1e-9d2f-4e8b-a6c4-1f0e2d3c4b5a")
+	parentId := uuid.MustParse("8b1c5c1e-2f0a-4d61-9c55-4b1e3f1a2d7e")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		comment *entity.CommentDB
+		setup   func(f fields)
+		wantErr error
+	}{
+		{
+			name: "success: top-level comment",
+			comment: &entity.CommentDB{
+				ID: commentId, MusicID: &musicId, UserID: userId, Body: "nice", Status: entity.CommentStatusVisible, CreatedAt: now,
+			},
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				expectCommentRateLock(f.db, userId, since, 2)
+				f.db.ExpectExec("INSERT INTO comments").
+					WithArgs(commentId, musicId, nil, userId, nil, "nice", entity.CommentStatusVisible, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				f.db.ExpectCommit()
+			},
+		},
+		{
+			name: "success: reply updates parent reply count",
+			comment: &entity.CommentDB{
+				ID: commentId, MusicID: &musicId, UserID: userId, ParentID: &parentId, Body: "agree", Status: entity.CommentStatusVisible, CreatedAt: now,
+			},
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				expectCommentRateLock(f.db, userId, since, 2)
+				f.db.ExpectExec("INSERT INTO comments").
+					WithArgs(commentId, musicId, nil, userId, &parentId, "agree", entity.CommentStatusVisible, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				f.db.ExpectExec("UPDATE comments SET reply_count").
+					WithArgs(parentId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				f.db.ExpectCommit()
+			},
+		},
+		{
+			name: "success: playlist comment",
+			comment: &entity.CommentDB{
+				ID: commentId, PlaylistID: &playlistId, UserID: userId, Body: "nice", Status: entity.CommentStatusVisible, CreatedAt: now,
+			},
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				expectCommentRateLock(f.db, userId, since, 2)
+				// Комментарий к приватному плейлисту может оставить только его владелец
+				f.db.ExpectExec("INSERT INTO comments .* OR EXISTS \\(SELECT 1 FROM playlists WHERE id = \\$3 AND \\(is_public OR user_id = \\$4\\)\\)").
+					WithArgs(commentId, nil, playlistId, userId, nil, "nice", entity.CommentStatusVisible, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				f.db.ExpectCommit()
+			},
+		},
+		{
+			name: "error: rate limit reached under lock",
+			comment: &entity.CommentDB{
+				ID: commentId, MusicID: &musicId, UserID: userId, Body: "nice", Status: entity.CommentStatusVisible, CreatedAt: now,
+			},
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				expectCommentRateLock(f.db, userId, since, 3)
+				f.db.ExpectRollback()
+			},
+			wantErr: entity.ErrCommentRateLimited,
+		},
+		{
+			name: "error: music not found",
+			comment: &entity.CommentDB{
+				ID: commentId, MusicID: &musicId, UserID: userId, Body: "nice", Status: entity.CommentStatusVisible, CreatedAt: now,
+			},
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				expectCommentRateLock(f.db, userId, since, 2)
+				f.db.ExpectExec("INSERT INTO comments").
+					WithArgs(commentId, musicId, nil, userId, nil, "nice", entity.CommentStatusVisible, now).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				f.db.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			commentSource := db.NewCommentSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(f)
+
+			err = commentSource.Create(context.Background(), tt.comment, 3, since)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// expectCommentRateLock ожидает блокировку автора и подсчет его комментариев за окно лимита
+func expectCommentRateLock(mock sqlmock.Sqlmock, userId uuid.UUID, since time.Time, count int) {
+	mock.ExpectExec("SELECT pg_advisory_xact_lock\\(\\$1, hashtext\\(\\$2::text\\)\\)").
+		WithArgs(sqlmock.AnyArg(), userId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM comments WHERE user_id = \\$1 AND created_at >= \\$2").
+		WithArgs(userId, since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+}
+
+func Test_commentSource_GetByMusic(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	cursorId := uuid.MustParse("8b1c5c1e-2f0a-4d61-9c55-4b1e3f1a2d7e")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	cursorTime := now.Add(time.Hour)
+
+	comment := &entity.CommentDB{
+		ID: commentId, MusicID: &musicId, UserID: userId, Username: "user", Body: "nice",
+		Status: entity.CommentStatusVisible, ReplyCount: 2, CreatedAt: now,
+	}
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(commentColumns).
+			AddRow(commentId, musicId, nil, userId, "user", nil, "nice", entity.CommentStatusVisible, 2, now, nil, nil, nil)
+	}
+
+	tests := []struct {
+		name    string
+		filter  *entity.CommentFilter
+		setup   func(f fields)
+		want    []*entity.CommentDB
+		wantErr error
+	}{
+		{
+			name:   "success: newest first page",
+			filter: &entity.CommentFilter{Sort: entity.CommentSortNewest, Limit: 20},
+			setup: func(f fields) {
+				// Комментарии пользователей в корзине не выдаются
+				f.db.ExpectQuery("JOIN users u ON u\\.id = c\\.user_id AND u\\.deleted_at IS NULL WHERE c\\.music_id = \\$1 .* "+
+					"ORDER BY c.created_at DESC, c.id DESC LIMIT \\$5").
+					WithArgs(musicId, false, nil, nil, 21).
+					WillReturnRows(rows())
+			},
+			want: []*entity.CommentDB{comment},
+		},
+		{
+			name: "success: top with cursor",
+			filter: &entity.CommentFilter{
+				Sort:          entity.CommentSortTop,
+				Cursor:        &entity.CommentCursor{CreatedAt: cursorTime, ID: cursorId, ReplyCount: 3},
+				Limit:         10,
+				IncludeHidden: true,
+			},
+			setup: func(f fields) {
+				f.db.ExpectQuery("ORDER BY c.reply_count DESC, c.created_at DESC, c.id DESC LIMIT \\$5").
+					WithArgs(musicId, true, &cursorTime, &cursorId, 11, 3).
+					WillReturnRows(rows())
+			},
+			want: []*entity.CommentDB{comment},
+		},
+		{
+			name:    "error: unknown sort",
+			filter:  &entity.CommentFilter{Sort: "oldest", Limit: 20},
+			setup:   func(f fields) {},
+			wantErr: entity.ErrUnknownCommentSort,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			commentSource := db.NewCommentSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(f)
+
+			got, err := commentSource.GetByMusic(context.Background(), musicId, tt.filter)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_commentSource_GetByPlaylist(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	playlistId := uuid.MustParse("5b7c3a1e-9d2f-4e8b-a6c4-1f0e2d3c4b5a")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectQuery("WHERE c\\.playlist_id = \\$1 AND c\\.parent_id IS NULL .* ORDER BY c.created_at DESC, c.id DESC LIMIT \\$5").
+		WithArgs(playlistId, false, nil, nil, 21).
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(commentId, nil, playlistId, userId, "user", nil, "nice", entity.CommentStatusVisible, 0, now, nil, nil, nil))
+
+	commentSource := db.NewCommentSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := commentSource.GetByPlaylist(context.Background(), playlistId, &entity.CommentFilter{Sort: entity.CommentSortNewest, Limit: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.CommentDB{{
+		ID: commentId, PlaylistID: &playlistId, UserID: userId, Username: "user", Body: "nice",
+		Status: entity.CommentStatusVisible, CreatedAt: now,
+	}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_commentSource_GetReplies(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	parentId := uuid.MustParse("8b1c5c1e-2f0a-4d61-9c55-4b1e3f1a2d7e")
+	replyId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	// Ответы пользователей в корзине не выдаются
+	mock.ExpectQuery("JOIN users u ON u\\.id = c\\.user_id AND u\\.deleted_at IS NULL WHERE c\\.parent_id = \\$1 .* ORDER BY c\\.created_at, c\\.id LIMIT \\$5").
+		WithArgs(parentId, false, nil, nil, 21).
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(replyId, musicId, nil, userId, "user", parentId, "reply", entity.CommentStatusVisible, 0, now, nil, nil, nil))
+
+	commentSource := db.NewCommentSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := commentSource.GetReplies(context.Background(), parentId, &entity.CommentFilter{Limit: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.CommentDB{{
+		ID: replyId, MusicID: &musicId, UserID: userId, Username: "user", ParentID: &parentId, Body: "reply",
+		Status: entity.CommentStatusVisible, CreatedAt: now,
+	}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_commentSource_MusicVisible(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	tests := []struct {
+		name            string
+		showUnpublished bool
+		query           string
+		visible         bool
+	}{
+		{
+			name:    "user: only published tracks",
+			query:   "SELECT EXISTS \\(SELECT 1 FROM music WHERE id = \\$1 AND deleted_at IS NULL AND published_at IS NOT NULL AND \\(takedown_at IS NULL OR takedown_at > now\\(\\)\\)\\)",
+			visible: false,
+		},
+		{
+			name:            "admin: tracks outside trash",
+			showUnpublished: true,
+			query:           "SELECT EXISTS \\(SELECT 1 FROM music WHERE id = \\$1 AND deleted_at IS NULL\\)",
+			visible:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectQuery(tt.query).
+				WithArgs(musicId).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.visible))
+
+			commentSource := db.NewCommentSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := commentSource.MusicVisible(context.Background(), musicId, tt.showUnpublished)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.visible, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_commentSource_SetStatus(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	parentId := uuid.MustParse("8b1c5c1e-2f0a-4d61-9c55-4b1e3f1a2d7e")
+	moderatorId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		status      string
+		moderatorId *uuid.UUID
+		setup       func(f fields)
+		wantErr     error
+	}{
+		{
+			name:        "success: hidden reply resolves reports",
+			status:      entity.CommentStatusHidden,
+			moderatorId: &moderatorId,
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery("UPDATE comments SET status").
+					WithArgs(commentId, entity.CommentStatusHidden, &moderatorId, now).
+					WillReturnRows(sqlmock.NewRows([]string{"parent_id"}).AddRow(parentId))
+				f.db.ExpectExec("UPDATE comments SET reply_count").
+					WithArgs(parentId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				f.db.ExpectExec("UPDATE comment_reports SET status").
+					WithArgs(commentId, entity.ReportStatusResolved, &moderatorId, now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				f.db.ExpectCommit()
+			},
+		},
+		{
+			name:        "success: unhidden top-level comment",
+			status:      entity.CommentStatusVisible,
+			moderatorId: &moderatorId,
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery("UPDATE comments SET status").
+					WithArgs(commentId, entity.CommentStatusVisible, &moderatorId, now).
+					WillReturnRows(sqlmock.NewRows([]string{"parent_id"}).AddRow(nil))
+				f.db.ExpectCommit()
+			},
+		},
+		{
+			name:   "error: comment not found",
+			status: entity.CommentStatusDeleted,
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery("UPDATE comments SET status").
+					WithArgs(commentId, entity.CommentStatusDeleted, nil, now).
+					WillReturnRows(sqlmock.NewRows([]string{"parent_id"}))
+				f.db.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			commentSource := db.NewCommentSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(f)
+
+			err = commentSource.SetStatus(context.Background(), commentId, tt.status, tt.moderatorId, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_commentSource_CreateReport(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	commentSource := db.NewCommentSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	report := &entity.CommentReportDB{
+		ID:        uuid.MustParse("6f8f1d2a-3b4c-4d5e-8f90-a1b2c3d4e5f6"),
+		CommentID: uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11"),
+		UserID:    uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+		Reason:    "spam",
+		Status:    entity.ReportStatusOpen,
+		CreatedAt: time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
+	}
+
+	mock.ExpectExec("INSERT INTO comment_reports").
+		WithArgs(report.ID, report.CommentID, report.UserID, report.Reason, report.Status, report.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO comment_reports").
+		WithArgs(report.ID, report.CommentID, report.UserID, report.Reason, report.Status, report.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	created, err := commentSource.CreateReport(context.Background(), report)
+	assert.NoError(t, err)
+	assert.True(t, created)
+
+	created, err = commentSource.CreateReport(context.Background(), report)
+	assert.NoError(t, err)
+	assert.False(t, created)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_commentSource_GetModerationQueue(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	commentSource := db.NewCommentSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	reportedAt := now.Add(time.Hour)
+
+	mock.ExpectQuery("FROM comment_reports WHERE status = 'open' GROUP BY comment_id").
+		WithArgs(20, 0).
+		WillReturnRows(sqlmock.NewRows(append(commentColumns, "reports", "last_reason", "last_reported_at")).
+			AddRow(commentId, musicId, nil, userId, "user", nil, "buy now", entity.CommentStatusVisible, 0, now, nil, nil, nil, 3, "spam", reportedAt))
+
+	got, err := commentSource.GetModerationQueue(context.Background(), 20, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.ModerationItemDB{{
+		CommentDB: entity.CommentDB{
+			ID: commentId, MusicID: &musicId, UserID: userId, Username: "user", Body: "buy now",
+			Status: entity.CommentStatusVisible, CreatedAt: now,
+		},
+		Reports:        3,
+		LastReason:     "spam",
+		LastReportedAt: reportedAt,
+	}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
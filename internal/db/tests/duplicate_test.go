@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_duplicateSource_GetCandidates(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	candidateId := uuid.MustParse("3f1a2b2c-7f7e-4c1e-9a57-2b9fd9a0c111")
+	hash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectQuery("WITH t AS \\(SELECT EXTRACT\\(EPOCH FROM duration\\) AS seconds FROM music WHERE id = \\$1\\)").
+		WithArgs(musicId, hash, entity.DuplicateDurationTolerance, entity.DuplicateCandidateLimit).
+		WillReturnRows(sqlmock.NewRows([]string{"music_id", "name", "duration_diff", "content_hash", "fingerprint"}).
+			AddRow(candidateId, "Song2", 1, hash, []byte{0xF0, 0x0F}).
+			AddRow(uuid.MustParse("8d3f0c7e-1d1b-4f4f-8d6b-5f9a4c2e7a10"), "Old upload", 2, nil, nil))
+
+	duplicateSource := db.NewDuplicateSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := duplicateSource.GetCandidates(context.Background(), musicId, hash, entity.DuplicateDurationTolerance, entity.DuplicateCandidateLimit)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, candidateId, got[0].MusicID)
+	assert.Equal(t, int64(1), got[0].DurationDiff)
+	assert.Equal(t, hash, *got[0].ContentHash)
+	assert.Equal(t, []byte{0xF0, 0x0F}, got[0].Fingerprint)
+	assert.Nil(t, got[1].ContentHash)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_duplicateSource_Merge(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	intoId := uuid.MustParse("3f1a2b2c-7f7e-4c1e-9a57-2b9fd9a0c111")
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    *entity.MusicMergeResult
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM music WHERE id IN \\(\\$1, \\$2\\) AND deleted_at IS NULL ORDER BY id FOR UPDATE").
+					WithArgs(musicId, intoId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(intoId).AddRow(musicId))
+				mock.ExpectExec("INSERT INTO user_music \\(user_id, music_id, created_at\\) SELECT user_id, \\$2, created_at FROM user_music WHERE music_id = \\$1").
+					WithArgs(musicId, intoId).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("DELETE FROM user_music WHERE music_id = \\$1").
+					WithArgs(musicId).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("UPDATE playlists SET updated_at = \\$2").
+					WithArgs(musicId, now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE playlist_music pm SET music_id = \\$2 WHERE pm.music_id = \\$1 AND NOT EXISTS").
+					WithArgs(musicId, intoId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM playlist_music WHERE music_id = \\$1").
+					WithArgs(musicId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE play_events SET music_id = \\$2 WHERE music_id = \\$1").
+					WithArgs(musicId, intoId).
+					WillReturnResult(sqlmock.NewResult(0, 12))
+				mock.ExpectExec("DELETE FROM music_duplicates WHERE music_id = \\$1 OR candidate_id = \\$1").
+					WithArgs(musicId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE music SET deleted_at = \\$2 WHERE id = \\$1").
+					WithArgs(musicId, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &entity.MusicMergeResult{MusicID: musicId, IntoID: intoId, Likes: 3, PlaylistEntries: 1, Plays: 12},
+		},
+		{
+			name: "error: music in trash",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM music").
+					WithArgs(musicId, intoId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(intoId))
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			tt.setup(mock)
+
+			duplicateSource := db.NewDuplicateSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := duplicateSource.Merge(context.Background(), musicId, intoId, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
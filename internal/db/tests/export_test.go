@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_exportSource_CountItems(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	playlistId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+
+	tests := []struct {
+		name    string
+		request *entity.ExportRequest
+		setup   func(mock sqlmock.Sqlmock)
+		want    int64
+		wantErr error
+	}{
+		{
+			name:    "success: library",
+			request: &entity.ExportRequest{Format: entity.ExportFormatJSON},
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\(SELECT COUNT\\(\\*\\) FROM user_music WHERE user_id = \\$1\\) \\+ .*").
+					WithArgs(userId).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+			},
+			want: 42,
+		},
+		{
+			name:    "success: all playlists",
+			request: &entity.ExportRequest{Format: entity.ExportFormatM3U8},
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM playlists p JOIN playlist_music pm .* WHERE p.user_id = \\$1$").
+					WithArgs(userId).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+			},
+			want: 7,
+		},
+		{
+			name:    "error: playlist of another user",
+			request: &entity.ExportRequest{Format: entity.ExportFormatXSPF, PlaylistID: &playlistId},
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT\\(pm.music_id\\) FROM playlists p LEFT JOIN playlist_music pm .* GROUP BY p.id").
+					WithArgs(userId, &playlistId).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			tt.setup(mock)
+
+			exportSource := db.NewExportSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := exportSource.CountItems(context.Background(), userId, tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_exportSource_GetHistory(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	eventId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	afterAt := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	afterId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	playedAt := afterAt.Add(time.Minute)
+
+	mock.ExpectQuery("SELECT t.id, m.id AS music_id, .* FROM play_events t JOIN music m ON m.id = t.music_id AND m.deleted_at IS NULL .* "+
+		"WHERE t.user_id = \\$1 AND \\(t.played_at, t.id\\) > \\(\\$2, \\$3\\) ORDER BY t.played_at, t.id LIMIT \\$4").
+		WithArgs(userId, afterAt, afterId, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "music_id", "name", "artist", "seconds", "event_type", "position_sec", "source", "played_at"}).
+			AddRow(eventId, musicId, "Song1", nil, 185, "start", 0, "client", playedAt))
+
+	exportSource := db.NewExportSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := exportSource.GetHistory(context.Background(), userId, afterAt, afterId, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.ExportPlayDB{{
+		ID:          eventId,
+		ExportTrack: entity.ExportTrack{MusicID: musicId, Name: "Song1", Duration: 185},
+		EventType:   "start",
+		Source:      "client",
+		PlayedAt:    playedAt,
+	}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_exportSource_ClaimJobs(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	staleBefore := now.Add(-30 * time.Minute)
+	jobId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	mock.ExpectQuery("UPDATE export_jobs SET status = 'running', started_at = \\$1 WHERE id IN \\(.* FOR UPDATE SKIP LOCKED\\) RETURNING \\*").
+		WithArgs(now, staleBefore, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "format", "status", "created_at", "started_at"}).
+			AddRow(jobId, userId, entity.ExportFormatJSON, entity.ExportStatusRunning, staleBefore, now))
+
+	exportSource := db.NewExportSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := exportSource.ClaimJobs(context.Background(), now, staleBefore, 5)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, jobId, got[0].ID)
+	assert.Equal(t, entity.ExportStatusRunning, got[0].Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
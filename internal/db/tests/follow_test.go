@@ -0,0 +1,141 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_followSource_FollowUser(t *testing.T) {
+	followerId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	followeeId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		exists  bool
+		wantErr error
+	}{
+		{
+			name:   "success: follow created",
+			exists: true,
+		},
+		{
+			name:    "error: user not found",
+			exists:  false,
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM users WHERE id = \\$1 AND deleted_at IS NULL\\)").
+				WithArgs(followeeId).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.exists))
+			if tt.exists {
+				mock.ExpectExec("INSERT INTO user_follows \\(follower_id, followee_id, created_at\\) VALUES \\(\\$1, \\$2, \\$3\\) "+
+					"ON CONFLICT \\(follower_id, followee_id\\) DO NOTHING").
+					WithArgs(followerId, followeeId, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			followSource := db.NewFollowSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			err = followSource.FollowUser(context.Background(), followerId, followeeId, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_followSource_UnfollowArtist(t *testing.T) {
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	artistId := uuid.MustParse("6a1c3c8e-2f4b-4d3a-9f7e-0c2d8e5b4a77")
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectExec("DELETE FROM artist_follows WHERE user_id = \\$1 AND artist_id = \\$2").
+		WithArgs(userId, artistId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	followSource := db.NewFollowSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	err = followSource.UnfollowArtist(context.Background(), userId, artistId)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_followSource_GetFeed(t *testing.T) {
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	artistId := uuid.MustParse("6a1c3c8e-2f4b-4d3a-9f7e-0c2d8e5b4a77")
+	musicId := uuid.MustParse("3d3c1c6e-7a8b-4c9d-8e0f-1a2b3c4d5e6f")
+	releasedAt := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	musicName := "Song1"
+	cursor := &entity.FeedCursor{OccurredAt: releasedAt.Add(time.Hour), ID: "like:x"}
+
+	tests := []struct {
+		name       string
+		filter     *entity.FeedFilter
+		cursorTime interface{}
+		cursorId   interface{}
+	}{
+		{
+			name:       "success: first page",
+			filter:     &entity.FeedFilter{Limit: 20},
+			cursorTime: nil,
+			cursorId:   nil,
+		},
+		{
+			name:       "success: page after cursor",
+			filter:     &entity.FeedFilter{Cursor: cursor, Limit: 20},
+			cursorTime: cursor.OccurredAt,
+			cursorId:   cursor.ID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectQuery("FROM artist_follows af .* UNION ALL .* FROM user_follows uf .* UNION ALL .* "+
+				"ORDER BY feed.occurred_at DESC, feed.id DESC LIMIT \\$4").
+				WithArgs(userId, tt.cursorTime, tt.cursorId, 21).
+				WillReturnRows(sqlmock.NewRows([]string{
+					"id", "kind", "occurred_at", "actor_id", "actor_name", "music_id", "music_name", "playlist_id", "playlist_name",
+				}).AddRow("release:"+musicId.String(), entity.FeedItemRelease, releasedAt, artistId, "Artist1", musicId, musicName, nil, nil))
+
+			followSource := db.NewFollowSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := followSource.GetFeed(context.Background(), userId, tt.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, []*entity.FeedItemDB{{
+				ID:         "release:" + musicId.String(),
+				Kind:       entity.FeedItemRelease,
+				OccurredAt: releasedAt,
+				ActorID:    artistId,
+				ActorName:  "Artist1",
+				MusicID:    &musicId,
+				MusicName:  &musicName,
+			}}, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_identitySource_Login(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	otherUserId := uuid.MustParse("0b7e3c1a-4a1f-4d5e-9c55-6f2f8c3e1d20")
+	identityId := uuid.MustParse("9c3f1f7e-2b0a-4d8e-8f55-1a2b3c4d5e6f")
+
+	identityColumns := []string{"id", "user_id", "issuer", "subject", "admin", "created_at", "last_login_at"}
+	userColumns := []string{"id", "username", "password", "role", "deleted_at", "hide_explicit", "share_likes", "share_playlists"}
+
+	login := func(admin bool, linkUserId *uuid.UUID) *entity.IdentityLogin {
+		return &entity.IdentityLogin{
+			Issuer:     "http://oidc:8080/default",
+			Subject:    "user-42",
+			LinkUserID: linkUserId,
+			NewUser:    &entity.UserCreate{Username: "jdoe", Password: "hash"},
+			Role:       entity.UserRole,
+			Admin:      admin,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		login    *entity.IdentityLogin
+		setup    func(mock sqlmock.Sqlmock)
+		wantRole string
+		wantErr  error
+	}{
+		{
+			name:  "success: first login creates user with taken username",
+			login: login(false, nil),
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM user_identities WHERE issuer = \\$1 AND subject = \\$2 FOR UPDATE").
+					WithArgs("http://oidc:8080/default", "user-42").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM users WHERE username = \\$1\\)").
+					WithArgs("jdoe").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectExec("INSERT INTO users \\(id, username, password, role\\)").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "hash", entity.UserRole).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO user_identities").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM users WHERE id = \\$1 AND deleted_at IS NULL").
+					WillReturnRows(sqlmock.NewRows(userColumns).AddRow(userId, "jdoe-4a6e104d", "hash", entity.UserRole, nil, false, true, true))
+				mock.ExpectCommit()
+			},
+			wantRole: entity.UserRole,
+		},
+		{
+			name:  "success: admin group grants role",
+			login: login(true, nil),
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM user_identities").
+					WillReturnRows(sqlmock.NewRows(identityColumns).AddRow(identityId, userId, "http://oidc:8080/default", "user-42", false, now, now))
+				mock.ExpectExec("UPDATE users SET role = \\$2 WHERE id = \\$1 AND role <> \\$2").
+					WithArgs(userId, entity.AdminRole).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE user_identities SET admin = \\$2, last_login_at = \\$3 WHERE id = \\$1").
+					WithArgs(identityId, true, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM users").
+					WillReturnRows(sqlmock.NewRows(userColumns).AddRow(userId, "jdoe", "hash", entity.AdminRole, nil, false, true, true))
+				mock.ExpectCommit()
+			},
+			wantRole: entity.AdminRole,
+		},
+		{
+			name:  "success: leaving admin group revokes role granted by group",
+			login: login(false, nil),
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM user_identities").
+					WillReturnRows(sqlmock.NewRows(identityColumns).AddRow(identityId, userId, "http://oidc:8080/default", "user-42", true, now, now))
+				mock.ExpectExec("UPDATE users SET role = \\$2 WHERE id = \\$1 AND role = \\$3").
+					WithArgs(userId, entity.UserRole, entity.AdminRole).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE user_identities SET admin").
+					WithArgs(identityId, false, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM users").
+					WillReturnRows(sqlmock.NewRows(userColumns).AddRow(userId, "jdoe", "hash", entity.UserRole, nil, false, true, true))
+				mock.ExpectCommit()
+			},
+			wantRole: entity.UserRole,
+		},
+		{
+			name:  "error: identity linked to another user",
+			login: login(false, &otherUserId),
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM user_identities").
+					WillReturnRows(sqlmock.NewRows(identityColumns).AddRow(identityId, userId, "http://oidc:8080/default", "user-42", false, now, now))
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrIdentityLinked,
+		},
+		{
+			name:  "error: user in trash",
+			login: login(false, nil),
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM user_identities").
+					WillReturnRows(sqlmock.NewRows(identityColumns).AddRow(identityId, userId, "http://oidc:8080/default", "user-42", false, now, now))
+				mock.ExpectExec("UPDATE user_identities SET admin").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM users").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			tt.setup(mock)
+
+			identitySource := db.NewIdentitySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := identitySource.Login(context.Background(), tt.login, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.wantRole, got.Role)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_identitySource_ConsumeLogin(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{"state", "nonce", "code_verifier", "user_id", "created_at", "expires_at"}
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			expiresAt: now.Add(time.Minute),
+		},
+		{
+			name:      "error: expired login",
+			expiresAt: now,
+			wantErr:   sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectQuery("DELETE FROM oidc_logins WHERE state = \\$1 RETURNING").
+				WithArgs("state").
+				WillReturnRows(sqlmock.NewRows(columns).AddRow("state", "nonce", "verifier", nil, now.Add(-time.Minute), tt.expiresAt))
+
+			identitySource := db.NewIdentitySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := identitySource.ConsumeLogin(context.Background(), "state", now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, "verifier", got.CodeVerifier)
+				assert.Nil(t, got.UserID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
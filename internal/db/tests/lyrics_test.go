@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var lyricsColumns = []string{"music_id", "language", "plain", "synced", "source", "created_at", "updated_at"}
+
+func Test_lyricsSource_Upsert(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	synced := "[00:01.50]Hello\n"
+	lyrics := &entity.LyricsDB{
+		MusicID: musicId, Language: "en", Plain: "Hello", Synced: &synced,
+		Source: entity.LyricsSourceUpload, CreatedAt: now, UpdatedAt: now,
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO music_lyrics").
+					WithArgs(musicId, "en", "Hello", &synced, entity.LyricsSourceUpload, now, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "error: music not found",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO music_lyrics").
+					WithArgs(musicId, "en", "Hello", &synced, entity.LyricsSourceUpload, now, now).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			lyricsSource := db.NewLyricsSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(mock)
+
+			err = lyricsSource.Upsert(context.Background(), lyrics)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_lyricsSource_GetByMusic(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	synced := "[00:01.50]Hello\n"
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	// Пользователям тексты выдаются только для опубликованных треков
+	mock.ExpectQuery("SELECT l\\.\\* FROM music_lyrics l JOIN music m ON m\\.id = l\\.music_id WHERE l\\.music_id = \\$1 " +
+		"AND m\\.deleted_at IS NULL AND m\\.published_at IS NOT NULL AND \\(m\\.takedown_at IS NULL OR m\\.takedown_at > now\\(\\)\\) " +
+		"ORDER BY l\\.created_at, l\\.language").
+		WithArgs(musicId).
+		WillReturnRows(sqlmock.NewRows(lyricsColumns).
+			AddRow(musicId, "en", "Hello", synced, entity.LyricsSourceUpload, now, now).
+			AddRow(musicId, "ru", "Привет", nil, entity.LyricsSourceID3, now, now))
+
+	lyricsSource := db.NewLyricsSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := lyricsSource.GetByMusic(context.Background(), musicId, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.LyricsDB{
+		{MusicID: musicId, Language: "en", Plain: "Hello", Synced: &synced, Source: entity.LyricsSourceUpload, CreatedAt: now, UpdatedAt: now},
+		{MusicID: musicId, Language: "ru", Plain: "Привет", Source: entity.LyricsSourceID3, CreatedAt: now, UpdatedAt: now},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_lyricsSource_GetByMusic_trashed(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	// Тексты трека в корзине не выдаются даже администраторам
+	mock.ExpectQuery("JOIN music m ON m\\.id = l\\.music_id WHERE l\\.music_id = \\$1 AND m\\.deleted_at IS NULL ORDER BY").
+		WithArgs(musicId).
+		WillReturnRows(sqlmock.NewRows(lyricsColumns))
+
+	lyricsSource := db.NewLyricsSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := lyricsSource.GetByMusic(context.Background(), musicId, true)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_lyricsSource_Delete(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{
+			name: "success",
+			rows: 1,
+		},
+		{
+			name:    "error: lyrics not found",
+			rows:    0,
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectExec("DELETE FROM music_lyrics WHERE music_id = \\$1 AND language = \\$2").
+				WithArgs(musicId, "en").
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			lyricsSource := db.NewLyricsSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			err = lyricsSource.Delete(context.Background(), musicId, "en")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var musicRevisionColumns = []string{
+	"id", "music_id", "version", "action", "name", "release_date", "file_name", "size",
+	"duration", "file_path", "reverted_from", "changed_by", "username", "created_at",
+}
+
+func Test_musicRevisionSource_Apply(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	release := time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	music := &entity.MusicDB{Id: musicId, Name: "Song2", Release: release, FileName: "new.mp3", Size: 900, Duration: "3:15"}
+	moves := []entity.MusicFileMove{{From: "./internal/storage/music_storage/old.mp3", To: "./revisions/old.mp3"}}
+
+	tests := []struct {
+		name        string
+		moves       []entity.MusicFileMove
+		setup       func(mock sqlmock.Sqlmock, revision *entity.MusicRevisionDB)
+		wantVersion int
+		wantErr     error
+	}{
+		{
+			name:  "success: file replaced",
+			moves: moves,
+			setup: func(mock sqlmock.Sqlmock, revision *entity.MusicRevisionDB) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE music SET name").
+					WithArgs(musicId, "Song2", release, "new.mp3", uint64(900), "3:15").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE music_revisions SET file_path").
+					WithArgs(musicId, moves[0].From, moves[0].To).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery("INSERT INTO music_revisions").
+					WithArgs(revision.ID, musicId, entity.MusicRevisionUpdate, "Song2", release, "new.mp3", uint64(900), "3:15",
+						revision.FilePath, nil, &userId, now).
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+				mock.ExpectCommit()
+			},
+			wantVersion: 3,
+		},
+		{
+			name: "error: music not found",
+			setup: func(mock sqlmock.Sqlmock, revision *entity.MusicRevisionDB) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE music SET name").
+					WithArgs(musicId, "Song2", release, "new.mp3", uint64(900), "3:15").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			revision := entity.NewMusicRevision(music, entity.MusicRevisionUpdate, userId, now)
+			tt.setup(mock, revision)
+
+			revisionSource := db.NewMusicRevisionSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			err = revisionSource.Apply(context.Background(), music, revision, tt.moves)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantVersion, revision.Version)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_musicRevisionSource_Create(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	release := time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	music := &entity.MusicDB{Id: musicId, Name: "Song2", Release: release, FileName: "new.mp3", Size: 900, Duration: "3:15"}
+	revision := entity.NewMusicRevision(music, entity.MusicRevisionCreate, uuid.Nil, now)
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectQuery("INSERT INTO music_revisions").
+		WithArgs(revision.ID, musicId, entity.MusicRevisionCreate, "Song2", release, "new.mp3", uint64(900), "3:15",
+			"./internal/storage/music_storage/new.mp3", nil, nil, now).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+
+	revisionSource := db.NewMusicRevisionSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	err = revisionSource.Create(context.Background(), revision)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, revision.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_musicRevisionSource_GetByMusic(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	revisionId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	release := time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	revertedFrom := 1
+	username := "admin"
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectQuery("FROM music_revisions r LEFT JOIN users u ON u.id = r.changed_by WHERE r.music_id = \\$1 ORDER BY r.version DESC LIMIT \\$2 OFFSET \\$3").
+		WithArgs(musicId, 20, 0).
+		WillReturnRows(sqlmock.NewRows(musicRevisionColumns).
+			AddRow(revisionId, musicId, 2, entity.MusicRevisionRevert, "Song1", release, "old.mp3", 500, "2:47",
+				"./internal/storage/music_storage/old.mp3", 1, userId, username, now))
+
+	revisionSource := db.NewMusicRevisionSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := revisionSource.GetByMusic(context.Background(), musicId, 20, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.MusicRevisionDB{{
+		ID: revisionId, MusicID: musicId, Version: 2, Action: entity.MusicRevisionRevert, Name: "Song1", Release: release,
+		FileName: "old.mp3", Size: 500, Duration: "2:47", FilePath: "./internal/storage/music_storage/old.mp3",
+		RevertedFrom: &revertedFrom, ChangedBy: &userId, Username: &username, CreatedAt: now,
+	}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_musicRevisionSource_Get(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectQuery("WHERE r.music_id = \\$1 AND r.version = \\$2").
+		WithArgs(musicId, 7).
+		WillReturnRows(sqlmock.NewRows(musicRevisionColumns))
+
+	revisionSource := db.NewMusicRevisionSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	_, err = revisionSource.Get(context.Background(), musicId, 7)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
 	"music-backend-test/internal/db"
 	"music-backend-test/internal/entity"
@@ -60,7 +61,7 @@ func Test_source_GetAll(t *testing.T) {
 						uint64(900),
 						"3:23",
 					)
-				f.db.ExpectQuery("SELECT * FROM music").WillReturnRows(rows)
+				f.db.ExpectQuery("SELECT * FROM music WHERE deleted_at IS NULL").WillReturnRows(rows)
 			},
 			want: []*entity.MusicDB{
 				{
@@ -107,7 +108,7 @@ func Test_source_GetAll(t *testing.T) {
 
 			tt.setup(tt.args, f)
 
-			got, err := musicSource.GetAll(tt.args.ctx)
+			got, err := musicSource.GetAll(tt.args.ctx, entity.MusicFilter{ShowUnpublished: true})
 			if tt.wantErr == true {
 				assert.Error(t, err)
 			} else {
@@ -159,7 +160,7 @@ func Test_source_Get(t *testing.T) {
 						uint64(900),
 						"3:23",
 					)
-				f.db.ExpectQuery("SELECT * FROM music WHERE id = $1").WithArgs(a.musicId.String()).WillReturnRows(rows)
+				f.db.ExpectQuery("SELECT * FROM music WHERE id = $1 AND deleted_at IS NULL").WithArgs(a.musicId.String()).WillReturnRows(rows)
 			},
 			want: &entity.MusicDB{
 				Id:       uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
@@ -186,7 +187,7 @@ func Test_source_Get(t *testing.T) {
 					"size",
 					"duration",
 				})
-				f.db.ExpectQuery("SELECT * FROM music WHERE id = $1").WithArgs(a.musicId.String()).WillReturnRows(rows)
+				f.db.ExpectQuery("SELECT * FROM music WHERE id = $1 AND deleted_at IS NULL").WithArgs(a.musicId.String()).WillReturnRows(rows)
 			},
 			want:    nil,
 			wantErr: true,
@@ -231,7 +232,7 @@ func Test_source_Get(t *testing.T) {
 	}
 }
 
-func Test_source_GetAndSortByPopular(t *testing.T) {
+func Test_source_GetAllSortByTime(t *testing.T) {
 	type fields struct {
 		db sqlmock.Sqlmock
 	}
@@ -249,7 +250,7 @@ func Test_source_GetAndSortByPopular(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "Get all sorted by popular music",
+			name: "Get all sorted by reliase music",
 			args: args{ctx: ctx},
 			setup: func(a args, f fields) {
 				rows := sqlmock.
@@ -277,11 +278,7 @@ func Test_source_GetAndSortByPopular(t *testing.T) {
 						uint64(900),
 						"3:23",
 					)
-				f.db.ExpectQuery("SELECT m.id AS id, m.name AS name " +
-					"FROM music m " +
-					"LEFT JOIN user_music um ON um.music_id = m.id " +
-					"GROUP BY m.id, m.name " +
-					"ORDER BY COALESCE(COUNT(um.music_id), 0) DESC;").WillReturnRows(rows)
+				f.db.ExpectQuery("SELECT * FROM music WHERE deleted_at IS NULL ORDER BY release_date").WillReturnRows(rows)
 			},
 			want: []*entity.MusicDB{
 				{
@@ -304,7 +301,7 @@ func Test_source_GetAndSortByPopular(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "Bad request to database at music.GetAndSortByPopular",
+			name: "Bad request to database at music.GetAllSortByTime",
 			args: args{ctx: ctx},
 			setup: func(a args, f fields) {
 				f.db.ExpectQuery("...").WillReturnError(fmt.Errorf("can't exec query"))
@@ -328,7 +325,7 @@ func Test_source_GetAndSortByPopular(t *testing.T) {
 
 			tt.setup(tt.args, f)
 
-			got, err := musicSource.GetAndSortByPopular(tt.args.ctx)
+			got, err := musicSource.GetAllSortByTime(tt.args.ctx, entity.MusicFilter{ShowUnpublished: true})
 			if tt.wantErr == true {
 				assert.Error(t, err)
 			} else {
@@ -336,12 +333,11 @@ func Test_source_GetAndSortByPopular(t *testing.T) {
 					assert.Equal(t, tt.want, got)
 				}
 			}
-
 		})
 	}
 }
 
-func Test_source_GetAllSortByTime(t *testing.T) {
+func Test_source_GetAllSortByRating(t *testing.T) {
 	type fields struct {
 		db sqlmock.Sqlmock
 	}
@@ -359,7 +355,7 @@ func Test_source_GetAllSortByTime(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "Get all sorted by reliase music",
+			name: "Get all sorted by bayesian rating",
 			args: args{ctx: ctx},
 			setup: func(a args, f fields) {
 				rows := sqlmock.
@@ -370,6 +366,8 @@ func Test_source_GetAllSortByTime(t *testing.T) {
 						"file_name",
 						"size",
 						"duration",
+						"rating_avg",
+						"rating_count",
 					}).
 					AddRow(
 						uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
@@ -378,42 +376,30 @@ func Test_source_GetAllSortByTime(t *testing.T) {
 						"Song1.mp3",
 						uint64(500),
 						"2:47",
-					).
-					AddRow(
-						uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
-						"Song2",
-						time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
-						"Song2.mp3",
-						uint64(900),
-						"3:23",
+						4.5,
+						int64(120),
 					)
-				f.db.ExpectQuery("SELECT * FROM music ORDER BY release_date").WillReturnRows(rows)
+				f.db.ExpectQuery("SELECT music.* FROM music CROSS JOIN").WillReturnRows(rows)
 			},
 			want: []*entity.MusicDB{
 				{
-					Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-					Name:     "Song1",
-					Release:  time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
-					FileName: "Song1.mp3",
-					Size:     uint64(500),
-					Duration: "2:47",
-				},
-				{
-					Id:       uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
-					Name:     "Song2",
-					Release:  time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
-					FileName: "Song2.mp3",
-					Size:     uint64(900),
-					Duration: "3:23",
+					Id:          uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+					Name:        "Song1",
+					Release:     time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
+					FileName:    "Song1.mp3",
+					Size:        uint64(500),
+					Duration:    "2:47",
+					RatingAvg:   4.5,
+					RatingCount: 120,
 				},
 			},
 			wantErr: false,
 		},
 		{
-			name: "Bad request to database at music.GetAllSortByTime",
+			name: "Bad request to database at music.GetAllSortByRating",
 			args: args{ctx: ctx},
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("...").WillReturnError(fmt.Errorf("can't exec query"))
+				f.db.ExpectQuery("SELECT music.* FROM music CROSS JOIN").WillReturnError(fmt.Errorf("can't exec query"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -422,7 +408,7 @@ func Test_source_GetAllSortByTime(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			database, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			database, mock, err := sqlmock.New()
 			assert.NoError(t, err)
 			defer database.Close()
 
@@ -434,7 +420,7 @@ func Test_source_GetAllSortByTime(t *testing.T) {
 
 			tt.setup(tt.args, f)
 
-			got, err := musicSource.GetAllSortByTime(tt.args.ctx)
+			got, err := musicSource.GetAllSortByRating(tt.args.ctx, entity.MusicFilter{ShowUnpublished: true})
 			if tt.wantErr == true {
 				assert.Error(t, err)
 			} else {
@@ -483,8 +469,8 @@ func Test_source_Create(t *testing.T) {
 
 				a.musicDB.Id = uuid.MustParse("31313131-3131-4131-b131-313131313131")
 				rows := sqlmock.NewResult(1, 1)
-				f.sqlmock.ExpectExec("INSERT INTO music (id, name, release_date, file_name, size, duration) VALUES ($1, $2, $3, $4, $5, $6)").
-					WithArgs(a.musicDB.Id, a.musicDB.Name, a.musicDB.Release, a.musicDB.FileName, a.musicDB.Size, a.musicDB.Duration).
+				f.sqlmock.ExpectExec("INSERT INTO music (id, name, release_date, file_name, size, duration, publish_at, published_at, explicit) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)").
+					WithArgs(a.musicDB.Id, a.musicDB.Name, a.musicDB.Release, a.musicDB.FileName, a.musicDB.Size, a.musicDB.Duration, a.musicDB.PublishAt, a.musicDB.PublishedAt, a.musicDB.Explicit).
 					WillReturnResult(rows)
 			},
 			wantErr: false,
@@ -508,7 +494,7 @@ func Test_source_Create(t *testing.T) {
 
 				a.musicDB.Id = uuid.MustParse("31313131-3131-4131-b131-313131313131")
 				f.sqlmock.ExpectExec("...").
-					WithArgs(a.musicDB.Id, a.musicDB.Name, a.musicDB.Release, a.musicDB.FileName, a.musicDB.Size, a.musicDB.Duration).
+					WithArgs(a.musicDB.Id, a.musicDB.Name, a.musicDB.Release, a.musicDB.FileName, a.musicDB.Size, a.musicDB.Duration, a.musicDB.PublishAt, a.musicDB.PublishedAt).
 					WillReturnError(fmt.Errorf("Bad request"))
 			},
 			wantErr: true,
@@ -571,7 +557,7 @@ func Test_source_Update(t *testing.T) {
 			},
 			setup: func(a args, f fields) {
 				rows := sqlmock.NewResult(1, 1)
-				f.db.ExpectExec("UPDATE music SET name = $2, release_date = $3, file_name = $4, size = $5, duration = $6 WHERE id = $1").
+				f.db.ExpectExec("UPDATE music SET name = $2, release_date = $3, file_name = $4, size = $5, duration = $6 WHERE id = $1 AND deleted_at IS NULL").
 					WithArgs(a.musicDb.Id, a.musicDb.Name, a.musicDb.Release, a.musicDb.FileName, a.musicDb.Size, a.musicDb.Duration).
 					WillReturnResult(rows)
 			},
@@ -592,7 +578,7 @@ func Test_source_Update(t *testing.T) {
 			},
 			setup: func(a args, f fields) {
 				rows := sqlmock.NewResult(1, 1)
-				f.db.ExpectExec("UPDATE music SET name = $2, release_date = $3 WHERE id = $1").
+				f.db.ExpectExec("UPDATE music SET name = $2, release_date = $3 WHERE id = $1 AND deleted_at IS NULL").
 					WithArgs(a.musicDb.Id, a.musicDb.Name, a.musicDb.Release).WillReturnResult(rows)
 			},
 			wantErr: false,
@@ -651,6 +637,7 @@ func Test_source_Delete(t *testing.T) {
 	type args struct {
 		ctx     context.Context
 		musicId uuid.UUID
+		now     time.Time
 	}
 
 	tests := []struct {
@@ -664,12 +651,27 @@ func Test_source_Delete(t *testing.T) {
 			args: args{
 				ctx:     context.Background(),
 				musicId: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
+				now:     time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
 			},
 			setup: func(a args, f fields) {
-				f.db.ExpectExec("DELETE FROM music WHERE id = $1").WithArgs(a.musicId).WillReturnResult(sqlmock.NewResult(1, 1))
+				f.db.ExpectExec("UPDATE music SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL").
+					WithArgs(a.musicId, a.now).WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			wantErr: false,
 		},
+		{
+			name: "Music not found or already deleted",
+			args: args{
+				ctx:     context.Background(),
+				musicId: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
+				now:     time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectExec("UPDATE music SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL").
+					WithArgs(a.musicId, a.now).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
 		{
 			name: "Bad request to database at music.Delete",
 			args: args{
@@ -677,7 +679,7 @@ func Test_source_Delete(t *testing.T) {
 				musicId: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
 			},
 			setup: func(a args, f fields) {
-				f.db.ExpectExec("...").WithArgs(a.musicId).WillReturnError(fmt.Errorf("can't exec query"))
+				f.db.ExpectExec("...").WithArgs(a.musicId, a.now).WillReturnError(fmt.Errorf("can't exec query"))
 			},
 			wantErr: true,
 		},
@@ -697,7 +699,7 @@ func Test_source_Delete(t *testing.T) {
 
 			tt.setup(tt.args, f)
 
-			err = musicSource.Delete(tt.args.ctx, tt.args.musicId)
+			err = musicSource.Delete(tt.args.ctx, tt.args.musicId, tt.args.now)
 			if tt.wantErr == true {
 				assert.Error(t, err)
 			} else {
@@ -706,3 +708,84 @@ func Test_source_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_source_SetExplicit(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	tests := []struct {
+		name    string
+		result  sql.Result
+		wantErr error
+	}{
+		{
+			name:   "success: explicit flag set",
+			result: sqlmock.NewResult(0, 1),
+		},
+		{
+			name:    "error: music not found",
+			result:  sqlmock.NewResult(0, 0),
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectExec("UPDATE music SET explicit = $2 WHERE id = $1 AND deleted_at IS NULL").
+				WithArgs(musicId, true).
+				WillReturnResult(tt.result)
+
+			musicSource := db.NewMusicSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			err = musicSource.SetExplicit(context.Background(), musicId, true)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_source_GetAll_filter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter entity.MusicFilter
+		query  string
+	}{
+		{
+			name:   "user sees only available tracks",
+			filter: entity.MusicFilter{},
+			query:  "SELECT * FROM music WHERE deleted_at IS NULL AND published_at IS NOT NULL AND (takedown_at IS NULL OR takedown_at > now())",
+		},
+		{
+			name:   "user hides explicit tracks",
+			filter: entity.MusicFilter{HideExplicit: true},
+			query:  "SELECT * FROM music WHERE deleted_at IS NULL AND published_at IS NOT NULL AND (takedown_at IS NULL OR takedown_at > now()) AND NOT explicit",
+		},
+		{
+			name:   "admin sees unpublished tracks but not trash",
+			filter: entity.MusicFilter{ShowUnpublished: true},
+			query:  "SELECT * FROM music WHERE deleted_at IS NULL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.NoError(t, err)
+			defer database.Close()
+
+			// Фильтр применяется в запросе, а не после него, поэтому выдача не теряет треки из-за фильтрации
+			mock.ExpectQuery(tt.query).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+			musicSource := db.NewMusicSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			_, err = musicSource.GetAll(context.Background(), tt.filter)
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_notificationSource_CreateReleases(t *testing.T) {
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectExec("WITH released AS \\(INSERT INTO release_notifications \\(music_id, notified_at\\) .* "+
+		"ON CONFLICT \\(music_id\\) DO NOTHING RETURNING music_id\\) INSERT INTO notifications .* JOIN artist_follows af").
+		WithArgs(now, entity.NotificationRelease).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	notificationSource := db.NewNotificationSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	created, err := notificationSource.CreateReleases(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), created)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_notificationSource_GetByUser(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	notificationId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	createdAt := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	cursor := &entity.NotificationCursor{CreatedAt: createdAt.Add(time.Hour), ID: uuid.MustParse("8b1c5c1e-2f0a-4d61-9c55-4b1e3f1a2d7e")}
+	actorName := "Artist1"
+
+	tests := []struct {
+		name       string
+		filter     *entity.NotificationFilter
+		cursorTime interface{}
+		cursorId   interface{}
+	}{
+		{
+			name:       "success: first page",
+			filter:     &entity.NotificationFilter{Limit: 20},
+			cursorTime: nil,
+			cursorId:   nil,
+		},
+		{
+			name:       "success: unread page after cursor",
+			filter:     &entity.NotificationFilter{Cursor: cursor, Limit: 20, UnreadOnly: true},
+			cursorTime: cursor.CreatedAt,
+			cursorId:   cursor.ID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectQuery("SELECT n.\\*, COALESCE\\(a.name, u.username\\) AS actor_name, m.name AS music_name, pl.name AS playlist_name FROM notifications n .* "+
+				"WHERE n.user_id = \\$1 AND NOT EXISTS .* ORDER BY n.created_at DESC, n.id DESC LIMIT \\$5").
+				WithArgs(userId, tt.filter.UnreadOnly, tt.cursorTime, tt.cursorId, 21).
+				WillReturnRows(sqlmock.NewRows([]string{
+					"id", "user_id", "type", "actor_id", "music_id", "comment_id", "created_at", "read_at", "delivered_at", "playlist_id",
+					"actor_name", "music_name", "playlist_name",
+				}).AddRow(notificationId, userId, entity.NotificationRelease, nil, nil, nil, createdAt, nil, nil, nil, actorName, nil, nil))
+
+			notificationSource := db.NewNotificationSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := notificationSource.GetByUser(context.Background(), userId, tt.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, []*entity.NotificationDB{{
+				ID:        notificationId,
+				UserID:    userId,
+				Type:      entity.NotificationRelease,
+				ActorName: &actorName,
+				CreatedAt: createdAt,
+			}}, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_notificationSource_MarkRead(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	notificationId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{
+			name:     "success",
+			affected: 1,
+		},
+		{
+			name:     "error: notification of another user",
+			affected: 0,
+			wantErr:  sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectExec("UPDATE notifications SET read_at = COALESCE\\(read_at, \\$3\\) WHERE id = \\$1 AND user_id = \\$2").
+				WithArgs(notificationId, userId, now).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			notificationSource := db.NewNotificationSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			err = notificationSource.MarkRead(context.Background(), userId, notificationId, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_notificationSource_GetSettings(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectQuery("SELECT email FROM notification_settings WHERE user_id = \\$1").
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"email"}))
+	mock.ExpectQuery("SELECT type, in_app, email, webhook FROM notification_preferences WHERE user_id = \\$1").
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"type", "in_app", "email", "webhook"}).
+			AddRow(entity.NotificationCommentReply, false, false, true))
+
+	notificationSource := db.NewNotificationSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := notificationSource.GetSettings(context.Background(), userId)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.NotificationSettings{Preferences: []*entity.NotificationPreference{
+		{Type: entity.NotificationRelease, InApp: true},
+		{Type: entity.NotificationCommentReply, Webhook: true},
+		{Type: entity.NotificationPlaylistComment, InApp: true},
+	}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
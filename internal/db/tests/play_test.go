@@ -0,0 +1,418 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+const insertPlayEventQuery = "INSERT INTO play_events (id, user_id, music_id, event_type, position_sec, source, client_event_id, played_at, created_at) " +
+	"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (user_id, client_event_id) DO NOTHING"
+
+func newPlayEventDB(id string) *entity.PlayEventDB {
+	return &entity.PlayEventDB{
+		ID:            uuid.MustParse(id),
+		UserID:        uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+		MusicID:       uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
+		EventType:     entity.PlayEventStart,
+		Position:      0,
+		Source:        entity.PlaySourceClient,
+		ClientEventID: uuid.MustParse("6cfc3a4d-28ae-4b13-9a11-d2c335ad8658"),
+		PlayedAt:      time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
+		CreatedAt:     time.Date(2023, time.March, 24, 12, 0, 1, 0, time.UTC),
+	}
+}
+
+func Test_playSource_Create(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+	type args struct {
+		ctx   context.Context
+		event *entity.PlayEventDB
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, f fields)
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "success: event created",
+			args: args{
+				ctx:   context.Background(),
+				event: newPlayEventDB("31313131-3131-4131-b131-313131313131"),
+			},
+			setup: func(a args, f fields) {
+				e := a.event
+				f.db.ExpectExec(insertPlayEventQuery).
+					WithArgs(e.ID, e.UserID, e.MusicID, e.EventType, e.Position, e.Source, e.ClientEventID, e.PlayedAt, e.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "success: duplicate event skipped",
+			args: args{
+				ctx:   context.Background(),
+				event: newPlayEventDB("31313131-3131-4131-b131-313131313131"),
+			},
+			setup: func(a args, f fields) {
+				e := a.event
+				f.db.ExpectExec(insertPlayEventQuery).
+					WithArgs(e.ID, e.UserID, e.MusicID, e.EventType, e.Position, e.Source, e.ClientEventID, e.PlayedAt, e.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "error: can't exec query",
+			args: args{
+				ctx:   context.Background(),
+				event: newPlayEventDB("31313131-3131-4131-b131-313131313131"),
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectExec(insertPlayEventQuery).WillReturnError(fmt.Errorf("can't exec query"))
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			playSource := db.NewPlaySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(tt.args, f)
+
+			got, err := playSource.Create(tt.args.ctx, tt.args.event)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_playSource_CreateBatch(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+	type args struct {
+		ctx    context.Context
+		events []*entity.PlayEventDB
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, f fields)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "success: one new event and one duplicate",
+			args: args{
+				ctx: context.Background(),
+				events: []*entity.PlayEventDB{
+					newPlayEventDB("31313131-3131-4131-b131-313131313131"),
+					newPlayEventDB("32323232-3232-4232-b232-323232323232"),
+				},
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectExec(insertPlayEventQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				f.db.ExpectExec(insertPlayEventQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				f.db.ExpectCommit()
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "error: rollback on failed insert",
+			args: args{
+				ctx: context.Background(),
+				events: []*entity.PlayEventDB{
+					newPlayEventDB("31313131-3131-4131-b131-313131313131"),
+				},
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectExec(insertPlayEventQuery).WillReturnError(fmt.Errorf("can't exec query"))
+				f.db.ExpectRollback()
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			playSource := db.NewPlaySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(tt.args, f)
+
+			got, err := playSource.CreateBatch(tt.args.ctx, tt.args.events)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.want, got)
+				}
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_playSource_GetKnownMusicIDs(t *testing.T) {
+	knownId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	unknownId := uuid.MustParse("0d5e7a8c-3f41-4b6e-9c2d-7a1b8e4f6c30")
+	query := "SELECT id FROM music WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL"
+
+	tests := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    []uuid.UUID
+		wantErr bool
+	}{
+		{
+			name: "success: only known music returned",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(pq.Array([]string{knownId.String(), unknownId.String()})).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(knownId))
+			},
+			want:    []uuid.UUID{knownId},
+			wantErr: false,
+		},
+		{
+			name: "error: query failed",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnError(fmt.Errorf("can't exec query"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.NoError(t, err)
+			defer database.Close()
+
+			playSource := db.NewPlaySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(mock)
+
+			got, err := playSource.GetKnownMusicIDs(context.Background(), []uuid.UUID{knownId, unknownId})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.want, got)
+				}
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_playSource_GetByUser(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+	type args struct {
+		ctx    context.Context
+		userId uuid.UUID
+		filter *entity.PlayHistoryFilter
+	}
+
+	filter := &entity.PlayHistoryFilter{
+		From:  time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:    time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Limit: 50,
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, f fields)
+		want    []*entity.PlayEventDB
+		wantErr bool
+	}{
+		{
+			name: "success: history returned",
+			args: args{
+				ctx:    context.Background(),
+				userId: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				filter: filter,
+			},
+			setup: func(a args, f fields) {
+				e := newPlayEventDB("31313131-3131-4131-b131-313131313131")
+				rows := sqlmock.NewRows([]string{
+					"id", "user_id", "music_id", "event_type", "position_sec", "source", "client_event_id", "played_at", "created_at",
+				}).AddRow(e.ID, e.UserID, e.MusicID, e.EventType, e.Position, e.Source, e.ClientEventID, e.PlayedAt, e.CreatedAt)
+				f.db.ExpectQuery("SELECT * FROM play_events WHERE user_id = $1 AND played_at >= $2 AND played_at < $3 ORDER BY played_at DESC LIMIT $4 OFFSET $5").
+					WithArgs(a.userId, a.filter.From, a.filter.To, a.filter.Limit, a.filter.Offset).
+					WillReturnRows(rows)
+			},
+			want:    []*entity.PlayEventDB{newPlayEventDB("31313131-3131-4131-b131-313131313131")},
+			wantErr: false,
+		},
+		{
+			name: "error: can't exec query",
+			args: args{
+				ctx:    context.Background(),
+				userId: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				filter: filter,
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectQuery("...").WillReturnError(fmt.Errorf("can't exec query"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			playSource := db.NewPlaySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(tt.args, f)
+
+			got, err := playSource.GetByUser(tt.args.ctx, tt.args.userId, tt.args.filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_playSource_Delete(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+	type args struct {
+		ctx     context.Context
+		userId  uuid.UUID
+		eventId uuid.UUID
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, f fields)
+		wantErr error
+	}{
+		{
+			name: "success: event deleted",
+			args: args{
+				ctx:     context.Background(),
+				userId:  uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				eventId: uuid.MustParse("31313131-3131-4131-b131-313131313131"),
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectExec("DELETE FROM play_events WHERE id = $1 AND user_id = $2").
+					WithArgs(a.eventId, a.userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "error: event of another user is not found",
+			args: args{
+				ctx:     context.Background(),
+				userId:  uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				eventId: uuid.MustParse("31313131-3131-4131-b131-313131313131"),
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectExec("DELETE FROM play_events WHERE id = $1 AND user_id = $2").
+					WithArgs(a.eventId, a.userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			playSource := db.NewPlaySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(tt.args, f)
+
+			err = playSource.Delete(tt.args.ctx, tt.args.userId, tt.args.eventId)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_playSource_DeleteByUser(t *testing.T) {
+	database, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer database.Close()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	filter := &entity.PlayHistoryFilter{
+		To: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	mock.ExpectExec("DELETE FROM play_events WHERE user_id = $1 AND played_at >= $2 AND played_at < $3").
+		WithArgs(userId, filter.From, filter.To).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	playSource := db.NewPlaySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := playSource.DeleteByUser(context.Background(), userId, filter)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(3), got)
+	}
+}
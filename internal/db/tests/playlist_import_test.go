@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_playlistImportSource_GetByIDs(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	artist := "Queen"
+
+	mock.ExpectQuery("SELECT m.id AS music_id, .* FROM music m LEFT JOIN artists a .* WHERE m.id = ANY\\(\\$1::uuid\\[\\]\\) AND m.deleted_at IS NULL .*").
+		WithArgs(pq.Array([]string{musicId.String()})).
+		WillReturnRows(sqlmock.NewRows([]string{"music_id", "name", "artist", "seconds"}).AddRow(musicId, "Bohemian Rhapsody", artist, 354))
+
+	playlistImportSource := db.NewPlaylistImportSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := playlistImportSource.GetByIDs(context.Background(), []uuid.UUID{musicId})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.ImportCandidateDB{{MusicID: musicId, Name: "Bohemian Rhapsody", Artist: &artist, Duration: 354}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_playlistImportSource_GetByTitles(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	artist := "Queen"
+
+	mock.ExpectQuery("SELECT m.id AS music_id, .* FROM music m LEFT JOIN artists a .* " +
+		"WHERE normalize_import_title\\(m.name\\) = ANY\\(\\$1::text\\[\\]\\) AND m.deleted_at IS NULL .* ORDER BY m.id").
+		WithArgs(pq.Array([]string{"bohemian rhapsody", "under pressure"})).
+		WillReturnRows(sqlmock.NewRows([]string{"music_id", "name", "artist", "seconds"}).AddRow(musicId, "Bohemian Rhapsody (Remastered)", artist, 354))
+
+	playlistImportSource := db.NewPlaylistImportSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := playlistImportSource.GetByTitles(context.Background(), []string{"bohemian rhapsody", "under pressure"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.ImportCandidateDB{{MusicID: musicId, Name: "Bohemian Rhapsody (Remastered)", Artist: &artist, Duration: 354}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_playlistImportSource_CreatePlaylist(t *testing.T) {
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	playlist := &entity.PlaylistDB{
+		ID:        uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11"),
+		UserID:    uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+		Name:      "Road trip",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	musicIds := []uuid.UUID{
+		uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
+		uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+	}
+
+	tests := []struct {
+		name     string
+		musicIds []uuid.UUID
+		setup    func(mock sqlmock.Sqlmock)
+		wantErr  bool
+	}{
+		{
+			name:     "success",
+			musicIds: musicIds,
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO playlists").
+					WithArgs(playlist.ID, playlist.UserID, playlist.Name, playlist.IsPublic, now, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO playlist_music .* FROM unnest\\(\\$2::uuid\\[\\]\\) WITH ORDINALITY").
+					WithArgs(playlist.ID, pq.Array([]string{musicIds[0].String(), musicIds[1].String()}), now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "success: empty playlist",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO playlists").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "error: tracks are rolled back with playlist",
+			musicIds: musicIds,
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO playlists").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO playlist_music").WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			tt.setup(mock)
+
+			playlistImportSource := db.NewPlaylistImportSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			err = playlistImportSource.CreatePlaylist(context.Background(), playlist, tt.musicIds)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_playlistSource_AddTrack(t *testing.T) {
+	playlistId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	musicId := uuid.MustParse("3d3c1c6e-7a8b-4c9d-8e0f-1a2b3c4d5e6f")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		available bool
+		inserted  int64
+		wantErr   error
+	}{
+		{
+			name:      "success: track appended",
+			available: true,
+			inserted:  1,
+		},
+		{
+			name:      "success: track already in playlist",
+			available: true,
+			inserted:  0,
+		},
+		{
+			name:      "error: music unavailable",
+			available: false,
+			wantErr:   sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM music WHERE id = \\$1 AND deleted_at IS NULL AND published_at IS NOT NULL").
+				WithArgs(musicId).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.available))
+			if tt.available {
+				mock.ExpectExec("INSERT INTO playlist_music .* ON CONFLICT \\(playlist_id, music_id\\) DO NOTHING").
+					WithArgs(playlistId, musicId, now).
+					WillReturnResult(sqlmock.NewResult(0, tt.inserted))
+				if tt.inserted > 0 {
+					mock.ExpectExec("UPDATE playlists SET updated_at = \\$2 WHERE id = \\$1").
+						WithArgs(playlistId, now).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			playlistSource := db.NewPlaylistSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			err = playlistSource.AddTrack(context.Background(), playlistId, musicId, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_playlistSource_RemoveTrack(t *testing.T) {
+	playlistId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	musicId := uuid.MustParse("3d3c1c6e-7a8b-4c9d-8e0f-1a2b3c4d5e6f")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM playlist_music WHERE playlist_id = \\$1 AND music_id = \\$2").
+		WithArgs(playlistId, musicId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	playlistSource := db.NewPlaylistSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	err = playlistSource.RemoveTrack(context.Background(), playlistId, musicId, now)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_popularitySource_Refresh(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+
+	cfg := &entity.PopularityConfig{
+		PlayWeight:       1,
+		LikeWeight:       5,
+		TrendingHalfLife: 48 * time.Hour,
+		RefreshTimeout:   time.Minute,
+	}
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setup   func(f fields)
+		wantErr bool
+	}{
+		{
+			name: "success: all windows and trending refreshed",
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectExec("INSERT INTO music_popularity").
+					WithArgs(entity.PopularityDay, cfg.PlayWeight, cfg.LikeWeight, now.AddDate(0, 0, -1), now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				f.db.ExpectExec("INSERT INTO music_popularity").
+					WithArgs(entity.PopularityWeek, cfg.PlayWeight, cfg.LikeWeight, now.AddDate(0, 0, -7), now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				f.db.ExpectExec("INSERT INTO music_popularity").
+					WithArgs(entity.PopularityMonth, cfg.PlayWeight, cfg.LikeWeight, now.AddDate(0, -1, 0), now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				f.db.ExpectExec("INSERT INTO music_popularity").
+					WithArgs(entity.PopularityAll, cfg.PlayWeight, cfg.LikeWeight, time.Time{}, now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				f.db.ExpectExec("INSERT INTO music_popularity").
+					WithArgs(entity.PopularityTrending, cfg.PlayWeight, cfg.LikeWeight, now.Add(-cfg.TrendingHalfLife*entity.TrendingHorizon), now, cfg.TrendingHalfLife.Seconds()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				f.db.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error: rollback when a window fails",
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectExec("INSERT INTO music_popularity").WillReturnError(fmt.Errorf("can't exec query"))
+				f.db.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			popularitySource := db.NewPopularitySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(f)
+
+			err = popularitySource.Refresh(context.Background(), cfg, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_popularitySource_Refresh_timeout(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	// Пересчет прерывается по собственному таймауту задачи
+	cfg := &entity.PopularityConfig{PlayWeight: 1, LikeWeight: 5, TrendingHalfLife: time.Hour, RefreshTimeout: 20 * time.Millisecond}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO music_popularity").WillDelayFor(time.Second).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	popularitySource := db.NewPopularitySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	start := time.Now()
+	err = popularitySource.Refresh(context.Background(), cfg, time.Now())
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func Test_popularitySource_Get(t *testing.T) {
+	database, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer database.Close()
+
+	rows := sqlmock.NewRows([]string{
+		"id", "name", "release_date", "file_name", "size", "duration", "plays", "likes", "score",
+	}).AddRow(
+		uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+		"Song1",
+		time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
+		"Song1.mp3",
+		uint64(500),
+		"2:47",
+		int64(10),
+		int64(2),
+		float64(20),
+	)
+	mock.ExpectQuery("SELECT m.*, mp.plays, mp.likes, mp.score FROM music_popularity mp JOIN music m ON m.id = mp.music_id "+
+		"WHERE mp.period = $1 AND m.deleted_at IS NULL AND m.published_at IS NOT NULL AND (m.takedown_at IS NULL OR m.takedown_at > now()) AND NOT (m.explicit AND $4) ORDER BY mp.score DESC, m.name LIMIT $2 OFFSET $3").
+		WithArgs(entity.PopularityWeek, 50, 0, true).
+		WillReturnRows(rows)
+
+	popularitySource := db.NewPopularitySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := popularitySource.Get(context.Background(), entity.PopularityWeek, 50, 0, true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*entity.MusicPopularityDB{
+			{
+				MusicDB: entity.MusicDB{
+					Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+					Name:     "Song1",
+					Release:  time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
+					FileName: "Song1.mp3",
+					Size:     uint64(500),
+					Duration: "2:47",
+				},
+				Plays: 10,
+				Likes: 2,
+				Score: 20,
+			},
+		}, got)
+	}
+}
@@ -0,0 +1,208 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ratingSource_Set(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setup   func(f fields)
+		want    *entity.RatingSummary
+		wantErr error
+	}{
+		{
+			name: "success: rating saved and aggregate updated",
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery("SELECT id FROM music WHERE id = \\$1 AND deleted_at IS NULL AND published_at IS NOT NULL .* FOR UPDATE").
+					WithArgs(musicId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(musicId))
+				f.db.ExpectExec("INSERT INTO music_ratings").
+					WithArgs(userId, musicId, 4, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				f.db.ExpectQuery("UPDATE music SET rating_avg").
+					WithArgs(musicId).
+					WillReturnRows(sqlmock.NewRows([]string{"rating_avg", "rating_count"}).AddRow(4.25, int64(4)))
+				f.db.ExpectCommit()
+			},
+			want: &entity.RatingSummary{MusicID: musicId, Rating: 4, Average: 4.25, Count: 4},
+		},
+		{
+			name: "error: music not found",
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery("SELECT id FROM music WHERE id = \\$1 AND deleted_at IS NULL AND published_at IS NOT NULL .* FOR UPDATE").
+					WithArgs(musicId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				f.db.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			ratingSource := db.NewRatingSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(f)
+
+			got, err := ratingSource.Set(context.Background(), userId, musicId, 4, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_ratingSource_Clear(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	tests := []struct {
+		name    string
+		setup   func(f fields)
+		want    *entity.RatingSummary
+		wantErr error
+	}{
+		{
+			name: "success: rating cleared",
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery("SELECT id FROM music WHERE id = \\$1 AND deleted_at IS NULL AND published_at IS NOT NULL .* FOR UPDATE").
+					WithArgs(musicId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(musicId))
+				f.db.ExpectExec("DELETE FROM music_ratings").
+					WithArgs(userId, musicId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				f.db.ExpectQuery("UPDATE music SET rating_avg").
+					WithArgs(musicId).
+					WillReturnRows(sqlmock.NewRows([]string{"rating_avg", "rating_count"}).AddRow(0.0, int64(0)))
+				f.db.ExpectCommit()
+			},
+			want: &entity.RatingSummary{MusicID: musicId},
+		},
+		{
+			name: "error: rating not found",
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery("SELECT id FROM music WHERE id = \\$1 AND deleted_at IS NULL AND published_at IS NOT NULL .* FOR UPDATE").
+					WithArgs(musicId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(musicId))
+				f.db.ExpectExec("DELETE FROM music_ratings").
+					WithArgs(userId, musicId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				f.db.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			ratingSource := db.NewRatingSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(f)
+
+			got, err := ratingSource.Clear(context.Background(), userId, musicId)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_ratingSource_GetLibrary(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	t.Run("success: sorted by rating", func(t *testing.T) {
+		database, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer database.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "name", "user_rating", "liked"}).
+			AddRow(uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"), "Song2", 5, false).
+			AddRow(uuid.MustParse("6cfc3a4d-28ae-4b13-9a11-d2c335ad8658"), "Song1", nil, true)
+		mock.ExpectQuery("ORDER BY r.rating DESC NULLS LAST").
+			WithArgs(userId).
+			WillReturnRows(rows)
+
+		ratingSource := db.NewRatingSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+		got, err := ratingSource.GetLibrary(context.Background(), userId, entity.LibrarySortRating)
+		if assert.NoError(t, err) && assert.Len(t, got, 2) {
+			assert.Equal(t, 5, *got[0].UserRating)
+			assert.False(t, got[0].Liked)
+			assert.Nil(t, got[1].UserRating)
+			assert.True(t, got[1].Liked)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error: unknown sort", func(t *testing.T) {
+		database, _, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer database.Close()
+
+		ratingSource := db.NewRatingSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+		_, err = ratingSource.GetLibrary(context.Background(), userId, "plays")
+		assert.ErrorIs(t, err, entity.ErrUnknownLibrarySort)
+	})
+
+	t.Run("error: exec failed", func(t *testing.T) {
+		database, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer database.Close()
+
+		mock.ExpectQuery("ORDER BY m.name").WillReturnError(fmt.Errorf("can't exec query"))
+
+		ratingSource := db.NewRatingSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+		_, err = ratingSource.GetLibrary(context.Background(), userId, entity.LibrarySortName)
+		assert.Error(t, err)
+	})
+}
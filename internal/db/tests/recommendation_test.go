@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_recommendationSource_Refresh(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+
+	cfg := &entity.SimilarityConfig{Neighbors: 50, MinCoLikes: 2, RefreshTimeout: time.Minute}
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setup   func(f fields)
+		wantErr bool
+	}{
+		{
+			name: "success: similarity recomputed",
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectExec("DELETE FROM music_similarity").WillReturnResult(sqlmock.NewResult(0, 10))
+				f.db.ExpectExec("INSERT INTO music_similarity").
+					WithArgs(cfg.MinCoLikes, cfg.Neighbors, now).
+					WillReturnResult(sqlmock.NewResult(0, 12))
+				f.db.ExpectCommit()
+			},
+		},
+		{
+			name: "error: rollback keeps previous similarity",
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectExec("DELETE FROM music_similarity").WillReturnResult(sqlmock.NewResult(0, 10))
+				f.db.ExpectExec("INSERT INTO music_similarity").WillReturnError(fmt.Errorf("can't exec query"))
+				f.db.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			f := fields{
+				db: mock,
+			}
+
+			recommendationSource := db.NewRecommendationSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			tt.setup(f)
+
+			err = recommendationSource.Refresh(context.Background(), cfg, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_recommendationSource_Refresh_timeout(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	// Пересчет прерывается по собственному таймауту задачи
+	cfg := &entity.SimilarityConfig{Neighbors: 50, MinCoLikes: 2, RefreshTimeout: 20 * time.Millisecond}
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM music_similarity").WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec("INSERT INTO music_similarity").WillDelayFor(time.Second).WillReturnResult(sqlmock.NewResult(0, 10))
+
+	recommendationSource := db.NewRecommendationSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	start := time.Now()
+	err = recommendationSource.Refresh(context.Background(), cfg, time.Now())
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func Test_recommendationSource_GetForUser(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	becauseId := uuid.MustParse("6cfc3a4d-28ae-4b13-9a11-d2c335ad8658")
+	release := time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "name", "release_date", "file_name", "size", "duration", "score", "because_id", "because_name",
+	}).AddRow(musicId, "Song2", release, "Song2.mp3", uint64(500), "3:01", 1.25, becauseId, "Song1")
+	mock.ExpectQuery("FROM user_music um JOIN music_similarity s").
+		WithArgs(userId, 20, 0).
+		WillReturnRows(rows)
+
+	recommendationSource := db.NewRecommendationSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := recommendationSource.GetForUser(context.Background(), userId, 20, 0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*entity.RecommendationDB{
+			{
+				MusicDB: entity.MusicDB{
+					Id:       musicId,
+					Name:     "Song2",
+					Release:  release,
+					FileName: "Song2.mp3",
+					Size:     uint64(500),
+					Duration: "3:01",
+				},
+				Score:       1.25,
+				BecauseID:   becauseId,
+				BecauseName: "Song1",
+			},
+		}, got)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_recommendationSource_GetSimilar(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	mock.ExpectQuery("FROM music_similarity s JOIN music m ON m.id = s.similar_id").
+		WithArgs(musicId, userId, 20, 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "co_likes", "score"}))
+
+	recommendationSource := db.NewRecommendationSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := recommendationSource.GetSimilar(context.Background(), userId, musicId, 20, 40)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
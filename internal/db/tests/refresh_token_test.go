@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_refreshTokenSource_Rotate(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tokenId := uuid.MustParse("9b2f0d55-6b0e-4c8e-8a41-3f9ad3c1e2a7")
+	familyId := uuid.MustParse("0b7e3c1a-4a1f-4d5e-9c55-6f2f8c3e1d20")
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	used := now.Add(-time.Minute)
+	columns := []string{"id", "family_id", "user_id", "token_hash", "created_at", "expires_at", "used_at", "revoked_at"}
+
+	device := entity.NewSessionDevice("", "okhttp/4.12", "203.0.113.7")
+	next := &entity.RefreshTokenDB{
+		ID:        uuid.MustParse("e3c8a4f1-2d6b-4b3f-a0f4-6a1c9e8d7b21"),
+		TokenHash: "next-hash",
+		CreatedAt: now,
+		ExpiresAt: now.Add(24 * time.Hour),
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT t.id, t.family_id, t.user_id").
+					WithArgs("old-hash").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(tokenId, familyId, userId, "old-hash", now.Add(-time.Hour), now.Add(time.Hour), nil, nil))
+				mock.ExpectExec("UPDATE refresh_tokens SET used_at = \\$2 WHERE id = \\$1").
+					WithArgs(tokenId, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO refresh_tokens").
+					WithArgs(next.ID, familyId, userId, "next-hash", now, next.ExpiresAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE sessions SET last_seen_at = \\$2, expires_at = \\$3").
+					WithArgs(familyId, now, next.ExpiresAt, "", "okhttp/4.12", "203.0.113.7").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "error: reused token revokes family",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT t.id, t.family_id, t.user_id").
+					WithArgs("old-hash").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(tokenId, familyId, userId, "old-hash", now.Add(-time.Hour), now.Add(time.Hour), used, nil))
+				mock.ExpectExec("UPDATE sessions SET revoked_at = COALESCE\\(revoked_at, \\$2\\) WHERE id = \\$1").
+					WithArgs(familyId, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE refresh_tokens SET revoked_at = \\$2 WHERE family_id IN \\(SELECT id FROM sessions WHERE id = \\$1\\)").
+					WithArgs(familyId, now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			wantErr: entity.ErrRefreshTokenReused,
+		},
+		{
+			name: "error: revoked token",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT t.id, t.family_id, t.user_id").
+					WithArgs("old-hash").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(tokenId, familyId, userId, "old-hash", now.Add(-time.Hour), now.Add(time.Hour), used, used))
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrInvalidRefreshToken,
+		},
+		{
+			name: "error: expired token",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT t.id, t.family_id, t.user_id").
+					WithArgs("old-hash").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(tokenId, familyId, userId, "old-hash", now.Add(-48*time.Hour), now.Add(-time.Hour), nil, nil))
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrInvalidRefreshToken,
+		},
+		{
+			name: "error: unknown token",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT t.id, t.family_id, t.user_id").
+					WithArgs("old-hash").
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			tt.setup(mock)
+
+			refreshTokenSource := db.NewRefreshTokenSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := refreshTokenSource.Rotate(context.Background(), "old-hash", next, device, now)
+			switch {
+			case errors.Is(tt.wantErr, entity.ErrRefreshTokenReused):
+				// Вместе с ошибкой возвращается семейство, сессия которого отозвана
+				assert.ErrorIs(t, err, tt.wantErr)
+				if assert.NotNil(t, got) {
+					assert.Equal(t, familyId, got.FamilyID)
+				}
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, familyId, got.FamilyID)
+				assert.Equal(t, userId, got.UserID)
+				assert.Equal(t, "next-hash", got.TokenHash)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
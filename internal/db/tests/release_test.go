@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_releaseSource_SetSchedule(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	publishAt := now.Add(24 * time.Hour)
+	schedule := &entity.MusicSchedule{PublishAt: publishAt}
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *entity.MusicDB
+		wantErr error
+	}{
+		{
+			name: "success: publication postponed",
+			rows: sqlmock.NewRows([]string{"id", "name", "publish_at", "published_at", "takedown_at"}).
+				AddRow(musicId, "Song1", publishAt, nil, nil),
+			want: &entity.MusicDB{Id: musicId, Name: "Song1", PublishAt: publishAt},
+		},
+		{
+			name:    "error: music not found",
+			rows:    sqlmock.NewRows([]string{"id"}),
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectQuery("UPDATE music SET publish_at = \\$2, takedown_at = \\$3, "+
+				"published_at = CASE WHEN \\$2 <= \\$4 THEN COALESCE\\(published_at, \\$2\\) ELSE NULL END "+
+				"WHERE id = \\$1 AND deleted_at IS NULL RETURNING \\*").
+				WithArgs(musicId, publishAt, nil, now).
+				WillReturnRows(tt.rows)
+
+			releaseSource := db.NewReleaseSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := releaseSource.SetSchedule(context.Background(), musicId, schedule, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_releaseSource_PublishDue(t *testing.T) {
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectExec("UPDATE music SET published_at = publish_at WHERE published_at IS NULL AND publish_at <= \\$1 AND deleted_at IS NULL").
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	releaseSource := db.NewReleaseSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	published, err := releaseSource.PublishDue(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), published)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_releaseSource_GetScheduled(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	publishAt := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectQuery("SELECT \\* FROM music WHERE deleted_at IS NULL AND \\(published_at IS NULL OR takedown_at > now\\(\\)\\) "+
+		"ORDER BY COALESCE\\(published_at, publish_at\\), takedown_at, id LIMIT \\$1 OFFSET \\$2").
+		WithArgs(20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "publish_at"}).AddRow(musicId, "Song1", publishAt))
+
+	releaseSource := db.NewReleaseSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := releaseSource.GetScheduled(context.Background(), 20, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.MusicDB{{Id: musicId, Name: "Song1", PublishAt: publishAt}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
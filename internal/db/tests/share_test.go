@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_shareSource_GetByTokenHash(t *testing.T) {
+	linkId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	createdAt := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	tokenHash := entity.HashShareToken("token")
+	maxPlays := 3
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *entity.ShareLinkDB
+		wantErr error
+	}{
+		{
+			name: "success",
+			rows: sqlmock.NewRows([]string{
+				"id", "token_hash", "user_id", "music_id", "playlist_id", "expires_at", "max_plays", "plays", "created_at", "revoked_at",
+			}).AddRow(linkId, tokenHash, userId, musicId, nil, nil, maxPlays, 1, createdAt, nil),
+			want: &entity.ShareLinkDB{
+				ID:        linkId,
+				TokenHash: tokenHash,
+				UserID:    userId,
+				MusicID:   &musicId,
+				MaxPlays:  &maxPlays,
+				Plays:     1,
+				CreatedAt: createdAt,
+			},
+		},
+		{
+			name:    "error: unknown token",
+			rows:    sqlmock.NewRows([]string{"id"}),
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectQuery("SELECT l.\\* FROM share_links l JOIN users u ON u.id = l.user_id WHERE l.token_hash = \\$1 AND u.deleted_at IS NULL").
+				WithArgs(tokenHash).
+				WillReturnRows(tt.rows)
+
+			shareSource := db.NewShareSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := shareSource.GetByTokenHash(context.Background(), tokenHash)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_shareSource_ConsumePlay(t *testing.T) {
+	linkId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{
+			name:     "success",
+			affected: 1,
+		},
+		{
+			name:     "error: limit reached concurrently",
+			affected: 0,
+			wantErr:  entity.ErrShareLinkUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectExec("UPDATE share_links SET plays = plays \\+ 1 WHERE id = \\$1 AND revoked_at IS NULL "+
+				"AND \\(expires_at IS NULL OR expires_at > \\$2\\) AND \\(max_plays IS NULL OR plays < max_plays\\)").
+				WithArgs(linkId, now).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			shareSource := db.NewShareSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			err = shareSource.ConsumePlay(context.Background(), linkId, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_shareSource_Revoke(t *testing.T) {
+	linkId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectExec("UPDATE share_links SET revoked_at = COALESCE\\(revoked_at, \\$3\\) WHERE id = \\$1 AND user_id = \\$2").
+		WithArgs(linkId, userId, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	shareSource := db.NewShareSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	err = shareSource.Revoke(context.Background(), userId, linkId, now)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_signingKeySource_Create(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	after := now.Add(-29 * 24 * time.Hour)
+	key := &entity.SigningKeyDB{
+		ID:          "kid",
+		Algorithm:   entity.SigningAlgorithmEdDSA,
+		PrivateKey:  "private",
+		CreatedAt:   now,
+		ActivatesAt: now.Add(24 * time.Hour),
+		RetiresAt:   now.Add(31 * 24 * time.Hour),
+		ExpiresAt:   now.Add(32 * 24 * time.Hour),
+	}
+
+	tests := []struct {
+		name        string
+		affected    int64
+		wantCreated bool
+	}{
+		{
+			name:        "success",
+			affected:    1,
+			wantCreated: true,
+		},
+		{
+			name:        "success: successor already created by another instance",
+			affected:    0,
+			wantCreated: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("SELECT pg_advisory_xact_lock").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec("INSERT INTO signing_keys .* WHERE NOT EXISTS").
+				WithArgs(key.ID, key.Algorithm, key.PrivateKey, key.CreatedAt, key.ActivatesAt, key.RetiresAt, key.ExpiresAt, after, now).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			mock.ExpectCommit()
+
+			signingKeySource := db.NewSigningKeySource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			created, err := signingKeySource.Create(context.Background(), key, after, now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCreated, created)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
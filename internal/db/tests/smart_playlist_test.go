@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_smartPlaylistSource_GetTracks(t *testing.T) {
+	viewerId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	yearStart := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		playlist  *entity.SmartPlaylistDB
+		wantWhere string
+		wantArgs  []driver.Value
+	}{
+		{
+			name: "released this year, under 4 minutes, not liked",
+			playlist: &entity.SmartPlaylistDB{
+				Rules: &entity.SmartRule{Group: entity.SmartGroupAnd, Rules: []*entity.SmartRule{
+					{Field: entity.SmartFieldReleaseDate, Operator: entity.SmartOpInCurrentYear},
+					{Field: entity.SmartFieldDuration, Operator: entity.SmartOpLt, Value: float64(240)},
+					{Field: entity.SmartFieldLiked, Operator: entity.SmartOpEq, Value: false},
+				}},
+				Sort: entity.SmartFieldReleaseDate, Order: entity.SmartOrderDesc, Limit: 50,
+			},
+			wantWhere: "AND ((m.release_date >= $2 AND m.release_date < $3) AND EXTRACT(EPOCH FROM m.duration) < $4::double precision AND (um.music_id IS NOT NULL) = $5) " +
+				"ORDER BY m.release_date DESC NULLS LAST, m.id LIMIT $6",
+			wantArgs: []driver.Value{viewerId, yearStart, yearStart.AddDate(1, 0, 0), float64(240), false, 50},
+		},
+		{
+			name: "text and date conditions in or group",
+			playlist: &entity.SmartPlaylistDB{
+				Rules: &entity.SmartRule{Group: entity.SmartGroupOr, Rules: []*entity.SmartRule{
+					{Field: entity.SmartFieldArtist, Operator: entity.SmartOpContains, Value: "50%_off"},
+					{Field: entity.SmartFieldName, Operator: entity.SmartOpEq, Value: "Intro"},
+					{Field: entity.SmartFieldLastPlayed, Operator: entity.SmartOpLte, Value: "2024-03-01"},
+				}},
+				Sort: entity.SmartFieldRandom, Order: entity.SmartOrderAsc, Limit: 10,
+			},
+			wantWhere: "AND (a.name ILIKE $2 OR lower(m.name) = lower($3) OR p.last_played < $4) ORDER BY random() ASC NULLS LAST, m.id LIMIT $5",
+			wantArgs:  []driver.Value{viewerId, `%50\%\_off%`, "Intro", day.AddDate(0, 0, 1), 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectQuery("^WITH plays AS \\(.*" + regexp.QuoteMeta(tt.wantWhere) + "$").
+				WithArgs(tt.wantArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"), "Track"))
+
+			smartPlaylistSource := db.NewSmartPlaylistSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := smartPlaylistSource.GetTracks(context.Background(), tt.playlist, viewerId, now)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_smartPlaylistSource_Get(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	id := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	rules := &entity.SmartRule{Field: entity.SmartFieldPlayCount, Operator: entity.SmartOpGte, Value: float64(3)}
+	data, err := json.Marshal(rules)
+	assert.NoError(t, err)
+
+	mock.ExpectQuery("SELECT id, user_id, name, is_public, rules, sort, sort_order, track_limit, created_at, updated_at FROM smart_playlists WHERE id = \\$1").
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "is_public", "rules", "sort", "sort_order", "track_limit", "created_at", "updated_at"}).
+			AddRow(id, userId, "On repeat", true, data, entity.SmartFieldPlayCount, entity.SmartOrderDesc, 25, now, now))
+
+	smartPlaylistSource := db.NewSmartPlaylistSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := smartPlaylistSource.Get(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.SmartPlaylistDB{
+		ID: id, UserID: userId, Name: "On repeat", IsPublic: true, Rules: rules,
+		Sort: entity.SmartFieldPlayCount, Order: entity.SmartOrderDesc, Limit: 25, CreatedAt: now, UpdatedAt: now,
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,175 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_statsSource_GetStats(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	artistId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	from := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	filter := &entity.StatsFilter{From: from, To: to, Limit: 5}
+	streakStart := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	streakEnd := time.Date(2023, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		streakRows *sqlmock.Rows
+		wantStreak *entity.ListeningStreak
+	}{
+		{
+			name:       "success",
+			streakRows: sqlmock.NewRows([]string{"start_day", "end_day", "days"}).AddRow(streakStart, streakEnd, 4),
+			wantStreak: &entity.ListeningStreak{Start: streakStart, End: streakEnd, Days: 4},
+		},
+		{
+			name:       "success: no plays",
+			streakRows: sqlmock.NewRows([]string{"start_day", "end_day", "days"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("WITH starts AS \\(.*\\) SELECT COUNT\\(\\*\\) AS plays, .* FROM listened$").
+				WithArgs(userId, from, to).
+				WillReturnRows(sqlmock.NewRows([]string{"plays", "seconds", "tracks", "active_days"}).AddRow(12, 2400, 3, 4))
+			mock.ExpectQuery("WITH starts AS \\(.*\\) SELECT m.\\*, t.plays, t.seconds FROM .* LIMIT \\$4").
+				WithArgs(userId, from, to, 5).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name", "plays", "seconds"}))
+			mock.ExpectQuery("WITH starts AS \\(.*\\) SELECT a.id AS artist_id, .* LIMIT \\$4").
+				WithArgs(userId, from, to, 5).
+				WillReturnRows(sqlmock.NewRows([]string{"artist_id", "name", "plays", "seconds"}).AddRow(artistId, "Artist1", 12, 2400))
+			mock.ExpectQuery("WITH starts AS \\(.*\\) SELECT EXTRACT\\(ISODOW .* GROUP BY 1, 2 ORDER BY 1, 2").
+				WithArgs(userId, from, to).
+				WillReturnRows(sqlmock.NewRows([]string{"weekday", "hour", "plays"}).AddRow(3, 21, 12))
+			mock.ExpectQuery("WITH starts AS \\(.*\\) SELECT MIN\\(day\\) AS start_day, .* LIMIT 1").
+				WithArgs(userId, from, to).
+				WillReturnRows(tt.streakRows)
+			mock.ExpectCommit()
+
+			statsSource := db.NewStatsSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := statsSource.GetStats(context.Background(), userId, filter)
+			assert.NoError(t, err)
+			assert.Equal(t, entity.ListeningTotals{Plays: 12, Seconds: 2400, Tracks: 3, ActiveDays: 4}, got.Totals)
+			assert.Equal(t, []*entity.TopArtistDB{{ArtistID: artistId, Name: "Artist1", Plays: 12, Seconds: 2400}}, got.TopArtists)
+			assert.Equal(t, []*entity.ListeningHourDB{{Weekday: 3, Hour: 21, Plays: 12}}, got.Heatmap)
+			assert.Equal(t, tt.wantStreak, got.LongestStreak)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_statsSource_GetReview(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	generatedAt := time.Date(2024, time.January, 1, 1, 0, 0, 0, time.UTC)
+	stats := &entity.ListeningStats{
+		From:   time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Totals: entity.ListeningTotals{Plays: 12, Seconds: 2400, Tracks: 3, ActiveDays: 4},
+	}
+	report, err := json.Marshal(stats)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *entity.YearReviewDB
+		wantErr error
+	}{
+		{
+			name: "success",
+			rows: sqlmock.NewRows([]string{"report", "generated_at"}).AddRow(report, generatedAt),
+			want: &entity.YearReviewDB{UserID: userId, Year: 2023, Stats: stats, GeneratedAt: generatedAt},
+		},
+		{
+			name:    "error: not generated",
+			rows:    sqlmock.NewRows([]string{"report", "generated_at"}),
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectQuery("SELECT report, generated_at FROM year_reviews WHERE user_id = \\$1 AND year = \\$2").
+				WithArgs(userId, 2023).
+				WillReturnRows(tt.rows)
+
+			statsSource := db.NewStatsSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := statsSource.GetReview(context.Background(), userId, 2023)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_statsSource_GetUsersWithoutReview(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	afterId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	userId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	now := time.Date(2024, time.January, 2, 3, 0, 0, 0, time.UTC)
+	period := entity.YearStatsFilter(2023, 0)
+
+	mock.ExpectQuery("SELECT DISTINCT e.user_id FROM play_events e .* AND e.user_id > \\$4 .*"+
+		"FROM year_review_failures f WHERE .* f.retry_at > \\$5\\) ORDER BY e.user_id LIMIT \\$6").
+		WithArgs(2023, period.From, period.To, afterId, now, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userId))
+
+	statsSource := db.NewStatsSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := statsSource.GetUsersWithoutReview(context.Background(), 2023, afterId, now, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{userId}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_statsSource_RecordReviewFailure(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	failure := &entity.YearReviewFailure{
+		UserID:   uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11"),
+		Year:     2023,
+		Error:    "statement timeout",
+		FailedAt: time.Date(2024, time.January, 2, 3, 0, 0, 0, time.UTC),
+	}
+
+	mock.ExpectExec("INSERT INTO year_review_failures .* ON CONFLICT \\(user_id, year\\) DO UPDATE SET attempts = f.attempts \\+ 1").
+		WithArgs(failure.UserID, failure.Year, failure.Error, failure.FailedAt,
+			entity.YearReviewRetryDelay.Seconds(), entity.MaxYearReviewRetryDelay.Seconds()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	statsSource := db.NewStatsSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	assert.NoError(t, statsSource.RecordReviewFailure(context.Background(), failure))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_syncSource_GetChanges(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	cursor := &entity.SyncCursor{TxID: 100, ID: 7}
+
+	tests := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    []*entity.SyncChangeDB
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, txid::text AS txid, kind, entity_id FROM sync_changes WHERE \\(user_id IS NULL OR user_id = \\$1\\) AND \\(txid, id\\) > \\(\\$2::xid8, \\$3\\) AND txid < \\$4::xid8 ORDER BY txid, id LIMIT \\$5").
+					WithArgs(userId, uint64(100), int64(7), uint64(120), 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "txid", "kind", "entity_id"}).AddRow(8, "101", entity.SyncKindLike, musicId))
+			},
+			want: []*entity.SyncChangeDB{{ID: 8, TxID: 101, Kind: entity.SyncKindLike, EntityID: musicId}},
+		},
+		{
+			name: "error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, txid::text AS txid, kind, entity_id FROM sync_changes").WillReturnError(errors.New("connection reset"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			tt.setup(mock)
+			syncSource := db.NewSyncSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			got, err := syncSource.GetChanges(context.Background(), userId, cursor, 120, 10)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_syncSource_GetTracks(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	mock.ExpectQuery("SELECT m.\\* FROM music m WHERE m.id = ANY\\(\\$2::uuid\\[\\]\\) AND m.deleted_at IS NULL .* AND NOT \\(m.explicit AND EXISTS .*\\) ORDER BY m.id").
+		WithArgs(userId, pq.Array([]string{musicId.String()})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(musicId, "Bohemian Rhapsody"))
+
+	syncSource := db.NewSyncSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := syncSource.GetTracks(context.Background(), userId, []uuid.UUID{musicId})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.MusicDB{{Id: musicId, Name: "Bohemian Rhapsody"}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_syncSource_DeleteChanges(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	before := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("DELETE FROM sync_changes WHERE id IN \\(SELECT id FROM sync_changes WHERE changed_at < \\$1 ORDER BY changed_at LIMIT \\$2\\)").
+		WithArgs(before, 100).
+		WillReturnResult(sqlmock.NewResult(0, 42))
+
+	syncSource := db.NewSyncSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	deleted, err := syncSource.DeleteChanges(context.Background(), before, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,198 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_trashSource_RestoreUser(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	tests := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT username FROM users WHERE id = \\$1 AND deleted_at IS NOT NULL FOR UPDATE").
+					WithArgs(userId).
+					WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("John"))
+				mock.ExpectQuery("SELECT EXISTS").
+					WithArgs("John").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec("UPDATE users SET deleted_at = NULL WHERE id = \\$1").
+					WithArgs(userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "error: username taken",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT username FROM users").
+					WithArgs(userId).
+					WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("John"))
+				mock.ExpectQuery("SELECT EXISTS").
+					WithArgs("John").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrUsernameTaken,
+		},
+		{
+			name: "error: user not in trash",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT username FROM users").
+					WithArgs(userId).
+					WillReturnRows(sqlmock.NewRows([]string{"username"}))
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			tt.setup(mock)
+
+			trashSource := db.NewTrashSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			err = trashSource.RestoreUser(context.Background(), userId)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_trashSource_RestoreMusic(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectExec("UPDATE music SET deleted_at = NULL WHERE id = \\$1 AND deleted_at IS NOT NULL").
+		WithArgs(musicId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	trashSource := db.NewTrashSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	err = trashSource.RestoreMusic(context.Background(), musicId)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_trashSource_PurgeMusic(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	before := time.Date(2023, time.February, 22, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		rows          *sqlmock.Rows
+		wantFileInUse bool
+		wantErr       error
+	}{
+		{
+			name:          "success: file shared with another track",
+			rows:          sqlmock.NewRows([]string{"file_in_use"}).AddRow(true),
+			wantFileInUse: true,
+		},
+		{
+			name:    "error: restored before purge",
+			rows:    sqlmock.NewRows([]string{"file_in_use"}),
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer database.Close()
+
+			mock.ExpectQuery("DELETE FROM music WHERE id = \\$1 AND deleted_at < \\$2 RETURNING EXISTS").
+				WithArgs(musicId, before).
+				WillReturnRows(tt.rows)
+
+			trashSource := db.NewTrashSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+			fileInUse, err := trashSource.PurgeMusic(context.Background(), musicId, before)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantFileInUse, fileInUse)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_trashSource_PurgeUsers(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	before := time.Date(2023, time.February, 22, 12, 0, 0, 0, time.UTC)
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT DISTINCT r.music_id FROM music_ratings r JOIN users u ON u.id = r.user_id WHERE u.deleted_at < \\$1").
+		WithArgs(before).
+		WillReturnRows(sqlmock.NewRows([]string{"music_id"}).AddRow(musicId))
+	mock.ExpectExec("DELETE FROM users WHERE deleted_at < \\$1").
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("UPDATE music SET rating_avg").
+		WithArgs(musicId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	trashSource := db.NewTrashSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	purged, err := trashSource.PurgeUsers(context.Background(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_trashSource_GetMusic(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	deletedAt := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+
+	database, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer database.Close()
+
+	mock.ExpectQuery("SELECT \\* FROM music WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id LIMIT \\$1 OFFSET \\$2").
+		WithArgs(20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "file_name", "deleted_at"}).
+			AddRow(musicId, "Song1", "Song1.mp3", deletedAt))
+
+	trashSource := db.NewTrashSource(db.NewSource(sqlx.NewDb(database, "sqlmock")))
+
+	got, err := trashSource.GetMusic(context.Background(), 20, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.MusicDB{{Id: musicId, Name: "Song1", FileName: "Song1.mp3", DeletedAt: &deletedAt}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
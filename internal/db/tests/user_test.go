@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"music-backend-test/internal/db"
 	"music-backend-test/internal/entity"
@@ -154,7 +155,7 @@ func Test_source_GetUserById(t *testing.T) {
 						"John",
 						"qwerty1234",
 					)
-				f.db.ExpectQuery("SELECT * FROM users WHERE id = $1").WithArgs(a.id.String()).WillReturnRows(rows)
+				f.db.ExpectQuery("SELECT * FROM users WHERE id = $1 AND deleted_at IS NULL").WithArgs(a.id.String()).WillReturnRows(rows)
 			},
 			wantErr: false,
 		},
@@ -166,7 +167,7 @@ func Test_source_GetUserById(t *testing.T) {
 			},
 			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("SELECT * FROM users WHERE id = $1").WithArgs(a.id.String()).WillReturnError(sql.ErrNoRows)
+				f.db.ExpectQuery("SELECT * FROM users WHERE id = $1 AND deleted_at IS NULL").WithArgs(a.id.String()).WillReturnError(sql.ErrNoRows)
 			},
 			wantErr: true,
 		},
@@ -190,7 +191,7 @@ func Test_source_GetUserById(t *testing.T) {
 			},
 			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("SELECT * FROM users WHERE id = $1").WithArgs(a.id.String()).WillReturnError(fmt.Errorf("can't scan user"))
+				f.db.ExpectQuery("SELECT * FROM users WHERE id = $1 AND deleted_at IS NULL").WithArgs(a.id.String()).WillReturnError(fmt.Errorf("can't scan user"))
 			},
 			wantErr: true,
 		},
@@ -260,7 +261,7 @@ func Test_source_GetUserByUsername(t *testing.T) {
 						"John",
 						"qwerty1234",
 					)
-				f.db.ExpectQuery("SELECT * FROM users WHERE username = $1").WithArgs(a.username).WillReturnRows(rows)
+				f.db.ExpectQuery("SELECT * FROM users WHERE username = $1 AND deleted_at IS NULL").WithArgs(a.username).WillReturnRows(rows)
 			},
 			wantErr: false,
 		},
@@ -272,7 +273,7 @@ func Test_source_GetUserByUsername(t *testing.T) {
 			},
 			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("SELECT * FROM users WHERE username = $1").WithArgs(a.username).WillReturnError(sql.ErrNoRows)
+				f.db.ExpectQuery("SELECT * FROM users WHERE username = $1 AND deleted_at IS NULL").WithArgs(a.username).WillReturnError(sql.ErrNoRows)
 			},
 			wantErr: true,
 		},
@@ -296,7 +297,7 @@ func Test_source_GetUserByUsername(t *testing.T) {
 			},
 			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("SELECT * FROM users WHERE username = $1").WithArgs(a.username).WillReturnError(fmt.Errorf("can't scan user"))
+				f.db.ExpectQuery("SELECT * FROM users WHERE username = $1 AND deleted_at IS NULL").WithArgs(a.username).WillReturnError(fmt.Errorf("can't scan user"))
 			},
 			wantErr: true,
 		},
@@ -375,7 +376,7 @@ func Test_source_UpdateUser(t *testing.T) {
 						"John",
 						"qwerty1234",
 					)
-				f.db.ExpectQuery("UPDATE users SET username = $1, password = $2 WHERE id = $3").
+				f.db.ExpectQuery("UPDATE users SET username = $1, password = $2 WHERE id = $3 AND deleted_at IS NULL").
 					WithArgs("John", "qwerty1234", uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).
 					WillReturnRows(rows)
 			},
@@ -419,7 +420,7 @@ func Test_source_UpdateUser(t *testing.T) {
 			},
 			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("UPDATE users SET username = $1, password = $2 WHERE id = $3").
+				f.db.ExpectQuery("UPDATE users SET username = $1, password = $2 WHERE id = $3 AND deleted_at IS NULL").
 					WithArgs("John", "qwerty1234", uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).
 					WillReturnError(fmt.Errorf("can't scan user"))
 			},
@@ -453,6 +454,78 @@ func Test_source_UpdateUser(t *testing.T) {
 	}
 }
 
+func Test_source_UpdatePassword(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+	type args struct {
+		ctx      context.Context
+		id       uuid.UUID
+		previous string
+		password string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, f fields)
+		wantErr bool
+	}{
+		{
+			name: "success: UpdatePassword source",
+			args: args{
+				ctx:      context.Background(),
+				id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				previous: "qwerty1234",
+				password: "$argon2id$v=19$m=19456,t=2,p=1$c2FsdA$aGFzaA",
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectExec("UPDATE users SET password = $3 WHERE id = $1 AND password = $2 AND deleted_at IS NULL").
+					WithArgs(a.id, a.previous, a.password).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error: UpdatePassword source: can't exec query",
+			args: args{
+				ctx:      context.Background(),
+				id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				previous: "qwerty1234",
+				password: "$argon2id$v=19$m=19456,t=2,p=1$c2FsdA$aGFzaA",
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectExec("UPDATE users SET password = $3 WHERE id = $1 AND password = $2 AND deleted_at IS NULL").
+					WithArgs(a.id, a.previous, a.password).
+					WillReturnError(fmt.Errorf("can't exec query"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Errorf("can't connect to database: %v", err)
+				return
+			}
+			f := fields{
+				db: mock,
+			}
+
+			usersSource := db.NewUserSourсe(db.NewSource(sqlx.NewDb(source, "sqlmock")))
+
+			tt.setup(tt.args, f)
+
+			if err := usersSource.UpdatePassword(tt.args.ctx, tt.args.id, tt.args.previous, tt.args.password); (err != nil) != tt.wantErr {
+				t.Errorf("source.UpdatePassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
 func Test_source_DeleteUser(t *testing.T) {
 	type fields struct {
 		db sqlmock.Sqlmock
@@ -460,6 +533,7 @@ func Test_source_DeleteUser(t *testing.T) {
 	type args struct {
 		ctx context.Context
 		id  uuid.UUID
+		now time.Time
 	}
 	tests := []struct {
 		name    string
@@ -473,6 +547,7 @@ func Test_source_DeleteUser(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				id:  uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				now: time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
 			},
 			want: nil,
 			setup: func(a args, f fields) {
@@ -488,8 +563,8 @@ func Test_source_DeleteUser(t *testing.T) {
 						"qwerty1234",
 					)
 
-				f.db.ExpectQuery("DELETE FROM users WHERE id = $1").
-					WithArgs(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).
+				f.db.ExpectQuery("UPDATE users SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL").
+					WithArgs(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"), a.now).
 					WillReturnRows(rows)
 			},
 			wantErr: false,
@@ -499,11 +574,12 @@ func Test_source_DeleteUser(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				id:  uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				now: time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
 			},
 			want: nil,
 			setup: func(a args, f fields) {
 				f.db.ExpectQuery("ABRA-CADABRA").
-					WithArgs(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).
+					WithArgs(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"), a.now).
 					WillReturnError(fmt.Errorf("can't exec query"))
 			},
 			wantErr: true,
@@ -524,7 +600,7 @@ func Test_source_DeleteUser(t *testing.T) {
 
 			tt.setup(tt.args, f)
 
-			if err := usersSource.DeleteUser(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
+			if err := usersSource.DeleteUser(tt.args.ctx, tt.args.id, tt.args.now); (err != nil) != tt.wantErr {
 				t.Errorf("source.DeleteUser() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -556,7 +632,7 @@ func Test_source_LikeTrack(t *testing.T) {
 			},
 			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("INSERT INTO user_music (user_id, music_id) VALUES ($1, $2)").
+				f.db.ExpectQuery("INSERT INTO user_music (user_id, music_id) SELECT $1, $2 WHERE EXISTS (SELECT 1 FROM music WHERE id = $2 AND deleted_at IS NULL AND published_at IS NOT NULL AND (takedown_at IS NULL OR takedown_at > now()))").
 					WithArgs(
 						a.userId,
 						a.trackId,
@@ -734,7 +810,7 @@ func Test_source_ShowLikedTracks(t *testing.T) {
 					"duration",
 				)
 
-				f.db.ExpectQuery("SELECT music.* FROM music JOIN user_music ON music.id = user_music.music_id WHERE user_music.user_id = $1").
+				f.db.ExpectQuery("SELECT music.* FROM music JOIN user_music ON music.id = user_music.music_id JOIN users ON users.id = user_music.user_id WHERE user_music.user_id = $1 AND music.deleted_at IS NULL AND music.published_at IS NOT NULL AND (music.takedown_at IS NULL OR music.takedown_at > now()) AND NOT (music.explicit AND users.hide_explicit)").
 					WithArgs(
 						a.id,
 					).WillReturnRows(rows)
@@ -781,7 +857,7 @@ func Test_source_ShowLikedTracks(t *testing.T) {
 					"duration",
 				)
 
-				f.db.ExpectQuery("SELECT music.* FROM music JOIN user_music ON music.id = user_music.music_id WHERE user_music.user_id = $1").
+				f.db.ExpectQuery("SELECT music.* FROM music JOIN user_music ON music.id = user_music.music_id JOIN users ON users.id = user_music.user_id WHERE user_music.user_id = $1 AND music.deleted_at IS NULL AND music.published_at IS NOT NULL AND (music.takedown_at IS NULL OR music.takedown_at > now()) AND NOT (music.explicit AND users.hide_explicit)").
 					WithArgs(
 						a.id,
 					).WillReturnRows(rows).WillReturnError(fmt.Errorf("can't scan rows"))
@@ -815,3 +891,47 @@ func Test_source_ShowLikedTracks(t *testing.T) {
 		})
 	}
 }
+
+func Test_source_SetContentFilter(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	tests := []struct {
+		name    string
+		result  sql.Result
+		wantErr error
+	}{
+		{
+			name:   "success: content filter set",
+			result: sqlmock.NewResult(0, 1),
+		},
+		{
+			name:    "error: user not found",
+			result:  sqlmock.NewResult(0, 0),
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Errorf("can't connect to database: %v", err)
+				return
+			}
+			defer source.Close()
+
+			mock.ExpectExec("UPDATE users SET hide_explicit = $2 WHERE id = $1 AND deleted_at IS NULL").
+				WithArgs(userId, true).
+				WillReturnResult(tt.result)
+
+			usersSource := db.NewUserSourсe(db.NewSource(sqlx.NewDb(source, "sqlmock")))
+
+			err = usersSource.SetContentFilter(context.Background(), userId, &entity.ContentFilter{HideExplicit: true})
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("source.SetContentFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("source.SetContentFilter() unexpected error = %v", err)
+			}
+		})
+	}
+}
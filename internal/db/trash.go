@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Строка удаляется, только если трек все еще в корзине и срок хранения истек.
+// Файл трека может быть занят другим треком с тем же именем файла, тогда его нельзя удалять.
+const purgeMusicQuery = "DELETE FROM music WHERE id = $1 AND deleted_at < $2 " +
+	"RETURNING EXISTS (SELECT 1 FROM music o WHERE o.file_name = music.file_name AND o.id <> music.id) AS file_in_use"
+
+type trashSource struct {
+	db *sqlx.DB
+}
+
+func NewTrashSource(source *source) *trashSource {
+	return &trashSource{
+		db: source.db,
+	}
+}
+
+func (t *trashSource) GetMusic(ctx context.Context, limit int, offset int) ([]*entity.MusicDB, error) {
+	return t.queryMusic(ctx,
+		"SELECT * FROM music WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id LIMIT $1 OFFSET $2", limit, offset)
+}
+
+// GetExpiredMusic возвращает треки, пролежавшие в корзине дольше срока хранения
+func (t *trashSource) GetExpiredMusic(ctx context.Context, before time.Time) ([]*entity.MusicDB, error) {
+	return t.queryMusic(ctx, "SELECT * FROM music WHERE deleted_at < $1 ORDER BY deleted_at", before)
+}
+
+func (t *trashSource) GetUsers(ctx context.Context, limit int, offset int) ([]*entity.UserDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := t.db.QueryxContext(dbCtx,
+		"SELECT * FROM users WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id LIMIT $1 OFFSET $2", limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.UserDB
+	for rows.Next() {
+		var scanEntity entity.UserDB
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan user: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
+
+// RestoreMusic возвращает трек из корзины. Если трека в корзине нет, возвращается sql.ErrNoRows.
+func (t *trashSource) RestoreMusic(ctx context.Context, id uuid.UUID) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := t.db.ExecContext(dbCtx, "UPDATE music SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// RestoreUser возвращает пользователя из корзины. Если пользователя в корзине нет, возвращается sql.ErrNoRows,
+// если его имя занял другой пользователь — entity.ErrUsernameTaken.
+func (t *trashSource) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := t.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var username string
+	err = tx.QueryRowxContext(dbCtx, "SELECT username FROM users WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE", id).Scan(&username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return err
+		}
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	var taken bool
+	err = tx.QueryRowxContext(dbCtx, "SELECT EXISTS (SELECT 1 FROM users WHERE username = $1 AND deleted_at IS NULL)", username).Scan(&taken)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	if taken {
+		return entity.ErrUsernameTaken
+	}
+
+	_, err = tx.ExecContext(dbCtx, "UPDATE users SET deleted_at = NULL WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeMusic окончательно удаляет трек вместе с версиями, текстами, комментариями и оценками.
+// Возвращает true, если файл трека используется другим треком. Если трек успели восстановить, возвращается sql.ErrNoRows.
+func (t *trashSource) PurgeMusic(ctx context.Context, id uuid.UUID, before time.Time) (bool, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	var fileInUse bool
+	err := t.db.QueryRowxContext(dbCtx, purgeMusicQuery, id, before).Scan(&fileInUse)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, err
+		}
+		return false, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return fileInUse, nil
+}
+
+// PurgeUsers окончательно удаляет пользователей, пролежавших в корзине дольше срока хранения.
+// Оценки пользователей удаляются каскадно, поэтому средние оценки их треков пересчитываются в той же транзакции.
+func (t *trashSource) PurgeUsers(ctx context.Context, before time.Time) (int64, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := t.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryxContext(dbCtx,
+		"SELECT DISTINCT r.music_id FROM music_ratings r JOIN users u ON u.id = r.user_id WHERE u.deleted_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+	var rated []uuid.UUID
+	for rows.Next() {
+		var musicId uuid.UUID
+		if err := rows.Scan(&musicId); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("can't scan music id: %w", err)
+		}
+		rated = append(rated, musicId)
+	}
+	rows.Close()
+
+	res, err := tx.ExecContext(dbCtx, "DELETE FROM users WHERE deleted_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't get affected rows: %w", err)
+	}
+
+	for _, musicId := range rated {
+		if _, err := tx.ExecContext(dbCtx, updateRatingAggregateQuery, musicId); err != nil {
+			return 0, fmt.Errorf("can't exec query: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return purged, nil
+}
+
+func (t *trashSource) queryMusic(ctx context.Context, query string, args ...any) ([]*entity.MusicDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	rows, err := t.db.QueryxContext(dbCtx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*entity.MusicDB
+	for rows.Next() {
+		var scanEntity entity.MusicDB
+		if err := rows.StructScan(&scanEntity); err != nil {
+			return nil, fmt.Errorf("can't scan music: %w", err)
+		}
+		data = append(data, &scanEntity)
+	}
+
+	return data, nil
+}
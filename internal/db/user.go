@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"music-backend-test/internal/entity"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -39,7 +40,7 @@ func (u *UserSourсe) GetUserById(ctx context.Context, id uuid.UUID) (*entity.Us
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	row := u.db.QueryRowxContext(dbCtx, "SELECT * FROM users WHERE id = $1", id.String())
+	row := u.db.QueryRowxContext(dbCtx, "SELECT * FROM users WHERE id = $1 AND deleted_at IS NULL", id.String())
 	if row.Err() != nil {
 		return nil, fmt.Errorf("can't exec query: %w", row.Err())
 	}
@@ -59,7 +60,7 @@ func (u *UserSourсe) GetUserByUsername(ctx context.Context, username string) (*
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	row := u.db.QueryRowxContext(dbCtx, "SELECT * FROM users WHERE username = $1", username)
+	row := u.db.QueryRowxContext(dbCtx, "SELECT * FROM users WHERE username = $1 AND deleted_at IS NULL", username)
 	if row.Err() != nil {
 		return nil, fmt.Errorf("can't exec query: %w", row.Err())
 	}
@@ -79,7 +80,7 @@ func (u *UserSourсe) UpdateUser(ctx context.Context, userDB *entity.UserDB, use
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	row := u.db.QueryRowxContext(dbCtx, "UPDATE users SET username = $1, password = $2 WHERE id = $3",
+	row := u.db.QueryRowxContext(dbCtx, "UPDATE users SET username = $1, password = $2 WHERE id = $3 AND deleted_at IS NULL",
 		user.Username, user.Password, userDB.ID.String())
 	if row.Err() != nil {
 		return nil, fmt.Errorf("can't exec query: %w", row.Err())
@@ -90,11 +91,27 @@ func (u *UserSourсe) UpdateUser(ctx context.Context, userDB *entity.UserDB, use
 	return userDB, nil
 }
 
-func (u *UserSourсe) DeleteUser(ctx context.Context, id uuid.UUID) error {
+// UpdatePassword заменяет сохраненный пароль, если он не изменился с момента чтения.
+// Так повторное хэширование при входе не перезаписывает пароль, измененный параллельным запросом.
+func (u *UserSourсe) UpdatePassword(ctx context.Context, id uuid.UUID, previous string, password string) error {
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	row := u.db.QueryRowxContext(dbCtx, "DELETE FROM users WHERE id = $1", id.String())
+	_, err := u.db.ExecContext(dbCtx, "UPDATE users SET password = $3 WHERE id = $1 AND password = $2 AND deleted_at IS NULL",
+		id, previous, password)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUser перемещает пользователя в корзину. Лайки, оценки и история сохраняются до очистки корзины.
+func (u *UserSourсe) DeleteUser(ctx context.Context, id uuid.UUID, now time.Time) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	row := u.db.QueryRowxContext(dbCtx, "UPDATE users SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL", id.String(), now)
 	if row.Err() != nil {
 		return fmt.Errorf("can't exec query: %w", row.Err())
 	}
@@ -106,7 +123,7 @@ func (u *UserSourсe) LikeTrack(ctx context.Context, userId uuid.UUID, trackId u
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	row := u.db.QueryRowxContext(dbCtx, "INSERT INTO user_music (user_id, music_id) VALUES ($1, $2)", userId.String(), trackId.String())
+	row := u.db.QueryRowxContext(dbCtx, "INSERT INTO user_music (user_id, music_id) SELECT $1, $2 WHERE EXISTS (SELECT 1 FROM music WHERE id = $2 AND "+availableMusic("")+")", userId.String(), trackId.String())
 	if row.Err() != nil {
 		return row.Err()
 	}
@@ -132,7 +149,9 @@ func (u *UserSourсe) ShowLikedTracks(ctx context.Context, id uuid.UUID) ([]*ent
 
 	rows, err := u.db.QueryxContext(
 		dbCtx,
-		"SELECT music.* FROM music JOIN user_music ON music.id = user_music.music_id WHERE user_music.user_id = $1",
+		"SELECT music.* FROM music JOIN user_music ON music.id = user_music.music_id JOIN users ON users.id = user_music.user_id "+
+			"WHERE user_music.user_id = $1 AND "+availableMusic("music")+" "+
+			"AND NOT (music.explicit AND users.hide_explicit)",
 		id.String(),
 	)
 	if err != nil {
@@ -152,3 +171,24 @@ func (u *UserSourсe) ShowLikedTracks(ctx context.Context, id uuid.UUID) ([]*ent
 
 	return data, nil
 }
+
+// SetContentFilter сохраняет настройки фильтра контента. Если пользователя нет, возвращается sql.ErrNoRows.
+func (u *UserSourсe) SetContentFilter(ctx context.Context, id uuid.UUID, filter *entity.ContentFilter) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	res, err := u.db.ExecContext(dbCtx, "UPDATE users SET hide_explicit = $2 WHERE id = $1 AND deleted_at IS NULL", id, filter.HideExplicit)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
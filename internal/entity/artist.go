@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const MaxArtistNameLength = 255
+
+var ErrInvalidArtist = errors.New("invalid artist")
+
+type ArtistDB struct {
+	ID        uuid.UUID `db:"id"`         // id исполнителя
+	Name      string    `db:"name"`       // имя исполнителя
+	CreatedAt time.Time `db:"created_at"` // время создания
+}
+
+type ArtistCreate struct {
+	Name string `json:"name"` // имя исполнителя
+}
+
+func (a *ArtistCreate) Validate() error {
+	name := strings.TrimSpace(a.Name)
+	if name == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidArtist)
+	}
+	if utf8.RuneCountInString(name) > MaxArtistNameLength {
+		return fmt.Errorf("%w: name is longer than %d characters", ErrInvalidArtist, MaxArtistNameLength)
+	}
+	return nil
+}
+
+func (a *ArtistCreate) ToDB(now time.Time) *ArtistDB {
+	return &ArtistDB{
+		ID:        uuid.New(),
+		Name:      strings.TrimSpace(a.Name),
+		CreatedAt: now,
+	}
+}
+
+// Исполнитель трека
+type MusicArtist struct {
+	ArtistID *uuid.UUID `json:"artist_id"` // id исполнителя, null чтобы убрать исполнителя
+}
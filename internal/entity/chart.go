@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"errors"
+	"music-backend-test/cmd/music-backend-test/config"
+	"time"
+)
+
+const (
+	ChartMovementNew     string = "new"
+	ChartMovementUp      string = "up"
+	ChartMovementDown    string = "down"
+	ChartMovementSame    string = "same"
+	ChartMovementReEntry string = "re-entry"
+)
+
+const DefaultChartSize = 100
+
+var ErrChartNotFound = errors.New("chart not found")
+
+// Параметры снимка недельного чарта
+type ChartConfig struct {
+	Size       int     // количество позиций в чарте
+	PlayWeight float64 // вес прослушивания
+	LikeWeight float64 // вес лайка
+}
+
+func NewChartConfig(cfg *config.Config) *ChartConfig {
+	size := cfg.Chart.Size
+	if size <= 0 {
+		size = DefaultChartSize
+	}
+
+	return &ChartConfig{
+		Size:       size,
+		PlayWeight: cfg.Popularity.PlayWeight,
+		LikeWeight: cfg.Popularity.LikeWeight,
+	}
+}
+
+// Позиция трека в недельном чарте
+type ChartEntryDB struct {
+	MusicDB
+	Week             time.Time `db:"week"`              // понедельник недели чарта
+	Position         int       `db:"position"`          // позиция в чарте
+	Plays            int64     `db:"plays"`             // количество прослушиваний за неделю
+	Likes            int64     `db:"likes"`             // количество лайков за неделю
+	Score            float64   `db:"score"`             // рейтинг за неделю
+	PreviousPosition *int      `db:"previous_position"` // позиция на предыдущей неделе, nil если трека не было в чарте
+	PeakPosition     int       `db:"peak_position"`     // лучшая позиция до этой недели включительно
+	WeeksOnChart     int       `db:"weeks_on_chart"`    // количество недель в чарте до этой недели включительно
+}
+
+// Movement возвращает изменение позиции относительно предыдущей недели
+func (e *ChartEntryDB) Movement() string {
+	switch {
+	case e.PreviousPosition == nil && e.WeeksOnChart > 1:
+		return ChartMovementReEntry
+	case e.PreviousPosition == nil:
+		return ChartMovementNew
+	case e.Position < *e.PreviousPosition:
+		return ChartMovementUp
+	case e.Position > *e.PreviousPosition:
+		return ChartMovementDown
+	default:
+		return ChartMovementSame
+	}
+}
+
+// ChartWeekStart возвращает начало недели (понедельник 00:00 UTC), в которую попадает t
+func ChartWeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,278 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/cmd/music-backend-test/config"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CommentStatusVisible string = "visible"
+	CommentStatusHidden  string = "hidden"
+	CommentStatusDeleted string = "deleted"
+)
+
+const (
+	CommentSortNewest string = "newest"
+	CommentSortTop    string = "top"
+)
+
+const (
+	ReportStatusOpen      string = "open"
+	ReportStatusResolved  string = "resolved"
+	ReportStatusDismissed string = "dismissed"
+)
+
+const (
+	MaxCommentLength      = 2000
+	MaxReportReasonLength = 500
+
+	DefaultCommentLimit = 20
+	MaxCommentLimit     = 100
+
+	DefaultCommentRateLimit  = 10
+	DefaultCommentRateWindow = time.Minute
+)
+
+var (
+	ErrInvalidComment       = errors.New("invalid comment")
+	ErrCommentForbidden     = errors.New("comment belongs to another user")
+	ErrCommentReplyDepth    = errors.New("replies to replies are not allowed")
+	ErrCommentRateLimited   = errors.New("comment rate limit exceeded")
+	ErrUnknownCommentSort   = errors.New("unknown comment sort")
+	ErrInvalidCommentCursor = errors.New("invalid comment cursor")
+	ErrDuplicateReport      = errors.New("comment already reported")
+)
+
+// Ограничение частоты публикации комментариев
+type CommentConfig struct {
+	RateLimit  int           // максимальное количество комментариев за окно
+	RateWindow time.Duration // окно ограничения
+}
+
+func NewCommentConfig(cfg *config.Config) *CommentConfig {
+	limit := cfg.Comments.RateLimit
+	if limit <= 0 {
+		limit = DefaultCommentRateLimit
+	}
+	window := cfg.Comments.RateWindow
+	if window <= 0 {
+		window = DefaultCommentRateWindow
+	}
+
+	return &CommentConfig{
+		RateLimit:  limit,
+		RateWindow: window,
+	}
+}
+
+type CommentDB struct {
+	ID          uuid.UUID  `db:"id"`           // id комментария
+	MusicID     *uuid.UUID `db:"music_id"`     // id трека, nil для комментария к плейлисту
+	PlaylistID  *uuid.UUID `db:"playlist_id"`  // id плейлиста, nil для комментария к треку
+	UserID      uuid.UUID  `db:"user_id"`      // id автора
+	Username    string     `db:"username"`     // имя автора
+	ParentID    *uuid.UUID `db:"parent_id"`    // id комментария, на который дан ответ
+	Body        string     `db:"body"`         // текст комментария
+	Status      string     `db:"status"`       // статус (visible, hidden, deleted)
+	ReplyCount  int        `db:"reply_count"`  // количество видимых ответов
+	CreatedAt   time.Time  `db:"created_at"`   // время создания
+	EditedAt    *time.Time `db:"edited_at"`    // время последнего редактирования
+	ModeratedBy *uuid.UUID `db:"moderated_by"` // id модератора, изменившего статус
+	ModeratedAt *time.Time `db:"moderated_at"` // время изменения статуса модератором
+}
+
+type CommentCreate struct {
+	Body     string     `json:"body"`      // текст комментария
+	ParentID *uuid.UUID `json:"parent_id"` // id комментария, на который дается ответ
+}
+
+func (c *CommentCreate) Validate() error {
+	return validateCommentBody(c.Body)
+}
+
+// ToDB создает комментарий к треку
+func (c *CommentCreate) ToDB(userId uuid.UUID, musicId uuid.UUID, now time.Time) *CommentDB {
+	return &CommentDB{
+		ID:        uuid.New(),
+		MusicID:   &musicId,
+		UserID:    userId,
+		ParentID:  c.ParentID,
+		Body:      strings.TrimSpace(c.Body),
+		Status:    CommentStatusVisible,
+		CreatedAt: now,
+	}
+}
+
+// ToPlaylistDB создает комментарий к плейлисту
+func (c *CommentCreate) ToPlaylistDB(userId uuid.UUID, playlistId uuid.UUID, now time.Time) *CommentDB {
+	return &CommentDB{
+		ID:         uuid.New(),
+		PlaylistID: &playlistId,
+		UserID:     userId,
+		ParentID:   c.ParentID,
+		Body:       strings.TrimSpace(c.Body),
+		Status:     CommentStatusVisible,
+		CreatedAt:  now,
+	}
+}
+
+// SameTarget сообщает, относятся ли комментарии к одному треку или плейлисту
+func (c *CommentDB) SameTarget(other *CommentDB) bool {
+	return sameID(c.MusicID, other.MusicID) && sameID(c.PlaylistID, other.PlaylistID)
+}
+
+func sameID(a *uuid.UUID, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+type CommentUpdate struct {
+	Body string `json:"body"` // новый текст комментария
+}
+
+func (c *CommentUpdate) Validate() error {
+	return validateCommentBody(c.Body)
+}
+
+func validateCommentBody(body string) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return fmt.Errorf("%w: empty body", ErrInvalidComment)
+	}
+	if utf8.RuneCountInString(body) > MaxCommentLength {
+		return fmt.Errorf("%w: body is longer than %d characters", ErrInvalidComment, MaxCommentLength)
+	}
+	return nil
+}
+
+// Позиция, с которой продолжается выдача комментариев
+type CommentCursor struct {
+	CreatedAt  time.Time `json:"t"`
+	ID         uuid.UUID `json:"id"`
+	ReplyCount int       `json:"r,omitempty"`
+}
+
+func (c *CommentCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCommentCursor(value string) (*CommentCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCommentCursor, err)
+	}
+
+	var cursor CommentCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCommentCursor, err)
+	}
+
+	return &cursor, nil
+}
+
+// Параметры выдачи комментариев
+type CommentFilter struct {
+	Sort            string         // newest или top
+	Cursor          *CommentCursor // позиция продолжения, nil для первой страницы
+	Limit           int            // размер страницы
+	IncludeHidden   bool           // показывать скрытые и удаленные комментарии (для администраторов)
+	ShowUnpublished bool           // показывать комментарии неопубликованных и снятых с публикации треков (для администраторов)
+	ViewerID        uuid.UUID      // читатель; комментарии приватного плейлиста видны только его владельцу
+}
+
+// Normalize проверяет сортировку и приводит размер страницы к допустимым значениям
+func (f *CommentFilter) Normalize() error {
+	switch f.Sort {
+	case "":
+		f.Sort = CommentSortNewest
+	case CommentSortNewest, CommentSortTop:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownCommentSort, f.Sort)
+	}
+
+	f.Limit, _ = NormalizeCommentPage(f.Limit, 0)
+	return nil
+}
+
+// NormalizeCommentPage приводит параметры пагинации к допустимым значениям
+func NormalizeCommentPage(limit int, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultCommentLimit
+	}
+	if limit > MaxCommentLimit {
+		limit = MaxCommentLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+type CommentPage struct {
+	Comments   []*CommentDB // комментарии страницы
+	NextCursor string       // курсор следующей страницы, пустой если страница последняя
+}
+
+// NewCommentPage обрезает выборку размером limit+1 до limit и формирует курсор следующей страницы
+func NewCommentPage(comments []*CommentDB, limit int) *CommentPage {
+	page := &CommentPage{Comments: comments}
+	if len(comments) <= limit {
+		return page
+	}
+
+	page.Comments = comments[:limit]
+	last := page.Comments[limit-1]
+	cursor := &CommentCursor{CreatedAt: last.CreatedAt, ID: last.ID, ReplyCount: last.ReplyCount}
+	page.NextCursor = cursor.Encode()
+	return page
+}
+
+type ReportCreate struct {
+	Reason string `json:"reason"` // причина жалобы
+}
+
+func (r *ReportCreate) Validate() error {
+	if utf8.RuneCountInString(r.Reason) > MaxReportReasonLength {
+		return fmt.Errorf("%w: reason is longer than %d characters", ErrInvalidComment, MaxReportReasonLength)
+	}
+	return nil
+}
+
+type CommentReportDB struct {
+	ID        uuid.UUID `db:"id"`         // id жалобы
+	CommentID uuid.UUID `db:"comment_id"` // id комментария
+	UserID    uuid.UUID `db:"user_id"`    // id пожаловавшегося пользователя
+	Reason    string    `db:"reason"`     // причина жалобы
+	Status    string    `db:"status"`     // статус (open, resolved, dismissed)
+	CreatedAt time.Time `db:"created_at"` // время создания
+}
+
+func (r *ReportCreate) ToDB(userId uuid.UUID, commentId uuid.UUID, now time.Time) *CommentReportDB {
+	return &CommentReportDB{
+		ID:        uuid.New(),
+		CommentID: commentId,
+		UserID:    userId,
+		Reason:    strings.TrimSpace(r.Reason),
+		Status:    ReportStatusOpen,
+		CreatedAt: now,
+	}
+}
+
+// Комментарий в очереди модерации
+type ModerationItemDB struct {
+	CommentDB
+	Reports        int       `db:"reports"`          // количество открытых жалоб
+	LastReason     string    `db:"last_reason"`      // причина последней жалобы
+	LastReportedAt time.Time `db:"last_reported_at"` // время последней жалобы
+}
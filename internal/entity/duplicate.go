@@ -0,0 +1,172 @@
+package entity
+
+import (
+	"errors"
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DuplicateDurationTolerance    = 3    // допустимая разница продолжительности дубликатов в секундах
+	DuplicateFingerprintThreshold = 0.85 // доля совпадающих битов отпечатка, с которой треки считаются одинаковыми
+	DuplicateFingerprintMinBits   = 32   // минимальное количество сравниваемых битов отпечатка
+	DuplicateFingerprintMaxShift  = 4    // максимальный сдвиг отпечатков при сравнении, в битах
+	MaxDuplicateCandidates        = 5    // количество предупреждений о дубликатах для одного трека
+	DuplicateCandidateLimit       = 500  // количество треков каталога, сравниваемых с загруженным
+)
+
+var ErrInvalidMerge = errors.New("invalid merge")
+
+// Признаки файла трека: хэш содержимого и отпечаток огибающей громкости. Отпечатка нет, если файл не удалось разобрать.
+type MusicFingerprintDB struct {
+	MusicID     uuid.UUID `db:"music_id"`     // id трека
+	ContentHash string    `db:"content_hash"` // SHA-256 файла в шестнадцатеричном виде
+	Fingerprint []byte    `db:"fingerprint"`  // отпечаток звука
+	CreatedAt   time.Time `db:"created_at"`   // время вычисления
+}
+
+// Трек каталога, с которым сравнивается загруженный трек
+type DuplicateCandidateDB struct {
+	MusicID      uuid.UUID `db:"music_id"`      // id трека
+	Name         string    `db:"name"`          // название трека
+	DurationDiff int64     `db:"duration_diff"` // разница продолжительности с загруженным треком в секундах
+	ContentHash  *string   `db:"content_hash"`  // хэш файла, nil для треков, загруженных до появления проверки
+	Fingerprint  []byte    `db:"fingerprint"`   // отпечаток звука
+}
+
+// Вероятный дубликат: трек MusicID похож на трек CandidateID
+type MusicDuplicateDB struct {
+	MusicID       uuid.UUID `db:"music_id"`       // id трека
+	CandidateID   uuid.UUID `db:"candidate_id"`   // id похожего трека
+	CandidateName string    `db:"candidate_name"` // название похожего трека
+	SameHash      bool      `db:"same_hash"`      // файлы совпадают побайтно
+	SameTitle     bool      `db:"same_title"`     // совпадают нормализованные названия при близкой продолжительности
+	Similarity    *float64  `db:"similarity"`     // сходство отпечатков от 0 до 1, nil если отпечатка нет
+	DetectedAt    time.Time `db:"detected_at"`    // время обнаружения
+}
+
+// DetectDuplicates сравнивает загруженный трек с кандидатами и возвращает вероятные дубликаты:
+// сначала совпадающие файлы, затем по убыванию сходства отпечатков
+func DetectDuplicates(music *MusicDB, fingerprint *MusicFingerprintDB, candidates []*DuplicateCandidateDB, now time.Time) []*MusicDuplicateDB {
+	title := NormalizeImportTitle(music.Name)
+
+	var duplicates []*MusicDuplicateDB
+	for _, candidate := range candidates {
+		if candidate.MusicID == music.Id {
+			continue
+		}
+
+		duplicate := &MusicDuplicateDB{
+			MusicID:       music.Id,
+			CandidateID:   candidate.MusicID,
+			CandidateName: candidate.Name,
+			SameHash:      candidate.ContentHash != nil && *candidate.ContentHash == fingerprint.ContentHash,
+			SameTitle:     title != "" && NormalizeImportTitle(candidate.Name) == title && candidate.DurationDiff <= DuplicateDurationTolerance,
+			DetectedAt:    now,
+		}
+		similar := false
+		if similarity, ok := FingerprintSimilarity(fingerprint.Fingerprint, candidate.Fingerprint); ok {
+			duplicate.Similarity = &similarity
+			similar = similarity >= DuplicateFingerprintThreshold && candidate.DurationDiff <= DuplicateDurationTolerance
+		}
+
+		if duplicate.SameHash || duplicate.SameTitle || similar {
+			duplicates = append(duplicates, duplicate)
+		}
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		if duplicates[i].SameHash != duplicates[j].SameHash {
+			return duplicates[i].SameHash
+		}
+		return similarityOf(duplicates[i]) > similarityOf(duplicates[j])
+	})
+	if len(duplicates) > MaxDuplicateCandidates {
+		duplicates = duplicates[:MaxDuplicateCandidates]
+	}
+
+	return duplicates
+}
+
+func similarityOf(duplicate *MusicDuplicateDB) float64 {
+	if duplicate.Similarity == nil {
+		return 0
+	}
+	return *duplicate.Similarity
+}
+
+// FingerprintSimilarity возвращает долю совпадающих битов отпечатков при наилучшем сдвиге.
+// Сдвиг компенсирует тишину разной длины в начале файла. Если отпечатков нет или они слишком короткие, ok — false.
+func FingerprintSimilarity(a []byte, b []byte) (similarity float64, ok bool) {
+	for shift := -DuplicateFingerprintMaxShift; shift <= DuplicateFingerprintMaxShift; shift++ {
+		matches, total := compareShifted(a, b, shift)
+		if total < DuplicateFingerprintMinBits {
+			continue
+		}
+		if value := float64(matches) / float64(total); !ok || value > similarity {
+			similarity, ok = value, true
+		}
+	}
+	return similarity, ok
+}
+
+// compareShifted сравнивает биты a[i+shift] и b[i] и возвращает количество совпадений и сравнений
+func compareShifted(a []byte, b []byte, shift int) (int, int) {
+	if shift < 0 {
+		return compareShifted(b, a, -shift)
+	}
+
+	total := min(len(a)*8-shift, len(b)*8)
+	if total <= 0 {
+		return 0, 0
+	}
+
+	// Сравнение по байтам: b[k] сопоставляется с восемью битами a, начиная с бита k*8+shift
+	differences := 0
+	for k := 0; k*8 < total; k++ {
+		word := fingerprintByte(a, k*8+shift)
+		diff := word ^ b[k]
+		if rest := total - k*8; rest < 8 {
+			diff &= byte(0xFF << (8 - rest))
+		}
+		differences += bits.OnesCount8(diff)
+	}
+
+	return total - differences, total
+}
+
+// fingerprintByte возвращает восемь битов fingerprint, начиная с бита offset; биты за концом равны нулю
+func fingerprintByte(fingerprint []byte, offset int) byte {
+	index, bit := offset/8, offset%8
+	var high, low byte
+	if index < len(fingerprint) {
+		high = fingerprint[index] << bit
+	}
+	if bit > 0 && index+1 < len(fingerprint) {
+		low = fingerprint[index+1] >> (8 - bit)
+	}
+	return high | low
+}
+
+// Результат загрузки трека с предупреждениями о вероятных дубликатах
+type MusicUploadReport struct {
+	Music      *MusicDB
+	Duplicates []*MusicDuplicateDB
+}
+
+// Перенос данных пользователей с дубликата на оставляемый трек
+type MusicMerge struct {
+	Into uuid.UUID `json:"into"` // id трека, который остается в каталоге
+}
+
+// Результат слияния. Дубликат после слияния перемещается в корзину.
+type MusicMergeResult struct {
+	MusicID         uuid.UUID // id дубликата
+	IntoID          uuid.UUID // id оставленного трека
+	Likes           int64     // перенесенные лайки
+	PlaylistEntries int64     // перенесенные записи плейлистов
+	Plays           int64     // перенесенные события истории прослушиваний
+}
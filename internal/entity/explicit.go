@@ -0,0 +1,21 @@
+package entity
+
+import "errors"
+
+var ErrExplicitContent = errors.New("explicit content is hidden for this account")
+
+// Ограничения выдачи треков пользователю
+type MusicFilter struct {
+	ShowUnpublished bool // показывать неопубликованные и снятые с публикации треки, только для администраторов
+	HideExplicit    bool // скрывать треки с ненормативным контентом
+}
+
+// Настройки фильтра контента пользователя
+type ContentFilter struct {
+	HideExplicit bool `json:"hide_explicit"` // скрывать треки с ненормативным контентом
+}
+
+// Отметка о ненормативном контенте трека
+type MusicExplicit struct {
+	Explicit bool `json:"explicit"` // трек содержит ненормативный контент
+}
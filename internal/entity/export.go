@@ -0,0 +1,239 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"music-backend-test/cmd/music-backend-test/config"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+	ExportFormatM3U8 = "m3u8"
+	ExportFormatXSPF = "xspf"
+
+	ExportStatusPending = "pending" // ожидает обработки
+	ExportStatusRunning = "running" // выполняется
+	ExportStatusDone    = "done"    // файл готов к скачиванию
+	ExportStatusFailed  = "failed"  // выгрузка завершилась ошибкой
+
+	ExportStorage = "./internal/storage/exports"
+
+	DefaultExportSyncLimit  = 5000
+	DefaultExportRetention  = 24 * time.Hour
+	DefaultExportBatchSize  = 5
+	DefaultExportJobTimeout = 30 * time.Minute
+
+	ExportHistoryBatchSize = 1000 // количество событий истории, читаемых за один запрос
+)
+
+var (
+	ErrInvalidExport  = errors.New("invalid export")
+	ErrExportNotReady = errors.New("export is not ready")
+	ErrExportExpired  = errors.New("export has expired")
+)
+
+// Параметры выгрузки библиотеки
+type ExportConfig struct {
+	BaseURL    string        // адрес API для ссылок на треки
+	SyncLimit  int           // максимальное количество записей, выгружаемых прямо в ответе
+	Retention  time.Duration // время хранения готового файла
+	BatchSize  int           // количество задач, обрабатываемых за один запуск
+	JobTimeout time.Duration // время, после которого выполняющаяся задача считается зависшей
+}
+
+func NewExportConfig(cfg *config.Config) *ExportConfig {
+	syncLimit := cfg.Export.SyncLimit
+	if syncLimit < 0 {
+		syncLimit = DefaultExportSyncLimit
+	}
+	retention := cfg.Export.Retention
+	if retention <= 0 {
+		retention = DefaultExportRetention
+	}
+	batchSize := cfg.Export.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultExportBatchSize
+	}
+	jobTimeout := cfg.Export.JobTimeout
+	if jobTimeout <= 0 {
+		jobTimeout = DefaultExportJobTimeout
+	}
+
+	return &ExportConfig{
+		BaseURL:    strings.TrimRight(cfg.Export.BaseURL, "/"),
+		SyncLimit:  syncLimit,
+		Retention:  retention,
+		BatchSize:  batchSize,
+		JobTimeout: jobTimeout,
+	}
+}
+
+// TrackURL возвращает постоянную ссылку на файл трека
+func (c *ExportConfig) TrackURL(musicId uuid.UUID) string {
+	return fmt.Sprintf("%s/music/download/%s", c.BaseURL, musicId)
+}
+
+// Запрос выгрузки. JSON и CSV содержат всю библиотеку, M3U8 и XSPF — плейлисты:
+// один плейлист, если указан PlaylistID, иначе ZIP-архив со всеми плейлистами.
+type ExportRequest struct {
+	Format     string     // формат выгрузки
+	PlaylistID *uuid.UUID // id плейлиста для M3U8 и XSPF
+	Async      bool       // выгрузить фоновой задачей независимо от размера
+}
+
+func (r *ExportRequest) Validate() error {
+	switch r.Format {
+	case ExportFormatJSON, ExportFormatCSV:
+		if r.PlaylistID != nil {
+			return fmt.Errorf("%w: playlist_id is supported only for %s and %s", ErrInvalidExport, ExportFormatM3U8, ExportFormatXSPF)
+		}
+	case ExportFormatM3U8, ExportFormatXSPF:
+	default:
+		return fmt.Errorf("%w: unknown format %q", ErrInvalidExport, r.Format)
+	}
+	return nil
+}
+
+// IsPlaylistFormat сообщает, выгружаются ли только плейлисты
+func (r *ExportRequest) IsPlaylistFormat() bool {
+	return r.Format == ExportFormatM3U8 || r.Format == ExportFormatXSPF
+}
+
+// IsArchive сообщает, выгружаются ли плейлисты ZIP-архивом
+func (r *ExportRequest) IsArchive() bool {
+	return r.IsPlaylistFormat() && r.PlaylistID == nil
+}
+
+// FileName возвращает имя файла выгрузки
+func (r *ExportRequest) FileName() string {
+	switch {
+	case r.IsArchive():
+		return fmt.Sprintf("playlists-%s.zip", r.Format)
+	case r.IsPlaylistFormat():
+		return fmt.Sprintf("playlist-%s.%s", r.PlaylistID, r.Format)
+	default:
+		return fmt.Sprintf("library.%s", r.Format)
+	}
+}
+
+// ContentType возвращает MIME-тип файла выгрузки
+func (r *ExportRequest) ContentType() string {
+	if r.IsArchive() {
+		return "application/zip"
+	}
+	switch r.Format {
+	case ExportFormatJSON:
+		return "application/json; charset=utf-8"
+	case ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case ExportFormatM3U8:
+		return "application/vnd.apple.mpegurl"
+	case ExportFormatXSPF:
+		return "application/xspf+xml"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func (r *ExportRequest) ToJob(userId uuid.UUID, items int64, now time.Time) *ExportJobDB {
+	return &ExportJobDB{
+		ID:         uuid.New(),
+		UserID:     userId,
+		Format:     r.Format,
+		PlaylistID: r.PlaylistID,
+		Status:     ExportStatusPending,
+		Items:      items,
+		CreatedAt:  now,
+	}
+}
+
+// Фоновая задача выгрузки
+type ExportJobDB struct {
+	ID         uuid.UUID  `db:"id"`          // id задачи
+	UserID     uuid.UUID  `db:"user_id"`     // id пользователя
+	Format     string     `db:"format"`      // формат выгрузки
+	PlaylistID *uuid.UUID `db:"playlist_id"` // id плейлиста для M3U8 и XSPF
+	Status     string     `db:"status"`      // состояние задачи
+	Items      int64      `db:"items"`       // количество записей на момент создания задачи
+	Size       int64      `db:"size"`        // размер готового файла в байтах
+	Error      *string    `db:"error"`       // текст ошибки для неудачной выгрузки
+	CreatedAt  time.Time  `db:"created_at"`  // время создания
+	StartedAt  *time.Time `db:"started_at"`  // время начала обработки
+	FinishedAt *time.Time `db:"finished_at"` // время завершения
+	ExpiresAt  *time.Time `db:"expires_at"`  // время удаления готового файла
+}
+
+func (j *ExportJobDB) Request() *ExportRequest {
+	return &ExportRequest{Format: j.Format, PlaylistID: j.PlaylistID, Async: true}
+}
+
+func (j *ExportJobDB) FilePath() string {
+	return filepath.Join(ExportStorage, j.ID.String())
+}
+
+// CheckDownload проверяет, что файл выгрузки готов и еще не удален
+func (j *ExportJobDB) CheckDownload(now time.Time) error {
+	if j.Status != ExportStatusDone {
+		return fmt.Errorf("%w: status is %s", ErrExportNotReady, j.Status)
+	}
+	if j.ExpiresAt != nil && !j.ExpiresAt.After(now) {
+		return ErrExportExpired
+	}
+	return nil
+}
+
+// Finish отмечает задачу завершенной. При ошибке файл не сохраняется.
+func (j *ExportJobDB) Finish(size int64, err error, now time.Time, retention time.Duration) {
+	j.FinishedAt = &now
+	if err != nil {
+		message := err.Error()
+		j.Status = ExportStatusFailed
+		j.Error = &message
+		return
+	}
+
+	expiresAt := now.Add(retention)
+	j.Status = ExportStatusDone
+	j.Size = size
+	j.ExpiresAt = &expiresAt
+}
+
+// Трек в выгрузке
+type ExportTrack struct {
+	MusicID  uuid.UUID `db:"music_id"` // id трека
+	Name     string    `db:"name"`     // название трека
+	Artist   *string   `db:"artist"`   // имя исполнителя
+	Duration int64     `db:"seconds"`  // продолжительность в секундах
+}
+
+type ExportLikeDB struct {
+	ExportTrack
+	LikedAt time.Time `db:"liked_at"` // время лайка
+}
+
+type ExportPlaylistTrackDB struct {
+	ExportTrack
+	Position int       `db:"position"` // позиция в плейлисте
+	AddedAt  time.Time `db:"added_at"` // время добавления
+}
+
+type ExportRatingDB struct {
+	ExportTrack
+	Rating    int       `db:"rating"`     // оценка от 1 до 5
+	UpdatedAt time.Time `db:"updated_at"` // время последнего изменения оценки
+}
+
+type ExportPlayDB struct {
+	ID uuid.UUID `db:"id"` // id события
+	ExportTrack
+	EventType   string    `db:"event_type"`   // тип события
+	PositionSec int       `db:"position_sec"` // позиция в треке
+	Source      string    `db:"source"`       // источник события
+	PlayedAt    time.Time `db:"played_at"`    // время события
+}
@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FeedItemRelease  string = "release"
+	FeedItemLike     string = "like"
+	FeedItemPlaylist string = "playlist"
+)
+
+const (
+	DefaultFeedLimit = 20
+	MaxFeedLimit     = 100
+)
+
+var (
+	ErrSelfFollow        = errors.New("users can't follow themselves")
+	ErrInvalidFeedCursor = errors.New("invalid feed cursor")
+)
+
+// Что пользователь показывает подписчикам в ленте
+type FeedPrivacy struct {
+	ShareLikes     bool `json:"share_likes"`     // показывать лайки
+	SharePlaylists bool `json:"share_playlists"` // показывать изменения публичных плейлистов
+}
+
+// Исполнители и пользователи, на которых подписан пользователь
+type Following struct {
+	Artists []*ArtistDB // исполнители
+	Users   []*UserDB   // пользователи
+}
+
+// Событие ленты. Для релиза автор — исполнитель, для лайка и плейлиста — пользователь.
+type FeedItemDB struct {
+	ID           string     `db:"id"`            // ключ события, уникальный в пределах ленты
+	Kind         string     `db:"kind"`          // тип события (release, like, playlist)
+	OccurredAt   time.Time  `db:"occurred_at"`   // время события
+	ActorID      uuid.UUID  `db:"actor_id"`      // id исполнителя или пользователя
+	ActorName    string     `db:"actor_name"`    // имя исполнителя или пользователя
+	MusicID      *uuid.UUID `db:"music_id"`      // id трека для релиза и лайка
+	MusicName    *string    `db:"music_name"`    // название трека для релиза и лайка
+	PlaylistID   *uuid.UUID `db:"playlist_id"`   // id плейлиста
+	PlaylistName *string    `db:"playlist_name"` // название плейлиста
+}
+
+// Позиция, с которой продолжается выдача ленты
+type FeedCursor struct {
+	OccurredAt time.Time `json:"t"`
+	ID         string    `json:"id"`
+}
+
+func (c *FeedCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeFeedCursor(value string) (*FeedCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFeedCursor, err)
+	}
+
+	var cursor FeedCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFeedCursor, err)
+	}
+	if cursor.ID == "" || cursor.OccurredAt.IsZero() {
+		return nil, fmt.Errorf("%w: empty position", ErrInvalidFeedCursor)
+	}
+
+	return &cursor, nil
+}
+
+// Параметры выдачи ленты
+type FeedFilter struct {
+	Cursor *FeedCursor // позиция продолжения, nil для первой страницы
+	Limit  int         // размер страницы
+}
+
+// Normalize приводит размер страницы к допустимым значениям
+func (f *FeedFilter) Normalize() {
+	if f.Limit <= 0 {
+		f.Limit = DefaultFeedLimit
+	}
+	if f.Limit > MaxFeedLimit {
+		f.Limit = MaxFeedLimit
+	}
+}
+
+type FeedPage struct {
+	Items      []*FeedItemDB // события страницы
+	NextCursor string        // курсор следующей страницы, пустой если страница последняя
+}
+
+// NewFeedPage обрезает выборку размером limit+1 до limit и формирует курсор следующей страницы
+func NewFeedPage(items []*FeedItemDB, limit int) *FeedPage {
+	page := &FeedPage{Items: items}
+	if len(items) <= limit {
+		return page
+	}
+
+	page.Items = items[:limit]
+	last := page.Items[limit-1]
+	cursor := &FeedCursor{OccurredAt: last.OccurredAt, ID: last.ID}
+	page.NextCursor = cursor.Encode()
+	return page
+}
@@ -0,0 +1,218 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"music-backend-test/cmd/music-backend-test/config"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultOIDCScopes          = "openid profile email"
+	DefaultOIDCGroupsClaim     = "groups"
+	DefaultOIDCStateTTL        = 10 * time.Minute
+	DefaultOIDCCleanupInterval = time.Hour
+
+	OIDCStateBytes        = 32  // длина state и nonce в байтах до кодирования
+	OIDCCodeVerifierBytes = 32  // длина PKCE code_verifier в байтах до кодирования, в base64url — 43 символа
+	MaxIdentityUsername   = 200 // максимальная длина имени пользователя, созданного при входе через провайдера, без суффикса для занятых имен
+
+	// Cookie со state начатого входа. Возврат от провайдера принимается только в браузере, где вход начат,
+	// иначе по чужой ссылке возврата можно войти в чужую учетную запись.
+	OIDCStateCookie = "oidc_state"
+)
+
+var (
+	ErrInvalidOIDCLogin  = errors.New("unknown or expired oidc login")
+	ErrOIDCCodeRejected  = errors.New("authorization code rejected by oidc provider")
+	ErrInvalidIDToken    = errors.New("invalid id token")
+	ErrIdentityLinked    = errors.New("identity is linked to another user")
+	ErrOIDCLinkForbidden = errors.New("identity link was started by another user")
+)
+
+// Параметры входа через OpenID Connect провайдера
+type OIDCConfig struct {
+	Issuer          string        // адрес издателя; пустой — вход через провайдера отключен
+	ClientID        string        // id клиента у провайдера
+	ClientSecret    string        // секрет клиента; пустой у публичного клиента
+	RedirectURL     string        // адрес возврата после входа у провайдера
+	Scopes          []string      // запрашиваемые scope, всегда включают openid
+	GroupsClaim     string        // утверждение ID-токена со списком групп пользователя
+	AdminGroup      string        // группа провайдера, участники которой получают роль ADMIN; пустая — роль не выдается
+	DefaultRole     string        // роль пользователя, созданного при первом входе
+	StateTTL        time.Duration // время, за которое нужно завершить начатый вход
+	CleanupInterval time.Duration // интервал удаления незавершенных входов
+}
+
+func NewOIDCConfig(cfg *config.Config) *OIDCConfig {
+	scopes := strings.Fields(cfg.OIDC.Scopes)
+	if len(scopes) == 0 {
+		scopes = strings.Fields(DefaultOIDCScopes)
+	}
+	hasOpenID := false
+	for _, scope := range scopes {
+		if scope == "openid" {
+			hasOpenID = true
+		}
+	}
+	if !hasOpenID {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+	groupsClaim := cfg.OIDC.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = DefaultOIDCGroupsClaim
+	}
+	defaultRole := cfg.OIDC.DefaultRole
+	if defaultRole != AdminRole && defaultRole != UserRole {
+		defaultRole = UserRole
+	}
+	stateTTL := cfg.OIDC.StateTTL
+	if stateTTL <= 0 {
+		stateTTL = DefaultOIDCStateTTL
+	}
+	cleanupInterval := cfg.OIDC.CleanupInterval
+	if cleanupInterval <= 0 {
+		cleanupInterval = DefaultOIDCCleanupInterval
+	}
+
+	return &OIDCConfig{
+		Issuer:          strings.TrimSuffix(cfg.OIDC.Issuer, "/"),
+		ClientID:        cfg.OIDC.ClientID,
+		ClientSecret:    cfg.OIDC.ClientSecret,
+		RedirectURL:     cfg.OIDC.RedirectURL,
+		Scopes:          scopes,
+		GroupsClaim:     groupsClaim,
+		AdminGroup:      cfg.OIDC.AdminGroup,
+		DefaultRole:     defaultRole,
+		StateTTL:        stateTTL,
+		CleanupInterval: cleanupInterval,
+	}
+}
+
+// Enabled сообщает, настроен ли вход через провайдера
+func (c *OIDCConfig) Enabled() bool {
+	return c.Issuer != "" && c.ClientID != ""
+}
+
+// Начатый вход через провайдера в бд
+type OIDCLoginDB struct {
+	State        string     `db:"state"`         // state запроса авторизации, по нему вход находится при возврате от провайдера
+	Nonce        string     `db:"nonce"`         // nonce, который провайдер должен вернуть в ID-токене
+	CodeVerifier string     `db:"code_verifier"` // PKCE code_verifier, предъявляется при обмене кода
+	UserID       *uuid.UUID `db:"user_id"`       // пользователь, к которому привязывается учетная запись; nil — обычный вход
+	CreatedAt    time.Time  `db:"created_at"`    // время начала входа
+	ExpiresAt    time.Time  `db:"expires_at"`    // время, после которого вход нельзя завершить
+}
+
+// NewOIDCLogin генерирует state, nonce и code_verifier входа, который нужно завершить в течение ttl.
+// userId задается, если учетная запись провайдера привязывается к уже вошедшему пользователю.
+func NewOIDCLogin(userId *uuid.UUID, now time.Time, ttl time.Duration) (*OIDCLoginDB, error) {
+	state, err := randomString(OIDCStateBytes)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString(OIDCStateBytes)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomString(OIDCCodeVerifierBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCLoginDB{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		UserID:       userId,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(ttl),
+	}, nil
+}
+
+// CodeChallenge возвращает PKCE code_challenge по методу S256 (RFC 7636)
+func (l *OIDCLoginDB) CodeChallenge() string {
+	sum := sha256.Sum256([]byte(l.CodeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Начатый вход: адрес страницы входа провайдера и state, который сохраняется в cookie браузера
+type OIDCAuthorization struct {
+	URL       string    // адрес страницы входа провайдера
+	State     string    // state входа
+	ExpiresAt time.Time // время, после которого вход нельзя завершить
+}
+
+// Учетная запись провайдера, подтвержденная ID-токеном
+type OIDCIdentity struct {
+	Issuer            string   // издатель
+	Subject           string   // sub, неизменный id учетной записи у издателя
+	PreferredUsername string   // preferred_username
+	Email             string   // email
+	Groups            []string // группы из утверждения OIDCConfig.GroupsClaim
+}
+
+// InGroup проверяет, состоит ли учетная запись в группе group
+func (i *OIDCIdentity) InGroup(group string) bool {
+	for _, g := range i.Groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// Username возвращает имя пользователя, создаваемого при первом входе: preferred_username,
+// иначе часть email до @, иначе имя, построенное по sub
+func (i *OIDCIdentity) Username() string {
+	username := strings.TrimSpace(i.PreferredUsername)
+	if username == "" {
+		username, _, _ = strings.Cut(strings.TrimSpace(i.Email), "@")
+	}
+	if username == "" {
+		sum := sha256.Sum256([]byte(i.Issuer + " " + i.Subject))
+		username = fmt.Sprintf("user-%x", sum[:4])
+	}
+	return truncateRunes(username, MaxIdentityUsername)
+}
+
+// Привязанная к пользователю учетная запись провайдера в бд
+type UserIdentityDB struct {
+	ID          uuid.UUID `db:"id"`            // id привязки
+	UserID      uuid.UUID `db:"user_id"`       // id пользователя
+	Issuer      string    `db:"issuer"`        // издатель
+	Subject     string    `db:"subject"`       // sub учетной записи у издателя
+	Admin       bool      `db:"admin"`         // роль ADMIN выдана по группе провайдера
+	CreatedAt   time.Time `db:"created_at"`    // время привязки
+	LastLoginAt time.Time `db:"last_login_at"` // время последнего входа через провайдера
+}
+
+// Вход через провайдера: учетная запись, пользователь, к которому она привязывается, и выдаваемая роль
+type IdentityLogin struct {
+	Issuer     string      // издатель
+	Subject    string      // sub учетной записи у издателя
+	LinkUserID *uuid.UUID  // пользователь, к которому привязывается учетная запись; nil — вход или регистрация
+	NewUser    *UserCreate // имя и пароль пользователя, создаваемого при первом входе
+	Role       string      // роль создаваемого пользователя; к ней же возвращается пользователь, вышедший из группы администраторов
+	Admin      bool        // учетная запись состоит в группе администраторов провайдера
+}
+
+// NewUnusablePassword возвращает случайный пароль для пользователя, созданного при входе через провайдера.
+// Пароль никому не сообщается, поэтому по имени и паролю такой пользователь не войдет, пока не сменит пароль.
+func NewUnusablePassword() (string, error) {
+	return randomString(RefreshTokenBytes)
+}
+
+func randomString(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("can't generate random string: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,217 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ImportFormatM3U  = "m3u"
+	ImportFormatXSPF = "xspf"
+	ImportFormatCSV  = "csv"
+
+	MaxImportFileSize       = 5 << 20 // максимальный размер файла плейлиста
+	MaxImportEntries        = 5000    // максимальное количество записей в файле
+	MaxImportCandidates     = 5       // количество вариантов, предлагаемых для неоднозначной записи
+	ImportDurationTolerance = 3       // допустимая разница продолжительности в секундах
+
+	ImportStatusMatched   = "matched"   // запись сопоставлена с треком каталога
+	ImportStatusAmbiguous = "ambiguous" // подходит несколько треков
+	ImportStatusUnmatched = "unmatched" // подходящих треков нет
+)
+
+var ErrInvalidImport = errors.New("invalid playlist import")
+
+// Скобки с уточнениями вроде «(Remastered 2011)» или «[Live]» при сравнении названий не учитываются
+var importBracketsPattern = regexp.MustCompile(`[\(\[][^\)\]]*[\)\]]`)
+
+// DetectImportFormat возвращает формат файла: явно указанный или по расширению имени файла
+func DetectImportFormat(format string, fileName string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(fileName), ".")
+	}
+
+	switch strings.ToLower(format) {
+	case "m3u", "m3u8":
+		return ImportFormatM3U, nil
+	case ImportFormatXSPF:
+		return ImportFormatXSPF, nil
+	case ImportFormatCSV:
+		return ImportFormatCSV, nil
+	default:
+		return "", fmt.Errorf("%w: unknown format %q", ErrInvalidImport, format)
+	}
+}
+
+// Запись файла плейлиста
+type ImportEntry struct {
+	Line     int        // номер записи в файле, начиная с 1
+	MusicID  *uuid.UUID // id трека, если он указан в ссылке или идентификаторе
+	Title    string     // название трека
+	Artist   string     // имя исполнителя
+	Duration int64      // продолжительность в секундах, 0 — неизвестна
+	Location string     // ссылка или путь к файлу из плейлиста
+}
+
+// Импорт файла плейлиста. Записи заполняются при разборе файла.
+type PlaylistImport struct {
+	Format   string // формат файла: m3u, xspf или csv
+	FileName string // имя загруженного файла
+	Name     string // название создаваемого плейлиста
+	IsPublic bool   // плейлист виден другим пользователям
+	Entries  []*ImportEntry
+}
+
+// SetDefaultName задает название плейлиста, если оно не указано: из файла плейлиста, иначе по имени файла
+func (p *PlaylistImport) SetDefaultName(parsedName string) {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		p.Name = parsedName
+	}
+	if p.Name == "" && p.FileName != "" {
+		p.Name = strings.TrimSuffix(filepath.Base(p.FileName), filepath.Ext(p.FileName))
+	}
+}
+
+func (p *PlaylistImport) Validate() error {
+	if err := (&PlaylistCreate{Name: p.Name}).Validate(); err != nil {
+		return err
+	}
+	if len(p.Entries) == 0 {
+		return fmt.Errorf("%w: no entries", ErrInvalidImport)
+	}
+	if len(p.Entries) > MaxImportEntries {
+		return fmt.Errorf("%w: more than %d entries", ErrInvalidImport, MaxImportEntries)
+	}
+	return nil
+}
+
+func (p *PlaylistImport) ToDB(userId uuid.UUID, now time.Time) *PlaylistDB {
+	return (&PlaylistCreate{Name: p.Name, IsPublic: p.IsPublic}).ToDB(userId, now)
+}
+
+// Трек каталога, с которым сопоставляются записи
+type ImportCandidateDB struct {
+	MusicID  uuid.UUID `db:"music_id"` // id трека
+	Name     string    `db:"name"`     // название трека
+	Artist   *string   `db:"artist"`   // имя исполнителя
+	Duration int64     `db:"seconds"`  // продолжительность в секундах
+}
+
+// NormalizeImportTitle приводит название трека или имя исполнителя к виду для сравнения:
+// нижний регистр, без уточнений в скобках, знаков препинания и лишних пробелов
+func NormalizeImportTitle(s string) string {
+	s = importBracketsPattern.ReplaceAllString(strings.ToLower(s), " ")
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), " ")
+}
+
+// Результат сопоставления записи
+type ImportMatch struct {
+	Entry      *ImportEntry
+	Status     string               // matched, ambiguous или unmatched
+	Music      *ImportCandidateDB   // найденный трек для matched
+	Candidates []*ImportCandidateDB // подходящие треки для ambiguous, треки с тем же названием для unmatched
+}
+
+// ImportMatcher сопоставляет записи с каталогом: сначала по id трека, затем по нормализованному названию
+// и исполнителю с допуском по продолжительности
+type ImportMatcher struct {
+	byID    map[uuid.UUID]*ImportCandidateDB
+	byTitle map[string][]*ImportCandidateDB
+}
+
+func NewImportMatcher() *ImportMatcher {
+	return &ImportMatcher{
+		byID:    map[uuid.UUID]*ImportCandidateDB{},
+		byTitle: map[string][]*ImportCandidateDB{},
+	}
+}
+
+// AddByID добавляет треки, найденные по id из записей
+func (m *ImportMatcher) AddByID(tracks []*ImportCandidateDB) {
+	for _, track := range tracks {
+		m.byID[track.MusicID] = track
+	}
+}
+
+// HasID сообщает, найден ли трек записи по id
+func (m *ImportMatcher) HasID(entry *ImportEntry) bool {
+	if entry.MusicID == nil {
+		return false
+	}
+	_, ok := m.byID[*entry.MusicID]
+	return ok
+}
+
+// AddByTitle добавляет треки каталога, названия которых есть среди titles
+func (m *ImportMatcher) AddByTitle(tracks []*ImportCandidateDB, titles map[string]bool) {
+	for _, track := range tracks {
+		title := NormalizeImportTitle(track.Name)
+		if titles[title] {
+			m.byTitle[title] = append(m.byTitle[title], track)
+		}
+	}
+}
+
+func (m *ImportMatcher) Match(entry *ImportEntry) *ImportMatch {
+	if entry.MusicID != nil {
+		if track, ok := m.byID[*entry.MusicID]; ok {
+			return &ImportMatch{Entry: entry, Status: ImportStatusMatched, Music: track}
+		}
+	}
+
+	sameTitle := m.byTitle[NormalizeImportTitle(entry.Title)]
+	artist := NormalizeImportTitle(entry.Artist)
+
+	var candidates []*ImportCandidateDB
+	for _, track := range sameTitle {
+		if artist != "" && (track.Artist == nil || NormalizeImportTitle(*track.Artist) != artist) {
+			continue
+		}
+		if entry.Duration > 0 && track.Duration > 0 && abs64(entry.Duration-track.Duration) > ImportDurationTolerance {
+			continue
+		}
+		candidates = append(candidates, track)
+	}
+
+	switch len(candidates) {
+	case 1:
+		return &ImportMatch{Entry: entry, Status: ImportStatusMatched, Music: candidates[0]}
+	case 0:
+		return &ImportMatch{Entry: entry, Status: ImportStatusUnmatched, Candidates: limitImportCandidates(sameTitle)}
+	default:
+		return &ImportMatch{Entry: entry, Status: ImportStatusAmbiguous, Candidates: limitImportCandidates(candidates)}
+	}
+}
+
+func limitImportCandidates(candidates []*ImportCandidateDB) []*ImportCandidateDB {
+	if len(candidates) > MaxImportCandidates {
+		return candidates[:MaxImportCandidates]
+	}
+	return candidates
+}
+
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Отчет об импорте: созданный плейлист и записи, которые пользователю нужно разобрать вручную
+type PlaylistImportReport struct {
+	Playlist   *PlaylistDB
+	Total      int            // количество записей в файле
+	Matched    int            // количество сопоставленных записей
+	Duplicates int            // сопоставленные записи, трек которых уже добавлен в плейлист
+	Unresolved []*ImportMatch // неоднозначные и несопоставленные записи в порядке файла
+}
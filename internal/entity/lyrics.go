@@ -0,0 +1,336 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LyricsSourceUpload string = "upload"
+	LyricsSourceID3    string = "id3"
+)
+
+const (
+	LyricsFormatJSON string = "json"
+	LyricsFormatLRC  string = "lrc"
+)
+
+// Язык текста, если он неизвестен (ISO 639-2)
+const UndefinedLanguage = "und"
+
+// Максимальный размер текста песни в байтах
+const MaxLyricsSize = 256 << 10
+
+var (
+	ErrInvalidLyrics       = errors.New("invalid lyrics")
+	ErrInvalidLRC          = errors.New("invalid lrc")
+	ErrNoSyncedLyrics      = errors.New("lyrics have no synced version")
+	ErrUnknownLyricsFormat = errors.New("unknown lyrics format")
+)
+
+var (
+	languageRegexp     = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+	lrcTimestampRegexp = regexp.MustCompile(`^\[(\d{1,3}):(\d{2})(?:[.:](\d{1,3}))?\]`)
+	lrcTagRegexp       = regexp.MustCompile(`^\[([a-zA-Z#]+):(.*)\]$`)
+)
+
+type LyricsDB struct {
+	MusicID   uuid.UUID `db:"music_id"`   // id трека
+	Language  string    `db:"language"`   // язык текста
+	Plain     string    `db:"plain"`      // текст без разметки времени
+	Synced    *string   `db:"synced"`     // синхронизированный текст в формате LRC
+	Source    string    `db:"source"`     // источник текста (upload, id3)
+	CreatedAt time.Time `db:"created_at"` // время добавления
+	UpdatedAt time.Time `db:"updated_at"` // время последнего изменения
+}
+
+// Текст песни вместе с разобранными строками и доступными языками
+type Lyrics struct {
+	LyricsDB
+	Lines     []LyricLine // строки синхронизированного текста
+	Languages []string    // все языки, на которых есть текст трека
+}
+
+type LyricLine struct {
+	Time time.Duration // время начала строки
+	Text string        // текст строки
+}
+
+type LRCTag struct {
+	Key   string
+	Value string
+}
+
+// Разобранный файл LRC
+type LRC struct {
+	Tags  []LRCTag    // теги метаданных (ar, ti, al...), кроме offset
+	Lines []LyricLine // строки, отсортированные по времени
+}
+
+type LyricsUpload struct {
+	Language string `json:"language"` // язык текста (ISO 639), по умолчанию und
+	Plain    string `json:"plain"`    // текст без разметки времени
+	Synced   string `json:"synced"`   // синхронизированный текст в формате LRC
+}
+
+func (l *LyricsUpload) Validate() error {
+	if _, err := NormalizeLanguage(l.Language); err != nil {
+		return err
+	}
+	if strings.TrimSpace(l.Plain) == "" && strings.TrimSpace(l.Synced) == "" {
+		return fmt.Errorf("%w: plain or synced text is required", ErrInvalidLyrics)
+	}
+	if len(l.Plain)+len(l.Synced) > MaxLyricsSize {
+		return fmt.Errorf("%w: lyrics are larger than %d bytes", ErrInvalidLyrics, MaxLyricsSize)
+	}
+	return nil
+}
+
+// ToDB разбирает синхронизированный текст и приводит его к каноничному виду.
+// Если текст без разметки не задан, он собирается из строк синхронизированного текста.
+func (l *LyricsUpload) ToDB(musicId uuid.UUID, now time.Time) (*LyricsDB, error) {
+	language, err := NormalizeLanguage(l.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	lyrics := &LyricsDB{
+		MusicID:   musicId,
+		Language:  language,
+		Plain:     strings.TrimSpace(l.Plain),
+		Source:    LyricsSourceUpload,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if strings.TrimSpace(l.Synced) != "" {
+		lrc, err := ParseLRC(l.Synced)
+		if err != nil {
+			return nil, err
+		}
+		synced := lrc.String()
+		lyrics.Synced = &synced
+		if lyrics.Plain == "" {
+			lyrics.Plain = lrc.PlainText()
+		}
+	}
+
+	return lyrics, nil
+}
+
+// NormalizeLanguage приводит код языка к нижнему регистру. Пустой код означает неизвестный язык.
+func NormalizeLanguage(language string) (string, error) {
+	language = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(language, "_", "-")))
+	if language == "" {
+		return UndefinedLanguage, nil
+	}
+	if !languageRegexp.MatchString(language) {
+		return "", fmt.Errorf("%w: invalid language %q", ErrInvalidLyrics, language)
+	}
+	return language, nil
+}
+
+func ValidateLyricsFormat(format string) (string, error) {
+	switch format {
+	case "":
+		return LyricsFormatJSON, nil
+	case LyricsFormatJSON, LyricsFormatLRC:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownLyricsFormat, format)
+	}
+}
+
+// ParseLRC разбирает текст в формате LRC. Строка может содержать несколько меток времени,
+// тег offset сдвигает все строки и в результат не попадает. Пустые строки пропускаются.
+func ParseLRC(text string) (*LRC, error) {
+	var (
+		lrc    LRC
+		offset time.Duration
+	)
+
+	for i, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+
+		if !lrcTimestampRegexp.MatchString(line) {
+			match := lrcTagRegexp.FindStringSubmatch(line)
+			if match == nil {
+				return nil, fmt.Errorf("%w: line %d: expected timestamp or tag", ErrInvalidLRC, i+1)
+			}
+
+			key, value := strings.ToLower(match[1]), strings.TrimSpace(match[2])
+			if key == "offset" {
+				ms, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("%w: line %d: invalid offset %q", ErrInvalidLRC, i+1, value)
+				}
+				offset = time.Duration(ms) * time.Millisecond
+				continue
+			}
+			lrc.Tags = append(lrc.Tags, LRCTag{Key: key, Value: value})
+			continue
+		}
+
+		var times []time.Duration
+		for {
+			match := lrcTimestampRegexp.FindStringSubmatch(line)
+			if match == nil {
+				break
+			}
+			t, err := parseLRCTimestamp(match)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidLRC, i+1, err)
+			}
+			times = append(times, t)
+			line = line[len(match[0]):]
+		}
+
+		for _, t := range times {
+			lrc.Lines = append(lrc.Lines, LyricLine{Time: t, Text: strings.TrimSpace(line)})
+		}
+	}
+
+	if len(lrc.Lines) == 0 {
+		return nil, fmt.Errorf("%w: no timed lines", ErrInvalidLRC)
+	}
+
+	// Положительный offset показывает строки раньше
+	for i := range lrc.Lines {
+		lrc.Lines[i].Time -= offset
+		if lrc.Lines[i].Time < 0 {
+			lrc.Lines[i].Time = 0
+		}
+	}
+
+	sort.SliceStable(lrc.Lines, func(i, j int) bool {
+		return lrc.Lines[i].Time < lrc.Lines[j].Time
+	})
+
+	return &lrc, nil
+}
+
+func parseLRCTimestamp(match []string) (time.Duration, error) {
+	minutes, _ := strconv.Atoi(match[1])
+	seconds, _ := strconv.Atoi(match[2])
+	if seconds >= 60 {
+		return 0, fmt.Errorf("invalid seconds in [%s:%s]", match[1], match[2])
+	}
+
+	t := time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	if fraction := match[3]; fraction != "" {
+		value, _ := strconv.Atoi(fraction)
+		// .5 — десятые, .50 — сотые, .500 — тысячные доли секунды
+		for i := len(fraction); i < 3; i++ {
+			value *= 10
+		}
+		t += time.Duration(value) * time.Millisecond
+	}
+
+	return t, nil
+}
+
+// String возвращает каноничный LRC: теги, затем строки с метками времени до сотых долей секунды
+func (l *LRC) String() string {
+	var b strings.Builder
+	for _, tag := range l.Tags {
+		fmt.Fprintf(&b, "[%s:%s]\n", tag.Key, tag.Value)
+	}
+	for _, line := range l.Lines {
+		fmt.Fprintf(&b, "%s%s\n", FormatLRCTimestamp(line.Time), line.Text)
+	}
+	return b.String()
+}
+
+// PlainText возвращает строки без меток времени
+func (l *LRC) PlainText() string {
+	texts := make([]string, len(l.Lines))
+	for i, line := range l.Lines {
+		texts[i] = line.Text
+	}
+	return strings.TrimSpace(strings.Join(texts, "\n"))
+}
+
+func FormatLRCTimestamp(t time.Duration) string {
+	centiseconds := t.Milliseconds() / 10
+	return fmt.Sprintf("[%02d:%02d.%02d]", centiseconds/6000, centiseconds/100%60, centiseconds%100)
+}
+
+// SelectLyrics выбирает текст на запрошенном языке. Пустой язык выбирает первый добавленный текст,
+// при отсутствии точного совпадения подходит текст с тем же основным языком (en для en-gb и наоборот).
+func SelectLyrics(variants []*LyricsDB, language string) *LyricsDB {
+	if len(variants) == 0 {
+		return nil
+	}
+	if language == "" {
+		return variants[0]
+	}
+
+	for _, lyrics := range variants {
+		if lyrics.Language == language {
+			return lyrics
+		}
+	}
+
+	primary := strings.SplitN(language, "-", 2)[0]
+	for _, lyrics := range variants {
+		if strings.SplitN(lyrics.Language, "-", 2)[0] == primary {
+			return lyrics
+		}
+	}
+
+	return nil
+}
+
+// NewLyrics разбирает синхронизированный текст и добавляет список доступных языков
+func NewLyrics(lyrics *LyricsDB, variants []*LyricsDB) (*Lyrics, error) {
+	result := &Lyrics{LyricsDB: *lyrics}
+	for _, variant := range variants {
+		result.Languages = append(result.Languages, variant.Language)
+	}
+
+	if lyrics.Synced != nil {
+		lrc, err := ParseLRC(*lyrics.Synced)
+		if err != nil {
+			return nil, err
+		}
+		result.Lines = lrc.Lines
+	}
+
+	return result, nil
+}
+
+// LRC возвращает синхронизированный текст в формате LRC. Если в тексте нет тега языка, он добавляется.
+func (l *Lyrics) LRC() (string, error) {
+	if l.Synced == nil {
+		return "", ErrNoSyncedLyrics
+	}
+
+	lrc, err := ParseLRC(*l.Synced)
+	if err != nil {
+		return "", err
+	}
+
+	if l.Language != UndefinedLanguage && !lrc.HasTag("la") {
+		lrc.Tags = append([]LRCTag{{Key: "la", Value: l.Language}}, lrc.Tags...)
+	}
+	return lrc.String(), nil
+}
+
+func (l *LRC) HasTag(key string) bool {
+	for _, tag := range l.Tags {
+		if tag.Key == key {
+			return true
+		}
+	}
+	return false
+}
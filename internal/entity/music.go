@@ -21,15 +21,30 @@ type MusicParse struct {
 	Release    time.Time
 	File       multipart.File        `swaggerignore:"true"`
 	FileHeader *multipart.FileHeader `swaggerignore:"true"`
+	PublishAt  *time.Time            // время публикации, по умолчанию — дата релиза
+	EditorID   uuid.UUID             `swaggerignore:"true"` // пользователь, вносящий изменение
 }
 
 type MusicDB struct {
-	Id       uuid.UUID `db:"id"`           // id трека
-	Name     string    `db:"name"`         // название трека
-	Release  time.Time `db:"release_date"` // дата релиза трека
-	FileName string    `db:"file_name"`    // имя файла
-	Size     uint64    `db:"size"`         // размер файла
-	Duration string    `db:"duration"`     // продолжительность трека
+	Id          uuid.UUID  `db:"id"`           // id трека
+	Name        string     `db:"name"`         // название трека
+	Release     time.Time  `db:"release_date"` // дата релиза трека
+	FileName    string     `db:"file_name"`    // имя файла
+	Size        uint64     `db:"size"`         // размер файла
+	Duration    string     `db:"duration"`     // продолжительность трека
+	RatingAvg   float64    `db:"rating_avg"`   // средняя оценка трека
+	RatingCount int64      `db:"rating_count"` // количество оценок трека
+	DeletedAt   *time.Time `db:"deleted_at"`   // время перемещения в корзину
+	PublishAt   time.Time  `db:"publish_at"`   // запланированное время публикации
+	PublishedAt *time.Time `db:"published_at"` // время публикации, пока трек не опубликован — nil
+	TakedownAt  *time.Time `db:"takedown_at"`  // время снятия с публикации
+	Explicit    bool       `db:"explicit"`     // трек содержит ненормативный контент
+	ArtistID    *uuid.UUID `db:"artist_id"`    // id исполнителя, nil если исполнитель не указан
+}
+
+// IsAvailable сообщает, виден ли трек пользователям без роли администратора
+func (m *MusicDB) IsAvailable(now time.Time) bool {
+	return m.DeletedAt == nil && m.PublishedAt != nil && (m.TakedownAt == nil || m.TakedownAt.After(now))
 }
 
 func (m *MusicDB) FilePath() string {
@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MusicRevisionCreate string = "create"
+	MusicRevisionUpdate string = "update"
+	MusicRevisionRevert string = "revert"
+)
+
+const (
+	DefaultMusicRevisionLimit = 20
+	MaxMusicRevisionLimit     = 100
+)
+
+// Каталог, в который переносятся замененные файлы треков
+const MusicRevisionStorage = "./internal/storage/music_storage/revisions"
+
+var (
+	ErrInvalidMusicRevision = errors.New("invalid music revision")
+	ErrRevertToCurrent      = errors.New("revision is already current")
+)
+
+// Снимок трека после изменения. Последняя версия совпадает с текущим состоянием трека.
+type MusicRevisionDB struct {
+	ID           uuid.UUID  `db:"id"`            // id версии
+	MusicID      uuid.UUID  `db:"music_id"`      // id трека
+	Version      int        `db:"version"`       // номер версии, начиная с 1
+	Action       string     `db:"action"`        // изменение (create, update, revert)
+	Name         string     `db:"name"`          // название трека
+	Release      time.Time  `db:"release_date"`  // дата релиза трека
+	FileName     string     `db:"file_name"`     // имя файла
+	Size         uint64     `db:"size"`          // размер файла
+	Duration     string     `db:"duration"`      // продолжительность трека
+	FilePath     string     `db:"file_path"`     // путь к файлу этой версии
+	RevertedFrom *int       `db:"reverted_from"` // версия, к которой откатили трек
+	ChangedBy    *uuid.UUID `db:"changed_by"`    // id пользователя, внесшего изменение
+	Username     *string    `db:"username"`      // имя пользователя, внесшего изменение
+	CreatedAt    time.Time  `db:"created_at"`    // время изменения
+}
+
+// NewMusicRevision снимает версию с состояния трека. Нулевой changedBy означает изменение без пользователя.
+func NewMusicRevision(music *MusicDB, action string, changedBy uuid.UUID, now time.Time) *MusicRevisionDB {
+	revision := &MusicRevisionDB{
+		ID:        uuid.New(),
+		MusicID:   music.Id,
+		Action:    action,
+		Name:      music.Name,
+		Release:   music.Release,
+		FileName:  music.FileName,
+		Size:      music.Size,
+		Duration:  music.Duration,
+		FilePath:  music.FilePath(),
+		CreatedAt: now,
+	}
+	if changedBy != uuid.Nil {
+		revision.ChangedBy = &changedBy
+	}
+	return revision
+}
+
+// ToMusic возвращает состояние трека, сохраненное в версии
+func (r *MusicRevisionDB) ToMusic() *MusicDB {
+	return &MusicDB{
+		Id:       r.MusicID,
+		Name:     r.Name,
+		Release:  r.Release,
+		FileName: r.FileName,
+		Size:     r.Size,
+		Duration: r.Duration,
+	}
+}
+
+// MusicRevisionFilePath возвращает путь, по которому хранится замененный файл трека
+func MusicRevisionFilePath(musicId uuid.UUID, fileName string) string {
+	return fmt.Sprintf("%s/%s_%s_%s", MusicRevisionStorage, musicId, uuid.New(), fileName)
+}
+
+// Перенос файла трека: все версии с путем From получают путь To.
+// Файл одной версии не копируется, поэтому совпадение путей означает совпадение содержимого.
+type MusicFileMove struct {
+	From string
+	To   string
+}
+
+// Изменение поля трека между двумя версиями
+type MusicFieldChange struct {
+	Field string // название поля
+	From  string // значение в исходной версии
+	To    string // значение в целевой версии
+}
+
+type MusicRevisionDiff struct {
+	MusicID uuid.UUID
+	From    *MusicRevisionDB
+	To      *MusicRevisionDB
+	Changes []MusicFieldChange
+}
+
+// DiffMusicRevisions сравнивает две версии трека. Замена файла с тем же именем отображается как изменение поля file.
+func DiffMusicRevisions(from *MusicRevisionDB, to *MusicRevisionDB) *MusicRevisionDiff {
+	diff := &MusicRevisionDiff{
+		MusicID: to.MusicID,
+		From:    from,
+		To:      to,
+		Changes: []MusicFieldChange{},
+	}
+
+	add := func(field string, fromValue string, toValue string) {
+		if fromValue != toValue {
+			diff.Changes = append(diff.Changes, MusicFieldChange{Field: field, From: fromValue, To: toValue})
+		}
+	}
+
+	add("name", from.Name, to.Name)
+	add("release_date", from.Release.Format("2006-01-02"), to.Release.Format("2006-01-02"))
+	add("file_name", from.FileName, to.FileName)
+	add("size", strconv.FormatUint(from.Size, 10), strconv.FormatUint(to.Size, 10))
+	add("duration", from.Duration, to.Duration)
+	if from.FileName == to.FileName && from.FilePath != to.FilePath {
+		add("file", "v"+strconv.Itoa(from.Version), "v"+strconv.Itoa(to.Version))
+	}
+
+	return diff
+}
+
+// NormalizeMusicRevisionPage приводит параметры пагинации к допустимым значениям
+func NormalizeMusicRevisionPage(limit int, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultMusicRevisionLimit
+	}
+	if limit > MaxMusicRevisionLimit {
+		limit = MaxMusicRevisionLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
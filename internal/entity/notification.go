@@ -0,0 +1,288 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-backend-test/cmd/music-backend-test/config"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationRelease         string = "release"          // новый трек исполнителя из подписок
+	NotificationCommentReply    string = "comment_reply"    // ответ на комментарий пользователя
+	NotificationPlaylistComment string = "playlist_comment" // комментарий к плейлисту пользователя
+)
+
+// Все типы уведомлений в порядке вывода настроек
+var NotificationTypes = []string{NotificationRelease, NotificationCommentReply, NotificationPlaylistComment}
+
+const (
+	NotificationChannelInApp   string = "in_app"
+	NotificationChannelEmail   string = "email"
+	NotificationChannelWebhook string = "webhook"
+)
+
+const (
+	DefaultNotificationLimit     = 20
+	MaxNotificationLimit         = 100
+	DefaultNotificationBatchSize = 100
+	MaxNotificationEmailLength   = 255
+)
+
+var (
+	ErrInvalidNotificationCursor   = errors.New("invalid notification cursor")
+	ErrInvalidNotificationSettings = errors.New("invalid notification settings")
+)
+
+// Параметры фоновой доставки уведомлений
+type NotificationConfig struct {
+	BatchSize int // количество уведомлений, отправляемых за один запуск
+}
+
+func NewNotificationConfig(cfg *config.Config) *NotificationConfig {
+	batchSize := cfg.Notifications.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultNotificationBatchSize
+	}
+
+	return &NotificationConfig{
+		BatchSize: batchSize,
+	}
+}
+
+// Уведомление пользователя. Для релиза автор — исполнитель, для ответа и комментария к плейлисту — автор комментария.
+type NotificationDB struct {
+	ID           uuid.UUID  `db:"id"`            // id уведомления
+	UserID       uuid.UUID  `db:"user_id"`       // id получателя
+	Type         string     `db:"type"`          // тип уведомления
+	ActorID      *uuid.UUID `db:"actor_id"`      // id исполнителя или пользователя
+	ActorName    *string    `db:"actor_name"`    // имя исполнителя или пользователя
+	MusicID      *uuid.UUID `db:"music_id"`      // id трека
+	MusicName    *string    `db:"music_name"`    // название трека
+	PlaylistID   *uuid.UUID `db:"playlist_id"`   // id плейлиста
+	PlaylistName *string    `db:"playlist_name"` // название плейлиста
+	CommentID    *uuid.UUID `db:"comment_id"`    // id ответа или комментария к плейлисту
+	CreatedAt    time.Time  `db:"created_at"`    // время создания
+	ReadAt       *time.Time `db:"read_at"`       // время прочтения, nil если не прочитано
+	DeliveredAt  *time.Time `db:"delivered_at"`  // время отправки во внешние каналы
+}
+
+// Message возвращает текст уведомления для внешних каналов
+func (n *NotificationDB) Message() string {
+	actor, music, playlist := "", "", ""
+	if n.ActorName != nil {
+		actor = *n.ActorName
+	}
+	if n.MusicName != nil {
+		music = *n.MusicName
+	}
+	if n.PlaylistName != nil {
+		playlist = *n.PlaylistName
+	}
+
+	switch n.Type {
+	case NotificationRelease:
+		return fmt.Sprintf("Новый трек исполнителя %s: «%s»", actor, music)
+	case NotificationCommentReply:
+		if n.PlaylistID != nil {
+			return fmt.Sprintf("Новый ответ от %s на ваш комментарий к плейлисту «%s»", actor, playlist)
+		}
+		return fmt.Sprintf("Новый ответ от %s на ваш комментарий к треку «%s»", actor, music)
+	case NotificationPlaylistComment:
+		return fmt.Sprintf("Новый комментарий от %s к вашему плейлисту «%s»", actor, playlist)
+	default:
+		return "Новое уведомление"
+	}
+}
+
+// NewCommentReplyNotification создает уведомление автору комментария об ответе на него
+func NewCommentReplyNotification(parent *CommentDB, reply *CommentDB) *NotificationDB {
+	return &NotificationDB{
+		ID:         uuid.New(),
+		UserID:     parent.UserID,
+		Type:       NotificationCommentReply,
+		ActorID:    &reply.UserID,
+		MusicID:    reply.MusicID,
+		PlaylistID: reply.PlaylistID,
+		CommentID:  &reply.ID,
+		CreatedAt:  reply.CreatedAt,
+	}
+}
+
+// NewPlaylistCommentNotification создает уведомление владельцу плейлиста о комментарии к нему
+func NewPlaylistCommentNotification(playlist *PlaylistDB, comment *CommentDB) *NotificationDB {
+	return &NotificationDB{
+		ID:         uuid.New(),
+		UserID:     playlist.UserID,
+		Type:       NotificationPlaylistComment,
+		ActorID:    &comment.UserID,
+		PlaylistID: &playlist.ID,
+		CommentID:  &comment.ID,
+		CreatedAt:  comment.CreatedAt,
+	}
+}
+
+// Уведомление, ожидающее отправки во внешние каналы, вместе с адресом и настройками получателя
+type NotificationDelivery struct {
+	NotificationDB
+	Email          *string `db:"email"`           // адрес для email-канала
+	EmailEnabled   bool    `db:"email_enabled"`   // получатель включил email для этого типа
+	WebhookEnabled bool    `db:"webhook_enabled"` // получатель включил webhook для этого типа
+}
+
+// Enabled сообщает, нужно ли отправлять уведомление в канал
+func (d *NotificationDelivery) Enabled(channel string) bool {
+	switch channel {
+	case NotificationChannelEmail:
+		return d.EmailEnabled && d.Email != nil
+	case NotificationChannelWebhook:
+		return d.WebhookEnabled
+	default:
+		return false
+	}
+}
+
+// Каналы доставки уведомлений одного типа
+type NotificationPreference struct {
+	Type    string `json:"type" db:"type"`       // тип уведомления
+	InApp   bool   `json:"in_app" db:"in_app"`   // показывать во входящих
+	Email   bool   `json:"email" db:"email"`     // отправлять на email
+	Webhook bool   `json:"webhook" db:"webhook"` // отправлять в webhook
+}
+
+// DefaultNotificationPreference возвращает настройки типа, которые действуют, пока пользователь их не менял
+func DefaultNotificationPreference(notificationType string) *NotificationPreference {
+	return &NotificationPreference{Type: notificationType, InApp: true}
+}
+
+// Настройки уведомлений пользователя
+type NotificationSettings struct {
+	Email       *string                   `json:"email"`       // адрес для email-канала, null отключает email
+	Preferences []*NotificationPreference `json:"preferences"` // каналы по типам уведомлений
+}
+
+// NewNotificationSettings дополняет сохраненные настройки значениями по умолчанию для остальных типов
+func NewNotificationSettings(email *string, stored []*NotificationPreference) *NotificationSettings {
+	byType := make(map[string]*NotificationPreference, len(stored))
+	for _, preference := range stored {
+		byType[preference.Type] = preference
+	}
+
+	settings := &NotificationSettings{Email: email}
+	for _, notificationType := range NotificationTypes {
+		preference, ok := byType[notificationType]
+		if !ok {
+			preference = DefaultNotificationPreference(notificationType)
+		}
+		settings.Preferences = append(settings.Preferences, preference)
+	}
+	return settings
+}
+
+// Validate проверяет адрес и типы уведомлений. Пустой адрес отключает email-канал.
+func (s *NotificationSettings) Validate() error {
+	if s.Email != nil {
+		email := strings.TrimSpace(*s.Email)
+		if email == "" {
+			s.Email = nil
+		} else {
+			address, err := mail.ParseAddress(email)
+			if err != nil || address.Address != email || len(email) > MaxNotificationEmailLength {
+				return fmt.Errorf("%w: invalid email", ErrInvalidNotificationSettings)
+			}
+			s.Email = &email
+		}
+	}
+
+	seen := make(map[string]bool, len(s.Preferences))
+	for _, preference := range s.Preferences {
+		if !isNotificationType(preference.Type) {
+			return fmt.Errorf("%w: unknown type %q", ErrInvalidNotificationSettings, preference.Type)
+		}
+		if seen[preference.Type] {
+			return fmt.Errorf("%w: duplicate type %q", ErrInvalidNotificationSettings, preference.Type)
+		}
+		seen[preference.Type] = true
+	}
+	return nil
+}
+
+func isNotificationType(value string) bool {
+	for _, notificationType := range NotificationTypes {
+		if value == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// Позиция, с которой продолжается выдача уведомлений
+type NotificationCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (c *NotificationCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeNotificationCursor(value string) (*NotificationCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNotificationCursor, err)
+	}
+
+	var cursor NotificationCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNotificationCursor, err)
+	}
+	if cursor.ID == uuid.Nil || cursor.CreatedAt.IsZero() {
+		return nil, fmt.Errorf("%w: empty position", ErrInvalidNotificationCursor)
+	}
+
+	return &cursor, nil
+}
+
+// Параметры выдачи входящих уведомлений
+type NotificationFilter struct {
+	Cursor     *NotificationCursor // позиция продолжения, nil для первой страницы
+	Limit      int                 // размер страницы
+	UnreadOnly bool                // только непрочитанные
+}
+
+// Normalize приводит размер страницы к допустимым значениям
+func (f *NotificationFilter) Normalize() {
+	if f.Limit <= 0 {
+		f.Limit = DefaultNotificationLimit
+	}
+	if f.Limit > MaxNotificationLimit {
+		f.Limit = MaxNotificationLimit
+	}
+}
+
+type NotificationPage struct {
+	Items       []*NotificationDB // уведомления страницы
+	UnreadCount int               // всего непрочитанных уведомлений
+	NextCursor  string            // курсор следующей страницы, пустой если страница последняя
+}
+
+// NewNotificationPage обрезает выборку размером limit+1 до limit и формирует курсор следующей страницы
+func NewNotificationPage(items []*NotificationDB, limit int, unreadCount int) *NotificationPage {
+	page := &NotificationPage{Items: items, UnreadCount: unreadCount}
+	if len(items) <= limit {
+		return page
+	}
+
+	page.Items = items[:limit]
+	last := page.Items[limit-1]
+	cursor := &NotificationCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	page.NextCursor = cursor.Encode()
+	return page
+}
@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PlayEventStart    string = "start"
+	PlayEventProgress string = "progress"
+	PlayEventComplete string = "complete"
+)
+
+const (
+	PlaySourceClient string = "client"
+	PlaySourceStream string = "stream"
+)
+
+const (
+	MaxPlayBatchSize      = 500              // максимальное количество событий в одном пакете
+	MaxPlayClockSkew      = 5 * time.Minute  // допустимое опережение часов клиента
+	StreamPlayDedupWindow = 30 * time.Minute // окно, в котором обращения к стриму считаются одним прослушиванием
+
+	DefaultPlayHistoryLimit = 50
+	MaxPlayHistoryLimit     = 500
+)
+
+var (
+	ErrInvalidPlayEvent = errors.New("invalid play event")
+	ErrUnknownPlayMusic = errors.New("play event for unknown music")
+)
+
+// Представление события прослушивания в бд
+type PlayEventDB struct {
+	ID            uuid.UUID `db:"id"`              // ID события
+	UserID        uuid.UUID `db:"user_id"`         // ID пользователя
+	MusicID       uuid.UUID `db:"music_id"`        // ID трека
+	EventType     string    `db:"event_type"`      // Тип события (start, progress, complete)
+	Position      int       `db:"position_sec"`    // Позиция воспроизведения в секундах
+	Source        string    `db:"source"`          // Источник события (client, stream)
+	ClientEventID uuid.UUID `db:"client_event_id"` // ID события на стороне клиента, используется для дедупликации
+	PlayedAt      time.Time `db:"played_at"`       // Время события по часам клиента
+	CreatedAt     time.Time `db:"created_at"`      // Время получения события сервером
+}
+
+// Событие прослушивания, присланное клиентом
+type PlayEventCreate struct {
+	ClientEventID uuid.UUID `json:"client_event_id"` // ID события на стороне клиента
+	MusicID       uuid.UUID `json:"music_id"`        // ID трека
+	EventType     string    `json:"event_type"`      // Тип события (start, progress, complete)
+	Position      int       `json:"position"`        // Позиция воспроизведения в секундах
+	PlayedAt      time.Time `json:"played_at"`       // Время события по часам клиента
+}
+
+// Пакет событий прослушивания от офлайн-клиента
+type PlayEventBatch struct {
+	Events []*PlayEventCreate `json:"events"`
+}
+
+// Результат обработки пакета событий
+type PlayBatchResult struct {
+	Accepted        int         // количество сохраненных событий
+	Duplicates      int         // количество событий, уже присланных ранее
+	UnknownMusicIDs []uuid.UUID // треки, которых нет в каталоге; события по ним пропущены
+}
+
+// Фильтр истории прослушиваний
+type PlayHistoryFilter struct {
+	From   time.Time
+	To     time.Time
+	Limit  int
+	Offset int
+}
+
+// Normalize подставляет значения по умолчанию для незаполненных полей фильтра
+func (f *PlayHistoryFilter) Normalize(now time.Time) {
+	if f.To.IsZero() {
+		f.To = now.Add(MaxPlayClockSkew)
+	}
+	if f.Limit <= 0 {
+		f.Limit = DefaultPlayHistoryLimit
+	}
+	if f.Limit > MaxPlayHistoryLimit {
+		f.Limit = MaxPlayHistoryLimit
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+}
+
+func (p *PlayEventCreate) Validate(now time.Time) error {
+	if p.MusicID == uuid.Nil {
+		return fmt.Errorf("%w: music_id is required", ErrInvalidPlayEvent)
+	}
+
+	switch p.EventType {
+	case PlayEventStart, PlayEventProgress, PlayEventComplete:
+	default:
+		return fmt.Errorf("%w: unknown event type %q", ErrInvalidPlayEvent, p.EventType)
+	}
+
+	if p.Position < 0 {
+		return fmt.Errorf("%w: negative position", ErrInvalidPlayEvent)
+	}
+
+	if p.PlayedAt.After(now.Add(MaxPlayClockSkew)) {
+		return fmt.Errorf("%w: played_at is in the future", ErrInvalidPlayEvent)
+	}
+
+	return nil
+}
+
+// ToDB готовит событие к записи в бд. Если клиент не прислал ID события или время,
+// они заполняются на стороне сервера.
+func (p *PlayEventCreate) ToDB(userId uuid.UUID, source string, now time.Time) *PlayEventDB {
+	event := &PlayEventDB{
+		ID:            uuid.New(),
+		UserID:        userId,
+		MusicID:       p.MusicID,
+		EventType:     p.EventType,
+		Position:      p.Position,
+		Source:        source,
+		ClientEventID: p.ClientEventID,
+		PlayedAt:      p.PlayedAt,
+		CreatedAt:     now,
+	}
+	if event.ClientEventID == uuid.Nil {
+		event.ClientEventID = uuid.New()
+	}
+	if event.PlayedAt.IsZero() {
+		event.PlayedAt = now
+	}
+
+	return event
+}
+
+// StreamPlayEventID возвращает детерминированный ID события для прослушивания,
+// выведенного из обращения к стриму. Повторные запросы к файлу в пределах одного окна
+// получают одинаковый ID и отбрасываются как дубликаты.
+func StreamPlayEventID(userId uuid.UUID, musicId uuid.UUID, at time.Time) uuid.UUID {
+	window := at.Truncate(StreamPlayDedupWindow).Unix()
+	return uuid.NewSHA1(userId, []byte(fmt.Sprintf("%s:%d", musicId, window)))
+}
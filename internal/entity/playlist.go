@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxPlaylistNameLength = 255
+
+	DefaultPlaylistLimit = 20
+	MaxPlaylistLimit     = 100
+)
+
+var (
+	ErrInvalidPlaylist   = errors.New("invalid playlist")
+	ErrPlaylistForbidden = errors.New("playlist belongs to another user")
+)
+
+type PlaylistDB struct {
+	ID        uuid.UUID `db:"id"`         // id плейлиста
+	UserID    uuid.UUID `db:"user_id"`    // id владельца
+	Name      string    `db:"name"`       // название плейлиста
+	IsPublic  bool      `db:"is_public"`  // плейлист виден другим пользователям
+	CreatedAt time.Time `db:"created_at"` // время создания
+	UpdatedAt time.Time `db:"updated_at"` // время последнего изменения названия или состава
+}
+
+// VisibleTo сообщает, может ли пользователь просматривать плейлист
+func (p *PlaylistDB) VisibleTo(userId uuid.UUID) bool {
+	return p.IsPublic || p.UserID == userId
+}
+
+type PlaylistCreate struct {
+	Name     string `json:"name"`      // название плейлиста
+	IsPublic bool   `json:"is_public"` // плейлист виден другим пользователям
+}
+
+func (p *PlaylistCreate) Validate() error {
+	name := strings.TrimSpace(p.Name)
+	if name == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidPlaylist)
+	}
+	if utf8.RuneCountInString(name) > MaxPlaylistNameLength {
+		return fmt.Errorf("%w: name is longer than %d characters", ErrInvalidPlaylist, MaxPlaylistNameLength)
+	}
+	return nil
+}
+
+func (p *PlaylistCreate) ToDB(userId uuid.UUID, now time.Time) *PlaylistDB {
+	return &PlaylistDB{
+		ID:        uuid.New(),
+		UserID:    userId,
+		Name:      strings.TrimSpace(p.Name),
+		IsPublic:  p.IsPublic,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Трек, добавляемый в плейлист
+type PlaylistTrack struct {
+	MusicID uuid.UUID `json:"music_id"` // id трека
+}
+
+func NormalizePlaylistPage(limit int, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultPlaylistLimit
+	}
+	if limit > MaxPlaylistLimit {
+		limit = MaxPlaylistLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
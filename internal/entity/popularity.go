@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"music-backend-test/cmd/music-backend-test/config"
+	"time"
+)
+
+const (
+	PopularityDay      string = "day"
+	PopularityWeek     string = "week"
+	PopularityMonth    string = "month"
+	PopularityAll      string = "all"
+	PopularityTrending string = "trending"
+)
+
+const (
+	DefaultChartLimit = 50
+	MaxChartLimit     = 500
+
+	// Вклад событий старше TrendingHorizon половин периода полураспада пренебрежимо мал
+	TrendingHorizon = 10
+)
+
+var ErrUnknownPopularityWindow = errors.New("unknown popularity window")
+
+// PopularityWindows перечисляет окна, для которых рассчитывается популярность
+var PopularityWindows = []string{PopularityDay, PopularityWeek, PopularityMonth, PopularityAll}
+
+// Веса и параметры расчета популярности
+type PopularityConfig struct {
+	PlayWeight       float64       // вес прослушивания
+	LikeWeight       float64       // вес лайка
+	TrendingHalfLife time.Duration // период полураспада для рейтинга "в тренде"
+	RefreshTimeout   time.Duration // максимальная длительность пересчета рейтингов
+}
+
+func NewPopularityConfig(cfg *config.Config) *PopularityConfig {
+	return &PopularityConfig{
+		PlayWeight:       cfg.Popularity.PlayWeight,
+		LikeWeight:       cfg.Popularity.LikeWeight,
+		TrendingHalfLife: cfg.Popularity.TrendingHalfLife,
+		RefreshTimeout:   cfg.Popularity.RefreshTimeout,
+	}
+}
+
+// Трек с рассчитанной популярностью
+type MusicPopularityDB struct {
+	MusicDB
+	Plays int64   `db:"plays"` // количество прослушиваний за окно
+	Likes int64   `db:"likes"` // количество лайков за окно
+	Score float64 `db:"score"` // итоговый рейтинг
+}
+
+// PopularityWindowStart возвращает начало окна популярности относительно now.
+// Для окна "all" возвращается нулевое время.
+func PopularityWindowStart(window string, now time.Time) (time.Time, error) {
+	switch window {
+	case PopularityDay:
+		return now.AddDate(0, 0, -1), nil
+	case PopularityWeek:
+		return now.AddDate(0, 0, -7), nil
+	case PopularityMonth:
+		return now.AddDate(0, -1, 0), nil
+	case PopularityAll:
+		return time.Time{}, nil
+	default:
+		return time.Time{}, fmt.Errorf("%w: %q", ErrUnknownPopularityWindow, window)
+	}
+}
+
+// NormalizeChartPage приводит параметры пагинации рейтинга к допустимым значениям
+func NormalizeChartPage(limit int, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultChartLimit
+	}
+	if limit > MaxChartLimit {
+		limit = MaxChartLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+const CatalogSortRating string = "rating"
+
+const (
+	LibrarySortRecent string = "recent"
+	LibrarySortRating string = "rating"
+	LibrarySortName   string = "name"
+)
+
+var (
+	ErrInvalidRating      = errors.New("invalid rating")
+	ErrUnknownLibrarySort = errors.New("unknown library sort")
+)
+
+type RatingCreate struct {
+	Rating int `json:"rating"` // оценка от 1 до 5
+}
+
+func (r *RatingCreate) Validate() error {
+	if r.Rating < MinRating || r.Rating > MaxRating {
+		return fmt.Errorf("%w: must be between %d and %d", ErrInvalidRating, MinRating, MaxRating)
+	}
+	return nil
+}
+
+// Оценка пользователя вместе с итоговой статистикой трека
+type RatingSummary struct {
+	MusicID uuid.UUID // id трека
+	Rating  int       // оценка пользователя, 0 если оценка снята
+	Average float64   // средняя оценка трека
+	Count   int64     // количество оценок трека
+}
+
+// Трек из библиотеки пользователя: лайкнутый или оцененный
+type LibraryMusicDB struct {
+	MusicDB
+	UserRating *int `db:"user_rating"` // оценка пользователя, nil если трек не оценен
+	Liked      bool `db:"liked"`       // трек лайкнут пользователем
+}
+
+// ValidateLibrarySort проверяет сортировку библиотеки. Пустая сортировка означает сортировку по времени добавления.
+func ValidateLibrarySort(sort string) (string, error) {
+	switch sort {
+	case "":
+		return LibrarySortRecent, nil
+	case LibrarySortRecent, LibrarySortRating, LibrarySortName:
+		return sort, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownLibrarySort, sort)
+	}
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"music-backend-test/cmd/music-backend-test/config"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultRecommendationLimit = 20
+	MaxRecommendationLimit     = 100
+
+	DefaultSimilarityNeighbors = 50
+)
+
+// Параметры расчета схожести треков
+type SimilarityConfig struct {
+	Neighbors      int           // количество похожих треков, сохраняемых для каждого трека
+	MinCoLikes     int           // минимальное количество пользователей, лайкнувших оба трека
+	RefreshTimeout time.Duration // максимальная длительность пересчета схожести
+}
+
+func NewSimilarityConfig(cfg *config.Config) *SimilarityConfig {
+	neighbors := cfg.Recommendations.Neighbors
+	if neighbors <= 0 {
+		neighbors = DefaultSimilarityNeighbors
+	}
+	minCoLikes := cfg.Recommendations.MinCoLikes
+	if minCoLikes <= 0 {
+		minCoLikes = 1
+	}
+
+	return &SimilarityConfig{
+		Neighbors:      neighbors,
+		MinCoLikes:     minCoLikes,
+		RefreshTimeout: cfg.Recommendations.RefreshTimeout,
+	}
+}
+
+// Трек, похожий на заданный
+type SimilarMusicDB struct {
+	MusicDB
+	CoLikes int64   `db:"co_likes"` // количество пользователей, лайкнувших оба трека
+	Score   float64 `db:"score"`    // косинусная мера схожести
+}
+
+// Рекомендованный пользователю трек
+type RecommendationDB struct {
+	MusicDB
+	Score       float64   `db:"score"`        // сумма схожести с лайкнутыми треками
+	BecauseID   uuid.UUID `db:"because_id"`   // лайкнутый трек, внесший наибольший вклад
+	BecauseName string    `db:"because_name"` // название лайкнутого трека
+}
+
+// NormalizeRecommendationPage приводит параметры пагинации рекомендаций к допустимым значениям
+func NormalizeRecommendationPage(limit int, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultRecommendationLimit
+	}
+	if limit > MaxRecommendationLimit {
+		limit = MaxRecommendationLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
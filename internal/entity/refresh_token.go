@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"music-backend-test/cmd/music-backend-test/config"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultAccessTokenTTL      = 15 * time.Minute
+	DefaultRefreshTokenTTL     = 30 * 24 * time.Hour
+	DefaultAuthCleanupInterval = time.Hour
+	DefaultSessionCacheTTL     = 30 * time.Second
+
+	RefreshTokenBytes = 32 // длина refresh-токена в байтах до кодирования
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+)
+
+// Параметры выдачи токенов
+type AuthConfig struct {
+	AccessTokenTTL  time.Duration // срок действия access-токена
+	RefreshTokenTTL time.Duration // срок действия refresh-токена, отсчитывается заново при каждом обновлении
+	CleanupInterval time.Duration // интервал удаления истекших refresh-токенов
+	SessionCacheTTL time.Duration // время кэширования проверки, что сессия не отозвана
+}
+
+func NewAuthConfig(cfg *config.Config) *AuthConfig {
+	accessTokenTTL := cfg.Auth.AccessTokenTTL
+	if accessTokenTTL <= 0 {
+		accessTokenTTL = DefaultAccessTokenTTL
+	}
+	refreshTokenTTL := cfg.Auth.RefreshTokenTTL
+	if refreshTokenTTL <= 0 {
+		refreshTokenTTL = DefaultRefreshTokenTTL
+	}
+	cleanupInterval := cfg.Auth.CleanupInterval
+	if cleanupInterval <= 0 {
+		cleanupInterval = DefaultAuthCleanupInterval
+	}
+	sessionCacheTTL := cfg.Auth.SessionCacheTTL
+	if sessionCacheTTL <= 0 {
+		sessionCacheTTL = DefaultSessionCacheTTL
+	}
+
+	return &AuthConfig{
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+		CleanupInterval: cleanupInterval,
+		SessionCacheTTL: sessionCacheTTL,
+	}
+}
+
+// Представление refresh-токена в бд. Сам токен не хранится, только его хэш.
+type RefreshTokenDB struct {
+	ID        uuid.UUID  `db:"id"`         // id токена
+	FamilyID  uuid.UUID  `db:"family_id"`  // id семейства: все токены, выданные после одного входа. Совпадает с id сессии.
+	UserID    uuid.UUID  `db:"user_id"`    // id пользователя
+	TokenHash string     `db:"token_hash"` // SHA-256 токена в шестнадцатеричном виде
+	CreatedAt time.Time  `db:"created_at"` // время выдачи
+	ExpiresAt time.Time  `db:"expires_at"` // время истечения
+	UsedAt    *time.Time `db:"used_at"`    // время обмена на новый токен
+	RevokedAt *time.Time `db:"revoked_at"` // время отзыва
+}
+
+// Refresh-токен в запросе на обновление или выход
+type RefreshToken struct {
+	RefreshToken string `json:"refresh_token"` // refresh-токен
+}
+
+// Пара токенов, выдаваемая при входе и обновлении
+type TokenPair struct {
+	SessionID        uuid.UUID // id сессии
+	Access           string    // подписанный access-токен
+	AccessExpiresAt  time.Time // время истечения access-токена
+	Refresh          string    // refresh-токен
+	RefreshExpiresAt time.Time // время истечения refresh-токена
+}
+
+// NewRefreshToken генерирует случайный refresh-токен и его запись для бд
+func NewRefreshToken(userId uuid.UUID, familyId uuid.UUID, now time.Time, ttl time.Duration) (string, *RefreshTokenDB, error) {
+	raw := make([]byte, RefreshTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("can't generate refresh token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	return token, &RefreshTokenDB{
+		ID:        uuid.New(),
+		FamilyID:  familyId,
+		UserID:    userId,
+		TokenHash: HashRefreshToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+// HashRefreshToken возвращает хэш, под которым refresh-токен хранится в бд
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
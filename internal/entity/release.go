@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultScheduledMusicLimit = 20
+	MaxScheduledMusicLimit     = 100
+)
+
+var ErrInvalidMusicSchedule = errors.New("invalid music schedule")
+
+// Расписание публикации трека
+type MusicSchedule struct {
+	PublishAt  time.Time  `json:"publish_at"`  // время публикации
+	TakedownAt *time.Time `json:"takedown_at"` // время снятия с публикации, необязательно
+}
+
+func (s *MusicSchedule) Validate() error {
+	if s.PublishAt.IsZero() {
+		return fmt.Errorf("%w: publish_at is required", ErrInvalidMusicSchedule)
+	}
+	if s.TakedownAt != nil && !s.TakedownAt.After(s.PublishAt) {
+		return fmt.Errorf("%w: takedown_at must be after publish_at", ErrInvalidMusicSchedule)
+	}
+	return nil
+}
+
+// PublishedAt возвращает время публикации трека по расписанию или nil, если оно еще не наступило
+func (s *MusicSchedule) PublishedAt(now time.Time) *time.Time {
+	if s.PublishAt.After(now) {
+		return nil
+	}
+	publishedAt := s.PublishAt
+	return &publishedAt
+}
+
+func NormalizeScheduledMusicPage(limit int, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultScheduledMusicLimit
+	}
+	if limit > MaxScheduledMusicLimit {
+		limit = MaxScheduledMusicLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxSessionDeviceName = 100 // максимальная длина названия устройства в символах
+	MaxSessionUserAgent  = 512 // максимальная длина User-Agent в символах
+	MaxSessionIP         = 45  // максимальная длина IP-адреса: IPv6 с IPv4-окончанием
+
+	MaxSessionCacheSize = 100000 // количество сессий в кэше проверки отзыва
+)
+
+// Представление сессии в бд. Сессия создается при входе и продлевается при каждом обновлении токенов.
+type SessionDB struct {
+	ID         uuid.UUID  `db:"id"`           // id сессии, совпадает с id семейства refresh-токенов
+	UserID     uuid.UUID  `db:"user_id"`      // id пользователя
+	DeviceName string     `db:"device_name"`  // название устройства из заголовка X-Device-Name
+	UserAgent  string     `db:"user_agent"`   // User-Agent последнего запроса на вход или обновление
+	IP         string     `db:"ip"`           // IP-адрес последнего запроса на вход или обновление
+	CreatedAt  time.Time  `db:"created_at"`   // время входа
+	LastSeenAt time.Time  `db:"last_seen_at"` // время последнего входа или обновления токенов
+	ExpiresAt  time.Time  `db:"expires_at"`   // время истечения последнего refresh-токена
+	RevokedAt  *time.Time `db:"revoked_at"`   // время отзыва
+}
+
+// Устройство, с которого выполняется вход или обновление токенов
+type SessionDevice struct {
+	Name      string // название устройства
+	UserAgent string // User-Agent
+	IP        string // IP-адрес
+}
+
+// NewSessionDevice обрезает данные устройства до размеров столбцов бд
+func NewSessionDevice(name string, userAgent string, ip string) *SessionDevice {
+	return &SessionDevice{
+		Name:      truncateRunes(name, MaxSessionDeviceName),
+		UserAgent: truncateRunes(userAgent, MaxSessionUserAgent),
+		IP:        truncateRunes(ip, MaxSessionIP),
+	}
+}
+
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	return string([]rune(s)[:limit])
+}
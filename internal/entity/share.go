@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ShareTokenBytes = 32 // размер случайной части токена
+
+	DefaultShareLinkLimit = 20
+	MaxShareLinkLimit     = 100
+
+	MaxShareAccessUserAgentLength = 255
+)
+
+var (
+	ErrInvalidShareLink = errors.New("invalid share link")
+	// Ссылка существует, но отозвана, истекла или исчерпала лимит прослушиваний
+	ErrShareLinkUnavailable = errors.New("share link is no longer available")
+)
+
+// Ссылка на трек или плейлист для доступа без аккаунта. Задан ровно один из MusicID и PlaylistID.
+type ShareLinkDB struct {
+	ID         uuid.UUID  `db:"id"`          // id ссылки
+	TokenHash  string     `db:"token_hash"`  // SHA-256 токена в hex, сам токен не хранится
+	UserID     uuid.UUID  `db:"user_id"`     // id владельца ссылки
+	MusicID    *uuid.UUID `db:"music_id"`    // id трека
+	PlaylistID *uuid.UUID `db:"playlist_id"` // id плейлиста
+	ExpiresAt  *time.Time `db:"expires_at"`  // время истечения, nil — бессрочно
+	MaxPlays   *int       `db:"max_plays"`   // лимит прослушиваний, nil — без лимита
+	Plays      int        `db:"plays"`       // количество прослушиваний по ссылке
+	CreatedAt  time.Time  `db:"created_at"`  // время создания
+	RevokedAt  *time.Time `db:"revoked_at"`  // время отзыва владельцем
+}
+
+// Check проверяет, что по ссылке еще можно получить доступ
+func (l *ShareLinkDB) Check(now time.Time) error {
+	if l.RevokedAt != nil {
+		return fmt.Errorf("%w: revoked", ErrShareLinkUnavailable)
+	}
+	if l.ExpiresAt != nil && !l.ExpiresAt.After(now) {
+		return fmt.Errorf("%w: expired", ErrShareLinkUnavailable)
+	}
+	if l.MaxPlays != nil && l.Plays >= *l.MaxPlays {
+		return fmt.Errorf("%w: play limit reached", ErrShareLinkUnavailable)
+	}
+	return nil
+}
+
+// PlaysLeft возвращает оставшееся количество прослушиваний, nil если лимита нет
+func (l *ShareLinkDB) PlaysLeft() *int {
+	if l.MaxPlays == nil {
+		return nil
+	}
+	left := *l.MaxPlays - l.Plays
+	if left < 0 {
+		left = 0
+	}
+	return &left
+}
+
+// Параметры создаваемой ссылки
+type ShareLinkCreate struct {
+	ExpiresAt *time.Time `json:"expires_at"` // время истечения в формате RFC3339, null — бессрочно
+	MaxPlays  *int       `json:"max_plays"`  // лимит прослушиваний, null — без лимита
+}
+
+func (s *ShareLinkCreate) Validate(now time.Time) error {
+	if s.ExpiresAt != nil && !s.ExpiresAt.After(now) {
+		return fmt.Errorf("%w: expires_at is in the past", ErrInvalidShareLink)
+	}
+	if s.MaxPlays != nil && *s.MaxPlays <= 0 {
+		return fmt.Errorf("%w: max_plays must be positive", ErrInvalidShareLink)
+	}
+	return nil
+}
+
+// ToDB создает ссылку на трек или плейлист; второй id должен быть nil
+func (s *ShareLinkCreate) ToDB(userId uuid.UUID, musicId *uuid.UUID, playlistId *uuid.UUID, tokenHash string, now time.Time) *ShareLinkDB {
+	return &ShareLinkDB{
+		ID:         uuid.New(),
+		TokenHash:  tokenHash,
+		UserID:     userId,
+		MusicID:    musicId,
+		PlaylistID: playlistId,
+		ExpiresAt:  s.ExpiresAt,
+		MaxPlays:   s.MaxPlays,
+		CreatedAt:  now,
+	}
+}
+
+// Созданная ссылка вместе с токеном. Токен показывается владельцу только один раз.
+type ShareLinkCreated struct {
+	Link  *ShareLinkDB
+	Token string
+}
+
+// NewShareToken генерирует случайный токен ссылки и его хэш для хранения
+func NewShareToken() (string, string, error) {
+	data := make([]byte, ShareTokenBytes)
+	if _, err := rand.Read(data); err != nil {
+		return "", "", fmt.Errorf("can't generate token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(data)
+	return token, HashShareToken(token), nil
+}
+
+// HashShareToken возвращает хэш, по которому ссылка ищется в базе
+func HashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Содержимое, открытое по ссылке: трек или плейлист с доступными треками
+type SharedContent struct {
+	Link     *ShareLinkDB
+	Music    *MusicDB
+	Playlist *PlaylistDB
+	Tracks   []*MusicDB
+}
+
+// Запись журнала обращений по ссылке
+type ShareAccessDB struct {
+	LinkID     *uuid.UUID `db:"link_id"`     // id ссылки, nil для неизвестного токена
+	MusicID    *uuid.UUID `db:"music_id"`    // id отданного трека
+	Route      string     `db:"route"`       // шаблон маршрута без токена
+	Status     int        `db:"status"`      // код ответа
+	IP         string     `db:"ip"`          // адрес клиента
+	UserAgent  string     `db:"user_agent"`  // User-Agent клиента
+	AccessedAt time.Time  `db:"accessed_at"` // время обращения
+}
+
+// NewShareAccess создает запись журнала, обрезая User-Agent до допустимой длины
+func NewShareAccess(link *ShareLinkDB, musicId *uuid.UUID, route string, status int, ip string, userAgent string, now time.Time) *ShareAccessDB {
+	if utf8.RuneCountInString(userAgent) > MaxShareAccessUserAgentLength {
+		userAgent = string([]rune(userAgent)[:MaxShareAccessUserAgentLength])
+	}
+
+	access := &ShareAccessDB{
+		MusicID:    musicId,
+		Route:      route,
+		Status:     status,
+		IP:         ip,
+		UserAgent:  userAgent,
+		AccessedAt: now,
+	}
+	if link != nil {
+		access.LinkID = &link.ID
+	}
+	return access
+}
+
+func NormalizeShareLinkPage(limit int, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultShareLinkLimit
+	}
+	if limit > MaxShareLinkLimit {
+		limit = MaxShareLinkLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
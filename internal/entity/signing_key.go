@@ -0,0 +1,185 @@
+package entity
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"music-backend-test/cmd/music-backend-test/config"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmEdDSA = "EdDSA"
+
+	DefaultSigningAlgorithm    = SigningAlgorithmRS256
+	DefaultKeyRotationInterval = 30 * 24 * time.Hour
+	DefaultKeyOverlap          = 24 * time.Hour
+	DefaultKeyCheckInterval    = time.Hour
+	DefaultKeyCacheTTL         = time.Minute
+
+	RSAKeyBits        = 2048            // длина RSA-ключа
+	KeyIDBytes        = 16              // длина kid в байтах до кодирования
+	KeyReloadInterval = 5 * time.Second // минимальный интервал внеочередной загрузки ключей при неизвестном kid
+	JWKSMaxAge        = 5 * time.Minute // время кэширования JWKS клиентами, должно быть меньше перекрытия ключей
+	LegacyTokenTTL    = 24 * time.Hour  // срок действия токенов HS256, выдававшихся до появления ключей подписи
+)
+
+var (
+	ErrNoSigningKey        = errors.New("no active signing key")
+	ErrUnknownSigningKey   = errors.New("unknown signing key")
+	ErrInvalidSigningKey   = errors.New("invalid signing key")
+	ErrUnsupportedKeyAlg   = errors.New("unsupported signing algorithm")
+	ErrSigningKeyAlgorithm = errors.New("token algorithm doesn't match signing key")
+	ErrLegacyTokenRejected = errors.New("legacy token is not accepted")
+)
+
+// Параметры ключей подписи токенов
+type KeysConfig struct {
+	Algorithm        string        // алгоритм новых ключей
+	RotationInterval time.Duration // сколько ключ подписывает новые токены
+	Overlap          time.Duration // сколько ключ публикуется до начала подписи и принимается после ее окончания
+	CheckInterval    time.Duration // интервал проверки, не пора ли создать следующий ключ
+	CacheTTL         time.Duration // время кэширования набора ключей
+	LegacySecret     string        // ключ HS256 для токенов без kid, выданных до появления ключей подписи. Пустой — такие токены не принимаются.
+	LegacyCutoff     time.Time     // момент перехода на ключи подписи: принимаются только токены HS256, выданные раньше
+}
+
+func NewKeysConfig(cfg *config.Config) *KeysConfig {
+	algorithm := cfg.Keys.Algorithm
+	if algorithm != SigningAlgorithmRS256 && algorithm != SigningAlgorithmEdDSA {
+		algorithm = DefaultSigningAlgorithm
+	}
+	rotationInterval := cfg.Keys.RotationInterval
+	if rotationInterval <= 0 {
+		rotationInterval = DefaultKeyRotationInterval
+	}
+	// Ключ должен приниматься, пока действуют подписанные им токены
+	overlap := cfg.Keys.Overlap
+	if overlap <= 0 {
+		overlap = DefaultKeyOverlap
+	}
+	if accessTokenTTL := NewAuthConfig(cfg).AccessTokenTTL; overlap < accessTokenTTL {
+		overlap = accessTokenTTL
+	}
+	// Следующий ключ создается за overlap до окончания подписи текущим, поэтому ключ должен подписывать дольше
+	if rotationInterval <= overlap {
+		rotationInterval = 2 * overlap
+	}
+	checkInterval := cfg.Keys.CheckInterval
+	if checkInterval <= 0 {
+		checkInterval = DefaultKeyCheckInterval
+	}
+	cacheTTL := cfg.Keys.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultKeyCacheTTL
+	}
+	// Токены HS256 подписаны api-key и не отзываются, поэтому без момента перехода на ключи подписи они не принимаются
+	legacySecret := ""
+	legacyCutoff, err := time.Parse(time.RFC3339, cfg.Keys.LegacyCutoff)
+	if cfg.Keys.LegacyHS256 && err == nil {
+		legacySecret = cfg.ApiKey
+	}
+
+	return &KeysConfig{
+		Algorithm:        algorithm,
+		RotationInterval: rotationInterval,
+		Overlap:          overlap,
+		CheckInterval:    checkInterval,
+		CacheTTL:         cacheTTL,
+		LegacySecret:     legacySecret,
+		LegacyCutoff:     legacyCutoff,
+	}
+}
+
+// Представление ключа подписи в бд
+type SigningKeyDB struct {
+	ID          string    `db:"kid"`          // kid, передается в заголовке токена
+	Algorithm   string    `db:"algorithm"`    // RS256 или EdDSA
+	PrivateKey  string    `db:"private_key"`  // закрытый ключ в PEM (PKCS #8)
+	CreatedAt   time.Time `db:"created_at"`   // время создания, с него ключ публикуется в JWKS
+	ActivatesAt time.Time `db:"activates_at"` // время, с которого ключ подписывает токены
+	RetiresAt   time.Time `db:"retires_at"`   // время, с которого ключ перестает подписывать токены
+	ExpiresAt   time.Time `db:"expires_at"`   // время, с которого подписанные ключом токены не принимаются
+}
+
+// Ключ подписи, готовый к использованию
+type SigningKey struct {
+	*SigningKeyDB
+	Method  jwt.SigningMethod // метод подписи
+	Private crypto.PrivateKey // закрытый ключ
+	Public  crypto.PublicKey  // открытый ключ
+}
+
+// NewSigningKey генерирует ключ algorithm, который подписывает токены с activatesAt в течение cfg.RotationInterval
+func NewSigningKey(algorithm string, now time.Time, activatesAt time.Time, cfg *KeysConfig) (*SigningKeyDB, error) {
+	var private crypto.PrivateKey
+	var err error
+	switch algorithm {
+	case SigningAlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, RSAKeyBits)
+	case SigningAlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyAlg, algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't generate signing key: %w", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("can't encode signing key: %w", err)
+	}
+
+	kid := make([]byte, KeyIDBytes)
+	if _, err := rand.Read(kid); err != nil {
+		return nil, fmt.Errorf("can't generate kid: %w", err)
+	}
+
+	retiresAt := activatesAt.Add(cfg.RotationInterval)
+	return &SigningKeyDB{
+		ID:          base64.RawURLEncoding.EncodeToString(kid),
+		Algorithm:   algorithm,
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		CreatedAt:   now,
+		ActivatesAt: activatesAt,
+		RetiresAt:   retiresAt,
+		ExpiresAt:   retiresAt.Add(cfg.Overlap),
+	}, nil
+}
+
+// Parse разбирает закрытый ключ из бд
+func (k *SigningKeyDB) Parse() (*SigningKey, error) {
+	key := &SigningKey{SigningKeyDB: k}
+	switch k.Algorithm {
+	case SigningAlgorithmRS256:
+		private, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(k.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %v", ErrInvalidSigningKey, k.ID, err)
+		}
+		key.Method, key.Private, key.Public = jwt.SigningMethodRS256, private, &private.PublicKey
+	case SigningAlgorithmEdDSA:
+		parsed, err := jwt.ParseEdPrivateKeyFromPEM([]byte(k.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %v", ErrInvalidSigningKey, k.ID, err)
+		}
+		private, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%w %s: not an Ed25519 key", ErrInvalidSigningKey, k.ID)
+		}
+		key.Method, key.Private, key.Public = jwt.SigningMethodEdDSA, private, private.Public()
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyAlg, k.Algorithm)
+	}
+
+	return key, nil
+}
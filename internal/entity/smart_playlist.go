@@ -0,0 +1,318 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultSmartPlaylistLimit = 100
+	MaxSmartPlaylistLimit     = 500
+
+	MaxSmartRuleDepth = 4  // максимальная вложенность групп правил
+	MaxSmartRules     = 32 // максимальное количество условий и групп в дереве
+	MaxSmartRuleValue = 255
+
+	SmartGroupAnd = "and"
+	SmartGroupOr  = "or"
+
+	SmartOrderAsc  = "asc"
+	SmartOrderDesc = "desc"
+)
+
+var ErrInvalidSmartPlaylist = errors.New("invalid smart playlist")
+
+// Поля треков, по которым строятся правила. Поля liked, my_rating, play_count и last_played
+// относятся к пользователю, для которого вычисляется плейлист.
+const (
+	SmartFieldName        = "name"         // название трека
+	SmartFieldArtist      = "artist"       // имя исполнителя
+	SmartFieldReleaseDate = "release_date" // дата релиза
+	SmartFieldPublishedAt = "published_at" // время публикации
+	SmartFieldDuration    = "duration"     // продолжительность в секундах
+	SmartFieldExplicit    = "explicit"     // ненормативный контент
+	SmartFieldRatingAvg   = "rating_avg"   // средняя оценка
+	SmartFieldRatingCount = "rating_count" // количество оценок
+	SmartFieldLiked       = "liked"        // трек в лайках пользователя
+	SmartFieldMyRating    = "my_rating"    // оценка пользователя, 0 — не оценен
+	SmartFieldPlayCount   = "play_count"   // количество прослушиваний пользователем
+	SmartFieldLastPlayed  = "last_played"  // время последнего прослушивания пользователем
+	SmartFieldRandom      = "random"       // только для сортировки: случайный порядок
+)
+
+// Операторы условий
+const (
+	SmartOpEq            = "eq"
+	SmartOpNeq           = "neq"
+	SmartOpLt            = "lt"
+	SmartOpLte           = "lte"
+	SmartOpGt            = "gt"
+	SmartOpGte           = "gte"
+	SmartOpContains      = "contains"
+	SmartOpNotContains   = "not_contains"
+	SmartOpStartsWith    = "starts_with"
+	SmartOpInLastDays    = "in_last_days"    // значение — количество дней до момента вычисления
+	SmartOpInCurrentYear = "in_current_year" // без значения, календарный год по UTC
+)
+
+const (
+	SmartTypeString = "string"
+	SmartTypeNumber = "number"
+	SmartTypeDate   = "date"
+	SmartTypeBool   = "bool"
+)
+
+var smartFieldTypes = map[string]string{
+	SmartFieldName:        SmartTypeString,
+	SmartFieldArtist:      SmartTypeString,
+	SmartFieldReleaseDate: SmartTypeDate,
+	SmartFieldPublishedAt: SmartTypeDate,
+	SmartFieldDuration:    SmartTypeNumber,
+	SmartFieldExplicit:    SmartTypeBool,
+	SmartFieldRatingAvg:   SmartTypeNumber,
+	SmartFieldRatingCount: SmartTypeNumber,
+	SmartFieldLiked:       SmartTypeBool,
+	SmartFieldMyRating:    SmartTypeNumber,
+	SmartFieldPlayCount:   SmartTypeNumber,
+	SmartFieldLastPlayed:  SmartTypeDate,
+}
+
+var smartTypeOperators = map[string][]string{
+	SmartTypeString: {SmartOpEq, SmartOpNeq, SmartOpContains, SmartOpNotContains, SmartOpStartsWith},
+	SmartTypeNumber: {SmartOpEq, SmartOpNeq, SmartOpLt, SmartOpLte, SmartOpGt, SmartOpGte},
+	SmartTypeDate:   {SmartOpEq, SmartOpNeq, SmartOpLt, SmartOpLte, SmartOpGt, SmartOpGte, SmartOpInLastDays, SmartOpInCurrentYear},
+	SmartTypeBool:   {SmartOpEq, SmartOpNeq},
+}
+
+// Поля, по которым можно сортировать плейлист
+var smartSortFields = map[string]bool{
+	SmartFieldName:        true,
+	SmartFieldArtist:      true,
+	SmartFieldReleaseDate: true,
+	SmartFieldPublishedAt: true,
+	SmartFieldDuration:    true,
+	SmartFieldRatingAvg:   true,
+	SmartFieldRatingCount: true,
+	SmartFieldMyRating:    true,
+	SmartFieldPlayCount:   true,
+	SmartFieldLastPlayed:  true,
+	SmartFieldRandom:      true,
+}
+
+// Узел дерева правил: группа, объединяющая правила через and или or, либо условие на поле трека.
+// Значение условия — строка для текстовых полей, число для числовых и in_last_days,
+// дата в формате 2006-01-02 для полей-дат и true/false для логических полей.
+type SmartRule struct {
+	Group    string       `json:"group,omitempty"`    // and или or для группы
+	Rules    []*SmartRule `json:"rules,omitempty"`    // правила группы
+	Field    string       `json:"field,omitempty"`    // поле трека для условия
+	Operator string       `json:"operator,omitempty"` // оператор условия
+	Value    interface{}  `json:"value,omitempty"`    // значение условия
+}
+
+func (r *SmartRule) IsGroup() bool {
+	return r.Group != ""
+}
+
+// Validate проверяет дерево правил: структуру групп, допустимость операторов для полей и типы значений
+func (r *SmartRule) Validate() error {
+	count := 0
+	return r.validate(1, &count)
+}
+
+func (r *SmartRule) validate(depth int, count *int) error {
+	*count++
+	if *count > MaxSmartRules {
+		return fmt.Errorf("%w: more than %d rules", ErrInvalidSmartPlaylist, MaxSmartRules)
+	}
+
+	if r.IsGroup() {
+		if r.Group != SmartGroupAnd && r.Group != SmartGroupOr {
+			return fmt.Errorf("%w: unknown group %q", ErrInvalidSmartPlaylist, r.Group)
+		}
+		if r.Field != "" || r.Operator != "" || r.Value != nil {
+			return fmt.Errorf("%w: group can't have field, operator or value", ErrInvalidSmartPlaylist)
+		}
+		if len(r.Rules) == 0 {
+			return fmt.Errorf("%w: empty group", ErrInvalidSmartPlaylist)
+		}
+		if depth > MaxSmartRuleDepth {
+			return fmt.Errorf("%w: groups are nested deeper than %d", ErrInvalidSmartPlaylist, MaxSmartRuleDepth)
+		}
+		for _, rule := range r.Rules {
+			if rule == nil {
+				return fmt.Errorf("%w: empty rule", ErrInvalidSmartPlaylist)
+			}
+			if err := rule.validate(depth+1, count); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(r.Rules) > 0 {
+		return fmt.Errorf("%w: condition can't have rules", ErrInvalidSmartPlaylist)
+	}
+	fieldType, ok := smartFieldTypes[r.Field]
+	if !ok {
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidSmartPlaylist, r.Field)
+	}
+	if !containsString(smartTypeOperators[fieldType], r.Operator) {
+		return fmt.Errorf("%w: operator %q is not allowed for %s", ErrInvalidSmartPlaylist, r.Operator, r.Field)
+	}
+	if err := r.validateValue(fieldType); err != nil {
+		return fmt.Errorf("%w: %s %s: %v", ErrInvalidSmartPlaylist, r.Field, r.Operator, err)
+	}
+	return nil
+}
+
+func (r *SmartRule) validateValue(fieldType string) error {
+	switch {
+	case r.Operator == SmartOpInCurrentYear:
+		if r.Value != nil {
+			return errors.New("value is not allowed")
+		}
+	case r.Operator == SmartOpInLastDays:
+		days, ok := r.Value.(float64)
+		if !ok || days < 1 || days != math.Trunc(days) {
+			return errors.New("value must be a positive number of days")
+		}
+	case fieldType == SmartTypeString:
+		value, ok := r.Value.(string)
+		if !ok || value == "" {
+			return errors.New("value must be a non-empty string")
+		}
+		if utf8.RuneCountInString(value) > MaxSmartRuleValue {
+			return fmt.Errorf("value is longer than %d characters", MaxSmartRuleValue)
+		}
+	case fieldType == SmartTypeNumber:
+		value, ok := r.Value.(float64)
+		if !ok || math.IsNaN(value) || math.IsInf(value, 0) {
+			return errors.New("value must be a number")
+		}
+	case fieldType == SmartTypeDate:
+		if _, err := r.DateValue(); err != nil {
+			return errors.New("value must be a date in format 2006-01-02")
+		}
+	case fieldType == SmartTypeBool:
+		if _, ok := r.Value.(bool); !ok {
+			return errors.New("value must be true or false")
+		}
+	}
+	return nil
+}
+
+// DateValue возвращает значение условия на поле-дату как начало дня по UTC
+func (r *SmartRule) DateValue() (time.Time, error) {
+	value, ok := r.Value.(string)
+	if !ok {
+		return time.Time{}, errors.New("value is not a string")
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// Period возвращает границы [from, to) для операторов in_last_days и in_current_year
+func (r *SmartRule) Period(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	if r.Operator == SmartOpInCurrentYear {
+		from := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return from, from.AddDate(1, 0, 0)
+	}
+	days, _ := r.Value.(float64)
+	return now.AddDate(0, 0, -int(days)), now
+}
+
+// SmartFieldType возвращает тип поля правил: string, number, date или bool
+func SmartFieldType(field string) string {
+	return smartFieldTypes[field]
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type SmartPlaylistDB struct {
+	ID        uuid.UUID  // id плейлиста
+	UserID    uuid.UUID  // id владельца
+	Name      string     // название плейлиста
+	IsPublic  bool       // плейлист виден другим пользователям
+	Rules     *SmartRule // дерево правил
+	Sort      string     // поле сортировки
+	Order     string     // направление сортировки
+	Limit     int        // максимальное количество треков
+	CreatedAt time.Time  // время создания
+	UpdatedAt time.Time  // время последнего изменения
+}
+
+// VisibleTo сообщает, может ли пользователь просматривать плейлист
+func (p *SmartPlaylistDB) VisibleTo(userId uuid.UUID) bool {
+	return p.IsPublic || p.UserID == userId
+}
+
+type SmartPlaylistCreate struct {
+	Name     string     `json:"name"`      // название плейлиста
+	IsPublic bool       `json:"is_public"` // плейлист виден другим пользователям
+	Rules    *SmartRule `json:"rules"`     // дерево правил
+	Sort     string     `json:"sort"`      // поле сортировки, по умолчанию published_at
+	Order    string     `json:"order"`     // asc или desc, по умолчанию desc
+	Limit    int        `json:"limit"`     // максимальное количество треков (по умолчанию 100, максимум 500)
+}
+
+// Validate проверяет плейлист и подставляет сортировку и количество треков по умолчанию
+func (p *SmartPlaylistCreate) Validate() error {
+	if err := (&PlaylistCreate{Name: p.Name}).Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSmartPlaylist, err)
+	}
+	if p.Rules == nil {
+		return fmt.Errorf("%w: empty rules", ErrInvalidSmartPlaylist)
+	}
+	if err := p.Rules.Validate(); err != nil {
+		return err
+	}
+
+	if p.Sort == "" {
+		p.Sort = SmartFieldPublishedAt
+	}
+	if !smartSortFields[p.Sort] {
+		return fmt.Errorf("%w: unknown sort %q", ErrInvalidSmartPlaylist, p.Sort)
+	}
+	if p.Order == "" {
+		p.Order = SmartOrderDesc
+	}
+	if p.Order != SmartOrderAsc && p.Order != SmartOrderDesc {
+		return fmt.Errorf("%w: unknown order %q", ErrInvalidSmartPlaylist, p.Order)
+	}
+	if p.Limit == 0 {
+		p.Limit = DefaultSmartPlaylistLimit
+	}
+	if p.Limit < 0 || p.Limit > MaxSmartPlaylistLimit {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidSmartPlaylist, MaxSmartPlaylistLimit)
+	}
+	return nil
+}
+
+func (p *SmartPlaylistCreate) ToDB(userId uuid.UUID, now time.Time) *SmartPlaylistDB {
+	return &SmartPlaylistDB{
+		ID:        uuid.New(),
+		UserID:    userId,
+		Name:      strings.TrimSpace(p.Name),
+		IsPublic:  p.IsPublic,
+		Rules:     p.Rules,
+		Sort:      p.Sort,
+		Order:     p.Order,
+		Limit:     p.Limit,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"music-backend-test/cmd/music-backend-test/config"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultStatsPeriod = 30 * 24 * time.Hour  // период статистики, если from не указан
+	MaxStatsPeriod     = 366 * 24 * time.Hour // максимальный период статистики
+
+	DefaultStatsTopSize        = 10
+	DefaultYearReviewBatchSize = 100
+	YearReviewRetryDelay       = time.Hour          // задержка повтора после первой неудачной попытки
+	MaxYearReviewRetryDelay    = 7 * 24 * time.Hour // максимальная задержка повтора
+	StatsHeatmapDays           = 7
+	StatsHeatmapHours          = 24
+)
+
+var ErrInvalidStatsPeriod = errors.New("invalid stats period")
+
+// Параметры статистики прослушиваний и итогов года
+type StatsConfig struct {
+	TopSize         int // количество треков и исполнителей в топах
+	ReviewBatchSize int // количество итогов года, рассчитываемых за один запуск
+}
+
+func NewStatsConfig(cfg *config.Config) *StatsConfig {
+	topSize := cfg.Stats.TopSize
+	if topSize <= 0 {
+		topSize = DefaultStatsTopSize
+	}
+	batchSize := cfg.Stats.ReviewBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultYearReviewBatchSize
+	}
+
+	return &StatsConfig{
+		TopSize:         topSize,
+		ReviewBatchSize: batchSize,
+	}
+}
+
+// Период статистики [From, To) и размер топов
+type StatsFilter struct {
+	From  time.Time
+	To    time.Time
+	Limit int
+}
+
+// Normalize подставляет период по умолчанию и проверяет, что период не пустой и не длиннее MaxStatsPeriod
+func (f *StatsFilter) Normalize(now time.Time, topSize int) error {
+	if f.To.IsZero() {
+		f.To = now
+	}
+	if f.From.IsZero() {
+		f.From = f.To.Add(-DefaultStatsPeriod)
+	}
+	if !f.From.Before(f.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidStatsPeriod)
+	}
+	if f.To.Sub(f.From) > MaxStatsPeriod {
+		return fmt.Errorf("%w: period is longer than %s", ErrInvalidStatsPeriod, MaxStatsPeriod)
+	}
+	if f.Limit <= 0 {
+		f.Limit = topSize
+	}
+	return nil
+}
+
+// YearStatsFilter возвращает фильтр календарного года в UTC
+func YearStatsFilter(year int, topSize int) *StatsFilter {
+	return &StatsFilter{
+		From:  time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:    time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Limit: topSize,
+	}
+}
+
+// Общие показатели за период. Прослушивание — событие начала воспроизведения.
+type ListeningTotals struct {
+	Plays      int64 `db:"plays"`       // количество прослушиваний
+	Seconds    int64 `db:"seconds"`     // время прослушивания в секундах
+	Tracks     int64 `db:"tracks"`      // количество разных треков
+	ActiveDays int64 `db:"active_days"` // количество дней с прослушиваниями
+}
+
+type TopTrackDB struct {
+	MusicDB
+	Plays   int64 `db:"plays"`   // количество прослушиваний за период
+	Seconds int64 `db:"seconds"` // время прослушивания в секундах
+}
+
+type TopArtistDB struct {
+	ArtistID uuid.UUID `db:"artist_id"` // id исполнителя
+	Name     string    `db:"name"`      // имя исполнителя
+	Plays    int64     `db:"plays"`     // количество прослушиваний за период
+	Seconds  int64     `db:"seconds"`   // время прослушивания в секундах
+}
+
+// Количество прослушиваний в час дня по UTC для дня недели (1 — понедельник, 7 — воскресенье)
+type ListeningHourDB struct {
+	Weekday int   `db:"weekday"`
+	Hour    int   `db:"hour"`
+	Plays   int64 `db:"plays"`
+}
+
+// Непрерывная серия дней с прослушиваниями
+type ListeningStreak struct {
+	Start time.Time `db:"start_day"` // первый день серии
+	End   time.Time `db:"end_day"`   // последний день серии
+	Days  int       `db:"days"`      // длина серии в днях
+}
+
+type ListeningStats struct {
+	From          time.Time          // начало периода
+	To            time.Time          // конец периода, не включая
+	Totals        ListeningTotals    // общие показатели
+	TopTracks     []*TopTrackDB      // самые прослушиваемые треки
+	TopArtists    []*TopArtistDB     // самые прослушиваемые исполнители
+	Heatmap       []*ListeningHourDB // прослушивания по дням недели и часам, только непустые ячейки
+	LongestStreak *ListeningStreak   // самая длинная серия, nil если прослушиваний не было
+}
+
+// HeatmapMatrix раскладывает прослушивания в матрицу 7×24: строки — дни недели с понедельника, столбцы — часы
+func (s *ListeningStats) HeatmapMatrix() [][]int64 {
+	matrix := make([][]int64, StatsHeatmapDays)
+	for i := range matrix {
+		matrix[i] = make([]int64, StatsHeatmapHours)
+	}
+	for _, cell := range s.Heatmap {
+		if cell.Weekday < 1 || cell.Weekday > StatsHeatmapDays || cell.Hour < 0 || cell.Hour >= StatsHeatmapHours {
+			continue
+		}
+		matrix[cell.Weekday-1][cell.Hour] = cell.Plays
+	}
+	return matrix
+}
+
+// Итоги года пользователя
+type YearReviewDB struct {
+	UserID      uuid.UUID       // id пользователя
+	Year        int             // год
+	Stats       *ListeningStats // статистика за календарный год по UTC
+	GeneratedAt time.Time       // время расчета
+}
+
+// Неудачная попытка расчета итогов года
+type YearReviewFailure struct {
+	UserID   uuid.UUID // id пользователя
+	Year     int       // год
+	Error    string    // текст ошибки
+	FailedAt time.Time // время попытки
+}
@@ -0,0 +1,167 @@
+package entity
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"music-backend-test/cmd/music-backend-test/config"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SyncKindTrack    = "track"    // изменение трека каталога
+	SyncKindLike     = "like"     // лайк поставлен или снят
+	SyncKindPlaylist = "playlist" // изменение плейлиста или его состава
+	SyncKindReset    = "reset"    // изменение, после которого нужна полная синхронизация
+
+	DefaultSyncTokenTTL        = 30 * 24 * time.Hour
+	DefaultSyncPageSize        = 1000
+	DefaultSyncCleanupInterval = time.Hour
+
+	// Журнал хранится дольше срока действия токена, чтобы изменения долгих транзакций,
+	// записанные раньше выдачи токена, но зафиксированные после, не удалялись раньше времени
+	SyncRetentionMargin = 24 * time.Hour
+	SyncCleanupBatch    = 10000 // количество записей журнала, удаляемых за один запрос
+)
+
+var (
+	ErrInvalidSyncToken = errors.New("invalid sync token")
+	ErrSyncTokenExpired = errors.New("sync token has expired")
+)
+
+// Параметры синхронизации
+type SyncConfig struct {
+	TokenTTL        time.Duration // срок действия токена синхронизации
+	PageSize        int           // максимальное количество изменений или треков в одном ответе
+	CleanupInterval time.Duration // интервал очистки журнала изменений
+}
+
+func NewSyncConfig(cfg *config.Config) *SyncConfig {
+	tokenTTL := cfg.Sync.TokenTTL
+	if tokenTTL <= 0 {
+		tokenTTL = DefaultSyncTokenTTL
+	}
+	pageSize := cfg.Sync.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultSyncPageSize
+	}
+	cleanupInterval := cfg.Sync.CleanupInterval
+	if cleanupInterval <= 0 {
+		cleanupInterval = DefaultSyncCleanupInterval
+	}
+
+	return &SyncConfig{
+		TokenTTL:        tokenTTL,
+		PageSize:        pageSize,
+		CleanupInterval: cleanupInterval,
+	}
+}
+
+// Retention возвращает время хранения записей журнала изменений
+func (c *SyncConfig) Retention() time.Duration {
+	return c.TokenTTL + SyncRetentionMargin
+}
+
+// Позиция клиента в журнале изменений. Клиент получил все изменения до записи (TxID, ID) включительно.
+// Пока After не nil, выполняется полная синхронизация и клиент получил треки каталога с id до After включительно.
+type SyncCursor struct {
+	TxID     uint64     // транзакция последнего полученного изменения
+	ID       int64      // id последнего полученного изменения
+	IssuedAt time.Time  // время выдачи токена, с него отсчитываются снятия с публикации
+	After    *uuid.UUID // последний полученный трек полной синхронизации
+}
+
+// Encode возвращает непрозрачный токен синхронизации
+func (c *SyncCursor) Encode() string {
+	after := ""
+	if c.After != nil {
+		after = c.After.String()
+	}
+	raw := fmt.Sprintf("%d.%d.%d.%s", c.TxID, c.ID, c.IssuedAt.UnixMilli(), after)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// IsExpired сообщает, истек ли срок действия токена
+func (c *SyncCursor) IsExpired(now time.Time, ttl time.Duration) bool {
+	return c.IssuedAt.Add(ttl).Before(now)
+}
+
+// ParseSyncToken разбирает токен, выданный Encode
+func ParseSyncToken(token string) (*SyncCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSyncToken, err)
+	}
+
+	parts := strings.Split(string(raw), ".")
+	if len(parts) != 4 {
+		return nil, ErrInvalidSyncToken
+	}
+
+	txId, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSyncToken, err)
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || id < 0 {
+		return nil, fmt.Errorf("%w: invalid change id", ErrInvalidSyncToken)
+	}
+	issuedAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSyncToken, err)
+	}
+
+	cursor := &SyncCursor{TxID: txId, ID: id, IssuedAt: time.UnixMilli(issuedAt)}
+	if parts[3] != "" {
+		after, err := uuid.Parse(parts[3])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSyncToken, err)
+		}
+		cursor.After = &after
+	}
+
+	return cursor, nil
+}
+
+// Запись журнала изменений
+type SyncChangeDB struct {
+	ID       int64     `db:"id"`        // id записи
+	TxID     uint64    `db:"txid"`      // транзакция, в которой произошло изменение
+	Kind     string    `db:"kind"`      // вид изменения: track, like, playlist или reset
+	EntityID uuid.UUID `db:"entity_id"` // id трека или плейлиста
+}
+
+// Лайк пользователя
+type SyncLikeDB struct {
+	MusicID uuid.UUID `db:"music_id"`   // id трека
+	LikedAt time.Time `db:"created_at"` // время лайка
+}
+
+// Трек плейлиста
+type SyncPlaylistTrackDB struct {
+	PlaylistID uuid.UUID `db:"playlist_id"` // id плейлиста
+	MusicID    uuid.UUID `db:"music_id"`    // id трека
+}
+
+// Плейлист с id доступных треков в порядке плейлиста
+type SyncPlaylist struct {
+	Playlist *PlaylistDB
+	TrackIDs []uuid.UUID
+}
+
+// Изменения, которые клиенту нужно применить к локальной копии
+type SyncDelta struct {
+	Cursor           *SyncCursor     // позиция для следующего запроса
+	Full             bool            // ответ — часть полной синхронизации, локальную копию нужно заменить
+	HasMore          bool            // есть следующая страница, запрашивать ее можно сразу
+	Tracks           []*MusicDB      // созданные и измененные треки
+	DeletedTracks    []uuid.UUID     // треки, удаленные или ставшие недоступными
+	Likes            []*SyncLikeDB   // поставленные лайки
+	RemovedLikes     []uuid.UUID     // треки, лайк которых снят
+	Playlists        []*SyncPlaylist // созданные и измененные плейлисты
+	DeletedPlaylists []uuid.UUID     // удаленные плейлисты
+}
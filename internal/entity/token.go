@@ -1,64 +1,76 @@
 package entity
 
 import (
+	"errors"
 	"fmt"
-	"music-backend-test/cmd/music-backend-test/config"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
 )
 
-type Token struct {
-	Token *jwt.Token
-}
+var ErrInvalidToken = errors.New("invalid token")
 
 type TokenShow struct {
 	Token string `json:"token"`
 }
 
-func (t *Token) String() (string, error) {
-	cfg, err := config.GetAppConfig()
-	if err != nil {
-		return "", fmt.Errorf("can't generate token: %w", err)
-	}
-
-	return t.Token.SignedString([]byte(cfg.ApiKey))
+// Поля access-токена
+type AccessClaims struct {
+	jwt.StandardClaims
+	SessionID string `json:"sid,omitempty"` // id сессии, в рамках которой выдан токен
 }
 
-func GenerateToken(id uuid.UUID) *Token {
-	return &Token{
-		Token: jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
+// NewAccessClaims возвращает поля access-токена пользователя id в сессии sessionId, действующего ttl с момента now
+func NewAccessClaims(id uuid.UUID, sessionId uuid.UUID, now time.Time, ttl time.Duration) *AccessClaims {
+	return &AccessClaims{
+		StandardClaims: jwt.StandardClaims{
 			Id:        id.String(),
-			IssuedAt:  time.Now().Unix(),
-			ExpiresAt: time.Now().Add(time.Hour * 24).Unix(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(ttl).Unix(),
 			Subject:   "auth",
-		}),
+		},
+		SessionID: sessionId.String(),
 	}
 }
 
-func ParseToken(tokenString string) (uuid.UUID, error) {
-	cfg, err := config.GetAppConfig()
-	if err != nil {
-		return uuid.Nil, err
+// ValidLegacy сообщает, мог ли токен без kid быть выдан до перехода на ключи подписи: он выдан раньше cutoff
+// и действует не дольше LegacyTokenTTL. Иначе это токен, подписанный api-key уже после перехода.
+func (c *AccessClaims) ValidLegacy(cutoff time.Time) bool {
+	if c.IssuedAt == 0 || c.ExpiresAt == 0 {
+		return false
 	}
+	issuedAt := time.Unix(c.IssuedAt, 0)
+	lifetime := time.Unix(c.ExpiresAt, 0).Sub(issuedAt)
 
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.StandardClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(cfg.ApiKey), nil
-	})
+	return issuedAt.Before(cutoff) && lifetime <= LegacyTokenTTL
+}
+
+// ParseToken проверяет подпись access-токена ключом, который возвращает keyfunc,
+// и возвращает id пользователя и id сессии. У токенов, выданных до появления сессий, id сессии равен uuid.Nil.
+func ParseToken(tokenString string, keyfunc jwt.Keyfunc) (uuid.UUID, uuid.UUID, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &AccessClaims{}, keyfunc)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("invalid token: %v", err)
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
 	}
 
-	claims, ok := token.Claims.(*jwt.StandardClaims)
+	claims, ok := token.Claims.(*AccessClaims)
 	if !ok || !token.Valid {
-		return uuid.Nil, fmt.Errorf("invalid token")
+		return uuid.Nil, uuid.Nil, ErrInvalidToken
 	}
 
 	id, err := uuid.Parse(claims.Id)
 	if err != nil {
-		return uuid.Nil, err
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	sessionId := uuid.Nil
+	if claims.SessionID != "" {
+		sessionId, err = uuid.Parse(claims.SessionID)
+		if err != nil {
+			return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid session id: %v", ErrInvalidToken, err)
+		}
 	}
 
-	return id, nil
+	return id, sessionId, nil
 }
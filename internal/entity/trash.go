@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"errors"
+	"music-backend-test/cmd/music-backend-test/config"
+	"time"
+)
+
+const (
+	DefaultTrashRetention = 30 * 24 * time.Hour
+	DefaultTrashLimit     = 20
+	MaxTrashLimit         = 100
+)
+
+// Имя пользователя из корзины успели занять, пока он был удален
+var ErrUsernameTaken = errors.New("username is already taken")
+
+// Параметры хранения удаленных треков и пользователей
+type TrashConfig struct {
+	Retention time.Duration // срок хранения в корзине, после которого строки и файлы удаляются окончательно
+}
+
+func NewTrashConfig(cfg *config.Config) *TrashConfig {
+	retention := cfg.Trash.Retention
+	if retention <= 0 {
+		retention = DefaultTrashRetention
+	}
+
+	return &TrashConfig{
+		Retention: retention,
+	}
+}
+
+func NormalizeTrashPage(limit int, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultTrashLimit
+	}
+	if limit > MaxTrashLimit {
+		limit = MaxTrashLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
@@ -1,15 +1,24 @@
 package entity
 
 import (
+	"errors"
+	"time"
+
 	"github.com/google/uuid"
 )
 
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
 // Представление пользователя в бд
 type UserDB struct {
-	ID       uuid.UUID `db:"id"`       // ID
-	Username string    `db:"username"` // Имя пользователя
-	Password string    `db:"password"` // Пароль
-	Role     string    `db:"role"`     // Роль
+	ID             uuid.UUID  `db:"id"`              // ID
+	Username       string     `db:"username"`        // Имя пользователя
+	Password       string     `db:"password"`        // Хэш пароля; у пользователей, не входивших после перехода на хэширование, — открытый текст
+	Role           string     `db:"role"`            // Роль
+	DeletedAt      *time.Time `db:"deleted_at"`      // Время перемещения в корзину
+	HideExplicit   bool       `db:"hide_explicit"`   // Скрывать треки с ненормативным контентом
+	ShareLikes     bool       `db:"share_likes"`     // Показывать лайки подписчикам
+	SharePlaylists bool       `db:"share_playlists"` // Показывать публичные плейлисты подписчикам
 }
 
 // Представление пользователя для создания записи в бд
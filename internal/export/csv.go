@@ -0,0 +1,149 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"music-backend-test/internal/entity"
+	"strconv"
+	"time"
+)
+
+// Колонки CSV-выгрузки. Каждая строка — одна запись раздела section, неприменимые колонки пустые.
+var csvHeader = []string{
+	"section", "track_id", "track_name", "artist", "duration_sec", "url",
+	"playlist_id", "playlist_name", "position", "rating", "event_id", "event_type", "position_sec", "source", "at",
+}
+
+// Индексы колонок в csvHeader
+const (
+	csvSection = iota
+	csvTrackID
+	csvTrackName
+	csvArtist
+	csvDuration
+	csvURL
+	csvPlaylistID
+	csvPlaylistName
+	csvPosition
+	csvRating
+	csvEventID
+	csvEventType
+	csvPositionSec
+	csvSource
+	csvAt
+)
+
+const (
+	csvSectionLike     = "like"
+	csvSectionPlaylist = "playlist"
+	csvSectionRating   = "rating"
+	csvSectionHistory  = "history"
+)
+
+type csvEncoder struct {
+	w    *csv.Writer
+	opts Options
+}
+
+func newCSVEncoder(w io.Writer, opts Options) (*csvEncoder, error) {
+	e := &csvEncoder{
+		w:    csv.NewWriter(w),
+		opts: opts,
+	}
+	if err := e.w.Write(csvHeader); err != nil {
+		return nil, fmt.Errorf("can't write csv: %w", err)
+	}
+	return e, nil
+}
+
+func (e *csvEncoder) WriteLikes(likes []*entity.ExportLikeDB) error {
+	for _, like := range likes {
+		record := e.record(csvSectionLike, &like.ExportTrack, like.LikedAt)
+		if err := e.w.Write(record); err != nil {
+			return fmt.Errorf("can't write csv: %w", err)
+		}
+	}
+	return e.flush()
+}
+
+// WritePlaylist пишет строку на каждый трек плейлиста. Пустой плейлист записывается одной строкой без трека.
+func (e *csvEncoder) WritePlaylist(playlist *entity.PlaylistDB, tracks []*entity.ExportPlaylistTrackDB) error {
+	if len(tracks) == 0 {
+		record := make([]string, len(csvHeader))
+		record[csvSection] = csvSectionPlaylist
+		record[csvPlaylistID] = playlist.ID.String()
+		record[csvPlaylistName] = playlist.Name
+		record[csvAt] = formatCSVTime(playlist.CreatedAt)
+		if err := e.w.Write(record); err != nil {
+			return fmt.Errorf("can't write csv: %w", err)
+		}
+		return e.flush()
+	}
+
+	for _, track := range tracks {
+		record := e.record(csvSectionPlaylist, &track.ExportTrack, track.AddedAt)
+		record[csvPlaylistID] = playlist.ID.String()
+		record[csvPlaylistName] = playlist.Name
+		record[csvPosition] = strconv.Itoa(track.Position)
+		if err := e.w.Write(record); err != nil {
+			return fmt.Errorf("can't write csv: %w", err)
+		}
+	}
+	return e.flush()
+}
+
+func (e *csvEncoder) WriteRatings(ratings []*entity.ExportRatingDB) error {
+	for _, rating := range ratings {
+		record := e.record(csvSectionRating, &rating.ExportTrack, rating.UpdatedAt)
+		record[csvRating] = strconv.Itoa(rating.Rating)
+		if err := e.w.Write(record); err != nil {
+			return fmt.Errorf("can't write csv: %w", err)
+		}
+	}
+	return e.flush()
+}
+
+func (e *csvEncoder) WriteHistory(events []*entity.ExportPlayDB) error {
+	for _, event := range events {
+		record := e.record(csvSectionHistory, &event.ExportTrack, event.PlayedAt)
+		record[csvEventID] = event.ID.String()
+		record[csvEventType] = event.EventType
+		record[csvPositionSec] = strconv.Itoa(event.PositionSec)
+		record[csvSource] = event.Source
+		if err := e.w.Write(record); err != nil {
+			return fmt.Errorf("can't write csv: %w", err)
+		}
+	}
+	return e.flush()
+}
+
+func (e *csvEncoder) Close() error {
+	return e.flush()
+}
+
+func (e *csvEncoder) record(section string, track *entity.ExportTrack, at time.Time) []string {
+	record := make([]string, len(csvHeader))
+	record[csvSection] = section
+	record[csvTrackID] = track.MusicID.String()
+	record[csvTrackName] = track.Name
+	if track.Artist != nil {
+		record[csvArtist] = *track.Artist
+	}
+	record[csvDuration] = strconv.FormatInt(track.Duration, 10)
+	record[csvURL] = e.opts.TrackURL(track.MusicID)
+	record[csvAt] = formatCSVTime(at)
+	return record
+}
+
+func (e *csvEncoder) flush() error {
+	e.w.Flush()
+	if err := e.w.Error(); err != nil {
+		return fmt.Errorf("can't write csv: %w", err)
+	}
+	return nil
+}
+
+func formatCSVTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Общие параметры выгрузки
+type Options struct {
+	UserID     uuid.UUID                      // id владельца библиотеки
+	ExportedAt time.Time                      // время выгрузки
+	TrackURL   func(musicId uuid.UUID) string // постоянная ссылка на файл трека
+}
+
+// NewEncoder создает кодировщик для формата запроса
+func NewEncoder(request *entity.ExportRequest, w io.Writer, opts Options) (Encoder, error) {
+	switch request.Format {
+	case entity.ExportFormatJSON:
+		return newJSONEncoder(w, opts), nil
+	case entity.ExportFormatCSV:
+		return newCSVEncoder(w, opts)
+	case entity.ExportFormatM3U8:
+		return newPlaylistEncoder(w, opts, request.Format, writeM3U8, request.IsArchive()), nil
+	case entity.ExportFormatXSPF:
+		return newPlaylistEncoder(w, opts, request.Format, writeXSPF, request.IsArchive()), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", entity.ErrInvalidExport, request.Format)
+	}
+}
+
+// Трек в JSON-выгрузке
+type jsonTrack struct {
+	ID       uuid.UUID `json:"track_id"`     // id трека
+	Name     string    `json:"name"`         // название трека
+	Artist   *string   `json:"artist"`       // имя исполнителя
+	Duration int64     `json:"duration_sec"` // продолжительность в секундах
+	URL      string    `json:"url"`          // ссылка на файл трека
+}
+
+func newJSONTrack(track *entity.ExportTrack, opts Options) jsonTrack {
+	return jsonTrack{
+		ID:       track.MusicID,
+		Name:     track.Name,
+		Artist:   track.Artist,
+		Duration: track.Duration,
+		URL:      opts.TrackURL(track.MusicID),
+	}
+}
+
+// trackTitle возвращает название трека с исполнителем для форматов плейлистов
+func trackTitle(track *entity.ExportTrack) string {
+	if track.Artist == nil || *track.Artist == "" {
+		return track.Name
+	}
+	return fmt.Sprintf("%s - %s", *track.Artist, track.Name)
+}
@@ -0,0 +1,17 @@
+package export
+
+import (
+	"music-backend-test/internal/entity"
+)
+
+// Encoder записывает выгрузку в поток по мере чтения данных. Разделы пишутся в порядке
+// лайки, плейлисты, оценки, история; плейлисты и история могут записываться частями.
+// Форматы плейлистов записывают только плейлисты, остальные разделы пропускают.
+type Encoder interface {
+	WriteLikes(likes []*entity.ExportLikeDB) error
+	WritePlaylist(playlist *entity.PlaylistDB, tracks []*entity.ExportPlaylistTrackDB) error
+	WriteRatings(ratings []*entity.ExportRatingDB) error
+	WriteHistory(events []*entity.ExportPlayDB) error
+	// Close дописывает завершающую часть выгрузки. Сам поток не закрывается.
+	Close() error
+}
@@ -0,0 +1,217 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Разделы JSON-выгрузки в порядке записи. Пустые разделы записываются пустыми массивами.
+var jsonSections = []string{"likes", "playlists", "ratings", "history"}
+
+const (
+	jsonSectionLikes = iota
+	jsonSectionPlaylists
+	jsonSectionRatings
+	jsonSectionHistory
+)
+
+type jsonLike struct {
+	jsonTrack
+	LikedAt time.Time `json:"liked_at"` // время лайка
+}
+
+type jsonPlaylistTrack struct {
+	jsonTrack
+	Position int       `json:"position"` // позиция в плейлисте
+	AddedAt  time.Time `json:"added_at"` // время добавления
+}
+
+type jsonPlaylist struct {
+	ID        uuid.UUID           `json:"id"`         // id плейлиста
+	Name      string              `json:"name"`       // название плейлиста
+	IsPublic  bool                `json:"is_public"`  // плейлист виден другим пользователям
+	CreatedAt time.Time           `json:"created_at"` // время создания
+	UpdatedAt time.Time           `json:"updated_at"` // время последнего изменения
+	Tracks    []jsonPlaylistTrack `json:"tracks"`     // треки по порядку
+}
+
+type jsonRating struct {
+	jsonTrack
+	Rating    int       `json:"rating"`     // оценка от 1 до 5
+	UpdatedAt time.Time `json:"updated_at"` // время последнего изменения оценки
+}
+
+type jsonPlay struct {
+	ID uuid.UUID `json:"id"` // id события
+	jsonTrack
+	EventType   string    `json:"event_type"`   // тип события
+	PositionSec int       `json:"position_sec"` // позиция в треке
+	Source      string    `json:"source"`       // источник события
+	PlayedAt    time.Time `json:"played_at"`    // время события
+}
+
+// jsonEncoder пишет один JSON-объект, разделы которого открываются по мере записи.
+// Элементы сериализуются по одному, поэтому выгрузка не собирается в памяти целиком.
+type jsonEncoder struct {
+	w       *bufio.Writer
+	opts    Options
+	started bool
+	section int // индекс открытого раздела, -1 — ни один раздел еще не открыт
+	empty   bool
+}
+
+func newJSONEncoder(w io.Writer, opts Options) *jsonEncoder {
+	return &jsonEncoder{
+		w:       bufio.NewWriter(w),
+		opts:    opts,
+		section: -1,
+	}
+}
+
+func (e *jsonEncoder) WriteLikes(likes []*entity.ExportLikeDB) error {
+	if err := e.open(jsonSectionLikes); err != nil {
+		return err
+	}
+	for _, like := range likes {
+		err := e.item(&jsonLike{jsonTrack: newJSONTrack(&like.ExportTrack, e.opts), LikedAt: like.LikedAt.UTC()})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *jsonEncoder) WritePlaylist(playlist *entity.PlaylistDB, tracks []*entity.ExportPlaylistTrackDB) error {
+	if err := e.open(jsonSectionPlaylists); err != nil {
+		return err
+	}
+
+	data := &jsonPlaylist{
+		ID:        playlist.ID,
+		Name:      playlist.Name,
+		IsPublic:  playlist.IsPublic,
+		CreatedAt: playlist.CreatedAt.UTC(),
+		UpdatedAt: playlist.UpdatedAt.UTC(),
+		Tracks:    make([]jsonPlaylistTrack, 0, len(tracks)),
+	}
+	for _, track := range tracks {
+		data.Tracks = append(data.Tracks, jsonPlaylistTrack{
+			jsonTrack: newJSONTrack(&track.ExportTrack, e.opts),
+			Position:  track.Position,
+			AddedAt:   track.AddedAt.UTC(),
+		})
+	}
+	return e.item(data)
+}
+
+func (e *jsonEncoder) WriteRatings(ratings []*entity.ExportRatingDB) error {
+	if err := e.open(jsonSectionRatings); err != nil {
+		return err
+	}
+	for _, rating := range ratings {
+		err := e.item(&jsonRating{jsonTrack: newJSONTrack(&rating.ExportTrack, e.opts), Rating: rating.Rating, UpdatedAt: rating.UpdatedAt.UTC()})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *jsonEncoder) WriteHistory(events []*entity.ExportPlayDB) error {
+	if err := e.open(jsonSectionHistory); err != nil {
+		return err
+	}
+	for _, event := range events {
+		err := e.item(&jsonPlay{
+			ID:          event.ID,
+			jsonTrack:   newJSONTrack(&event.ExportTrack, e.opts),
+			EventType:   event.EventType,
+			PositionSec: event.PositionSec,
+			Source:      event.Source,
+			PlayedAt:    event.PlayedAt.UTC(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *jsonEncoder) Close() error {
+	if err := e.open(len(jsonSections)); err != nil {
+		return err
+	}
+	if _, err := e.w.WriteString("}\n"); err != nil {
+		return fmt.Errorf("can't write json: %w", err)
+	}
+	if err := e.w.Flush(); err != nil {
+		return fmt.Errorf("can't flush json: %w", err)
+	}
+	return nil
+}
+
+// open закрывает текущий раздел и открывает разделы до section включительно.
+// Раздел, который уже закрыт, повторно не открывается.
+func (e *jsonEncoder) open(section int) error {
+	if e.section == len(jsonSections) {
+		return errors.New("encoder is closed")
+	}
+	if section < e.section {
+		return fmt.Errorf("section %s is written after %s", jsonSections[section], jsonSections[e.section])
+	}
+	if !e.started {
+		header, err := json.Marshal(map[string]interface{}{
+			"user_id":     e.opts.UserID,
+			"exported_at": e.opts.ExportedAt.UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("can't marshal json: %w", err)
+		}
+		// Заголовок пишется без закрывающей скобки, разделы дописываются следом
+		if _, err := e.w.Write(header[:len(header)-1]); err != nil {
+			return fmt.Errorf("can't write json: %w", err)
+		}
+		e.started = true
+	}
+
+	for e.section < section {
+		if e.section >= 0 {
+			if err := e.w.WriteByte(']'); err != nil {
+				return fmt.Errorf("can't write json: %w", err)
+			}
+		}
+		e.section++
+		if e.section == len(jsonSections) {
+			break
+		}
+		if _, err := fmt.Fprintf(e.w, ",%q:[", jsonSections[e.section]); err != nil {
+			return fmt.Errorf("can't write json: %w", err)
+		}
+		e.empty = true
+	}
+	return nil
+}
+
+func (e *jsonEncoder) item(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("can't marshal json: %w", err)
+	}
+	if !e.empty {
+		if err := e.w.WriteByte(','); err != nil {
+			return fmt.Errorf("can't write json: %w", err)
+		}
+	}
+	e.empty = false
+	if _, err := e.w.Write(data); err != nil {
+		return fmt.Errorf("can't write json: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"music-backend-test/internal/entity"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	xspfNamespace = "http://xspf.org/ns/0/"
+
+	maxArchiveNameLength = 64 // длина названия плейлиста в имени файла архива
+)
+
+// Символы, недопустимые в именах файлов архива
+var unsafeFileNameChars = regexp.MustCompile(`[^\p{L}\p{N} ._-]+`)
+
+// playlistWriter записывает один плейлист в формате M3U8 или XSPF
+type playlistWriter func(w io.Writer, playlist *entity.PlaylistDB, tracks []*entity.ExportPlaylistTrackDB, opts Options) error
+
+// playlistEncoder выгружает только плейлисты: один плейлист прямо в поток или все плейлисты ZIP-архивом,
+// по файлу на плейлист
+type playlistEncoder struct {
+	w       io.Writer
+	archive *zip.Writer
+	opts    Options
+	write   playlistWriter
+	format  string
+	written int
+}
+
+func newPlaylistEncoder(w io.Writer, opts Options, format string, write playlistWriter, archive bool) *playlistEncoder {
+	e := &playlistEncoder{
+		w:      w,
+		opts:   opts,
+		write:  write,
+		format: format,
+	}
+	if archive {
+		e.archive = zip.NewWriter(w)
+	}
+	return e
+}
+
+func (e *playlistEncoder) WriteLikes(likes []*entity.ExportLikeDB) error {
+	return nil
+}
+
+func (e *playlistEncoder) WritePlaylist(playlist *entity.PlaylistDB, tracks []*entity.ExportPlaylistTrackDB) error {
+	e.written++
+	if e.archive == nil {
+		if e.written > 1 {
+			return errors.New("only one playlist can be written without archive")
+		}
+		return e.write(e.w, playlist, tracks, e.opts)
+	}
+
+	file, err := e.archive.CreateHeader(&zip.FileHeader{
+		Name:     archiveFileName(e.written, playlist, e.format),
+		Method:   zip.Deflate,
+		Modified: playlist.UpdatedAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("can't create archive file: %w", err)
+	}
+	return e.write(file, playlist, tracks, e.opts)
+}
+
+func (e *playlistEncoder) WriteRatings(ratings []*entity.ExportRatingDB) error {
+	return nil
+}
+
+func (e *playlistEncoder) WriteHistory(events []*entity.ExportPlayDB) error {
+	return nil
+}
+
+func (e *playlistEncoder) Close() error {
+	if e.archive == nil {
+		return nil
+	}
+	if err := e.archive.Close(); err != nil {
+		return fmt.Errorf("can't close archive: %w", err)
+	}
+	return nil
+}
+
+// archiveFileName возвращает уникальное имя файла плейлиста в архиве: порядковый номер и название
+func archiveFileName(n int, playlist *entity.PlaylistDB, format string) string {
+	name := strings.TrimSpace(unsafeFileNameChars.ReplaceAllString(playlist.Name, "_"))
+	if utf8.RuneCountInString(name) > maxArchiveNameLength {
+		name = string([]rune(name)[:maxArchiveNameLength])
+	}
+	if name == "" {
+		name = playlist.ID.String()
+	}
+	return fmt.Sprintf("%03d %s.%s", n, name, format)
+}
+
+// writeM3U8 записывает плейлист в расширенном формате M3U в UTF-8
+func writeM3U8(w io.Writer, playlist *entity.PlaylistDB, tracks []*entity.ExportPlaylistTrackDB, opts Options) error {
+	buf := bufio.NewWriter(w)
+	fmt.Fprintf(buf, "#EXTM3U\n#PLAYLIST:%s\n", m3uLine(playlist.Name))
+	for _, track := range tracks {
+		fmt.Fprintf(buf, "#EXTINF:%d,%s\n%s\n", track.Duration, m3uLine(trackTitle(&track.ExportTrack)), opts.TrackURL(track.MusicID))
+	}
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("can't write m3u8: %w", err)
+	}
+	return nil
+}
+
+// m3uLine убирает переводы строк, которые разорвали бы директиву M3U
+func m3uLine(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
+
+type xspfPlaylist struct {
+	XMLName xml.Name    `xml:"playlist"`
+	Version string      `xml:"version,attr"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	Date    string      `xml:"date"`
+	Tracks  []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location   string `xml:"location"`
+	Identifier string `xml:"identifier"`
+	Title      string `xml:"title"`
+	Creator    string `xml:"creator,omitempty"`
+	Duration   int64  `xml:"duration"` // продолжительность в миллисекундах
+	TrackNum   int    `xml:"trackNum"`
+}
+
+// writeXSPF записывает плейлист в формате XSPF 1
+func writeXSPF(w io.Writer, playlist *entity.PlaylistDB, tracks []*entity.ExportPlaylistTrackDB, opts Options) error {
+	data := &xspfPlaylist{
+		Version: "1",
+		XMLNS:   xspfNamespace,
+		Title:   playlist.Name,
+		Date:    playlist.UpdatedAt.UTC().Format(time.RFC3339),
+		Tracks:  make([]xspfTrack, 0, len(tracks)),
+	}
+	for i, track := range tracks {
+		xspf := xspfTrack{
+			Location:   opts.TrackURL(track.MusicID),
+			Identifier: "urn:uuid:" + track.MusicID.String(),
+			Title:      track.Name,
+			Duration:   track.Duration * 1000,
+			TrackNum:   i + 1,
+		}
+		if track.Artist != nil {
+			xspf.Creator = *track.Artist
+		}
+		data.Tracks = append(data.Tracks, xspf)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("can't write xspf: %w", err)
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("can't write xspf: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("can't write xspf: %w", err)
+	}
+	return nil
+}
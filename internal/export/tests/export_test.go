@@ -0,0 +1,174 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/export"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testUserId  = uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	testMusicId = uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	testTime    = time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	testArtist  = "Artist1"
+	testTrack   = entity.ExportTrack{MusicID: testMusicId, Name: "Song1", Artist: &testArtist, Duration: 185}
+	testOptions = export.Options{
+		UserID:     testUserId,
+		ExportedAt: testTime,
+		TrackURL: func(musicId uuid.UUID) string {
+			return "http://music.local/music/download/" + musicId.String()
+		},
+	}
+	testPlaylist = &entity.PlaylistDB{
+		ID:        uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11"),
+		UserID:    testUserId,
+		Name:      "Road / Trip",
+		CreatedAt: testTime,
+		UpdatedAt: testTime,
+	}
+	testPlaylistTracks = []*entity.ExportPlaylistTrackDB{{ExportTrack: testTrack, Position: 1, AddedAt: testTime}}
+)
+
+func writeLibrary(t *testing.T, request *entity.ExportRequest, history [][]*entity.ExportPlayDB) []byte {
+	var buf bytes.Buffer
+	encoder, err := export.NewEncoder(request, &buf, testOptions)
+	assert.NoError(t, err)
+
+	assert.NoError(t, encoder.WriteLikes([]*entity.ExportLikeDB{{ExportTrack: testTrack, LikedAt: testTime}}))
+	assert.NoError(t, encoder.WritePlaylist(testPlaylist, testPlaylistTracks))
+	assert.NoError(t, encoder.WriteRatings(nil))
+	for _, page := range history {
+		assert.NoError(t, encoder.WriteHistory(page))
+	}
+	assert.NoError(t, encoder.Close())
+	return buf.Bytes()
+}
+
+func Test_jsonEncoder(t *testing.T) {
+	event := func(id string) *entity.ExportPlayDB {
+		return &entity.ExportPlayDB{ID: uuid.MustParse(id), ExportTrack: testTrack, EventType: "start", Source: "client", PlayedAt: testTime}
+	}
+
+	data := writeLibrary(t, &entity.ExportRequest{Format: entity.ExportFormatJSON}, [][]*entity.ExportPlayDB{
+		{event("00000000-0000-0000-0000-000000000001")},
+		{event("00000000-0000-0000-0000-000000000002")},
+	})
+
+	var got struct {
+		UserID    uuid.UUID `json:"user_id"`
+		Likes     []map[string]interface{}
+		Playlists []struct {
+			Name   string
+			Tracks []map[string]interface{}
+		}
+		Ratings []map[string]interface{}
+		History []map[string]interface{}
+	}
+	assert.NoError(t, json.Unmarshal(data, &got), string(data))
+	assert.Equal(t, testUserId, got.UserID)
+	assert.Len(t, got.Likes, 1)
+	assert.Equal(t, "http://music.local/music/download/"+testMusicId.String(), got.Likes[0]["url"])
+	assert.Len(t, got.Playlists, 1)
+	assert.Len(t, got.Playlists[0].Tracks, 1)
+	assert.NotNil(t, got.Ratings)
+	assert.Len(t, got.Ratings, 0)
+	assert.Len(t, got.History, 2)
+}
+
+func Test_jsonEncoder_emptyLibrary(t *testing.T) {
+	var buf bytes.Buffer
+	encoder, err := export.NewEncoder(&entity.ExportRequest{Format: entity.ExportFormatJSON}, &buf, testOptions)
+	assert.NoError(t, err)
+	assert.NoError(t, encoder.Close())
+
+	var got map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &got), buf.String())
+	for _, section := range []string{"likes", "playlists", "ratings", "history"} {
+		assert.Equal(t, []interface{}{}, got[section], section)
+	}
+}
+
+func Test_csvEncoder(t *testing.T) {
+	data := writeLibrary(t, &entity.ExportRequest{Format: entity.ExportFormatCSV}, nil)
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, "section", records[0][0])
+	assert.Equal(t, []string{"like", testMusicId.String(), "Song1", "Artist1", "185"}, records[1][:5])
+	assert.Equal(t, "playlist", records[2][0])
+	assert.Equal(t, "Road / Trip", records[2][7])
+	assert.Equal(t, "1", records[2][8])
+}
+
+func Test_playlistEncoder(t *testing.T) {
+	playlistId := testPlaylist.ID
+
+	tests := []struct {
+		name    string
+		request *entity.ExportRequest
+		want    []string
+	}{
+		{
+			name:    "m3u8",
+			request: &entity.ExportRequest{Format: entity.ExportFormatM3U8, PlaylistID: &playlistId},
+			want: []string{
+				"#EXTM3U",
+				"#PLAYLIST:Road / Trip",
+				"#EXTINF:185,Artist1 - Song1",
+				"http://music.local/music/download/" + testMusicId.String(),
+			},
+		},
+		{
+			name:    "xspf",
+			request: &entity.ExportRequest{Format: entity.ExportFormatXSPF, PlaylistID: &playlistId},
+			want: []string{
+				`<playlist version="1" xmlns="http://xspf.org/ns/0/">`,
+				"<location>http://music.local/music/download/" + testMusicId.String() + "</location>",
+				"<creator>Artist1</creator>",
+				"<duration>185000</duration>",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := string(writeLibrary(t, tt.request, nil))
+			for _, want := range tt.want {
+				assert.Contains(t, data, want)
+			}
+			assert.NotContains(t, data, "liked_at")
+		})
+	}
+}
+
+func Test_playlistEncoder_archive(t *testing.T) {
+	var buf bytes.Buffer
+	encoder, err := export.NewEncoder(&entity.ExportRequest{Format: entity.ExportFormatM3U8}, &buf, testOptions)
+	assert.NoError(t, err)
+	assert.NoError(t, encoder.WritePlaylist(testPlaylist, testPlaylistTracks))
+	assert.NoError(t, encoder.WritePlaylist(&entity.PlaylistDB{ID: uuid.New(), Name: "Empty"}, nil))
+	assert.NoError(t, encoder.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, archive.File, 2)
+	assert.Equal(t, "001 Road _ Trip.m3u8", archive.File[0].Name)
+	assert.Equal(t, "002 Empty.m3u8", archive.File[1].Name)
+
+	file, err := archive.File[0].Open()
+	assert.NoError(t, err)
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "#EXTM3U\n"))
+}
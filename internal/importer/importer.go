@@ -0,0 +1,289 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"music-backend-test/internal/entity"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// id трека в ссылке вида …/music/download/<id>, идентификаторе urn:uuid:<id> или колонке CSV
+var uuidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// Parse разбирает файл плейлиста. Возвращает название плейлиста из файла, если оно указано, и записи.
+// Ошибки формата оборачивают entity.ErrInvalidImport.
+func Parse(format string, r io.Reader) (string, []*entity.ImportEntry, error) {
+	var name string
+	var entries []*entity.ImportEntry
+	var err error
+	switch format {
+	case entity.ImportFormatM3U:
+		name, entries, err = parseM3U(r)
+	case entity.ImportFormatXSPF:
+		name, entries, err = parseXSPF(r)
+	case entity.ImportFormatCSV:
+		entries, err = parseCSV(r)
+	default:
+		return "", nil, fmt.Errorf("%w: unknown format %q", entity.ErrInvalidImport, format)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	for i, entry := range entries {
+		entry.Line = i + 1
+		if entry.Title == "" && entry.Location != "" {
+			entry.Title = titleFromLocation(entry.Location)
+		}
+	}
+	return strings.TrimSpace(name), entries, nil
+}
+
+// parseM3U разбирает M3U и M3U8. Директива #EXTINF задает продолжительность и название «Исполнитель - Название»
+// для следующей строки с адресом, остальные директивы кроме #PLAYLIST пропускаются.
+func parseM3U(r io.Reader) (string, []*entity.ImportEntry, error) {
+	var name string
+	var entries []*entity.ImportEntry
+	var info *entity.ImportEntry
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\uFEFF"))
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXTINF:"):
+			info = parseEXTINF(strings.TrimPrefix(line, "#EXTINF:"))
+		case strings.HasPrefix(line, "#PLAYLIST:"):
+			name = strings.TrimPrefix(line, "#PLAYLIST:")
+		case strings.HasPrefix(line, "#"):
+		default:
+			entry := info
+			if entry == nil {
+				entry = &entity.ImportEntry{}
+			}
+			entry.Location = line
+			entry.MusicID = findMusicID(line)
+			entries = append(entries, entry)
+			info = nil
+		}
+		if len(entries) > entity.MaxImportEntries {
+			return "", nil, fmt.Errorf("%w: more than %d entries", entity.ErrInvalidImport, entity.MaxImportEntries)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, fmt.Errorf("%w: can't read m3u: %v", entity.ErrInvalidImport, err)
+	}
+
+	return name, entries, nil
+}
+
+// parseEXTINF разбирает «185 tvg-id="…",Исполнитель - Название». Атрибуты после продолжительности пропускаются.
+func parseEXTINF(value string) *entity.ImportEntry {
+	entry := &entity.ImportEntry{}
+
+	duration, title, found := strings.Cut(value, ",")
+	if !found {
+		title = ""
+	}
+	if fields := strings.Fields(duration); len(fields) > 0 {
+		if seconds, err := strconv.ParseFloat(fields[0], 64); err == nil && seconds > 0 {
+			entry.Duration = int64(seconds + 0.5)
+		}
+	}
+
+	entry.Artist, entry.Title = splitArtistTitle(strings.TrimSpace(title))
+	return entry
+}
+
+// splitArtistTitle делит «Исполнитель - Название». Без разделителя вся строка считается названием.
+func splitArtistTitle(value string) (string, string) {
+	if artist, title, found := strings.Cut(value, " - "); found {
+		return strings.TrimSpace(artist), strings.TrimSpace(title)
+	}
+	return "", value
+}
+
+type xspfPlaylist struct {
+	Title  string      `xml:"title"`
+	Tracks []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Locations   []string `xml:"location"`
+	Identifiers []string `xml:"identifier"`
+	Title       string   `xml:"title"`
+	Creator     string   `xml:"creator"`
+	Duration    int64    `xml:"duration"` // продолжительность в миллисекундах
+}
+
+func parseXSPF(r io.Reader) (string, []*entity.ImportEntry, error) {
+	var playlist xspfPlaylist
+	if err := xml.NewDecoder(r).Decode(&playlist); err != nil {
+		return "", nil, fmt.Errorf("%w: can't parse xspf: %v", entity.ErrInvalidImport, err)
+	}
+	if len(playlist.Tracks) > entity.MaxImportEntries {
+		return "", nil, fmt.Errorf("%w: more than %d entries", entity.ErrInvalidImport, entity.MaxImportEntries)
+	}
+
+	entries := make([]*entity.ImportEntry, 0, len(playlist.Tracks))
+	for _, track := range playlist.Tracks {
+		entry := &entity.ImportEntry{
+			Title:    strings.TrimSpace(track.Title),
+			Artist:   strings.TrimSpace(track.Creator),
+			Duration: (track.Duration + 500) / 1000,
+		}
+		if len(track.Locations) > 0 {
+			entry.Location = strings.TrimSpace(track.Locations[0])
+		}
+		for _, value := range append(track.Identifiers, track.Locations...) {
+			if entry.MusicID = findMusicID(value); entry.MusicID != nil {
+				break
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return playlist.Title, entries, nil
+}
+
+// Названия колонок CSV, из которых читаются поля записи. Подходят выгрузки этого сервиса и распространенных конвертеров.
+var (
+	csvIDColumns       = []string{"track_id", "music_id", "id"}
+	csvTitleColumns    = []string{"track_name", "title", "name", "track"}
+	csvArtistColumns   = []string{"artist", "artist_name", "artists", "creator"}
+	csvDurationColumns = []string{"duration_sec", "duration"}
+	csvURLColumns      = []string{"url", "location", "uri"}
+)
+
+// parseCSV разбирает CSV с заголовком. В выгрузке этого сервиса берутся только строки плейлистов.
+func parseCSV(r io.Reader) ([]*entity.ImportEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: can't read csv header: %v", entity.ErrInvalidImport, err)
+	}
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\uFEFF")))] = i
+	}
+	titleColumn := findColumn(columns, csvTitleColumns)
+	idColumn := findColumn(columns, csvIDColumns)
+	if titleColumn < 0 && idColumn < 0 {
+		return nil, fmt.Errorf("%w: csv has neither title nor id column", entity.ErrInvalidImport)
+	}
+	sectionColumn := findColumn(columns, []string{"section"})
+	artistColumn := findColumn(columns, csvArtistColumns)
+	durationColumn := findColumn(columns, csvDurationColumns)
+	urlColumn := findColumn(columns, csvURLColumns)
+
+	var entries []*entity.ImportEntry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: can't read csv: %v", entity.ErrInvalidImport, err)
+		}
+		if section := field(record, sectionColumn); section != "" && section != "playlist" {
+			continue
+		}
+
+		entry := &entity.ImportEntry{
+			Title:    field(record, titleColumn),
+			Artist:   field(record, artistColumn),
+			Duration: parseDuration(field(record, durationColumn)),
+			Location: field(record, urlColumn),
+			MusicID:  findMusicID(field(record, idColumn)),
+		}
+		if entry.MusicID == nil {
+			entry.MusicID = findMusicID(entry.Location)
+		}
+		if entry.Title == "" && entry.MusicID == nil && entry.Location == "" {
+			continue
+		}
+		entries = append(entries, entry)
+		if len(entries) > entity.MaxImportEntries {
+			return nil, fmt.Errorf("%w: more than %d entries", entity.ErrInvalidImport, entity.MaxImportEntries)
+		}
+	}
+
+	return entries, nil
+}
+
+func findColumn(columns map[string]int, names []string) int {
+	for _, name := range names {
+		if i, ok := columns[name]; ok {
+			return i
+		}
+	}
+	return -1
+}
+
+func field(record []string, column int) string {
+	if column < 0 || column >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[column])
+}
+
+// parseDuration читает продолжительность в секундах или в виде м:сс и ч:мм:сс. Нераспознанное значение — 0.
+func parseDuration(value string) int64 {
+	if value == "" {
+		return 0
+	}
+	var seconds int64
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil || n < 0 {
+			return 0
+		}
+		seconds = seconds*60 + int64(n+0.5)
+	}
+	return seconds
+}
+
+// findMusicID возвращает последний UUID в строке
+func findMusicID(value string) *uuid.UUID {
+	matches := uuidPattern.FindAllString(value, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	id, err := uuid.Parse(matches[len(matches)-1])
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// titleFromLocation возвращает имя файла без расширения как название трека, если других данных нет
+func titleFromLocation(location string) string {
+	if parsed, err := url.Parse(location); err == nil && parsed.Path != "" {
+		location = parsed.Path
+	}
+	location = strings.ReplaceAll(location, "\\", "/")
+	name := path.Base(location)
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	name = strings.TrimSuffix(name, path.Ext(name))
+	if name == "." || name == "/" || uuidPattern.MatchString(name) {
+		return ""
+	}
+
+	_, title := splitArtistTitle(name)
+	return title
+}
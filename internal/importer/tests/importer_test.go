@@ -0,0 +1,180 @@
+package importer
+
+import (
+	"errors"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/importer"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	tests := []struct {
+		name        string
+		format      string
+		data        string
+		wantName    string
+		wantEntries []*entity.ImportEntry
+		wantErr     error
+	}{
+		{
+			name:   "success: m3u8 with extinf",
+			format: entity.ImportFormatM3U,
+			data: "#EXTM3U\n#PLAYLIST:Road trip\n" +
+				"#EXTINF:185,Queen - Bohemian Rhapsody\nhttp://music.local/music/download/ff578289-cdca-406e-9a57-f8c773f0cd15\n" +
+				"\n#EXTINF:-1 tvg-id=\"x\",Unknown song\nC:\\Music\\unknown.mp3\n",
+			wantName: "Road trip",
+			wantEntries: []*entity.ImportEntry{
+				{Line: 1, MusicID: &musicId, Title: "Bohemian Rhapsody", Artist: "Queen", Duration: 185, Location: "http://music.local/music/download/ff578289-cdca-406e-9a57-f8c773f0cd15"},
+				{Line: 2, Title: "Unknown song", Location: "C:\\Music\\unknown.mp3"},
+			},
+		},
+		{
+			name:   "success: plain m3u takes title from file name",
+			format: entity.ImportFormatM3U,
+			data:   "/home/user/Music/Queen%20-%20Under%20Pressure.mp3\n",
+			wantEntries: []*entity.ImportEntry{
+				{Line: 1, Title: "Under Pressure", Location: "/home/user/Music/Queen%20-%20Under%20Pressure.mp3"},
+			},
+		},
+		{
+			name:   "success: xspf",
+			format: entity.ImportFormatXSPF,
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+<playlist version="1" xmlns="http://xspf.org/ns/0/">
+  <title>Favourites</title>
+  <trackList>
+    <track>
+      <location>file:///music/song.mp3</location>
+      <identifier>urn:uuid:ff578289-cdca-406e-9a57-f8c773f0cd15</identifier>
+      <title>Bohemian Rhapsody</title>
+      <creator>Queen</creator>
+      <duration>354600</duration>
+    </track>
+    <track>
+      <title>Imagine</title>
+      <creator>John Lennon</creator>
+    </track>
+  </trackList>
+</playlist>`,
+			wantName: "Favourites",
+			wantEntries: []*entity.ImportEntry{
+				{Line: 1, MusicID: &musicId, Title: "Bohemian Rhapsody", Artist: "Queen", Duration: 355, Location: "file:///music/song.mp3"},
+				{Line: 2, Title: "Imagine", Artist: "John Lennon"},
+			},
+		},
+		{
+			name:   "success: csv export skips other sections",
+			format: entity.ImportFormatCSV,
+			data: "section,track_id,track_name,artist,duration_sec,url\n" +
+				"like,ff578289-cdca-406e-9a57-f8c773f0cd15,Liked,Queen,100,\n" +
+				"playlist,ff578289-cdca-406e-9a57-f8c773f0cd15,Bohemian Rhapsody,Queen,354,\n",
+			wantEntries: []*entity.ImportEntry{
+				{Line: 1, MusicID: &musicId, Title: "Bohemian Rhapsody", Artist: "Queen", Duration: 354},
+			},
+		},
+		{
+			name:   "success: csv with other columns",
+			format: entity.ImportFormatCSV,
+			data:   "Title,Artist,Duration\nImagine,John Lennon,3:07\n",
+			wantEntries: []*entity.ImportEntry{
+				{Line: 1, Title: "Imagine", Artist: "John Lennon", Duration: 187},
+			},
+		},
+		{
+			name:    "error: csv without title and id",
+			format:  entity.ImportFormatCSV,
+			data:    "foo,bar\n1,2\n",
+			wantErr: entity.ErrInvalidImport,
+		},
+		{
+			name:    "error: malformed xspf",
+			format:  entity.ImportFormatXSPF,
+			data:    "<playlist><trackList>",
+			wantErr: entity.ErrInvalidImport,
+		},
+		{
+			name:    "error: unknown format",
+			format:  "pls",
+			data:    "[playlist]",
+			wantErr: entity.ErrInvalidImport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, entries, err := importer.Parse(tt.format, strings.NewReader(tt.data))
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantEntries, entries)
+		})
+	}
+}
+
+func Test_ImportMatcher_Match(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	queen := "Queen"
+	other := "Panic! at the Disco"
+
+	original := &entity.ImportCandidateDB{MusicID: musicId, Name: "Bohemian Rhapsody", Artist: &queen, Duration: 354}
+	remaster := &entity.ImportCandidateDB{MusicID: uuid.New(), Name: "Bohemian Rhapsody (Remastered 2011)", Artist: &queen, Duration: 355}
+	cover := &entity.ImportCandidateDB{MusicID: uuid.New(), Name: "Bohemian Rhapsody", Artist: &other, Duration: 363}
+
+	matcher := entity.NewImportMatcher()
+	matcher.AddByID([]*entity.ImportCandidateDB{original})
+	matcher.AddByTitle([]*entity.ImportCandidateDB{original, remaster, cover}, map[string]bool{"bohemian rhapsody": true})
+
+	tests := []struct {
+		name           string
+		entry          *entity.ImportEntry
+		wantStatus     string
+		wantMusic      *entity.ImportCandidateDB
+		wantCandidates int
+	}{
+		{
+			name:       "matched by id",
+			entry:      &entity.ImportEntry{MusicID: &musicId},
+			wantStatus: entity.ImportStatusMatched,
+			wantMusic:  original,
+		},
+		{
+			name:       "matched by title, artist and duration",
+			entry:      &entity.ImportEntry{Title: "bohemian rhapsody!", Artist: "Panic! At The Disco", Duration: 361},
+			wantStatus: entity.ImportStatusMatched,
+			wantMusic:  cover,
+		},
+		{
+			name:           "ambiguous without duration",
+			entry:          &entity.ImportEntry{Title: "Bohemian Rhapsody", Artist: "QUEEN"},
+			wantStatus:     entity.ImportStatusAmbiguous,
+			wantCandidates: 2,
+		},
+		{
+			name:           "unmatched duration suggests same title",
+			entry:          &entity.ImportEntry{Title: "Bohemian Rhapsody", Artist: "Queen", Duration: 600},
+			wantStatus:     entity.ImportStatusUnmatched,
+			wantCandidates: 3,
+		},
+		{
+			name:       "unmatched title",
+			entry:      &entity.ImportEntry{Title: "Imagine"},
+			wantStatus: entity.ImportStatusUnmatched,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matcher.Match(tt.entry)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, tt.wantMusic, got.Music)
+			assert.Len(t, got.Candidates, tt.wantCandidates)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package notify
+
+import "music-backend-test/cmd/music-backend-test/config"
+
+// NewChannels создает каналы, настроенные в конфигурации. Канал без адреса сервера отключен.
+func NewChannels(cfg *config.Config) []Channel {
+	var channels []Channel
+	if cfg.Notifications.SMTPAddr != "" {
+		channels = append(channels, NewEmailChannel(cfg.Notifications.SMTPAddr, cfg.Notifications.SMTPFrom))
+	}
+	if cfg.Notifications.WebhookURL != "" {
+		channels = append(channels, NewWebhookChannel(cfg.Notifications.WebhookURL))
+	}
+	return channels
+}
@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"music-backend-test/internal/entity"
+	"net/smtp"
+	"strings"
+)
+
+// emailChannel отправляет уведомления письмом через SMTP-сервер без авторизации,
+// например через локальный MailHog или почтовый relay в той же сети
+type emailChannel struct {
+	addr string
+	from string
+}
+
+func NewEmailChannel(addr string, from string) *emailChannel {
+	return &emailChannel{
+		addr: addr,
+		from: from,
+	}
+}
+
+func (e *emailChannel) Name() string {
+	return entity.NotificationChannelEmail
+}
+
+func (e *emailChannel) Send(ctx context.Context, delivery *entity.NotificationDelivery) error {
+	if delivery.Email == nil {
+		return fmt.Errorf("recipient has no email")
+	}
+
+	err := smtp.SendMail(e.addr, nil, e.from, []string{*delivery.Email}, buildEmail(e.from, *delivery.Email, delivery.Message()))
+	if err != nil {
+		return fmt.Errorf("can't send email: %w", err)
+	}
+
+	return nil
+}
+
+func buildEmail(from string, to string, message string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", message) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(message + "\r\n")
+	return []byte(b.String())
+}
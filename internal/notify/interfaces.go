@@ -0,0 +1,16 @@
+package notify
+
+import (
+	"context"
+	"music-backend-test/internal/entity"
+)
+
+//go:generate mockgen -source=./interfaces.go -destination=./notify_mock.go -package=notify
+
+// Channel внешний канал доставки уведомлений. Входящие (in-app) — само хранилище уведомлений,
+// поэтому отдельного канала для них нет.
+type Channel interface {
+	// Name возвращает имя канала, под которым пользователь включает его в настройках
+	Name() string
+	Send(ctx context.Context, delivery *entity.NotificationDelivery) error
+}
@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/notify"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_webhookChannel_Send(t *testing.T) {
+	artist, music := "Artist1", "Song1"
+	delivery := &entity.NotificationDelivery{NotificationDB: entity.NotificationDB{
+		ID:        uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
+		UserID:    uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+		Type:      entity.NotificationRelease,
+		ActorName: &artist,
+		MusicName: &music,
+		CreatedAt: time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
+	}}
+
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			status: http.StatusNoContent,
+		},
+		{
+			name:    "error: receiver failed",
+			status:  http.StatusBadGateway,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			channel := notify.NewWebhookChannel(server.URL)
+			assert.Equal(t, entity.NotificationChannelWebhook, channel.Name())
+
+			err := channel.Send(context.Background(), delivery)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "ff578289-cdca-406e-9a57-f8c773f0cd15", received["id"])
+			assert.Equal(t, entity.NotificationRelease, received["type"])
+			assert.Equal(t, "Новый трек исполнителя Artist1: «Song1»", received["message"])
+			assert.Equal(t, "2023-03-24T12:00:00Z", received["created_at"])
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Тело запроса webhook-канала
+type webhookPayload struct {
+	ID        uuid.UUID  `json:"id"`         // id уведомления
+	UserID    uuid.UUID  `json:"user_id"`    // id получателя
+	Type      string     `json:"type"`       // тип уведомления
+	Message   string     `json:"message"`    // текст уведомления
+	ActorID   *uuid.UUID `json:"actor_id"`   // id исполнителя или пользователя
+	MusicID   *uuid.UUID `json:"music_id"`   // id трека
+	CommentID *uuid.UUID `json:"comment_id"` // id ответа на комментарий
+	CreatedAt time.Time  `json:"created_at"` // время создания
+}
+
+// webhookChannel отправляет уведомления POST-запросом с JSON на заданный URL.
+// Ответ со статусом вне 2xx считается ошибкой доставки.
+type webhookChannel struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookChannel(url string) *webhookChannel {
+	return &webhookChannel{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (w *webhookChannel) Name() string {
+	return entity.NotificationChannelWebhook
+}
+
+func (w *webhookChannel) Send(ctx context.Context, delivery *entity.NotificationDelivery) error {
+	body, err := json.Marshal(&webhookPayload{
+		ID:        delivery.ID,
+		UserID:    delivery.UserID,
+		Type:      delivery.Type,
+		Message:   delivery.Message(),
+		ActorID:   delivery.ActorID,
+		MusicID:   delivery.MusicID,
+		CommentID: delivery.CommentID,
+		CreatedAt: delivery.CreatedAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("can't marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
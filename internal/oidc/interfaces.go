@@ -0,0 +1,17 @@
+package oidc
+
+import (
+	"context"
+	"music-backend-test/internal/entity"
+)
+
+//go:generate mockgen -source=./interfaces.go -destination=./oidc_mock.go -package=oidc
+
+// Provider OpenID Connect провайдер, через которого входят по коду авторизации с PKCE
+type Provider interface {
+	// AuthCodeURL возвращает адрес страницы входа провайдера, куда перенаправляется пользователь
+	AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	// Exchange обменивает код авторизации на ID-токен, проверяет его и возвращает подтвержденную учетную запись.
+	// Отклоненный провайдером код возвращает ошибку entity.ErrOIDCCodeRejected, недействительный ID-токен — entity.ErrInvalidIDToken.
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*entity.OIDCIdentity, error)
+}
@@ -0,0 +1,378 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"music-backend-test/internal/entity"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	providerTimeout   = 10 * time.Second
+	discoveryTTL      = time.Hour        // время кэширования метаданных и ключей провайдера
+	keyReloadInterval = 10 * time.Second // минимальный интервал перечитывания ключей при неизвестном kid
+	maxResponseSize   = 1 << 20          // максимальный размер ответа провайдера
+)
+
+// Алгоритмы подписи ID-токенов, которые принимаются от провайдера
+var idTokenMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// Метаданные провайдера из /.well-known/openid-configuration
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Открытый ключ провайдера в формате JWK
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// Ответ token endpoint
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// provider входит через OpenID Connect провайдера по коду авторизации с PKCE (RFC 7636).
+// Метаданные и ключи провайдера загружаются при первом входе и кэшируются;
+// ключи перечитываются раньше, если ID-токен подписан неизвестным ключом.
+type provider struct {
+	cfg    *entity.OIDCConfig
+	client *http.Client
+
+	mu           sync.Mutex
+	discovery    *discoveryDocument
+	discoveredAt time.Time
+	keys         map[string]crypto.PublicKey
+	keysLoadedAt time.Time
+}
+
+func NewProvider(cfg *entity.OIDCConfig) *provider {
+	return &provider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: providerTimeout},
+	}
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.RedirectURL)
+	query.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*entity.OIDCIdentity, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("can't send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&token)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return nil, fmt.Errorf("%w: %s %s", entity.ErrOIDCCodeRejected, token.Error, token.ErrorDescription)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("token endpoint responded with status %d", resp.StatusCode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't decode token response: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id_token", entity.ErrInvalidIDToken)
+	}
+
+	return p.verify(ctx, token.IDToken, nonce)
+}
+
+// verify проверяет подпись, издателя, получателя, срок действия и nonce ID-токена
+func (p *provider) verify(ctx context.Context, idToken string, nonce string) (*entity.OIDCIdentity, error) {
+	// Ошибку загрузки ключей keyfunc не может вернуть как есть: парсер токена оборачивает ее в ошибку проверки
+	var loadErr error
+	claims := jwt.MapClaims{}
+	parser := &jwt.Parser{ValidMethods: idTokenMethods}
+	_, err := parser.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := p.getKey(ctx, kid)
+		if err != nil {
+			loadErr = err
+			return nil, err
+		}
+		if key == nil {
+			return nil, fmt.Errorf("unknown key %q", kid)
+		}
+		return key, nil
+	})
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrInvalidIDToken, err)
+	}
+
+	now := time.Now().Unix()
+	issuer, _ := claims["iss"].(string)
+	subject, _ := claims["sub"].(string)
+	tokenNonce, _ := claims["nonce"].(string)
+	switch {
+	case issuer != p.cfg.Issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer %q", entity.ErrInvalidIDToken, issuer)
+	case !claims.VerifyAudience(p.cfg.ClientID, true):
+		return nil, fmt.Errorf("%w: token is not issued for this client", entity.ErrInvalidIDToken)
+	case claims["azp"] != nil && claims["azp"] != p.cfg.ClientID:
+		return nil, fmt.Errorf("%w: token is authorized for another party", entity.ErrInvalidIDToken)
+	case !claims.VerifyExpiresAt(now, true):
+		return nil, fmt.Errorf("%w: token is expired", entity.ErrInvalidIDToken)
+	case subject == "":
+		return nil, fmt.Errorf("%w: token has no subject", entity.ErrInvalidIDToken)
+	case tokenNonce != nonce:
+		return nil, fmt.Errorf("%w: nonce mismatch", entity.ErrInvalidIDToken)
+	}
+
+	identity := &entity.OIDCIdentity{
+		Issuer:  issuer,
+		Subject: subject,
+		Groups:  stringList(claims[p.cfg.GroupsClaim]),
+	}
+	identity.PreferredUsername, _ = claims["preferred_username"].(string)
+	identity.Email, _ = claims["email"].(string)
+
+	return identity, nil
+}
+
+// getDiscovery возвращает метаданные провайдера. Издатель в метаданных должен совпадать с настроенным.
+func (p *provider) getDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil && time.Since(p.discoveredAt) < discoveryTTL {
+		return p.discovery, nil
+	}
+
+	var discovery discoveryDocument
+	err := p.getJSON(ctx, p.cfg.Issuer+"/.well-known/openid-configuration", &discovery)
+	if err != nil {
+		return nil, fmt.Errorf("can't get provider metadata: %w", err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != p.cfg.Issuer {
+		return nil, fmt.Errorf("provider metadata issuer %q doesn't match %q", discovery.Issuer, p.cfg.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("provider metadata has no authorization, token or jwks endpoint")
+	}
+
+	p.discovery = &discovery
+	p.discoveredAt = time.Now()
+	// Ключи могли смениться вместе с метаданными
+	p.keysLoadedAt = time.Time{}
+	return p.discovery, nil
+}
+
+// getKey возвращает открытый ключ kid или nil, если у провайдера такого ключа нет.
+// Неизвестный ключ мог появиться при смене ключей провайдером, поэтому набор перечитывается,
+// но не чаще keyReloadInterval.
+func (p *provider) getKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok && time.Since(p.keysLoadedAt) < discoveryTTL {
+		return key, nil
+	}
+	if !p.keysLoadedAt.IsZero() && time.Since(p.keysLoadedAt) < keyReloadInterval {
+		return nil, nil
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	err = p.getJSON(ctx, discovery.JWKSURI, &set)
+	if err != nil {
+		return nil, fmt.Errorf("can't get provider keys: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// Неподдерживаемые ключи пропускаются: ими просто нельзя проверить токен
+		if key, err := jwk.publicKey(); err == nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	p.keys = keys
+	p.keysLoadedAt = time.Now()
+
+	return p.keys[kid], nil
+}
+
+func (p *provider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s responded with status %d", url, resp.StatusCode)
+	}
+
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v)
+	if err != nil {
+		return fmt.Errorf("can't decode response: %w", err)
+	}
+
+	return nil
+}
+
+// publicKey разбирает ключ RSA, EC (P-256, P-384, P-521) или OKP (Ed25519)
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid rsa key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("invalid ec key")
+		}
+		return key, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+// stringList разбирает утверждение со списком строк. Некоторые провайдеры передают одну группу строкой.
+func stringList(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
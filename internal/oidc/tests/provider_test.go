@@ -0,0 +1,254 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/oidc"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockIssuer — локальный OpenID Connect провайдер: отдает метаданные и ключи, а на token endpoint
+// проверяет PKCE и выдает ID-токен с утверждениями claims
+type mockIssuer struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	claims    func(issuer string) jwt.MapClaims
+	status    int
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	m := &mockIssuer{key: key, status: http.StatusOK}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize",
+			"token_endpoint":         m.server.URL + "/token",
+			"jwks_uri":               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"kid": "mock-kid",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+		user, password, _ := r.BasicAuth()
+		assert.Equal(t, "music-backend", user)
+		assert.Equal(t, "secret", password)
+
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != "valid-code" || base64.RawURLEncoding.EncodeToString(sum[:]) != m.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		if m.status != http.StatusOK {
+			w.WriteHeader(m.status)
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, m.claims(m.server.URL))
+		token.Header["kid"] = "mock-kid"
+		idToken, err := token.SignedString(key)
+		assert.NoError(t, err)
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken, "token_type": "Bearer"})
+	})
+	m.server = httptest.NewServer(mux)
+
+	return m
+}
+
+func testClaims(issuer string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":                issuer,
+		"sub":                "user-42",
+		"aud":                []string{"music-backend"},
+		"exp":                time.Now().Add(time.Minute).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              "nonce",
+		"preferred_username": "jdoe",
+		"email":              "jdoe@example.com",
+		"groups":             []string{"staff", "music-admins"},
+	}
+}
+
+func Test_provider_AuthCodeURL(t *testing.T) {
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+
+	p := oidc.NewProvider(&entity.OIDCConfig{
+		Issuer:      issuer.server.URL,
+		ClientID:    "music-backend",
+		RedirectURL: "http://localhost:8000/auth/oidc/callback",
+		Scopes:      []string{"openid", "profile"},
+	})
+
+	got, err := p.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	assert.NoError(t, err)
+
+	authURL, err := url.Parse(got)
+	assert.NoError(t, err)
+	assert.Equal(t, issuer.server.URL+"/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+	assert.Equal(t, url.Values{
+		"response_type":         {"code"},
+		"client_id":             {"music-backend"},
+		"redirect_uri":          {"http://localhost:8000/auth/oidc/callback"},
+		"scope":                 {"openid profile"},
+		"state":                 {"state"},
+		"nonce":                 {"nonce"},
+		"code_challenge":        {"challenge"},
+		"code_challenge_method": {"S256"},
+	}, authURL.Query())
+}
+
+func Test_provider_AuthCodeURL_issuerMismatch(t *testing.T) {
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+
+	// Метаданные, полученные по адресу издателя, должны относиться к нему же
+	p := oidc.NewProvider(&entity.OIDCConfig{Issuer: issuer.server.URL + "/other", ClientID: "music-backend"})
+
+	_, err := p.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	assert.Error(t, err)
+}
+
+func Test_provider_Exchange(t *testing.T) {
+	login := &entity.OIDCLoginDB{CodeVerifier: "verifier-verifier-verifier-verifier-verifier"}
+
+	tests := []struct {
+		name     string
+		code     string
+		verifier string
+		claims   func(issuer string) jwt.MapClaims
+		status   int
+		wantErr  error
+	}{
+		{
+			name:     "success",
+			code:     "valid-code",
+			verifier: login.CodeVerifier,
+			claims:   testClaims,
+		},
+		{
+			name:     "error: wrong code verifier",
+			code:     "valid-code",
+			verifier: "stolen-code-without-verifier-stolen-code-without",
+			claims:   testClaims,
+			wantErr:  entity.ErrOIDCCodeRejected,
+		},
+		{
+			name:     "error: nonce mismatch",
+			code:     "valid-code",
+			verifier: login.CodeVerifier,
+			claims: func(issuer string) jwt.MapClaims {
+				claims := testClaims(issuer)
+				claims["nonce"] = "replayed"
+				return claims
+			},
+			wantErr: entity.ErrInvalidIDToken,
+		},
+		{
+			name:     "error: token for another client",
+			code:     "valid-code",
+			verifier: login.CodeVerifier,
+			claims: func(issuer string) jwt.MapClaims {
+				claims := testClaims(issuer)
+				claims["aud"] = "another-client"
+				return claims
+			},
+			wantErr: entity.ErrInvalidIDToken,
+		},
+		{
+			name:     "error: token from another issuer",
+			code:     "valid-code",
+			verifier: login.CodeVerifier,
+			claims: func(issuer string) jwt.MapClaims {
+				claims := testClaims(issuer)
+				claims["iss"] = "https://evil.example.com"
+				return claims
+			},
+			wantErr: entity.ErrInvalidIDToken,
+		},
+		{
+			name:     "error: expired token",
+			code:     "valid-code",
+			verifier: login.CodeVerifier,
+			claims: func(issuer string) jwt.MapClaims {
+				claims := testClaims(issuer)
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+				return claims
+			},
+			wantErr: entity.ErrInvalidIDToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newMockIssuer(t)
+			defer issuer.server.Close()
+			issuer.challenge = login.CodeChallenge()
+			issuer.claims = tt.claims
+
+			p := oidc.NewProvider(&entity.OIDCConfig{
+				Issuer:       issuer.server.URL,
+				ClientID:     "music-backend",
+				ClientSecret: "secret",
+				RedirectURL:  "http://localhost:8000/auth/oidc/callback",
+				GroupsClaim:  "groups",
+			})
+
+			got, err := p.Exchange(context.Background(), tt.code, tt.verifier, "nonce")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, &entity.OIDCIdentity{
+				Issuer:            issuer.server.URL,
+				Subject:           "user-42",
+				PreferredUsername: "jdoe",
+				Email:             "jdoe@example.com",
+				Groups:            []string{"staff", "music-admins"},
+			}, got)
+		})
+	}
+}
+
+func Test_provider_Exchange_providerFailure(t *testing.T) {
+	login := &entity.OIDCLoginDB{CodeVerifier: "verifier-verifier-verifier-verifier-verifier"}
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+	issuer.challenge = login.CodeChallenge()
+	issuer.status = http.StatusBadGateway
+
+	p := oidc.NewProvider(&entity.OIDCConfig{Issuer: issuer.server.URL, ClientID: "music-backend", ClientSecret: "secret"})
+
+	// Сбой провайдера не должен выглядеть как отклоненный код
+	_, err := p.Exchange(context.Background(), "valid-code", login.CodeVerifier, "nonce")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, entity.ErrOIDCCodeRejected)
+	assert.NotErrorIs(t, err, entity.ErrInvalidIDToken)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type artistRepository struct {
+	source db.ArtistSource
+}
+
+func NewArtistRepository(source db.ArtistSource) *artistRepository {
+	return &artistRepository{
+		source: source,
+	}
+}
+
+func (r *artistRepository) Create(ctx context.Context, artist *entity.ArtistDB) error {
+	err := r.source.Create(ctx, artist)
+	if err != nil {
+		return fmt.Errorf("/db/artist.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (r *artistRepository) Get(ctx context.Context, id uuid.UUID) (*entity.ArtistDB, error) {
+	artist, err := r.source.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("/db/artist.Get: %w", err)
+	}
+
+	return artist, nil
+}
+
+func (r *artistRepository) SetMusicArtist(ctx context.Context, musicId uuid.UUID, artistId *uuid.UUID) error {
+	err := r.source.SetMusicArtist(ctx, musicId, artistId)
+	if err != nil {
+		return fmt.Errorf("/db/artist.SetMusicArtist: %w", err)
+	}
+
+	return nil
+}
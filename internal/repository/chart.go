@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type chartRepository struct {
+	source db.ChartSource
+}
+
+func NewChartRepository(source db.ChartSource) *chartRepository {
+	return &chartRepository{
+		source: source,
+	}
+}
+
+func (c *chartRepository) Snapshot(ctx context.Context, cfg *entity.ChartConfig, week time.Time) (int64, error) {
+	inserted, err := c.source.Snapshot(ctx, cfg, week)
+	if err != nil {
+		return 0, fmt.Errorf("/db/chart.Snapshot: %w", err)
+	}
+
+	return inserted, nil
+}
+
+func (c *chartRepository) LatestWeek(ctx context.Context) (time.Time, error) {
+	week, err := c.source.LatestWeek(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("/db/chart.LatestWeek: %w", err)
+	}
+
+	return week, nil
+}
+
+func (c *chartRepository) GetByWeek(ctx context.Context, week time.Time) ([]*entity.ChartEntryDB, error) {
+	entries, err := c.source.GetByWeek(ctx, week)
+	if err != nil {
+		return nil, fmt.Errorf("/db/chart.GetByWeek: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (c *chartRepository) GetByMusic(ctx context.Context, musicId uuid.UUID) ([]*entity.ChartEntryDB, error) {
+	entries, err := c.source.GetByMusic(ctx, musicId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/chart.GetByMusic: %w", err)
+	}
+
+	return entries, nil
+}
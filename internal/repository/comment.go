@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type commentRepository struct {
+	source        db.CommentSource
+	notifications db.NotificationSource
+}
+
+func NewCommentRepository(source db.CommentSource, notifications db.NotificationSource) *commentRepository {
+	return &commentRepository{
+		source:        source,
+		notifications: notifications,
+	}
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *entity.CommentDB, rateLimit int, since time.Time) error {
+	err := r.source.Create(ctx, comment, rateLimit, since)
+	if err != nil {
+		return fmt.Errorf("/db/comment.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (r *commentRepository) Get(ctx context.Context, id uuid.UUID) (*entity.CommentDB, error) {
+	comment, err := r.source.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("/db/comment.Get: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (r *commentRepository) GetByMusic(ctx context.Context, musicId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	comments, err := r.source.GetByMusic(ctx, musicId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/db/comment.GetByMusic: %w", err)
+	}
+
+	return comments, nil
+}
+
+func (r *commentRepository) GetByPlaylist(ctx context.Context, playlistId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	comments, err := r.source.GetByPlaylist(ctx, playlistId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/db/comment.GetByPlaylist: %w", err)
+	}
+
+	return comments, nil
+}
+
+func (r *commentRepository) GetReplies(ctx context.Context, parentId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	comments, err := r.source.GetReplies(ctx, parentId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/db/comment.GetReplies: %w", err)
+	}
+
+	return comments, nil
+}
+
+func (r *commentRepository) MusicVisible(ctx context.Context, musicId uuid.UUID, showUnpublished bool) (bool, error) {
+	visible, err := r.source.MusicVisible(ctx, musicId, showUnpublished)
+	if err != nil {
+		return false, fmt.Errorf("/db/comment.MusicVisible: %w", err)
+	}
+
+	return visible, nil
+}
+
+func (r *commentRepository) GetPlaylist(ctx context.Context, playlistId uuid.UUID) (*entity.PlaylistDB, error) {
+	playlist, err := r.source.GetPlaylist(ctx, playlistId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/comment.GetPlaylist: %w", err)
+	}
+
+	return playlist, nil
+}
+
+func (r *commentRepository) Update(ctx context.Context, id uuid.UUID, body string, now time.Time) error {
+	err := r.source.Update(ctx, id, body, now)
+	if err != nil {
+		return fmt.Errorf("/db/comment.Update: %w", err)
+	}
+
+	return nil
+}
+
+func (r *commentRepository) SetStatus(ctx context.Context, id uuid.UUID, status string, moderatorId *uuid.UUID, now time.Time) error {
+	err := r.source.SetStatus(ctx, id, status, moderatorId, now)
+	if err != nil {
+		return fmt.Errorf("/db/comment.SetStatus: %w", err)
+	}
+
+	return nil
+}
+
+func (r *commentRepository) CreateReport(ctx context.Context, report *entity.CommentReportDB) (bool, error) {
+	created, err := r.source.CreateReport(ctx, report)
+	if err != nil {
+		return false, fmt.Errorf("/db/comment.CreateReport: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *commentRepository) GetModerationQueue(ctx context.Context, limit int, offset int) ([]*entity.ModerationItemDB, error) {
+	items, err := r.source.GetModerationQueue(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/db/comment.GetModerationQueue: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *commentRepository) DismissReports(ctx context.Context, commentId uuid.UUID, moderatorId uuid.UUID, now time.Time) (int64, error) {
+	dismissed, err := r.source.DismissReports(ctx, commentId, moderatorId, now)
+	if err != nil {
+		return 0, fmt.Errorf("/db/comment.DismissReports: %w", err)
+	}
+
+	return dismissed, nil
+}
+
+func (r *commentRepository) Notify(ctx context.Context, notification *entity.NotificationDB) error {
+	err := r.notifications.Create(ctx, notification)
+	if err != nil {
+		return fmt.Errorf("/db/notification.Create: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/utils"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type exportRepository struct {
+	source     db.ExportSource
+	FileSystem utils.FileSystem
+}
+
+func NewExportRepository(source db.ExportSource, filesystem utils.FileSystem) *exportRepository {
+	return &exportRepository{
+		source:     source,
+		FileSystem: filesystem,
+	}
+}
+
+func (r *exportRepository) CountItems(ctx context.Context, userId uuid.UUID, request *entity.ExportRequest) (int64, error) {
+	count, err := r.source.CountItems(ctx, userId, request)
+	if err != nil {
+		return 0, fmt.Errorf("/db/export.CountItems: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *exportRepository) GetLikes(ctx context.Context, userId uuid.UUID) ([]*entity.ExportLikeDB, error) {
+	likes, err := r.source.GetLikes(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/export.GetLikes: %w", err)
+	}
+
+	return likes, nil
+}
+
+func (r *exportRepository) GetPlaylists(ctx context.Context, userId uuid.UUID, playlistId *uuid.UUID) ([]*entity.PlaylistDB, error) {
+	playlists, err := r.source.GetPlaylists(ctx, userId, playlistId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/export.GetPlaylists: %w", err)
+	}
+
+	return playlists, nil
+}
+
+func (r *exportRepository) GetPlaylistTracks(ctx context.Context, playlistId uuid.UUID) ([]*entity.ExportPlaylistTrackDB, error) {
+	tracks, err := r.source.GetPlaylistTracks(ctx, playlistId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/export.GetPlaylistTracks: %w", err)
+	}
+
+	return tracks, nil
+}
+
+func (r *exportRepository) GetRatings(ctx context.Context, userId uuid.UUID) ([]*entity.ExportRatingDB, error) {
+	ratings, err := r.source.GetRatings(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/export.GetRatings: %w", err)
+	}
+
+	return ratings, nil
+}
+
+func (r *exportRepository) GetHistory(ctx context.Context, userId uuid.UUID, afterAt time.Time, afterId uuid.UUID, limit int) ([]*entity.ExportPlayDB, error) {
+	events, err := r.source.GetHistory(ctx, userId, afterAt, afterId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("/db/export.GetHistory: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *exportRepository) CreateJob(ctx context.Context, job *entity.ExportJobDB) error {
+	err := r.source.CreateJob(ctx, job)
+	if err != nil {
+		return fmt.Errorf("/db/export.CreateJob: %w", err)
+	}
+
+	return nil
+}
+
+func (r *exportRepository) GetJob(ctx context.Context, id uuid.UUID) (*entity.ExportJobDB, error) {
+	job, err := r.source.GetJob(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("/db/export.GetJob: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *exportRepository) ClaimJobs(ctx context.Context, now time.Time, staleBefore time.Time, limit int) ([]*entity.ExportJobDB, error) {
+	jobs, err := r.source.ClaimJobs(ctx, now, staleBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("/db/export.ClaimJobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func (r *exportRepository) FinishJob(ctx context.Context, job *entity.ExportJobDB) error {
+	err := r.source.FinishJob(ctx, job)
+	if err != nil {
+		return fmt.Errorf("/db/export.FinishJob: %w", err)
+	}
+
+	return nil
+}
+
+func (r *exportRepository) DeleteExpiredJobs(ctx context.Context, now time.Time, limit int) ([]*entity.ExportJobDB, error) {
+	jobs, err := r.source.DeleteExpiredJobs(ctx, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("/db/export.DeleteExpiredJobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// WriteFile создает файл выгрузки задачи и заполняет его через write. Возвращает размер файла.
+// При ошибке недописанный файл удаляется.
+func (r *exportRepository) WriteFile(job *entity.ExportJobDB, write func(w io.Writer) error) (int64, error) {
+	err := r.FileSystem.MkdirAll(entity.ExportStorage)
+	if err != nil {
+		return 0, fmt.Errorf("can't create export storage: %w", err)
+	}
+
+	file, err := r.FileSystem.Create(job.FilePath())
+	if err != nil {
+		return 0, fmt.Errorf("can't create export file: %w", err)
+	}
+
+	size, err := writeExportFile(file, write)
+	if err != nil {
+		r.FileSystem.Remove(job.FilePath())
+		return 0, fmt.Errorf("can't write export file: %w", err)
+	}
+
+	return size, nil
+}
+
+func writeExportFile(file *os.File, write func(w io.Writer) error) (int64, error) {
+	defer file.Close()
+
+	if err := write(file); err != nil {
+		return 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), file.Close()
+}
+
+// RemoveFile удаляет файл выгрузки задачи. Отсутствие файла ошибкой не считается.
+func (r *exportRepository) RemoveFile(job *entity.ExportJobDB) error {
+	err := r.FileSystem.Remove(job.FilePath())
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("can't remove export file: %w", err)
+	}
+
+	return nil
+}
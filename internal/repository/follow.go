@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type followRepository struct {
+	source db.FollowSource
+}
+
+func NewFollowRepository(source db.FollowSource) *followRepository {
+	return &followRepository{
+		source: source,
+	}
+}
+
+func (r *followRepository) FollowArtist(ctx context.Context, userId uuid.UUID, artistId uuid.UUID, now time.Time) error {
+	err := r.source.FollowArtist(ctx, userId, artistId, now)
+	if err != nil {
+		return fmt.Errorf("/db/follow.FollowArtist: %w", err)
+	}
+
+	return nil
+}
+
+func (r *followRepository) UnfollowArtist(ctx context.Context, userId uuid.UUID, artistId uuid.UUID) error {
+	err := r.source.UnfollowArtist(ctx, userId, artistId)
+	if err != nil {
+		return fmt.Errorf("/db/follow.UnfollowArtist: %w", err)
+	}
+
+	return nil
+}
+
+func (r *followRepository) FollowUser(ctx context.Context, followerId uuid.UUID, followeeId uuid.UUID, now time.Time) error {
+	err := r.source.FollowUser(ctx, followerId, followeeId, now)
+	if err != nil {
+		return fmt.Errorf("/db/follow.FollowUser: %w", err)
+	}
+
+	return nil
+}
+
+func (r *followRepository) UnfollowUser(ctx context.Context, followerId uuid.UUID, followeeId uuid.UUID) error {
+	err := r.source.UnfollowUser(ctx, followerId, followeeId)
+	if err != nil {
+		return fmt.Errorf("/db/follow.UnfollowUser: %w", err)
+	}
+
+	return nil
+}
+
+func (r *followRepository) GetFollowing(ctx context.Context, userId uuid.UUID) (*entity.Following, error) {
+	artists, err := r.source.GetFollowedArtists(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/follow.GetFollowedArtists: %w", err)
+	}
+
+	users, err := r.source.GetFollowedUsers(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/follow.GetFollowedUsers: %w", err)
+	}
+
+	return &entity.Following{
+		Artists: artists,
+		Users:   users,
+	}, nil
+}
+
+func (r *followRepository) GetFeed(ctx context.Context, userId uuid.UUID, filter *entity.FeedFilter) ([]*entity.FeedItemDB, error) {
+	items, err := r.source.GetFeed(ctx, userId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/db/follow.GetFeed: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *followRepository) SetPrivacy(ctx context.Context, userId uuid.UUID, privacy *entity.FeedPrivacy) error {
+	err := r.source.SetPrivacy(ctx, userId, privacy)
+	if err != nil {
+		return fmt.Errorf("/db/follow.SetPrivacy: %w", err)
+	}
+
+	return nil
+}
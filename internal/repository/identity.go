@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/oidc"
+	"music-backend-test/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type identityRepository struct {
+	source   db.IdentitySource
+	provider oidc.Provider
+	hasher   utils.PasswordHasher
+}
+
+func NewIdentityRepository(source db.IdentitySource, provider oidc.Provider, hasher utils.PasswordHasher) *identityRepository {
+	return &identityRepository{
+		source:   source,
+		provider: provider,
+		hasher:   hasher,
+	}
+}
+
+// Begin сохраняет начатый вход и возвращает адрес страницы входа провайдера
+func (r *identityRepository) Begin(ctx context.Context, login *entity.OIDCLoginDB) (string, error) {
+	authURL, err := r.provider.AuthCodeURL(ctx, login.State, login.Nonce, login.CodeChallenge())
+	if err != nil {
+		return "", fmt.Errorf("/oidc/provider.AuthCodeURL: %w", err)
+	}
+
+	err = r.source.CreateLogin(ctx, login)
+	if err != nil {
+		return "", fmt.Errorf("/db/identity.CreateLogin: %w", err)
+	}
+
+	return authURL, nil
+}
+
+func (r *identityRepository) ConsumeLogin(ctx context.Context, state string, now time.Time) (*entity.OIDCLoginDB, error) {
+	login, err := r.source.ConsumeLogin(ctx, state, now)
+	if err != nil {
+		return nil, fmt.Errorf("/db/identity.ConsumeLogin: %w", err)
+	}
+
+	return login, nil
+}
+
+// Exchange обменивает код авторизации начатого входа на учетную запись провайдера
+func (r *identityRepository) Exchange(ctx context.Context, login *entity.OIDCLoginDB, code string) (*entity.OIDCIdentity, error) {
+	identity, err := r.provider.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("/oidc/provider.Exchange: %w", err)
+	}
+
+	return identity, nil
+}
+
+// Login находит или создает пользователя учетной записи провайдера. Пароль нового пользователя хэшируется.
+func (r *identityRepository) Login(ctx context.Context, login *entity.IdentityLogin, now time.Time) (*entity.UserDB, error) {
+	if login.NewUser != nil {
+		hash, err := r.hasher.Hash(login.NewUser.Password)
+		if err != nil {
+			return nil, fmt.Errorf("can't hash password: %w", err)
+		}
+		hashed := *login
+		hashed.NewUser = &entity.UserCreate{Username: login.NewUser.Username, Password: hash}
+		login = &hashed
+	}
+
+	user, err := r.source.Login(ctx, login, now)
+	if err != nil {
+		return nil, fmt.Errorf("/db/identity.Login: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *identityRepository) GetByUser(ctx context.Context, userId uuid.UUID) ([]*entity.UserIdentityDB, error) {
+	identities, err := r.source.GetByUser(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/identity.GetByUser: %w", err)
+	}
+
+	return identities, nil
+}
+
+func (r *identityRepository) DeleteExpiredLogins(ctx context.Context, now time.Time) error {
+	err := r.source.DeleteExpiredLogins(ctx, now)
+	if err != nil {
+		return fmt.Errorf("/db/identity.DeleteExpiredLogins: %w", err)
+	}
+
+	return nil
+}
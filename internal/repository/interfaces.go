@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"io"
 	"music-backend-test/internal/entity"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,19 +14,237 @@ type UserRepository interface {
 	Create(ctx context.Context, user *entity.UserCreate) (uuid.UUID, error)
 	GetById(ctx context.Context, id uuid.UUID) (*entity.UserDB, error)
 	GetByUsername(ctx context.Context, username string) (*entity.UserDB, error)
+	Authenticate(ctx context.Context, username string, password string) (*entity.UserDB, error)
 	Update(ctx context.Context, id uuid.UUID, user *entity.UserCreate) (*entity.UserDB, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, now time.Time) error
 	LikeTrack(ctx context.Context, userId uuid.UUID, trackId uuid.UUID) error
 	DislikeTrack(ctx context.Context, userId uuid.UUID, trackId uuid.UUID) error
 	ShowLikedTracks(ctx context.Context, id uuid.UUID) ([]*entity.MusicDB, error)
+	SetContentFilter(ctx context.Context, id uuid.UUID, filter *entity.ContentFilter) error
+	SignAccessToken(ctx context.Context, claims *entity.AccessClaims, now time.Time) (string, error)
+	CreateRefreshToken(ctx context.Context, session *entity.SessionDB, token *entity.RefreshTokenDB) error
+	RotateRefreshToken(ctx context.Context, tokenHash string, next *entity.RefreshTokenDB, device *entity.SessionDevice, now time.Time) (*entity.RefreshTokenDB, error)
+	RevokeRefreshTokenFamily(ctx context.Context, tokenHash string, now time.Time) error
+	RevokeRefreshTokens(ctx context.Context, userId uuid.UUID, now time.Time) error
+	DeleteExpiredRefreshTokens(ctx context.Context, now time.Time) error
 }
 
 type MusicRepository interface {
-	GetAll(ctx context.Context) ([]*entity.MusicDB, error)
+	GetAll(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error)
 	Get(ctx context.Context, musicId uuid.UUID) (*entity.MusicDB, error)
-	GetAndSortByPopular(ctx context.Context) ([]*entity.MusicDB, error)
-	GetAllSortByTime(ctx context.Context) ([]*entity.MusicDB, error)
-	Create(ctx context.Context, musicCreate *entity.MusicParse) error
+	GetAllSortByTime(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error)
+	GetAllSortByRating(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error)
+	Create(ctx context.Context, musicCreate *entity.MusicParse) (*entity.MusicDB, error)
 	Update(ctx context.Context, id uuid.UUID, musicUpdate *entity.MusicParse) error
+	SetExplicit(ctx context.Context, id uuid.UUID, explicit bool) error
+	Delete(ctx context.Context, id uuid.UUID, now time.Time) error
+	Fingerprint(ctx context.Context, music *entity.MusicDB) (*entity.MusicFingerprintDB, error)
+	GetDuplicateCandidates(ctx context.Context, fingerprint *entity.MusicFingerprintDB) ([]*entity.DuplicateCandidateDB, error)
+	SaveDuplicates(ctx context.Context, duplicates []*entity.MusicDuplicateDB) error
+	GetDuplicates(ctx context.Context, id uuid.UUID) ([]*entity.MusicDuplicateDB, error)
+	Merge(ctx context.Context, id uuid.UUID, intoId uuid.UUID, now time.Time) (*entity.MusicMergeResult, error)
+}
+
+type PlayRepository interface {
+	Create(ctx context.Context, event *entity.PlayEventDB) (bool, error)
+	CreateBatch(ctx context.Context, events []*entity.PlayEventDB) (int, error)
+	GetKnownMusicIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) ([]*entity.PlayEventDB, error)
+	Delete(ctx context.Context, userId uuid.UUID, eventId uuid.UUID) error
+	DeleteByUser(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) (int64, error)
+}
+
+type PopularityRepository interface {
+	Refresh(ctx context.Context, cfg *entity.PopularityConfig, now time.Time) error
+	Get(ctx context.Context, window string, limit int, offset int, hideExplicit bool) ([]*entity.MusicPopularityDB, error)
+}
+
+type ChartRepository interface {
+	Snapshot(ctx context.Context, cfg *entity.ChartConfig, week time.Time) (int64, error)
+	LatestWeek(ctx context.Context) (time.Time, error)
+	GetByWeek(ctx context.Context, week time.Time) ([]*entity.ChartEntryDB, error)
+	GetByMusic(ctx context.Context, musicId uuid.UUID) ([]*entity.ChartEntryDB, error)
+}
+
+type RecommendationRepository interface {
+	Refresh(ctx context.Context, cfg *entity.SimilarityConfig, now time.Time) error
+	GetForUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.RecommendationDB, error)
+	GetSimilar(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, limit int, offset int) ([]*entity.SimilarMusicDB, error)
+}
+
+type RatingRepository interface {
+	Set(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, rating int, now time.Time) (*entity.RatingSummary, error)
+	Clear(ctx context.Context, userId uuid.UUID, musicId uuid.UUID) (*entity.RatingSummary, error)
+	GetLibrary(ctx context.Context, userId uuid.UUID, sort string) ([]*entity.LibraryMusicDB, error)
+}
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment *entity.CommentDB, rateLimit int, since time.Time) error
+	Get(ctx context.Context, id uuid.UUID) (*entity.CommentDB, error)
+	GetByMusic(ctx context.Context, musicId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error)
+	GetByPlaylist(ctx context.Context, playlistId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error)
+	GetReplies(ctx context.Context, parentId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error)
+	MusicVisible(ctx context.Context, musicId uuid.UUID, showUnpublished bool) (bool, error)
+	GetPlaylist(ctx context.Context, playlistId uuid.UUID) (*entity.PlaylistDB, error)
+	Update(ctx context.Context, id uuid.UUID, body string, now time.Time) error
+	SetStatus(ctx context.Context, id uuid.UUID, status string, moderatorId *uuid.UUID, now time.Time) error
+	CreateReport(ctx context.Context, report *entity.CommentReportDB) (bool, error)
+	GetModerationQueue(ctx context.Context, limit int, offset int) ([]*entity.ModerationItemDB, error)
+	DismissReports(ctx context.Context, commentId uuid.UUID, moderatorId uuid.UUID, now time.Time) (int64, error)
+	Notify(ctx context.Context, notification *entity.NotificationDB) error
+}
+
+type LyricsRepository interface {
+	Upsert(ctx context.Context, lyrics *entity.LyricsDB) error
+	GetByMusic(ctx context.Context, musicId uuid.UUID, showUnpublished bool) ([]*entity.LyricsDB, error)
+	Delete(ctx context.Context, musicId uuid.UUID, language string) error
+}
+
+type MusicRevisionRepository interface {
+	GetByMusic(ctx context.Context, musicId uuid.UUID, limit int, offset int) ([]*entity.MusicRevisionDB, error)
+	Get(ctx context.Context, musicId uuid.UUID, version int) (*entity.MusicRevisionDB, error)
+	GetLatest(ctx context.Context, musicId uuid.UUID) (*entity.MusicRevisionDB, error)
+	Revert(ctx context.Context, target *entity.MusicRevisionDB, current *entity.MusicRevisionDB, revision *entity.MusicRevisionDB) error
+}
+
+type TrashRepository interface {
+	GetMusic(ctx context.Context, limit int, offset int) ([]*entity.MusicDB, error)
+	GetUsers(ctx context.Context, limit int, offset int) ([]*entity.UserDB, error)
+	RestoreMusic(ctx context.Context, id uuid.UUID) error
+	RestoreUser(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, before time.Time) error
+}
+
+type ReleaseRepository interface {
+	GetScheduled(ctx context.Context, limit int, offset int) ([]*entity.MusicDB, error)
+	SetSchedule(ctx context.Context, id uuid.UUID, schedule *entity.MusicSchedule, now time.Time) (*entity.MusicDB, error)
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
+}
+
+type ArtistRepository interface {
+	Create(ctx context.Context, artist *entity.ArtistDB) error
+	Get(ctx context.Context, id uuid.UUID) (*entity.ArtistDB, error)
+	SetMusicArtist(ctx context.Context, musicId uuid.UUID, artistId *uuid.UUID) error
+}
+
+type PlaylistRepository interface {
+	Create(ctx context.Context, playlist *entity.PlaylistDB) error
+	Get(ctx context.Context, id uuid.UUID) (*entity.PlaylistDB, error)
+	GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.PlaylistDB, error)
+	Update(ctx context.Context, playlist *entity.PlaylistDB) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetTracks(ctx context.Context, id uuid.UUID, viewerId uuid.UUID) ([]*entity.MusicDB, error)
+	AddTrack(ctx context.Context, id uuid.UUID, musicId uuid.UUID, now time.Time) error
+	RemoveTrack(ctx context.Context, id uuid.UUID, musicId uuid.UUID, now time.Time) error
+}
+
+type FollowRepository interface {
+	FollowArtist(ctx context.Context, userId uuid.UUID, artistId uuid.UUID, now time.Time) error
+	UnfollowArtist(ctx context.Context, userId uuid.UUID, artistId uuid.UUID) error
+	FollowUser(ctx context.Context, followerId uuid.UUID, followeeId uuid.UUID, now time.Time) error
+	UnfollowUser(ctx context.Context, followerId uuid.UUID, followeeId uuid.UUID) error
+	GetFollowing(ctx context.Context, userId uuid.UUID) (*entity.Following, error)
+	GetFeed(ctx context.Context, userId uuid.UUID, filter *entity.FeedFilter) ([]*entity.FeedItemDB, error)
+	SetPrivacy(ctx context.Context, userId uuid.UUID, privacy *entity.FeedPrivacy) error
+}
+
+type NotificationRepository interface {
+	CreateReleases(ctx context.Context, now time.Time) (int64, error)
+	GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.NotificationFilter) ([]*entity.NotificationDB, error)
+	CountUnread(ctx context.Context, userId uuid.UUID) (int, error)
+	MarkRead(ctx context.Context, userId uuid.UUID, id uuid.UUID, now time.Time) error
+	MarkAllRead(ctx context.Context, userId uuid.UUID, now time.Time) (int64, error)
+	GetSettings(ctx context.Context, userId uuid.UUID) (*entity.NotificationSettings, error)
+	SetSettings(ctx context.Context, userId uuid.UUID, settings *entity.NotificationSettings) error
+	GetUndelivered(ctx context.Context, limit int) ([]*entity.NotificationDelivery, error)
+	Deliver(ctx context.Context, delivery *entity.NotificationDelivery) error
+	MarkDelivered(ctx context.Context, id uuid.UUID, now time.Time) error
+}
+
+type ShareRepository interface {
+	Create(ctx context.Context, link *entity.ShareLinkDB) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.ShareLinkDB, error)
+	GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.ShareLinkDB, error)
+	Revoke(ctx context.Context, userId uuid.UUID, id uuid.UUID, now time.Time) error
+	ConsumePlay(ctx context.Context, id uuid.UUID, now time.Time) error
+	RecordAccess(ctx context.Context, access *entity.ShareAccessDB) error
+	GetMusic(ctx context.Context, musicId uuid.UUID) (*entity.MusicDB, error)
+	GetPlaylist(ctx context.Context, playlistId uuid.UUID) (*entity.PlaylistDB, error)
+	GetPlaylistTracks(ctx context.Context, playlistId uuid.UUID) ([]*entity.MusicDB, error)
+}
+
+type StatsRepository interface {
+	GetStats(ctx context.Context, userId uuid.UUID, filter *entity.StatsFilter) (*entity.ListeningStats, error)
+	GetUsersWithoutReview(ctx context.Context, year int, afterId uuid.UUID, now time.Time, limit int) ([]uuid.UUID, error)
+	SaveReview(ctx context.Context, review *entity.YearReviewDB) error
+	RecordReviewFailure(ctx context.Context, failure *entity.YearReviewFailure) error
+	GetReview(ctx context.Context, userId uuid.UUID, year int) (*entity.YearReviewDB, error)
+}
+
+type ExportRepository interface {
+	CountItems(ctx context.Context, userId uuid.UUID, request *entity.ExportRequest) (int64, error)
+	GetLikes(ctx context.Context, userId uuid.UUID) ([]*entity.ExportLikeDB, error)
+	GetPlaylists(ctx context.Context, userId uuid.UUID, playlistId *uuid.UUID) ([]*entity.PlaylistDB, error)
+	GetPlaylistTracks(ctx context.Context, playlistId uuid.UUID) ([]*entity.ExportPlaylistTrackDB, error)
+	GetRatings(ctx context.Context, userId uuid.UUID) ([]*entity.ExportRatingDB, error)
+	GetHistory(ctx context.Context, userId uuid.UUID, afterAt time.Time, afterId uuid.UUID, limit int) ([]*entity.ExportPlayDB, error)
+	CreateJob(ctx context.Context, job *entity.ExportJobDB) error
+	GetJob(ctx context.Context, id uuid.UUID) (*entity.ExportJobDB, error)
+	ClaimJobs(ctx context.Context, now time.Time, staleBefore time.Time, limit int) ([]*entity.ExportJobDB, error)
+	FinishJob(ctx context.Context, job *entity.ExportJobDB) error
+	DeleteExpiredJobs(ctx context.Context, now time.Time, limit int) ([]*entity.ExportJobDB, error)
+	WriteFile(job *entity.ExportJobDB, write func(w io.Writer) error) (int64, error)
+	RemoveFile(job *entity.ExportJobDB) error
+}
+
+type PlaylistImportRepository interface {
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ImportCandidateDB, error)
+	GetByTitles(ctx context.Context, titles []string) ([]*entity.ImportCandidateDB, error)
+	CreatePlaylist(ctx context.Context, playlist *entity.PlaylistDB, musicIds []uuid.UUID) error
+}
+
+type SmartPlaylistRepository interface {
+	Create(ctx context.Context, playlist *entity.SmartPlaylistDB) error
+	Get(ctx context.Context, id uuid.UUID) (*entity.SmartPlaylistDB, error)
+	GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.SmartPlaylistDB, error)
+	Update(ctx context.Context, playlist *entity.SmartPlaylistDB) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	GetTracks(ctx context.Context, playlist *entity.SmartPlaylistDB, viewerId uuid.UUID, now time.Time) ([]*entity.MusicDB, error)
+}
+
+type SyncRepository interface {
+	GetXmin(ctx context.Context) (uint64, error)
+	GetChanges(ctx context.Context, userId uuid.UUID, cursor *entity.SyncCursor, xmin uint64, limit int) ([]*entity.SyncChangeDB, error)
+	GetTracks(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.MusicDB, error)
+	GetTrackPage(ctx context.Context, userId uuid.UUID, after uuid.UUID, limit int) ([]*entity.MusicDB, error)
+	GetTakenDown(ctx context.Context, from time.Time, to time.Time) ([]uuid.UUID, error)
+	GetLikes(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.SyncLikeDB, error)
+	GetAllLikes(ctx context.Context, userId uuid.UUID) ([]*entity.SyncLikeDB, error)
+	GetPlaylists(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.PlaylistDB, error)
+	GetAllPlaylists(ctx context.Context, userId uuid.UUID) ([]*entity.PlaylistDB, error)
+	GetPlaylistTracks(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.SyncPlaylistTrackDB, error)
+	DeleteChanges(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+type SessionRepository interface {
+	GetByUser(ctx context.Context, userId uuid.UUID, now time.Time) ([]*entity.SessionDB, error)
+	Revoke(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID, now time.Time) error
+	RevokeAll(ctx context.Context, userId uuid.UUID, now time.Time) error
+	IsRevoked(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID, now time.Time) (bool, error)
+}
+
+type SigningKeyRepository interface {
+	GetKeys(ctx context.Context, now time.Time) ([]*entity.SigningKey, error)
+	GetVerificationKey(ctx context.Context, kid string, now time.Time) (*entity.SigningKey, error)
+	Create(ctx context.Context, key *entity.SigningKeyDB, after time.Time, now time.Time) (bool, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type IdentityRepository interface {
+	Begin(ctx context.Context, login *entity.OIDCLoginDB) (string, error)
+	ConsumeLogin(ctx context.Context, state string, now time.Time) (*entity.OIDCLoginDB, error)
+	Exchange(ctx context.Context, login *entity.OIDCLoginDB, code string) (*entity.OIDCIdentity, error)
+	Login(ctx context.Context, login *entity.IdentityLogin, now time.Time) (*entity.UserDB, error)
+	GetByUser(ctx context.Context, userId uuid.UUID) ([]*entity.UserIdentityDB, error)
+	DeleteExpiredLogins(ctx context.Context, now time.Time) error
 }
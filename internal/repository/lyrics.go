@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type lyricsRepository struct {
+	source db.LyricsSource
+}
+
+func NewLyricsRepository(source db.LyricsSource) *lyricsRepository {
+	return &lyricsRepository{
+		source: source,
+	}
+}
+
+func (r *lyricsRepository) Upsert(ctx context.Context, lyrics *entity.LyricsDB) error {
+	err := r.source.Upsert(ctx, lyrics)
+	if err != nil {
+		return fmt.Errorf("/db/lyrics.Upsert: %w", err)
+	}
+
+	return nil
+}
+
+func (r *lyricsRepository) GetByMusic(ctx context.Context, musicId uuid.UUID, showUnpublished bool) ([]*entity.LyricsDB, error) {
+	lyrics, err := r.source.GetByMusic(ctx, musicId, showUnpublished)
+	if err != nil {
+		return nil, fmt.Errorf("/db/lyrics.GetByMusic: %w", err)
+	}
+
+	return lyrics, nil
+}
+
+func (r *lyricsRepository) Delete(ctx context.Context, musicId uuid.UUID, language string) error {
+	err := r.source.Delete(ctx, musicId, language)
+	if err != nil {
+		return fmt.Errorf("/db/lyrics.Delete: %w", err)
+	}
+
+	return nil
+}
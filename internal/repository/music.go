@@ -2,30 +2,40 @@ package repository
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"music-backend-test/internal/db"
 	"music-backend-test/internal/entity"
 	"music-backend-test/internal/utils"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type musicRepository struct {
 	source     db.MusicSource
+	revisions  db.MusicRevisionSource
+	lyrics     db.LyricsSource
+	duplicates db.DuplicateSource
 	utils      utils.MusicUtils
 	FileSystem utils.FileSystem
 }
 
-func NewMusicRepository(source db.MusicSource, utils utils.MusicUtils, filesystem utils.FileSystem) *musicRepository {
+func NewMusicRepository(source db.MusicSource, revisions db.MusicRevisionSource, lyrics db.LyricsSource, duplicates db.DuplicateSource, utils utils.MusicUtils, filesystem utils.FileSystem) *musicRepository {
 	return &musicRepository{
 		source:     source,
+		revisions:  revisions,
+		lyrics:     lyrics,
+		duplicates: duplicates,
 		utils:      utils,
 		FileSystem: filesystem,
 	}
 }
 
-func (m *musicRepository) GetAll(ctx context.Context) ([]*entity.MusicDB, error) {
-	musicsDB, err := m.source.GetAll(ctx)
+func (m *musicRepository) GetAll(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
+	musicsDB, err := m.source.GetAll(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("/db/music.GetAll: %w", err)
 	}
@@ -41,25 +51,25 @@ func (m *musicRepository) Get(ctx context.Context, musicId uuid.UUID) (*entity.M
 	return musicDB, nil
 }
 
-func (m *musicRepository) GetAndSortByPopular(ctx context.Context) ([]*entity.MusicDB, error) {
-	musicsDB, err := m.source.GetAndSortByPopular(ctx)
+func (m *musicRepository) GetAllSortByTime(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
+	musicsDB, err := m.source.GetAllSortByTime(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("/db/music.GetAndSortByPopular: %w", err)
+		return nil, fmt.Errorf("/db/music.GetAllSortByTime: %w", err)
 	}
 
 	return musicsDB, nil
 }
 
-func (m *musicRepository) GetAllSortByTime(ctx context.Context) ([]*entity.MusicDB, error) {
-	musicsDB, err := m.source.GetAllSortByTime(ctx)
+func (m *musicRepository) GetAllSortByRating(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
+	musicsDB, err := m.source.GetAllSortByRating(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("/db/music.GetAllSortByTime: %w", err)
+		return nil, fmt.Errorf("/db/music.GetAllSortByRating: %w", err)
 	}
 
 	return musicsDB, nil
 }
 
-func (m *musicRepository) Create(ctx context.Context, musicParse *entity.MusicParse) error {
+func (m *musicRepository) Create(ctx context.Context, musicParse *entity.MusicParse) (*entity.MusicDB, error) {
 	musicCreate := &entity.MusicDB{
 		Name:     musicParse.Name,
 		Release:  musicParse.Release,
@@ -68,101 +78,261 @@ func (m *musicRepository) Create(ctx context.Context, musicParse *entity.MusicPa
 
 	fileType, err := m.utils.GetSupportedFileType(musicParse.FileHeader.Filename)
 	if err != nil {
-		return fmt.Errorf("/utils.GetSupportedFileType: %w", err)
+		return nil, fmt.Errorf("/utils.GetSupportedFileType: %w", err)
 	}
 
 	// скачиваем файл
 	download_file, err := m.FileSystem.Create(musicCreate.FilePath())
 	if err != nil {
-		return fmt.Errorf("can't create file: %w", err)
+		return nil, fmt.Errorf("can't create file: %w", err)
 	}
 	defer download_file.Close()
 	defer musicParse.File.Close()
 
 	if _, err := m.FileSystem.Copy(download_file, musicParse.File); err != nil {
-		return fmt.Errorf("can't copy file: %w", err)
+		return nil, fmt.Errorf("can't copy file: %w", err)
 	}
 
 	musicCreate.Size = uint64(musicParse.FileHeader.Size)
 
 	musicCreate.Duration, err = m.utils.GetAudioDuration(fileType, musicCreate.FilePath(), m.FileSystem)
 	if err != nil {
-		return fmt.Errorf("/utils.GetAudioDuration: %w", err)
+		return nil, fmt.Errorf("/utils.GetAudioDuration: %w", err)
+	}
+
+	// Отметка о ненормативном контенте из тегов необязательна: без нее трек считается обычным
+	explicit, err := m.utils.GetExplicit(fileType, musicCreate.FilePath(), m.FileSystem)
+	if err == nil {
+		musicCreate.Explicit = explicit
 	}
 
+	// Без явного времени публикации трек публикуется в дату релиза
+	schedule := entity.MusicSchedule{PublishAt: musicParse.Release}
+	if musicParse.PublishAt != nil {
+		schedule.PublishAt = *musicParse.PublishAt
+	}
+	now := time.Now()
+	musicCreate.PublishAt = schedule.PublishAt
+	musicCreate.PublishedAt = schedule.PublishedAt(now)
+
 	err = m.source.Create(ctx, musicCreate)
 	if err != nil {
-		return fmt.Errorf("/db/music.Create: %w", err)
+		return nil, fmt.Errorf("/db/music.Create: %w", err)
 	}
 
-	return nil
-}
-
-func (m *musicRepository) Update(ctx context.Context, id uuid.UUID, musicParse *entity.MusicParse) error {
-	musicUpdate := &entity.MusicDB{
-		Id:      id,
-		Name:    musicParse.Name,
-		Release: musicParse.Release,
+	err = m.revisions.Create(ctx, entity.NewMusicRevision(musicCreate, entity.MusicRevisionCreate, musicParse.EditorID, now))
+	if err != nil {
+		return nil, fmt.Errorf("/db/music_revision.Create: %w", err)
 	}
 
-	if musicParse.FileHeader != nil {
-		musicUpdate.FileName = musicParse.FileHeader.Filename
+	// Текст из тегов файла необязателен: файл без тега или с поврежденным тегом загружается без текста
+	embedded, err := m.utils.GetLyrics(fileType, musicCreate.FilePath(), m.FileSystem)
+	if err != nil {
+		return musicCreate, nil
+	}
 
-		fileType, err := m.utils.GetSupportedFileType(musicParse.FileHeader.Filename)
+	for _, lyrics := range embedded {
+		err = m.lyrics.Upsert(ctx, embeddedLyricsToDB(musicCreate.Id, lyrics, now))
 		if err != nil {
-			return fmt.Errorf("/utils.GetSupportedFileType: %w", err)
+			return nil, fmt.Errorf("/db/lyrics.Upsert: %w", err)
 		}
+	}
 
-		music, err := m.source.Get(ctx, id)
-		if err != nil {
-			return fmt.Errorf("/db/music.Get: %w", err)
+	return musicCreate, nil
+}
+
+// embeddedLyricsToDB переводит текст из тега ID3 в запись БД. Код языка "xxx" и некорректные коды означают неизвестный язык.
+func embeddedLyricsToDB(musicId uuid.UUID, lyrics *utils.EmbeddedLyrics, now time.Time) *entity.LyricsDB {
+	language, err := entity.NormalizeLanguage(lyrics.Language)
+	if err != nil || language == "xxx" {
+		language = entity.UndefinedLanguage
+	}
+
+	lyricsDB := &entity.LyricsDB{
+		MusicID:   musicId,
+		Language:  language,
+		Plain:     strings.TrimSpace(lyrics.Text),
+		Source:    entity.LyricsSourceID3,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if len(lyrics.Synced) > 0 {
+		lrc := &entity.LRC{}
+		for _, line := range lyrics.Synced {
+			lrc.Lines = append(lrc.Lines, entity.LyricLine{Time: line.Time, Text: line.Text})
 		}
+		synced := lrc.String()
+		lyricsDB.Synced = &synced
+		if lyricsDB.Plain == "" {
+			lyricsDB.Plain = lrc.PlainText()
+		}
+	}
 
-		m.FileSystem.Remove(music.FilePath())
+	return lyricsDB
+}
 
-		download_file, err := m.FileSystem.Create(music.FilePath())
+// Update изменяет трек и сохраняет его новую версию. Замененный файл не удаляется,
+// а переносится в хранилище версий, чтобы к нему можно было откатиться.
+func (m *musicRepository) Update(ctx context.Context, id uuid.UUID, musicParse *entity.MusicParse) error {
+	var fileType utils.FileType
+	if musicParse.FileHeader != nil {
+		var err error
+		fileType, err = m.utils.GetSupportedFileType(musicParse.FileHeader.Filename)
 		if err != nil {
-			return fmt.Errorf("can't create file: %w", err)
+			return fmt.Errorf("/utils.GetSupportedFileType: %w", err)
 		}
-		defer download_file.Close()
-		defer musicParse.File.Close()
+	}
 
-		if _, err := m.FileSystem.Copy(download_file, musicParse.File); err != nil {
-			return fmt.Errorf("can't copy file: %w", err)
-		}
+	music, err := m.source.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("/db/music.Get: %w", err)
+	}
 
-		musicUpdate.Size = uint64(musicParse.FileHeader.Size)
+	musicUpdate := &entity.MusicDB{
+		Id:       id,
+		Name:     musicParse.Name,
+		Release:  musicParse.Release,
+		FileName: music.FileName,
+		Size:     music.Size,
+		Duration: music.Duration,
+	}
 
-		musicUpdate.Duration, err = m.utils.GetAudioDuration(fileType, musicUpdate.FilePath(), m.FileSystem)
+	var moves []entity.MusicFileMove
+	if musicParse.FileHeader != nil {
+		moves = []entity.MusicFileMove{{From: music.FilePath(), To: entity.MusicRevisionFilePath(id, music.FileName)}}
+		err = moveMusicFiles(m.FileSystem, moves)
 		if err != nil {
-			return fmt.Errorf("/utils.GetAudioDuration: %w", err)
+			return err
 		}
 
 		musicUpdate.FileName = musicParse.FileHeader.Filename
+		musicUpdate.Size = uint64(musicParse.FileHeader.Size)
+		musicUpdate.Duration, err = m.replaceFile(fileType, musicUpdate.FilePath(), musicParse)
+		if err != nil {
+			restoreMusicFiles(m.FileSystem, moves)
+			return err
+		}
 	}
 
-	err := m.source.Update(ctx, musicUpdate)
+	revision := entity.NewMusicRevision(musicUpdate, entity.MusicRevisionUpdate, musicParse.EditorID, time.Now())
+	err = m.revisions.Apply(ctx, musicUpdate, revision, moves)
 	if err != nil {
-		return fmt.Errorf("/db/music.Update: %w", err)
+		if moves != nil {
+			m.FileSystem.Remove(musicUpdate.FilePath())
+			restoreMusicFiles(m.FileSystem, moves)
+		}
+		return fmt.Errorf("/db/music_revision.Apply: %w", err)
 	}
 
 	return nil
 }
 
-func (m *musicRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	music, err := m.source.Get(ctx, id)
+// replaceFile записывает загруженный файл и возвращает его продолжительность
+func (m *musicRepository) replaceFile(fileType utils.FileType, filePath string, musicParse *entity.MusicParse) (string, error) {
+	download_file, err := m.FileSystem.Create(filePath)
 	if err != nil {
-		return fmt.Errorf("/db/music.Get: %w", err)
+		return "", fmt.Errorf("can't create file: %w", err)
 	}
-	err = m.FileSystem.Remove(music.FilePath())
+	defer download_file.Close()
+	defer musicParse.File.Close()
+
+	if _, err := m.FileSystem.Copy(download_file, musicParse.File); err != nil {
+		m.FileSystem.Remove(filePath)
+		return "", fmt.Errorf("can't copy file: %w", err)
+	}
+
+	duration, err := m.utils.GetAudioDuration(fileType, filePath, m.FileSystem)
 	if err != nil {
-		return fmt.Errorf("can't delete music file: %w", err)
+		m.FileSystem.Remove(filePath)
+		return "", fmt.Errorf("/utils.GetAudioDuration: %w", err)
 	}
 
-	err = m.source.Delete(ctx, id)
+	return duration, nil
+}
+
+func (m *musicRepository) SetExplicit(ctx context.Context, id uuid.UUID, explicit bool) error {
+	err := m.source.SetExplicit(ctx, id, explicit)
+	if err != nil {
+		return fmt.Errorf("/db/music.SetExplicit: %w", err)
+	}
+
+	return nil
+}
+
+// Delete перемещает трек в корзину. Файлы трека и его версий удаляются при очистке корзины.
+func (m *musicRepository) Delete(ctx context.Context, id uuid.UUID, now time.Time) error {
+	err := m.source.Delete(ctx, id, now)
 	if err != nil {
 		return fmt.Errorf("/db/music.Delete: %w", err)
 	}
 
 	return nil
 }
+
+// Fingerprint вычисляет и сохраняет признаки файла трека. Отпечаток звука необязателен:
+// для файла, который не удалось разобрать, сохраняется только хэш.
+func (m *musicRepository) Fingerprint(ctx context.Context, music *entity.MusicDB) (*entity.MusicFingerprintDB, error) {
+	file, err := m.FileSystem.Open(music.FilePath())
+	if err != nil {
+		return nil, fmt.Errorf("can't open file: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := m.FileSystem.Copy(hash, file); err != nil {
+		return nil, fmt.Errorf("can't hash file: %w", err)
+	}
+
+	fingerprint := &entity.MusicFingerprintDB{
+		MusicID:     music.Id,
+		ContentHash: hex.EncodeToString(hash.Sum(nil)),
+		CreatedAt:   time.Now(),
+	}
+	if fileType, err := m.utils.GetSupportedFileType(music.FileName); err == nil {
+		fingerprint.Fingerprint, _ = m.utils.GetFingerprint(fileType, music.FilePath(), m.FileSystem)
+	}
+
+	err = m.duplicates.SaveFingerprint(ctx, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("/db/duplicate.SaveFingerprint: %w", err)
+	}
+
+	return fingerprint, nil
+}
+
+func (m *musicRepository) GetDuplicateCandidates(ctx context.Context, fingerprint *entity.MusicFingerprintDB) ([]*entity.DuplicateCandidateDB, error) {
+	candidates, err := m.duplicates.GetCandidates(ctx, fingerprint.MusicID, fingerprint.ContentHash, entity.DuplicateDurationTolerance, entity.DuplicateCandidateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("/db/duplicate.GetCandidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+func (m *musicRepository) SaveDuplicates(ctx context.Context, duplicates []*entity.MusicDuplicateDB) error {
+	err := m.duplicates.SaveDuplicates(ctx, duplicates)
+	if err != nil {
+		return fmt.Errorf("/db/duplicate.SaveDuplicates: %w", err)
+	}
+
+	return nil
+}
+
+func (m *musicRepository) GetDuplicates(ctx context.Context, id uuid.UUID) ([]*entity.MusicDuplicateDB, error) {
+	duplicates, err := m.duplicates.GetByMusic(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("/db/duplicate.GetByMusic: %w", err)
+	}
+
+	return duplicates, nil
+}
+
+func (m *musicRepository) Merge(ctx context.Context, id uuid.UUID, intoId uuid.UUID, now time.Time) (*entity.MusicMergeResult, error) {
+	result, err := m.duplicates.Merge(ctx, id, intoId, now)
+	if err != nil {
+		return nil, fmt.Errorf("/db/duplicate.Merge: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+type musicRevisionRepository struct {
+	source     db.MusicRevisionSource
+	FileSystem utils.FileSystem
+}
+
+func NewMusicRevisionRepository(source db.MusicRevisionSource, filesystem utils.FileSystem) *musicRevisionRepository {
+	return &musicRevisionRepository{
+		source:     source,
+		FileSystem: filesystem,
+	}
+}
+
+func (r *musicRevisionRepository) GetByMusic(ctx context.Context, musicId uuid.UUID, limit int, offset int) ([]*entity.MusicRevisionDB, error) {
+	revisions, err := r.source.GetByMusic(ctx, musicId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/db/music_revision.GetByMusic: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *musicRevisionRepository) Get(ctx context.Context, musicId uuid.UUID, version int) (*entity.MusicRevisionDB, error) {
+	revision, err := r.source.Get(ctx, musicId, version)
+	if err != nil {
+		return nil, fmt.Errorf("/db/music_revision.Get: %w", err)
+	}
+
+	return revision, nil
+}
+
+func (r *musicRevisionRepository) GetLatest(ctx context.Context, musicId uuid.UUID) (*entity.MusicRevisionDB, error) {
+	revision, err := r.source.GetLatest(ctx, musicId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/music_revision.GetLatest: %w", err)
+	}
+
+	return revision, nil
+}
+
+// Revert возвращает трек к состоянию версии target. Если у версий разные файлы, файл текущей версии
+// переносится в хранилище версий, а файл target возвращается на место файла трека.
+func (r *musicRevisionRepository) Revert(ctx context.Context, target *entity.MusicRevisionDB, current *entity.MusicRevisionDB, revision *entity.MusicRevisionDB) error {
+	var moves []entity.MusicFileMove
+	if target.FilePath != current.FilePath {
+		moves = []entity.MusicFileMove{
+			{From: current.FilePath, To: entity.MusicRevisionFilePath(current.MusicID, current.FileName)},
+			{From: target.FilePath, To: revision.FilePath},
+		}
+		err := moveMusicFiles(r.FileSystem, moves)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := r.source.Apply(ctx, revision.ToMusic(), revision, moves)
+	if err != nil {
+		restoreMusicFiles(r.FileSystem, moves)
+		return fmt.Errorf("/db/music_revision.Apply: %w", err)
+	}
+
+	return nil
+}
+
+// moveMusicFiles переносит файлы по порядку. При ошибке уже перенесенные файлы возвращаются на место.
+func moveMusicFiles(fs utils.FileSystem, moves []entity.MusicFileMove) error {
+	err := fs.MkdirAll(entity.MusicRevisionStorage)
+	if err != nil {
+		return fmt.Errorf("can't create revision storage: %w", err)
+	}
+
+	for i, move := range moves {
+		err := fs.Rename(move.From, move.To)
+		if err != nil {
+			restoreMusicFiles(fs, moves[:i])
+			return fmt.Errorf("can't move music file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func restoreMusicFiles(fs utils.FileSystem, moves []entity.MusicFileMove) {
+	for i := len(moves) - 1; i >= 0; i-- {
+		fs.Rename(moves[i].To, moves[i].From)
+	}
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/notify"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type notificationRepository struct {
+	source   db.NotificationSource
+	channels []notify.Channel
+}
+
+func NewNotificationRepository(source db.NotificationSource, channels []notify.Channel) *notificationRepository {
+	return &notificationRepository{
+		source:   source,
+		channels: channels,
+	}
+}
+
+func (r *notificationRepository) CreateReleases(ctx context.Context, now time.Time) (int64, error) {
+	created, err := r.source.CreateReleases(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("/db/notification.CreateReleases: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *notificationRepository) GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.NotificationFilter) ([]*entity.NotificationDB, error) {
+	notifications, err := r.source.GetByUser(ctx, userId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/db/notification.GetByUser: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userId uuid.UUID) (int, error) {
+	count, err := r.source.CountUnread(ctx, userId)
+	if err != nil {
+		return 0, fmt.Errorf("/db/notification.CountUnread: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userId uuid.UUID, id uuid.UUID, now time.Time) error {
+	err := r.source.MarkRead(ctx, userId, id, now)
+	if err != nil {
+		return fmt.Errorf("/db/notification.MarkRead: %w", err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userId uuid.UUID, now time.Time) (int64, error) {
+	read, err := r.source.MarkAllRead(ctx, userId, now)
+	if err != nil {
+		return 0, fmt.Errorf("/db/notification.MarkAllRead: %w", err)
+	}
+
+	return read, nil
+}
+
+func (r *notificationRepository) GetSettings(ctx context.Context, userId uuid.UUID) (*entity.NotificationSettings, error) {
+	settings, err := r.source.GetSettings(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/notification.GetSettings: %w", err)
+	}
+
+	return settings, nil
+}
+
+func (r *notificationRepository) SetSettings(ctx context.Context, userId uuid.UUID, settings *entity.NotificationSettings) error {
+	err := r.source.SetSettings(ctx, userId, settings)
+	if err != nil {
+		return fmt.Errorf("/db/notification.SetSettings: %w", err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) GetUndelivered(ctx context.Context, limit int) ([]*entity.NotificationDelivery, error) {
+	deliveries, err := r.source.GetUndelivered(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("/db/notification.GetUndelivered: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// Deliver отправляет уведомление во все каналы, включенные получателем. Ошибка одного канала не мешает остальным.
+func (r *notificationRepository) Deliver(ctx context.Context, delivery *entity.NotificationDelivery) error {
+	var errs []error
+	for _, channel := range r.channels {
+		if !delivery.Enabled(channel.Name()) {
+			continue
+		}
+		if err := channel.Send(ctx, delivery); err != nil {
+			errs = append(errs, fmt.Errorf("/notify/%s.Send: %w", channel.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *notificationRepository) MarkDelivered(ctx context.Context, id uuid.UUID, now time.Time) error {
+	err := r.source.MarkDelivered(ctx, id, now)
+	if err != nil {
+		return fmt.Errorf("/db/notification.MarkDelivered: %w", err)
+	}
+
+	return nil
+}
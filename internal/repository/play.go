@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type playRepository struct {
+	source db.PlaySource
+}
+
+func NewPlayRepository(source db.PlaySource) *playRepository {
+	return &playRepository{
+		source: source,
+	}
+}
+
+func (p *playRepository) Create(ctx context.Context, event *entity.PlayEventDB) (bool, error) {
+	created, err := p.source.Create(ctx, event)
+	if err != nil {
+		return false, fmt.Errorf("/db/play.Create: %w", err)
+	}
+
+	return created, nil
+}
+
+func (p *playRepository) CreateBatch(ctx context.Context, events []*entity.PlayEventDB) (int, error) {
+	inserted, err := p.source.CreateBatch(ctx, events)
+	if err != nil {
+		return 0, fmt.Errorf("/db/play.CreateBatch: %w", err)
+	}
+
+	return inserted, nil
+}
+
+func (p *playRepository) GetKnownMusicIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	known, err := p.source.GetKnownMusicIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("/db/play.GetKnownMusicIDs: %w", err)
+	}
+
+	return known, nil
+}
+
+func (p *playRepository) GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) ([]*entity.PlayEventDB, error) {
+	events, err := p.source.GetByUser(ctx, userId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/db/play.GetByUser: %w", err)
+	}
+
+	return events, nil
+}
+
+func (p *playRepository) Delete(ctx context.Context, userId uuid.UUID, eventId uuid.UUID) error {
+	err := p.source.Delete(ctx, userId, eventId)
+	if err != nil {
+		return fmt.Errorf("/db/play.Delete: %w", err)
+	}
+
+	return nil
+}
+
+func (p *playRepository) DeleteByUser(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) (int64, error) {
+	deleted, err := p.source.DeleteByUser(ctx, userId, filter)
+	if err != nil {
+		return 0, fmt.Errorf("/db/play.DeleteByUser: %w", err)
+	}
+
+	return deleted, nil
+}
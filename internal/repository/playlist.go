@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type playlistRepository struct {
+	source db.PlaylistSource
+}
+
+func NewPlaylistRepository(source db.PlaylistSource) *playlistRepository {
+	return &playlistRepository{
+		source: source,
+	}
+}
+
+func (r *playlistRepository) Create(ctx context.Context, playlist *entity.PlaylistDB) error {
+	err := r.source.Create(ctx, playlist)
+	if err != nil {
+		return fmt.Errorf("/db/playlist.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (r *playlistRepository) Get(ctx context.Context, id uuid.UUID) (*entity.PlaylistDB, error) {
+	playlist, err := r.source.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("/db/playlist.Get: %w", err)
+	}
+
+	return playlist, nil
+}
+
+func (r *playlistRepository) GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.PlaylistDB, error) {
+	playlists, err := r.source.GetByUser(ctx, userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/db/playlist.GetByUser: %w", err)
+	}
+
+	return playlists, nil
+}
+
+func (r *playlistRepository) Update(ctx context.Context, playlist *entity.PlaylistDB) error {
+	err := r.source.Update(ctx, playlist)
+	if err != nil {
+		return fmt.Errorf("/db/playlist.Update: %w", err)
+	}
+
+	return nil
+}
+
+func (r *playlistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	err := r.source.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("/db/playlist.Delete: %w", err)
+	}
+
+	return nil
+}
+
+func (r *playlistRepository) GetTracks(ctx context.Context, id uuid.UUID, viewerId uuid.UUID) ([]*entity.MusicDB, error) {
+	music, err := r.source.GetTracks(ctx, id, viewerId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/playlist.GetTracks: %w", err)
+	}
+
+	return music, nil
+}
+
+func (r *playlistRepository) AddTrack(ctx context.Context, id uuid.UUID, musicId uuid.UUID, now time.Time) error {
+	err := r.source.AddTrack(ctx, id, musicId, now)
+	if err != nil {
+		return fmt.Errorf("/db/playlist.AddTrack: %w", err)
+	}
+
+	return nil
+}
+
+func (r *playlistRepository) RemoveTrack(ctx context.Context, id uuid.UUID, musicId uuid.UUID, now time.Time) error {
+	err := r.source.RemoveTrack(ctx, id, musicId, now)
+	if err != nil {
+		return fmt.Errorf("/db/playlist.RemoveTrack: %w", err)
+	}
+
+	return nil
+}
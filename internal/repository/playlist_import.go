@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type playlistImportRepository struct {
+	source db.PlaylistImportSource
+}
+
+func NewPlaylistImportRepository(source db.PlaylistImportSource) *playlistImportRepository {
+	return &playlistImportRepository{
+		source: source,
+	}
+}
+
+func (r *playlistImportRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ImportCandidateDB, error) {
+	tracks, err := r.source.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("/db/playlist_import.GetByIDs: %w", err)
+	}
+
+	return tracks, nil
+}
+
+func (r *playlistImportRepository) GetByTitles(ctx context.Context, titles []string) ([]*entity.ImportCandidateDB, error) {
+	tracks, err := r.source.GetByTitles(ctx, titles)
+	if err != nil {
+		return nil, fmt.Errorf("/db/playlist_import.GetByTitles: %w", err)
+	}
+
+	return tracks, nil
+}
+
+func (r *playlistImportRepository) CreatePlaylist(ctx context.Context, playlist *entity.PlaylistDB, musicIds []uuid.UUID) error {
+	err := r.source.CreatePlaylist(ctx, playlist, musicIds)
+	if err != nil {
+		return fmt.Errorf("/db/playlist_import.CreatePlaylist: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+)
+
+type popularityRepository struct {
+	source db.PopularitySource
+}
+
+func NewPopularityRepository(source db.PopularitySource) *popularityRepository {
+	return &popularityRepository{
+		source: source,
+	}
+}
+
+func (p *popularityRepository) Refresh(ctx context.Context, cfg *entity.PopularityConfig, now time.Time) error {
+	err := p.source.Refresh(ctx, cfg, now)
+	if err != nil {
+		return fmt.Errorf("/db/popularity.Refresh: %w", err)
+	}
+
+	return nil
+}
+
+func (p *popularityRepository) Get(ctx context.Context, window string, limit int, offset int, hideExplicit bool) ([]*entity.MusicPopularityDB, error) {
+	musics, err := p.source.Get(ctx, window, limit, offset, hideExplicit)
+	if err != nil {
+		return nil, fmt.Errorf("/db/popularity.Get: %w", err)
+	}
+
+	return musics, nil
+}
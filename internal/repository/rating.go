@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ratingRepository struct {
+	source db.RatingSource
+}
+
+func NewRatingRepository(source db.RatingSource) *ratingRepository {
+	return &ratingRepository{
+		source: source,
+	}
+}
+
+func (r *ratingRepository) Set(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, rating int, now time.Time) (*entity.RatingSummary, error) {
+	summary, err := r.source.Set(ctx, userId, musicId, rating, now)
+	if err != nil {
+		return nil, fmt.Errorf("/db/rating.Set: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (r *ratingRepository) Clear(ctx context.Context, userId uuid.UUID, musicId uuid.UUID) (*entity.RatingSummary, error) {
+	summary, err := r.source.Clear(ctx, userId, musicId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/rating.Clear: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (r *ratingRepository) GetLibrary(ctx context.Context, userId uuid.UUID, sort string) ([]*entity.LibraryMusicDB, error) {
+	musics, err := r.source.GetLibrary(ctx, userId, sort)
+	if err != nil {
+		return nil, fmt.Errorf("/db/rating.GetLibrary: %w", err)
+	}
+
+	return musics, nil
+}
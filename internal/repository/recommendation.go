@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type recommendationRepository struct {
+	source db.RecommendationSource
+}
+
+func NewRecommendationRepository(source db.RecommendationSource) *recommendationRepository {
+	return &recommendationRepository{
+		source: source,
+	}
+}
+
+func (r *recommendationRepository) Refresh(ctx context.Context, cfg *entity.SimilarityConfig, now time.Time) error {
+	err := r.source.Refresh(ctx, cfg, now)
+	if err != nil {
+		return fmt.Errorf("/db/recommendation.Refresh: %w", err)
+	}
+
+	return nil
+}
+
+func (r *recommendationRepository) GetForUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.RecommendationDB, error) {
+	musics, err := r.source.GetForUser(ctx, userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/db/recommendation.GetForUser: %w", err)
+	}
+
+	return musics, nil
+}
+
+func (r *recommendationRepository) GetSimilar(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, limit int, offset int) ([]*entity.SimilarMusicDB, error) {
+	musics, err := r.source.GetSimilar(ctx, userId, musicId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/db/recommendation.GetSimilar: %w", err)
+	}
+
+	return musics, nil
+}
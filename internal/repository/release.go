@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type releaseRepository struct {
+	source db.ReleaseSource
+}
+
+func NewReleaseRepository(source db.ReleaseSource) *releaseRepository {
+	return &releaseRepository{
+		source: source,
+	}
+}
+
+func (r *releaseRepository) GetScheduled(ctx context.Context, limit int, offset int) ([]*entity.MusicDB, error) {
+	music, err := r.source.GetScheduled(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/db/release.GetScheduled: %w", err)
+	}
+
+	return music, nil
+}
+
+func (r *releaseRepository) SetSchedule(ctx context.Context, id uuid.UUID, schedule *entity.MusicSchedule, now time.Time) (*entity.MusicDB, error) {
+	music, err := r.source.SetSchedule(ctx, id, schedule, now)
+	if err != nil {
+		return nil, fmt.Errorf("/db/release.SetSchedule: %w", err)
+	}
+
+	return music, nil
+}
+
+func (r *releaseRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	published, err := r.source.PublishDue(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("/db/release.PublishDue: %w", err)
+	}
+
+	return published, nil
+}
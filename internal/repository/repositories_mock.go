@@ -6,8 +6,10 @@ package repository
 
 import (
 	context "context"
+	io "io"
 	entity "music-backend-test/internal/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,6 +38,21 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Authenticate mocks base method.
+func (m *MockUserRepository) Authenticate(ctx context.Context, username, password string) (*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, username, password)
+	ret0, _ := ret[0].(*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockUserRepositoryMockRecorder) Authenticate(ctx, username, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUserRepository)(nil).Authenticate), ctx, username, password)
+}
+
 // Create mocks base method.
 func (m *MockUserRepository) Create(ctx context.Context, user *entity.UserCreate) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -51,18 +68,46 @@ func (mr *MockUserRepositoryMockRecorder) Create(ctx, user interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepository)(nil).Create), ctx, user)
 }
 
+// CreateRefreshToken mocks base method.
+func (m *MockUserRepository) CreateRefreshToken(ctx context.Context, session *entity.SessionDB, token *entity.RefreshTokenDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, session, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockUserRepositoryMockRecorder) CreateRefreshToken(ctx, session, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockUserRepository)(nil).CreateRefreshToken), ctx, session, token)
+}
+
 // Delete mocks base method.
-func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID, now time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, now)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id, now)
+}
+
+// DeleteExpiredRefreshTokens mocks base method.
+func (m *MockUserRepository) DeleteExpiredRefreshTokens(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredRefreshTokens", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredRefreshTokens indicates an expected call of DeleteExpiredRefreshTokens.
+func (mr *MockUserRepositoryMockRecorder) DeleteExpiredRefreshTokens(ctx, now interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredRefreshTokens", reflect.TypeOf((*MockUserRepository)(nil).DeleteExpiredRefreshTokens), ctx, now)
 }
 
 // DislikeTrack mocks base method.
@@ -123,6 +168,63 @@ func (mr *MockUserRepositoryMockRecorder) LikeTrack(ctx, userId, trackId interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikeTrack", reflect.TypeOf((*MockUserRepository)(nil).LikeTrack), ctx, userId, trackId)
 }
 
+// RevokeRefreshTokenFamily mocks base method.
+func (m *MockUserRepository) RevokeRefreshTokenFamily(ctx context.Context, tokenHash string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokenFamily", ctx, tokenHash, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokenFamily indicates an expected call of RevokeRefreshTokenFamily.
+func (mr *MockUserRepositoryMockRecorder) RevokeRefreshTokenFamily(ctx, tokenHash, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokenFamily", reflect.TypeOf((*MockUserRepository)(nil).RevokeRefreshTokenFamily), ctx, tokenHash, now)
+}
+
+// RevokeRefreshTokens mocks base method.
+func (m *MockUserRepository) RevokeRefreshTokens(ctx context.Context, userId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokens", ctx, userId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokens indicates an expected call of RevokeRefreshTokens.
+func (mr *MockUserRepositoryMockRecorder) RevokeRefreshTokens(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokens", reflect.TypeOf((*MockUserRepository)(nil).RevokeRefreshTokens), ctx, userId, now)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockUserRepository) RotateRefreshToken(ctx context.Context, tokenHash string, next *entity.RefreshTokenDB, device *entity.SessionDevice, now time.Time) (*entity.RefreshTokenDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, tokenHash, next, device, now)
+	ret0, _ := ret[0].(*entity.RefreshTokenDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockUserRepositoryMockRecorder) RotateRefreshToken(ctx, tokenHash, next, device, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockUserRepository)(nil).RotateRefreshToken), ctx, tokenHash, next, device, now)
+}
+
+// SetContentFilter mocks base method.
+func (m *MockUserRepository) SetContentFilter(ctx context.Context, id uuid.UUID, filter *entity.ContentFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetContentFilter", ctx, id, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetContentFilter indicates an expected call of SetContentFilter.
+func (mr *MockUserRepositoryMockRecorder) SetContentFilter(ctx, id, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContentFilter", reflect.TypeOf((*MockUserRepository)(nil).SetContentFilter), ctx, id, filter)
+}
+
 // ShowLikedTracks mocks base method.
 func (m *MockUserRepository) ShowLikedTracks(ctx context.Context, id uuid.UUID) ([]*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +240,21 @@ func (mr *MockUserRepositoryMockRecorder) ShowLikedTracks(ctx, id interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowLikedTracks", reflect.TypeOf((*MockUserRepository)(nil).ShowLikedTracks), ctx, id)
 }
 
+// SignAccessToken mocks base method.
+func (m *MockUserRepository) SignAccessToken(ctx context.Context, claims *entity.AccessClaims, now time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignAccessToken", ctx, claims, now)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignAccessToken indicates an expected call of SignAccessToken.
+func (mr *MockUserRepositoryMockRecorder) SignAccessToken(ctx, claims, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignAccessToken", reflect.TypeOf((*MockUserRepository)(nil).SignAccessToken), ctx, claims, now)
+}
+
 // Update mocks base method.
 func (m *MockUserRepository) Update(ctx context.Context, id uuid.UUID, user *entity.UserCreate) (*entity.UserDB, error) {
 	m.ctrl.T.Helper()
@@ -177,11 +294,12 @@ func (m *MockMusicRepository) EXPECT() *MockMusicRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockMusicRepository) Create(ctx context.Context, musicCreate *entity.MusicParse) error {
+func (m *MockMusicRepository) Create(ctx context.Context, musicCreate *entity.MusicParse) (*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", ctx, musicCreate)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
@@ -191,17 +309,32 @@ func (mr *MockMusicRepositoryMockRecorder) Create(ctx, musicCreate interface{})
 }
 
 // Delete mocks base method.
-func (m *MockMusicRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockMusicRepository) Delete(ctx context.Context, id uuid.UUID, now time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, now)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockMusicRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockMusicRepositoryMockRecorder) Delete(ctx, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMusicRepository)(nil).Delete), ctx, id, now)
+}
+
+// Fingerprint mocks base method.
+func (m *MockMusicRepository) Fingerprint(ctx context.Context, music *entity.MusicDB) (*entity.MusicFingerprintDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fingerprint", ctx, music)
+	ret0, _ := ret[0].(*entity.MusicFingerprintDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fingerprint indicates an expected call of Fingerprint.
+func (mr *MockMusicRepositoryMockRecorder) Fingerprint(ctx, music interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMusicRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fingerprint", reflect.TypeOf((*MockMusicRepository)(nil).Fingerprint), ctx, music)
 }
 
 // Get mocks base method.
@@ -220,48 +353,121 @@ func (mr *MockMusicRepositoryMockRecorder) Get(ctx, musicId interface{}) *gomock
 }
 
 // GetAll mocks base method.
-func (m *MockMusicRepository) GetAll(ctx context.Context) ([]*entity.MusicDB, error) {
+func (m *MockMusicRepository) GetAll(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
 	ret0, _ := ret[0].([]*entity.MusicDB)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockMusicRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+func (mr *MockMusicRepositoryMockRecorder) GetAll(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockMusicRepository)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockMusicRepository)(nil).GetAll), ctx, filter)
+}
+
+// GetAllSortByRating mocks base method.
+func (m *MockMusicRepository) GetAllSortByRating(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSortByRating", ctx, filter)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSortByRating indicates an expected call of GetAllSortByRating.
+func (mr *MockMusicRepositoryMockRecorder) GetAllSortByRating(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSortByRating", reflect.TypeOf((*MockMusicRepository)(nil).GetAllSortByRating), ctx, filter)
 }
 
 // GetAllSortByTime mocks base method.
-func (m *MockMusicRepository) GetAllSortByTime(ctx context.Context) ([]*entity.MusicDB, error) {
+func (m *MockMusicRepository) GetAllSortByTime(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllSortByTime", ctx)
+	ret := m.ctrl.Call(m, "GetAllSortByTime", ctx, filter)
 	ret0, _ := ret[0].([]*entity.MusicDB)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllSortByTime indicates an expected call of GetAllSortByTime.
-func (mr *MockMusicRepositoryMockRecorder) GetAllSortByTime(ctx interface{}) *gomock.Call {
+func (mr *MockMusicRepositoryMockRecorder) GetAllSortByTime(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSortByTime", reflect.TypeOf((*MockMusicRepository)(nil).GetAllSortByTime), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSortByTime", reflect.TypeOf((*MockMusicRepository)(nil).GetAllSortByTime), ctx, filter)
 }
 
-// GetAndSortByPopular mocks base method.
-func (m *MockMusicRepository) GetAndSortByPopular(ctx context.Context) ([]*entity.MusicDB, error) {
+// GetDuplicateCandidates mocks base method.
+func (m *MockMusicRepository) GetDuplicateCandidates(ctx context.Context, fingerprint *entity.MusicFingerprintDB) ([]*entity.DuplicateCandidateDB, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAndSortByPopular", ctx)
-	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret := m.ctrl.Call(m, "GetDuplicateCandidates", ctx, fingerprint)
+	ret0, _ := ret[0].([]*entity.DuplicateCandidateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicateCandidates indicates an expected call of GetDuplicateCandidates.
+func (mr *MockMusicRepositoryMockRecorder) GetDuplicateCandidates(ctx, fingerprint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicateCandidates", reflect.TypeOf((*MockMusicRepository)(nil).GetDuplicateCandidates), ctx, fingerprint)
+}
+
+// GetDuplicates mocks base method.
+func (m *MockMusicRepository) GetDuplicates(ctx context.Context, id uuid.UUID) ([]*entity.MusicDuplicateDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicates", ctx, id)
+	ret0, _ := ret[0].([]*entity.MusicDuplicateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicates indicates an expected call of GetDuplicates.
+func (mr *MockMusicRepositoryMockRecorder) GetDuplicates(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicates", reflect.TypeOf((*MockMusicRepository)(nil).GetDuplicates), ctx, id)
+}
+
+// Merge mocks base method.
+func (m *MockMusicRepository) Merge(ctx context.Context, id, intoId uuid.UUID, now time.Time) (*entity.MusicMergeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, id, intoId, now)
+	ret0, _ := ret[0].(*entity.MusicMergeResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAndSortByPopular indicates an expected call of GetAndSortByPopular.
-func (mr *MockMusicRepositoryMockRecorder) GetAndSortByPopular(ctx interface{}) *gomock.Call {
+// Merge indicates an expected call of Merge.
+func (mr *MockMusicRepositoryMockRecorder) Merge(ctx, id, intoId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockMusicRepository)(nil).Merge), ctx, id, intoId, now)
+}
+
+// SaveDuplicates mocks base method.
+func (m *MockMusicRepository) SaveDuplicates(ctx context.Context, duplicates []*entity.MusicDuplicateDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDuplicates", ctx, duplicates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDuplicates indicates an expected call of SaveDuplicates.
+func (mr *MockMusicRepositoryMockRecorder) SaveDuplicates(ctx, duplicates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDuplicates", reflect.TypeOf((*MockMusicRepository)(nil).SaveDuplicates), ctx, duplicates)
+}
+
+// SetExplicit mocks base method.
+func (m *MockMusicRepository) SetExplicit(ctx context.Context, id uuid.UUID, explicit bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExplicit", ctx, id, explicit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExplicit indicates an expected call of SetExplicit.
+func (mr *MockMusicRepositoryMockRecorder) SetExplicit(ctx, id, explicit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAndSortByPopular", reflect.TypeOf((*MockMusicRepository)(nil).GetAndSortByPopular), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExplicit", reflect.TypeOf((*MockMusicRepository)(nil).SetExplicit), ctx, id, explicit)
 }
 
 // Update mocks base method.
@@ -277,3 +483,2511 @@ func (mr *MockMusicRepositoryMockRecorder) Update(ctx, id, musicUpdate interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMusicRepository)(nil).Update), ctx, id, musicUpdate)
 }
+
+// MockPlayRepository is a mock of PlayRepository interface.
+type MockPlayRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlayRepositoryMockRecorder
+}
+
+// MockPlayRepositoryMockRecorder is the mock recorder for MockPlayRepository.
+type MockPlayRepositoryMockRecorder struct {
+	mock *MockPlayRepository
+}
+
+// NewMockPlayRepository creates a new mock instance.
+func NewMockPlayRepository(ctrl *gomock.Controller) *MockPlayRepository {
+	mock := &MockPlayRepository{ctrl: ctrl}
+	mock.recorder = &MockPlayRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlayRepository) EXPECT() *MockPlayRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPlayRepository) Create(ctx context.Context, event *entity.PlayEventDB) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPlayRepositoryMockRecorder) Create(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPlayRepository)(nil).Create), ctx, event)
+}
+
+// CreateBatch mocks base method.
+func (m *MockPlayRepository) CreateBatch(ctx context.Context, events []*entity.PlayEventDB) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, events)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockPlayRepositoryMockRecorder) CreateBatch(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockPlayRepository)(nil).CreateBatch), ctx, events)
+}
+
+// Delete mocks base method.
+func (m *MockPlayRepository) Delete(ctx context.Context, userId, eventId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, eventId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPlayRepositoryMockRecorder) Delete(ctx, userId, eventId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPlayRepository)(nil).Delete), ctx, userId, eventId)
+}
+
+// DeleteByUser mocks base method.
+func (m *MockPlayRepository) DeleteByUser(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUser", ctx, userId, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUser indicates an expected call of DeleteByUser.
+func (mr *MockPlayRepositoryMockRecorder) DeleteByUser(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUser", reflect.TypeOf((*MockPlayRepository)(nil).DeleteByUser), ctx, userId, filter)
+}
+
+// GetByUser mocks base method.
+func (m *MockPlayRepository) GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) ([]*entity.PlayEventDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, filter)
+	ret0, _ := ret[0].([]*entity.PlayEventDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockPlayRepositoryMockRecorder) GetByUser(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockPlayRepository)(nil).GetByUser), ctx, userId, filter)
+}
+
+// GetKnownMusicIDs mocks base method.
+func (m *MockPlayRepository) GetKnownMusicIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKnownMusicIDs", ctx, ids)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKnownMusicIDs indicates an expected call of GetKnownMusicIDs.
+func (mr *MockPlayRepositoryMockRecorder) GetKnownMusicIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKnownMusicIDs", reflect.TypeOf((*MockPlayRepository)(nil).GetKnownMusicIDs), ctx, ids)
+}
+
+// MockPopularityRepository is a mock of PopularityRepository interface.
+type MockPopularityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPopularityRepositoryMockRecorder
+}
+
+// MockPopularityRepositoryMockRecorder is the mock recorder for MockPopularityRepository.
+type MockPopularityRepositoryMockRecorder struct {
+	mock *MockPopularityRepository
+}
+
+// NewMockPopularityRepository creates a new mock instance.
+func NewMockPopularityRepository(ctrl *gomock.Controller) *MockPopularityRepository {
+	mock := &MockPopularityRepository{ctrl: ctrl}
+	mock.recorder = &MockPopularityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPopularityRepository) EXPECT() *MockPopularityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPopularityRepository) Get(ctx context.Context, window string, limit, offset int, hideExplicit bool) ([]*entity.MusicPopularityDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, window, limit, offset, hideExplicit)
+	ret0, _ := ret[0].([]*entity.MusicPopularityDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPopularityRepositoryMockRecorder) Get(ctx, window, limit, offset, hideExplicit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPopularityRepository)(nil).Get), ctx, window, limit, offset, hideExplicit)
+}
+
+// Refresh mocks base method.
+func (m *MockPopularityRepository) Refresh(ctx context.Context, cfg *entity.PopularityConfig, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, cfg, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockPopularityRepositoryMockRecorder) Refresh(ctx, cfg, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockPopularityRepository)(nil).Refresh), ctx, cfg, now)
+}
+
+// MockChartRepository is a mock of ChartRepository interface.
+type MockChartRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChartRepositoryMockRecorder
+}
+
+// MockChartRepositoryMockRecorder is the mock recorder for MockChartRepository.
+type MockChartRepositoryMockRecorder struct {
+	mock *MockChartRepository
+}
+
+// NewMockChartRepository creates a new mock instance.
+func NewMockChartRepository(ctrl *gomock.Controller) *MockChartRepository {
+	mock := &MockChartRepository{ctrl: ctrl}
+	mock.recorder = &MockChartRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChartRepository) EXPECT() *MockChartRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByMusic mocks base method.
+func (m *MockChartRepository) GetByMusic(ctx context.Context, musicId uuid.UUID) ([]*entity.ChartEntryDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMusic", ctx, musicId)
+	ret0, _ := ret[0].([]*entity.ChartEntryDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMusic indicates an expected call of GetByMusic.
+func (mr *MockChartRepositoryMockRecorder) GetByMusic(ctx, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMusic", reflect.TypeOf((*MockChartRepository)(nil).GetByMusic), ctx, musicId)
+}
+
+// GetByWeek mocks base method.
+func (m *MockChartRepository) GetByWeek(ctx context.Context, week time.Time) ([]*entity.ChartEntryDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByWeek", ctx, week)
+	ret0, _ := ret[0].([]*entity.ChartEntryDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByWeek indicates an expected call of GetByWeek.
+func (mr *MockChartRepositoryMockRecorder) GetByWeek(ctx, week interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByWeek", reflect.TypeOf((*MockChartRepository)(nil).GetByWeek), ctx, week)
+}
+
+// LatestWeek mocks base method.
+func (m *MockChartRepository) LatestWeek(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestWeek", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestWeek indicates an expected call of LatestWeek.
+func (mr *MockChartRepositoryMockRecorder) LatestWeek(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestWeek", reflect.TypeOf((*MockChartRepository)(nil).LatestWeek), ctx)
+}
+
+// Snapshot mocks base method.
+func (m *MockChartRepository) Snapshot(ctx context.Context, cfg *entity.ChartConfig, week time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, cfg, week)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockChartRepositoryMockRecorder) Snapshot(ctx, cfg, week interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockChartRepository)(nil).Snapshot), ctx, cfg, week)
+}
+
+// MockRecommendationRepository is a mock of RecommendationRepository interface.
+type MockRecommendationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationRepositoryMockRecorder
+}
+
+// MockRecommendationRepositoryMockRecorder is the mock recorder for MockRecommendationRepository.
+type MockRecommendationRepositoryMockRecorder struct {
+	mock *MockRecommendationRepository
+}
+
+// NewMockRecommendationRepository creates a new mock instance.
+func NewMockRecommendationRepository(ctrl *gomock.Controller) *MockRecommendationRepository {
+	mock := &MockRecommendationRepository{ctrl: ctrl}
+	mock.recorder = &MockRecommendationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationRepository) EXPECT() *MockRecommendationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetForUser mocks base method.
+func (m *MockRecommendationRepository) GetForUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.RecommendationDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.RecommendationDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUser indicates an expected call of GetForUser.
+func (mr *MockRecommendationRepositoryMockRecorder) GetForUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUser", reflect.TypeOf((*MockRecommendationRepository)(nil).GetForUser), ctx, userId, limit, offset)
+}
+
+// GetSimilar mocks base method.
+func (m *MockRecommendationRepository) GetSimilar(ctx context.Context, userId, musicId uuid.UUID, limit, offset int) ([]*entity.SimilarMusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSimilar", ctx, userId, musicId, limit, offset)
+	ret0, _ := ret[0].([]*entity.SimilarMusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimilar indicates an expected call of GetSimilar.
+func (mr *MockRecommendationRepositoryMockRecorder) GetSimilar(ctx, userId, musicId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimilar", reflect.TypeOf((*MockRecommendationRepository)(nil).GetSimilar), ctx, userId, musicId, limit, offset)
+}
+
+// Refresh mocks base method.
+func (m *MockRecommendationRepository) Refresh(ctx context.Context, cfg *entity.SimilarityConfig, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, cfg, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockRecommendationRepositoryMockRecorder) Refresh(ctx, cfg, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockRecommendationRepository)(nil).Refresh), ctx, cfg, now)
+}
+
+// MockRatingRepository is a mock of RatingRepository interface.
+type MockRatingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingRepositoryMockRecorder
+}
+
+// MockRatingRepositoryMockRecorder is the mock recorder for MockRatingRepository.
+type MockRatingRepositoryMockRecorder struct {
+	mock *MockRatingRepository
+}
+
+// NewMockRatingRepository creates a new mock instance.
+func NewMockRatingRepository(ctrl *gomock.Controller) *MockRatingRepository {
+	mock := &MockRatingRepository{ctrl: ctrl}
+	mock.recorder = &MockRatingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingRepository) EXPECT() *MockRatingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockRatingRepository) Clear(ctx context.Context, userId, musicId uuid.UUID) (*entity.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, userId, musicId)
+	ret0, _ := ret[0].(*entity.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockRatingRepositoryMockRecorder) Clear(ctx, userId, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockRatingRepository)(nil).Clear), ctx, userId, musicId)
+}
+
+// GetLibrary mocks base method.
+func (m *MockRatingRepository) GetLibrary(ctx context.Context, userId uuid.UUID, sort string) ([]*entity.LibraryMusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLibrary", ctx, userId, sort)
+	ret0, _ := ret[0].([]*entity.LibraryMusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLibrary indicates an expected call of GetLibrary.
+func (mr *MockRatingRepositoryMockRecorder) GetLibrary(ctx, userId, sort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLibrary", reflect.TypeOf((*MockRatingRepository)(nil).GetLibrary), ctx, userId, sort)
+}
+
+// Set mocks base method.
+func (m *MockRatingRepository) Set(ctx context.Context, userId, musicId uuid.UUID, rating int, now time.Time) (*entity.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, userId, musicId, rating, now)
+	ret0, _ := ret[0].(*entity.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRatingRepositoryMockRecorder) Set(ctx, userId, musicId, rating, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRatingRepository)(nil).Set), ctx, userId, musicId, rating, now)
+}
+
+// MockCommentRepository is a mock of CommentRepository interface.
+type MockCommentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentRepositoryMockRecorder
+}
+
+// MockCommentRepositoryMockRecorder is the mock recorder for MockCommentRepository.
+type MockCommentRepositoryMockRecorder struct {
+	mock *MockCommentRepository
+}
+
+// NewMockCommentRepository creates a new mock instance.
+func NewMockCommentRepository(ctrl *gomock.Controller) *MockCommentRepository {
+	mock := &MockCommentRepository{ctrl: ctrl}
+	mock.recorder = &MockCommentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentRepository) EXPECT() *MockCommentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentRepository) Create(ctx context.Context, comment *entity.CommentDB, rateLimit int, since time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comment, rateLimit, since)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentRepositoryMockRecorder) Create(ctx, comment, rateLimit, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentRepository)(nil).Create), ctx, comment, rateLimit, since)
+}
+
+// CreateReport mocks base method.
+func (m *MockCommentRepository) CreateReport(ctx context.Context, report *entity.CommentReportDB) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReport", ctx, report)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReport indicates an expected call of CreateReport.
+func (mr *MockCommentRepositoryMockRecorder) CreateReport(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReport", reflect.TypeOf((*MockCommentRepository)(nil).CreateReport), ctx, report)
+}
+
+// DismissReports mocks base method.
+func (m *MockCommentRepository) DismissReports(ctx context.Context, commentId, moderatorId uuid.UUID, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissReports", ctx, commentId, moderatorId, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DismissReports indicates an expected call of DismissReports.
+func (mr *MockCommentRepositoryMockRecorder) DismissReports(ctx, commentId, moderatorId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissReports", reflect.TypeOf((*MockCommentRepository)(nil).DismissReports), ctx, commentId, moderatorId, now)
+}
+
+// Get mocks base method.
+func (m *MockCommentRepository) Get(ctx context.Context, id uuid.UUID) (*entity.CommentDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.CommentDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCommentRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCommentRepository)(nil).Get), ctx, id)
+}
+
+// GetByMusic mocks base method.
+func (m *MockCommentRepository) GetByMusic(ctx context.Context, musicId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMusic", ctx, musicId, filter)
+	ret0, _ := ret[0].([]*entity.CommentDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMusic indicates an expected call of GetByMusic.
+func (mr *MockCommentRepositoryMockRecorder) GetByMusic(ctx, musicId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMusic", reflect.TypeOf((*MockCommentRepository)(nil).GetByMusic), ctx, musicId, filter)
+}
+
+// GetByPlaylist mocks base method.
+func (m *MockCommentRepository) GetByPlaylist(ctx context.Context, playlistId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPlaylist", ctx, playlistId, filter)
+	ret0, _ := ret[0].([]*entity.CommentDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPlaylist indicates an expected call of GetByPlaylist.
+func (mr *MockCommentRepositoryMockRecorder) GetByPlaylist(ctx, playlistId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPlaylist", reflect.TypeOf((*MockCommentRepository)(nil).GetByPlaylist), ctx, playlistId, filter)
+}
+
+// GetModerationQueue mocks base method.
+func (m *MockCommentRepository) GetModerationQueue(ctx context.Context, limit, offset int) ([]*entity.ModerationItemDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationQueue", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.ModerationItemDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationQueue indicates an expected call of GetModerationQueue.
+func (mr *MockCommentRepositoryMockRecorder) GetModerationQueue(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationQueue", reflect.TypeOf((*MockCommentRepository)(nil).GetModerationQueue), ctx, limit, offset)
+}
+
+// GetPlaylist mocks base method.
+func (m *MockCommentRepository) GetPlaylist(ctx context.Context, playlistId uuid.UUID) (*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylist", ctx, playlistId)
+	ret0, _ := ret[0].(*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylist indicates an expected call of GetPlaylist.
+func (mr *MockCommentRepositoryMockRecorder) GetPlaylist(ctx, playlistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylist", reflect.TypeOf((*MockCommentRepository)(nil).GetPlaylist), ctx, playlistId)
+}
+
+// GetReplies mocks base method.
+func (m *MockCommentRepository) GetReplies(ctx context.Context, parentId uuid.UUID, filter *entity.CommentFilter) ([]*entity.CommentDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplies", ctx, parentId, filter)
+	ret0, _ := ret[0].([]*entity.CommentDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplies indicates an expected call of GetReplies.
+func (mr *MockCommentRepositoryMockRecorder) GetReplies(ctx, parentId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplies", reflect.TypeOf((*MockCommentRepository)(nil).GetReplies), ctx, parentId, filter)
+}
+
+// MusicVisible mocks base method.
+func (m *MockCommentRepository) MusicVisible(ctx context.Context, musicId uuid.UUID, showUnpublished bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MusicVisible", ctx, musicId, showUnpublished)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MusicVisible indicates an expected call of MusicVisible.
+func (mr *MockCommentRepositoryMockRecorder) MusicVisible(ctx, musicId, showUnpublished interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MusicVisible", reflect.TypeOf((*MockCommentRepository)(nil).MusicVisible), ctx, musicId, showUnpublished)
+}
+
+// Notify mocks base method.
+func (m *MockCommentRepository) Notify(ctx context.Context, notification *entity.NotificationDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockCommentRepositoryMockRecorder) Notify(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockCommentRepository)(nil).Notify), ctx, notification)
+}
+
+// SetStatus mocks base method.
+func (m *MockCommentRepository) SetStatus(ctx context.Context, id uuid.UUID, status string, moderatorId *uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, status, moderatorId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockCommentRepositoryMockRecorder) SetStatus(ctx, id, status, moderatorId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockCommentRepository)(nil).SetStatus), ctx, id, status, moderatorId, now)
+}
+
+// Update mocks base method.
+func (m *MockCommentRepository) Update(ctx context.Context, id uuid.UUID, body string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, body, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentRepositoryMockRecorder) Update(ctx, id, body, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommentRepository)(nil).Update), ctx, id, body, now)
+}
+
+// MockLyricsRepository is a mock of LyricsRepository interface.
+type MockLyricsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLyricsRepositoryMockRecorder
+}
+
+// MockLyricsRepositoryMockRecorder is the mock recorder for MockLyricsRepository.
+type MockLyricsRepositoryMockRecorder struct {
+	mock *MockLyricsRepository
+}
+
+// NewMockLyricsRepository creates a new mock instance.
+func NewMockLyricsRepository(ctrl *gomock.Controller) *MockLyricsRepository {
+	mock := &MockLyricsRepository{ctrl: ctrl}
+	mock.recorder = &MockLyricsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLyricsRepository) EXPECT() *MockLyricsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockLyricsRepository) Delete(ctx context.Context, musicId uuid.UUID, language string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, musicId, language)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLyricsRepositoryMockRecorder) Delete(ctx, musicId, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLyricsRepository)(nil).Delete), ctx, musicId, language)
+}
+
+// GetByMusic mocks base method.
+func (m *MockLyricsRepository) GetByMusic(ctx context.Context, musicId uuid.UUID, showUnpublished bool) ([]*entity.LyricsDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMusic", ctx, musicId, showUnpublished)
+	ret0, _ := ret[0].([]*entity.LyricsDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMusic indicates an expected call of GetByMusic.
+func (mr *MockLyricsRepositoryMockRecorder) GetByMusic(ctx, musicId, showUnpublished interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMusic", reflect.TypeOf((*MockLyricsRepository)(nil).GetByMusic), ctx, musicId, showUnpublished)
+}
+
+// Upsert mocks base method.
+func (m *MockLyricsRepository) Upsert(ctx context.Context, lyrics *entity.LyricsDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, lyrics)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockLyricsRepositoryMockRecorder) Upsert(ctx, lyrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockLyricsRepository)(nil).Upsert), ctx, lyrics)
+}
+
+// MockMusicRevisionRepository is a mock of MusicRevisionRepository interface.
+type MockMusicRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMusicRevisionRepositoryMockRecorder
+}
+
+// MockMusicRevisionRepositoryMockRecorder is the mock recorder for MockMusicRevisionRepository.
+type MockMusicRevisionRepositoryMockRecorder struct {
+	mock *MockMusicRevisionRepository
+}
+
+// NewMockMusicRevisionRepository creates a new mock instance.
+func NewMockMusicRevisionRepository(ctrl *gomock.Controller) *MockMusicRevisionRepository {
+	mock := &MockMusicRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockMusicRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMusicRevisionRepository) EXPECT() *MockMusicRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockMusicRevisionRepository) Get(ctx context.Context, musicId uuid.UUID, version int) (*entity.MusicRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, musicId, version)
+	ret0, _ := ret[0].(*entity.MusicRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMusicRevisionRepositoryMockRecorder) Get(ctx, musicId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMusicRevisionRepository)(nil).Get), ctx, musicId, version)
+}
+
+// GetByMusic mocks base method.
+func (m *MockMusicRevisionRepository) GetByMusic(ctx context.Context, musicId uuid.UUID, limit, offset int) ([]*entity.MusicRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMusic", ctx, musicId, limit, offset)
+	ret0, _ := ret[0].([]*entity.MusicRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMusic indicates an expected call of GetByMusic.
+func (mr *MockMusicRevisionRepositoryMockRecorder) GetByMusic(ctx, musicId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMusic", reflect.TypeOf((*MockMusicRevisionRepository)(nil).GetByMusic), ctx, musicId, limit, offset)
+}
+
+// GetLatest mocks base method.
+func (m *MockMusicRevisionRepository) GetLatest(ctx context.Context, musicId uuid.UUID) (*entity.MusicRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatest", ctx, musicId)
+	ret0, _ := ret[0].(*entity.MusicRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockMusicRevisionRepositoryMockRecorder) GetLatest(ctx, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockMusicRevisionRepository)(nil).GetLatest), ctx, musicId)
+}
+
+// Revert mocks base method.
+func (m *MockMusicRevisionRepository) Revert(ctx context.Context, target, current, revision *entity.MusicRevisionDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, target, current, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockMusicRevisionRepositoryMockRecorder) Revert(ctx, target, current, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockMusicRevisionRepository)(nil).Revert), ctx, target, current, revision)
+}
+
+// MockTrashRepository is a mock of TrashRepository interface.
+type MockTrashRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashRepositoryMockRecorder
+}
+
+// MockTrashRepositoryMockRecorder is the mock recorder for MockTrashRepository.
+type MockTrashRepositoryMockRecorder struct {
+	mock *MockTrashRepository
+}
+
+// NewMockTrashRepository creates a new mock instance.
+func NewMockTrashRepository(ctrl *gomock.Controller) *MockTrashRepository {
+	mock := &MockTrashRepository{ctrl: ctrl}
+	mock.recorder = &MockTrashRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashRepository) EXPECT() *MockTrashRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetMusic mocks base method.
+func (m *MockTrashRepository) GetMusic(ctx context.Context, limit, offset int) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMusic", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMusic indicates an expected call of GetMusic.
+func (mr *MockTrashRepositoryMockRecorder) GetMusic(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMusic", reflect.TypeOf((*MockTrashRepository)(nil).GetMusic), ctx, limit, offset)
+}
+
+// GetUsers mocks base method.
+func (m *MockTrashRepository) GetUsers(ctx context.Context, limit, offset int) ([]*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockTrashRepositoryMockRecorder) GetUsers(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockTrashRepository)(nil).GetUsers), ctx, limit, offset)
+}
+
+// Purge mocks base method.
+func (m *MockTrashRepository) Purge(ctx context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTrashRepositoryMockRecorder) Purge(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTrashRepository)(nil).Purge), ctx, before)
+}
+
+// RestoreMusic mocks base method.
+func (m *MockTrashRepository) RestoreMusic(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMusic", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMusic indicates an expected call of RestoreMusic.
+func (mr *MockTrashRepositoryMockRecorder) RestoreMusic(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMusic", reflect.TypeOf((*MockTrashRepository)(nil).RestoreMusic), ctx, id)
+}
+
+// RestoreUser mocks base method.
+func (m *MockTrashRepository) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockTrashRepositoryMockRecorder) RestoreUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockTrashRepository)(nil).RestoreUser), ctx, id)
+}
+
+// MockReleaseRepository is a mock of ReleaseRepository interface.
+type MockReleaseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReleaseRepositoryMockRecorder
+}
+
+// MockReleaseRepositoryMockRecorder is the mock recorder for MockReleaseRepository.
+type MockReleaseRepositoryMockRecorder struct {
+	mock *MockReleaseRepository
+}
+
+// NewMockReleaseRepository creates a new mock instance.
+func NewMockReleaseRepository(ctrl *gomock.Controller) *MockReleaseRepository {
+	mock := &MockReleaseRepository{ctrl: ctrl}
+	mock.recorder = &MockReleaseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReleaseRepository) EXPECT() *MockReleaseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetScheduled mocks base method.
+func (m *MockReleaseRepository) GetScheduled(ctx context.Context, limit, offset int) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduled", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduled indicates an expected call of GetScheduled.
+func (mr *MockReleaseRepositoryMockRecorder) GetScheduled(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduled", reflect.TypeOf((*MockReleaseRepository)(nil).GetScheduled), ctx, limit, offset)
+}
+
+// PublishDue mocks base method.
+func (m *MockReleaseRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDue", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDue indicates an expected call of PublishDue.
+func (mr *MockReleaseRepositoryMockRecorder) PublishDue(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDue", reflect.TypeOf((*MockReleaseRepository)(nil).PublishDue), ctx, now)
+}
+
+// SetSchedule mocks base method.
+func (m *MockReleaseRepository) SetSchedule(ctx context.Context, id uuid.UUID, schedule *entity.MusicSchedule, now time.Time) (*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchedule", ctx, id, schedule, now)
+	ret0, _ := ret[0].(*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSchedule indicates an expected call of SetSchedule.
+func (mr *MockReleaseRepositoryMockRecorder) SetSchedule(ctx, id, schedule, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedule", reflect.TypeOf((*MockReleaseRepository)(nil).SetSchedule), ctx, id, schedule, now)
+}
+
+// MockArtistRepository is a mock of ArtistRepository interface.
+type MockArtistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtistRepositoryMockRecorder
+}
+
+// MockArtistRepositoryMockRecorder is the mock recorder for MockArtistRepository.
+type MockArtistRepositoryMockRecorder struct {
+	mock *MockArtistRepository
+}
+
+// NewMockArtistRepository creates a new mock instance.
+func NewMockArtistRepository(ctrl *gomock.Controller) *MockArtistRepository {
+	mock := &MockArtistRepository{ctrl: ctrl}
+	mock.recorder = &MockArtistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtistRepository) EXPECT() *MockArtistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockArtistRepository) Create(ctx context.Context, artist *entity.ArtistDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, artist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArtistRepositoryMockRecorder) Create(ctx, artist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArtistRepository)(nil).Create), ctx, artist)
+}
+
+// Get mocks base method.
+func (m *MockArtistRepository) Get(ctx context.Context, id uuid.UUID) (*entity.ArtistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.ArtistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockArtistRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArtistRepository)(nil).Get), ctx, id)
+}
+
+// SetMusicArtist mocks base method.
+func (m *MockArtistRepository) SetMusicArtist(ctx context.Context, musicId uuid.UUID, artistId *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMusicArtist", ctx, musicId, artistId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMusicArtist indicates an expected call of SetMusicArtist.
+func (mr *MockArtistRepositoryMockRecorder) SetMusicArtist(ctx, musicId, artistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMusicArtist", reflect.TypeOf((*MockArtistRepository)(nil).SetMusicArtist), ctx, musicId, artistId)
+}
+
+// MockPlaylistRepository is a mock of PlaylistRepository interface.
+type MockPlaylistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaylistRepositoryMockRecorder
+}
+
+// MockPlaylistRepositoryMockRecorder is the mock recorder for MockPlaylistRepository.
+type MockPlaylistRepositoryMockRecorder struct {
+	mock *MockPlaylistRepository
+}
+
+// NewMockPlaylistRepository creates a new mock instance.
+func NewMockPlaylistRepository(ctrl *gomock.Controller) *MockPlaylistRepository {
+	mock := &MockPlaylistRepository{ctrl: ctrl}
+	mock.recorder = &MockPlaylistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaylistRepository) EXPECT() *MockPlaylistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddTrack mocks base method.
+func (m *MockPlaylistRepository) AddTrack(ctx context.Context, id, musicId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTrack", ctx, id, musicId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTrack indicates an expected call of AddTrack.
+func (mr *MockPlaylistRepositoryMockRecorder) AddTrack(ctx, id, musicId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrack", reflect.TypeOf((*MockPlaylistRepository)(nil).AddTrack), ctx, id, musicId, now)
+}
+
+// Create mocks base method.
+func (m *MockPlaylistRepository) Create(ctx context.Context, playlist *entity.PlaylistDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, playlist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPlaylistRepositoryMockRecorder) Create(ctx, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPlaylistRepository)(nil).Create), ctx, playlist)
+}
+
+// Delete mocks base method.
+func (m *MockPlaylistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPlaylistRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPlaylistRepository)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockPlaylistRepository) Get(ctx context.Context, id uuid.UUID) (*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPlaylistRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPlaylistRepository)(nil).Get), ctx, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockPlaylistRepository) GetByUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockPlaylistRepositoryMockRecorder) GetByUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockPlaylistRepository)(nil).GetByUser), ctx, userId, limit, offset)
+}
+
+// GetTracks mocks base method.
+func (m *MockPlaylistRepository) GetTracks(ctx context.Context, id, viewerId uuid.UUID) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracks", ctx, id, viewerId)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTracks indicates an expected call of GetTracks.
+func (mr *MockPlaylistRepositoryMockRecorder) GetTracks(ctx, id, viewerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracks", reflect.TypeOf((*MockPlaylistRepository)(nil).GetTracks), ctx, id, viewerId)
+}
+
+// RemoveTrack mocks base method.
+func (m *MockPlaylistRepository) RemoveTrack(ctx context.Context, id, musicId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTrack", ctx, id, musicId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTrack indicates an expected call of RemoveTrack.
+func (mr *MockPlaylistRepositoryMockRecorder) RemoveTrack(ctx, id, musicId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrack", reflect.TypeOf((*MockPlaylistRepository)(nil).RemoveTrack), ctx, id, musicId, now)
+}
+
+// Update mocks base method.
+func (m *MockPlaylistRepository) Update(ctx context.Context, playlist *entity.PlaylistDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, playlist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPlaylistRepositoryMockRecorder) Update(ctx, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPlaylistRepository)(nil).Update), ctx, playlist)
+}
+
+// MockFollowRepository is a mock of FollowRepository interface.
+type MockFollowRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFollowRepositoryMockRecorder
+}
+
+// MockFollowRepositoryMockRecorder is the mock recorder for MockFollowRepository.
+type MockFollowRepositoryMockRecorder struct {
+	mock *MockFollowRepository
+}
+
+// NewMockFollowRepository creates a new mock instance.
+func NewMockFollowRepository(ctrl *gomock.Controller) *MockFollowRepository {
+	mock := &MockFollowRepository{ctrl: ctrl}
+	mock.recorder = &MockFollowRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFollowRepository) EXPECT() *MockFollowRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FollowArtist mocks base method.
+func (m *MockFollowRepository) FollowArtist(ctx context.Context, userId, artistId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowArtist", ctx, userId, artistId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowArtist indicates an expected call of FollowArtist.
+func (mr *MockFollowRepositoryMockRecorder) FollowArtist(ctx, userId, artistId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowArtist", reflect.TypeOf((*MockFollowRepository)(nil).FollowArtist), ctx, userId, artistId, now)
+}
+
+// FollowUser mocks base method.
+func (m *MockFollowRepository) FollowUser(ctx context.Context, followerId, followeeId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowUser", ctx, followerId, followeeId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowUser indicates an expected call of FollowUser.
+func (mr *MockFollowRepositoryMockRecorder) FollowUser(ctx, followerId, followeeId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockFollowRepository)(nil).FollowUser), ctx, followerId, followeeId, now)
+}
+
+// GetFeed mocks base method.
+func (m *MockFollowRepository) GetFeed(ctx context.Context, userId uuid.UUID, filter *entity.FeedFilter) ([]*entity.FeedItemDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, userId, filter)
+	ret0, _ := ret[0].([]*entity.FeedItemDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockFollowRepositoryMockRecorder) GetFeed(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockFollowRepository)(nil).GetFeed), ctx, userId, filter)
+}
+
+// GetFollowing mocks base method.
+func (m *MockFollowRepository) GetFollowing(ctx context.Context, userId uuid.UUID) (*entity.Following, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", ctx, userId)
+	ret0, _ := ret[0].(*entity.Following)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *MockFollowRepositoryMockRecorder) GetFollowing(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*MockFollowRepository)(nil).GetFollowing), ctx, userId)
+}
+
+// SetPrivacy mocks base method.
+func (m *MockFollowRepository) SetPrivacy(ctx context.Context, userId uuid.UUID, privacy *entity.FeedPrivacy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrivacy", ctx, userId, privacy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrivacy indicates an expected call of SetPrivacy.
+func (mr *MockFollowRepositoryMockRecorder) SetPrivacy(ctx, userId, privacy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrivacy", reflect.TypeOf((*MockFollowRepository)(nil).SetPrivacy), ctx, userId, privacy)
+}
+
+// UnfollowArtist mocks base method.
+func (m *MockFollowRepository) UnfollowArtist(ctx context.Context, userId, artistId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowArtist", ctx, userId, artistId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowArtist indicates an expected call of UnfollowArtist.
+func (mr *MockFollowRepositoryMockRecorder) UnfollowArtist(ctx, userId, artistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowArtist", reflect.TypeOf((*MockFollowRepository)(nil).UnfollowArtist), ctx, userId, artistId)
+}
+
+// UnfollowUser mocks base method.
+func (m *MockFollowRepository) UnfollowUser(ctx context.Context, followerId, followeeId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowUser", ctx, followerId, followeeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowUser indicates an expected call of UnfollowUser.
+func (mr *MockFollowRepositoryMockRecorder) UnfollowUser(ctx, followerId, followeeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowUser", reflect.TypeOf((*MockFollowRepository)(nil).UnfollowUser), ctx, followerId, followeeId)
+}
+
+// MockNotificationRepository is a mock of NotificationRepository interface.
+type MockNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryMockRecorder
+}
+
+// MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
+type MockNotificationRepositoryMockRecorder struct {
+	mock *MockNotificationRepository
+}
+
+// NewMockNotificationRepository creates a new mock instance.
+func NewMockNotificationRepository(ctrl *gomock.Controller) *MockNotificationRepository {
+	mock := &MockNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockNotificationRepository) CountUnread(ctx context.Context, userId uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", ctx, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationRepositoryMockRecorder) CountUnread(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotificationRepository)(nil).CountUnread), ctx, userId)
+}
+
+// CreateReleases mocks base method.
+func (m *MockNotificationRepository) CreateReleases(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReleases", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReleases indicates an expected call of CreateReleases.
+func (mr *MockNotificationRepositoryMockRecorder) CreateReleases(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReleases", reflect.TypeOf((*MockNotificationRepository)(nil).CreateReleases), ctx, now)
+}
+
+// Deliver mocks base method.
+func (m *MockNotificationRepository) Deliver(ctx context.Context, delivery *entity.NotificationDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockNotificationRepositoryMockRecorder) Deliver(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockNotificationRepository)(nil).Deliver), ctx, delivery)
+}
+
+// GetByUser mocks base method.
+func (m *MockNotificationRepository) GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.NotificationFilter) ([]*entity.NotificationDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, filter)
+	ret0, _ := ret[0].([]*entity.NotificationDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockNotificationRepositoryMockRecorder) GetByUser(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockNotificationRepository)(nil).GetByUser), ctx, userId, filter)
+}
+
+// GetSettings mocks base method.
+func (m *MockNotificationRepository) GetSettings(ctx context.Context, userId uuid.UUID) (*entity.NotificationSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, userId)
+	ret0, _ := ret[0].(*entity.NotificationSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockNotificationRepositoryMockRecorder) GetSettings(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockNotificationRepository)(nil).GetSettings), ctx, userId)
+}
+
+// GetUndelivered mocks base method.
+func (m *MockNotificationRepository) GetUndelivered(ctx context.Context, limit int) ([]*entity.NotificationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUndelivered", ctx, limit)
+	ret0, _ := ret[0].([]*entity.NotificationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUndelivered indicates an expected call of GetUndelivered.
+func (mr *MockNotificationRepositoryMockRecorder) GetUndelivered(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUndelivered", reflect.TypeOf((*MockNotificationRepository)(nil).GetUndelivered), ctx, limit)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotificationRepository) MarkAllRead(ctx context.Context, userId uuid.UUID, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx, userId, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationRepositoryMockRecorder) MarkAllRead(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotificationRepository)(nil).MarkAllRead), ctx, userId, now)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockNotificationRepository) MarkDelivered(ctx context.Context, id uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockNotificationRepositoryMockRecorder) MarkDelivered(ctx, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockNotificationRepository)(nil).MarkDelivered), ctx, id, now)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationRepository) MarkRead(ctx context.Context, userId, id uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userId, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationRepositoryMockRecorder) MarkRead(ctx, userId, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationRepository)(nil).MarkRead), ctx, userId, id, now)
+}
+
+// SetSettings mocks base method.
+func (m *MockNotificationRepository) SetSettings(ctx context.Context, userId uuid.UUID, settings *entity.NotificationSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSettings", ctx, userId, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSettings indicates an expected call of SetSettings.
+func (mr *MockNotificationRepositoryMockRecorder) SetSettings(ctx, userId, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSettings", reflect.TypeOf((*MockNotificationRepository)(nil).SetSettings), ctx, userId, settings)
+}
+
+// MockShareRepository is a mock of ShareRepository interface.
+type MockShareRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareRepositoryMockRecorder
+}
+
+// MockShareRepositoryMockRecorder is the mock recorder for MockShareRepository.
+type MockShareRepositoryMockRecorder struct {
+	mock *MockShareRepository
+}
+
+// NewMockShareRepository creates a new mock instance.
+func NewMockShareRepository(ctrl *gomock.Controller) *MockShareRepository {
+	mock := &MockShareRepository{ctrl: ctrl}
+	mock.recorder = &MockShareRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareRepository) EXPECT() *MockShareRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumePlay mocks base method.
+func (m *MockShareRepository) ConsumePlay(ctx context.Context, id uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumePlay", ctx, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumePlay indicates an expected call of ConsumePlay.
+func (mr *MockShareRepositoryMockRecorder) ConsumePlay(ctx, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumePlay", reflect.TypeOf((*MockShareRepository)(nil).ConsumePlay), ctx, id, now)
+}
+
+// Create mocks base method.
+func (m *MockShareRepository) Create(ctx context.Context, link *entity.ShareLinkDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShareRepositoryMockRecorder) Create(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShareRepository)(nil).Create), ctx, link)
+}
+
+// GetByTokenHash mocks base method.
+func (m *MockShareRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.ShareLinkDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.ShareLinkDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTokenHash indicates an expected call of GetByTokenHash.
+func (mr *MockShareRepositoryMockRecorder) GetByTokenHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTokenHash", reflect.TypeOf((*MockShareRepository)(nil).GetByTokenHash), ctx, tokenHash)
+}
+
+// GetByUser mocks base method.
+func (m *MockShareRepository) GetByUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.ShareLinkDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.ShareLinkDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockShareRepositoryMockRecorder) GetByUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockShareRepository)(nil).GetByUser), ctx, userId, limit, offset)
+}
+
+// GetMusic mocks base method.
+func (m *MockShareRepository) GetMusic(ctx context.Context, musicId uuid.UUID) (*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMusic", ctx, musicId)
+	ret0, _ := ret[0].(*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMusic indicates an expected call of GetMusic.
+func (mr *MockShareRepositoryMockRecorder) GetMusic(ctx, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMusic", reflect.TypeOf((*MockShareRepository)(nil).GetMusic), ctx, musicId)
+}
+
+// GetPlaylist mocks base method.
+func (m *MockShareRepository) GetPlaylist(ctx context.Context, playlistId uuid.UUID) (*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylist", ctx, playlistId)
+	ret0, _ := ret[0].(*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylist indicates an expected call of GetPlaylist.
+func (mr *MockShareRepositoryMockRecorder) GetPlaylist(ctx, playlistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylist", reflect.TypeOf((*MockShareRepository)(nil).GetPlaylist), ctx, playlistId)
+}
+
+// GetPlaylistTracks mocks base method.
+func (m *MockShareRepository) GetPlaylistTracks(ctx context.Context, playlistId uuid.UUID) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylistTracks", ctx, playlistId)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylistTracks indicates an expected call of GetPlaylistTracks.
+func (mr *MockShareRepositoryMockRecorder) GetPlaylistTracks(ctx, playlistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylistTracks", reflect.TypeOf((*MockShareRepository)(nil).GetPlaylistTracks), ctx, playlistId)
+}
+
+// RecordAccess mocks base method.
+func (m *MockShareRepository) RecordAccess(ctx context.Context, access *entity.ShareAccessDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAccess", ctx, access)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAccess indicates an expected call of RecordAccess.
+func (mr *MockShareRepositoryMockRecorder) RecordAccess(ctx, access interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAccess", reflect.TypeOf((*MockShareRepository)(nil).RecordAccess), ctx, access)
+}
+
+// Revoke mocks base method.
+func (m *MockShareRepository) Revoke(ctx context.Context, userId, id uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userId, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockShareRepositoryMockRecorder) Revoke(ctx, userId, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockShareRepository)(nil).Revoke), ctx, userId, id, now)
+}
+
+// MockStatsRepository is a mock of StatsRepository interface.
+type MockStatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsRepositoryMockRecorder
+}
+
+// MockStatsRepositoryMockRecorder is the mock recorder for MockStatsRepository.
+type MockStatsRepositoryMockRecorder struct {
+	mock *MockStatsRepository
+}
+
+// NewMockStatsRepository creates a new mock instance.
+func NewMockStatsRepository(ctrl *gomock.Controller) *MockStatsRepository {
+	mock := &MockStatsRepository{ctrl: ctrl}
+	mock.recorder = &MockStatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsRepository) EXPECT() *MockStatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetReview mocks base method.
+func (m *MockStatsRepository) GetReview(ctx context.Context, userId uuid.UUID, year int) (*entity.YearReviewDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", ctx, userId, year)
+	ret0, _ := ret[0].(*entity.YearReviewDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockStatsRepositoryMockRecorder) GetReview(ctx, userId, year interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockStatsRepository)(nil).GetReview), ctx, userId, year)
+}
+
+// GetStats mocks base method.
+func (m *MockStatsRepository) GetStats(ctx context.Context, userId uuid.UUID, filter *entity.StatsFilter) (*entity.ListeningStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, userId, filter)
+	ret0, _ := ret[0].(*entity.ListeningStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockStatsRepositoryMockRecorder) GetStats(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockStatsRepository)(nil).GetStats), ctx, userId, filter)
+}
+
+// GetUsersWithoutReview mocks base method.
+func (m *MockStatsRepository) GetUsersWithoutReview(ctx context.Context, year int, afterId uuid.UUID, now time.Time, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersWithoutReview", ctx, year, afterId, now, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersWithoutReview indicates an expected call of GetUsersWithoutReview.
+func (mr *MockStatsRepositoryMockRecorder) GetUsersWithoutReview(ctx, year, afterId, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithoutReview", reflect.TypeOf((*MockStatsRepository)(nil).GetUsersWithoutReview), ctx, year, afterId, now, limit)
+}
+
+// RecordReviewFailure mocks base method.
+func (m *MockStatsRepository) RecordReviewFailure(ctx context.Context, failure *entity.YearReviewFailure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordReviewFailure", ctx, failure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordReviewFailure indicates an expected call of RecordReviewFailure.
+func (mr *MockStatsRepositoryMockRecorder) RecordReviewFailure(ctx, failure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordReviewFailure", reflect.TypeOf((*MockStatsRepository)(nil).RecordReviewFailure), ctx, failure)
+}
+
+// SaveReview mocks base method.
+func (m *MockStatsRepository) SaveReview(ctx context.Context, review *entity.YearReviewDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReview", ctx, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveReview indicates an expected call of SaveReview.
+func (mr *MockStatsRepositoryMockRecorder) SaveReview(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReview", reflect.TypeOf((*MockStatsRepository)(nil).SaveReview), ctx, review)
+}
+
+// MockExportRepository is a mock of ExportRepository interface.
+type MockExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportRepositoryMockRecorder
+}
+
+// MockExportRepositoryMockRecorder is the mock recorder for MockExportRepository.
+type MockExportRepositoryMockRecorder struct {
+	mock *MockExportRepository
+}
+
+// NewMockExportRepository creates a new mock instance.
+func NewMockExportRepository(ctrl *gomock.Controller) *MockExportRepository {
+	mock := &MockExportRepository{ctrl: ctrl}
+	mock.recorder = &MockExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportRepository) EXPECT() *MockExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimJobs mocks base method.
+func (m *MockExportRepository) ClaimJobs(ctx context.Context, now, staleBefore time.Time, limit int) ([]*entity.ExportJobDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJobs", ctx, now, staleBefore, limit)
+	ret0, _ := ret[0].([]*entity.ExportJobDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJobs indicates an expected call of ClaimJobs.
+func (mr *MockExportRepositoryMockRecorder) ClaimJobs(ctx, now, staleBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJobs", reflect.TypeOf((*MockExportRepository)(nil).ClaimJobs), ctx, now, staleBefore, limit)
+}
+
+// CountItems mocks base method.
+func (m *MockExportRepository) CountItems(ctx context.Context, userId uuid.UUID, request *entity.ExportRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountItems", ctx, userId, request)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountItems indicates an expected call of CountItems.
+func (mr *MockExportRepositoryMockRecorder) CountItems(ctx, userId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountItems", reflect.TypeOf((*MockExportRepository)(nil).CountItems), ctx, userId, request)
+}
+
+// CreateJob mocks base method.
+func (m *MockExportRepository) CreateJob(ctx context.Context, job *entity.ExportJobDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockExportRepositoryMockRecorder) CreateJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockExportRepository)(nil).CreateJob), ctx, job)
+}
+
+// DeleteExpiredJobs mocks base method.
+func (m *MockExportRepository) DeleteExpiredJobs(ctx context.Context, now time.Time, limit int) ([]*entity.ExportJobDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredJobs", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.ExportJobDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredJobs indicates an expected call of DeleteExpiredJobs.
+func (mr *MockExportRepositoryMockRecorder) DeleteExpiredJobs(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredJobs", reflect.TypeOf((*MockExportRepository)(nil).DeleteExpiredJobs), ctx, now, limit)
+}
+
+// FinishJob mocks base method.
+func (m *MockExportRepository) FinishJob(ctx context.Context, job *entity.ExportJobDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishJob indicates an expected call of FinishJob.
+func (mr *MockExportRepositoryMockRecorder) FinishJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishJob", reflect.TypeOf((*MockExportRepository)(nil).FinishJob), ctx, job)
+}
+
+// GetHistory mocks base method.
+func (m *MockExportRepository) GetHistory(ctx context.Context, userId uuid.UUID, afterAt time.Time, afterId uuid.UUID, limit int) ([]*entity.ExportPlayDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, userId, afterAt, afterId, limit)
+	ret0, _ := ret[0].([]*entity.ExportPlayDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockExportRepositoryMockRecorder) GetHistory(ctx, userId, afterAt, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockExportRepository)(nil).GetHistory), ctx, userId, afterAt, afterId, limit)
+}
+
+// GetJob mocks base method.
+func (m *MockExportRepository) GetJob(ctx context.Context, id uuid.UUID) (*entity.ExportJobDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, id)
+	ret0, _ := ret[0].(*entity.ExportJobDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockExportRepositoryMockRecorder) GetJob(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockExportRepository)(nil).GetJob), ctx, id)
+}
+
+// GetLikes mocks base method.
+func (m *MockExportRepository) GetLikes(ctx context.Context, userId uuid.UUID) ([]*entity.ExportLikeDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikes", ctx, userId)
+	ret0, _ := ret[0].([]*entity.ExportLikeDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikes indicates an expected call of GetLikes.
+func (mr *MockExportRepositoryMockRecorder) GetLikes(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikes", reflect.TypeOf((*MockExportRepository)(nil).GetLikes), ctx, userId)
+}
+
+// GetPlaylistTracks mocks base method.
+func (m *MockExportRepository) GetPlaylistTracks(ctx context.Context, playlistId uuid.UUID) ([]*entity.ExportPlaylistTrackDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylistTracks", ctx, playlistId)
+	ret0, _ := ret[0].([]*entity.ExportPlaylistTrackDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylistTracks indicates an expected call of GetPlaylistTracks.
+func (mr *MockExportRepositoryMockRecorder) GetPlaylistTracks(ctx, playlistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylistTracks", reflect.TypeOf((*MockExportRepository)(nil).GetPlaylistTracks), ctx, playlistId)
+}
+
+// GetPlaylists mocks base method.
+func (m *MockExportRepository) GetPlaylists(ctx context.Context, userId uuid.UUID, playlistId *uuid.UUID) ([]*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylists", ctx, userId, playlistId)
+	ret0, _ := ret[0].([]*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylists indicates an expected call of GetPlaylists.
+func (mr *MockExportRepositoryMockRecorder) GetPlaylists(ctx, userId, playlistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylists", reflect.TypeOf((*MockExportRepository)(nil).GetPlaylists), ctx, userId, playlistId)
+}
+
+// GetRatings mocks base method.
+func (m *MockExportRepository) GetRatings(ctx context.Context, userId uuid.UUID) ([]*entity.ExportRatingDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatings", ctx, userId)
+	ret0, _ := ret[0].([]*entity.ExportRatingDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatings indicates an expected call of GetRatings.
+func (mr *MockExportRepositoryMockRecorder) GetRatings(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatings", reflect.TypeOf((*MockExportRepository)(nil).GetRatings), ctx, userId)
+}
+
+// RemoveFile mocks base method.
+func (m *MockExportRepository) RemoveFile(job *entity.ExportJobDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFile", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFile indicates an expected call of RemoveFile.
+func (mr *MockExportRepositoryMockRecorder) RemoveFile(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFile", reflect.TypeOf((*MockExportRepository)(nil).RemoveFile), job)
+}
+
+// WriteFile mocks base method.
+func (m *MockExportRepository) WriteFile(job *entity.ExportJobDB, write func(io.Writer) error) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteFile", job, write)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteFile indicates an expected call of WriteFile.
+func (mr *MockExportRepositoryMockRecorder) WriteFile(job, write interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteFile", reflect.TypeOf((*MockExportRepository)(nil).WriteFile), job, write)
+}
+
+// MockPlaylistImportRepository is a mock of PlaylistImportRepository interface.
+type MockPlaylistImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaylistImportRepositoryMockRecorder
+}
+
+// MockPlaylistImportRepositoryMockRecorder is the mock recorder for MockPlaylistImportRepository.
+type MockPlaylistImportRepositoryMockRecorder struct {
+	mock *MockPlaylistImportRepository
+}
+
+// NewMockPlaylistImportRepository creates a new mock instance.
+func NewMockPlaylistImportRepository(ctrl *gomock.Controller) *MockPlaylistImportRepository {
+	mock := &MockPlaylistImportRepository{ctrl: ctrl}
+	mock.recorder = &MockPlaylistImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaylistImportRepository) EXPECT() *MockPlaylistImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePlaylist mocks base method.
+func (m *MockPlaylistImportRepository) CreatePlaylist(ctx context.Context, playlist *entity.PlaylistDB, musicIds []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlaylist", ctx, playlist, musicIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePlaylist indicates an expected call of CreatePlaylist.
+func (mr *MockPlaylistImportRepositoryMockRecorder) CreatePlaylist(ctx, playlist, musicIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaylist", reflect.TypeOf((*MockPlaylistImportRepository)(nil).CreatePlaylist), ctx, playlist, musicIds)
+}
+
+// GetByIDs mocks base method.
+func (m *MockPlaylistImportRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ImportCandidateDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*entity.ImportCandidateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockPlaylistImportRepositoryMockRecorder) GetByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockPlaylistImportRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// GetByTitles mocks base method.
+func (m *MockPlaylistImportRepository) GetByTitles(ctx context.Context, titles []string) ([]*entity.ImportCandidateDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTitles", ctx, titles)
+	ret0, _ := ret[0].([]*entity.ImportCandidateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTitles indicates an expected call of GetByTitles.
+func (mr *MockPlaylistImportRepositoryMockRecorder) GetByTitles(ctx, titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTitles", reflect.TypeOf((*MockPlaylistImportRepository)(nil).GetByTitles), ctx, titles)
+}
+
+// MockSmartPlaylistRepository is a mock of SmartPlaylistRepository interface.
+type MockSmartPlaylistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmartPlaylistRepositoryMockRecorder
+}
+
+// MockSmartPlaylistRepositoryMockRecorder is the mock recorder for MockSmartPlaylistRepository.
+type MockSmartPlaylistRepositoryMockRecorder struct {
+	mock *MockSmartPlaylistRepository
+}
+
+// NewMockSmartPlaylistRepository creates a new mock instance.
+func NewMockSmartPlaylistRepository(ctrl *gomock.Controller) *MockSmartPlaylistRepository {
+	mock := &MockSmartPlaylistRepository{ctrl: ctrl}
+	mock.recorder = &MockSmartPlaylistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmartPlaylistRepository) EXPECT() *MockSmartPlaylistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSmartPlaylistRepository) Create(ctx context.Context, playlist *entity.SmartPlaylistDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, playlist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSmartPlaylistRepositoryMockRecorder) Create(ctx, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSmartPlaylistRepository)(nil).Create), ctx, playlist)
+}
+
+// Delete mocks base method.
+func (m *MockSmartPlaylistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSmartPlaylistRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSmartPlaylistRepository)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockSmartPlaylistRepository) Get(ctx context.Context, id uuid.UUID) (*entity.SmartPlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.SmartPlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSmartPlaylistRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSmartPlaylistRepository)(nil).Get), ctx, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockSmartPlaylistRepository) GetByUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.SmartPlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.SmartPlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockSmartPlaylistRepositoryMockRecorder) GetByUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockSmartPlaylistRepository)(nil).GetByUser), ctx, userId, limit, offset)
+}
+
+// GetTracks mocks base method.
+func (m *MockSmartPlaylistRepository) GetTracks(ctx context.Context, playlist *entity.SmartPlaylistDB, viewerId uuid.UUID, now time.Time) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracks", ctx, playlist, viewerId, now)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTracks indicates an expected call of GetTracks.
+func (mr *MockSmartPlaylistRepositoryMockRecorder) GetTracks(ctx, playlist, viewerId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracks", reflect.TypeOf((*MockSmartPlaylistRepository)(nil).GetTracks), ctx, playlist, viewerId, now)
+}
+
+// Update mocks base method.
+func (m *MockSmartPlaylistRepository) Update(ctx context.Context, playlist *entity.SmartPlaylistDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, playlist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSmartPlaylistRepositoryMockRecorder) Update(ctx, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSmartPlaylistRepository)(nil).Update), ctx, playlist)
+}
+
+// MockSyncRepository is a mock of SyncRepository interface.
+type MockSyncRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncRepositoryMockRecorder
+}
+
+// MockSyncRepositoryMockRecorder is the mock recorder for MockSyncRepository.
+type MockSyncRepositoryMockRecorder struct {
+	mock *MockSyncRepository
+}
+
+// NewMockSyncRepository creates a new mock instance.
+func NewMockSyncRepository(ctrl *gomock.Controller) *MockSyncRepository {
+	mock := &MockSyncRepository{ctrl: ctrl}
+	mock.recorder = &MockSyncRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncRepository) EXPECT() *MockSyncRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteChanges mocks base method.
+func (m *MockSyncRepository) DeleteChanges(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChanges", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChanges indicates an expected call of DeleteChanges.
+func (mr *MockSyncRepositoryMockRecorder) DeleteChanges(ctx, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChanges", reflect.TypeOf((*MockSyncRepository)(nil).DeleteChanges), ctx, before, limit)
+}
+
+// GetAllLikes mocks base method.
+func (m *MockSyncRepository) GetAllLikes(ctx context.Context, userId uuid.UUID) ([]*entity.SyncLikeDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllLikes", ctx, userId)
+	ret0, _ := ret[0].([]*entity.SyncLikeDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllLikes indicates an expected call of GetAllLikes.
+func (mr *MockSyncRepositoryMockRecorder) GetAllLikes(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllLikes", reflect.TypeOf((*MockSyncRepository)(nil).GetAllLikes), ctx, userId)
+}
+
+// GetAllPlaylists mocks base method.
+func (m *MockSyncRepository) GetAllPlaylists(ctx context.Context, userId uuid.UUID) ([]*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPlaylists", ctx, userId)
+	ret0, _ := ret[0].([]*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPlaylists indicates an expected call of GetAllPlaylists.
+func (mr *MockSyncRepositoryMockRecorder) GetAllPlaylists(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPlaylists", reflect.TypeOf((*MockSyncRepository)(nil).GetAllPlaylists), ctx, userId)
+}
+
+// GetChanges mocks base method.
+func (m *MockSyncRepository) GetChanges(ctx context.Context, userId uuid.UUID, cursor *entity.SyncCursor, xmin uint64, limit int) ([]*entity.SyncChangeDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", ctx, userId, cursor, xmin, limit)
+	ret0, _ := ret[0].([]*entity.SyncChangeDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockSyncRepositoryMockRecorder) GetChanges(ctx, userId, cursor, xmin, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockSyncRepository)(nil).GetChanges), ctx, userId, cursor, xmin, limit)
+}
+
+// GetLikes mocks base method.
+func (m *MockSyncRepository) GetLikes(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.SyncLikeDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikes", ctx, userId, ids)
+	ret0, _ := ret[0].([]*entity.SyncLikeDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikes indicates an expected call of GetLikes.
+func (mr *MockSyncRepositoryMockRecorder) GetLikes(ctx, userId, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikes", reflect.TypeOf((*MockSyncRepository)(nil).GetLikes), ctx, userId, ids)
+}
+
+// GetPlaylistTracks mocks base method.
+func (m *MockSyncRepository) GetPlaylistTracks(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.SyncPlaylistTrackDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylistTracks", ctx, userId, ids)
+	ret0, _ := ret[0].([]*entity.SyncPlaylistTrackDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylistTracks indicates an expected call of GetPlaylistTracks.
+func (mr *MockSyncRepositoryMockRecorder) GetPlaylistTracks(ctx, userId, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylistTracks", reflect.TypeOf((*MockSyncRepository)(nil).GetPlaylistTracks), ctx, userId, ids)
+}
+
+// GetPlaylists mocks base method.
+func (m *MockSyncRepository) GetPlaylists(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylists", ctx, userId, ids)
+	ret0, _ := ret[0].([]*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylists indicates an expected call of GetPlaylists.
+func (mr *MockSyncRepositoryMockRecorder) GetPlaylists(ctx, userId, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylists", reflect.TypeOf((*MockSyncRepository)(nil).GetPlaylists), ctx, userId, ids)
+}
+
+// GetTakenDown mocks base method.
+func (m *MockSyncRepository) GetTakenDown(ctx context.Context, from, to time.Time) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTakenDown", ctx, from, to)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTakenDown indicates an expected call of GetTakenDown.
+func (mr *MockSyncRepositoryMockRecorder) GetTakenDown(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTakenDown", reflect.TypeOf((*MockSyncRepository)(nil).GetTakenDown), ctx, from, to)
+}
+
+// GetTrackPage mocks base method.
+func (m *MockSyncRepository) GetTrackPage(ctx context.Context, userId, after uuid.UUID, limit int) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrackPage", ctx, userId, after, limit)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrackPage indicates an expected call of GetTrackPage.
+func (mr *MockSyncRepositoryMockRecorder) GetTrackPage(ctx, userId, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrackPage", reflect.TypeOf((*MockSyncRepository)(nil).GetTrackPage), ctx, userId, after, limit)
+}
+
+// GetTracks mocks base method.
+func (m *MockSyncRepository) GetTracks(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracks", ctx, userId, ids)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTracks indicates an expected call of GetTracks.
+func (mr *MockSyncRepositoryMockRecorder) GetTracks(ctx, userId, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracks", reflect.TypeOf((*MockSyncRepository)(nil).GetTracks), ctx, userId, ids)
+}
+
+// GetXmin mocks base method.
+func (m *MockSyncRepository) GetXmin(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXmin", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetXmin indicates an expected call of GetXmin.
+func (mr *MockSyncRepositoryMockRecorder) GetXmin(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXmin", reflect.TypeOf((*MockSyncRepository)(nil).GetXmin), ctx)
+}
+
+// MockSessionRepository is a mock of SessionRepository interface.
+type MockSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepositoryMockRecorder
+}
+
+// MockSessionRepositoryMockRecorder is the mock recorder for MockSessionRepository.
+type MockSessionRepositoryMockRecorder struct {
+	mock *MockSessionRepository
+}
+
+// NewMockSessionRepository creates a new mock instance.
+func NewMockSessionRepository(ctrl *gomock.Controller) *MockSessionRepository {
+	mock := &MockSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByUser mocks base method.
+func (m *MockSessionRepository) GetByUser(ctx context.Context, userId uuid.UUID, now time.Time) ([]*entity.SessionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, now)
+	ret0, _ := ret[0].([]*entity.SessionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockSessionRepositoryMockRecorder) GetByUser(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockSessionRepository)(nil).GetByUser), ctx, userId, now)
+}
+
+// IsRevoked mocks base method.
+func (m *MockSessionRepository) IsRevoked(ctx context.Context, userId, sessionId uuid.UUID, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, userId, sessionId, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockSessionRepositoryMockRecorder) IsRevoked(ctx, userId, sessionId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockSessionRepository)(nil).IsRevoked), ctx, userId, sessionId, now)
+}
+
+// Revoke mocks base method.
+func (m *MockSessionRepository) Revoke(ctx context.Context, userId, sessionId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userId, sessionId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionRepositoryMockRecorder) Revoke(ctx, userId, sessionId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSessionRepository)(nil).Revoke), ctx, userId, sessionId, now)
+}
+
+// RevokeAll mocks base method.
+func (m *MockSessionRepository) RevokeAll(ctx context.Context, userId uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, userId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockSessionRepositoryMockRecorder) RevokeAll(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockSessionRepository)(nil).RevokeAll), ctx, userId, now)
+}
+
+// MockSigningKeyRepository is a mock of SigningKeyRepository interface.
+type MockSigningKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSigningKeyRepositoryMockRecorder
+}
+
+// MockSigningKeyRepositoryMockRecorder is the mock recorder for MockSigningKeyRepository.
+type MockSigningKeyRepositoryMockRecorder struct {
+	mock *MockSigningKeyRepository
+}
+
+// NewMockSigningKeyRepository creates a new mock instance.
+func NewMockSigningKeyRepository(ctrl *gomock.Controller) *MockSigningKeyRepository {
+	mock := &MockSigningKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockSigningKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigningKeyRepository) EXPECT() *MockSigningKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSigningKeyRepository) Create(ctx context.Context, key *entity.SigningKeyDB, after, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key, after, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSigningKeyRepositoryMockRecorder) Create(ctx, key, after, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSigningKeyRepository)(nil).Create), ctx, key, after, now)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockSigningKeyRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockSigningKeyRepositoryMockRecorder) DeleteExpired(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockSigningKeyRepository)(nil).DeleteExpired), ctx, now)
+}
+
+// GetKeys mocks base method.
+func (m *MockSigningKeyRepository) GetKeys(ctx context.Context, now time.Time) ([]*entity.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeys", ctx, now)
+	ret0, _ := ret[0].([]*entity.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeys indicates an expected call of GetKeys.
+func (mr *MockSigningKeyRepositoryMockRecorder) GetKeys(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeys", reflect.TypeOf((*MockSigningKeyRepository)(nil).GetKeys), ctx, now)
+}
+
+// GetVerificationKey mocks base method.
+func (m *MockSigningKeyRepository) GetVerificationKey(ctx context.Context, kid string, now time.Time) (*entity.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerificationKey", ctx, kid, now)
+	ret0, _ := ret[0].(*entity.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerificationKey indicates an expected call of GetVerificationKey.
+func (mr *MockSigningKeyRepositoryMockRecorder) GetVerificationKey(ctx, kid, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerificationKey", reflect.TypeOf((*MockSigningKeyRepository)(nil).GetVerificationKey), ctx, kid, now)
+}
+
+// MockIdentityRepository is a mock of IdentityRepository interface.
+type MockIdentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityRepositoryMockRecorder
+}
+
+// MockIdentityRepositoryMockRecorder is the mock recorder for MockIdentityRepository.
+type MockIdentityRepositoryMockRecorder struct {
+	mock *MockIdentityRepository
+}
+
+// NewMockIdentityRepository creates a new mock instance.
+func NewMockIdentityRepository(ctrl *gomock.Controller) *MockIdentityRepository {
+	mock := &MockIdentityRepository{ctrl: ctrl}
+	mock.recorder = &MockIdentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityRepository) EXPECT() *MockIdentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockIdentityRepository) Begin(ctx context.Context, login *entity.OIDCLoginDB) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx, login)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIdentityRepositoryMockRecorder) Begin(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdentityRepository)(nil).Begin), ctx, login)
+}
+
+// ConsumeLogin mocks base method.
+func (m *MockIdentityRepository) ConsumeLogin(ctx context.Context, state string, now time.Time) (*entity.OIDCLoginDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeLogin", ctx, state, now)
+	ret0, _ := ret[0].(*entity.OIDCLoginDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeLogin indicates an expected call of ConsumeLogin.
+func (mr *MockIdentityRepositoryMockRecorder) ConsumeLogin(ctx, state, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeLogin", reflect.TypeOf((*MockIdentityRepository)(nil).ConsumeLogin), ctx, state, now)
+}
+
+// DeleteExpiredLogins mocks base method.
+func (m *MockIdentityRepository) DeleteExpiredLogins(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredLogins", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredLogins indicates an expected call of DeleteExpiredLogins.
+func (mr *MockIdentityRepositoryMockRecorder) DeleteExpiredLogins(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredLogins", reflect.TypeOf((*MockIdentityRepository)(nil).DeleteExpiredLogins), ctx, now)
+}
+
+// Exchange mocks base method.
+func (m *MockIdentityRepository) Exchange(ctx context.Context, login *entity.OIDCLoginDB, code string) (*entity.OIDCIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, login, code)
+	ret0, _ := ret[0].(*entity.OIDCIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockIdentityRepositoryMockRecorder) Exchange(ctx, login, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockIdentityRepository)(nil).Exchange), ctx, login, code)
+}
+
+// GetByUser mocks base method.
+func (m *MockIdentityRepository) GetByUser(ctx context.Context, userId uuid.UUID) ([]*entity.UserIdentityDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId)
+	ret0, _ := ret[0].([]*entity.UserIdentityDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockIdentityRepositoryMockRecorder) GetByUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockIdentityRepository)(nil).GetByUser), ctx, userId)
+}
+
+// Login mocks base method.
+func (m *MockIdentityRepository) Login(ctx context.Context, login *entity.IdentityLogin, now time.Time) (*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, login, now)
+	ret0, _ := ret[0].(*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockIdentityRepositoryMockRecorder) Login(ctx, login, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIdentityRepository)(nil).Login), ctx, login, now)
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type sessionRepository struct {
+	source db.SessionSource
+	cache  *sessionCache
+}
+
+func NewSessionRepository(source db.SessionSource, cache *sessionCache) *sessionRepository {
+	return &sessionRepository{
+		source: source,
+		cache:  cache,
+	}
+}
+
+func (s *sessionRepository) GetByUser(ctx context.Context, userId uuid.UUID, now time.Time) ([]*entity.SessionDB, error) {
+	sessions, err := s.source.GetByUser(ctx, userId, now)
+	if err != nil {
+		return nil, fmt.Errorf("/db/session.GetByUser: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (s *sessionRepository) Revoke(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID, now time.Time) error {
+	err := s.source.Revoke(ctx, userId, sessionId, now)
+	if err != nil {
+		return fmt.Errorf("/db/session.Revoke: %w", err)
+	}
+	s.cache.revoke(sessionId)
+
+	return nil
+}
+
+func (s *sessionRepository) RevokeAll(ctx context.Context, userId uuid.UUID, now time.Time) error {
+	err := s.source.RevokeUser(ctx, userId, now)
+	if err != nil {
+		return fmt.Errorf("/db/session.RevokeUser: %w", err)
+	}
+	s.cache.revokeUser(userId)
+
+	return nil
+}
+
+// IsRevoked проверяет, отозвана ли сессия. Ответ кэшируется, поэтому отзыв на другом экземпляре сервиса
+// вступает в силу не позже чем через время кэширования; отзыв на этом экземпляре — сразу.
+func (s *sessionRepository) IsRevoked(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID, now time.Time) (bool, error) {
+	if revoked, ok := s.cache.get(sessionId, now); ok {
+		return revoked, nil
+	}
+
+	active, err := s.source.IsActive(ctx, sessionId, now)
+	if err != nil {
+		return false, fmt.Errorf("/db/session.IsActive: %w", err)
+	}
+	s.cache.set(sessionId, userId, !active, now)
+
+	return !active, nil
+}
+
+type sessionCacheEntry struct {
+	userId    uuid.UUID // id пользователя, нужен только для отзыва всех его сессий
+	revoked   bool
+	expiresAt time.Time // время, после которого запись нужно перепроверить в бд. У отозванных сессий не задано.
+}
+
+// sessionCache хранит результаты проверки отзыва сессий. Отозванная сессия не может стать действующей,
+// поэтому отзыв хранится до вытеснения, а действующая сессия — не дольше ttl.
+// Кэш общий для репозиториев пользователей и сессий, чтобы выход и отзыв сразу учитывались при проверке.
+type sessionCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[uuid.UUID]sessionCacheEntry
+}
+
+func NewSessionCache(ttl time.Duration) *sessionCache {
+	return &sessionCache{
+		ttl:     ttl,
+		entries: make(map[uuid.UUID]sessionCacheEntry),
+	}
+}
+
+func (c *sessionCache) get(sessionId uuid.UUID, now time.Time) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[sessionId]
+	if !ok {
+		return false, false
+	}
+	if !entry.revoked && !now.Before(entry.expiresAt) {
+		delete(c.entries, sessionId)
+		return false, false
+	}
+
+	return entry.revoked, true
+}
+
+func (c *sessionCache) set(sessionId uuid.UUID, userId uuid.UUID, revoked bool, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= entity.MaxSessionCacheSize {
+		for id, entry := range c.entries {
+			if !entry.revoked && !now.Before(entry.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+		if len(c.entries) >= entity.MaxSessionCacheSize {
+			c.entries = make(map[uuid.UUID]sessionCacheEntry)
+		}
+	}
+
+	entry := sessionCacheEntry{userId: userId, revoked: revoked}
+	if !revoked {
+		entry.expiresAt = now.Add(c.ttl)
+	}
+	c.entries[sessionId] = entry
+}
+
+// revoke помечает закэшированную сессию отозванной.
+// Незакэшированная сессия при следующей проверке будет прочитана из бд уже отозванной.
+func (c *sessionCache) revoke(sessionId uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[sessionId]; ok {
+		c.entries[sessionId] = sessionCacheEntry{revoked: true}
+	}
+}
+
+// revokeUser помечает отозванными закэшированные сессии пользователя
+func (c *sessionCache) revokeUser(userId uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, entry := range c.entries {
+		if !entry.revoked && entry.userId == userId {
+			c.entries[id] = sessionCacheEntry{revoked: true}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type shareRepository struct {
+	source    db.ShareSource
+	music     db.MusicSource
+	playlists db.PlaylistSource
+}
+
+func NewShareRepository(source db.ShareSource, music db.MusicSource, playlists db.PlaylistSource) *shareRepository {
+	return &shareRepository{
+		source:    source,
+		music:     music,
+		playlists: playlists,
+	}
+}
+
+func (r *shareRepository) Create(ctx context.Context, link *entity.ShareLinkDB) error {
+	err := r.source.Create(ctx, link)
+	if err != nil {
+		return fmt.Errorf("/db/share.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (r *shareRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.ShareLinkDB, error) {
+	link, err := r.source.GetByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("/db/share.GetByTokenHash: %w", err)
+	}
+
+	return link, nil
+}
+
+func (r *shareRepository) GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.ShareLinkDB, error) {
+	links, err := r.source.GetByUser(ctx, userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/db/share.GetByUser: %w", err)
+	}
+
+	return links, nil
+}
+
+func (r *shareRepository) Revoke(ctx context.Context, userId uuid.UUID, id uuid.UUID, now time.Time) error {
+	err := r.source.Revoke(ctx, userId, id, now)
+	if err != nil {
+		return fmt.Errorf("/db/share.Revoke: %w", err)
+	}
+
+	return nil
+}
+
+func (r *shareRepository) ConsumePlay(ctx context.Context, id uuid.UUID, now time.Time) error {
+	err := r.source.ConsumePlay(ctx, id, now)
+	if err != nil {
+		return fmt.Errorf("/db/share.ConsumePlay: %w", err)
+	}
+
+	return nil
+}
+
+func (r *shareRepository) RecordAccess(ctx context.Context, access *entity.ShareAccessDB) error {
+	err := r.source.RecordAccess(ctx, access)
+	if err != nil {
+		return fmt.Errorf("/db/share.RecordAccess: %w", err)
+	}
+
+	return nil
+}
+
+func (r *shareRepository) GetMusic(ctx context.Context, musicId uuid.UUID) (*entity.MusicDB, error) {
+	music, err := r.music.Get(ctx, musicId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/music.Get: %w", err)
+	}
+
+	return music, nil
+}
+
+func (r *shareRepository) GetPlaylist(ctx context.Context, playlistId uuid.UUID) (*entity.PlaylistDB, error) {
+	playlist, err := r.playlists.Get(ctx, playlistId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/playlist.Get: %w", err)
+	}
+
+	return playlist, nil
+}
+
+// GetPlaylistTracks возвращает доступные треки плейлиста для читателя без аккаунта
+func (r *shareRepository) GetPlaylistTracks(ctx context.Context, playlistId uuid.UUID) ([]*entity.MusicDB, error) {
+	tracks, err := r.playlists.GetTracks(ctx, playlistId, uuid.Nil)
+	if err != nil {
+		return nil, fmt.Errorf("/db/playlist.GetTracks: %w", err)
+	}
+
+	return tracks, nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+type signingKeyRepository struct {
+	source db.SigningKeySource
+	keys   *keyManager
+}
+
+func NewSigningKeyRepository(source db.SigningKeySource, keys *keyManager) *signingKeyRepository {
+	return &signingKeyRepository{
+		source: source,
+		keys:   keys,
+	}
+}
+
+// GetKeys возвращает ключи, которые еще принимаются при проверке, начиная с последнего по времени начала подписи
+func (r *signingKeyRepository) GetKeys(ctx context.Context, now time.Time) ([]*entity.SigningKey, error) {
+	keys, err := r.keys.get(ctx, now, false)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/signing_key.GetKeys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// GetVerificationKey возвращает ключ kid. Неизвестный ключ мог быть создан другим экземпляром сервиса,
+// поэтому набор ключей перечитывается, но не чаще entity.KeyReloadInterval.
+func (r *signingKeyRepository) GetVerificationKey(ctx context.Context, kid string, now time.Time) (*entity.SigningKey, error) {
+	key, err := r.keys.find(ctx, kid, now)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/signing_key.GetVerificationKey: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *signingKeyRepository) Create(ctx context.Context, key *entity.SigningKeyDB, after time.Time, now time.Time) (bool, error) {
+	created, err := r.source.Create(ctx, key, after, now)
+	if err != nil {
+		return false, fmt.Errorf("/db/signing_key.Create: %w", err)
+	}
+	// Ключ мог создать и другой экземпляр сервиса: в обоих случаях набор ключей изменился
+	r.keys.invalidate()
+
+	return created, nil
+}
+
+func (r *signingKeyRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	err := r.source.DeleteExpired(ctx, now)
+	if err != nil {
+		return fmt.Errorf("/db/signing_key.DeleteExpired: %w", err)
+	}
+
+	return nil
+}
+
+// keyManager хранит разобранные ключи подписи и подписывает ими токены.
+// Набор ключей общий для репозиториев пользователей и ключей и перечитывается из бд не реже раза в ttl.
+type keyManager struct {
+	mu       sync.Mutex
+	source   db.SigningKeySource
+	ttl      time.Duration
+	keys     []*entity.SigningKey
+	loadedAt time.Time
+}
+
+func NewKeyManager(source db.SigningKeySource, ttl time.Duration) *keyManager {
+	return &keyManager{
+		source: source,
+		ttl:    ttl,
+	}
+}
+
+// get возвращает действующие на момент now ключи. При force набор перечитывается,
+// если с прошлой загрузки прошло не меньше entity.KeyReloadInterval.
+func (m *keyManager) get(ctx context.Context, now time.Time, force bool) ([]*entity.SigningKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fresh := !m.loadedAt.IsZero() && now.Before(m.loadedAt.Add(m.ttl))
+	if force {
+		fresh = !m.loadedAt.IsZero() && now.Before(m.loadedAt.Add(entity.KeyReloadInterval))
+	}
+	if !fresh {
+		rows, err := m.source.GetValid(ctx, now)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]*entity.SigningKey, 0, len(rows))
+		for _, row := range rows {
+			key, err := row.Parse()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		m.keys = keys
+		m.loadedAt = now
+	}
+
+	valid := make([]*entity.SigningKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		if key.ExpiresAt.After(now) {
+			valid = append(valid, key)
+		}
+	}
+	return valid, nil
+}
+
+func (m *keyManager) find(ctx context.Context, kid string, now time.Time) (*entity.SigningKey, error) {
+	for _, force := range []bool{false, true} {
+		keys, err := m.get(ctx, now, force)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if key.ID == kid {
+				return key, nil
+			}
+		}
+	}
+
+	return nil, entity.ErrUnknownSigningKey
+}
+
+// sign подписывает claims последним ключом, который уже начал подписывать токены, и указывает его kid в заголовке
+func (m *keyManager) sign(ctx context.Context, claims jwt.Claims, now time.Time) (string, error) {
+	for _, force := range []bool{false, true} {
+		keys, err := m.get(ctx, now, force)
+		if err != nil {
+			return "", err
+		}
+		for _, key := range keys {
+			if key.ActivatesAt.After(now) {
+				continue
+			}
+			token := jwt.NewWithClaims(key.Method, claims)
+			token.Header["kid"] = key.ID
+			return token.SignedString(key.Private)
+		}
+	}
+
+	return "", entity.ErrNoSigningKey
+}
+
+func (m *keyManager) invalidate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.loadedAt = time.Time{}
+}
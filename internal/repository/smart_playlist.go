@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type smartPlaylistRepository struct {
+	source db.SmartPlaylistSource
+}
+
+func NewSmartPlaylistRepository(source db.SmartPlaylistSource) *smartPlaylistRepository {
+	return &smartPlaylistRepository{
+		source: source,
+	}
+}
+
+func (r *smartPlaylistRepository) Create(ctx context.Context, playlist *entity.SmartPlaylistDB) error {
+	err := r.source.Create(ctx, playlist)
+	if err != nil {
+		return fmt.Errorf("/db/smart_playlist.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (r *smartPlaylistRepository) Get(ctx context.Context, id uuid.UUID) (*entity.SmartPlaylistDB, error) {
+	playlist, err := r.source.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("/db/smart_playlist.Get: %w", err)
+	}
+
+	return playlist, nil
+}
+
+func (r *smartPlaylistRepository) GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.SmartPlaylistDB, error) {
+	playlists, err := r.source.GetByUser(ctx, userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/db/smart_playlist.GetByUser: %w", err)
+	}
+
+	return playlists, nil
+}
+
+func (r *smartPlaylistRepository) Update(ctx context.Context, playlist *entity.SmartPlaylistDB) error {
+	err := r.source.Update(ctx, playlist)
+	if err != nil {
+		return fmt.Errorf("/db/smart_playlist.Update: %w", err)
+	}
+
+	return nil
+}
+
+func (r *smartPlaylistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	err := r.source.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("/db/smart_playlist.Delete: %w", err)
+	}
+
+	return nil
+}
+
+func (r *smartPlaylistRepository) GetTracks(ctx context.Context, playlist *entity.SmartPlaylistDB, viewerId uuid.UUID, now time.Time) ([]*entity.MusicDB, error) {
+	music, err := r.source.GetTracks(ctx, playlist, viewerId, now)
+	if err != nil {
+		return nil, fmt.Errorf("/db/smart_playlist.GetTracks: %w", err)
+	}
+
+	return music, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type statsRepository struct {
+	source db.StatsSource
+}
+
+func NewStatsRepository(source db.StatsSource) *statsRepository {
+	return &statsRepository{
+		source: source,
+	}
+}
+
+func (r *statsRepository) GetStats(ctx context.Context, userId uuid.UUID, filter *entity.StatsFilter) (*entity.ListeningStats, error) {
+	stats, err := r.source.GetStats(ctx, userId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/db/stats.GetStats: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *statsRepository) GetUsersWithoutReview(ctx context.Context, year int, afterId uuid.UUID, now time.Time, limit int) ([]uuid.UUID, error) {
+	userIds, err := r.source.GetUsersWithoutReview(ctx, year, afterId, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("/db/stats.GetUsersWithoutReview: %w", err)
+	}
+
+	return userIds, nil
+}
+
+func (r *statsRepository) SaveReview(ctx context.Context, review *entity.YearReviewDB) error {
+	err := r.source.SaveReview(ctx, review)
+	if err != nil {
+		return fmt.Errorf("/db/stats.SaveReview: %w", err)
+	}
+
+	return nil
+}
+
+func (r *statsRepository) RecordReviewFailure(ctx context.Context, failure *entity.YearReviewFailure) error {
+	err := r.source.RecordReviewFailure(ctx, failure)
+	if err != nil {
+		return fmt.Errorf("/db/stats.RecordReviewFailure: %w", err)
+	}
+
+	return nil
+}
+
+func (r *statsRepository) GetReview(ctx context.Context, userId uuid.UUID, year int) (*entity.YearReviewDB, error) {
+	review, err := r.source.GetReview(ctx, userId, year)
+	if err != nil {
+		return nil, fmt.Errorf("/db/stats.GetReview: %w", err)
+	}
+
+	return review, nil
+}
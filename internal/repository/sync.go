@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type syncRepository struct {
+	source db.SyncSource
+}
+
+func NewSyncRepository(source db.SyncSource) *syncRepository {
+	return &syncRepository{
+		source: source,
+	}
+}
+
+func (r *syncRepository) GetXmin(ctx context.Context) (uint64, error) {
+	xmin, err := r.source.GetXmin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("/db/sync.GetXmin: %w", err)
+	}
+
+	return xmin, nil
+}
+
+func (r *syncRepository) GetChanges(ctx context.Context, userId uuid.UUID, cursor *entity.SyncCursor, xmin uint64, limit int) ([]*entity.SyncChangeDB, error) {
+	changes, err := r.source.GetChanges(ctx, userId, cursor, xmin, limit)
+	if err != nil {
+		return nil, fmt.Errorf("/db/sync.GetChanges: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (r *syncRepository) GetTracks(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.MusicDB, error) {
+	tracks, err := r.source.GetTracks(ctx, userId, ids)
+	if err != nil {
+		return nil, fmt.Errorf("/db/sync.GetTracks: %w", err)
+	}
+
+	return tracks, nil
+}
+
+func (r *syncRepository) GetTrackPage(ctx context.Context, userId uuid.UUID, after uuid.UUID, limit int) ([]*entity.MusicDB, error) {
+	tracks, err := r.source.GetTrackPage(ctx, userId, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("/db/sync.GetTrackPage: %w", err)
+	}
+
+	return tracks, nil
+}
+
+func (r *syncRepository) GetTakenDown(ctx context.Context, from time.Time, to time.Time) ([]uuid.UUID, error) {
+	ids, err := r.source.GetTakenDown(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("/db/sync.GetTakenDown: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *syncRepository) GetLikes(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.SyncLikeDB, error) {
+	likes, err := r.source.GetLikes(ctx, userId, ids)
+	if err != nil {
+		return nil, fmt.Errorf("/db/sync.GetLikes: %w", err)
+	}
+
+	return likes, nil
+}
+
+func (r *syncRepository) GetAllLikes(ctx context.Context, userId uuid.UUID) ([]*entity.SyncLikeDB, error) {
+	likes, err := r.source.GetAllLikes(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/sync.GetAllLikes: %w", err)
+	}
+
+	return likes, nil
+}
+
+func (r *syncRepository) GetPlaylists(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.PlaylistDB, error) {
+	playlists, err := r.source.GetPlaylists(ctx, userId, ids)
+	if err != nil {
+		return nil, fmt.Errorf("/db/sync.GetPlaylists: %w", err)
+	}
+
+	return playlists, nil
+}
+
+func (r *syncRepository) GetAllPlaylists(ctx context.Context, userId uuid.UUID) ([]*entity.PlaylistDB, error) {
+	playlists, err := r.source.GetAllPlaylists(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/db/sync.GetAllPlaylists: %w", err)
+	}
+
+	return playlists, nil
+}
+
+func (r *syncRepository) GetPlaylistTracks(ctx context.Context, userId uuid.UUID, ids []uuid.UUID) ([]*entity.SyncPlaylistTrackDB, error) {
+	tracks, err := r.source.GetPlaylistTracks(ctx, userId, ids)
+	if err != nil {
+		return nil, fmt.Errorf("/db/sync.GetPlaylistTracks: %w", err)
+	}
+
+	return tracks, nil
+}
+
+func (r *syncRepository) DeleteChanges(ctx context.Context, before time.Time, limit int) (int64, error) {
+	deleted, err := r.source.DeleteChanges(ctx, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("/db/sync.DeleteChanges: %w", err)
+	}
+
+	return deleted, nil
+}
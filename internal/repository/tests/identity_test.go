@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/oidc"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_identityRepository_Begin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	source := db.NewMockIdentitySource(ctrl)
+	provider := oidc.NewMockProvider(ctrl)
+	login := &entity.OIDCLoginDB{State: "state", Nonce: "nonce", CodeVerifier: "verifier"}
+
+	// Провайдеру уходит только code_challenge, code_verifier остается в бд до обмена кода
+	provider.EXPECT().AuthCodeURL(gomock.Any(), "state", "nonce", login.CodeChallenge()).Return("http://oidc:8080/default/authorize", nil)
+	source.EXPECT().CreateLogin(gomock.Any(), login).Return(nil)
+
+	r := repository.NewIdentityRepository(source, provider, utils.NewMockPasswordHasher(ctrl))
+	got, err := r.Begin(context.Background(), login)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://oidc:8080/default/authorize", got)
+}
+
+func Test_identityRepository_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	source := db.NewMockIdentitySource(ctrl)
+	hasher := utils.NewMockPasswordHasher(ctrl)
+	login := &entity.IdentityLogin{
+		Issuer:  "http://oidc:8080/default",
+		Subject: "user-42",
+		NewUser: &entity.UserCreate{Username: "jdoe", Password: "random-password"},
+		Role:    entity.UserRole,
+	}
+	user := &entity.UserDB{Username: "jdoe", Role: entity.UserRole}
+
+	hasher.EXPECT().Hash("random-password").Return("hash", nil)
+	source.EXPECT().Login(gomock.Any(), gomock.Any(), now).DoAndReturn(
+		func(_ context.Context, got *entity.IdentityLogin, _ time.Time) (*entity.UserDB, error) {
+			assert.Equal(t, "hash", got.NewUser.Password)
+			assert.Equal(t, "jdoe", got.NewUser.Username)
+			return user, nil
+		})
+
+	r := repository.NewIdentityRepository(source, oidc.NewMockProvider(ctrl), hasher)
+	got, err := r.Login(context.Background(), login, now)
+	assert.NoError(t, err)
+	assert.Equal(t, user, got)
+	// Вход вызывающего не изменяется
+	assert.Equal(t, "random-password", login.NewUser.Password)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/utils"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_musicRevisionRepository_Revert(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	archived := entity.MusicRevisionStorage + "/ff578289-cdca-406e-9a57-f8c773f0cd15_old_first.mp3"
+	current := &entity.MusicRevisionDB{MusicID: musicId, Version: 3, FileName: "second.mp3", FilePath: "./internal/storage/music_storage/second.mp3"}
+
+	tests := []struct {
+		name    string
+		target  *entity.MusicRevisionDB
+		setup   func(source *db.MockMusicRevisionSource)
+		wantErr bool
+	}{
+		{
+			name:   "Revert metadata only",
+			target: &entity.MusicRevisionDB{MusicID: musicId, Version: 2, Name: "Old name", FileName: "second.mp3", FilePath: current.FilePath},
+			setup: func(source *db.MockMusicRevisionSource) {
+				source.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Nil()).
+					DoAndReturn(func(_ context.Context, music *entity.MusicDB, _ *entity.MusicRevisionDB, _ []entity.MusicFileMove) error {
+						assert.Equal(t, "Old name", music.Name)
+						return nil
+					})
+			},
+		},
+		{
+			name:   "Revert file",
+			target: &entity.MusicRevisionDB{MusicID: musicId, Version: 1, FileName: "first.mp3", FilePath: archived},
+			setup: func(source *db.MockMusicRevisionSource) {
+				source.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, music *entity.MusicDB, _ *entity.MusicRevisionDB, moves []entity.MusicFileMove) error {
+						assert.Equal(t, "first.mp3", music.FileName)
+						assert.Len(t, moves, 2)
+						assert.Equal(t, current.FilePath, moves[0].From)
+						assert.True(t, strings.HasPrefix(moves[0].To, entity.MusicRevisionStorage+"/"+musicId.String()))
+						assert.Equal(t, entity.MusicFileMove{From: archived, To: "./internal/storage/music_storage/first.mp3"}, moves[1])
+						return nil
+					})
+			},
+		},
+		{
+			name:   "Error in source",
+			target: &entity.MusicRevisionDB{MusicID: musicId, Version: 1, FileName: "first.mp3", FilePath: archived},
+			setup: func(source *db.MockMusicRevisionSource) {
+				source.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("Error in Apply"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			source := db.NewMockMusicRevisionSource(ctrl)
+			tt.setup(source)
+
+			revision := entity.NewMusicRevision(tt.target.ToMusic(), entity.MusicRevisionRevert, uuid.Nil, current.CreatedAt)
+			err := repository.NewMusicRevisionRepository(source, utils.NewMockOS()).Revert(context.Background(), tt.target, current, revision)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
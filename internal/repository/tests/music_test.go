@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"mime/multipart"
 	"music-backend-test/internal/db"
@@ -41,7 +42,7 @@ func Test_GetAll(t *testing.T) {
 				ctx: ctx,
 			},
 			setup: func(a args, f fields) {
-				f.source.EXPECT().GetAll(a.ctx).Return([]*entity.MusicDB{
+				f.source.EXPECT().GetAll(a.ctx, entity.MusicFilter{}).Return([]*entity.MusicDB{
 					{
 						Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
 						Name:     "Song1",
@@ -85,7 +86,7 @@ func Test_GetAll(t *testing.T) {
 				ctx: ctx,
 			},
 			setup: func(a args, f fields) {
-				f.source.EXPECT().GetAll(a.ctx).Return(nil, fmt.Errorf("Error in source.GetAll()"))
+				f.source.EXPECT().GetAll(a.ctx, entity.MusicFilter{}).Return(nil, fmt.Errorf("Error in source.GetAll()"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -101,11 +102,11 @@ func Test_GetAll(t *testing.T) {
 				utils:  utils.NewMockMusicUtils(ctrl),
 			}
 			os := utils.NewMockOS()
-			musicRepository := repository.NewMusicRepository(f.source, f.utils, os)
+			musicRepository := repository.NewMusicRepository(f.source, db.NewMockMusicRevisionSource(ctrl), db.NewMockLyricsSource(ctrl), db.NewMockDuplicateSource(ctrl), f.utils, os)
 
 			tt.setup(tt.args, f)
 
-			got, err := musicRepository.GetAll(tt.args.ctx)
+			got, err := musicRepository.GetAll(tt.args.ctx, entity.MusicFilter{})
 			if tt.wantErr == true {
 				assert.Error(t, err)
 			} else {
@@ -184,7 +185,7 @@ func Test_Get(t *testing.T) {
 				utils:  utils.NewMockMusicUtils(ctrl),
 			}
 			os := utils.NewMockOS()
-			musicRepository := repository.NewMusicRepository(f.source, f.utils, os)
+			musicRepository := repository.NewMusicRepository(f.source, db.NewMockMusicRevisionSource(ctrl), db.NewMockLyricsSource(ctrl), db.NewMockDuplicateSource(ctrl), f.utils, os)
 
 			tt.setup(tt.args, f)
 
@@ -201,7 +202,7 @@ func Test_Get(t *testing.T) {
 	}
 }
 
-func Test_GetAndSortByPopular(t *testing.T) {
+func Test_GetAllSortByTime(t *testing.T) {
 	type fields struct {
 		source *db.MockMusicSource
 		utils  *utils.MockMusicUtils
@@ -224,7 +225,7 @@ func Test_GetAndSortByPopular(t *testing.T) {
 				ctx: ctx,
 			},
 			setup: func(a args, f fields) {
-				f.source.EXPECT().GetAndSortByPopular(a.ctx).Return([]*entity.MusicDB{
+				f.source.EXPECT().GetAllSortByTime(a.ctx, entity.MusicFilter{}).Return([]*entity.MusicDB{
 					{
 						Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
 						Name:     "Song1",
@@ -268,7 +269,7 @@ func Test_GetAndSortByPopular(t *testing.T) {
 				ctx: ctx,
 			},
 			setup: func(a args, f fields) {
-				f.source.EXPECT().GetAndSortByPopular(a.ctx).Return(nil, fmt.Errorf("Error in source.GetAll()"))
+				f.source.EXPECT().GetAllSortByTime(a.ctx, entity.MusicFilter{}).Return(nil, fmt.Errorf("Error in source.GetAll()"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -284,11 +285,11 @@ func Test_GetAndSortByPopular(t *testing.T) {
 				utils:  utils.NewMockMusicUtils(ctrl),
 			}
 			os := utils.NewMockOS()
-			musicRepository := repository.NewMusicRepository(f.source, f.utils, os)
+			musicRepository := repository.NewMusicRepository(f.source, db.NewMockMusicRevisionSource(ctrl), db.NewMockLyricsSource(ctrl), db.NewMockDuplicateSource(ctrl), f.utils, os)
 
 			tt.setup(tt.args, f)
 
-			got, err := musicRepository.GetAndSortByPopular(tt.args.ctx)
+			got, err := musicRepository.GetAllSortByTime(tt.args.ctx, entity.MusicFilter{})
 			if tt.wantErr == true {
 				assert.Error(t, err)
 			} else {
@@ -301,127 +302,210 @@ func Test_GetAndSortByPopular(t *testing.T) {
 	}
 }
 
-func Test_GetAllSortByTime(t *testing.T) {
+func Test_Create(t *testing.T) {
 	type fields struct {
-		source *db.MockMusicSource
-		utils  *utils.MockMusicUtils
+		source    *db.MockMusicSource
+		revisions *db.MockMusicRevisionSource
+		lyrics    *db.MockLyricsSource
+		utils     *utils.MockMusicUtils
 	}
 	type args struct {
-		ctx context.Context
+		ctx        context.Context
+		musicParse *entity.MusicParse
 	}
 	ctx := context.Background()
+	publishAt := time.Now().Add(24 * time.Hour)
 
 	tests := []struct {
-		name    string
-		args    args
-		setup   func(a args, f fields)
-		want    []*entity.MusicDB
-		wantErr bool
+		name                      string
+		args                      args
+		setupGetSupportedFileType func(a args, f fields) utils.FileType
+		setupGetAudioDuration     func(fileType utils.FileType, filePath string, os utils.FileSystem, f fields) string
+		setupGetExplicit          func(fileType utils.FileType, filePath string, os utils.FileSystem, f fields) bool
+		setupCreate               func(ctx context.Context, musicCreate *entity.MusicDB, f fields)
+		wantErr                   bool
 	}{
 		{
-			name: "Get all music",
+			name: "Create music",
 			args: args{
 				ctx: ctx,
+				musicParse: &entity.MusicParse{
+					Name:    "Song2",
+					Release: time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
+					File:    os.NewFile(uintptr(syscall.Stdout), "Test.MP3"),
+					FileHeader: &multipart.FileHeader{
+						Filename: "Test.MP3",
+						Size:     900,
+					},
+				},
 			},
-			setup: func(a args, f fields) {
-				f.source.EXPECT().GetAllSortByTime(a.ctx).Return([]*entity.MusicDB{
-					{
-						Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-						Name:     "Song1",
-						Release:  time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
-						FileName: "Song1.mp3",
-						Size:     uint64(500),
-						Duration: "2:47",
+			setupGetSupportedFileType: func(a args, f fields) utils.FileType {
+				f.utils.EXPECT().GetSupportedFileType(a.musicParse.FileHeader.Filename).Return(utils.FileType(utils.MP3), nil)
+				return utils.FileType(utils.MP3)
+			},
+			setupGetAudioDuration: func(fileType utils.FileType, filePath string, os utils.FileSystem, f fields) string {
+				f.utils.EXPECT().GetAudioDuration(fileType, filePath, os).Return("3:15", nil)
+				return "3:15"
+			},
+			setupCreate: func(ctx context.Context, musicCreate *entity.MusicDB, f fields) {
+				f.source.EXPECT().Create(ctx, musicCreate).Return(nil)
+				f.revisions.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, revision *entity.MusicRevisionDB) error {
+					assert.Equal(t, entity.MusicRevisionCreate, revision.Action)
+					assert.Equal(t, musicCreate.FilePath(), revision.FilePath)
+					return nil
+				})
+				f.utils.EXPECT().GetLyrics(utils.FileType(utils.MP3), musicCreate.FilePath(), utils.NewMockOS()).Return(nil, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Create music scheduled for publishing",
+			args: args{
+				ctx: ctx,
+				musicParse: &entity.MusicParse{
+					Name:      "Song2",
+					Release:   time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
+					PublishAt: &publishAt,
+					File:      os.NewFile(uintptr(syscall.Stdout), "Test.MP3"),
+					FileHeader: &multipart.FileHeader{
+						Filename: "Test.MP3",
+						Size:     900,
 					},
-					{
-						Id:       uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
-						Name:     "Song2",
-						Release:  time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
-						FileName: "Song2.mp3",
-						Size:     uint64(900),
-						Duration: "3:23",
-					}}, nil)
+				},
 			},
-			want: []*entity.MusicDB{
-				{
-					Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-					Name:     "Song1",
-					Release:  time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
-					FileName: "Song1.mp3",
-					Size:     uint64(500),
-					Duration: "2:47",
+			setupGetSupportedFileType: func(a args, f fields) utils.FileType {
+				f.utils.EXPECT().GetSupportedFileType(a.musicParse.FileHeader.Filename).Return(utils.FileType(utils.MP3), nil)
+				return utils.FileType(utils.MP3)
+			},
+			setupGetAudioDuration: func(fileType utils.FileType, filePath string, os utils.FileSystem, f fields) string {
+				f.utils.EXPECT().GetAudioDuration(fileType, filePath, os).Return("3:15", nil)
+				return "3:15"
+			},
+			setupCreate: func(ctx context.Context, musicCreate *entity.MusicDB, f fields) {
+				assert.Nil(t, musicCreate.PublishedAt)
+				f.source.EXPECT().Create(ctx, musicCreate).Return(nil)
+				f.revisions.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				f.utils.EXPECT().GetLyrics(utils.FileType(utils.MP3), musicCreate.FilePath(), utils.NewMockOS()).Return(nil, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Create music with embedded lyrics",
+			args: args{
+				ctx: ctx,
+				musicParse: &entity.MusicParse{
+					Name:    "Song2",
+					Release: time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
+					File:    os.NewFile(uintptr(syscall.Stdout), "Test.MP3"),
+					FileHeader: &multipart.FileHeader{
+						Filename: "Test.MP3",
+						Size:     900,
+					},
 				},
-				{
-					Id:       uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
-					Name:     "Song2",
-					Release:  time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
-					FileName: "Song2.mp3",
-					Size:     uint64(900),
-					Duration: "3:23",
+			},
+			setupGetSupportedFileType: func(a args, f fields) utils.FileType {
+				f.utils.EXPECT().GetSupportedFileType(a.musicParse.FileHeader.Filename).Return(utils.FileType(utils.MP3), nil)
+				return utils.FileType(utils.MP3)
+			},
+			setupGetAudioDuration: func(fileType utils.FileType, filePath string, os utils.FileSystem, f fields) string {
+				f.utils.EXPECT().GetAudioDuration(fileType, filePath, os).Return("3:15", nil)
+				return "3:15"
+			},
+			setupCreate: func(ctx context.Context, musicCreate *entity.MusicDB, f fields) {
+				f.source.EXPECT().Create(ctx, musicCreate).Return(nil)
+				f.revisions.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, revision *entity.MusicRevisionDB) error {
+					assert.Equal(t, entity.MusicRevisionCreate, revision.Action)
+					assert.Equal(t, musicCreate.FilePath(), revision.FilePath)
+					return nil
+				})
+				f.utils.EXPECT().GetLyrics(utils.FileType(utils.MP3), musicCreate.FilePath(), utils.NewMockOS()).Return([]*utils.EmbeddedLyrics{
+					{Language: "ENG", Synced: []utils.SyncedLyric{{Time: 1500 * time.Millisecond, Text: "Hello"}}},
+					{Language: "xxx", Text: "Unknown"},
+				}, nil)
+				f.lyrics.EXPECT().Upsert(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, lyrics *entity.LyricsDB) error {
+					assert.Equal(t, "eng", lyrics.Language)
+					assert.Equal(t, "Hello", lyrics.Plain)
+					assert.Equal(t, "[00:01.50]Hello\n", *lyrics.Synced)
+					assert.Equal(t, entity.LyricsSourceID3, lyrics.Source)
+					return nil
+				})
+				f.lyrics.EXPECT().Upsert(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, lyrics *entity.LyricsDB) error {
+					assert.Equal(t, entity.UndefinedLanguage, lyrics.Language)
+					assert.Nil(t, lyrics.Synced)
+					return nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ignore unreadable lyrics tag",
+			args: args{
+				ctx: ctx,
+				musicParse: &entity.MusicParse{
+					Name:    "Song2",
+					Release: time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
+					File:    os.NewFile(uintptr(syscall.Stdout), "Test.MP3"),
+					FileHeader: &multipart.FileHeader{
+						Filename: "Test.MP3",
+						Size:     900,
+					},
 				},
 			},
+			setupGetSupportedFileType: func(a args, f fields) utils.FileType {
+				f.utils.EXPECT().GetSupportedFileType(a.musicParse.FileHeader.Filename).Return(utils.FileType(utils.MP3), nil)
+				return utils.FileType(utils.MP3)
+			},
+			setupGetAudioDuration: func(fileType utils.FileType, filePath string, os utils.FileSystem, f fields) string {
+				f.utils.EXPECT().GetAudioDuration(fileType, filePath, os).Return("3:15", nil)
+				return "3:15"
+			},
+			setupCreate: func(ctx context.Context, musicCreate *entity.MusicDB, f fields) {
+				f.source.EXPECT().Create(ctx, musicCreate).Return(nil)
+				f.revisions.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, revision *entity.MusicRevisionDB) error {
+					assert.Equal(t, entity.MusicRevisionCreate, revision.Action)
+					assert.Equal(t, musicCreate.FilePath(), revision.FilePath)
+					return nil
+				})
+				f.utils.EXPECT().GetLyrics(utils.FileType(utils.MP3), musicCreate.FilePath(), utils.NewMockOS()).Return(nil, fmt.Errorf("can't read tag"))
+			},
 			wantErr: false,
 		},
 		{
-			name: "Get error from source",
+			name: "Create explicit music",
 			args: args{
 				ctx: ctx,
+				musicParse: &entity.MusicParse{
+					Name:    "Song2",
+					Release: time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
+					File:    os.NewFile(uintptr(syscall.Stdout), "Test.MP3"),
+					FileHeader: &multipart.FileHeader{
+						Filename: "Test.MP3",
+						Size:     900,
+					},
+				},
 			},
-			setup: func(a args, f fields) {
-				f.source.EXPECT().GetAllSortByTime(a.ctx).Return(nil, fmt.Errorf("Error in source.GetAll()"))
+			setupGetSupportedFileType: func(a args, f fields) utils.FileType {
+				f.utils.EXPECT().GetSupportedFileType(a.musicParse.FileHeader.Filename).Return(utils.FileType(utils.MP3), nil)
+				return utils.FileType(utils.MP3)
 			},
-			want:    nil,
-			wantErr: true,
+			setupGetAudioDuration: func(fileType utils.FileType, filePath string, os utils.FileSystem, f fields) string {
+				f.utils.EXPECT().GetAudioDuration(fileType, filePath, os).Return("3:15", nil)
+				return "3:15"
+			},
+			setupGetExplicit: func(fileType utils.FileType, filePath string, os utils.FileSystem, f fields) bool {
+				f.utils.EXPECT().GetExplicit(fileType, filePath, os).Return(true, nil)
+				return true
+			},
+			setupCreate: func(ctx context.Context, musicCreate *entity.MusicDB, f fields) {
+				assert.True(t, musicCreate.Explicit)
+				f.source.EXPECT().Create(ctx, musicCreate).Return(nil)
+				f.revisions.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				f.utils.EXPECT().GetLyrics(utils.FileType(utils.MP3), musicCreate.FilePath(), utils.NewMockOS()).Return(nil, nil)
+			},
+			wantErr: false,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-			f := fields{
-				source: db.NewMockMusicSource(ctrl),
-				utils:  utils.NewMockMusicUtils(ctrl),
-			}
-			os := utils.NewMockOS()
-			musicRepository := repository.NewMusicRepository(f.source, f.utils, os)
-
-			tt.setup(tt.args, f)
-
-			got, err := musicRepository.GetAllSortByTime(tt.args.ctx)
-			if tt.wantErr == true {
-				assert.Error(t, err)
-			} else {
-				if assert.NoError(t, err) {
-					assert.Equal(t, tt.want, got)
-				}
-			}
-		})
-
-	}
-}
-
-func Test_Create(t *testing.T) {
-	type fields struct {
-		source *db.MockMusicSource
-		utils  *utils.MockMusicUtils
-	}
-	type args struct {
-		ctx        context.Context
-		musicParse *entity.MusicParse
-	}
-	ctx := context.Background()
-
-	tests := []struct {
-		name                      string
-		args                      args
-		setupGetSupportedFileType func(a args, f fields) utils.FileType
-		setupGetAudioDuration     func(fileType utils.FileType, filePath string, os utils.FileSystem, f fields) string
-		setupCreate               func(ctx context.Context, musicCreate *entity.MusicDB, f fields)
-		wantErr                   bool
-	}{
 		{
-			name: "Create music",
+			name: "Ignore unreadable advisory tag",
 			args: args{
 				ctx: ctx,
 				musicParse: &entity.MusicParse{
@@ -442,8 +526,14 @@ func Test_Create(t *testing.T) {
 				f.utils.EXPECT().GetAudioDuration(fileType, filePath, os).Return("3:15", nil)
 				return "3:15"
 			},
+			setupGetExplicit: func(fileType utils.FileType, filePath string, os utils.FileSystem, f fields) bool {
+				f.utils.EXPECT().GetExplicit(fileType, filePath, os).Return(false, fmt.Errorf("can't read tag"))
+				return false
+			},
 			setupCreate: func(ctx context.Context, musicCreate *entity.MusicDB, f fields) {
 				f.source.EXPECT().Create(ctx, musicCreate).Return(nil)
+				f.revisions.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				f.utils.EXPECT().GetLyrics(utils.FileType(utils.MP3), musicCreate.FilePath(), utils.NewMockOS()).Return(nil, nil)
 			},
 			wantErr: false,
 		},
@@ -518,6 +608,34 @@ func Test_Create(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Get error from revision source",
+			args: args{
+				ctx: ctx,
+				musicParse: &entity.MusicParse{
+					Name:    "Song2",
+					Release: time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
+					File:    os.NewFile(uintptr(syscall.Stdout), "Test.MP3"),
+					FileHeader: &multipart.FileHeader{
+						Filename: "Test.MP3",
+						Size:     900,
+					},
+				},
+			},
+			setupGetSupportedFileType: func(a args, f fields) utils.FileType {
+				f.utils.EXPECT().GetSupportedFileType(a.musicParse.FileHeader.Filename).Return(utils.FileType(utils.MP3), nil)
+				return utils.FileType(utils.MP3)
+			},
+			setupGetAudioDuration: func(fileType utils.FileType, filePath string, os utils.FileSystem, f fields) string {
+				f.utils.EXPECT().GetAudioDuration(fileType, filePath, os).Return("3:15", nil)
+				return "3:15"
+			},
+			setupCreate: func(ctx context.Context, musicCreate *entity.MusicDB, f fields) {
+				f.source.EXPECT().Create(ctx, musicCreate).Return(nil)
+				f.revisions.EXPECT().Create(ctx, gomock.Any()).Return(fmt.Errorf("Error in revisions.Create()"))
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -525,34 +643,53 @@ func Test_Create(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			f := fields{
-				source: db.NewMockMusicSource(ctrl),
-				utils:  utils.NewMockMusicUtils(ctrl),
+				source:    db.NewMockMusicSource(ctrl),
+				revisions: db.NewMockMusicRevisionSource(ctrl),
+				lyrics:    db.NewMockLyricsSource(ctrl),
+				utils:     utils.NewMockMusicUtils(ctrl),
 			}
 			os := utils.NewMockOS()
-			musicRepository := repository.NewMusicRepository(f.source, f.utils, os)
+			musicRepository := repository.NewMusicRepository(f.source, f.revisions, f.lyrics, db.NewMockDuplicateSource(ctrl), f.utils, os)
 
 			fileType := tt.setupGetSupportedFileType(tt.args, f)
+			filePath := "./internal/storage/music_storage/" + tt.args.musicParse.FileHeader.Filename
 			var duration string
 			if tt.setupGetAudioDuration != nil {
-				duration = tt.setupGetAudioDuration(fileType, "./internal/storage/music_storage/"+tt.args.musicParse.FileHeader.Filename, musicRepository.FileSystem, f)
+				duration = tt.setupGetAudioDuration(fileType, filePath, musicRepository.FileSystem, f)
+			}
+			var explicit bool
+			if tt.setupGetExplicit != nil {
+				explicit = tt.setupGetExplicit(fileType, filePath, musicRepository.FileSystem, f)
+			} else if tt.setupCreate != nil {
+				f.utils.EXPECT().GetExplicit(fileType, filePath, musicRepository.FileSystem).Return(false, nil)
 			}
 
 			musicDB := &entity.MusicDB{
-				Name:     tt.args.musicParse.Name,
-				Release:  tt.args.musicParse.Release,
-				FileName: tt.args.musicParse.FileHeader.Filename,
-				Size:     uint64(tt.args.musicParse.FileHeader.Size),
-				Duration: duration,
+				Name:      tt.args.musicParse.Name,
+				Release:   tt.args.musicParse.Release,
+				FileName:  tt.args.musicParse.FileHeader.Filename,
+				Size:      uint64(tt.args.musicParse.FileHeader.Size),
+				Duration:  duration,
+				Explicit:  explicit,
+				PublishAt: tt.args.musicParse.Release,
+			}
+			if tt.args.musicParse.PublishAt != nil {
+				musicDB.PublishAt = *tt.args.musicParse.PublishAt
+			}
+			if !musicDB.PublishAt.After(time.Now()) {
+				musicDB.PublishedAt = &musicDB.PublishAt
 			}
 			if tt.setupCreate != nil {
 				tt.setupCreate(tt.args.ctx, musicDB, f)
 			}
 
-			err := musicRepository.Create(tt.args.ctx, tt.args.musicParse)
+			got, err := musicRepository.Create(tt.args.ctx, tt.args.musicParse)
 			if tt.wantErr == true {
 				assert.Error(t, err)
+				assert.Nil(t, got)
 			} else {
 				assert.NoError(t, err)
+				assert.NotNil(t, got)
 			}
 		})
 	}
@@ -560,8 +697,9 @@ func Test_Create(t *testing.T) {
 
 func Test_Update(t *testing.T) {
 	type fields struct {
-		source *db.MockMusicSource
-		utils  *utils.MockMusicUtils
+		source    *db.MockMusicSource
+		revisions *db.MockMusicRevisionSource
+		utils     *utils.MockMusicUtils
 	}
 	type args struct {
 		ctx        context.Context
@@ -613,7 +751,14 @@ func Test_Update(t *testing.T) {
 				return "3:15"
 			},
 			setupUpdate: func(ctx context.Context, musicUpdate *entity.MusicDB, f fields) {
-				f.source.EXPECT().Update(ctx, musicUpdate).Return(nil)
+				f.revisions.EXPECT().Apply(ctx, musicUpdate, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *entity.MusicDB, revision *entity.MusicRevisionDB, moves []entity.MusicFileMove) error {
+						assert.Equal(t, entity.MusicRevisionUpdate, revision.Action)
+						assert.Len(t, moves, 1)
+						assert.Equal(t, "./internal/storage/music_storage/Test.MP3", moves[0].From)
+						assert.Contains(t, moves[0].To, entity.MusicRevisionStorage)
+						return nil
+					})
 			},
 			wantErr: false,
 		},
@@ -627,8 +772,22 @@ func Test_Update(t *testing.T) {
 					Release: time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
 				},
 			},
+			setupGet: func(a args, f fields) {
+				f.source.EXPECT().Get(a.ctx, a.id).Return(&entity.MusicDB{
+					Id:       uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
+					Name:     "Song1",
+					Release:  time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
+					FileName: "Test.MP3",
+					Size:     uint64(500),
+					Duration: "2:47",
+				}, nil)
+			},
 			setupUpdate: func(ctx context.Context, musicUpdate *entity.MusicDB, f fields) {
-				f.source.EXPECT().Update(ctx, musicUpdate).Return(nil)
+				// Файл не меняется, поэтому в версию попадают данные текущего файла
+				musicUpdate.FileName = "Test.MP3"
+				musicUpdate.Size = 500
+				musicUpdate.Duration = "2:47"
+				f.revisions.EXPECT().Apply(ctx, musicUpdate, gomock.Any(), gomock.Nil()).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -722,7 +881,7 @@ func Test_Update(t *testing.T) {
 				return "3:15"
 			},
 			setupUpdate: func(ctx context.Context, musicCreate *entity.MusicDB, f fields) {
-				f.source.EXPECT().Update(ctx, musicCreate).Return(fmt.Errorf("Error in source.GetAll()"))
+				f.revisions.EXPECT().Apply(ctx, musicCreate, gomock.Any(), gomock.Any()).Return(fmt.Errorf("Error in source.GetAll()"))
 			},
 			wantErr: true,
 		},
@@ -733,11 +892,12 @@ func Test_Update(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			f := fields{
-				source: db.NewMockMusicSource(ctrl),
-				utils:  utils.NewMockMusicUtils(ctrl),
+				source:    db.NewMockMusicSource(ctrl),
+				revisions: db.NewMockMusicRevisionSource(ctrl),
+				utils:     utils.NewMockMusicUtils(ctrl),
 			}
 			os := utils.NewMockOS()
-			musicRepository := repository.NewMusicRepository(f.source, f.utils, os)
+			musicRepository := repository.NewMusicRepository(f.source, f.revisions, db.NewMockLyricsSource(ctrl), db.NewMockDuplicateSource(ctrl), f.utils, os)
 
 			if tt.setupGet != nil {
 				tt.setupGet(tt.args, f)
@@ -782,52 +942,42 @@ func Test_Update(t *testing.T) {
 func Test_Delete(t *testing.T) {
 	type fields struct {
 		source *db.MockMusicSource
-		utils  *utils.MockMusicUtils
 	}
 
 	type args struct {
 		ctx     context.Context
 		musicId uuid.UUID
+		now     time.Time
 	}
 
 	tests := []struct {
-		name        string
-		args        args
-		setupDelete func(a args, f fields)
-		setupGet    func(a args, f fields)
-		wantErr     bool
+		name    string
+		args    args
+		setup   func(a args, f fields)
+		wantErr error
 	}{
 		{
 			name: "Delete music",
 			args: args{
 				ctx:     context.Background(),
 				musicId: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
+				now:     time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
 			},
-			setupGet: func(a args, f fields) {
-				f.source.EXPECT().Get(a.ctx, a.musicId).Return(&entity.MusicDB{
-					Id:       uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
-					Name:     "Song1",
-					Release:  time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
-					FileName: "Song1.mp3",
-					Size:     uint64(500),
-					Duration: "2:47",
-				}, nil)
-			},
-			setupDelete: func(a args, f fields) {
-				f.source.EXPECT().Delete(a.ctx, a.musicId).Return(nil)
+			setup: func(a args, f fields) {
+				f.source.EXPECT().Delete(a.ctx, a.musicId, a.now).Return(nil)
 			},
-			wantErr: false,
 		},
 		{
 			name: "Not in base",
 			args: args{
 				ctx:     context.Background(),
 				musicId: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
+				now:     time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
 			},
-			setupGet: func(a args, f fields) {
-				f.source.EXPECT().Get(a.ctx, a.musicId).Return(nil, fmt.Errorf("Not in base"))
+			setup: func(a args, f fields) {
+				f.source.EXPECT().Delete(a.ctx, a.musicId, a.now).Return(sql.ErrNoRows)
 			},
-			wantErr: true,
+			wantErr: sql.ErrNoRows,
 		},
 	}
 
@@ -837,18 +987,15 @@ func Test_Delete(t *testing.T) {
 			defer ctrl.Finish()
 			f := fields{
 				source: db.NewMockMusicSource(ctrl),
-				utils:  utils.NewMockMusicUtils(ctrl),
-			}
-			os := utils.NewMockOS()
-			musicSource := repository.NewMusicRepository(f.source, f.utils, os)
-			tt.setupGet(tt.args, f)
-			if tt.setupDelete != nil {
-				tt.setupDelete(tt.args, f)
 			}
+			// Файлы трека остаются на месте до очистки корзины, поэтому моки версий и утилит не ожидают вызовов
+			musicSource := repository.NewMusicRepository(f.source, db.NewMockMusicRevisionSource(ctrl), db.NewMockLyricsSource(ctrl), db.NewMockDuplicateSource(ctrl),
+				utils.NewMockMusicUtils(ctrl), utils.NewMockOS())
+			tt.setup(tt.args, f)
 
-			err := musicSource.Delete(tt.args.ctx, tt.args.musicId)
-			if tt.wantErr == true {
-				assert.Error(t, err)
+			err := musicSource.Delete(tt.args.ctx, tt.args.musicId, tt.args.now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
 			} else {
 				assert.NoError(t, err)
 			}
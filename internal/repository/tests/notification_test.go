@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/notify"
+	"music-backend-test/internal/repository"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func Test_notificationRepository_Deliver(t *testing.T) {
+	email := "user@example.com"
+	notification := entity.NotificationDB{ID: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"), Type: entity.NotificationRelease}
+
+	tests := []struct {
+		name     string
+		delivery *entity.NotificationDelivery
+		setup    func(emailChannel *notify.MockChannel, webhookChannel *notify.MockChannel)
+		wantErr  bool
+	}{
+		{
+			name:     "Send to enabled channels",
+			delivery: &entity.NotificationDelivery{NotificationDB: notification, Email: &email, EmailEnabled: true, WebhookEnabled: true},
+			setup: func(emailChannel *notify.MockChannel, webhookChannel *notify.MockChannel) {
+				emailChannel.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+				webhookChannel.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:     "Skip email without address",
+			delivery: &entity.NotificationDelivery{NotificationDB: notification, EmailEnabled: true},
+			setup:    func(emailChannel *notify.MockChannel, webhookChannel *notify.MockChannel) {},
+		},
+		{
+			name:     "Keep sending after channel error",
+			delivery: &entity.NotificationDelivery{NotificationDB: notification, Email: &email, EmailEnabled: true, WebhookEnabled: true},
+			setup: func(emailChannel *notify.MockChannel, webhookChannel *notify.MockChannel) {
+				emailChannel.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+				webhookChannel.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			emailChannel := notify.NewMockChannel(ctrl)
+			emailChannel.EXPECT().Name().Return(entity.NotificationChannelEmail).AnyTimes()
+			webhookChannel := notify.NewMockChannel(ctrl)
+			webhookChannel.EXPECT().Name().Return(entity.NotificationChannelWebhook).AnyTimes()
+			tt.setup(emailChannel, webhookChannel)
+
+			r := repository.NewNotificationRepository(db.NewMockNotificationSource(ctrl), []notify.Channel{emailChannel, webhookChannel})
+			err := r.Deliver(context.Background(), tt.delivery)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("notificationRepository.Deliver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func Test_sessionRepository_IsRevoked(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	sessionId := uuid.MustParse("0b7e3c1a-4a1f-4d5e-9c55-6f2f8c3e1d20")
+
+	t.Run("active session is cached for ttl", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		source := db.NewMockSessionSource(ctrl)
+		r := repository.NewSessionRepository(source, repository.NewSessionCache(time.Minute))
+
+		source.EXPECT().IsActive(ctx, sessionId, now).Return(true, nil)
+		source.EXPECT().IsActive(ctx, sessionId, now.Add(time.Minute)).Return(false, nil)
+
+		for _, at := range []time.Time{now, now.Add(30 * time.Second)} {
+			revoked, err := r.IsRevoked(ctx, userId, sessionId, at)
+			if err != nil || revoked {
+				t.Fatalf("sessionRepository.IsRevoked() at %v = %v, %v", at, revoked, err)
+			}
+		}
+
+		revoked, err := r.IsRevoked(ctx, userId, sessionId, now.Add(time.Minute))
+		if err != nil || !revoked {
+			t.Fatalf("sessionRepository.IsRevoked() after ttl = %v, %v", revoked, err)
+		}
+
+		// отозванная сессия больше не проверяется в бд
+		revoked, err = r.IsRevoked(ctx, userId, sessionId, now.Add(time.Hour))
+		if err != nil || !revoked {
+			t.Fatalf("sessionRepository.IsRevoked() for revoked session = %v, %v", revoked, err)
+		}
+	})
+
+	t.Run("local revocation is visible immediately", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		source := db.NewMockSessionSource(ctrl)
+		r := repository.NewSessionRepository(source, repository.NewSessionCache(time.Minute))
+
+		source.EXPECT().IsActive(ctx, sessionId, now).Return(true, nil)
+		source.EXPECT().Revoke(ctx, userId, sessionId, now).Return(nil)
+
+		if _, err := r.IsRevoked(ctx, userId, sessionId, now); err != nil {
+			t.Fatalf("sessionRepository.IsRevoked() error = %v", err)
+		}
+		if err := r.Revoke(ctx, userId, sessionId, now); err != nil {
+			t.Fatalf("sessionRepository.Revoke() error = %v", err)
+		}
+
+		revoked, err := r.IsRevoked(ctx, userId, sessionId, now.Add(time.Second))
+		if err != nil || !revoked {
+			t.Fatalf("sessionRepository.IsRevoked() after Revoke = %v, %v", revoked, err)
+		}
+	})
+
+	t.Run("revoking all sessions of user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		source := db.NewMockSessionSource(ctrl)
+		r := repository.NewSessionRepository(source, repository.NewSessionCache(time.Minute))
+		otherId := uuid.MustParse("e3c8a4f1-2d6b-4b3f-a0f4-6a1c9e8d7b21")
+
+		source.EXPECT().IsActive(ctx, sessionId, now).Return(true, nil)
+		source.EXPECT().IsActive(ctx, otherId, now).Return(true, nil)
+		source.EXPECT().RevokeUser(ctx, userId, now).Return(nil)
+
+		r.IsRevoked(ctx, userId, sessionId, now)
+		r.IsRevoked(ctx, uuid.New(), otherId, now)
+		if err := r.RevokeAll(ctx, userId, now); err != nil {
+			t.Fatalf("sessionRepository.RevokeAll() error = %v", err)
+		}
+
+		if revoked, _ := r.IsRevoked(ctx, userId, sessionId, now); !revoked {
+			t.Errorf("sessionRepository.IsRevoked() must report revoked session of user")
+		}
+		if revoked, _ := r.IsRevoked(ctx, userId, otherId, now); revoked {
+			t.Errorf("sessionRepository.IsRevoked() must keep sessions of other users")
+		}
+	})
+
+	t.Run("logout through user repository shares cache", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		source := db.NewMockSessionSource(ctrl)
+		refreshTokens := db.NewMockRefreshTokenSource(ctrl)
+		cache := repository.NewSessionCache(time.Minute)
+		r := repository.NewSessionRepository(source, cache)
+		users := repository.NewUserRepository(db.NewMockUserSource(ctrl), refreshTokens, cache, repository.NewKeyManager(db.NewMockSigningKeySource(ctrl), time.Minute), utils.NewMockPasswordHasher(ctrl))
+
+		source.EXPECT().IsActive(ctx, sessionId, now).Return(true, nil)
+		refreshTokens.EXPECT().RevokeFamily(ctx, "token-hash", now).Return(sessionId, nil)
+
+		r.IsRevoked(ctx, userId, sessionId, now)
+		if err := users.RevokeRefreshTokenFamily(ctx, "token-hash", now); err != nil {
+			t.Fatalf("userRepository.RevokeRefreshTokenFamily() error = %v", err)
+		}
+
+		if revoked, _ := r.IsRevoked(ctx, userId, sessionId, now); !revoked {
+			t.Errorf("sessionRepository.IsRevoked() must report session revoked by logout")
+		}
+	})
+
+	t.Run("refresh token reuse revokes cached session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		source := db.NewMockSessionSource(ctrl)
+		refreshTokens := db.NewMockRefreshTokenSource(ctrl)
+		cache := repository.NewSessionCache(time.Minute)
+		r := repository.NewSessionRepository(source, cache)
+		users := repository.NewUserRepository(db.NewMockUserSource(ctrl), refreshTokens, cache, repository.NewKeyManager(db.NewMockSigningKeySource(ctrl), time.Minute), utils.NewMockPasswordHasher(ctrl))
+
+		source.EXPECT().IsActive(ctx, sessionId, now).Return(true, nil)
+		refreshTokens.EXPECT().Rotate(ctx, "token-hash", gomock.Any(), gomock.Any(), now).
+			Return(&entity.RefreshTokenDB{FamilyID: sessionId, UserID: userId}, entity.ErrRefreshTokenReused)
+
+		r.IsRevoked(ctx, userId, sessionId, now)
+		_, err := users.RotateRefreshToken(ctx, "token-hash", &entity.RefreshTokenDB{}, &entity.SessionDevice{}, now)
+		if !errors.Is(err, entity.ErrRefreshTokenReused) {
+			t.Fatalf("userRepository.RotateRefreshToken() error = %v, want entity.ErrRefreshTokenReused", err)
+		}
+
+		if revoked, _ := r.IsRevoked(ctx, userId, sessionId, now); !revoked {
+			t.Errorf("sessionRepository.IsRevoked() must report session revoked by refresh token reuse")
+		}
+	})
+
+	t.Run("error is not cached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		source := db.NewMockSessionSource(ctrl)
+		r := repository.NewSessionRepository(source, repository.NewSessionCache(time.Minute))
+
+		source.EXPECT().IsActive(ctx, sessionId, now).Return(false, fmt.Errorf("connection refused"))
+		source.EXPECT().IsActive(ctx, sessionId, now).Return(true, nil)
+
+		if _, err := r.IsRevoked(ctx, userId, sessionId, now); err == nil {
+			t.Fatalf("sessionRepository.IsRevoked() expected error")
+		}
+		if revoked, err := r.IsRevoked(ctx, userId, sessionId, now); err != nil || revoked {
+			t.Fatalf("sessionRepository.IsRevoked() = %v, %v", revoked, err)
+		}
+	})
+}
+
+func Test_sessionRepository_Revoke(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	sessionId := uuid.MustParse("0b7e3c1a-4a1f-4d5e-9c55-6f2f8c3e1d20")
+
+	ctrl := gomock.NewController(t)
+	source := db.NewMockSessionSource(ctrl)
+	r := repository.NewSessionRepository(source, repository.NewSessionCache(time.Minute))
+
+	source.EXPECT().Revoke(ctx, userId, sessionId, now).Return(sql.ErrNoRows)
+
+	err := r.Revoke(ctx, userId, sessionId, now)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("sessionRepository.Revoke() error = %v, want sql.ErrNoRows", err)
+	}
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var testKeysConfig = &entity.KeysConfig{
+	Algorithm:        entity.SigningAlgorithmEdDSA,
+	RotationInterval: 30 * 24 * time.Hour,
+	Overlap:          24 * time.Hour,
+	CacheTTL:         time.Minute,
+}
+
+func testSigningKeyDB(t *testing.T, activatesAt time.Time) *entity.SigningKeyDB {
+	key, err := entity.NewSigningKey(entity.SigningAlgorithmEdDSA, activatesAt, activatesAt, testKeysConfig)
+	if err != nil {
+		t.Fatalf("entity.NewSigningKey() error = %v", err)
+	}
+	return key
+}
+
+func Test_userRepository_SignAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	current := testSigningKeyDB(t, now.Add(-time.Hour))
+	next := testSigningKeyDB(t, now.Add(time.Hour))
+
+	source := db.NewMockSigningKeySource(ctrl)
+	// Следующий ключ уже опубликован, но подписывать начнет только через час
+	source.EXPECT().GetValid(gomock.Any(), now).Return([]*entity.SigningKeyDB{next, current}, nil)
+
+	keys := repository.NewKeyManager(source, time.Minute)
+	users := repository.NewUserRepository(db.NewMockUserSource(ctrl), db.NewMockRefreshTokenSource(ctrl), repository.NewSessionCache(time.Minute), keys, nil)
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	signed, err := users.SignAccessToken(context.Background(), entity.NewAccessClaims(userId, uuid.Nil, now, time.Minute), now)
+	assert.NoError(t, err)
+
+	token, _, err := new(jwt.Parser).ParseUnverified(signed, &entity.AccessClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, current.ID, token.Header["kid"])
+	assert.Equal(t, entity.SigningAlgorithmEdDSA, token.Header["alg"])
+
+	// Набор ключей закэширован: повторная проверка не обращается к бд
+	key, err := repository.NewSigningKeyRepository(source, keys).GetVerificationKey(context.Background(), current.ID, now)
+	assert.NoError(t, err)
+	_, _, err = entity.ParseToken(signed, func(*jwt.Token) (interface{}, error) { return key.Public, nil })
+	assert.NoError(t, err)
+}
+
+func Test_userRepository_SignAccessToken_noKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	source := db.NewMockSigningKeySource(ctrl)
+	source.EXPECT().GetValid(gomock.Any(), now).Return(nil, nil)
+
+	users := repository.NewUserRepository(db.NewMockUserSource(ctrl), db.NewMockRefreshTokenSource(ctrl), repository.NewSessionCache(time.Minute),
+		repository.NewKeyManager(source, time.Minute), nil)
+
+	_, err := users.SignAccessToken(context.Background(), entity.NewAccessClaims(uuid.New(), uuid.Nil, now, time.Minute), now)
+	assert.ErrorIs(t, err, entity.ErrNoSigningKey)
+}
+
+func Test_signingKeyRepository_GetVerificationKey(t *testing.T) {
+	now := time.Now()
+	current := testSigningKeyDB(t, now.Add(-time.Hour))
+	next := testSigningKeyDB(t, now.Add(time.Hour))
+
+	tests := []struct {
+		name    string
+		kid     string
+		setup   func(source *db.MockSigningKeySource)
+		wantErr error
+	}{
+		{
+			name: "success: cached key",
+			kid:  current.ID,
+			setup: func(source *db.MockSigningKeySource) {
+				source.EXPECT().GetValid(gomock.Any(), gomock.Any()).Return([]*entity.SigningKeyDB{current}, nil)
+			},
+		},
+		{
+			name: "success: key created by another instance is reloaded",
+			kid:  next.ID,
+			setup: func(source *db.MockSigningKeySource) {
+				gomock.InOrder(
+					source.EXPECT().GetValid(gomock.Any(), gomock.Any()).Return([]*entity.SigningKeyDB{current}, nil),
+					source.EXPECT().GetValid(gomock.Any(), gomock.Any()).Return([]*entity.SigningKeyDB{next, current}, nil),
+				)
+			},
+		},
+		{
+			name: "error: unknown key is reloaded only once per interval",
+			kid:  "unknown",
+			setup: func(source *db.MockSigningKeySource) {
+				source.EXPECT().GetValid(gomock.Any(), gomock.Any()).Return([]*entity.SigningKeyDB{current}, nil).Times(2)
+			},
+			wantErr: entity.ErrUnknownSigningKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			source := db.NewMockSigningKeySource(ctrl)
+			tt.setup(source)
+			r := repository.NewSigningKeyRepository(source, repository.NewKeyManager(source, time.Minute))
+
+			// Первая загрузка набора ключей
+			_, err := r.GetKeys(context.Background(), now)
+			assert.NoError(t, err)
+
+			for i := 0; i < 2; i++ {
+				key, err := r.GetVerificationKey(context.Background(), tt.kid, now.Add(entity.KeyReloadInterval))
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+					continue
+				}
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.kid, key.ID)
+				}
+			}
+		})
+	}
+}
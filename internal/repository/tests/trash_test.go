@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// removeRecorder запоминает удаленные файлы
+type removeRecorder struct {
+	*utils.MockOS
+	removed []string
+}
+
+func (r *removeRecorder) Remove(file string) error {
+	r.removed = append(r.removed, file)
+	return nil
+}
+
+func Test_trashRepository_Purge(t *testing.T) {
+	before := time.Date(2023, time.February, 22, 12, 0, 0, 0, time.UTC)
+	song := &entity.MusicDB{Id: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"), FileName: "Song1.mp3"}
+	shared := &entity.MusicDB{Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"), FileName: "Song2.mp3"}
+	archived := entity.MusicRevisionStorage + "/ff578289-cdca-406e-9a57-f8c773f0cd15_old_Song0.mp3"
+
+	tests := []struct {
+		name        string
+		setup       func(source *db.MockTrashSource, revisions *db.MockMusicRevisionSource)
+		wantRemoved []string
+		wantErr     bool
+	}{
+		{
+			name: "Purge music files and users",
+			setup: func(source *db.MockTrashSource, revisions *db.MockMusicRevisionSource) {
+				source.EXPECT().GetExpiredMusic(gomock.Any(), before).Return([]*entity.MusicDB{song, shared}, nil)
+				revisions.EXPECT().GetFilePaths(gomock.Any(), song.Id).Return([]string{song.FilePath(), archived}, nil)
+				source.EXPECT().PurgeMusic(gomock.Any(), song.Id, before).Return(false, nil)
+				revisions.EXPECT().GetFilePaths(gomock.Any(), shared.Id).Return([]string{shared.FilePath()}, nil)
+				source.EXPECT().PurgeMusic(gomock.Any(), shared.Id, before).Return(true, nil)
+				source.EXPECT().PurgeUsers(gomock.Any(), before).Return(int64(1), nil)
+			},
+			wantRemoved: []string{song.FilePath(), archived},
+		},
+		{
+			name: "Skip music restored before purge",
+			setup: func(source *db.MockTrashSource, revisions *db.MockMusicRevisionSource) {
+				source.EXPECT().GetExpiredMusic(gomock.Any(), before).Return([]*entity.MusicDB{song}, nil)
+				revisions.EXPECT().GetFilePaths(gomock.Any(), song.Id).Return([]string{song.FilePath(), archived}, nil)
+				source.EXPECT().PurgeMusic(gomock.Any(), song.Id, before).Return(false, sql.ErrNoRows)
+				source.EXPECT().PurgeUsers(gomock.Any(), before).Return(int64(0), nil)
+			},
+		},
+		{
+			name: "Error in PurgeMusic",
+			setup: func(source *db.MockTrashSource, revisions *db.MockMusicRevisionSource) {
+				source.EXPECT().GetExpiredMusic(gomock.Any(), before).Return([]*entity.MusicDB{song}, nil)
+				revisions.EXPECT().GetFilePaths(gomock.Any(), song.Id).Return(nil, nil)
+				source.EXPECT().PurgeMusic(gomock.Any(), song.Id, before).Return(false, fmt.Errorf("Error in PurgeMusic"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			source := db.NewMockTrashSource(ctrl)
+			revisions := db.NewMockMusicRevisionSource(ctrl)
+			tt.setup(source, revisions)
+
+			fs := &removeRecorder{MockOS: utils.NewMockOS()}
+			err := repository.NewTrashRepository(source, revisions, fs).Purge(context.Background(), before)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRemoved, fs.removed)
+		})
+	}
+}
@@ -2,10 +2,13 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"music-backend-test/internal/db"
 	"music-backend-test/internal/entity"
 	"music-backend-test/internal/repository"
+	"music-backend-test/internal/utils"
 	"reflect"
 	"testing"
 	"time"
@@ -17,6 +20,7 @@ import (
 func Test_userRepository_Create(t *testing.T) {
 	type fields struct {
 		source *db.MockUserSource
+		hasher *utils.MockPasswordHasher
 	}
 	type args struct {
 		ctx  context.Context
@@ -40,7 +44,9 @@ func Test_userRepository_Create(t *testing.T) {
 			},
 			want: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
 			setup: func(a args, f fields) {
-				f.source.EXPECT().CreateUser(a.ctx, a.user).Return(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"), nil)
+				f.hasher.EXPECT().Hash(a.user.Password).Return("$argon2id$hash", nil)
+				f.source.EXPECT().CreateUser(a.ctx, &entity.UserCreate{Username: "John", Password: "$argon2id$hash"}).
+					Return(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"), nil)
 			},
 			wantErr: false,
 		},
@@ -55,7 +61,8 @@ func Test_userRepository_Create(t *testing.T) {
 			},
 			want: uuid.Nil,
 			setup: func(a args, f fields) {
-				f.source.EXPECT().CreateUser(a.ctx, a.user).Return(uuid.Nil, fmt.Errorf("can't create user in source"))
+				f.hasher.EXPECT().Hash(a.user.Password).Return("$argon2id$hash", nil)
+				f.source.EXPECT().CreateUser(a.ctx, gomock.Any()).Return(uuid.Nil, fmt.Errorf("can't create user in source"))
 			},
 			wantErr: true,
 		},
@@ -65,9 +72,10 @@ func Test_userRepository_Create(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			f := fields{
 				source: db.NewMockUserSource(ctrl),
+				hasher: utils.NewMockPasswordHasher(ctrl),
 			}
 
-			r := repository.NewUserRepository(f.source)
+			r := repository.NewUserRepository(f.source, db.NewMockRefreshTokenSource(ctrl), repository.NewSessionCache(time.Minute), repository.NewKeyManager(db.NewMockSigningKeySource(ctrl), time.Minute), f.hasher)
 
 			tt.setup(tt.args, f)
 
@@ -134,7 +142,7 @@ func Test_userRepository_GetById(t *testing.T) {
 				source: db.NewMockUserSource(ctrl),
 			}
 
-			r := repository.NewUserRepository(f.source)
+			r := repository.NewUserRepository(f.source, db.NewMockRefreshTokenSource(ctrl), repository.NewSessionCache(time.Minute), repository.NewKeyManager(db.NewMockSigningKeySource(ctrl), time.Minute), utils.NewMockPasswordHasher(ctrl))
 
 			tt.setup(tt.args, f)
 
@@ -201,7 +209,7 @@ func Test_userRepository_GetByUsername(t *testing.T) {
 				source: db.NewMockUserSource(ctrl),
 			}
 
-			r := repository.NewUserRepository(f.source)
+			r := repository.NewUserRepository(f.source, db.NewMockRefreshTokenSource(ctrl), repository.NewSessionCache(time.Minute), repository.NewKeyManager(db.NewMockSigningKeySource(ctrl), time.Minute), utils.NewMockPasswordHasher(ctrl))
 
 			tt.setup(tt.args, f)
 
@@ -217,9 +225,108 @@ func Test_userRepository_GetByUsername(t *testing.T) {
 	}
 }
 
+func Test_userRepository_Authenticate(t *testing.T) {
+	type fields struct {
+		source *db.MockUserSource
+		hasher *utils.MockPasswordHasher
+	}
+	type args struct {
+		ctx      context.Context
+		username string
+		password string
+	}
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	tests := []struct {
+		name    string
+		args    args
+		want    *entity.UserDB
+		setup   func(a args, f fields)
+		wantErr error
+	}{
+		{
+			name: "success: Authenticate with current hash",
+			args: args{
+				ctx:      context.Background(),
+				username: "John",
+				password: "qwerty1234",
+			},
+			want: &entity.UserDB{ID: userId, Username: "John", Password: "$argon2id$hash"},
+			setup: func(a args, f fields) {
+				f.source.EXPECT().GetUserByUsername(a.ctx, a.username).Return(&entity.UserDB{ID: userId, Username: "John", Password: "$argon2id$hash"}, nil)
+				f.hasher.EXPECT().Verify("$argon2id$hash", a.password).Return(true, false)
+			},
+		},
+		{
+			name: "success: Authenticate rehashes plaintext password",
+			args: args{
+				ctx:      context.Background(),
+				username: "John",
+				password: "qwerty1234",
+			},
+			want: &entity.UserDB{ID: userId, Username: "John", Password: "$argon2id$hash"},
+			setup: func(a args, f fields) {
+				f.source.EXPECT().GetUserByUsername(a.ctx, a.username).Return(&entity.UserDB{ID: userId, Username: "John", Password: "qwerty1234"}, nil)
+				f.hasher.EXPECT().Verify("qwerty1234", a.password).Return(true, true)
+				f.hasher.EXPECT().Hash(a.password).Return("$argon2id$hash", nil)
+				f.source.EXPECT().UpdatePassword(a.ctx, userId, "qwerty1234", "$argon2id$hash").Return(nil)
+			},
+		},
+		{
+			name: "error: Authenticate with wrong password",
+			args: args{
+				ctx:      context.Background(),
+				username: "John",
+				password: "wrong",
+			},
+			setup: func(a args, f fields) {
+				f.source.EXPECT().GetUserByUsername(a.ctx, a.username).Return(&entity.UserDB{ID: userId, Username: "John", Password: "qwerty1234"}, nil)
+				f.hasher.EXPECT().Verify("qwerty1234", a.password).Return(false, false)
+			},
+			wantErr: entity.ErrInvalidCredentials,
+		},
+		{
+			name: "error: Authenticate unknown user",
+			args: args{
+				ctx:      context.Background(),
+				username: "Paul",
+				password: "qwerty1234",
+			},
+			setup: func(a args, f fields) {
+				f.source.EXPECT().GetUserByUsername(a.ctx, a.username).Return(nil, sql.ErrNoRows)
+				// Пароль проверяется и для несуществующего пользователя, чтобы время ответа не отличалось
+				f.hasher.EXPECT().Verify(utils.DummyPasswordHash, a.password).Return(false, false)
+			},
+			wantErr: entity.ErrInvalidCredentials,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			f := fields{
+				source: db.NewMockUserSource(ctrl),
+				hasher: utils.NewMockPasswordHasher(ctrl),
+			}
+
+			r := repository.NewUserRepository(f.source, db.NewMockRefreshTokenSource(ctrl), repository.NewSessionCache(time.Minute), repository.NewKeyManager(db.NewMockSigningKeySource(ctrl), time.Minute), f.hasher)
+
+			tt.setup(tt.args, f)
+
+			got, err := r.Authenticate(tt.args.ctx, tt.args.username, tt.args.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("userRepository.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("userRepository.Authenticate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_userRepository_Update(t *testing.T) {
 	type fields struct {
 		source *db.MockUserSource
+		hasher *utils.MockPasswordHasher
 	}
 	type args struct {
 		ctx  context.Context
@@ -255,7 +362,8 @@ func Test_userRepository_Update(t *testing.T) {
 					Role:     "USER",
 				}
 				f.source.EXPECT().GetUserById(a.ctx, a.id).Return(userDB, nil)
-				f.source.EXPECT().UpdateUser(a.ctx, userDB, a.user).Return(&entity.UserDB{
+				f.hasher.EXPECT().Hash(a.user.Password).Return("$argon2id$hash", nil)
+				f.source.EXPECT().UpdateUser(a.ctx, userDB, &entity.UserCreate{Username: "Paul", Password: "$argon2id$hash"}).Return(&entity.UserDB{
 					ID:       a.id,
 					Username: "Paul",
 					Role:     "USER",
@@ -285,9 +393,10 @@ func Test_userRepository_Update(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			f := fields{
 				source: db.NewMockUserSource(ctrl),
+				hasher: utils.NewMockPasswordHasher(ctrl),
 			}
 
-			r := repository.NewUserRepository(f.source)
+			r := repository.NewUserRepository(f.source, db.NewMockRefreshTokenSource(ctrl), repository.NewSessionCache(time.Minute), repository.NewKeyManager(db.NewMockSigningKeySource(ctrl), time.Minute), f.hasher)
 
 			tt.setup(tt.args, f)
 
@@ -310,6 +419,7 @@ func Test_userRepository_Delete(t *testing.T) {
 	type args struct {
 		ctx context.Context
 		id  uuid.UUID
+		now time.Time
 	}
 	tests := []struct {
 		name    string
@@ -323,6 +433,7 @@ func Test_userRepository_Delete(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				id:  uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				now: time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
 			},
 			want: nil,
 			setup: func(a args, f fields) {
@@ -330,7 +441,7 @@ func Test_userRepository_Delete(t *testing.T) {
 					ID:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
 					Username: "John",
 				}, nil)
-				f.source.EXPECT().DeleteUser(a.ctx, a.id).Return(nil)
+				f.source.EXPECT().DeleteUser(a.ctx, a.id, a.now).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -339,6 +450,7 @@ func Test_userRepository_Delete(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				id:  uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				now: time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
 			},
 			want: fmt.Errorf("can't delete user in source"),
 			setup: func(a args, f fields) {
@@ -354,11 +466,11 @@ func Test_userRepository_Delete(t *testing.T) {
 				source: db.NewMockUserSource(ctrl),
 			}
 
-			r := repository.NewUserRepository(f.source)
+			r := repository.NewUserRepository(f.source, db.NewMockRefreshTokenSource(ctrl), repository.NewSessionCache(time.Minute), repository.NewKeyManager(db.NewMockSigningKeySource(ctrl), time.Minute), utils.NewMockPasswordHasher(ctrl))
 
 			tt.setup(tt.args, f)
 
-			if err := r.Delete(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
+			if err := r.Delete(tt.args.ctx, tt.args.id, tt.args.now); (err != nil) != tt.wantErr {
 				t.Errorf("userRepository.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -411,7 +523,7 @@ func Test_userRepository_LikeTrack(t *testing.T) {
 			f := fields{
 				source: db.NewMockUserSource(ctrl),
 			}
-			r := repository.NewUserRepository(f.source)
+			r := repository.NewUserRepository(f.source, db.NewMockRefreshTokenSource(ctrl), repository.NewSessionCache(time.Minute), repository.NewKeyManager(db.NewMockSigningKeySource(ctrl), time.Minute), utils.NewMockPasswordHasher(ctrl))
 			tt.setup(tt.args, f)
 			err := r.LikeTrack(tt.args.ctx, tt.args.userId, tt.args.trackId)
 			if (err != nil) != tt.wantErr {
@@ -470,7 +582,7 @@ func Test_userRepository_DislikeTrack(t *testing.T) {
 			f := fields{
 				source: db.NewMockUserSource(ctrl),
 			}
-			r := repository.NewUserRepository(f.source)
+			r := repository.NewUserRepository(f.source, db.NewMockRefreshTokenSource(ctrl), repository.NewSessionCache(time.Minute), repository.NewKeyManager(db.NewMockSigningKeySource(ctrl), time.Minute), utils.NewMockPasswordHasher(ctrl))
 			tt.setup(tt.args, f)
 			err := r.DislikeTrack(tt.args.ctx, tt.args.userId, tt.args.trackId)
 			if (err != nil) != tt.wantErr {
@@ -568,7 +680,7 @@ func Test_userRepository_ShowLikedTracks(t *testing.T) {
 			f := fields{
 				source: db.NewMockUserSource(ctrl),
 			}
-			r := repository.NewUserRepository(f.source)
+			r := repository.NewUserRepository(f.source, db.NewMockRefreshTokenSource(ctrl), repository.NewSessionCache(time.Minute), repository.NewKeyManager(db.NewMockSigningKeySource(ctrl), time.Minute), utils.NewMockPasswordHasher(ctrl))
 			tt.setup(tt.args, f)
 			got, err := r.ShowLikedTracks(tt.args.ctx, tt.args.id)
 			if (err != nil) != tt.wantErr {
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/db"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type trashRepository struct {
+	source     db.TrashSource
+	revisions  db.MusicRevisionSource
+	FileSystem utils.FileSystem
+}
+
+func NewTrashRepository(source db.TrashSource, revisions db.MusicRevisionSource, filesystem utils.FileSystem) *trashRepository {
+	return &trashRepository{
+		source:     source,
+		revisions:  revisions,
+		FileSystem: filesystem,
+	}
+}
+
+func (r *trashRepository) GetMusic(ctx context.Context, limit int, offset int) ([]*entity.MusicDB, error) {
+	music, err := r.source.GetMusic(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/db/trash.GetMusic: %w", err)
+	}
+
+	return music, nil
+}
+
+func (r *trashRepository) GetUsers(ctx context.Context, limit int, offset int) ([]*entity.UserDB, error) {
+	users, err := r.source.GetUsers(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/db/trash.GetUsers: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *trashRepository) RestoreMusic(ctx context.Context, id uuid.UUID) error {
+	err := r.source.RestoreMusic(ctx, id)
+	if err != nil {
+		return fmt.Errorf("/db/trash.RestoreMusic: %w", err)
+	}
+
+	return nil
+}
+
+func (r *trashRepository) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	err := r.source.RestoreUser(ctx, id)
+	if err != nil {
+		return fmt.Errorf("/db/trash.RestoreUser: %w", err)
+	}
+
+	return nil
+}
+
+// Purge окончательно удаляет треки и пользователей, удаленных раньше before.
+// Файлы трека и его версий удаляются после удаления строки, чтобы восстановленный за это время трек не остался без файла.
+func (r *trashRepository) Purge(ctx context.Context, before time.Time) error {
+	expired, err := r.source.GetExpiredMusic(ctx, before)
+	if err != nil {
+		return fmt.Errorf("/db/trash.GetExpiredMusic: %w", err)
+	}
+
+	for _, music := range expired {
+		paths, err := r.revisions.GetFilePaths(ctx, music.Id)
+		if err != nil {
+			return fmt.Errorf("/db/music_revision.GetFilePaths: %w", err)
+		}
+
+		fileInUse, err := r.source.PurgeMusic(ctx, music.Id, before)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return fmt.Errorf("/db/trash.PurgeMusic: %w", err)
+		}
+
+		if !fileInUse {
+			r.FileSystem.Remove(music.FilePath())
+		}
+		// Файлы прошлых версий принадлежат только этому треку
+		for _, path := range paths {
+			if path != music.FilePath() {
+				r.FileSystem.Remove(path)
+			}
+		}
+	}
+
+	_, err = r.source.PurgeUsers(ctx, before)
+	if err != nil {
+		return fmt.Errorf("/db/trash.PurgeUsers: %w", err)
+	}
+
+	return nil
+}
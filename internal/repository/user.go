@@ -3,25 +3,41 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"music-backend-test/internal/db"
 	"music-backend-test/internal/entity"
+	"music-backend-test/internal/utils"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type userRepository struct {
-	source db.UserSource
+	source        db.UserSource
+	refreshTokens db.RefreshTokenSource
+	sessions      *sessionCache
+	keys          *keyManager
+	hasher        utils.PasswordHasher
 }
 
-func NewUserRepository(source db.UserSource) *userRepository {
+func NewUserRepository(source db.UserSource, refreshTokens db.RefreshTokenSource, sessions *sessionCache, keys *keyManager, hasher utils.PasswordHasher) *userRepository {
 	return &userRepository{
-		source: source,
+		source:        source,
+		refreshTokens: refreshTokens,
+		sessions:      sessions,
+		keys:          keys,
+		hasher:        hasher,
 	}
 }
 
 func (u *userRepository) Create(ctx context.Context, user *entity.UserCreate) (uuid.UUID, error) {
-	userId, err := u.source.CreateUser(ctx, user)
+	hashed, err := u.hashPassword(user)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	userId, err := u.source.CreateUser(ctx, hashed)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("can't create user: %w", err)
 	}
@@ -62,7 +78,12 @@ func (u *userRepository) Update(ctx context.Context, id uuid.UUID, user *entity.
 		return nil, fmt.Errorf("can't get user from db: %w", err)
 	}
 
-	dbUser, err := u.source.UpdateUser(ctx, userDB, user)
+	hashed, err := u.hashPassword(user)
+	if err != nil {
+		return nil, err
+	}
+
+	dbUser, err := u.source.UpdateUser(ctx, userDB, hashed)
 	if err != nil {
 		return nil, fmt.Errorf("Update: can't to update user: %w", err)
 	}
@@ -70,7 +91,118 @@ func (u *userRepository) Update(ctx context.Context, id uuid.UUID, user *entity.
 	return dbUser, nil
 }
 
-func (u *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// Authenticate проверяет пароль пользователя. Если пользователь не найден или пароль неверный, возвращается
+// entity.ErrInvalidCredentials. Для несуществующего пользователя пароль проверяется по utils.DummyPasswordHash,
+// чтобы ответ занимал столько же времени. Пароль, сохраненный открытым текстом, bcrypt или Argon2id
+// с устаревшими параметрами, после успешной проверки заменяется хэшем Argon2id.
+func (u *userRepository) Authenticate(ctx context.Context, username string, password string) (*entity.UserDB, error) {
+	user, err := u.source.GetUserByUsername(ctx, username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			u.hasher.Verify(utils.DummyPasswordHash, password)
+			return nil, entity.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("can't get user by username from db: %w", err)
+	}
+
+	ok, rehash := u.hasher.Verify(user.Password, password)
+	if !ok {
+		return nil, entity.ErrInvalidCredentials
+	}
+
+	if rehash {
+		hash, err := u.hasher.Hash(password)
+		if err != nil {
+			return nil, fmt.Errorf("can't hash password: %w", err)
+		}
+
+		err = u.source.UpdatePassword(ctx, user.ID, user.Password, hash)
+		if err != nil {
+			return nil, fmt.Errorf("can't update password: %w", err)
+		}
+		user.Password = hash
+	}
+
+	return user, nil
+}
+
+// hashPassword возвращает копию данных пользователя с хэшем вместо пароля
+func (u *userRepository) hashPassword(user *entity.UserCreate) (*entity.UserCreate, error) {
+	hash, err := u.hasher.Hash(user.Password)
+	if err != nil {
+		return nil, fmt.Errorf("can't hash password: %w", err)
+	}
+
+	return &entity.UserCreate{
+		Username: user.Username,
+		Password: hash,
+	}, nil
+}
+
+// SignAccessToken подписывает access-токен текущим ключом подписи
+func (u *userRepository) SignAccessToken(ctx context.Context, claims *entity.AccessClaims, now time.Time) (string, error) {
+	token, err := u.keys.sign(ctx, claims, now)
+	if err != nil {
+		return "", fmt.Errorf("can't sign access token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (u *userRepository) CreateRefreshToken(ctx context.Context, session *entity.SessionDB, token *entity.RefreshTokenDB) error {
+	err := u.refreshTokens.Create(ctx, session, token)
+	if err != nil {
+		return fmt.Errorf("/db/refresh_token.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (u *userRepository) RotateRefreshToken(ctx context.Context, tokenHash string, next *entity.RefreshTokenDB, device *entity.SessionDevice, now time.Time) (*entity.RefreshTokenDB, error) {
+	token, err := u.refreshTokens.Rotate(ctx, tokenHash, next, device, now)
+	if err != nil {
+		// Сессия отозвана в бд из-за повторного предъявления токена: access-токены отклоняются сразу, а не по истечении кэша
+		if errors.Is(err, entity.ErrRefreshTokenReused) && token != nil {
+			u.sessions.revoke(token.FamilyID)
+		}
+		return nil, fmt.Errorf("/db/refresh_token.Rotate: %w", err)
+	}
+
+	return token, nil
+}
+
+func (u *userRepository) RevokeRefreshTokenFamily(ctx context.Context, tokenHash string, now time.Time) error {
+	sessionId, err := u.refreshTokens.RevokeFamily(ctx, tokenHash, now)
+	if err != nil {
+		return fmt.Errorf("/db/refresh_token.RevokeFamily: %w", err)
+	}
+	if sessionId != uuid.Nil {
+		u.sessions.revoke(sessionId)
+	}
+
+	return nil
+}
+
+func (u *userRepository) RevokeRefreshTokens(ctx context.Context, userId uuid.UUID, now time.Time) error {
+	err := u.refreshTokens.RevokeUser(ctx, userId, now)
+	if err != nil {
+		return fmt.Errorf("/db/refresh_token.RevokeUser: %w", err)
+	}
+	u.sessions.revokeUser(userId)
+
+	return nil
+}
+
+func (u *userRepository) DeleteExpiredRefreshTokens(ctx context.Context, now time.Time) error {
+	err := u.refreshTokens.DeleteExpired(ctx, now)
+	if err != nil {
+		return fmt.Errorf("/db/refresh_token.DeleteExpired: %w", err)
+	}
+
+	return nil
+}
+
+func (u *userRepository) Delete(ctx context.Context, id uuid.UUID, now time.Time) error {
 	_, err := u.source.GetUserById(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -79,7 +211,7 @@ func (u *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("can't get user from db: %w", err)
 	}
 
-	err = u.source.DeleteUser(ctx, id)
+	err = u.source.DeleteUser(ctx, id, now)
 	if err != nil {
 		return fmt.Errorf("can't delete user from db: %w", err)
 	}
@@ -113,3 +245,12 @@ func (u *userRepository) ShowLikedTracks(ctx context.Context, id uuid.UUID) ([]*
 
 	return musicsDB, nil
 }
+
+func (u *userRepository) SetContentFilter(ctx context.Context, id uuid.UUID, filter *entity.ContentFilter) error {
+	err := u.source.SetContentFilter(ctx, id, filter)
+	if err != nil {
+		return fmt.Errorf("/db/user.SetContentFilter: %w", err)
+	}
+
+	return nil
+}
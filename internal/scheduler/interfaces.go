@@ -0,0 +1,14 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Task периодическая фоновая задача
+type Task func(ctx context.Context) error
+
+type Scheduler interface {
+	Add(name string, interval time.Duration, fn Task)
+	Run(ctx context.Context)
+}
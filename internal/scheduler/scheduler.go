@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type task struct {
+	name     string
+	interval time.Duration
+	fn       Task
+}
+
+type scheduler struct {
+	tasks  []*task
+	logger *zap.Logger
+}
+
+func NewScheduler(logger *zap.Logger) *scheduler {
+	return &scheduler{
+		logger: logger,
+	}
+}
+
+// Add регистрирует периодическую задачу. Задачи с неположительным интервалом не запускаются.
+func (s *scheduler) Add(name string, interval time.Duration, fn Task) {
+	if interval <= 0 {
+		s.logger.Warn("scheduler task disabled", zap.String("task", name))
+		return
+	}
+
+	s.tasks = append(s.tasks, &task{
+		name:     name,
+		interval: interval,
+		fn:       fn,
+	})
+}
+
+// Run запускает все задачи: каждая выполняется сразу и затем с заданным интервалом
+// до отмены контекста.
+func (s *scheduler) Run(ctx context.Context) {
+	for _, t := range s.tasks {
+		go s.loop(ctx, t)
+	}
+}
+
+func (s *scheduler) loop(ctx context.Context, t *task) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, t)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *scheduler) run(ctx context.Context, t *task) {
+	defer func() {
+		if e := recover(); e != nil {
+			s.logger.Error("scheduler task panic", zap.String("task", t.name), zap.Any("panic", e))
+		}
+	}()
+
+	start := time.Now()
+	err := t.fn(ctx)
+	if err != nil {
+		s.logger.Error("scheduler task failed", zap.String("task", t.name), zap.Error(err))
+		return
+	}
+
+	s.logger.Debug("scheduler task done", zap.String("task", t.name), zap.Duration("took", time.Since(start)))
+}
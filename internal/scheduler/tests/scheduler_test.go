@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/scheduler"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func Test_scheduler_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int32
+	var failures atomic.Int32
+	var disabled atomic.Int32
+
+	s := scheduler.NewScheduler(zap.NewNop())
+	s.Add("counter", 10*time.Millisecond, func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+	s.Add("failing", 10*time.Millisecond, func(ctx context.Context) error {
+		failures.Add(1)
+		return fmt.Errorf("task failed")
+	})
+	s.Add("disabled", 0, func(ctx context.Context) error {
+		disabled.Add(1)
+		return nil
+	})
+	s.Run(ctx)
+
+	// задача выполняется сразу и затем по таймеру, ошибки не останавливают расписание
+	assert.Eventually(t, func() bool {
+		return calls.Load() >= 3 && failures.Load() >= 3
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(0), disabled.Load())
+
+	cancel()
+	time.Sleep(30 * time.Millisecond)
+	stopped := calls.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, calls.Load())
+}
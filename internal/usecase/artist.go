@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type artistInteractor struct {
+	repo repository.ArtistRepository
+}
+
+func NewArtistInteractor(repo repository.ArtistRepository) *artistInteractor {
+	return &artistInteractor{
+		repo: repo,
+	}
+}
+
+func (a *artistInteractor) Create(ctx context.Context, artist *entity.ArtistCreate) (*entity.ArtistDB, error) {
+	if err := artist.Validate(); err != nil {
+		return nil, err
+	}
+
+	artistDB := artist.ToDB(time.Now())
+	err := a.repo.Create(ctx, artistDB)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/artist.Create: %w", err)
+	}
+
+	return artistDB, nil
+}
+
+func (a *artistInteractor) Get(ctx context.Context, id uuid.UUID) (*entity.ArtistDB, error) {
+	artist, err := a.repo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/artist.Get: %w", err)
+	}
+
+	return artist, nil
+}
+
+func (a *artistInteractor) SetMusicArtist(ctx context.Context, musicId uuid.UUID, artist *entity.MusicArtist) error {
+	err := a.repo.SetMusicArtist(ctx, musicId, artist.ArtistID)
+	if err != nil {
+		return fmt.Errorf("/repository/artist.SetMusicArtist: %w", err)
+	}
+
+	return nil
+}
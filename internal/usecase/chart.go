@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type chartInteractor struct {
+	repo repository.ChartRepository
+	cfg  *entity.ChartConfig
+}
+
+func NewChartInteractor(repo repository.ChartRepository, cfg *entity.ChartConfig) *chartInteractor {
+	return &chartInteractor{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// Snapshot сохраняет чарты всех завершившихся недель после последнего сохраненного чарта, чтобы недели,
+// пропущенные во время простоя, не терялись. Без сохраненных чартов сохраняется только последняя завершившаяся неделя.
+// Вызывается планировщиком.
+func (c *chartInteractor) Snapshot(ctx context.Context) error {
+	lastCompleted := entity.ChartWeekStart(time.Now()).AddDate(0, 0, -7)
+
+	week := lastCompleted
+	latest, err := c.repo.LatestWeek(ctx)
+	switch {
+	case err == nil:
+		week = entity.ChartWeekStart(latest).AddDate(0, 0, 7)
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("/repository/chart.LatestWeek: %w", err)
+	}
+
+	for ; !week.After(lastCompleted); week = week.AddDate(0, 0, 7) {
+		_, err := c.repo.Snapshot(ctx, c.cfg, week)
+		if err != nil {
+			return fmt.Errorf("/repository/chart.Snapshot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetWeekly возвращает чарт недели, в которую попадает week. Нулевое время означает последний сохраненный чарт.
+func (c *chartInteractor) GetWeekly(ctx context.Context, week time.Time, hideExplicit bool) ([]*entity.ChartEntryDB, error) {
+	if week.IsZero() {
+		latest, err := c.repo.LatestWeek(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, entity.ErrChartNotFound
+			}
+			return nil, fmt.Errorf("/repository/chart.LatestWeek: %w", err)
+		}
+		week = latest
+	}
+
+	week = entity.ChartWeekStart(week)
+	entries, err := c.repo.GetByWeek(ctx, week)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/chart.GetByWeek: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: week %s", entity.ErrChartNotFound, week.Format("2006-01-02"))
+	}
+
+	// Позиции сохраненного чарта не пересчитываются: скрытые треки оставляют пропуски
+	if hideExplicit {
+		visible := make([]*entity.ChartEntryDB, 0, len(entries))
+		for _, entry := range entries {
+			if !entry.Explicit {
+				visible = append(visible, entry)
+			}
+		}
+		entries = visible
+	}
+
+	return entries, nil
+}
+
+func (c *chartInteractor) GetMusicHistory(ctx context.Context, musicId uuid.UUID) ([]*entity.ChartEntryDB, error) {
+	entries, err := c.repo.GetByMusic(ctx, musicId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/chart.GetByMusic: %w", err)
+	}
+
+	return entries, nil
+}
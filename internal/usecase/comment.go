@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type commentInteractor struct {
+	repo repository.CommentRepository
+	cfg  *entity.CommentConfig
+}
+
+func NewCommentInteractor(repo repository.CommentRepository, cfg *entity.CommentConfig) *commentInteractor {
+	return &commentInteractor{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// Create публикует комментарий к треку или ответ на комментарий верхнего уровня
+func (c *commentInteractor) Create(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, comment *entity.CommentCreate) (*entity.CommentDB, error) {
+	if err := comment.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c.create(ctx, comment.ToDB(userId, musicId, time.Now()), nil)
+}
+
+// CreateForPlaylist публикует комментарий к плейлисту, видимому автору, и уведомляет владельца плейлиста
+func (c *commentInteractor) CreateForPlaylist(ctx context.Context, userId uuid.UUID, playlistId uuid.UUID, comment *entity.CommentCreate) (*entity.CommentDB, error) {
+	if err := comment.Validate(); err != nil {
+		return nil, err
+	}
+
+	playlist, err := c.repo.GetPlaylist(ctx, playlistId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/comment.GetPlaylist: %w", err)
+	}
+	if !playlist.VisibleTo(userId) {
+		return nil, fmt.Errorf("playlist is private: %w", sql.ErrNoRows)
+	}
+
+	return c.create(ctx, comment.ToPlaylistDB(userId, playlistId, time.Now()), playlist)
+}
+
+// create сохраняет комментарий commentDB и уведомляет автора родительского комментария
+// и владельца плейлиста playlist, если комментарий оставлен к плейлисту.
+// Количество комментариев пользователя за окно cfg.RateWindow ограничено cfg.RateLimit.
+func (c *commentInteractor) create(ctx context.Context, commentDB *entity.CommentDB, playlist *entity.PlaylistDB) (*entity.CommentDB, error) {
+	userId := commentDB.UserID
+
+	var parent *entity.CommentDB
+	if commentDB.ParentID != nil {
+		var err error
+		parent, err = c.repo.Get(ctx, *commentDB.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("/repository/comment.Get: %w", err)
+		}
+		if parent.Status != entity.CommentStatusVisible {
+			return nil, fmt.Errorf("parent comment is %s: %w", parent.Status, sql.ErrNoRows)
+		}
+		if parent.ParentID != nil {
+			return nil, entity.ErrCommentReplyDepth
+		}
+		if !parent.SameTarget(commentDB) {
+			return nil, fmt.Errorf("%w: parent comment belongs to another track or playlist", entity.ErrInvalidComment)
+		}
+	}
+
+	err := c.repo.Create(ctx, commentDB, c.cfg.RateLimit, commentDB.CreatedAt.Add(-c.cfg.RateWindow))
+	if err != nil {
+		if errors.Is(err, entity.ErrCommentRateLimited) {
+			return nil, fmt.Errorf("%w: %d comments per %s", entity.ErrCommentRateLimited, c.cfg.RateLimit, c.cfg.RateWindow)
+		}
+		return nil, fmt.Errorf("/repository/comment.Create: %w", err)
+	}
+
+	created, err := c.repo.Get(ctx, commentDB.ID)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/comment.Get: %w", err)
+	}
+
+	// Уведомления необязательны: комментарий уже опубликован, и ошибка уведомления его не отменяет
+	if parent != nil && parent.UserID != userId {
+		_ = c.repo.Notify(ctx, entity.NewCommentReplyNotification(parent, created))
+	}
+	// Владелец плейлиста, которому ответили на его же комментарий, уже получил уведомление об ответе
+	if playlist != nil && playlist.UserID != userId && (parent == nil || parent.UserID != playlist.UserID) {
+		_ = c.repo.Notify(ctx, entity.NewPlaylistCommentNotification(playlist, created))
+	}
+
+	return created, nil
+}
+
+func (c *commentInteractor) GetByMusic(ctx context.Context, musicId uuid.UUID, filter *entity.CommentFilter) (*entity.CommentPage, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkMusic(ctx, musicId, filter); err != nil {
+		return nil, err
+	}
+
+	comments, err := c.repo.GetByMusic(ctx, musicId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/comment.GetByMusic: %w", err)
+	}
+
+	return entity.NewCommentPage(comments, filter.Limit), nil
+}
+
+func (c *commentInteractor) GetByPlaylist(ctx context.Context, playlistId uuid.UUID, filter *entity.CommentFilter) (*entity.CommentPage, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkPlaylist(ctx, playlistId, filter); err != nil {
+		return nil, err
+	}
+
+	comments, err := c.repo.GetByPlaylist(ctx, playlistId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/comment.GetByPlaylist: %w", err)
+	}
+
+	return entity.NewCommentPage(comments, filter.Limit), nil
+}
+
+func (c *commentInteractor) GetReplies(ctx context.Context, commentId uuid.UUID, filter *entity.CommentFilter) (*entity.CommentPage, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+
+	parent, err := c.repo.Get(ctx, commentId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/comment.Get: %w", err)
+	}
+	if parent.Status != entity.CommentStatusVisible && !filter.IncludeHidden {
+		return nil, fmt.Errorf("comment is %s: %w", parent.Status, sql.ErrNoRows)
+	}
+	if parent.PlaylistID != nil {
+		err = c.checkPlaylist(ctx, *parent.PlaylistID, filter)
+	} else {
+		err = c.checkMusic(ctx, *parent.MusicID, filter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := c.repo.GetReplies(ctx, commentId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/comment.GetReplies: %w", err)
+	}
+
+	return entity.NewCommentPage(comments, filter.Limit), nil
+}
+
+// Update изменяет текст комментария. Редактировать комментарий может только его автор.
+func (c *commentInteractor) Update(ctx context.Context, userId uuid.UUID, commentId uuid.UUID, comment *entity.CommentUpdate) (*entity.CommentDB, error) {
+	if err := comment.Validate(); err != nil {
+		return nil, err
+	}
+
+	commentDB, err := c.repo.Get(ctx, commentId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/comment.Get: %w", err)
+	}
+	if commentDB.Status != entity.CommentStatusVisible {
+		return nil, fmt.Errorf("comment is %s: %w", commentDB.Status, sql.ErrNoRows)
+	}
+	if commentDB.UserID != userId {
+		return nil, entity.ErrCommentForbidden
+	}
+
+	err = c.repo.Update(ctx, commentId, comment.Body, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("/repository/comment.Update: %w", err)
+	}
+
+	updated, err := c.repo.Get(ctx, commentId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/comment.Get: %w", err)
+	}
+
+	return updated, nil
+}
+
+// Delete мягко удаляет комментарий. Удалить комментарий может автор или администратор.
+func (c *commentInteractor) Delete(ctx context.Context, userId uuid.UUID, commentId uuid.UUID, isAdmin bool) error {
+	commentDB, err := c.repo.Get(ctx, commentId)
+	if err != nil {
+		return fmt.Errorf("/repository/comment.Get: %w", err)
+	}
+	if commentDB.Status == entity.CommentStatusDeleted {
+		return fmt.Errorf("comment is already deleted: %w", sql.ErrNoRows)
+	}
+
+	var moderatorId *uuid.UUID
+	if commentDB.UserID != userId {
+		if !isAdmin {
+			return entity.ErrCommentForbidden
+		}
+		moderatorId = &userId
+	}
+
+	err = c.repo.SetStatus(ctx, commentId, entity.CommentStatusDeleted, moderatorId, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/comment.SetStatus: %w", err)
+	}
+
+	return nil
+}
+
+func (c *commentInteractor) Hide(ctx context.Context, moderatorId uuid.UUID, commentId uuid.UUID) error {
+	return c.moderate(ctx, moderatorId, commentId, entity.CommentStatusVisible, entity.CommentStatusHidden)
+}
+
+func (c *commentInteractor) Unhide(ctx context.Context, moderatorId uuid.UUID, commentId uuid.UUID) error {
+	return c.moderate(ctx, moderatorId, commentId, entity.CommentStatusHidden, entity.CommentStatusVisible)
+}
+
+// moderate переводит комментарий из статуса from в статус to от имени модератора
+func (c *commentInteractor) moderate(ctx context.Context, moderatorId uuid.UUID, commentId uuid.UUID, from string, to string) error {
+	commentDB, err := c.repo.Get(ctx, commentId)
+	if err != nil {
+		return fmt.Errorf("/repository/comment.Get: %w", err)
+	}
+	if commentDB.Status != from {
+		return fmt.Errorf("comment is %s: %w", commentDB.Status, sql.ErrNoRows)
+	}
+
+	err = c.repo.SetStatus(ctx, commentId, to, &moderatorId, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/comment.SetStatus: %w", err)
+	}
+
+	return nil
+}
+
+// Report сохраняет жалобу на видимый комментарий. Повторная жалоба того же пользователя возвращает ErrDuplicateReport.
+func (c *commentInteractor) Report(ctx context.Context, userId uuid.UUID, commentId uuid.UUID, report *entity.ReportCreate) error {
+	if err := report.Validate(); err != nil {
+		return err
+	}
+
+	commentDB, err := c.repo.Get(ctx, commentId)
+	if err != nil {
+		return fmt.Errorf("/repository/comment.Get: %w", err)
+	}
+	if commentDB.Status != entity.CommentStatusVisible {
+		return fmt.Errorf("comment is %s: %w", commentDB.Status, sql.ErrNoRows)
+	}
+	if commentDB.UserID == userId {
+		return fmt.Errorf("%w: can't report own comment", entity.ErrInvalidComment)
+	}
+
+	created, err := c.repo.CreateReport(ctx, report.ToDB(userId, commentId, time.Now()))
+	if err != nil {
+		return fmt.Errorf("/repository/comment.CreateReport: %w", err)
+	}
+	if !created {
+		return entity.ErrDuplicateReport
+	}
+
+	return nil
+}
+
+func (c *commentInteractor) GetModerationQueue(ctx context.Context, limit int, offset int) ([]*entity.ModerationItemDB, error) {
+	limit, offset = entity.NormalizeCommentPage(limit, offset)
+	items, err := c.repo.GetModerationQueue(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/comment.GetModerationQueue: %w", err)
+	}
+
+	return items, nil
+}
+
+func (c *commentInteractor) DismissReports(ctx context.Context, moderatorId uuid.UUID, commentId uuid.UUID) (int64, error) {
+	dismissed, err := c.repo.DismissReports(ctx, commentId, moderatorId, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("/repository/comment.DismissReports: %w", err)
+	}
+	if dismissed == 0 {
+		return 0, fmt.Errorf("no open reports: %w", sql.ErrNoRows)
+	}
+
+	return dismissed, nil
+}
+
+// checkMusic возвращает sql.ErrNoRows, если комментарии трека недоступны: трек в корзине
+// или не опубликован, а filter не разрешает показывать неопубликованные треки
+func (c *commentInteractor) checkMusic(ctx context.Context, musicId uuid.UUID, filter *entity.CommentFilter) error {
+	visible, err := c.repo.MusicVisible(ctx, musicId, filter.ShowUnpublished)
+	if err != nil {
+		return fmt.Errorf("/repository/comment.MusicVisible: %w", err)
+	}
+	if !visible {
+		return fmt.Errorf("music not found: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// checkPlaylist возвращает sql.ErrNoRows, если плейлиста нет или он приватный и не принадлежит читателю
+func (c *commentInteractor) checkPlaylist(ctx context.Context, playlistId uuid.UUID, filter *entity.CommentFilter) error {
+	playlist, err := c.repo.GetPlaylist(ctx, playlistId)
+	if err != nil {
+		return fmt.Errorf("/repository/comment.GetPlaylist: %w", err)
+	}
+	if !playlist.VisibleTo(filter.ViewerID) {
+		return fmt.Errorf("playlist is private: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/export"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type exportInteractor struct {
+	repo repository.ExportRepository
+	cfg  *entity.ExportConfig
+}
+
+func NewExportInteractor(repo repository.ExportRepository, cfg *entity.ExportConfig) *exportInteractor {
+	return &exportInteractor{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// Start проверяет запрос выгрузки. Небольшая выгрузка отдается прямо в ответе: задача не создается и возвращается nil.
+// Выгрузка больше SyncLimit записей или запрошенная с Async ставится в очередь фоновой задачей.
+func (e *exportInteractor) Start(ctx context.Context, userId uuid.UUID, request *entity.ExportRequest) (*entity.ExportJobDB, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	items, err := e.repo.CountItems(ctx, userId, request)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/export.CountItems: %w", err)
+	}
+	if !request.Async && items <= int64(e.cfg.SyncLimit) {
+		return nil, nil
+	}
+
+	job := request.ToJob(userId, items, time.Now())
+	err = e.repo.CreateJob(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/export.CreateJob: %w", err)
+	}
+
+	return job, nil
+}
+
+// Write записывает выгрузку в w. История читается страницами, поэтому память не зависит от ее размера.
+func (e *exportInteractor) Write(ctx context.Context, userId uuid.UUID, request *entity.ExportRequest, w io.Writer) error {
+	encoder, err := export.NewEncoder(request, w, export.Options{
+		UserID:     userId,
+		ExportedAt: time.Now(),
+		TrackURL:   e.cfg.TrackURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !request.IsPlaylistFormat() {
+		likes, err := e.repo.GetLikes(ctx, userId)
+		if err != nil {
+			return fmt.Errorf("/repository/export.GetLikes: %w", err)
+		}
+		if err := encoder.WriteLikes(likes); err != nil {
+			return fmt.Errorf("can't write likes: %w", err)
+		}
+	}
+
+	playlists, err := e.repo.GetPlaylists(ctx, userId, request.PlaylistID)
+	if err != nil {
+		return fmt.Errorf("/repository/export.GetPlaylists: %w", err)
+	}
+	if request.PlaylistID != nil && len(playlists) == 0 {
+		return fmt.Errorf("/repository/export.GetPlaylists: %w", sql.ErrNoRows)
+	}
+	for _, playlist := range playlists {
+		tracks, err := e.repo.GetPlaylistTracks(ctx, playlist.ID)
+		if err != nil {
+			return fmt.Errorf("/repository/export.GetPlaylistTracks: %w", err)
+		}
+		if err := encoder.WritePlaylist(playlist, tracks); err != nil {
+			return fmt.Errorf("can't write playlist: %w", err)
+		}
+	}
+
+	if !request.IsPlaylistFormat() {
+		ratings, err := e.repo.GetRatings(ctx, userId)
+		if err != nil {
+			return fmt.Errorf("/repository/export.GetRatings: %w", err)
+		}
+		if err := encoder.WriteRatings(ratings); err != nil {
+			return fmt.Errorf("can't write ratings: %w", err)
+		}
+
+		if err := e.writeHistory(ctx, userId, encoder); err != nil {
+			return err
+		}
+	}
+
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("can't finish export: %w", err)
+	}
+
+	return nil
+}
+
+func (e *exportInteractor) writeHistory(ctx context.Context, userId uuid.UUID, encoder export.Encoder) error {
+	var afterAt time.Time
+	var afterId uuid.UUID
+	for {
+		events, err := e.repo.GetHistory(ctx, userId, afterAt, afterId, entity.ExportHistoryBatchSize)
+		if err != nil {
+			return fmt.Errorf("/repository/export.GetHistory: %w", err)
+		}
+		if err := encoder.WriteHistory(events); err != nil {
+			return fmt.Errorf("can't write history: %w", err)
+		}
+		if len(events) < entity.ExportHistoryBatchSize {
+			return nil
+		}
+
+		last := events[len(events)-1]
+		afterAt, afterId = last.PlayedAt, last.ID
+	}
+}
+
+// GetJob возвращает задачу выгрузки. Задачи других пользователей не находятся.
+func (e *exportInteractor) GetJob(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.ExportJobDB, error) {
+	job, err := e.repo.GetJob(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/export.GetJob: %w", err)
+	}
+	if job.UserID != userId {
+		return nil, fmt.Errorf("/repository/export.GetJob: %w", sql.ErrNoRows)
+	}
+
+	return job, nil
+}
+
+// GetDownload возвращает задачу, файл которой можно скачать
+func (e *exportInteractor) GetDownload(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.ExportJobDB, error) {
+	job, err := e.GetJob(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := job.CheckDownload(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// ProcessJobs удаляет устаревшие файлы выгрузок и выполняет задачи из очереди. Ошибка одной задачи
+// отмечается в самой задаче и не останавливает остальные.
+func (e *exportInteractor) ProcessJobs(ctx context.Context) error {
+	now := time.Now()
+	var errs []error
+
+	expired, err := e.repo.DeleteExpiredJobs(ctx, now, e.cfg.BatchSize)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("/repository/export.DeleteExpiredJobs: %w", err))
+	}
+	for _, job := range expired {
+		if err := e.repo.RemoveFile(job); err != nil {
+			errs = append(errs, fmt.Errorf("/repository/export.RemoveFile %s: %w", job.ID, err))
+		}
+	}
+
+	jobs, err := e.repo.ClaimJobs(ctx, now, now.Add(-e.cfg.JobTimeout), e.cfg.BatchSize)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("/repository/export.ClaimJobs: %w", err))
+		return errors.Join(errs...)
+	}
+	for _, job := range jobs {
+		size, err := e.repo.WriteFile(job, func(w io.Writer) error {
+			return e.Write(ctx, job.UserID, job.Request(), w)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("/repository/export.WriteFile %s: %w", job.ID, err))
+		}
+
+		job.Finish(size, err, time.Now(), e.cfg.Retention)
+		if err := e.repo.FinishJob(ctx, job); err != nil {
+			errs = append(errs, fmt.Errorf("/repository/export.FinishJob %s: %w", job.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
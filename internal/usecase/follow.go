@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type followInteractor struct {
+	repo repository.FollowRepository
+}
+
+func NewFollowInteractor(repo repository.FollowRepository) *followInteractor {
+	return &followInteractor{
+		repo: repo,
+	}
+}
+
+func (f *followInteractor) FollowArtist(ctx context.Context, userId uuid.UUID, artistId uuid.UUID) error {
+	err := f.repo.FollowArtist(ctx, userId, artistId, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/follow.FollowArtist: %w", err)
+	}
+
+	return nil
+}
+
+func (f *followInteractor) UnfollowArtist(ctx context.Context, userId uuid.UUID, artistId uuid.UUID) error {
+	err := f.repo.UnfollowArtist(ctx, userId, artistId)
+	if err != nil {
+		return fmt.Errorf("/repository/follow.UnfollowArtist: %w", err)
+	}
+
+	return nil
+}
+
+func (f *followInteractor) FollowUser(ctx context.Context, followerId uuid.UUID, followeeId uuid.UUID) error {
+	if followerId == followeeId {
+		return entity.ErrSelfFollow
+	}
+
+	err := f.repo.FollowUser(ctx, followerId, followeeId, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/follow.FollowUser: %w", err)
+	}
+
+	return nil
+}
+
+func (f *followInteractor) UnfollowUser(ctx context.Context, followerId uuid.UUID, followeeId uuid.UUID) error {
+	err := f.repo.UnfollowUser(ctx, followerId, followeeId)
+	if err != nil {
+		return fmt.Errorf("/repository/follow.UnfollowUser: %w", err)
+	}
+
+	return nil
+}
+
+func (f *followInteractor) GetFollowing(ctx context.Context, userId uuid.UUID) (*entity.Following, error) {
+	following, err := f.repo.GetFollowing(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/follow.GetFollowing: %w", err)
+	}
+
+	return following, nil
+}
+
+func (f *followInteractor) GetFeed(ctx context.Context, userId uuid.UUID, filter *entity.FeedFilter) (*entity.FeedPage, error) {
+	filter.Normalize()
+
+	items, err := f.repo.GetFeed(ctx, userId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/follow.GetFeed: %w", err)
+	}
+
+	return entity.NewFeedPage(items, filter.Limit), nil
+}
+
+func (f *followInteractor) SetPrivacy(ctx context.Context, userId uuid.UUID, privacy *entity.FeedPrivacy) error {
+	err := f.repo.SetPrivacy(ctx, userId, privacy)
+	if err != nil {
+		return fmt.Errorf("/repository/follow.SetPrivacy: %w", err)
+	}
+
+	return nil
+}
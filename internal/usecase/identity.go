@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type identityInteractor struct {
+	repo repository.IdentityRepository
+	cfg  *entity.OIDCConfig
+}
+
+func NewIdentityInteractor(repo repository.IdentityRepository, cfg *entity.OIDCConfig) *identityInteractor {
+	return &identityInteractor{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// Begin начинает вход через провайдера и возвращает адрес его страницы входа и state.
+// Если задан userId, учетная запись провайдера после входа привязывается к этому пользователю.
+func (i *identityInteractor) Begin(ctx context.Context, userId *uuid.UUID) (*entity.OIDCAuthorization, error) {
+	login, err := entity.NewOIDCLogin(userId, time.Now(), i.cfg.StateTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := i.repo.Begin(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/identity.Begin: %w", err)
+	}
+
+	return &entity.OIDCAuthorization{URL: authURL, State: login.State, ExpiresAt: login.ExpiresAt}, nil
+}
+
+// Complete завершает вход с state по коду авторизации от провайдера и возвращает пользователя.
+// При первом входе создается пользователь с ролью по умолчанию; участники группы администраторов
+// провайдера получают роль ADMIN, а при выходе из группы — теряют ее, если она была выдана по группе.
+// Привязку может завершить только пользователь userId, который ее начал, иначе возвращается
+// entity.ErrOIDCLinkForbidden. Неизвестный или истекший вход возвращает entity.ErrInvalidOIDCLogin.
+func (i *identityInteractor) Complete(ctx context.Context, state string, code string, userId *uuid.UUID) (*entity.UserDB, error) {
+	now := time.Now()
+
+	login, err := i.repo.ConsumeLogin(ctx, state, now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrInvalidOIDCLogin
+		}
+		return nil, fmt.Errorf("/repository/identity.ConsumeLogin: %w", err)
+	}
+	if login.UserID != nil && (userId == nil || *userId != *login.UserID) {
+		return nil, entity.ErrOIDCLinkForbidden
+	}
+
+	identity, err := i.repo.Exchange(ctx, login, code)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/identity.Exchange: %w", err)
+	}
+
+	password, err := entity.NewUnusablePassword()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := i.repo.Login(ctx, &entity.IdentityLogin{
+		Issuer:     identity.Issuer,
+		Subject:    identity.Subject,
+		LinkUserID: login.UserID,
+		NewUser:    &entity.UserCreate{Username: identity.Username(), Password: password},
+		Role:       i.cfg.DefaultRole,
+		Admin:      i.cfg.AdminGroup != "" && identity.InGroup(i.cfg.AdminGroup),
+	}, now)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/identity.Login: %w", err)
+	}
+
+	return user, nil
+}
+
+func (i *identityInteractor) GetByUser(ctx context.Context, userId uuid.UUID) ([]*entity.UserIdentityDB, error) {
+	identities, err := i.repo.GetByUser(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/identity.GetByUser: %w", err)
+	}
+
+	return identities, nil
+}
+
+// CleanupLogins удаляет входы, которые не были завершены вовремя
+func (i *identityInteractor) CleanupLogins(ctx context.Context) error {
+	err := i.repo.DeleteExpiredLogins(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/identity.DeleteExpiredLogins: %w", err)
+	}
+
+	return nil
+}
@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"io"
 	"music-backend-test/internal/entity"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,19 +14,203 @@ type UserInteractor interface {
 	Create(ctx context.Context, user *entity.UserCreate) (uuid.UUID, error)
 	GetById(ctx context.Context, id uuid.UUID) (*entity.UserDB, error)
 	GetByUsername(ctx context.Context, username string) (*entity.UserDB, error)
+	SignIn(ctx context.Context, user *entity.UserCreate) (*entity.UserDB, error)
 	Update(ctx context.Context, id uuid.UUID, user *entity.UserCreate) (*entity.UserDB, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	LikeTrack(ctx context.Context, userId uuid.UUID, trackId uuid.UUID) error
 	DislikeTrack(ctx context.Context, userId uuid.UUID, trackId uuid.UUID) error
 	ShowLikedTracks(ctx context.Context, id uuid.UUID) ([]*entity.MusicDB, error)
+	SetContentFilter(ctx context.Context, id uuid.UUID, filter *entity.ContentFilter) error
+	IssueTokens(ctx context.Context, userId uuid.UUID, device *entity.SessionDevice) (*entity.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string, device *entity.SessionDevice) (*entity.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	LogoutAll(ctx context.Context, userId uuid.UUID) error
+	CleanupRefreshTokens(ctx context.Context) error
 }
 
 type MusicInteractor interface {
-	GetAll(ctx context.Context) ([]*entity.MusicDB, error)
-	Get(ctx context.Context, musicId uuid.UUID) (*entity.MusicDB, error)
-	GetAndSortByPopular(ctx context.Context) ([]*entity.MusicDB, error)
-	GetAllSortByTime(ctx context.Context) ([]*entity.MusicDB, error)
-	Create(ctx context.Context, musicCreate *entity.MusicParse) error
+	GetAll(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error)
+	Get(ctx context.Context, musicId uuid.UUID, filter entity.MusicFilter) (*entity.MusicDB, error)
+	GetAllSortByTime(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error)
+	GetAllSortByRating(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error)
+	Create(ctx context.Context, musicCreate *entity.MusicParse) (*entity.MusicUploadReport, error)
 	Update(ctx context.Context, id uuid.UUID, musicUpdate *entity.MusicParse) error
+	SetExplicit(ctx context.Context, id uuid.UUID, explicit bool) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	GetDuplicates(ctx context.Context, id uuid.UUID) ([]*entity.MusicDuplicateDB, error)
+	Merge(ctx context.Context, id uuid.UUID, merge *entity.MusicMerge) (*entity.MusicMergeResult, error)
+}
+
+type PlayInteractor interface {
+	Record(ctx context.Context, userId uuid.UUID, event *entity.PlayEventCreate) (bool, error)
+	RecordBatch(ctx context.Context, userId uuid.UUID, events []*entity.PlayEventCreate) (*entity.PlayBatchResult, error)
+	RecordStream(ctx context.Context, userId uuid.UUID, musicId uuid.UUID) error
+	GetHistory(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) ([]*entity.PlayEventDB, error)
+	DeleteHistory(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) (int64, error)
+	DeleteHistoryEvent(ctx context.Context, userId uuid.UUID, eventId uuid.UUID) error
+}
+
+type PopularityInteractor interface {
+	GetPopular(ctx context.Context, window string, limit int, offset int, hideExplicit bool) ([]*entity.MusicPopularityDB, error)
+	GetTrending(ctx context.Context, limit int, offset int, hideExplicit bool) ([]*entity.MusicPopularityDB, error)
+	Refresh(ctx context.Context) error
+}
+
+type ChartInteractor interface {
+	Snapshot(ctx context.Context) error
+	GetWeekly(ctx context.Context, week time.Time, hideExplicit bool) ([]*entity.ChartEntryDB, error)
+	GetMusicHistory(ctx context.Context, musicId uuid.UUID) ([]*entity.ChartEntryDB, error)
+}
+
+type RecommendationInteractor interface {
+	GetForUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.RecommendationDB, error)
+	GetSimilar(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, limit int, offset int) ([]*entity.SimilarMusicDB, error)
+	Refresh(ctx context.Context) error
+}
+
+type RatingInteractor interface {
+	Set(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, rating *entity.RatingCreate) (*entity.RatingSummary, error)
+	Clear(ctx context.Context, userId uuid.UUID, musicId uuid.UUID) (*entity.RatingSummary, error)
+	GetLibrary(ctx context.Context, userId uuid.UUID, sort string) ([]*entity.LibraryMusicDB, error)
+}
+
+type CommentInteractor interface {
+	Create(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, comment *entity.CommentCreate) (*entity.CommentDB, error)
+	CreateForPlaylist(ctx context.Context, userId uuid.UUID, playlistId uuid.UUID, comment *entity.CommentCreate) (*entity.CommentDB, error)
+	GetByMusic(ctx context.Context, musicId uuid.UUID, filter *entity.CommentFilter) (*entity.CommentPage, error)
+	GetByPlaylist(ctx context.Context, playlistId uuid.UUID, filter *entity.CommentFilter) (*entity.CommentPage, error)
+	GetReplies(ctx context.Context, commentId uuid.UUID, filter *entity.CommentFilter) (*entity.CommentPage, error)
+	Update(ctx context.Context, userId uuid.UUID, commentId uuid.UUID, comment *entity.CommentUpdate) (*entity.CommentDB, error)
+	Delete(ctx context.Context, userId uuid.UUID, commentId uuid.UUID, isAdmin bool) error
+	Hide(ctx context.Context, moderatorId uuid.UUID, commentId uuid.UUID) error
+	Unhide(ctx context.Context, moderatorId uuid.UUID, commentId uuid.UUID) error
+	Report(ctx context.Context, userId uuid.UUID, commentId uuid.UUID, report *entity.ReportCreate) error
+	GetModerationQueue(ctx context.Context, limit int, offset int) ([]*entity.ModerationItemDB, error)
+	DismissReports(ctx context.Context, moderatorId uuid.UUID, commentId uuid.UUID) (int64, error)
+}
+
+type LyricsInteractor interface {
+	Get(ctx context.Context, musicId uuid.UUID, language string, showUnpublished bool) (*entity.Lyrics, error)
+	Set(ctx context.Context, musicId uuid.UUID, lyrics *entity.LyricsUpload) (*entity.Lyrics, error)
+	Delete(ctx context.Context, musicId uuid.UUID, language string) error
+}
+
+type MusicRevisionInteractor interface {
+	GetByMusic(ctx context.Context, musicId uuid.UUID, limit int, offset int) ([]*entity.MusicRevisionDB, error)
+	Get(ctx context.Context, musicId uuid.UUID, version int) (*entity.MusicRevisionDB, error)
+	Diff(ctx context.Context, musicId uuid.UUID, from int, to int) (*entity.MusicRevisionDiff, error)
+	Revert(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, version int) (*entity.MusicRevisionDB, error)
+}
+
+type TrashInteractor interface {
+	GetMusic(ctx context.Context, limit int, offset int) ([]*entity.MusicDB, error)
+	GetUsers(ctx context.Context, limit int, offset int) ([]*entity.UserDB, error)
+	RestoreMusic(ctx context.Context, id uuid.UUID) error
+	RestoreUser(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context) error
+}
+
+type ReleaseInteractor interface {
+	GetScheduled(ctx context.Context, limit int, offset int) ([]*entity.MusicDB, error)
+	SetSchedule(ctx context.Context, id uuid.UUID, schedule *entity.MusicSchedule) (*entity.MusicDB, error)
+	Publish(ctx context.Context) error
+}
+
+type ArtistInteractor interface {
+	Create(ctx context.Context, artist *entity.ArtistCreate) (*entity.ArtistDB, error)
+	Get(ctx context.Context, id uuid.UUID) (*entity.ArtistDB, error)
+	SetMusicArtist(ctx context.Context, musicId uuid.UUID, artist *entity.MusicArtist) error
+}
+
+type PlaylistInteractor interface {
+	Create(ctx context.Context, userId uuid.UUID, playlist *entity.PlaylistCreate) (*entity.PlaylistDB, error)
+	Get(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.PlaylistDB, error)
+	GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.PlaylistDB, error)
+	Update(ctx context.Context, userId uuid.UUID, id uuid.UUID, playlist *entity.PlaylistCreate) (*entity.PlaylistDB, error)
+	Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+	GetTracks(ctx context.Context, userId uuid.UUID, id uuid.UUID) ([]*entity.MusicDB, error)
+	AddTrack(ctx context.Context, userId uuid.UUID, id uuid.UUID, musicId uuid.UUID) error
+	RemoveTrack(ctx context.Context, userId uuid.UUID, id uuid.UUID, musicId uuid.UUID) error
+}
+
+type FollowInteractor interface {
+	FollowArtist(ctx context.Context, userId uuid.UUID, artistId uuid.UUID) error
+	UnfollowArtist(ctx context.Context, userId uuid.UUID, artistId uuid.UUID) error
+	FollowUser(ctx context.Context, followerId uuid.UUID, followeeId uuid.UUID) error
+	UnfollowUser(ctx context.Context, followerId uuid.UUID, followeeId uuid.UUID) error
+	GetFollowing(ctx context.Context, userId uuid.UUID) (*entity.Following, error)
+	GetFeed(ctx context.Context, userId uuid.UUID, filter *entity.FeedFilter) (*entity.FeedPage, error)
+	SetPrivacy(ctx context.Context, userId uuid.UUID, privacy *entity.FeedPrivacy) error
+}
+
+type NotificationInteractor interface {
+	GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.NotificationFilter) (*entity.NotificationPage, error)
+	MarkRead(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, userId uuid.UUID) (int64, error)
+	GetSettings(ctx context.Context, userId uuid.UUID) (*entity.NotificationSettings, error)
+	SetSettings(ctx context.Context, userId uuid.UUID, settings *entity.NotificationSettings) (*entity.NotificationSettings, error)
+	Deliver(ctx context.Context) error
+}
+
+type ShareInteractor interface {
+	CreateForMusic(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, share *entity.ShareLinkCreate) (*entity.ShareLinkCreated, error)
+	CreateForPlaylist(ctx context.Context, userId uuid.UUID, playlistId uuid.UUID, share *entity.ShareLinkCreate) (*entity.ShareLinkCreated, error)
+	GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.ShareLinkDB, error)
+	Revoke(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+	Resolve(ctx context.Context, token string) (*entity.ShareLinkDB, error)
+	GetContent(ctx context.Context, link *entity.ShareLinkDB) (*entity.SharedContent, error)
+	Stream(ctx context.Context, link *entity.ShareLinkDB, musicId *uuid.UUID) (*entity.MusicDB, error)
+	RecordAccess(ctx context.Context, access *entity.ShareAccessDB) error
+}
+
+type StatsInteractor interface {
+	GetStats(ctx context.Context, userId uuid.UUID, filter *entity.StatsFilter) (*entity.ListeningStats, error)
+	GetYearReview(ctx context.Context, userId uuid.UUID, year int) (*entity.YearReviewDB, error)
+	GenerateYearReviews(ctx context.Context) error
+}
+
+type ExportInteractor interface {
+	Start(ctx context.Context, userId uuid.UUID, request *entity.ExportRequest) (*entity.ExportJobDB, error)
+	Write(ctx context.Context, userId uuid.UUID, request *entity.ExportRequest, w io.Writer) error
+	GetJob(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.ExportJobDB, error)
+	GetDownload(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.ExportJobDB, error)
+	ProcessJobs(ctx context.Context) error
+}
+
+type PlaylistImportInteractor interface {
+	Import(ctx context.Context, userId uuid.UUID, request *entity.PlaylistImport, r io.Reader) (*entity.PlaylistImportReport, error)
+}
+
+type SmartPlaylistInteractor interface {
+	Create(ctx context.Context, userId uuid.UUID, playlist *entity.SmartPlaylistCreate) (*entity.SmartPlaylistDB, error)
+	Get(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.SmartPlaylistDB, error)
+	GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.SmartPlaylistDB, error)
+	Update(ctx context.Context, userId uuid.UUID, id uuid.UUID, playlist *entity.SmartPlaylistCreate) (*entity.SmartPlaylistDB, error)
+	Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+	GetTracks(ctx context.Context, userId uuid.UUID, id uuid.UUID) ([]*entity.MusicDB, error)
+}
+
+type SyncInteractor interface {
+	Sync(ctx context.Context, userId uuid.UUID, token string) (*entity.SyncDelta, error)
+	CleanupChanges(ctx context.Context) error
+}
+
+type SessionInteractor interface {
+	GetByUser(ctx context.Context, userId uuid.UUID) ([]*entity.SessionDB, error)
+	Revoke(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error
+	RevokeAll(ctx context.Context, userId uuid.UUID) error
+	IsRevoked(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) (bool, error)
+}
+
+type SigningKeyInteractor interface {
+	ParseAccessToken(ctx context.Context, tokenString string) (uuid.UUID, uuid.UUID, error)
+	GetKeys(ctx context.Context) ([]*entity.SigningKey, error)
+	Rotate(ctx context.Context) error
+}
+
+type IdentityInteractor interface {
+	Begin(ctx context.Context, userId *uuid.UUID) (*entity.OIDCAuthorization, error)
+	Complete(ctx context.Context, state string, code string, userId *uuid.UUID) (*entity.UserDB, error)
+	GetByUser(ctx context.Context, userId uuid.UUID) ([]*entity.UserIdentityDB, error)
+	CleanupLogins(ctx context.Context) error
 }
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type lyricsInteractor struct {
+	repo repository.LyricsRepository
+}
+
+func NewLyricsInteractor(repo repository.LyricsRepository) *lyricsInteractor {
+	return &lyricsInteractor{
+		repo: repo,
+	}
+}
+
+// Get возвращает текст трека на запрошенном языке. Пустой язык выбирает первый добавленный текст.
+// Тексты неопубликованных и снятых с публикации треков возвращаются только при showUnpublished.
+func (l *lyricsInteractor) Get(ctx context.Context, musicId uuid.UUID, language string, showUnpublished bool) (*entity.Lyrics, error) {
+	if language != "" {
+		var err error
+		language, err = entity.NormalizeLanguage(language)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	variants, err := l.repo.GetByMusic(ctx, musicId, showUnpublished)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/lyrics.GetByMusic: %w", err)
+	}
+
+	lyrics := entity.SelectLyrics(variants, language)
+	if lyrics == nil {
+		return nil, fmt.Errorf("lyrics not found: %w", sql.ErrNoRows)
+	}
+
+	return entity.NewLyrics(lyrics, variants)
+}
+
+func (l *lyricsInteractor) Set(ctx context.Context, musicId uuid.UUID, lyrics *entity.LyricsUpload) (*entity.Lyrics, error) {
+	if err := lyrics.Validate(); err != nil {
+		return nil, err
+	}
+
+	lyricsDB, err := lyrics.ToDB(musicId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.repo.Upsert(ctx, lyricsDB)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/lyrics.Upsert: %w", err)
+	}
+
+	return l.Get(ctx, musicId, lyricsDB.Language, true)
+}
+
+func (l *lyricsInteractor) Delete(ctx context.Context, musicId uuid.UUID, language string) error {
+	language, err := entity.NormalizeLanguage(language)
+	if err != nil {
+		return err
+	}
+
+	err = l.repo.Delete(ctx, musicId, language)
+	if err != nil {
+		return fmt.Errorf("/repository/lyrics.Delete: %w", err)
+	}
+
+	return nil
+}
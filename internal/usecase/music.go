@@ -2,9 +2,11 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"music-backend-test/internal/entity"
 	"music-backend-test/internal/repository"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -19,8 +21,8 @@ func NewMusicInteractor(repo repository.MusicRepository) *musicInteractor {
 	}
 }
 
-func (m *musicInteractor) GetAll(ctx context.Context) ([]*entity.MusicDB, error) {
-	music, err := m.repo.GetAll(ctx)
+func (m *musicInteractor) GetAll(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
+	music, err := m.repo.GetAll(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("/repository/music.GetAll: %w", err)
 	}
@@ -28,38 +30,65 @@ func (m *musicInteractor) GetAll(ctx context.Context) ([]*entity.MusicDB, error)
 	return music, nil
 }
 
-func (m *musicInteractor) Get(ctx context.Context, musicId uuid.UUID) (*entity.MusicDB, error) {
+func (m *musicInteractor) Get(ctx context.Context, musicId uuid.UUID, filter entity.MusicFilter) (*entity.MusicDB, error) {
 	music, err := m.repo.Get(ctx, musicId)
 	if err != nil {
 		return nil, fmt.Errorf("/repository/music.Get: %w", err)
 	}
+	// Неопубликованный трек для пользователя не существует
+	if !filter.ShowUnpublished && !music.IsAvailable(time.Now()) {
+		return nil, fmt.Errorf("music is not published: %w", sql.ErrNoRows)
+	}
+	if filter.HideExplicit && music.Explicit {
+		return nil, entity.ErrExplicitContent
+	}
 
 	return music, nil
 }
 
-func (m *musicInteractor) GetAllSortByTime(ctx context.Context) ([]*entity.MusicDB, error) {
-	musics, err := m.repo.GetAllSortByTime(ctx)
+func (m *musicInteractor) GetAllSortByTime(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
+	musics, err := m.repo.GetAllSortByTime(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("/repository/music.GetAllSortByTime: %w", err)
 	}
 	return musics, nil
 }
 
-func (m musicInteractor) GetAndSortByPopular(ctx context.Context) ([]*entity.MusicDB, error) {
-	musics, err := m.repo.GetAndSortByPopular(ctx)
+func (m *musicInteractor) GetAllSortByRating(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
+	musics, err := m.repo.GetAllSortByRating(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("/repository/music.GetAndSortByPopular: %w", err)
+		return nil, fmt.Errorf("/repository/music.GetAllSortByRating: %w", err)
 	}
 	return musics, nil
 }
 
-func (m *musicInteractor) Create(ctx context.Context, musicParse *entity.MusicParse) error {
-	err := m.repo.Create(ctx, musicParse)
+// Create загружает трек и ищет его вероятные дубликаты: тот же файл, то же название при близкой
+// продолжительности или похожий отпечаток звука. Дубликаты не мешают загрузке и возвращаются предупреждениями.
+func (m *musicInteractor) Create(ctx context.Context, musicParse *entity.MusicParse) (*entity.MusicUploadReport, error) {
+	music, err := m.repo.Create(ctx, musicParse)
 	if err != nil {
-		return fmt.Errorf("/repository/music.Create: %w", err)
+		return nil, fmt.Errorf("/repository/music.Create: %w", err)
 	}
 
-	return nil
+	fingerprint, err := m.repo.Fingerprint(ctx, music)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/music.Fingerprint: %w", err)
+	}
+
+	candidates, err := m.repo.GetDuplicateCandidates(ctx, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/music.GetDuplicateCandidates: %w", err)
+	}
+
+	duplicates := entity.DetectDuplicates(music, fingerprint, candidates, time.Now())
+	if len(duplicates) > 0 {
+		err = m.repo.SaveDuplicates(ctx, duplicates)
+		if err != nil {
+			return nil, fmt.Errorf("/repository/music.SaveDuplicates: %w", err)
+		}
+	}
+
+	return &entity.MusicUploadReport{Music: music, Duplicates: duplicates}, nil
 }
 
 func (m *musicInteractor) Update(ctx context.Context, id uuid.UUID, musicParse *entity.MusicParse) error {
@@ -71,11 +100,44 @@ func (m *musicInteractor) Update(ctx context.Context, id uuid.UUID, musicParse *
 	return nil
 }
 
+func (m *musicInteractor) SetExplicit(ctx context.Context, id uuid.UUID, explicit bool) error {
+	err := m.repo.SetExplicit(ctx, id, explicit)
+	if err != nil {
+		return fmt.Errorf("/repository/music.SetExplicit: %w", err)
+	}
+
+	return nil
+}
+
 func (m *musicInteractor) Delete(ctx context.Context, id uuid.UUID) error {
-	err := m.repo.Delete(ctx, id)
+	err := m.repo.Delete(ctx, id, time.Now())
 	if err != nil {
 		return fmt.Errorf("/repository/music.Delete: %w", err)
 	}
 
 	return nil
 }
+
+func (m *musicInteractor) GetDuplicates(ctx context.Context, id uuid.UUID) ([]*entity.MusicDuplicateDB, error) {
+	duplicates, err := m.repo.GetDuplicates(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/music.GetDuplicates: %w", err)
+	}
+
+	return duplicates, nil
+}
+
+// Merge переносит лайки, записи плейлистов и историю прослушиваний дубликата id на трек merge.Into,
+// после чего дубликат перемещается в корзину
+func (m *musicInteractor) Merge(ctx context.Context, id uuid.UUID, merge *entity.MusicMerge) (*entity.MusicMergeResult, error) {
+	if merge.Into == uuid.Nil || merge.Into == id {
+		return nil, fmt.Errorf("%w: track can't be merged into itself", entity.ErrInvalidMerge)
+	}
+
+	result, err := m.repo.Merge(ctx, id, merge.Into, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("/repository/music.Merge: %w", err)
+	}
+
+	return result, nil
+}
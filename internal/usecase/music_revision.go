@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type musicRevisionInteractor struct {
+	repo repository.MusicRevisionRepository
+}
+
+func NewMusicRevisionInteractor(repo repository.MusicRevisionRepository) *musicRevisionInteractor {
+	return &musicRevisionInteractor{
+		repo: repo,
+	}
+}
+
+func (r *musicRevisionInteractor) GetByMusic(ctx context.Context, musicId uuid.UUID, limit int, offset int) ([]*entity.MusicRevisionDB, error) {
+	limit, offset = entity.NormalizeMusicRevisionPage(limit, offset)
+
+	revisions, err := r.repo.GetByMusic(ctx, musicId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/music_revision.GetByMusic: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *musicRevisionInteractor) Get(ctx context.Context, musicId uuid.UUID, version int) (*entity.MusicRevisionDB, error) {
+	if version <= 0 {
+		return nil, fmt.Errorf("%w: version must be positive", entity.ErrInvalidMusicRevision)
+	}
+
+	revision, err := r.repo.Get(ctx, musicId, version)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/music_revision.Get: %w", err)
+	}
+
+	return revision, nil
+}
+
+// Diff сравнивает версии from и to. Нулевой to означает текущую версию, нулевой from — версию перед to.
+func (r *musicRevisionInteractor) Diff(ctx context.Context, musicId uuid.UUID, from int, to int) (*entity.MusicRevisionDiff, error) {
+	if from < 0 || to < 0 {
+		return nil, fmt.Errorf("%w: version must be positive", entity.ErrInvalidMusicRevision)
+	}
+
+	var (
+		toRevision *entity.MusicRevisionDB
+		err        error
+	)
+	if to == 0 {
+		toRevision, err = r.repo.GetLatest(ctx, musicId)
+		if err != nil {
+			return nil, fmt.Errorf("/repository/music_revision.GetLatest: %w", err)
+		}
+	} else {
+		toRevision, err = r.repo.Get(ctx, musicId, to)
+		if err != nil {
+			return nil, fmt.Errorf("/repository/music_revision.Get: %w", err)
+		}
+	}
+
+	if from == 0 {
+		from = toRevision.Version - 1
+		if from == 0 {
+			return nil, fmt.Errorf("%w: version %d has no previous version", entity.ErrInvalidMusicRevision, toRevision.Version)
+		}
+	}
+
+	fromRevision, err := r.repo.Get(ctx, musicId, from)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/music_revision.Get: %w", err)
+	}
+
+	return entity.DiffMusicRevisions(fromRevision, toRevision), nil
+}
+
+// Revert возвращает трек к состоянию версии и сохраняет откат как новую версию
+func (r *musicRevisionInteractor) Revert(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, version int) (*entity.MusicRevisionDB, error) {
+	target, err := r.Get(ctx, musicId, version)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := r.repo.GetLatest(ctx, musicId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/music_revision.GetLatest: %w", err)
+	}
+	if current.Version == target.Version {
+		return nil, fmt.Errorf("%w: version %d", entity.ErrRevertToCurrent, version)
+	}
+
+	revision := entity.NewMusicRevision(target.ToMusic(), entity.MusicRevisionRevert, userId, time.Now())
+	revision.RevertedFrom = &target.Version
+
+	err = r.repo.Revert(ctx, target, current, revision)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/music_revision.Revert: %w", err)
+	}
+
+	return r.Get(ctx, musicId, revision.Version)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type notificationInteractor struct {
+	repo repository.NotificationRepository
+	cfg  *entity.NotificationConfig
+}
+
+func NewNotificationInteractor(repo repository.NotificationRepository, cfg *entity.NotificationConfig) *notificationInteractor {
+	return &notificationInteractor{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+func (n *notificationInteractor) GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.NotificationFilter) (*entity.NotificationPage, error) {
+	filter.Normalize()
+
+	notifications, err := n.repo.GetByUser(ctx, userId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/notification.GetByUser: %w", err)
+	}
+
+	unread, err := n.repo.CountUnread(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/notification.CountUnread: %w", err)
+	}
+
+	return entity.NewNotificationPage(notifications, filter.Limit, unread), nil
+}
+
+func (n *notificationInteractor) MarkRead(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	err := n.repo.MarkRead(ctx, userId, id, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/notification.MarkRead: %w", err)
+	}
+
+	return nil
+}
+
+func (n *notificationInteractor) MarkAllRead(ctx context.Context, userId uuid.UUID) (int64, error) {
+	read, err := n.repo.MarkAllRead(ctx, userId, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("/repository/notification.MarkAllRead: %w", err)
+	}
+
+	return read, nil
+}
+
+func (n *notificationInteractor) GetSettings(ctx context.Context, userId uuid.UUID) (*entity.NotificationSettings, error) {
+	settings, err := n.repo.GetSettings(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/notification.GetSettings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SetSettings сохраняет настройки и возвращает их вместе с настройками типов, которые не передавались
+func (n *notificationInteractor) SetSettings(ctx context.Context, userId uuid.UUID, settings *entity.NotificationSettings) (*entity.NotificationSettings, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := n.repo.SetSettings(ctx, userId, settings)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/notification.SetSettings: %w", err)
+	}
+
+	return n.GetSettings(ctx, userId)
+}
+
+// Deliver рассылает уведомления о новых релизах подписчикам и отправляет накопившиеся уведомления во внешние каналы.
+// Уведомление отмечается отправленным и при ошибке канала, чтобы недоступный канал не задерживал очередь:
+// доставка во внешние каналы выполняется не более одного раза, ошибки возвращаются для журнала.
+func (n *notificationInteractor) Deliver(ctx context.Context) error {
+	now := time.Now()
+
+	_, err := n.repo.CreateReleases(ctx, now)
+	if err != nil {
+		return fmt.Errorf("/repository/notification.CreateReleases: %w", err)
+	}
+
+	deliveries, err := n.repo.GetUndelivered(ctx, n.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("/repository/notification.GetUndelivered: %w", err)
+	}
+
+	var errs []error
+	for _, delivery := range deliveries {
+		if err := n.repo.Deliver(ctx, delivery); err != nil {
+			errs = append(errs, fmt.Errorf("/repository/notification.Deliver %s: %w", delivery.ID, err))
+		}
+
+		err = n.repo.MarkDelivered(ctx, delivery.ID, now)
+		if err != nil {
+			return fmt.Errorf("/repository/notification.MarkDelivered: %w", err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
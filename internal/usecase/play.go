@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type playInteractor struct {
+	repo repository.PlayRepository
+}
+
+func NewPlayInteractor(repo repository.PlayRepository) *playInteractor {
+	return &playInteractor{
+		repo: repo,
+	}
+}
+
+func (p *playInteractor) Record(ctx context.Context, userId uuid.UUID, event *entity.PlayEventCreate) (bool, error) {
+	now := time.Now()
+	if err := event.Validate(now); err != nil {
+		return false, err
+	}
+
+	known, err := p.repo.GetKnownMusicIDs(ctx, []uuid.UUID{event.MusicID})
+	if err != nil {
+		return false, fmt.Errorf("/repository/play.GetKnownMusicIDs: %w", err)
+	}
+	if len(known) == 0 {
+		return false, fmt.Errorf("%w: %s", entity.ErrUnknownPlayMusic, event.MusicID)
+	}
+
+	created, err := p.repo.Create(ctx, event.ToDB(userId, entity.PlaySourceClient, now))
+	if err != nil {
+		return false, fmt.Errorf("/repository/play.Create: %w", err)
+	}
+
+	return created, nil
+}
+
+func (p *playInteractor) RecordBatch(ctx context.Context, userId uuid.UUID, events []*entity.PlayEventCreate) (*entity.PlayBatchResult, error) {
+	if len(events) == 0 {
+		return &entity.PlayBatchResult{}, nil
+	}
+	if len(events) > entity.MaxPlayBatchSize {
+		return nil, fmt.Errorf("%w: batch is larger than %d events", entity.ErrInvalidPlayEvent, entity.MaxPlayBatchSize)
+	}
+
+	now := time.Now()
+	musicIds := make([]uuid.UUID, 0, len(events))
+	seen := make(map[uuid.UUID]bool, len(events))
+	for i, event := range events {
+		if err := event.Validate(now); err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		if !seen[event.MusicID] {
+			seen[event.MusicID] = true
+			musicIds = append(musicIds, event.MusicID)
+		}
+	}
+
+	// События по неизвестным трекам пропускаются, иначе внешний ключ отклонил бы весь пакет
+	knownIds, err := p.repo.GetKnownMusicIDs(ctx, musicIds)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/play.GetKnownMusicIDs: %w", err)
+	}
+	known := make(map[uuid.UUID]bool, len(knownIds))
+	for _, id := range knownIds {
+		known[id] = true
+	}
+
+	result := &entity.PlayBatchResult{}
+	for _, id := range musicIds {
+		if !known[id] {
+			result.UnknownMusicIDs = append(result.UnknownMusicIDs, id)
+		}
+	}
+
+	eventsDB := make([]*entity.PlayEventDB, 0, len(events))
+	for _, event := range events {
+		if known[event.MusicID] {
+			eventsDB = append(eventsDB, event.ToDB(userId, entity.PlaySourceClient, now))
+		}
+	}
+	if len(eventsDB) == 0 {
+		return result, nil
+	}
+
+	accepted, err := p.repo.CreateBatch(ctx, eventsDB)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/play.CreateBatch: %w", err)
+	}
+
+	result.Accepted = accepted
+	result.Duplicates = len(eventsDB) - accepted
+	return result, nil
+}
+
+// RecordStream записывает прослушивание, выведенное из обращения к файлу трека.
+// Повторные обращения в пределах entity.StreamPlayDedupWindow не создают новых событий.
+func (p *playInteractor) RecordStream(ctx context.Context, userId uuid.UUID, musicId uuid.UUID) error {
+	now := time.Now()
+	event := &entity.PlayEventCreate{
+		ClientEventID: entity.StreamPlayEventID(userId, musicId, now),
+		MusicID:       musicId,
+		EventType:     entity.PlayEventStart,
+		PlayedAt:      now,
+	}
+
+	_, err := p.repo.Create(ctx, event.ToDB(userId, entity.PlaySourceStream, now))
+	if err != nil {
+		return fmt.Errorf("/repository/play.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (p *playInteractor) GetHistory(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) ([]*entity.PlayEventDB, error) {
+	filter.Normalize(time.Now())
+
+	events, err := p.repo.GetByUser(ctx, userId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/play.GetByUser: %w", err)
+	}
+
+	return events, nil
+}
+
+func (p *playInteractor) DeleteHistory(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) (int64, error) {
+	filter.Normalize(time.Now())
+
+	deleted, err := p.repo.DeleteByUser(ctx, userId, filter)
+	if err != nil {
+		return 0, fmt.Errorf("/repository/play.DeleteByUser: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func (p *playInteractor) DeleteHistoryEvent(ctx context.Context, userId uuid.UUID, eventId uuid.UUID) error {
+	err := p.repo.Delete(ctx, userId, eventId)
+	if err != nil {
+		return fmt.Errorf("/repository/play.Delete: %w", err)
+	}
+
+	return nil
+}
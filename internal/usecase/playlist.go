@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type playlistInteractor struct {
+	repo repository.PlaylistRepository
+}
+
+func NewPlaylistInteractor(repo repository.PlaylistRepository) *playlistInteractor {
+	return &playlistInteractor{
+		repo: repo,
+	}
+}
+
+func (p *playlistInteractor) Create(ctx context.Context, userId uuid.UUID, playlist *entity.PlaylistCreate) (*entity.PlaylistDB, error) {
+	if err := playlist.Validate(); err != nil {
+		return nil, err
+	}
+
+	playlistDB := playlist.ToDB(userId, time.Now())
+	err := p.repo.Create(ctx, playlistDB)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/playlist.Create: %w", err)
+	}
+
+	return playlistDB, nil
+}
+
+// Get возвращает плейлист, если он публичный или принадлежит пользователю.
+// Чужой приватный плейлист неотличим от несуществующего.
+func (p *playlistInteractor) Get(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.PlaylistDB, error) {
+	playlist, err := p.repo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/playlist.Get: %w", err)
+	}
+	if !playlist.VisibleTo(userId) {
+		return nil, fmt.Errorf("playlist is private: %w", sql.ErrNoRows)
+	}
+
+	return playlist, nil
+}
+
+func (p *playlistInteractor) GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.PlaylistDB, error) {
+	limit, offset = entity.NormalizePlaylistPage(limit, offset)
+
+	playlists, err := p.repo.GetByUser(ctx, userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/playlist.GetByUser: %w", err)
+	}
+
+	return playlists, nil
+}
+
+func (p *playlistInteractor) Update(ctx context.Context, userId uuid.UUID, id uuid.UUID, playlist *entity.PlaylistCreate) (*entity.PlaylistDB, error) {
+	if err := playlist.Validate(); err != nil {
+		return nil, err
+	}
+
+	playlistDB, err := p.getOwned(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	playlistDB.Name = strings.TrimSpace(playlist.Name)
+	playlistDB.IsPublic = playlist.IsPublic
+	playlistDB.UpdatedAt = time.Now()
+
+	err = p.repo.Update(ctx, playlistDB)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/playlist.Update: %w", err)
+	}
+
+	return playlistDB, nil
+}
+
+func (p *playlistInteractor) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	if _, err := p.getOwned(ctx, userId, id); err != nil {
+		return err
+	}
+
+	err := p.repo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("/repository/playlist.Delete: %w", err)
+	}
+
+	return nil
+}
+
+func (p *playlistInteractor) GetTracks(ctx context.Context, userId uuid.UUID, id uuid.UUID) ([]*entity.MusicDB, error) {
+	if _, err := p.Get(ctx, userId, id); err != nil {
+		return nil, err
+	}
+
+	music, err := p.repo.GetTracks(ctx, id, userId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/playlist.GetTracks: %w", err)
+	}
+
+	return music, nil
+}
+
+func (p *playlistInteractor) AddTrack(ctx context.Context, userId uuid.UUID, id uuid.UUID, musicId uuid.UUID) error {
+	if _, err := p.getOwned(ctx, userId, id); err != nil {
+		return err
+	}
+
+	err := p.repo.AddTrack(ctx, id, musicId, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/playlist.AddTrack: %w", err)
+	}
+
+	return nil
+}
+
+func (p *playlistInteractor) RemoveTrack(ctx context.Context, userId uuid.UUID, id uuid.UUID, musicId uuid.UUID) error {
+	if _, err := p.getOwned(ctx, userId, id); err != nil {
+		return err
+	}
+
+	err := p.repo.RemoveTrack(ctx, id, musicId, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/playlist.RemoveTrack: %w", err)
+	}
+
+	return nil
+}
+
+// getOwned возвращает плейлист для изменения. Изменять плейлист может только владелец.
+func (p *playlistInteractor) getOwned(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.PlaylistDB, error) {
+	playlist, err := p.Get(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.UserID != userId {
+		return nil, entity.ErrPlaylistForbidden
+	}
+
+	return playlist, nil
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/importer"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type playlistImportInteractor struct {
+	repo repository.PlaylistImportRepository
+}
+
+func NewPlaylistImportInteractor(repo repository.PlaylistImportRepository) *playlistImportInteractor {
+	return &playlistImportInteractor{
+		repo: repo,
+	}
+}
+
+// Import разбирает файл плейлиста, сопоставляет записи с каталогом и создает плейлист из найденных треков.
+// Записи сопоставляются сначала по id трека из ссылки, затем по нормализованному названию и исполнителю
+// с допуском по продолжительности. Неоднозначные и несопоставленные записи возвращаются в отчете.
+func (p *playlistImportInteractor) Import(ctx context.Context, userId uuid.UUID, request *entity.PlaylistImport, r io.Reader) (*entity.PlaylistImportReport, error) {
+	name, entries, err := importer.Parse(request.Format, r)
+	if err != nil {
+		return nil, err
+	}
+	request.Entries = entries
+	request.SetDefaultName(name)
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	matcher, err := p.loadCandidates(ctx, request.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.PlaylistImportReport{Total: len(request.Entries)}
+	added := map[uuid.UUID]bool{}
+	var musicIds []uuid.UUID
+	for _, entry := range request.Entries {
+		match := matcher.Match(entry)
+		if match.Status != entity.ImportStatusMatched {
+			report.Unresolved = append(report.Unresolved, match)
+			continue
+		}
+
+		report.Matched++
+		if added[match.Music.MusicID] {
+			report.Duplicates++
+			continue
+		}
+		added[match.Music.MusicID] = true
+		musicIds = append(musicIds, match.Music.MusicID)
+	}
+
+	report.Playlist = request.ToDB(userId, time.Now())
+	err = p.repo.CreatePlaylist(ctx, report.Playlist, musicIds)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/playlist_import.CreatePlaylist: %w", err)
+	}
+
+	return report, nil
+}
+
+// loadCandidates загружает треки, указанные в записях по id, и треки каталога с названиями остальных записей.
+// Названия сравниваются в базе, каталог целиком не читается.
+func (p *playlistImportInteractor) loadCandidates(ctx context.Context, entries []*entity.ImportEntry) (*entity.ImportMatcher, error) {
+	matcher := entity.NewImportMatcher()
+
+	var ids []uuid.UUID
+	for _, entry := range entries {
+		if entry.MusicID != nil {
+			ids = append(ids, *entry.MusicID)
+		}
+	}
+	if len(ids) > 0 {
+		tracks, err := p.repo.GetByIDs(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("/repository/playlist_import.GetByIDs: %w", err)
+		}
+		matcher.AddByID(tracks)
+	}
+
+	titles := map[string]bool{}
+	var titleList []string
+	for _, entry := range entries {
+		if matcher.HasID(entry) {
+			continue
+		}
+		if title := entity.NormalizeImportTitle(entry.Title); title != "" && !titles[title] {
+			titles[title] = true
+			titleList = append(titleList, title)
+		}
+	}
+	if len(titleList) == 0 {
+		return matcher, nil
+	}
+
+	tracks, err := p.repo.GetByTitles(ctx, titleList)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/playlist_import.GetByTitles: %w", err)
+	}
+	matcher.AddByTitle(tracks, titles)
+
+	return matcher, nil
+}
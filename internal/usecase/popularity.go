@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+)
+
+type popularityInteractor struct {
+	repo repository.PopularityRepository
+	cfg  *entity.PopularityConfig
+}
+
+func NewPopularityInteractor(repo repository.PopularityRepository, cfg *entity.PopularityConfig) *popularityInteractor {
+	return &popularityInteractor{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+func (p *popularityInteractor) GetPopular(ctx context.Context, window string, limit int, offset int, hideExplicit bool) ([]*entity.MusicPopularityDB, error) {
+	if window == "" {
+		window = entity.PopularityAll
+	}
+	if _, err := entity.PopularityWindowStart(window, time.Now()); err != nil {
+		return nil, err
+	}
+
+	limit, offset = entity.NormalizeChartPage(limit, offset)
+	musics, err := p.repo.Get(ctx, window, limit, offset, hideExplicit)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/popularity.Get: %w", err)
+	}
+
+	return musics, nil
+}
+
+func (p *popularityInteractor) GetTrending(ctx context.Context, limit int, offset int, hideExplicit bool) ([]*entity.MusicPopularityDB, error) {
+	limit, offset = entity.NormalizeChartPage(limit, offset)
+	musics, err := p.repo.Get(ctx, entity.PopularityTrending, limit, offset, hideExplicit)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/popularity.Get: %w", err)
+	}
+
+	return musics, nil
+}
+
+// Refresh пересчитывает материализованные рейтинги. Вызывается планировщиком.
+func (p *popularityInteractor) Refresh(ctx context.Context) error {
+	err := p.repo.Refresh(ctx, p.cfg, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/popularity.Refresh: %w", err)
+	}
+
+	return nil
+}
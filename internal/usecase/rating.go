@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ratingInteractor struct {
+	repo repository.RatingRepository
+}
+
+func NewRatingInteractor(repo repository.RatingRepository) *ratingInteractor {
+	return &ratingInteractor{
+		repo: repo,
+	}
+}
+
+func (r *ratingInteractor) Set(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, rating *entity.RatingCreate) (*entity.RatingSummary, error) {
+	if err := rating.Validate(); err != nil {
+		return nil, err
+	}
+
+	summary, err := r.repo.Set(ctx, userId, musicId, rating.Rating, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("/repository/rating.Set: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (r *ratingInteractor) Clear(ctx context.Context, userId uuid.UUID, musicId uuid.UUID) (*entity.RatingSummary, error) {
+	summary, err := r.repo.Clear(ctx, userId, musicId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/rating.Clear: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (r *ratingInteractor) GetLibrary(ctx context.Context, userId uuid.UUID, sort string) ([]*entity.LibraryMusicDB, error) {
+	sort, err := entity.ValidateLibrarySort(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	musics, err := r.repo.GetLibrary(ctx, userId, sort)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/rating.GetLibrary: %w", err)
+	}
+
+	return musics, nil
+}
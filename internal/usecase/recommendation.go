@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type recommendationInteractor struct {
+	repo repository.RecommendationRepository
+	cfg  *entity.SimilarityConfig
+}
+
+func NewRecommendationInteractor(repo repository.RecommendationRepository, cfg *entity.SimilarityConfig) *recommendationInteractor {
+	return &recommendationInteractor{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+func (r *recommendationInteractor) GetForUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.RecommendationDB, error) {
+	limit, offset = entity.NormalizeRecommendationPage(limit, offset)
+	musics, err := r.repo.GetForUser(ctx, userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/recommendation.GetForUser: %w", err)
+	}
+
+	return musics, nil
+}
+
+func (r *recommendationInteractor) GetSimilar(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, limit int, offset int) ([]*entity.SimilarMusicDB, error) {
+	limit, offset = entity.NormalizeRecommendationPage(limit, offset)
+	musics, err := r.repo.GetSimilar(ctx, userId, musicId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/recommendation.GetSimilar: %w", err)
+	}
+
+	return musics, nil
+}
+
+// Refresh пересчитывает схожесть треков. Вызывается планировщиком.
+func (r *recommendationInteractor) Refresh(ctx context.Context) error {
+	err := r.repo.Refresh(ctx, r.cfg, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/recommendation.Refresh: %w", err)
+	}
+
+	return nil
+}
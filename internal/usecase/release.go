@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type releaseInteractor struct {
+	repo repository.ReleaseRepository
+}
+
+func NewReleaseInteractor(repo repository.ReleaseRepository) *releaseInteractor {
+	return &releaseInteractor{
+		repo: repo,
+	}
+}
+
+func (r *releaseInteractor) GetScheduled(ctx context.Context, limit int, offset int) ([]*entity.MusicDB, error) {
+	limit, offset = entity.NormalizeScheduledMusicPage(limit, offset)
+
+	music, err := r.repo.GetScheduled(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/release.GetScheduled: %w", err)
+	}
+
+	return music, nil
+}
+
+func (r *releaseInteractor) SetSchedule(ctx context.Context, id uuid.UUID, schedule *entity.MusicSchedule) (*entity.MusicDB, error) {
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	music, err := r.repo.SetSchedule(ctx, id, schedule, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("/repository/release.SetSchedule: %w", err)
+	}
+
+	return music, nil
+}
+
+// Publish публикует треки, время публикации которых наступило. Вызывается планировщиком.
+func (r *releaseInteractor) Publish(ctx context.Context) error {
+	_, err := r.repo.PublishDue(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/release.PublishDue: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type sessionInteractor struct {
+	repo repository.SessionRepository
+}
+
+func NewSessionInteractor(repo repository.SessionRepository) *sessionInteractor {
+	return &sessionInteractor{
+		repo: repo,
+	}
+}
+
+// GetByUser возвращает действующие сессии пользователя
+func (s *sessionInteractor) GetByUser(ctx context.Context, userId uuid.UUID) ([]*entity.SessionDB, error) {
+	sessions, err := s.repo.GetByUser(ctx, userId, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("/repository/session.GetByUser: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Revoke отзывает сессию пользователя. Access-токены сессии перестают приниматься, refresh-токены — обмениваться.
+func (s *sessionInteractor) Revoke(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error {
+	err := s.repo.Revoke(ctx, userId, sessionId, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/session.Revoke: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAll отзывает все сессии пользователя
+func (s *sessionInteractor) RevokeAll(ctx context.Context, userId uuid.UUID) error {
+	err := s.repo.RevokeAll(ctx, userId, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/session.RevokeAll: %w", err)
+	}
+
+	return nil
+}
+
+// IsRevoked проверяет, что сессия, в которой выдан access-токен, отозвана, истекла или удалена
+func (s *sessionInteractor) IsRevoked(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) (bool, error) {
+	revoked, err := s.repo.IsRevoked(ctx, userId, sessionId, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("/repository/session.IsRevoked: %w", err)
+	}
+
+	return revoked, nil
+}
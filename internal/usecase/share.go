@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type shareInteractor struct {
+	repo repository.ShareRepository
+}
+
+func NewShareInteractor(repo repository.ShareRepository) *shareInteractor {
+	return &shareInteractor{
+		repo: repo,
+	}
+}
+
+// CreateForMusic создает ссылку на опубликованный трек
+func (s *shareInteractor) CreateForMusic(ctx context.Context, userId uuid.UUID, musicId uuid.UUID, share *entity.ShareLinkCreate) (*entity.ShareLinkCreated, error) {
+	now := time.Now()
+	if err := share.Validate(now); err != nil {
+		return nil, err
+	}
+
+	music, err := s.repo.GetMusic(ctx, musicId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/share.GetMusic: %w", err)
+	}
+	if !music.IsAvailable(now) {
+		return nil, fmt.Errorf("music is not published: %w", sql.ErrNoRows)
+	}
+
+	return s.create(ctx, func(tokenHash string) *entity.ShareLinkDB {
+		return share.ToDB(userId, &music.Id, nil, tokenHash, now)
+	})
+}
+
+// CreateForPlaylist создает ссылку на плейлист. Делиться плейлистом может только владелец.
+// Чужой приватный плейлист неотличим от несуществующего.
+func (s *shareInteractor) CreateForPlaylist(ctx context.Context, userId uuid.UUID, playlistId uuid.UUID, share *entity.ShareLinkCreate) (*entity.ShareLinkCreated, error) {
+	now := time.Now()
+	if err := share.Validate(now); err != nil {
+		return nil, err
+	}
+
+	playlist, err := s.repo.GetPlaylist(ctx, playlistId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/share.GetPlaylist: %w", err)
+	}
+	if !playlist.VisibleTo(userId) {
+		return nil, fmt.Errorf("playlist is private: %w", sql.ErrNoRows)
+	}
+	if playlist.UserID != userId {
+		return nil, entity.ErrPlaylistForbidden
+	}
+
+	return s.create(ctx, func(tokenHash string) *entity.ShareLinkDB {
+		return share.ToDB(userId, nil, &playlist.ID, tokenHash, now)
+	})
+}
+
+func (s *shareInteractor) create(ctx context.Context, toDB func(tokenHash string) *entity.ShareLinkDB) (*entity.ShareLinkCreated, error) {
+	token, tokenHash, err := entity.NewShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	link := toDB(tokenHash)
+	err = s.repo.Create(ctx, link)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/share.Create: %w", err)
+	}
+
+	return &entity.ShareLinkCreated{Link: link, Token: token}, nil
+}
+
+func (s *shareInteractor) GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.ShareLinkDB, error) {
+	limit, offset = entity.NormalizeShareLinkPage(limit, offset)
+
+	links, err := s.repo.GetByUser(ctx, userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/share.GetByUser: %w", err)
+	}
+
+	return links, nil
+}
+
+func (s *shareInteractor) Revoke(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	err := s.repo.Revoke(ctx, userId, id, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/share.Revoke: %w", err)
+	}
+
+	return nil
+}
+
+// Resolve находит ссылку по токену и проверяет, что она действует.
+// Недействующая ссылка возвращается вместе с ошибкой, чтобы обращение попало в журнал.
+func (s *shareInteractor) Resolve(ctx context.Context, token string) (*entity.ShareLinkDB, error) {
+	link, err := s.repo.GetByTokenHash(ctx, entity.HashShareToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("/repository/share.GetByTokenHash: %w", err)
+	}
+	if err := link.Check(time.Now()); err != nil {
+		return link, err
+	}
+
+	return link, nil
+}
+
+// GetContent возвращает трек или плейлист ссылки. Просмотр не расходует лимит прослушиваний.
+func (s *shareInteractor) GetContent(ctx context.Context, link *entity.ShareLinkDB) (*entity.SharedContent, error) {
+	content := &entity.SharedContent{Link: link}
+
+	if link.MusicID != nil {
+		music, err := s.getMusic(ctx, *link.MusicID)
+		if err != nil {
+			return nil, err
+		}
+		content.Music = music
+		return content, nil
+	}
+
+	playlist, err := s.repo.GetPlaylist(ctx, *link.PlaylistID)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/share.GetPlaylist: %w", err)
+	}
+	tracks, err := s.repo.GetPlaylistTracks(ctx, playlist.ID)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/share.GetPlaylistTracks: %w", err)
+	}
+	content.Playlist = playlist
+	content.Tracks = tracks
+
+	return content, nil
+}
+
+// Stream возвращает трек для прослушивания и засчитывает прослушивание по ссылке.
+// Для ссылки на трек musicId не указывается, для ссылки на плейлист — это id трека из плейлиста.
+func (s *shareInteractor) Stream(ctx context.Context, link *entity.ShareLinkDB, musicId *uuid.UUID) (*entity.MusicDB, error) {
+	music, err := s.getSharedTrack(ctx, link, musicId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.ConsumePlay(ctx, link.ID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("/repository/share.ConsumePlay: %w", err)
+	}
+
+	return music, nil
+}
+
+func (s *shareInteractor) RecordAccess(ctx context.Context, access *entity.ShareAccessDB) error {
+	err := s.repo.RecordAccess(ctx, access)
+	if err != nil {
+		return fmt.Errorf("/repository/share.RecordAccess: %w", err)
+	}
+
+	return nil
+}
+
+func (s *shareInteractor) getSharedTrack(ctx context.Context, link *entity.ShareLinkDB, musicId *uuid.UUID) (*entity.MusicDB, error) {
+	if link.MusicID != nil {
+		if musicId != nil && *musicId != *link.MusicID {
+			return nil, fmt.Errorf("music is not shared: %w", sql.ErrNoRows)
+		}
+		return s.getMusic(ctx, *link.MusicID)
+	}
+
+	if musicId == nil {
+		return nil, fmt.Errorf("music id is required for playlist link: %w", sql.ErrNoRows)
+	}
+	tracks, err := s.repo.GetPlaylistTracks(ctx, *link.PlaylistID)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/share.GetPlaylistTracks: %w", err)
+	}
+	for _, track := range tracks {
+		if track.Id == *musicId {
+			return track, nil
+		}
+	}
+
+	return nil, fmt.Errorf("music is not in shared playlist: %w", sql.ErrNoRows)
+}
+
+// getMusic возвращает трек, если он все еще опубликован
+func (s *shareInteractor) getMusic(ctx context.Context, musicId uuid.UUID) (*entity.MusicDB, error) {
+	music, err := s.repo.GetMusic(ctx, musicId)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/share.GetMusic: %w", err)
+	}
+	if !music.IsAvailable(time.Now()) {
+		return nil, fmt.Errorf("music is not published: %w", sql.ErrNoRows)
+	}
+
+	return music, nil
+}
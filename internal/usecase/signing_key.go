@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+type signingKeyInteractor struct {
+	repo repository.SigningKeyRepository
+	cfg  *entity.KeysConfig
+}
+
+func NewSigningKeyInteractor(repo repository.SigningKeyRepository, cfg *entity.KeysConfig) *signingKeyInteractor {
+	return &signingKeyInteractor{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// ParseAccessToken проверяет подпись access-токена ключом из его заголовка kid и возвращает id пользователя и id сессии.
+// Токены без kid, выданные до появления ключей подписи, проверяются ключом HS256, если это разрешено в настройках,
+// и принимаются, только если выданы до cfg.LegacyCutoff и действуют не дольше entity.LegacyTokenTTL.
+// Недействительный токен возвращает ошибку entity.ErrInvalidToken.
+func (s *signingKeyInteractor) ParseAccessToken(ctx context.Context, tokenString string) (uuid.UUID, uuid.UUID, error) {
+	now := time.Now()
+
+	// Ошибку бд keyfunc не может вернуть как есть: парсер токена оборачивает ее в ошибку проверки
+	var lookupErr error
+	userId, sessionId, err := entity.ParseToken(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			if s.cfg.LegacySecret == "" || token.Method != jwt.SigningMethodHS256 {
+				return nil, entity.ErrUnknownSigningKey
+			}
+			claims, ok := token.Claims.(*entity.AccessClaims)
+			if !ok || !claims.ValidLegacy(s.cfg.LegacyCutoff) {
+				return nil, entity.ErrLegacyTokenRejected
+			}
+			return []byte(s.cfg.LegacySecret), nil
+		}
+
+		key, err := s.repo.GetVerificationKey(ctx, kid, now)
+		if err != nil {
+			if !errors.Is(err, entity.ErrUnknownSigningKey) {
+				lookupErr = err
+			}
+			return nil, err
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, entity.ErrSigningKeyAlgorithm
+		}
+		return key.Public, nil
+	})
+	if lookupErr != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("/repository/signing_key.GetVerificationKey: %w", lookupErr)
+	}
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return userId, sessionId, nil
+}
+
+// GetKeys возвращает ключи, которыми проверяются токены, включая опубликованный заранее следующий ключ
+func (s *signingKeyInteractor) GetKeys(ctx context.Context) ([]*entity.SigningKey, error) {
+	keys, err := s.repo.GetKeys(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("/repository/signing_key.GetKeys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Rotate удаляет истекшие ключи и создает следующий ключ за cfg.Overlap до окончания подписи текущим,
+// чтобы другие сервисы успели получить его из JWKS. Если действующих ключей нет, ключ создается сразу.
+func (s *signingKeyInteractor) Rotate(ctx context.Context) error {
+	now := time.Now()
+
+	err := s.repo.DeleteExpired(ctx, now)
+	if err != nil {
+		return fmt.Errorf("/repository/signing_key.DeleteExpired: %w", err)
+	}
+
+	keys, err := s.repo.GetKeys(ctx, now)
+	if err != nil {
+		return fmt.Errorf("/repository/signing_key.GetKeys: %w", err)
+	}
+
+	// Ключи отсортированы по времени начала подписи, первый — текущий или уже опубликованный следующий
+	var after time.Time
+	activatesAt := now
+	if len(keys) > 0 {
+		latest := keys[0]
+		if now.Before(latest.RetiresAt.Add(-s.cfg.Overlap)) {
+			return nil
+		}
+		after = latest.ActivatesAt
+		if latest.RetiresAt.After(now) {
+			activatesAt = latest.RetiresAt
+		}
+	}
+
+	key, err := entity.NewSigningKey(s.cfg.Algorithm, now, activatesAt, s.cfg)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.Create(ctx, key, after, now)
+	if err != nil {
+		return fmt.Errorf("/repository/signing_key.Create: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type smartPlaylistInteractor struct {
+	repo repository.SmartPlaylistRepository
+}
+
+func NewSmartPlaylistInteractor(repo repository.SmartPlaylistRepository) *smartPlaylistInteractor {
+	return &smartPlaylistInteractor{
+		repo: repo,
+	}
+}
+
+func (s *smartPlaylistInteractor) Create(ctx context.Context, userId uuid.UUID, playlist *entity.SmartPlaylistCreate) (*entity.SmartPlaylistDB, error) {
+	if err := playlist.Validate(); err != nil {
+		return nil, err
+	}
+
+	playlistDB := playlist.ToDB(userId, time.Now())
+	err := s.repo.Create(ctx, playlistDB)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/smart_playlist.Create: %w", err)
+	}
+
+	return playlistDB, nil
+}
+
+// Get возвращает плейлист, если он публичный или принадлежит пользователю.
+// Чужой приватный плейлист неотличим от несуществующего.
+func (s *smartPlaylistInteractor) Get(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.SmartPlaylistDB, error) {
+	playlist, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/smart_playlist.Get: %w", err)
+	}
+	if !playlist.VisibleTo(userId) {
+		return nil, fmt.Errorf("smart playlist is private: %w", sql.ErrNoRows)
+	}
+
+	return playlist, nil
+}
+
+func (s *smartPlaylistInteractor) GetByUser(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]*entity.SmartPlaylistDB, error) {
+	limit, offset = entity.NormalizePlaylistPage(limit, offset)
+
+	playlists, err := s.repo.GetByUser(ctx, userId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/smart_playlist.GetByUser: %w", err)
+	}
+
+	return playlists, nil
+}
+
+func (s *smartPlaylistInteractor) Update(ctx context.Context, userId uuid.UUID, id uuid.UUID, playlist *entity.SmartPlaylistCreate) (*entity.SmartPlaylistDB, error) {
+	if err := playlist.Validate(); err != nil {
+		return nil, err
+	}
+
+	playlistDB, err := s.getOwned(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	playlistDB.Name = strings.TrimSpace(playlist.Name)
+	playlistDB.IsPublic = playlist.IsPublic
+	playlistDB.Rules = playlist.Rules
+	playlistDB.Sort = playlist.Sort
+	playlistDB.Order = playlist.Order
+	playlistDB.Limit = playlist.Limit
+	playlistDB.UpdatedAt = time.Now()
+
+	err = s.repo.Update(ctx, playlistDB)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/smart_playlist.Update: %w", err)
+	}
+
+	return playlistDB, nil
+}
+
+func (s *smartPlaylistInteractor) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	if _, err := s.getOwned(ctx, userId, id); err != nil {
+		return err
+	}
+
+	err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("/repository/smart_playlist.Delete: %w", err)
+	}
+
+	return nil
+}
+
+// GetTracks вычисляет плейлист на текущий момент. Условия на лайки, оценки и прослушивания
+// проверяются для запрашивающего пользователя, поэтому состав чужого публичного плейлиста у каждого свой.
+func (s *smartPlaylistInteractor) GetTracks(ctx context.Context, userId uuid.UUID, id uuid.UUID) ([]*entity.MusicDB, error) {
+	playlist, err := s.Get(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	music, err := s.repo.GetTracks(ctx, playlist, userId, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("/repository/smart_playlist.GetTracks: %w", err)
+	}
+
+	return music, nil
+}
+
+// getOwned возвращает плейлист для изменения. Изменять плейлист может только владелец.
+func (s *smartPlaylistInteractor) getOwned(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.SmartPlaylistDB, error) {
+	playlist, err := s.Get(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.UserID != userId {
+		return nil, entity.ErrPlaylistForbidden
+	}
+
+	return playlist, nil
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type statsInteractor struct {
+	repo repository.StatsRepository
+	cfg  *entity.StatsConfig
+
+	// Последний обработанный пользователь: следующий запуск продолжает с него.
+	// Задача планировщика выполняется последовательно, поэтому доступ не синхронизируется.
+	reviewCursor uuid.UUID
+}
+
+func NewStatsInteractor(repo repository.StatsRepository, cfg *entity.StatsConfig) *statsInteractor {
+	return &statsInteractor{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+func (s *statsInteractor) GetStats(ctx context.Context, userId uuid.UUID, filter *entity.StatsFilter) (*entity.ListeningStats, error) {
+	if err := filter.Normalize(time.Now(), s.cfg.TopSize); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.GetStats(ctx, userId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/stats.GetStats: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (s *statsInteractor) GetYearReview(ctx context.Context, userId uuid.UUID, year int) (*entity.YearReviewDB, error) {
+	review, err := s.repo.GetReview(ctx, userId, year)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/stats.GetReview: %w", err)
+	}
+
+	return review, nil
+}
+
+// GenerateYearReviews рассчитывает итоги прошедшего года для следующей страницы пользователей, у которых их еще нет.
+// Ошибка расчета для одного пользователя не останавливает остальных: попытка сохраняется,
+// и пользователь пропускается до времени повтора, которое растет с каждой неудачей.
+func (s *statsInteractor) GenerateYearReviews(ctx context.Context) error {
+	now := time.Now()
+	year := now.UTC().Year() - 1
+
+	userIds, err := s.repo.GetUsersWithoutReview(ctx, year, s.reviewCursor, now, s.cfg.ReviewBatchSize)
+	if err != nil {
+		return fmt.Errorf("/repository/stats.GetUsersWithoutReview: %w", err)
+	}
+
+	// Неполная страница означает конец списка: следующий запуск начинает сначала
+	s.reviewCursor = uuid.Nil
+	if len(userIds) == s.cfg.ReviewBatchSize {
+		s.reviewCursor = userIds[len(userIds)-1]
+	}
+
+	var errs []error
+	for _, userId := range userIds {
+		err := s.generateYearReview(ctx, userId, year, now)
+		if err == nil {
+			continue
+		}
+		errs = append(errs, err)
+
+		err = s.repo.RecordReviewFailure(ctx, &entity.YearReviewFailure{UserID: userId, Year: year, Error: err.Error(), FailedAt: now})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("/repository/stats.RecordReviewFailure %s: %w", userId, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *statsInteractor) generateYearReview(ctx context.Context, userId uuid.UUID, year int, now time.Time) error {
+	stats, err := s.repo.GetStats(ctx, userId, entity.YearStatsFilter(year, s.cfg.TopSize))
+	if err != nil {
+		return fmt.Errorf("/repository/stats.GetStats %s: %w", userId, err)
+	}
+
+	err = s.repo.SaveReview(ctx, &entity.YearReviewDB{UserID: userId, Year: year, Stats: stats, GeneratedAt: now})
+	if err != nil {
+		return fmt.Errorf("/repository/stats.SaveReview %s: %w", userId, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type syncInteractor struct {
+	repo repository.SyncRepository
+	cfg  *entity.SyncConfig
+}
+
+func NewSyncInteractor(repo repository.SyncRepository, cfg *entity.SyncConfig) *syncInteractor {
+	return &syncInteractor{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// Sync возвращает изменения после токена. Без токена начинается полная синхронизация: лайки и плейлисты
+// отдаются на первой странице, каталог — страницами по PageSize треков. Просроченный токен и изменения,
+// после которых локальную копию нельзя обновить частично, требуют полной синхронизации.
+func (s *syncInteractor) Sync(ctx context.Context, userId uuid.UUID, token string) (*entity.SyncDelta, error) {
+	now := time.Now()
+
+	if token == "" {
+		// Изменения транзакций, не завершенных к началу полной синхронизации, клиент получит следующим запросом
+		xmin, err := s.repo.GetXmin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("/repository/sync.GetXmin: %w", err)
+		}
+		return s.full(ctx, userId, &entity.SyncCursor{TxID: xmin, IssuedAt: now, After: &uuid.Nil})
+	}
+
+	cursor, err := entity.ParseSyncToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.IsExpired(now, s.cfg.TokenTTL) {
+		return nil, entity.ErrSyncTokenExpired
+	}
+	if cursor.After != nil {
+		return s.full(ctx, userId, cursor)
+	}
+
+	return s.changes(ctx, userId, cursor, now)
+}
+
+// full возвращает следующую страницу полной синхронизации. Время выдачи токена сохраняется с ее начала,
+// чтобы следующий запрос вернул треки, снятые с публикации за время синхронизации.
+func (s *syncInteractor) full(ctx context.Context, userId uuid.UUID, cursor *entity.SyncCursor) (*entity.SyncDelta, error) {
+	delta := &entity.SyncDelta{Full: true}
+
+	if *cursor.After == uuid.Nil {
+		likes, err := s.repo.GetAllLikes(ctx, userId)
+		if err != nil {
+			return nil, fmt.Errorf("/repository/sync.GetAllLikes: %w", err)
+		}
+		delta.Likes = likes
+
+		playlists, err := s.repo.GetAllPlaylists(ctx, userId)
+		if err != nil {
+			return nil, fmt.Errorf("/repository/sync.GetAllPlaylists: %w", err)
+		}
+		delta.Playlists, err = s.withTracks(ctx, userId, playlists)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tracks, err := s.repo.GetTrackPage(ctx, userId, *cursor.After, s.cfg.PageSize+1)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/sync.GetTrackPage: %w", err)
+	}
+
+	next := *cursor
+	next.After = nil
+	if len(tracks) > s.cfg.PageSize {
+		tracks = tracks[:s.cfg.PageSize]
+		last := tracks[len(tracks)-1].Id
+		next.After = &last
+		delta.HasMore = true
+	}
+	delta.Tracks = tracks
+	delta.Cursor = &next
+
+	return delta, nil
+}
+
+// changes возвращает изменения из журнала после позиции cursor. Сущность, которой больше нет
+// или которая стала недоступна, возвращается надгробием.
+func (s *syncInteractor) changes(ctx context.Context, userId uuid.UUID, cursor *entity.SyncCursor, now time.Time) (*entity.SyncDelta, error) {
+	// xmin читается до журнала: изменения транзакций, завершившихся после чтения, попадут в следующий ответ
+	xmin, err := s.repo.GetXmin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/sync.GetXmin: %w", err)
+	}
+
+	changes, err := s.repo.GetChanges(ctx, userId, cursor, xmin, s.cfg.PageSize+1)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/sync.GetChanges: %w", err)
+	}
+
+	delta := &entity.SyncDelta{}
+	next := &entity.SyncCursor{TxID: max(xmin, cursor.TxID), IssuedAt: now}
+	if len(changes) > s.cfg.PageSize {
+		changes = changes[:s.cfg.PageSize]
+		last := changes[len(changes)-1]
+		next.TxID, next.ID = last.TxID, last.ID
+		delta.HasMore = true
+	}
+	delta.Cursor = next
+
+	var trackIds, likeIds, playlistIds []uuid.UUID
+	seen := map[string]map[uuid.UUID]bool{}
+	for _, change := range changes {
+		if change.Kind == entity.SyncKindReset {
+			return nil, entity.ErrSyncTokenExpired
+		}
+		if seen[change.Kind] == nil {
+			seen[change.Kind] = map[uuid.UUID]bool{}
+		}
+		if seen[change.Kind][change.EntityID] {
+			continue
+		}
+		seen[change.Kind][change.EntityID] = true
+
+		switch change.Kind {
+		case entity.SyncKindTrack:
+			trackIds = append(trackIds, change.EntityID)
+		case entity.SyncKindLike:
+			likeIds = append(likeIds, change.EntityID)
+		case entity.SyncKindPlaylist:
+			playlistIds = append(playlistIds, change.EntityID)
+		}
+	}
+
+	if len(trackIds) > 0 {
+		tracks, err := s.repo.GetTracks(ctx, userId, trackIds)
+		if err != nil {
+			return nil, fmt.Errorf("/repository/sync.GetTracks: %w", err)
+		}
+		found := map[uuid.UUID]bool{}
+		for _, track := range tracks {
+			found[track.Id] = true
+		}
+		delta.Tracks = tracks
+		delta.DeletedTracks = missingIds(trackIds, found)
+	}
+
+	takenDown, err := s.repo.GetTakenDown(ctx, cursor.IssuedAt, now)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/sync.GetTakenDown: %w", err)
+	}
+	deleted := map[uuid.UUID]bool{}
+	for _, id := range delta.DeletedTracks {
+		deleted[id] = true
+	}
+	for _, id := range takenDown {
+		if !deleted[id] {
+			delta.DeletedTracks = append(delta.DeletedTracks, id)
+		}
+	}
+
+	if len(likeIds) > 0 {
+		likes, err := s.repo.GetLikes(ctx, userId, likeIds)
+		if err != nil {
+			return nil, fmt.Errorf("/repository/sync.GetLikes: %w", err)
+		}
+		found := map[uuid.UUID]bool{}
+		for _, like := range likes {
+			found[like.MusicID] = true
+		}
+		delta.Likes = likes
+		delta.RemovedLikes = missingIds(likeIds, found)
+	}
+
+	if len(playlistIds) > 0 {
+		playlists, err := s.repo.GetPlaylists(ctx, userId, playlistIds)
+		if err != nil {
+			return nil, fmt.Errorf("/repository/sync.GetPlaylists: %w", err)
+		}
+		found := map[uuid.UUID]bool{}
+		for _, playlist := range playlists {
+			found[playlist.ID] = true
+		}
+		delta.Playlists, err = s.withTracks(ctx, userId, playlists)
+		if err != nil {
+			return nil, err
+		}
+		delta.DeletedPlaylists = missingIds(playlistIds, found)
+	}
+
+	return delta, nil
+}
+
+// withTracks добавляет к плейлистам id доступных пользователю треков
+func (s *syncInteractor) withTracks(ctx context.Context, userId uuid.UUID, playlists []*entity.PlaylistDB) ([]*entity.SyncPlaylist, error) {
+	if len(playlists) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(playlists))
+	for _, playlist := range playlists {
+		ids = append(ids, playlist.ID)
+	}
+	tracks, err := s.repo.GetPlaylistTracks(ctx, userId, ids)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/sync.GetPlaylistTracks: %w", err)
+	}
+
+	trackIds := map[uuid.UUID][]uuid.UUID{}
+	for _, track := range tracks {
+		trackIds[track.PlaylistID] = append(trackIds[track.PlaylistID], track.MusicID)
+	}
+
+	data := make([]*entity.SyncPlaylist, 0, len(playlists))
+	for _, playlist := range playlists {
+		data = append(data, &entity.SyncPlaylist{Playlist: playlist, TrackIDs: trackIds[playlist.ID]})
+	}
+
+	return data, nil
+}
+
+// CleanupChanges удаляет записи журнала старше срока хранения
+func (s *syncInteractor) CleanupChanges(ctx context.Context) error {
+	before := time.Now().Add(-s.cfg.Retention())
+	for {
+		deleted, err := s.repo.DeleteChanges(ctx, before, entity.SyncCleanupBatch)
+		if err != nil {
+			return fmt.Errorf("/repository/sync.DeleteChanges: %w", err)
+		}
+		if deleted < entity.SyncCleanupBatch {
+			return nil
+		}
+	}
+}
+
+// missingIds возвращает id, которых нет среди найденных, в исходном порядке
+func missingIds(ids []uuid.UUID, found map[uuid.UUID]bool) []uuid.UUID {
+	var data []uuid.UUID
+	for _, id := range ids {
+		if !found[id] {
+			data = append(data, id)
+		}
+	}
+	return data
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_chartInteractor_GetWeekly(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		week         time.Time
+		hideExplicit bool
+	}
+
+	monday := time.Date(2023, time.March, 20, 0, 0, 0, 0, time.UTC)
+	entries := []*entity.ChartEntryDB{{Week: monday, Position: 1}}
+	explicitEntries := []*entity.ChartEntryDB{
+		{MusicDB: entity.MusicDB{Explicit: true}, Week: monday, Position: 1},
+		{Week: monday, Position: 2},
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, r *repository.MockChartRepository)
+		want    []*entity.ChartEntryDB
+		wantErr error
+	}{
+		{
+			name: "success: any day is normalized to monday",
+			args: args{ctx: context.Background(), week: time.Date(2023, time.March, 23, 15, 0, 0, 0, time.UTC)},
+			setup: func(a args, r *repository.MockChartRepository) {
+				r.EXPECT().GetByWeek(a.ctx, monday).Return(entries, nil)
+			},
+			want: entries,
+		},
+		{
+			name: "success: latest chart",
+			args: args{ctx: context.Background()},
+			setup: func(a args, r *repository.MockChartRepository) {
+				r.EXPECT().LatestWeek(a.ctx).Return(monday, nil)
+				r.EXPECT().GetByWeek(a.ctx, monday).Return(entries, nil)
+			},
+			want: entries,
+		},
+		{
+			name: "success: explicit tracks hidden, positions kept",
+			args: args{ctx: context.Background(), week: monday, hideExplicit: true},
+			setup: func(a args, r *repository.MockChartRepository) {
+				r.EXPECT().GetByWeek(a.ctx, monday).Return(explicitEntries, nil)
+			},
+			want: explicitEntries[1:],
+		},
+		{
+			name: "error: no charts yet",
+			args: args{ctx: context.Background()},
+			setup: func(a args, r *repository.MockChartRepository) {
+				r.EXPECT().LatestWeek(a.ctx).Return(time.Time{}, fmt.Errorf("/db/chart.LatestWeek: %w", sql.ErrNoRows))
+			},
+			wantErr: entity.ErrChartNotFound,
+		},
+		{
+			name: "error: week without chart",
+			args: args{ctx: context.Background(), week: monday},
+			setup: func(a args, r *repository.MockChartRepository) {
+				r.EXPECT().GetByWeek(a.ctx, monday).Return(nil, nil)
+			},
+			wantErr: entity.ErrChartNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			repo := repository.NewMockChartRepository(cntr)
+			chartUsecase := usecase.NewChartInteractor(repo, &entity.ChartConfig{Size: 100})
+			tt.setup(tt.args, repo)
+
+			got, err := chartUsecase.GetWeekly(tt.args.ctx, tt.args.week, tt.args.hideExplicit)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_chartInteractor_Snapshot(t *testing.T) {
+	cfg := &entity.ChartConfig{Size: 100, PlayWeight: 1, LikeWeight: 5}
+	previousWeek := entity.ChartWeekStart(time.Now()).AddDate(0, 0, -7)
+
+	tests := []struct {
+		name    string
+		setup   func(r *repository.MockChartRepository)
+		wantErr bool
+	}{
+		{
+			name: "success: no stored charts",
+			setup: func(r *repository.MockChartRepository) {
+				r.EXPECT().LatestWeek(gomock.Any()).Return(time.Time{}, fmt.Errorf("/db/chart.LatestWeek: %w", sql.ErrNoRows))
+				r.EXPECT().Snapshot(gomock.Any(), cfg, previousWeek).Return(int64(100), nil)
+			},
+		},
+		{
+			name: "success: previous week already stored",
+			setup: func(r *repository.MockChartRepository) {
+				r.EXPECT().LatestWeek(gomock.Any()).Return(previousWeek, nil)
+			},
+		},
+		{
+			name: "success: catch up weeks missed during downtime",
+			setup: func(r *repository.MockChartRepository) {
+				r.EXPECT().LatestWeek(gomock.Any()).Return(previousWeek.AddDate(0, 0, -21), nil)
+				gomock.InOrder(
+					r.EXPECT().Snapshot(gomock.Any(), cfg, previousWeek.AddDate(0, 0, -14)).Return(int64(100), nil),
+					r.EXPECT().Snapshot(gomock.Any(), cfg, previousWeek.AddDate(0, 0, -7)).Return(int64(0), nil),
+					r.EXPECT().Snapshot(gomock.Any(), cfg, previousWeek).Return(int64(100), nil),
+				)
+			},
+		},
+		{
+			name: "error: snapshot stops catch-up",
+			setup: func(r *repository.MockChartRepository) {
+				r.EXPECT().LatestWeek(gomock.Any()).Return(previousWeek.AddDate(0, 0, -14), nil)
+				r.EXPECT().Snapshot(gomock.Any(), cfg, previousWeek.AddDate(0, 0, -7)).Return(int64(0), fmt.Errorf("can't snapshot"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error: latest week",
+			setup: func(r *repository.MockChartRepository) {
+				r.EXPECT().LatestWeek(gomock.Any()).Return(time.Time{}, fmt.Errorf("can't get latest week"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			repo := repository.NewMockChartRepository(cntr)
+			tt.setup(repo)
+
+			err := usecase.NewChartInteractor(repo, cfg).Snapshot(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_chartInteractor_GetMusicHistory(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockChartRepository(cntr)
+	chartUsecase := usecase.NewChartInteractor(repo, &entity.ChartConfig{})
+
+	ctx := context.Background()
+	musicId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	repo.EXPECT().GetByMusic(ctx, musicId).Return(nil, fmt.Errorf("can't get history"))
+
+	_, err := chartUsecase.GetMusicHistory(ctx, musicId)
+	assert.Error(t, err)
+}
@@ -0,0 +1,541 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var commentConfig = &entity.CommentConfig{RateLimit: 3, RateWindow: time.Minute}
+
+func Test_commentInteractor_Create(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		comment *entity.CommentCreate
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	parentId := uuid.MustParse("8b1c5c1e-2f0a-4d61-9c55-4b1e3f1a2d7e")
+	otherId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	created := &entity.CommentDB{MusicID: &musicId, UserID: userId, Username: "user", Body: "nice", Status: entity.CommentStatusVisible}
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, r *repository.MockCommentRepository)
+		want    *entity.CommentDB
+		wantErr error
+	}{
+		{
+			name: "success: top-level comment",
+			args: args{ctx: context.Background(), comment: &entity.CommentCreate{Body: "  nice  "}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Create(a.ctx, gomock.Any(), commentConfig.RateLimit, gomock.Any()).DoAndReturn(func(_ context.Context, comment *entity.CommentDB, _ int, since time.Time) error {
+					assert.Equal(t, "nice", comment.Body)
+					assert.Equal(t, comment.CreatedAt.Add(-commentConfig.RateWindow), since)
+					assert.Nil(t, comment.ParentID)
+					return nil
+				})
+				r.EXPECT().Get(a.ctx, gomock.Any()).Return(created, nil)
+			},
+			want: created,
+		},
+		{
+			name: "success: reply notifies parent author",
+			args: args{ctx: context.Background(), comment: &entity.CommentCreate{Body: "agree", ParentID: &parentId}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, parentId).Return(&entity.CommentDB{ID: parentId, MusicID: &musicId, UserID: otherId, Status: entity.CommentStatusVisible}, nil)
+				r.EXPECT().Create(a.ctx, gomock.Any(), commentConfig.RateLimit, gomock.Any()).Return(nil)
+				r.EXPECT().Get(a.ctx, gomock.Any()).Return(created, nil)
+				r.EXPECT().Notify(a.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, notification *entity.NotificationDB) error {
+					assert.Equal(t, otherId, notification.UserID)
+					assert.Equal(t, entity.NotificationCommentReply, notification.Type)
+					assert.Equal(t, &userId, notification.ActorID)
+					return nil
+				})
+			},
+			want: created,
+		},
+		{
+			name: "success: reply to own comment",
+			args: args{ctx: context.Background(), comment: &entity.CommentCreate{Body: "agree", ParentID: &parentId}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, parentId).Return(&entity.CommentDB{ID: parentId, MusicID: &musicId, UserID: userId, Status: entity.CommentStatusVisible}, nil)
+				r.EXPECT().Create(a.ctx, gomock.Any(), commentConfig.RateLimit, gomock.Any()).Return(nil)
+				r.EXPECT().Get(a.ctx, gomock.Any()).Return(created, nil)
+			},
+			want: created,
+		},
+		{
+			name: "success: notification failure keeps reply",
+			args: args{ctx: context.Background(), comment: &entity.CommentCreate{Body: "agree", ParentID: &parentId}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, parentId).Return(&entity.CommentDB{ID: parentId, MusicID: &musicId, UserID: otherId, Status: entity.CommentStatusVisible}, nil)
+				r.EXPECT().Create(a.ctx, gomock.Any(), commentConfig.RateLimit, gomock.Any()).Return(nil)
+				r.EXPECT().Get(a.ctx, gomock.Any()).Return(created, nil)
+				r.EXPECT().Notify(a.ctx, gomock.Any()).Return(fmt.Errorf("/db/notification.Create: %w", sql.ErrConnDone))
+			},
+			want: created,
+		},
+		{
+			name:    "error: empty body",
+			args:    args{ctx: context.Background(), comment: &entity.CommentCreate{Body: "   "}},
+			setup:   func(a args, r *repository.MockCommentRepository) {},
+			wantErr: entity.ErrInvalidComment,
+		},
+		{
+			name: "error: rate limit exceeded",
+			args: args{ctx: context.Background(), comment: &entity.CommentCreate{Body: "nice"}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Create(a.ctx, gomock.Any(), commentConfig.RateLimit, gomock.Any()).Return(fmt.Errorf("/db/comment.Create: %w", entity.ErrCommentRateLimited))
+			},
+			wantErr: entity.ErrCommentRateLimited,
+		},
+		{
+			name: "error: reply to reply",
+			args: args{ctx: context.Background(), comment: &entity.CommentCreate{Body: "agree", ParentID: &parentId}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, parentId).Return(&entity.CommentDB{
+					ID: parentId, MusicID: &musicId, ParentID: &otherId, Status: entity.CommentStatusVisible,
+				}, nil)
+			},
+			wantErr: entity.ErrCommentReplyDepth,
+		},
+		{
+			name: "error: parent comment hidden",
+			args: args{ctx: context.Background(), comment: &entity.CommentCreate{Body: "agree", ParentID: &parentId}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, parentId).Return(&entity.CommentDB{ID: parentId, MusicID: &musicId, Status: entity.CommentStatusHidden}, nil)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "error: parent comment of another track",
+			args: args{ctx: context.Background(), comment: &entity.CommentCreate{Body: "agree", ParentID: &parentId}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, parentId).Return(&entity.CommentDB{ID: parentId, MusicID: &otherId, Status: entity.CommentStatusVisible}, nil)
+			},
+			wantErr: entity.ErrInvalidComment,
+		},
+		{
+			name: "error: music not found",
+			args: args{ctx: context.Background(), comment: &entity.CommentCreate{Body: "nice"}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Create(a.ctx, gomock.Any(), commentConfig.RateLimit, gomock.Any()).Return(fmt.Errorf("/db/comment.Create: %w", sql.ErrNoRows))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			repo := repository.NewMockCommentRepository(cntr)
+			commentUsecase := usecase.NewCommentInteractor(repo, commentConfig)
+			tt.setup(tt.args, repo)
+
+			got, err := commentUsecase.Create(tt.args.ctx, userId, musicId, tt.args.comment)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_commentInteractor_CreateForPlaylist(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		userId  uuid.UUID
+		comment *entity.CommentCreate
+	}
+
+	ownerId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	playlistId := uuid.MustParse("5b7c3a1e-9d2f-4e8b-a6c4-1f0e2d3c4b5a")
+	parentId := uuid.MustParse("8b1c5c1e-2f0a-4d61-9c55-4b1e3f1a2d7e")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	publicPlaylist := &entity.PlaylistDB{ID: playlistId, UserID: ownerId, Name: "Road trip", IsPublic: true}
+	privatePlaylist := &entity.PlaylistDB{ID: playlistId, UserID: ownerId, Name: "Road trip"}
+	created := &entity.CommentDB{ID: commentId, PlaylistID: &playlistId, UserID: userId, Body: "nice", Status: entity.CommentStatusVisible}
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, r *repository.MockCommentRepository)
+		want    *entity.CommentDB
+		wantErr error
+	}{
+		{
+			name: "success: playlist owner notified",
+			args: args{ctx: context.Background(), userId: userId, comment: &entity.CommentCreate{Body: "nice"}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().GetPlaylist(a.ctx, playlistId).Return(publicPlaylist, nil)
+				r.EXPECT().Create(a.ctx, gomock.Any(), commentConfig.RateLimit, gomock.Any()).DoAndReturn(func(_ context.Context, comment *entity.CommentDB, _ int, _ time.Time) error {
+					assert.Equal(t, &playlistId, comment.PlaylistID)
+					assert.Nil(t, comment.MusicID)
+					return nil
+				})
+				r.EXPECT().Get(a.ctx, gomock.Any()).Return(created, nil)
+				r.EXPECT().Notify(a.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, notification *entity.NotificationDB) error {
+					assert.Equal(t, ownerId, notification.UserID)
+					assert.Equal(t, entity.NotificationPlaylistComment, notification.Type)
+					assert.Equal(t, &userId, notification.ActorID)
+					assert.Equal(t, &playlistId, notification.PlaylistID)
+					assert.Equal(t, &commentId, notification.CommentID)
+					return nil
+				})
+			},
+			want: created,
+		},
+		{
+			name: "success: owner's own comment is not notified",
+			args: args{ctx: context.Background(), userId: ownerId, comment: &entity.CommentCreate{Body: "nice"}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().GetPlaylist(a.ctx, playlistId).Return(privatePlaylist, nil)
+				r.EXPECT().Create(a.ctx, gomock.Any(), commentConfig.RateLimit, gomock.Any()).Return(nil)
+				r.EXPECT().Get(a.ctx, gomock.Any()).Return(created, nil)
+			},
+			want: created,
+		},
+		{
+			name: "success: reply to owner notifies only about the reply",
+			args: args{ctx: context.Background(), userId: userId, comment: &entity.CommentCreate{Body: "agree", ParentID: &parentId}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().GetPlaylist(a.ctx, playlistId).Return(publicPlaylist, nil)
+				r.EXPECT().Get(a.ctx, parentId).Return(&entity.CommentDB{
+					ID: parentId, PlaylistID: &playlistId, UserID: ownerId, Status: entity.CommentStatusVisible,
+				}, nil)
+				r.EXPECT().Create(a.ctx, gomock.Any(), commentConfig.RateLimit, gomock.Any()).Return(nil)
+				r.EXPECT().Get(a.ctx, gomock.Any()).Return(created, nil)
+				r.EXPECT().Notify(a.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, notification *entity.NotificationDB) error {
+					assert.Equal(t, entity.NotificationCommentReply, notification.Type)
+					assert.Equal(t, &playlistId, notification.PlaylistID)
+					return nil
+				})
+			},
+			want: created,
+		},
+		{
+			name: "error: parent comment belongs to a track",
+			args: args{ctx: context.Background(), userId: userId, comment: &entity.CommentCreate{Body: "agree", ParentID: &parentId}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().GetPlaylist(a.ctx, playlistId).Return(publicPlaylist, nil)
+				r.EXPECT().Get(a.ctx, parentId).Return(&entity.CommentDB{
+					ID: parentId, MusicID: &commentId, UserID: ownerId, Status: entity.CommentStatusVisible,
+				}, nil)
+			},
+			wantErr: entity.ErrInvalidComment,
+		},
+		{
+			name: "error: private playlist of another user",
+			args: args{ctx: context.Background(), userId: userId, comment: &entity.CommentCreate{Body: "nice"}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().GetPlaylist(a.ctx, playlistId).Return(privatePlaylist, nil)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "error: playlist not found",
+			args: args{ctx: context.Background(), userId: userId, comment: &entity.CommentCreate{Body: "nice"}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().GetPlaylist(a.ctx, playlistId).Return(nil, fmt.Errorf("/db/comment.GetPlaylist: %w", sql.ErrNoRows))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			repo := repository.NewMockCommentRepository(cntr)
+			commentUsecase := usecase.NewCommentInteractor(repo, commentConfig)
+			tt.setup(tt.args, repo)
+
+			got, err := commentUsecase.CreateForPlaylist(tt.args.ctx, tt.args.userId, playlistId, tt.args.comment)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_commentInteractor_GetByPlaylist(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockCommentRepository(cntr)
+	commentUsecase := usecase.NewCommentInteractor(repo, commentConfig)
+
+	ctx := context.Background()
+	ownerId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	readerId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	playlistId := uuid.MustParse("5b7c3a1e-9d2f-4e8b-a6c4-1f0e2d3c4b5a")
+	playlist := &entity.PlaylistDB{ID: playlistId, UserID: ownerId}
+	comments := []*entity.CommentDB{{ID: uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11"), PlaylistID: &playlistId}}
+
+	repo.EXPECT().GetPlaylist(ctx, playlistId).Return(playlist, nil)
+	repo.EXPECT().GetByPlaylist(ctx, playlistId, gomock.Any()).Return(comments, nil)
+
+	page, err := commentUsecase.GetByPlaylist(ctx, playlistId, &entity.CommentFilter{ViewerID: ownerId})
+	assert.NoError(t, err)
+	assert.Equal(t, comments, page.Comments)
+
+	// Комментарии приватного плейлиста видны только владельцу
+	repo.EXPECT().GetPlaylist(ctx, playlistId).Return(playlist, nil)
+
+	_, err = commentUsecase.GetByPlaylist(ctx, playlistId, &entity.CommentFilter{ViewerID: readerId})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func Test_commentInteractor_GetByMusic(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockCommentRepository(cntr)
+	commentUsecase := usecase.NewCommentInteractor(repo, commentConfig)
+
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	now := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	comments := []*entity.CommentDB{
+		{ID: uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11"), ReplyCount: 5, CreatedAt: now},
+		{ID: uuid.MustParse("8b1c5c1e-2f0a-4d61-9c55-4b1e3f1a2d7e"), ReplyCount: 4, CreatedAt: now},
+		{ID: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"), ReplyCount: 1, CreatedAt: now},
+	}
+
+	repo.EXPECT().MusicVisible(ctx, musicId, false).Return(true, nil)
+	repo.EXPECT().GetByMusic(ctx, musicId, &entity.CommentFilter{Sort: entity.CommentSortTop, Limit: 2}).Return(comments, nil)
+
+	page, err := commentUsecase.GetByMusic(ctx, musicId, &entity.CommentFilter{Sort: entity.CommentSortTop, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, comments[:2], page.Comments)
+
+	cursor, err := entity.DecodeCommentCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, comments[1].ID, cursor.ID)
+	assert.Equal(t, 4, cursor.ReplyCount)
+	assert.True(t, now.Equal(cursor.CreatedAt))
+
+	_, err = commentUsecase.GetByMusic(ctx, musicId, &entity.CommentFilter{Sort: "oldest"})
+	assert.ErrorIs(t, err, entity.ErrUnknownCommentSort)
+
+	// Трек в корзине или не опубликован: комментарии не выдаются
+	repo.EXPECT().MusicVisible(ctx, musicId, false).Return(false, nil)
+
+	_, err = commentUsecase.GetByMusic(ctx, musicId, &entity.CommentFilter{})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Администратору доступны комментарии неопубликованного трека
+	repo.EXPECT().MusicVisible(ctx, musicId, true).Return(true, nil)
+	repo.EXPECT().GetByMusic(ctx, musicId, gomock.Any()).Return(nil, nil)
+
+	_, err = commentUsecase.GetByMusic(ctx, musicId, &entity.CommentFilter{ShowUnpublished: true})
+	assert.NoError(t, err)
+}
+
+func Test_commentInteractor_GetReplies(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockCommentRepository(cntr)
+	commentUsecase := usecase.NewCommentInteractor(repo, commentConfig)
+
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	parentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	parent := &entity.CommentDB{ID: parentId, MusicID: &musicId, Status: entity.CommentStatusVisible}
+	replies := []*entity.CommentDB{{ID: uuid.MustParse("8b1c5c1e-2f0a-4d61-9c55-4b1e3f1a2d7e"), ParentID: &parentId}}
+
+	repo.EXPECT().Get(ctx, parentId).Return(parent, nil)
+	repo.EXPECT().MusicVisible(ctx, musicId, false).Return(true, nil)
+	repo.EXPECT().GetReplies(ctx, parentId, gomock.Any()).Return(replies, nil)
+
+	page, err := commentUsecase.GetReplies(ctx, parentId, &entity.CommentFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, replies, page.Comments)
+
+	// Ответы к комментариям трека в корзине не выдаются
+	repo.EXPECT().Get(ctx, parentId).Return(parent, nil)
+	repo.EXPECT().MusicVisible(ctx, musicId, false).Return(false, nil)
+
+	_, err = commentUsecase.GetReplies(ctx, parentId, &entity.CommentFilter{})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func Test_commentInteractor_Delete(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		userId  uuid.UUID
+		isAdmin bool
+	}
+
+	authorId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	adminId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	comment := &entity.CommentDB{ID: commentId, UserID: authorId, Status: entity.CommentStatusVisible}
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, r *repository.MockCommentRepository)
+		wantErr error
+	}{
+		{
+			name: "success: author",
+			args: args{ctx: context.Background(), userId: authorId},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, commentId).Return(comment, nil)
+				r.EXPECT().SetStatus(a.ctx, commentId, entity.CommentStatusDeleted, nil, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "success: admin",
+			args: args{ctx: context.Background(), userId: adminId, isAdmin: true},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, commentId).Return(comment, nil)
+				r.EXPECT().SetStatus(a.ctx, commentId, entity.CommentStatusDeleted, &adminId, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "error: another user",
+			args: args{ctx: context.Background(), userId: adminId},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, commentId).Return(comment, nil)
+			},
+			wantErr: entity.ErrCommentForbidden,
+		},
+		{
+			name: "error: already deleted",
+			args: args{ctx: context.Background(), userId: authorId},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, commentId).Return(&entity.CommentDB{ID: commentId, UserID: authorId, Status: entity.CommentStatusDeleted}, nil)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			repo := repository.NewMockCommentRepository(cntr)
+			commentUsecase := usecase.NewCommentInteractor(repo, commentConfig)
+			tt.setup(tt.args, repo)
+
+			err := commentUsecase.Delete(tt.args.ctx, tt.args.userId, commentId, tt.args.isAdmin)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_commentInteractor_Update(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockCommentRepository(cntr)
+	commentUsecase := usecase.NewCommentInteractor(repo, commentConfig)
+
+	ctx := context.Background()
+	authorId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	otherId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	comment := &entity.CommentDB{ID: commentId, UserID: authorId, Body: "old", Status: entity.CommentStatusVisible}
+	updated := &entity.CommentDB{ID: commentId, UserID: authorId, Body: "new", Status: entity.CommentStatusVisible}
+
+	repo.EXPECT().Get(ctx, commentId).Return(comment, nil)
+	repo.EXPECT().Update(ctx, commentId, "new", gomock.Any()).Return(nil)
+	repo.EXPECT().Get(ctx, commentId).Return(updated, nil)
+
+	got, err := commentUsecase.Update(ctx, authorId, commentId, &entity.CommentUpdate{Body: "new"})
+	assert.NoError(t, err)
+	assert.Equal(t, updated, got)
+
+	repo.EXPECT().Get(ctx, commentId).Return(comment, nil)
+
+	_, err = commentUsecase.Update(ctx, otherId, commentId, &entity.CommentUpdate{Body: "new"})
+	assert.ErrorIs(t, err, entity.ErrCommentForbidden)
+}
+
+func Test_commentInteractor_Report(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userId uuid.UUID
+		report *entity.ReportCreate
+	}
+
+	authorId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	reporterId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+	comment := &entity.CommentDB{ID: commentId, UserID: authorId, Status: entity.CommentStatusVisible}
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, r *repository.MockCommentRepository)
+		wantErr error
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), userId: reporterId, report: &entity.ReportCreate{Reason: "spam"}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, commentId).Return(comment, nil)
+				r.EXPECT().CreateReport(a.ctx, gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name: "error: duplicate report",
+			args: args{ctx: context.Background(), userId: reporterId, report: &entity.ReportCreate{Reason: "spam"}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, commentId).Return(comment, nil)
+				r.EXPECT().CreateReport(a.ctx, gomock.Any()).Return(false, nil)
+			},
+			wantErr: entity.ErrDuplicateReport,
+		},
+		{
+			name: "error: own comment",
+			args: args{ctx: context.Background(), userId: authorId, report: &entity.ReportCreate{Reason: "spam"}},
+			setup: func(a args, r *repository.MockCommentRepository) {
+				r.EXPECT().Get(a.ctx, commentId).Return(comment, nil)
+			},
+			wantErr: entity.ErrInvalidComment,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			repo := repository.NewMockCommentRepository(cntr)
+			commentUsecase := usecase.NewCommentInteractor(repo, commentConfig)
+			tt.setup(tt.args, repo)
+
+			err := commentUsecase.Report(tt.args.ctx, tt.args.userId, commentId, tt.args.report)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_commentInteractor_Hide(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockCommentRepository(cntr)
+	commentUsecase := usecase.NewCommentInteractor(repo, commentConfig)
+
+	ctx := context.Background()
+	moderatorId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	commentId := uuid.MustParse("0d2f4c43-5a51-4e8f-8f1e-9d0b0d8b6c11")
+
+	repo.EXPECT().Get(ctx, commentId).Return(&entity.CommentDB{ID: commentId, Status: entity.CommentStatusVisible}, nil)
+	repo.EXPECT().SetStatus(ctx, commentId, entity.CommentStatusHidden, &moderatorId, gomock.Any()).Return(nil)
+	assert.NoError(t, commentUsecase.Hide(ctx, moderatorId, commentId))
+
+	repo.EXPECT().Get(ctx, commentId).Return(&entity.CommentDB{ID: commentId, Status: entity.CommentStatusHidden}, nil)
+	assert.ErrorIs(t, commentUsecase.Hide(ctx, moderatorId, commentId), sql.ErrNoRows)
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var testExportConfig = &entity.ExportConfig{
+	BaseURL:    "http://music.local",
+	SyncLimit:  100,
+	Retention:  24 * time.Hour,
+	BatchSize:  5,
+	JobTimeout: 30 * time.Minute,
+}
+
+func Test_exportInteractor_Start(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	playlistId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+
+	tests := []struct {
+		name    string
+		request *entity.ExportRequest
+		setup   func(repo *repository.MockExportRepository)
+		wantJob bool
+		wantErr error
+	}{
+		{
+			name:    "success: small export is streamed",
+			request: &entity.ExportRequest{Format: entity.ExportFormatJSON},
+			setup: func(repo *repository.MockExportRepository) {
+				repo.EXPECT().CountItems(gomock.Any(), userId, gomock.Any()).Return(int64(100), nil)
+			},
+		},
+		{
+			name:    "success: large export becomes a job",
+			request: &entity.ExportRequest{Format: entity.ExportFormatCSV},
+			setup: func(repo *repository.MockExportRepository) {
+				repo.EXPECT().CountItems(gomock.Any(), userId, gomock.Any()).Return(int64(101), nil)
+				repo.EXPECT().CreateJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *entity.ExportJobDB) error {
+					assert.Equal(t, userId, job.UserID)
+					assert.Equal(t, entity.ExportStatusPending, job.Status)
+					assert.Equal(t, int64(101), job.Items)
+					return nil
+				})
+			},
+			wantJob: true,
+		},
+		{
+			name:    "success: async export becomes a job",
+			request: &entity.ExportRequest{Format: entity.ExportFormatM3U8, PlaylistID: &playlistId, Async: true},
+			setup: func(repo *repository.MockExportRepository) {
+				repo.EXPECT().CountItems(gomock.Any(), userId, gomock.Any()).Return(int64(3), nil)
+				repo.EXPECT().CreateJob(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantJob: true,
+		},
+		{
+			name:    "error: unknown format",
+			request: &entity.ExportRequest{Format: "xml"},
+			setup:   func(repo *repository.MockExportRepository) {},
+			wantErr: entity.ErrInvalidExport,
+		},
+		{
+			name:    "error: playlist_id with library format",
+			request: &entity.ExportRequest{Format: entity.ExportFormatJSON, PlaylistID: &playlistId},
+			setup:   func(repo *repository.MockExportRepository) {},
+			wantErr: entity.ErrInvalidExport,
+		},
+		{
+			name:    "error: playlist not found",
+			request: &entity.ExportRequest{Format: entity.ExportFormatXSPF, PlaylistID: &playlistId},
+			setup: func(repo *repository.MockExportRepository) {
+				repo.EXPECT().CountItems(gomock.Any(), userId, gomock.Any()).Return(int64(0), sql.ErrNoRows)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockExportRepository(ctrl)
+			tt.setup(repo)
+
+			job, err := usecase.NewExportInteractor(repo, testExportConfig).Start(context.Background(), userId, tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantJob, job != nil)
+		})
+	}
+}
+
+func Test_exportInteractor_Write_history(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	page := make([]*entity.ExportPlayDB, entity.ExportHistoryBatchSize)
+	for i := range page {
+		page[i] = &entity.ExportPlayDB{ID: uuid.New(), PlayedAt: time.Date(2023, time.March, 24, 12, 0, i, 0, time.UTC)}
+	}
+	last := page[len(page)-1]
+
+	repo := repository.NewMockExportRepository(ctrl)
+	repo.EXPECT().GetLikes(gomock.Any(), userId).Return(nil, nil)
+	repo.EXPECT().GetPlaylists(gomock.Any(), userId, nil).Return(nil, nil)
+	repo.EXPECT().GetRatings(gomock.Any(), userId).Return(nil, nil)
+	gomock.InOrder(
+		repo.EXPECT().GetHistory(gomock.Any(), userId, time.Time{}, uuid.Nil, entity.ExportHistoryBatchSize).Return(page, nil),
+		repo.EXPECT().GetHistory(gomock.Any(), userId, last.PlayedAt, last.ID, entity.ExportHistoryBatchSize).Return(nil, nil),
+	)
+
+	var buf bytes.Buffer
+	err := usecase.NewExportInteractor(repo, testExportConfig).Write(context.Background(), userId, &entity.ExportRequest{Format: entity.ExportFormatCSV}, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ExportHistoryBatchSize+1, bytes.Count(buf.Bytes(), []byte("\n")))
+}
+
+func Test_exportInteractor_GetDownload(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	jobId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		job     *entity.ExportJobDB
+		wantErr error
+	}{
+		{
+			name: "success",
+			job:  &entity.ExportJobDB{ID: jobId, UserID: userId, Status: entity.ExportStatusDone, ExpiresAt: &future},
+		},
+		{
+			name:    "error: job of another user",
+			job:     &entity.ExportJobDB{ID: jobId, UserID: uuid.New(), Status: entity.ExportStatusDone, ExpiresAt: &future},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name:    "error: still running",
+			job:     &entity.ExportJobDB{ID: jobId, UserID: userId, Status: entity.ExportStatusRunning},
+			wantErr: entity.ErrExportNotReady,
+		},
+		{
+			name:    "error: expired",
+			job:     &entity.ExportJobDB{ID: jobId, UserID: userId, Status: entity.ExportStatusDone, ExpiresAt: &past},
+			wantErr: entity.ErrExportExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockExportRepository(ctrl)
+			repo.EXPECT().GetJob(gomock.Any(), jobId).Return(tt.job, nil)
+
+			_, err := usecase.NewExportInteractor(repo, testExportConfig).GetDownload(context.Background(), userId, jobId)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_exportInteractor_ProcessJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	playlistId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	expired := &entity.ExportJobDB{ID: uuid.New(), UserID: userId}
+	done := &entity.ExportJobDB{ID: uuid.New(), UserID: userId, Format: entity.ExportFormatM3U8, PlaylistID: &playlistId}
+	failed := &entity.ExportJobDB{ID: uuid.New(), UserID: userId, Format: entity.ExportFormatXSPF}
+	dbErr := errors.New("connection reset")
+
+	repo := repository.NewMockExportRepository(ctrl)
+	repo.EXPECT().DeleteExpiredJobs(gomock.Any(), gomock.Any(), 5).Return([]*entity.ExportJobDB{expired}, nil)
+	repo.EXPECT().RemoveFile(expired).Return(nil)
+	repo.EXPECT().ClaimJobs(gomock.Any(), gomock.Any(), gomock.Any(), 5).Return([]*entity.ExportJobDB{done, failed}, nil)
+	repo.EXPECT().WriteFile(gomock.Any(), gomock.Any()).DoAndReturn(func(job *entity.ExportJobDB, write func(w io.Writer) error) (int64, error) {
+		var buf bytes.Buffer
+		if err := write(&buf); err != nil {
+			return 0, err
+		}
+		return int64(buf.Len()), nil
+	}).Times(2)
+	repo.EXPECT().GetPlaylists(gomock.Any(), userId, &playlistId).Return([]*entity.PlaylistDB{{ID: playlistId, UserID: userId, Name: "Road"}}, nil)
+	repo.EXPECT().GetPlaylistTracks(gomock.Any(), playlistId).Return(nil, nil)
+	repo.EXPECT().GetPlaylists(gomock.Any(), userId, nil).Return(nil, dbErr)
+	repo.EXPECT().FinishJob(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	err := usecase.NewExportInteractor(repo, testExportConfig).ProcessJobs(context.Background())
+	assert.ErrorIs(t, err, dbErr)
+
+	assert.Equal(t, entity.ExportStatusDone, done.Status)
+	assert.Greater(t, done.Size, int64(0))
+	assert.NotNil(t, done.ExpiresAt)
+	assert.Equal(t, entity.ExportStatusFailed, failed.Status)
+	assert.NotNil(t, failed.Error)
+	assert.Nil(t, failed.ExpiresAt)
+}
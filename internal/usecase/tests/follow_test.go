@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_followInteractor_FollowUser(t *testing.T) {
+	followerId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	followeeId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+
+	tests := []struct {
+		name       string
+		followeeId uuid.UUID
+		setup      func(repo *repository.MockFollowRepository)
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			followeeId: followeeId,
+			setup: func(repo *repository.MockFollowRepository) {
+				repo.EXPECT().FollowUser(gomock.Any(), followerId, followeeId, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:       "error: self follow",
+			followeeId: followerId,
+			setup:      func(repo *repository.MockFollowRepository) {},
+			wantErr:    entity.ErrSelfFollow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockFollowRepository(ctrl)
+			tt.setup(repo)
+
+			err := usecase.NewFollowInteractor(repo).FollowUser(context.Background(), followerId, tt.followeeId)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_followInteractor_GetFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	occurredAt := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	items := []*entity.FeedItemDB{
+		{ID: "release:1", Kind: entity.FeedItemRelease, OccurredAt: occurredAt},
+		{ID: "like:2", Kind: entity.FeedItemLike, OccurredAt: occurredAt.Add(-time.Minute)},
+		{ID: "playlist:3", Kind: entity.FeedItemPlaylist, OccurredAt: occurredAt.Add(-time.Hour)},
+	}
+
+	repo := repository.NewMockFollowRepository(ctrl)
+	repo.EXPECT().GetFeed(gomock.Any(), userId, &entity.FeedFilter{Limit: 2}).Return(items, nil)
+
+	page, err := usecase.NewFollowInteractor(repo).GetFeed(context.Background(), userId, &entity.FeedFilter{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, items[:2], page.Items)
+
+	cursor, err := entity.DecodeFeedCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.FeedCursor{OccurredAt: items[1].OccurredAt, ID: items[1].ID}, cursor)
+}
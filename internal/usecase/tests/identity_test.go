@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var testOIDCConfig = &entity.OIDCConfig{
+	Issuer:      "http://oidc:8080/default",
+	ClientID:    "music-backend",
+	Scopes:      []string{"openid"},
+	GroupsClaim: "groups",
+	AdminGroup:  "music-admins",
+	DefaultRole: entity.UserRole,
+	StateTTL:    10 * time.Minute,
+}
+
+func Test_identityInteractor_Begin(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repository.NewMockIdentityRepository(ctrl)
+
+	repo.EXPECT().Begin(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, login *entity.OIDCLoginDB) (string, error) {
+			assert.Equal(t, &userId, login.UserID)
+			assert.NotEmpty(t, login.State)
+			assert.NotEqual(t, login.State, login.Nonce)
+			assert.Len(t, login.CodeVerifier, 43)
+			assert.WithinDuration(t, time.Now().Add(testOIDCConfig.StateTTL), login.ExpiresAt, time.Second)
+			return "http://oidc:8080/default/authorize?state=" + login.State, nil
+		})
+
+	got, err := usecase.NewIdentityInteractor(repo, testOIDCConfig).Begin(context.Background(), &userId)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "http://oidc:8080/default/authorize?state="+got.State, got.URL)
+		assert.WithinDuration(t, time.Now().Add(testOIDCConfig.StateTTL), got.ExpiresAt, time.Second)
+	}
+}
+
+func Test_identityInteractor_Complete(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	attackerId := uuid.MustParse("0b7e3c1a-4a1f-4d5e-9c55-6f2f8c3e1d20")
+	user := &entity.UserDB{ID: userId, Username: "jdoe", Role: entity.UserRole}
+
+	identity := func(groups ...string) *entity.OIDCIdentity {
+		return &entity.OIDCIdentity{
+			Issuer:            "http://oidc:8080/default",
+			Subject:           "user-42",
+			PreferredUsername: "jdoe",
+			Groups:            groups,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		userId  *uuid.UUID
+		setup   func(repo *repository.MockIdentityRepository)
+		wantErr error
+	}{
+		{
+			name: "success: first login",
+			setup: func(repo *repository.MockIdentityRepository) {
+				login := &entity.OIDCLoginDB{State: "state", Nonce: "nonce", CodeVerifier: "verifier"}
+				repo.EXPECT().ConsumeLogin(gomock.Any(), "state", gomock.Any()).Return(login, nil)
+				repo.EXPECT().Exchange(gomock.Any(), login, "code").Return(identity("staff"), nil)
+				repo.EXPECT().Login(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, login *entity.IdentityLogin, _ time.Time) (*entity.UserDB, error) {
+						assert.Equal(t, "user-42", login.Subject)
+						assert.Nil(t, login.LinkUserID)
+						assert.Equal(t, "jdoe", login.NewUser.Username)
+						assert.NotEmpty(t, login.NewUser.Password)
+						assert.Equal(t, entity.UserRole, login.Role)
+						assert.False(t, login.Admin)
+						return user, nil
+					})
+			},
+		},
+		{
+			name:   "success: admin group and link",
+			userId: &userId,
+			setup: func(repo *repository.MockIdentityRepository) {
+				login := &entity.OIDCLoginDB{State: "state", UserID: &userId}
+				repo.EXPECT().ConsumeLogin(gomock.Any(), "state", gomock.Any()).Return(login, nil)
+				repo.EXPECT().Exchange(gomock.Any(), login, "code").Return(identity("staff", "music-admins"), nil)
+				repo.EXPECT().Login(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, login *entity.IdentityLogin, _ time.Time) (*entity.UserDB, error) {
+						assert.Equal(t, &userId, login.LinkUserID)
+						assert.True(t, login.Admin)
+						return user, nil
+					})
+			},
+		},
+		{
+			name: "error: unknown state",
+			setup: func(repo *repository.MockIdentityRepository) {
+				repo.EXPECT().ConsumeLogin(gomock.Any(), "state", gomock.Any()).Return(nil, fmt.Errorf("/db/identity.ConsumeLogin: %w", sql.ErrNoRows))
+			},
+			wantErr: entity.ErrInvalidOIDCLogin,
+		},
+		{
+			name: "error: code rejected",
+			setup: func(repo *repository.MockIdentityRepository) {
+				repo.EXPECT().ConsumeLogin(gomock.Any(), "state", gomock.Any()).Return(&entity.OIDCLoginDB{State: "state"}, nil)
+				repo.EXPECT().Exchange(gomock.Any(), gomock.Any(), "code").Return(nil, fmt.Errorf("/oidc/provider.Exchange: %w", entity.ErrOIDCCodeRejected))
+			},
+			wantErr: entity.ErrOIDCCodeRejected,
+		},
+		{
+			name: "error: link completed without authentication",
+			setup: func(repo *repository.MockIdentityRepository) {
+				repo.EXPECT().ConsumeLogin(gomock.Any(), "state", gomock.Any()).Return(&entity.OIDCLoginDB{State: "state", UserID: &userId}, nil)
+			},
+			wantErr: entity.ErrOIDCLinkForbidden,
+		},
+		{
+			name:   "error: link completed by another user",
+			userId: &attackerId,
+			setup: func(repo *repository.MockIdentityRepository) {
+				repo.EXPECT().ConsumeLogin(gomock.Any(), "state", gomock.Any()).Return(&entity.OIDCLoginDB{State: "state", UserID: &userId}, nil)
+			},
+			wantErr: entity.ErrOIDCLinkForbidden,
+		},
+		{
+			name:   "error: identity linked to another user",
+			userId: &userId,
+			setup: func(repo *repository.MockIdentityRepository) {
+				login := &entity.OIDCLoginDB{State: "state", UserID: &userId}
+				repo.EXPECT().ConsumeLogin(gomock.Any(), "state", gomock.Any()).Return(login, nil)
+				repo.EXPECT().Exchange(gomock.Any(), login, "code").Return(identity(), nil)
+				repo.EXPECT().Login(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("/db/identity.Login: %w", entity.ErrIdentityLinked))
+			},
+			wantErr: entity.ErrIdentityLinked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := repository.NewMockIdentityRepository(ctrl)
+			tt.setup(repo)
+
+			got, err := usecase.NewIdentityInteractor(repo, testOIDCConfig).Complete(context.Background(), "state", "code", tt.userId)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, user, got)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lyricsInteractor_Get(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	synced := "[00:01.50]Hello\n[00:03.00]World\n"
+	repoErr := fmt.Errorf("db error")
+	variants := []*entity.LyricsDB{
+		{MusicID: musicId, Language: "en-gb", Plain: "Hello\nWorld", Synced: &synced},
+		{MusicID: musicId, Language: "ru", Plain: "Привет"},
+	}
+
+	tests := []struct {
+		name            string
+		language        string
+		showUnpublished bool
+		setup           func(r *repository.MockLyricsRepository)
+		wantLanguage    string
+		wantLines       []entity.LyricLine
+		wantErr         error
+	}{
+		{
+			name:     "success: first variant without language",
+			language: "",
+			setup: func(r *repository.MockLyricsRepository) {
+				r.EXPECT().GetByMusic(gomock.Any(), musicId, false).Return(variants, nil)
+			},
+			wantLanguage: "en-gb",
+			wantLines: []entity.LyricLine{
+				{Time: 1500 * time.Millisecond, Text: "Hello"},
+				{Time: 3 * time.Second, Text: "World"},
+			},
+		},
+		{
+			name:     "success: exact language",
+			language: "RU",
+			setup: func(r *repository.MockLyricsRepository) {
+				r.EXPECT().GetByMusic(gomock.Any(), musicId, false).Return(variants, nil)
+			},
+			wantLanguage: "ru",
+		},
+		{
+			name:     "success: primary language fallback",
+			language: "en",
+			setup: func(r *repository.MockLyricsRepository) {
+				r.EXPECT().GetByMusic(gomock.Any(), musicId, false).Return(variants, nil)
+			},
+			wantLanguage: "en-gb",
+			wantLines: []entity.LyricLine{
+				{Time: 1500 * time.Millisecond, Text: "Hello"},
+				{Time: 3 * time.Second, Text: "World"},
+			},
+		},
+		{
+			name:     "error: language not found",
+			language: "de",
+			setup: func(r *repository.MockLyricsRepository) {
+				r.EXPECT().GetByMusic(gomock.Any(), musicId, false).Return(variants, nil)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name:            "success: unpublished track for admin",
+			language:        "ru",
+			showUnpublished: true,
+			setup: func(r *repository.MockLyricsRepository) {
+				r.EXPECT().GetByMusic(gomock.Any(), musicId, true).Return(variants, nil)
+			},
+			wantLanguage: "ru",
+		},
+		{
+			name: "error: track in trash or not published",
+			setup: func(r *repository.MockLyricsRepository) {
+				r.EXPECT().GetByMusic(gomock.Any(), musicId, false).Return(nil, nil)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name:     "error: invalid language",
+			language: "not a language",
+			setup:    func(r *repository.MockLyricsRepository) {},
+			wantErr:  entity.ErrInvalidLyrics,
+		},
+		{
+			name:     "error: repository",
+			language: "",
+			setup: func(r *repository.MockLyricsRepository) {
+				r.EXPECT().GetByMusic(gomock.Any(), musicId, false).Return(nil, repoErr)
+			},
+			wantErr: repoErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockLyricsRepository(ctrl)
+			tt.setup(repo)
+
+			got, err := usecase.NewLyricsInteractor(repo).Get(context.Background(), musicId, tt.language, tt.showUnpublished)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLanguage, got.Language)
+			assert.Equal(t, tt.wantLines, got.Lines)
+			assert.Equal(t, []string{"en-gb", "ru"}, got.Languages)
+		})
+	}
+}
+
+func Test_lyricsInteractor_Set(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	tests := []struct {
+		name    string
+		lyrics  *entity.LyricsUpload
+		setup   func(r *repository.MockLyricsRepository)
+		wantErr error
+	}{
+		{
+			name:   "success: lrc is normalized and plain text derived",
+			lyrics: &entity.LyricsUpload{Language: "EN", Synced: "[ar:Artist]\n[offset:500]\n[00:03.5][00:01.00]Hello\n[00:02.25]World"},
+			setup: func(r *repository.MockLyricsRepository) {
+				var saved *entity.LyricsDB
+				r.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, lyrics *entity.LyricsDB) error {
+					assert.Equal(t, musicId, lyrics.MusicID)
+					assert.Equal(t, "en", lyrics.Language)
+					assert.Equal(t, "Hello\nWorld\nHello", lyrics.Plain)
+					assert.Equal(t, "[ar:Artist]\n[00:00.50]Hello\n[00:01.75]World\n[00:03.00]Hello\n", *lyrics.Synced)
+					assert.Equal(t, entity.LyricsSourceUpload, lyrics.Source)
+					saved = lyrics
+					return nil
+				})
+				r.EXPECT().GetByMusic(gomock.Any(), musicId, true).DoAndReturn(func(_ context.Context, _ uuid.UUID, _ bool) ([]*entity.LyricsDB, error) {
+					return []*entity.LyricsDB{saved}, nil
+				})
+			},
+		},
+		{
+			name:   "success: plain text only",
+			lyrics: &entity.LyricsUpload{Plain: "  Hello  "},
+			setup: func(r *repository.MockLyricsRepository) {
+				r.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, lyrics *entity.LyricsDB) error {
+					assert.Equal(t, entity.UndefinedLanguage, lyrics.Language)
+					assert.Equal(t, "Hello", lyrics.Plain)
+					assert.Nil(t, lyrics.Synced)
+					return nil
+				})
+				r.EXPECT().GetByMusic(gomock.Any(), musicId, true).Return([]*entity.LyricsDB{{MusicID: musicId, Language: entity.UndefinedLanguage, Plain: "Hello"}}, nil)
+			},
+		},
+		{
+			name:    "error: empty lyrics",
+			lyrics:  &entity.LyricsUpload{Plain: " "},
+			setup:   func(r *repository.MockLyricsRepository) {},
+			wantErr: entity.ErrInvalidLyrics,
+		},
+		{
+			name:    "error: invalid lrc line",
+			lyrics:  &entity.LyricsUpload{Synced: "[00:01.00]Hello\njust text"},
+			setup:   func(r *repository.MockLyricsRepository) {},
+			wantErr: entity.ErrInvalidLRC,
+		},
+		{
+			name:    "error: invalid seconds",
+			lyrics:  &entity.LyricsUpload{Synced: "[00:75.00]Hello"},
+			setup:   func(r *repository.MockLyricsRepository) {},
+			wantErr: entity.ErrInvalidLRC,
+		},
+		{
+			name:   "error: music not found",
+			lyrics: &entity.LyricsUpload{Plain: "Hello"},
+			setup: func(r *repository.MockLyricsRepository) {
+				r.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockLyricsRepository(ctrl)
+			tt.setup(repo)
+
+			got, err := usecase.NewLyricsInteractor(repo).Set(context.Background(), musicId, tt.lyrics)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
+
+func Test_lyricsInteractor_Delete(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockLyricsRepository(ctrl)
+	repo.EXPECT().Delete(gomock.Any(), musicId, entity.UndefinedLanguage).Return(nil)
+	repo.EXPECT().Delete(gomock.Any(), musicId, "pt-br").Return(sql.ErrNoRows)
+
+	interactor := usecase.NewLyricsInteractor(repo)
+	assert.NoError(t, interactor.Delete(context.Background(), musicId, ""))
+	assert.ErrorIs(t, interactor.Delete(context.Background(), musicId, "pt_BR"), sql.ErrNoRows)
+	assert.ErrorIs(t, interactor.Delete(context.Background(), musicId, "!"), entity.ErrInvalidLyrics)
+}
+
+func Test_lyricsInteractor_LRCExport(t *testing.T) {
+	synced := "[ti:Song]\n[00:01.50]Hello\n"
+	lyrics, err := entity.NewLyrics(&entity.LyricsDB{Language: "en", Synced: &synced}, nil)
+	assert.NoError(t, err)
+
+	lrc, err := lyrics.LRC()
+	assert.NoError(t, err)
+	assert.Equal(t, "[la:en]\n[ti:Song]\n[00:01.50]Hello\n", lrc)
+
+	plain, err := entity.NewLyrics(&entity.LyricsDB{Language: "en", Plain: "Hello"}, nil)
+	assert.NoError(t, err)
+	_, err = plain.LRC()
+	assert.ErrorIs(t, err, entity.ErrNoSyncedLyrics)
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_musicRevisionInteractor_Diff(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	release := time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC)
+	v1 := &entity.MusicRevisionDB{MusicID: musicId, Version: 1, Name: "Song", Release: release, FileName: "a.mp3", Size: 500,
+		Duration: "2:47", FilePath: "./revisions/a.mp3"}
+	v2 := &entity.MusicRevisionDB{MusicID: musicId, Version: 2, Name: "Song (Remastered)", Release: release, FileName: "a.mp3", Size: 900,
+		Duration: "2:47", FilePath: "./internal/storage/music_storage/a.mp3"}
+
+	tests := []struct {
+		name        string
+		from        int
+		to          int
+		setup       func(r *repository.MockMusicRevisionRepository)
+		wantChanges []entity.MusicFieldChange
+		wantErr     error
+	}{
+		{
+			name: "success: latest against previous",
+			setup: func(r *repository.MockMusicRevisionRepository) {
+				r.EXPECT().GetLatest(gomock.Any(), musicId).Return(v2, nil)
+				r.EXPECT().Get(gomock.Any(), musicId, 1).Return(v1, nil)
+			},
+			wantChanges: []entity.MusicFieldChange{
+				{Field: "name", From: "Song", To: "Song (Remastered)"},
+				{Field: "size", From: "500", To: "900"},
+				{Field: "file", From: "v1", To: "v2"},
+			},
+		},
+		{
+			name: "success: same version",
+			from: 2,
+			to:   2,
+			setup: func(r *repository.MockMusicRevisionRepository) {
+				r.EXPECT().Get(gomock.Any(), musicId, 2).Return(v2, nil).Times(2)
+			},
+			wantChanges: []entity.MusicFieldChange{},
+		},
+		{
+			name: "error: first version has no previous",
+			to:   1,
+			setup: func(r *repository.MockMusicRevisionRepository) {
+				r.EXPECT().Get(gomock.Any(), musicId, 1).Return(v1, nil)
+			},
+			wantErr: entity.ErrInvalidMusicRevision,
+		},
+		{
+			name:    "error: negative version",
+			from:    -1,
+			setup:   func(r *repository.MockMusicRevisionRepository) {},
+			wantErr: entity.ErrInvalidMusicRevision,
+		},
+		{
+			name: "error: version not found",
+			from: 5,
+			to:   2,
+			setup: func(r *repository.MockMusicRevisionRepository) {
+				r.EXPECT().Get(gomock.Any(), musicId, 2).Return(v2, nil)
+				r.EXPECT().Get(gomock.Any(), musicId, 5).Return(nil, fmt.Errorf("/db/music_revision.Get: %w", sql.ErrNoRows))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockMusicRevisionRepository(ctrl)
+			tt.setup(repo)
+
+			got, err := usecase.NewMusicRevisionInteractor(repo).Diff(context.Background(), musicId, tt.from, tt.to)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantChanges, got.Changes)
+		})
+	}
+}
+
+func Test_musicRevisionInteractor_Revert(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	v1 := &entity.MusicRevisionDB{MusicID: musicId, Version: 1, Name: "Song", FileName: "a.mp3", FilePath: "./revisions/a.mp3"}
+	v2 := &entity.MusicRevisionDB{MusicID: musicId, Version: 2, Name: "Song 2", FileName: "b.mp3", FilePath: "./internal/storage/music_storage/b.mp3"}
+	v3 := &entity.MusicRevisionDB{MusicID: musicId, Version: 3, Name: "Song", FileName: "a.mp3", Action: entity.MusicRevisionRevert}
+
+	tests := []struct {
+		name    string
+		version int
+		setup   func(r *repository.MockMusicRevisionRepository)
+		want    *entity.MusicRevisionDB
+		wantErr error
+	}{
+		{
+			name:    "success",
+			version: 1,
+			setup: func(r *repository.MockMusicRevisionRepository) {
+				r.EXPECT().Get(gomock.Any(), musicId, 1).Return(v1, nil)
+				r.EXPECT().GetLatest(gomock.Any(), musicId).Return(v2, nil)
+				r.EXPECT().Revert(gomock.Any(), v1, v2, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *entity.MusicRevisionDB, _ *entity.MusicRevisionDB, revision *entity.MusicRevisionDB) error {
+						assert.Equal(t, entity.MusicRevisionRevert, revision.Action)
+						assert.Equal(t, "Song", revision.Name)
+						assert.Equal(t, 1, *revision.RevertedFrom)
+						assert.Equal(t, userId, *revision.ChangedBy)
+						revision.Version = 3
+						return nil
+					})
+				r.EXPECT().Get(gomock.Any(), musicId, 3).Return(v3, nil)
+			},
+			want: v3,
+		},
+		{
+			name:    "error: revert to current version",
+			version: 2,
+			setup: func(r *repository.MockMusicRevisionRepository) {
+				r.EXPECT().Get(gomock.Any(), musicId, 2).Return(v2, nil)
+				r.EXPECT().GetLatest(gomock.Any(), musicId).Return(v2, nil)
+			},
+			wantErr: entity.ErrRevertToCurrent,
+		},
+		{
+			name:    "error: invalid version",
+			version: 0,
+			setup:   func(r *repository.MockMusicRevisionRepository) {},
+			wantErr: entity.ErrInvalidMusicRevision,
+		},
+		{
+			name:    "error: version not found",
+			version: 9,
+			setup: func(r *repository.MockMusicRevisionRepository) {
+				r.EXPECT().Get(gomock.Any(), musicId, 9).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockMusicRevisionRepository(ctrl)
+			tt.setup(repo)
+
+			got, err := usecase.NewMusicRevisionInteractor(repo).Revert(context.Background(), userId, musicId, tt.version)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_musicRevisionInteractor_GetByMusic(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockMusicRevisionRepository(ctrl)
+	repo.EXPECT().GetByMusic(gomock.Any(), musicId, entity.MaxMusicRevisionLimit, 0).Return(nil, nil)
+
+	_, err := usecase.NewMusicRevisionInteractor(repo).GetByMusic(context.Background(), musicId, 1000, -5)
+	assert.NoError(t, err)
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"mime/multipart"
 	"music-backend-test/internal/entity"
@@ -40,7 +41,7 @@ func Test_GetAll(t *testing.T) {
 				ctx: ctx,
 			},
 			setup: func(a args, f field) {
-				f.repository.EXPECT().GetAll(a.ctx).Return([]*entity.MusicDB{
+				f.repository.EXPECT().GetAll(a.ctx, entity.MusicFilter{ShowUnpublished: true}).Return([]*entity.MusicDB{
 					{
 						Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
 						Name:     "Song1",
@@ -85,7 +86,7 @@ func Test_GetAll(t *testing.T) {
 				ctx: ctx,
 			},
 			setup: func(a args, f field) {
-				f.repository.EXPECT().GetAll(a.ctx).Return(nil, fmt.Errorf("Error in GetAll"))
+				f.repository.EXPECT().GetAll(a.ctx, entity.MusicFilter{ShowUnpublished: true}).Return(nil, fmt.Errorf("Error in GetAll"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -100,7 +101,7 @@ func Test_GetAll(t *testing.T) {
 			musicUsecase := usecase.NewMusicInteractor(f.repository)
 			tt.setup(tt.args, f)
 
-			got, gotErr := musicUsecase.GetAll(tt.args.ctx)
+			got, gotErr := musicUsecase.GetAll(tt.args.ctx, entity.MusicFilter{ShowUnpublished: true})
 			if tt.wantErr == true {
 				assert.Error(t, gotErr)
 			} else {
@@ -178,7 +179,7 @@ func Test_Get(t *testing.T) {
 			musicUsecase := usecase.NewMusicInteractor(f.repository)
 			tt.setup(tt.args, f)
 
-			got, gotErr := musicUsecase.Get(tt.args.ctx, tt.args.musicId)
+			got, gotErr := musicUsecase.Get(tt.args.ctx, tt.args.musicId, entity.MusicFilter{ShowUnpublished: true})
 			if tt.wantErr == true {
 				assert.Error(t, gotErr)
 			} else {
@@ -189,7 +190,7 @@ func Test_Get(t *testing.T) {
 		})
 	}
 }
-func Test_GetAndSortByPopular(t *testing.T) {
+func Test_GetAllSortByTime(t *testing.T) {
 	type field struct {
 		repository *repository.MockMusicRepository
 	}
@@ -207,12 +208,12 @@ func Test_GetAndSortByPopular(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "GetAndSortByPopular",
+			name: "GetAllSortByTime",
 			args: args{
 				ctx: ctx,
 			},
 			setup: func(a args, f field) {
-				f.repository.EXPECT().GetAndSortByPopular(a.ctx).Return([]*entity.MusicDB{
+				f.repository.EXPECT().GetAllSortByTime(a.ctx, entity.MusicFilter{ShowUnpublished: true}).Return([]*entity.MusicDB{
 					{
 						Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
 						Name:     "Song1",
@@ -252,12 +253,12 @@ func Test_GetAndSortByPopular(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "Error in repository GetAndSortByPopular",
+			name: "Error in repository GetAllSortByTime",
 			args: args{
 				ctx: ctx,
 			},
 			setup: func(a args, f field) {
-				f.repository.EXPECT().GetAndSortByPopular(a.ctx).Return(nil, fmt.Errorf("Error in GetAndSortByPopular"))
+				f.repository.EXPECT().GetAllSortByTime(a.ctx, entity.MusicFilter{ShowUnpublished: true}).Return(nil, fmt.Errorf("Error in GetAllSortByTime"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -272,7 +273,7 @@ func Test_GetAndSortByPopular(t *testing.T) {
 			musicUsecase := usecase.NewMusicInteractor(f.repository)
 			tt.setup(tt.args, f)
 
-			got, gotErr := musicUsecase.GetAndSortByPopular(tt.args.ctx)
+			got, gotErr := musicUsecase.GetAllSortByTime(tt.args.ctx, entity.MusicFilter{ShowUnpublished: true})
 			if tt.wantErr == true {
 				assert.Error(t, gotErr)
 			} else {
@@ -284,13 +285,101 @@ func Test_GetAndSortByPopular(t *testing.T) {
 	}
 }
 
-func Test_GetAllSortByTime(t *testing.T) {
+func Test_GetAllSortByRating(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockMusicRepository(cntr)
+	musicUsecase := usecase.NewMusicInteractor(repo)
+
+	ctx := context.Background()
+	musics := []*entity.MusicDB{{Name: "Song1", RatingAvg: 4.5, RatingCount: 120}}
+	repo.EXPECT().GetAllSortByRating(ctx, entity.MusicFilter{ShowUnpublished: true}).Return(musics, nil)
+
+	got, err := musicUsecase.GetAllSortByRating(ctx, entity.MusicFilter{ShowUnpublished: true})
+	if assert.NoError(t, err) {
+		assert.Equal(t, musics, got)
+	}
+
+	repo.EXPECT().GetAllSortByRating(ctx, entity.MusicFilter{ShowUnpublished: true}).Return(nil, fmt.Errorf("Error in GetAllSortByRating"))
+	_, err = musicUsecase.GetAllSortByRating(ctx, entity.MusicFilter{ShowUnpublished: true})
+	assert.Error(t, err)
+}
+
+func Test_musicInteractor_HidesUnpublished(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockMusicRepository(cntr)
+	musicUsecase := usecase.NewMusicInteractor(repo)
+
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+	published := &entity.MusicDB{Id: uuid.New(), Name: "Published", PublishedAt: &past}
+	scheduled := &entity.MusicDB{Id: uuid.New(), Name: "Scheduled", PublishAt: time.Now().Add(time.Hour)}
+	takenDown := &entity.MusicDB{Id: uuid.New(), Name: "Taken down", PublishedAt: &past, TakedownAt: &past}
+
+	// Списки фильтруются запросом к бд, интерактор только передает фильтр
+	repo.EXPECT().GetAllSortByTime(ctx, entity.MusicFilter{}).Return([]*entity.MusicDB{published}, nil)
+
+	got, err := musicUsecase.GetAllSortByTime(ctx, entity.MusicFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.MusicDB{published}, got)
+
+	repo.EXPECT().Get(ctx, scheduled.Id).Return(scheduled, nil).Times(2)
+
+	_, err = musicUsecase.Get(ctx, scheduled.Id, entity.MusicFilter{})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	music, err := musicUsecase.Get(ctx, scheduled.Id, entity.MusicFilter{ShowUnpublished: true})
+	assert.NoError(t, err)
+	assert.Equal(t, scheduled, music)
+
+	repo.EXPECT().Get(ctx, takenDown.Id).Return(takenDown, nil)
+
+	_, err = musicUsecase.Get(ctx, takenDown.Id, entity.MusicFilter{})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func Test_musicInteractor_HidesExplicit(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockMusicRepository(cntr)
+	musicUsecase := usecase.NewMusicInteractor(repo)
+
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+	clean := &entity.MusicDB{Id: uuid.New(), Name: "Clean", PublishedAt: &past}
+	explicit := &entity.MusicDB{Id: uuid.New(), Name: "Explicit", PublishedAt: &past, Explicit: true}
+
+	repo.EXPECT().GetAll(ctx, entity.MusicFilter{HideExplicit: true}).Return([]*entity.MusicDB{clean}, nil)
+
+	got, err := musicUsecase.GetAll(ctx, entity.MusicFilter{HideExplicit: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.MusicDB{clean}, got)
+
+	repo.EXPECT().Get(ctx, explicit.Id).Return(explicit, nil)
+
+	_, err = musicUsecase.Get(ctx, explicit.Id, entity.MusicFilter{HideExplicit: true})
+	assert.ErrorIs(t, err, entity.ErrExplicitContent)
+}
+
+func Test_musicInteractor_SetExplicit(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockMusicRepository(cntr)
+	musicUsecase := usecase.NewMusicInteractor(repo)
+
+	ctx := context.Background()
+	id := uuid.New()
+	repo.EXPECT().SetExplicit(ctx, id, true).Return(fmt.Errorf("/db/music.SetExplicit: %w", sql.ErrNoRows))
+
+	err := musicUsecase.SetExplicit(ctx, id, true)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func Test_Create(t *testing.T) {
 	type field struct {
 		repository *repository.MockMusicRepository
 	}
 
 	type args struct {
-		ctx context.Context
+		ctx        context.Context
+		musicParse *entity.MusicParse
 	}
 	ctx := context.Background()
 
@@ -298,106 +387,70 @@ func Test_GetAllSortByTime(t *testing.T) {
 		name    string
 		args    args
 		setup   func(a args, f field)
-		want    []*entity.MusicDB
 		wantErr bool
 	}{
 		{
-			name: "GetAllSortByTime",
+			name: "Create music",
 			args: args{
 				ctx: ctx,
-			},
-			setup: func(a args, f field) {
-				f.repository.EXPECT().GetAllSortByTime(a.ctx).Return([]*entity.MusicDB{
-					{
-						Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-						Name:     "Song1",
-						Release:  time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
-						FileName: "Song1.mp3",
-						Size:     uint64(500),
-						Duration: "2:47",
-					},
-					{
-						Id:       uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
-						Name:     "Song2",
-						Release:  time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
-						FileName: "Song2.mp3",
-						Size:     uint64(900),
-						Duration: "3:23",
+				musicParse: &entity.MusicParse{
+					Name:    "Song2",
+					Release: time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
+					File:    os.NewFile(uintptr(syscall.Stdout), "Test.MP3"),
+					FileHeader: &multipart.FileHeader{
+						Filename: "Test.MP3",
+						Size:     64,
 					},
-				}, nil)
-			},
-			want: []*entity.MusicDB{
-				{
-					Id:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-					Name:     "Song1",
-					Release:  time.Date(2023, time.March, 24, 0, 0, 0, 0, time.UTC),
-					FileName: "Song1.mp3",
-					Size:     uint64(500),
-					Duration: "2:47",
-				},
-				{
-					Id:       uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
-					Name:     "Song2",
-					Release:  time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
-					FileName: "Song2.mp3",
-					Size:     uint64(900),
-					Duration: "3:23",
 				},
 			},
+			setup: func(a args, f field) {
+				music := &entity.MusicDB{Id: uuid.New(), Name: a.musicParse.Name}
+				fingerprint := &entity.MusicFingerprintDB{MusicID: music.Id, ContentHash: "abc"}
+				f.repository.EXPECT().Create(a.ctx, a.musicParse).Return(music, nil)
+				f.repository.EXPECT().Fingerprint(a.ctx, music).Return(fingerprint, nil)
+				f.repository.EXPECT().GetDuplicateCandidates(a.ctx, fingerprint).Return(nil, nil)
+			},
 			wantErr: false,
 		},
 		{
-			name: "Error in repository GetAllSortByTime",
+			name: "Create music with duplicates",
 			args: args{
 				ctx: ctx,
+				musicParse: &entity.MusicParse{
+					Name:    "Song2 (Remastered)",
+					Release: time.Date(2021, time.November, 15, 0, 0, 0, 0, time.UTC),
+					File:    os.NewFile(uintptr(syscall.Stdout), "Test.MP3"),
+					FileHeader: &multipart.FileHeader{
+						Filename: "Test.MP3",
+						Size:     64,
+					},
+				},
 			},
 			setup: func(a args, f field) {
-				f.repository.EXPECT().GetAllSortByTime(a.ctx).Return(nil, fmt.Errorf("Error in GetAllSortByTime"))
+				hash := "abc"
+				music := &entity.MusicDB{Id: uuid.New(), Name: a.musicParse.Name}
+				fingerprint := &entity.MusicFingerprintDB{MusicID: music.Id, ContentHash: hash}
+				candidates := []*entity.DuplicateCandidateDB{
+					{MusicID: uuid.New(), Name: "Song2", DurationDiff: 1},
+					{MusicID: uuid.New(), Name: "Other", DurationDiff: 40, ContentHash: &hash},
+					{MusicID: uuid.New(), Name: "Song2", DurationDiff: 60},
+				}
+				f.repository.EXPECT().Create(a.ctx, a.musicParse).Return(music, nil)
+				f.repository.EXPECT().Fingerprint(a.ctx, music).Return(fingerprint, nil)
+				f.repository.EXPECT().GetDuplicateCandidates(a.ctx, fingerprint).Return(candidates, nil)
+				f.repository.EXPECT().SaveDuplicates(a.ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, duplicates []*entity.MusicDuplicateDB) error {
+						if len(duplicates) != 2 || duplicates[0].CandidateID != candidates[1].MusicID || !duplicates[0].SameHash ||
+							duplicates[1].CandidateID != candidates[0].MusicID || !duplicates[1].SameTitle {
+							return fmt.Errorf("unexpected duplicates")
+						}
+						return nil
+					})
 			},
-			want:    nil,
-			wantErr: true,
+			wantErr: false,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			cntr := gomock.NewController(t)
-			f := field{
-				repository: repository.NewMockMusicRepository(cntr),
-			}
-			musicUsecase := usecase.NewMusicInteractor(f.repository)
-			tt.setup(tt.args, f)
-
-			got, gotErr := musicUsecase.GetAllSortByTime(tt.args.ctx)
-			if tt.wantErr == true {
-				assert.Error(t, gotErr)
-			} else {
-				if assert.NoError(t, gotErr) {
-					assert.Equal(t, tt.want, got)
-				}
-			}
-		})
-	}
-}
-
-func Test_Create(t *testing.T) {
-	type field struct {
-		repository *repository.MockMusicRepository
-	}
-
-	type args struct {
-		ctx        context.Context
-		musicParse *entity.MusicParse
-	}
-	ctx := context.Background()
-
-	tests := []struct {
-		name    string
-		args    args
-		setup   func(a args, f field)
-		wantErr bool
-	}{
 		{
-			name: "Create music",
+			name: "Error in repository Fingerprint",
 			args: args{
 				ctx: ctx,
 				musicParse: &entity.MusicParse{
@@ -411,9 +464,11 @@ func Test_Create(t *testing.T) {
 				},
 			},
 			setup: func(a args, f field) {
-				f.repository.EXPECT().Create(a.ctx, a.musicParse).Return(nil)
+				music := &entity.MusicDB{Id: uuid.New(), Name: a.musicParse.Name}
+				f.repository.EXPECT().Create(a.ctx, a.musicParse).Return(music, nil)
+				f.repository.EXPECT().Fingerprint(a.ctx, music).Return(nil, fmt.Errorf("Error in repository Fingerprint"))
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name: "Error in repository Create",
@@ -430,7 +485,7 @@ func Test_Create(t *testing.T) {
 				},
 			},
 			setup: func(a args, f field) {
-				f.repository.EXPECT().Create(a.ctx, a.musicParse).Return(fmt.Errorf("Error in repository Create"))
+				f.repository.EXPECT().Create(a.ctx, a.musicParse).Return(nil, fmt.Errorf("Error in repository Create"))
 			},
 			wantErr: true,
 		},
@@ -444,9 +499,12 @@ func Test_Create(t *testing.T) {
 			musicUsecase := usecase.NewMusicInteractor(f.repository)
 			tt.setup(tt.args, f)
 
-			gotErr := musicUsecase.Create(tt.args.ctx, tt.args.musicParse)
+			got, gotErr := musicUsecase.Create(tt.args.ctx, tt.args.musicParse)
 			if tt.wantErr == true {
 				assert.Error(t, gotErr)
+			} else {
+				assert.NoError(t, gotErr)
+				assert.NotNil(t, got)
 			}
 		})
 	}
@@ -551,7 +609,7 @@ func Test_Delete(t *testing.T) {
 				musicId: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
 			},
 			setup: func(a args, f field) {
-				f.repository.EXPECT().Delete(a.ctx, a.musicId).Return(nil)
+				f.repository.EXPECT().Delete(a.ctx, a.musicId, gomock.Any()).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -562,7 +620,7 @@ func Test_Delete(t *testing.T) {
 				musicId: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15"),
 			},
 			setup: func(a args, f field) {
-				f.repository.EXPECT().Delete(a.ctx, a.musicId).Return(fmt.Errorf("Error in repository Delete"))
+				f.repository.EXPECT().Delete(a.ctx, a.musicId, gomock.Any()).Return(fmt.Errorf("Error in repository Delete"))
 			},
 			wantErr: true,
 		},
@@ -583,3 +641,91 @@ func Test_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_musicInteractor_Merge(t *testing.T) {
+	type field struct {
+		repository *repository.MockMusicRepository
+	}
+
+	type args struct {
+		ctx     context.Context
+		musicId uuid.UUID
+		merge   *entity.MusicMerge
+	}
+	ctx := context.Background()
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	intoId := uuid.MustParse("3f1a2b2c-7f7e-4c1e-9a57-2b9fd9a0c111")
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, f field)
+		want    *entity.MusicMergeResult
+		wantErr error
+	}{
+		{
+			name: "Merge music",
+			args: args{
+				ctx:     ctx,
+				musicId: musicId,
+				merge:   &entity.MusicMerge{Into: intoId},
+			},
+			setup: func(a args, f field) {
+				f.repository.EXPECT().Merge(a.ctx, a.musicId, a.merge.Into, gomock.Any()).Return(&entity.MusicMergeResult{
+					MusicID: a.musicId, IntoID: a.merge.Into, Likes: 2, PlaylistEntries: 1, Plays: 7,
+				}, nil)
+			},
+			want: &entity.MusicMergeResult{MusicID: musicId, IntoID: intoId, Likes: 2, PlaylistEntries: 1, Plays: 7},
+		},
+		{
+			name: "Merge into itself",
+			args: args{
+				ctx:     ctx,
+				musicId: musicId,
+				merge:   &entity.MusicMerge{Into: musicId},
+			},
+			setup:   func(a args, f field) {},
+			wantErr: entity.ErrInvalidMerge,
+		},
+		{
+			name: "Merge without target",
+			args: args{
+				ctx:     ctx,
+				musicId: musicId,
+				merge:   &entity.MusicMerge{},
+			},
+			setup:   func(a args, f field) {},
+			wantErr: entity.ErrInvalidMerge,
+		},
+		{
+			name: "Music not found",
+			args: args{
+				ctx:     ctx,
+				musicId: musicId,
+				merge:   &entity.MusicMerge{Into: intoId},
+			},
+			setup: func(a args, f field) {
+				f.repository.EXPECT().Merge(a.ctx, a.musicId, a.merge.Into, gomock.Any()).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			f := field{
+				repository: repository.NewMockMusicRepository(cntr),
+			}
+			musicUsecase := usecase.NewMusicInteractor(f.repository)
+			tt.setup(tt.args, f)
+
+			got, gotErr := musicUsecase.Merge(tt.args.ctx, tt.args.musicId, tt.args.merge)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, gotErr, tt.wantErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_notificationInteractor_Deliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := &entity.NotificationDelivery{NotificationDB: entity.NotificationDB{ID: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")}}
+	second := &entity.NotificationDelivery{NotificationDB: entity.NotificationDB{ID: uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")}}
+	sendErr := errors.New("connection refused")
+
+	repo := repository.NewMockNotificationRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().CreateReleases(gomock.Any(), gomock.Any()).Return(int64(2), nil),
+		repo.EXPECT().GetUndelivered(gomock.Any(), 50).Return([]*entity.NotificationDelivery{first, second}, nil),
+	)
+	repo.EXPECT().Deliver(gomock.Any(), first).Return(sendErr)
+	repo.EXPECT().Deliver(gomock.Any(), second).Return(nil)
+	repo.EXPECT().MarkDelivered(gomock.Any(), first.ID, gomock.Any()).Return(nil)
+	repo.EXPECT().MarkDelivered(gomock.Any(), second.ID, gomock.Any()).Return(nil)
+
+	err := usecase.NewNotificationInteractor(repo, &entity.NotificationConfig{BatchSize: 50}).Deliver(context.Background())
+	assert.ErrorIs(t, err, sendErr)
+}
+
+func Test_notificationInteractor_GetByUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	items := []*entity.NotificationDB{
+		{ID: uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")},
+		{ID: uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")},
+	}
+
+	repo := repository.NewMockNotificationRepository(ctrl)
+	repo.EXPECT().GetByUser(gomock.Any(), userId, &entity.NotificationFilter{Limit: 1}).Return(items, nil)
+	repo.EXPECT().CountUnread(gomock.Any(), userId).Return(7, nil)
+
+	page, err := usecase.NewNotificationInteractor(repo, &entity.NotificationConfig{}).
+		GetByUser(context.Background(), userId, &entity.NotificationFilter{Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, items[:1], page.Items)
+	assert.Equal(t, 7, page.UnreadCount)
+	assert.NotEmpty(t, page.NextCursor)
+}
+
+func Test_notificationInteractor_SetSettings(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	invalidEmail := "not an email"
+	validEmail := " user@music.local "
+	trimmedEmail := "user@music.local"
+
+	tests := []struct {
+		name     string
+		settings *entity.NotificationSettings
+		setup    func(repo *repository.MockNotificationRepository)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			settings: &entity.NotificationSettings{Email: &validEmail, Preferences: []*entity.NotificationPreference{
+				{Type: entity.NotificationRelease, InApp: true, Email: true},
+			}},
+			setup: func(repo *repository.MockNotificationRepository) {
+				repo.EXPECT().SetSettings(gomock.Any(), userId, &entity.NotificationSettings{Email: &trimmedEmail, Preferences: []*entity.NotificationPreference{
+					{Type: entity.NotificationRelease, InApp: true, Email: true},
+				}}).Return(nil)
+				repo.EXPECT().GetSettings(gomock.Any(), userId).Return(&entity.NotificationSettings{}, nil)
+			},
+		},
+		{
+			name:     "error: invalid email",
+			settings: &entity.NotificationSettings{Email: &invalidEmail},
+			setup:    func(repo *repository.MockNotificationRepository) {},
+			wantErr:  entity.ErrInvalidNotificationSettings,
+		},
+		{
+			name: "error: unknown type",
+			settings: &entity.NotificationSettings{Preferences: []*entity.NotificationPreference{
+				{Type: "digest", InApp: true},
+			}},
+			setup:   func(repo *repository.MockNotificationRepository) {},
+			wantErr: entity.ErrInvalidNotificationSettings,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockNotificationRepository(ctrl)
+			tt.setup(repo)
+
+			_, err := usecase.NewNotificationInteractor(repo, &entity.NotificationConfig{}).SetSettings(context.Background(), userId, tt.settings)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
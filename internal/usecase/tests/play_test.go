@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_playInteractor_Record(t *testing.T) {
+	type field struct {
+		repository *repository.MockPlayRepository
+	}
+	type args struct {
+		ctx    context.Context
+		userId uuid.UUID
+		event  *entity.PlayEventCreate
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	clientEventId := uuid.MustParse("6cfc3a4d-28ae-4b13-9a11-d2c335ad8658")
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, f field)
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "success: event recorded",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				event: &entity.PlayEventCreate{
+					ClientEventID: clientEventId,
+					MusicID:       musicId,
+					EventType:     entity.PlayEventComplete,
+					Position:      180,
+					PlayedAt:      time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC),
+				},
+			},
+			setup: func(a args, f field) {
+				f.repository.EXPECT().GetKnownMusicIDs(a.ctx, []uuid.UUID{musicId}).Return([]uuid.UUID{musicId}, nil)
+				f.repository.EXPECT().Create(a.ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, event *entity.PlayEventDB) (bool, error) {
+						assert.Equal(t, a.userId, event.UserID)
+						assert.Equal(t, clientEventId, event.ClientEventID)
+						assert.Equal(t, entity.PlaySourceClient, event.Source)
+						assert.Equal(t, a.event.PlayedAt, event.PlayedAt)
+						return true, nil
+					})
+			},
+			want:    true,
+			wantErr: nil,
+		},
+		{
+			name: "error: unknown music",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				event: &entity.PlayEventCreate{
+					ClientEventID: clientEventId,
+					MusicID:       musicId,
+					EventType:     entity.PlayEventStart,
+				},
+			},
+			setup: func(a args, f field) {
+				f.repository.EXPECT().GetKnownMusicIDs(a.ctx, []uuid.UUID{musicId}).Return(nil, nil)
+			},
+			want:    false,
+			wantErr: entity.ErrUnknownPlayMusic,
+		},
+		{
+			name: "error: unknown event type",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				event: &entity.PlayEventCreate{
+					MusicID:   musicId,
+					EventType: "pause",
+				},
+			},
+			setup:   func(a args, f field) {},
+			want:    false,
+			wantErr: entity.ErrInvalidPlayEvent,
+		},
+		{
+			name: "error: event from the future",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				event: &entity.PlayEventCreate{
+					MusicID:   musicId,
+					EventType: entity.PlayEventStart,
+					PlayedAt:  time.Now().Add(time.Hour),
+				},
+			},
+			setup:   func(a args, f field) {},
+			want:    false,
+			wantErr: entity.ErrInvalidPlayEvent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			f := field{
+				repository: repository.NewMockPlayRepository(cntr),
+			}
+			playUsecase := usecase.NewPlayInteractor(f.repository)
+			tt.setup(tt.args, f)
+
+			got, err := playUsecase.Record(tt.args.ctx, tt.args.userId, tt.args.event)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_playInteractor_RecordBatch(t *testing.T) {
+	type field struct {
+		repository *repository.MockPlayRepository
+	}
+	type args struct {
+		ctx    context.Context
+		userId uuid.UUID
+		events []*entity.PlayEventCreate
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	unknownId := uuid.MustParse("0d5e7a8c-3f41-4b6e-9c2d-7a1b8e4f6c30")
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, f field)
+		want    *entity.PlayBatchResult
+		wantErr bool
+	}{
+		{
+			name: "success: duplicates counted",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				events: []*entity.PlayEventCreate{
+					{MusicID: musicId, EventType: entity.PlayEventStart},
+					{MusicID: musicId, EventType: entity.PlayEventProgress, Position: 30},
+					{MusicID: musicId, EventType: entity.PlayEventComplete, Position: 180},
+				},
+			},
+			setup: func(a args, f field) {
+				f.repository.EXPECT().GetKnownMusicIDs(a.ctx, []uuid.UUID{musicId}).Return([]uuid.UUID{musicId}, nil)
+				f.repository.EXPECT().CreateBatch(a.ctx, gomock.Len(3)).Return(2, nil)
+			},
+			want:    &entity.PlayBatchResult{Accepted: 2, Duplicates: 1},
+			wantErr: false,
+		},
+		{
+			name: "success: unknown music skipped and reported",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				events: []*entity.PlayEventCreate{
+					{MusicID: unknownId, EventType: entity.PlayEventStart},
+					{MusicID: musicId, EventType: entity.PlayEventStart},
+					{MusicID: unknownId, EventType: entity.PlayEventComplete, Position: 180},
+				},
+			},
+			setup: func(a args, f field) {
+				f.repository.EXPECT().GetKnownMusicIDs(a.ctx, []uuid.UUID{unknownId, musicId}).Return([]uuid.UUID{musicId}, nil)
+				f.repository.EXPECT().CreateBatch(a.ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, events []*entity.PlayEventDB) (int, error) {
+						if assert.Len(t, events, 1) {
+							assert.Equal(t, musicId, events[0].MusicID)
+						}
+						return 1, nil
+					})
+			},
+			want:    &entity.PlayBatchResult{Accepted: 1, UnknownMusicIDs: []uuid.UUID{unknownId}},
+			wantErr: false,
+		},
+		{
+			name: "success: only unknown music",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				events: []*entity.PlayEventCreate{
+					{MusicID: unknownId, EventType: entity.PlayEventStart},
+				},
+			},
+			setup: func(a args, f field) {
+				f.repository.EXPECT().GetKnownMusicIDs(a.ctx, []uuid.UUID{unknownId}).Return(nil, nil)
+			},
+			want:    &entity.PlayBatchResult{UnknownMusicIDs: []uuid.UUID{unknownId}},
+			wantErr: false,
+		},
+		{
+			name: "success: empty batch",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				events: nil,
+			},
+			setup:   func(a args, f field) {},
+			want:    &entity.PlayBatchResult{},
+			wantErr: false,
+		},
+		{
+			name: "error: invalid event rejects whole batch",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				events: []*entity.PlayEventCreate{
+					{MusicID: musicId, EventType: entity.PlayEventStart},
+					{MusicID: uuid.Nil, EventType: entity.PlayEventStart},
+				},
+			},
+			setup:   func(a args, f field) {},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error: repository failed",
+			args: args{
+				ctx:    context.Background(),
+				userId: userId,
+				events: []*entity.PlayEventCreate{
+					{MusicID: musicId, EventType: entity.PlayEventStart},
+				},
+			},
+			setup: func(a args, f field) {
+				f.repository.EXPECT().GetKnownMusicIDs(a.ctx, []uuid.UUID{musicId}).Return([]uuid.UUID{musicId}, nil)
+				f.repository.EXPECT().CreateBatch(a.ctx, gomock.Any()).Return(0, fmt.Errorf("can't create batch"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			f := field{
+				repository: repository.NewMockPlayRepository(cntr),
+			}
+			playUsecase := usecase.NewPlayInteractor(f.repository)
+			tt.setup(tt.args, f)
+
+			got, err := playUsecase.RecordBatch(tt.args.ctx, tt.args.userId, tt.args.events)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_playInteractor_RecordStream(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockPlayRepository(cntr)
+	playUsecase := usecase.NewPlayInteractor(repo)
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	var clientEventIds []uuid.UUID
+	repo.EXPECT().Create(ctx, gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, event *entity.PlayEventDB) (bool, error) {
+			assert.Equal(t, entity.PlaySourceStream, event.Source)
+			assert.Equal(t, musicId, event.MusicID)
+			clientEventIds = append(clientEventIds, event.ClientEventID)
+			return true, nil
+		})
+
+	assert.NoError(t, playUsecase.RecordStream(ctx, userId, musicId))
+	assert.NoError(t, playUsecase.RecordStream(ctx, userId, musicId))
+
+	// оба обращения попадают в одно окно дедупликации
+	if assert.Len(t, clientEventIds, 2) {
+		assert.Equal(t, clientEventIds[0], clientEventIds[1])
+	}
+}
+
+func Test_playInteractor_GetHistory(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockPlayRepository(cntr)
+	playUsecase := usecase.NewPlayInteractor(repo)
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	filter := &entity.PlayHistoryFilter{Limit: 10000}
+
+	repo.EXPECT().GetByUser(ctx, userId, filter).DoAndReturn(
+		func(_ context.Context, _ uuid.UUID, filter *entity.PlayHistoryFilter) ([]*entity.PlayEventDB, error) {
+			assert.Equal(t, entity.MaxPlayHistoryLimit, filter.Limit)
+			assert.False(t, filter.To.IsZero())
+			return nil, errors.New("can't get history")
+		})
+
+	_, err := playUsecase.GetHistory(ctx, userId, filter)
+	assert.Error(t, err)
+}
+
+func Test_playInteractor_DeleteHistoryEvent(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockPlayRepository(cntr)
+	playUsecase := usecase.NewPlayInteractor(repo)
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	eventId := uuid.MustParse("31313131-3131-4131-b131-313131313131")
+
+	repo.EXPECT().Delete(ctx, userId, eventId).Return(nil)
+
+	assert.NoError(t, playUsecase.DeleteHistoryEvent(ctx, userId, eventId))
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_playlistImportInteractor_Import(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	queen := "Queen"
+	byId := &entity.ImportCandidateDB{MusicID: musicId, Name: "Bohemian Rhapsody", Artist: &queen, Duration: 354}
+	pressure := &entity.ImportCandidateDB{MusicID: uuid.New(), Name: "Under Pressure", Artist: &queen, Duration: 248}
+	live := &entity.ImportCandidateDB{MusicID: uuid.New(), Name: "Love of My Life (Live)", Artist: &queen, Duration: 220}
+	studio := &entity.ImportCandidateDB{MusicID: uuid.New(), Name: "Love of My Life", Artist: &queen, Duration: 219}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockPlaylistImportRepository(ctrl)
+	repo.EXPECT().GetByIDs(gomock.Any(), []uuid.UUID{musicId}).Return([]*entity.ImportCandidateDB{byId}, nil)
+	repo.EXPECT().GetByTitles(gomock.Any(), []string{"under pressure", "bohemian rhapsody", "love of my life", "nothing"}).
+		Return([]*entity.ImportCandidateDB{byId, pressure, live, studio}, nil)
+	repo.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), []uuid.UUID{musicId, pressure.MusicID}).
+		DoAndReturn(func(_ context.Context, playlist *entity.PlaylistDB, _ []uuid.UUID) error {
+			assert.Equal(t, userId, playlist.UserID)
+			assert.Equal(t, "Road trip", playlist.Name)
+			return nil
+		})
+
+	data := "#EXTM3U\n" +
+		"#EXTINF:354,Queen - Bohemian Rhapsody\nhttp://music.local/music/download/ff578289-cdca-406e-9a57-f8c773f0cd15\n" +
+		"#EXTINF:250,Queen - Under Pressure\nunder_pressure.mp3\n" +
+		"#EXTINF:354,Queen - Bohemian Rhapsody (Remastered)\nrhapsody.mp3\n" +
+		"#EXTINF:220,Queen - Love Of My Life\nlove.mp3\n" +
+		"#EXTINF:100,Nobody - Nothing\nnothing.mp3\n"
+
+	report, err := usecase.NewPlaylistImportInteractor(repo).Import(context.Background(), userId,
+		&entity.PlaylistImport{Format: entity.ImportFormatM3U, FileName: "Road trip.m3u8"}, strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 3, report.Matched)
+	assert.Equal(t, 1, report.Duplicates)
+	assert.Len(t, report.Unresolved, 2)
+	assert.Equal(t, entity.ImportStatusAmbiguous, report.Unresolved[0].Status)
+	assert.Equal(t, 4, report.Unresolved[0].Entry.Line)
+	assert.Equal(t, entity.ImportStatusUnmatched, report.Unresolved[1].Status)
+}
+
+func Test_playlistImportInteractor_Import_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *entity.PlaylistImport
+		data    string
+		wantErr error
+	}{
+		{
+			name:    "empty file",
+			request: &entity.PlaylistImport{Format: entity.ImportFormatM3U, FileName: "empty.m3u"},
+			data:    "#EXTM3U\n",
+			wantErr: entity.ErrInvalidImport,
+		},
+		{
+			name:    "malformed file",
+			request: &entity.PlaylistImport{Format: entity.ImportFormatXSPF, FileName: "broken.xspf"},
+			data:    "<playlist>",
+			wantErr: entity.ErrInvalidImport,
+		},
+		{
+			name:    "name is too long",
+			request: &entity.PlaylistImport{Format: entity.ImportFormatM3U, Name: strings.Repeat("a", entity.MaxPlaylistNameLength+1)},
+			data:    "song.mp3\n",
+			wantErr: entity.ErrInvalidPlaylist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockPlaylistImportRepository(ctrl)
+
+			_, err := usecase.NewPlaylistImportInteractor(repo).Import(context.Background(), uuid.New(), tt.request, strings.NewReader(tt.data))
+			assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_playlistInteractor_Get(t *testing.T) {
+	ownerId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	viewerId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	playlistId := uuid.MustParse("6a1c3c8e-2f4b-4d3a-9f7e-0c2d8e5b4a77")
+
+	tests := []struct {
+		name     string
+		userId   uuid.UUID
+		isPublic bool
+		wantErr  error
+	}{
+		{
+			name:   "success: own private playlist",
+			userId: ownerId,
+		},
+		{
+			name:     "success: public playlist of another user",
+			userId:   viewerId,
+			isPublic: true,
+		},
+		{
+			name:    "error: private playlist of another user",
+			userId:  viewerId,
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockPlaylistRepository(ctrl)
+			repo.EXPECT().Get(gomock.Any(), playlistId).
+				Return(&entity.PlaylistDB{ID: playlistId, UserID: ownerId, IsPublic: tt.isPublic}, nil)
+
+			_, err := usecase.NewPlaylistInteractor(repo).Get(context.Background(), tt.userId, playlistId)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_playlistInteractor_Update(t *testing.T) {
+	ownerId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	viewerId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	playlistId := uuid.MustParse("6a1c3c8e-2f4b-4d3a-9f7e-0c2d8e5b4a77")
+
+	tests := []struct {
+		name     string
+		userId   uuid.UUID
+		playlist *entity.PlaylistCreate
+		setup    func(repo *repository.MockPlaylistRepository)
+		wantErr  error
+	}{
+		{
+			name:     "success",
+			userId:   ownerId,
+			playlist: &entity.PlaylistCreate{Name: " Road trip ", IsPublic: true},
+			setup: func(repo *repository.MockPlaylistRepository) {
+				repo.EXPECT().Get(gomock.Any(), playlistId).
+					Return(&entity.PlaylistDB{ID: playlistId, UserID: ownerId}, nil)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, playlist *entity.PlaylistDB) error {
+						assert.Equal(t, "Road trip", playlist.Name)
+						assert.True(t, playlist.IsPublic)
+						return nil
+					})
+			},
+		},
+		{
+			name:     "error: public playlist of another user",
+			userId:   viewerId,
+			playlist: &entity.PlaylistCreate{Name: "Mine now"},
+			setup: func(repo *repository.MockPlaylistRepository) {
+				repo.EXPECT().Get(gomock.Any(), playlistId).
+					Return(&entity.PlaylistDB{ID: playlistId, UserID: ownerId, IsPublic: true}, nil)
+			},
+			wantErr: entity.ErrPlaylistForbidden,
+		},
+		{
+			name:     "error: empty name",
+			userId:   ownerId,
+			playlist: &entity.PlaylistCreate{Name: "  "},
+			setup:    func(repo *repository.MockPlaylistRepository) {},
+			wantErr:  entity.ErrInvalidPlaylist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockPlaylistRepository(ctrl)
+			tt.setup(repo)
+
+			_, err := usecase.NewPlaylistInteractor(repo).Update(context.Background(), tt.userId, playlistId, tt.playlist)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
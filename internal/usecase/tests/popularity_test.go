@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_popularityInteractor_GetPopular(t *testing.T) {
+	type field struct {
+		repository *repository.MockPopularityRepository
+	}
+	type args struct {
+		ctx          context.Context
+		window       string
+		limit        int
+		offset       int
+		hideExplicit bool
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, f field)
+		want    []*entity.MusicPopularityDB
+		wantErr error
+	}{
+		{
+			name: "success: window and page passed through",
+			args: args{ctx: context.Background(), window: entity.PopularityWeek, limit: 10, offset: 20, hideExplicit: true},
+			setup: func(a args, f field) {
+				f.repository.EXPECT().Get(a.ctx, entity.PopularityWeek, 10, 20, true).
+					Return([]*entity.MusicPopularityDB{{Score: 3}}, nil)
+			},
+			want: []*entity.MusicPopularityDB{{Score: 3}},
+		},
+		{
+			name: "success: empty window means all time, limit clamped",
+			args: args{ctx: context.Background(), window: "", limit: 100000, offset: -1},
+			setup: func(a args, f field) {
+				f.repository.EXPECT().Get(a.ctx, entity.PopularityAll, entity.MaxChartLimit, 0, false).Return(nil, nil)
+			},
+			want: nil,
+		},
+		{
+			name:    "error: unknown window",
+			args:    args{ctx: context.Background(), window: "year"},
+			setup:   func(a args, f field) {},
+			wantErr: entity.ErrUnknownPopularityWindow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			f := field{
+				repository: repository.NewMockPopularityRepository(cntr),
+			}
+			popularityUsecase := usecase.NewPopularityInteractor(f.repository, &entity.PopularityConfig{})
+			tt.setup(tt.args, f)
+
+			got, err := popularityUsecase.GetPopular(tt.args.ctx, tt.args.window, tt.args.limit, tt.args.offset, tt.args.hideExplicit)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_popularityInteractor_GetTrending(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockPopularityRepository(cntr)
+	popularityUsecase := usecase.NewPopularityInteractor(repo, &entity.PopularityConfig{})
+
+	ctx := context.Background()
+	repo.EXPECT().Get(ctx, entity.PopularityTrending, entity.DefaultChartLimit, 0, false).Return(nil, fmt.Errorf("can't get trending"))
+
+	_, err := popularityUsecase.GetTrending(ctx, 0, 0, false)
+	assert.Error(t, err)
+}
+
+func Test_popularityInteractor_Refresh(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockPopularityRepository(cntr)
+	cfg := &entity.PopularityConfig{PlayWeight: 1, LikeWeight: 5, TrendingHalfLife: time.Hour}
+	popularityUsecase := usecase.NewPopularityInteractor(repo, cfg)
+
+	ctx := context.Background()
+	repo.EXPECT().Refresh(ctx, cfg, gomock.Any()).Return(nil)
+
+	assert.NoError(t, popularityUsecase.Refresh(ctx))
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ratingInteractor_Set(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		rating *entity.RatingCreate
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	summary := &entity.RatingSummary{MusicID: musicId, Rating: 3, Average: 3.5, Count: 2}
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, r *repository.MockRatingRepository)
+		want    *entity.RatingSummary
+		wantErr error
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), rating: &entity.RatingCreate{Rating: 3}},
+			setup: func(a args, r *repository.MockRatingRepository) {
+				r.EXPECT().Set(a.ctx, userId, musicId, 3, gomock.Any()).Return(summary, nil)
+			},
+			want: summary,
+		},
+		{
+			name:    "error: rating above scale",
+			args:    args{ctx: context.Background(), rating: &entity.RatingCreate{Rating: 6}},
+			setup:   func(a args, r *repository.MockRatingRepository) {},
+			wantErr: entity.ErrInvalidRating,
+		},
+		{
+			name:    "error: rating below scale",
+			args:    args{ctx: context.Background(), rating: &entity.RatingCreate{Rating: 0}},
+			setup:   func(a args, r *repository.MockRatingRepository) {},
+			wantErr: entity.ErrInvalidRating,
+		},
+		{
+			name: "error: music not found",
+			args: args{ctx: context.Background(), rating: &entity.RatingCreate{Rating: 5}},
+			setup: func(a args, r *repository.MockRatingRepository) {
+				r.EXPECT().Set(a.ctx, userId, musicId, 5, gomock.Any()).Return(nil, fmt.Errorf("/db/rating.Set: %w", sql.ErrNoRows))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			repo := repository.NewMockRatingRepository(cntr)
+			ratingUsecase := usecase.NewRatingInteractor(repo)
+			tt.setup(tt.args, repo)
+
+			got, err := ratingUsecase.Set(tt.args.ctx, userId, musicId, tt.args.rating)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_ratingInteractor_Clear(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockRatingRepository(cntr)
+	ratingUsecase := usecase.NewRatingInteractor(repo)
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	summary := &entity.RatingSummary{MusicID: musicId, Average: 4, Count: 1}
+	repo.EXPECT().Clear(ctx, userId, musicId).Return(summary, nil)
+
+	got, err := ratingUsecase.Clear(ctx, userId, musicId)
+	if assert.NoError(t, err) {
+		assert.Equal(t, summary, got)
+	}
+}
+
+func Test_ratingInteractor_GetLibrary(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		sort string
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, r *repository.MockRatingRepository)
+		wantErr error
+	}{
+		{
+			name: "success: default sort is recent",
+			args: args{ctx: context.Background()},
+			setup: func(a args, r *repository.MockRatingRepository) {
+				r.EXPECT().GetLibrary(a.ctx, userId, entity.LibrarySortRecent).Return(nil, nil)
+			},
+		},
+		{
+			name: "success: sort by rating",
+			args: args{ctx: context.Background(), sort: entity.LibrarySortRating},
+			setup: func(a args, r *repository.MockRatingRepository) {
+				r.EXPECT().GetLibrary(a.ctx, userId, entity.LibrarySortRating).Return(nil, nil)
+			},
+		},
+		{
+			name:    "error: unknown sort",
+			args:    args{ctx: context.Background(), sort: "plays"},
+			setup:   func(a args, r *repository.MockRatingRepository) {},
+			wantErr: entity.ErrUnknownLibrarySort,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			repo := repository.NewMockRatingRepository(cntr)
+			ratingUsecase := usecase.NewRatingInteractor(repo)
+			tt.setup(tt.args, repo)
+
+			_, err := ratingUsecase.GetLibrary(tt.args.ctx, userId, tt.args.sort)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_recommendationInteractor_GetForUser(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		limit  int
+		offset int
+	}
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, r *repository.MockRecommendationRepository)
+		wantErr bool
+	}{
+		{
+			name: "success: default page",
+			args: args{ctx: context.Background()},
+			setup: func(a args, r *repository.MockRecommendationRepository) {
+				r.EXPECT().GetForUser(a.ctx, userId, entity.DefaultRecommendationLimit, 0).Return(nil, nil)
+			},
+		},
+		{
+			name: "success: limit clamped",
+			args: args{ctx: context.Background(), limit: 1000, offset: 10},
+			setup: func(a args, r *repository.MockRecommendationRepository) {
+				r.EXPECT().GetForUser(a.ctx, userId, entity.MaxRecommendationLimit, 10).Return(nil, nil)
+			},
+		},
+		{
+			name: "error: repository",
+			args: args{ctx: context.Background(), limit: 5},
+			setup: func(a args, r *repository.MockRecommendationRepository) {
+				r.EXPECT().GetForUser(a.ctx, userId, 5, 0).Return(nil, fmt.Errorf("can't get recommendations"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cntr := gomock.NewController(t)
+			repo := repository.NewMockRecommendationRepository(cntr)
+			recommendationUsecase := usecase.NewRecommendationInteractor(repo, &entity.SimilarityConfig{})
+			tt.setup(tt.args, repo)
+
+			_, err := recommendationUsecase.GetForUser(tt.args.ctx, userId, tt.args.limit, tt.args.offset)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_recommendationInteractor_GetSimilar(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockRecommendationRepository(cntr)
+	recommendationUsecase := usecase.NewRecommendationInteractor(repo, &entity.SimilarityConfig{})
+
+	ctx := context.Background()
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	musics := []*entity.SimilarMusicDB{{CoLikes: 3, Score: 0.5}}
+	repo.EXPECT().GetSimilar(ctx, userId, musicId, 10, 0).Return(musics, nil)
+
+	got, err := recommendationUsecase.GetSimilar(ctx, userId, musicId, 10, -5)
+	if assert.NoError(t, err) {
+		assert.Equal(t, musics, got)
+	}
+}
+
+func Test_recommendationInteractor_Refresh(t *testing.T) {
+	cntr := gomock.NewController(t)
+	repo := repository.NewMockRecommendationRepository(cntr)
+	cfg := &entity.SimilarityConfig{Neighbors: 50, MinCoLikes: 2}
+	recommendationUsecase := usecase.NewRecommendationInteractor(repo, cfg)
+
+	ctx := context.Background()
+	repo.EXPECT().Refresh(ctx, cfg, gomock.Any()).Return(fmt.Errorf("can't refresh"))
+
+	assert.Error(t, recommendationUsecase.Refresh(ctx))
+}
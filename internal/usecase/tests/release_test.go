@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_releaseInteractor_SetSchedule(t *testing.T) {
+	musicId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	publishAt := time.Date(2023, time.March, 24, 12, 0, 0, 0, time.UTC)
+	takedownAt := publishAt.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		schedule *entity.MusicSchedule
+		setup    func(repo *repository.MockReleaseRepository)
+		wantErr  error
+	}{
+		{
+			name:     "success",
+			schedule: &entity.MusicSchedule{PublishAt: publishAt},
+			setup: func(repo *repository.MockReleaseRepository) {
+				repo.EXPECT().SetSchedule(gomock.Any(), musicId, &entity.MusicSchedule{PublishAt: publishAt}, gomock.Any()).
+					Return(&entity.MusicDB{Id: musicId, PublishAt: publishAt}, nil)
+			},
+		},
+		{
+			name:     "error: takedown before publish",
+			schedule: &entity.MusicSchedule{PublishAt: publishAt, TakedownAt: &takedownAt},
+			setup:    func(repo *repository.MockReleaseRepository) {},
+			wantErr:  entity.ErrInvalidMusicSchedule,
+		},
+		{
+			name:     "error: publish_at missing",
+			schedule: &entity.MusicSchedule{},
+			setup:    func(repo *repository.MockReleaseRepository) {},
+			wantErr:  entity.ErrInvalidMusicSchedule,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockReleaseRepository(ctrl)
+			tt.setup(repo)
+
+			_, err := usecase.NewReleaseInteractor(repo).SetSchedule(context.Background(), musicId, tt.schedule)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_releaseInteractor_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockReleaseRepository(ctrl)
+	repo.EXPECT().PublishDue(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, now time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now(), now, time.Minute)
+			return 2, nil
+		})
+
+	err := usecase.NewReleaseInteractor(repo).Publish(context.Background())
+	assert.NoError(t, err)
+}
+
+func Test_releaseInteractor_GetScheduled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockReleaseRepository(ctrl)
+	repo.EXPECT().GetScheduled(gomock.Any(), entity.DefaultScheduledMusicLimit, 0).Return(nil, nil)
+
+	_, err := usecase.NewReleaseInteractor(repo).GetScheduled(context.Background(), 0, -5)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_shareInteractor_CreateForPlaylist(t *testing.T) {
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	otherId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	playlistId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	past := time.Now().Add(-time.Hour)
+	zero := 0
+
+	tests := []struct {
+		name    string
+		share   *entity.ShareLinkCreate
+		setup   func(repo *repository.MockShareRepository)
+		wantErr error
+	}{
+		{
+			name:  "success",
+			share: &entity.ShareLinkCreate{},
+			setup: func(repo *repository.MockShareRepository) {
+				repo.EXPECT().GetPlaylist(gomock.Any(), playlistId).Return(&entity.PlaylistDB{ID: playlistId, UserID: userId}, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, link *entity.ShareLinkDB) error {
+					assert.Equal(t, &playlistId, link.PlaylistID)
+					assert.Nil(t, link.MusicID)
+					assert.Len(t, link.TokenHash, 64)
+					return nil
+				})
+			},
+		},
+		{
+			name:  "error: public playlist of another user",
+			share: &entity.ShareLinkCreate{},
+			setup: func(repo *repository.MockShareRepository) {
+				repo.EXPECT().GetPlaylist(gomock.Any(), playlistId).Return(&entity.PlaylistDB{ID: playlistId, UserID: otherId, IsPublic: true}, nil)
+			},
+			wantErr: entity.ErrPlaylistForbidden,
+		},
+		{
+			name:  "error: private playlist of another user",
+			share: &entity.ShareLinkCreate{},
+			setup: func(repo *repository.MockShareRepository) {
+				repo.EXPECT().GetPlaylist(gomock.Any(), playlistId).Return(&entity.PlaylistDB{ID: playlistId, UserID: otherId}, nil)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name:    "error: expiry in the past",
+			share:   &entity.ShareLinkCreate{ExpiresAt: &past},
+			setup:   func(repo *repository.MockShareRepository) {},
+			wantErr: entity.ErrInvalidShareLink,
+		},
+		{
+			name:    "error: zero play limit",
+			share:   &entity.ShareLinkCreate{MaxPlays: &zero},
+			setup:   func(repo *repository.MockShareRepository) {},
+			wantErr: entity.ErrInvalidShareLink,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockShareRepository(ctrl)
+			tt.setup(repo)
+
+			created, err := usecase.NewShareInteractor(repo).CreateForPlaylist(context.Background(), userId, playlistId, tt.share)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, entity.HashShareToken(created.Token), created.Link.TokenHash)
+		})
+	}
+}
+
+func Test_shareInteractor_Resolve(t *testing.T) {
+	token := "token"
+	past := time.Now().Add(-time.Hour)
+	maxPlays := 2
+
+	tests := []struct {
+		name    string
+		link    *entity.ShareLinkDB
+		wantErr error
+	}{
+		{
+			name: "success",
+			link: &entity.ShareLinkDB{MaxPlays: &maxPlays, Plays: 1},
+		},
+		{
+			name:    "error: revoked",
+			link:    &entity.ShareLinkDB{RevokedAt: &past},
+			wantErr: entity.ErrShareLinkUnavailable,
+		},
+		{
+			name:    "error: expired",
+			link:    &entity.ShareLinkDB{ExpiresAt: &past},
+			wantErr: entity.ErrShareLinkUnavailable,
+		},
+		{
+			name:    "error: play limit reached",
+			link:    &entity.ShareLinkDB{MaxPlays: &maxPlays, Plays: 2},
+			wantErr: entity.ErrShareLinkUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockShareRepository(ctrl)
+			repo.EXPECT().GetByTokenHash(gomock.Any(), entity.HashShareToken(token)).Return(tt.link, nil)
+
+			link, err := usecase.NewShareInteractor(repo).Resolve(context.Background(), token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.link, link)
+		})
+	}
+}
+
+func Test_shareInteractor_Stream(t *testing.T) {
+	linkId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	playlistId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	musicId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	otherMusicId := uuid.MustParse("8b1c5c1e-2f0a-4d61-9c55-4b1e3f1a2d7e")
+	publishedAt := time.Now().Add(-time.Hour)
+	music := &entity.MusicDB{Id: musicId, PublishedAt: &publishedAt}
+	musicLink := &entity.ShareLinkDB{ID: linkId, MusicID: &musicId}
+	playlistLink := &entity.ShareLinkDB{ID: linkId, PlaylistID: &playlistId}
+
+	tests := []struct {
+		name    string
+		link    *entity.ShareLinkDB
+		musicId *uuid.UUID
+		setup   func(repo *repository.MockShareRepository)
+		wantErr error
+	}{
+		{
+			name: "success: music link",
+			link: musicLink,
+			setup: func(repo *repository.MockShareRepository) {
+				repo.EXPECT().GetMusic(gomock.Any(), musicId).Return(music, nil)
+				repo.EXPECT().ConsumePlay(gomock.Any(), linkId, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "success: playlist track",
+			link:    playlistLink,
+			musicId: &musicId,
+			setup: func(repo *repository.MockShareRepository) {
+				repo.EXPECT().GetPlaylistTracks(gomock.Any(), playlistId).Return([]*entity.MusicDB{music}, nil)
+				repo.EXPECT().ConsumePlay(gomock.Any(), linkId, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "error: track is not in playlist",
+			link:    playlistLink,
+			musicId: &otherMusicId,
+			setup: func(repo *repository.MockShareRepository) {
+				repo.EXPECT().GetPlaylistTracks(gomock.Any(), playlistId).Return([]*entity.MusicDB{music}, nil)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name:    "error: other track by music link",
+			link:    musicLink,
+			musicId: &otherMusicId,
+			setup:   func(repo *repository.MockShareRepository) {},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "error: unpublished track",
+			link: musicLink,
+			setup: func(repo *repository.MockShareRepository) {
+				repo.EXPECT().GetMusic(gomock.Any(), musicId).Return(&entity.MusicDB{Id: musicId}, nil)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "error: limit reached concurrently",
+			link: musicLink,
+			setup: func(repo *repository.MockShareRepository) {
+				repo.EXPECT().GetMusic(gomock.Any(), musicId).Return(music, nil)
+				repo.EXPECT().ConsumePlay(gomock.Any(), linkId, gomock.Any()).Return(entity.ErrShareLinkUnavailable)
+			},
+			wantErr: entity.ErrShareLinkUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockShareRepository(ctrl)
+			tt.setup(repo)
+
+			got, err := usecase.NewShareInteractor(repo).Stream(context.Background(), tt.link, tt.musicId)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, music, got)
+		})
+	}
+}
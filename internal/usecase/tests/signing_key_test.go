@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var testKeysConfig = &entity.KeysConfig{
+	Algorithm:        entity.SigningAlgorithmEdDSA,
+	RotationInterval: 30 * 24 * time.Hour,
+	Overlap:          24 * time.Hour,
+	CheckInterval:    time.Hour,
+	CacheTTL:         time.Minute,
+	LegacySecret:     "legacy-secret",
+	LegacyCutoff:     time.Now().Add(time.Hour),
+}
+
+// testSigningKey возвращает ключ algorithm, который начал подписывать токены activatesAt
+func testSigningKey(t *testing.T, algorithm string, activatesAt time.Time) *entity.SigningKey {
+	row, err := entity.NewSigningKey(algorithm, activatesAt, activatesAt, testKeysConfig)
+	if err != nil {
+		t.Fatalf("entity.NewSigningKey() error = %v", err)
+	}
+	key, err := row.Parse()
+	if err != nil {
+		t.Fatalf("SigningKeyDB.Parse() error = %v", err)
+	}
+	return key
+}
+
+// testSignToken подписывает access-токен ключом key, указывая kid в заголовке
+func testSignToken(t *testing.T, key *entity.SigningKey, userId uuid.UUID, sessionId uuid.UUID, ttl time.Duration) string {
+	token := jwt.NewWithClaims(key.Method, entity.NewAccessClaims(userId, sessionId, time.Now(), ttl))
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString(key.Private)
+	if err != nil {
+		t.Fatalf("jwt.SignedString() error = %v", err)
+	}
+	return signed
+}
+
+func Test_signingKeyInteractor_ParseAccessToken(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	sessionId := uuid.MustParse("0b7e3c1a-4a1f-4d5e-9c55-6f2f8c3e1d20")
+	rsaKey := testSigningKey(t, entity.SigningAlgorithmRS256, time.Now().Add(-time.Hour))
+	edKey := testSigningKey(t, entity.SigningAlgorithmEdDSA, time.Now().Add(-time.Hour))
+
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, entity.NewAccessClaims(userId, uuid.Nil, time.Now(), time.Minute)).
+		SignedString([]byte("legacy-secret"))
+	assert.NoError(t, err)
+
+	// Токен с kid ключа RS256, но подписанный ключом EdDSA
+	forged := jwt.NewWithClaims(edKey.Method, entity.NewAccessClaims(userId, sessionId, time.Now(), time.Minute))
+	forged.Header["kid"] = rsaKey.ID
+	forgedString, err := forged.SignedString(edKey.Private)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		token         string
+		setup         func(repo *repository.MockSigningKeyRepository)
+		wantSessionId uuid.UUID
+		wantErr       error
+	}{
+		{
+			name:  "success RS256",
+			token: testSignToken(t, rsaKey, userId, sessionId, time.Minute),
+			setup: func(repo *repository.MockSigningKeyRepository) {
+				repo.EXPECT().GetVerificationKey(gomock.Any(), rsaKey.ID, gomock.Any()).Return(rsaKey, nil)
+			},
+			wantSessionId: sessionId,
+		},
+		{
+			name:  "success EdDSA",
+			token: testSignToken(t, edKey, userId, sessionId, time.Minute),
+			setup: func(repo *repository.MockSigningKeyRepository) {
+				repo.EXPECT().GetVerificationKey(gomock.Any(), edKey.ID, gomock.Any()).Return(edKey, nil)
+			},
+			wantSessionId: sessionId,
+		},
+		{
+			name:          "success legacy HS256 token without kid",
+			token:         legacy,
+			setup:         func(repo *repository.MockSigningKeyRepository) {},
+			wantSessionId: uuid.Nil,
+		},
+		{
+			name:  "error: unknown kid",
+			token: testSignToken(t, edKey, userId, sessionId, time.Minute),
+			setup: func(repo *repository.MockSigningKeyRepository) {
+				repo.EXPECT().GetVerificationKey(gomock.Any(), edKey.ID, gomock.Any()).
+					Return(nil, fmt.Errorf("/repository/signing_key.GetVerificationKey: %w", entity.ErrUnknownSigningKey))
+			},
+			wantErr: entity.ErrInvalidToken,
+		},
+		{
+			name:  "error: algorithm doesn't match key",
+			token: forgedString,
+			setup: func(repo *repository.MockSigningKeyRepository) {
+				repo.EXPECT().GetVerificationKey(gomock.Any(), rsaKey.ID, gomock.Any()).Return(rsaKey, nil)
+			},
+			wantErr: entity.ErrInvalidToken,
+		},
+		{
+			name:  "error: expired token",
+			token: testSignToken(t, edKey, userId, sessionId, -time.Minute),
+			setup: func(repo *repository.MockSigningKeyRepository) {
+				repo.EXPECT().GetVerificationKey(gomock.Any(), edKey.ID, gomock.Any()).Return(edKey, nil)
+			},
+			wantErr: entity.ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockSigningKeyRepository(ctrl)
+			tt.setup(repo)
+
+			gotUserId, gotSessionId, err := usecase.NewSigningKeyInteractor(repo, testKeysConfig).ParseAccessToken(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, userId, gotUserId)
+			assert.Equal(t, tt.wantSessionId, gotSessionId)
+		})
+	}
+}
+
+func Test_signingKeyInteractor_ParseAccessToken_legacyDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, entity.NewAccessClaims(userId, uuid.Nil, time.Now(), time.Minute)).
+		SignedString([]byte("legacy-secret"))
+	assert.NoError(t, err)
+
+	cfg := *testKeysConfig
+	cfg.LegacySecret = ""
+
+	_, _, err = usecase.NewSigningKeyInteractor(repository.NewMockSigningKeyRepository(ctrl), &cfg).ParseAccessToken(context.Background(), legacy)
+	assert.ErrorIs(t, err, entity.ErrInvalidToken)
+}
+
+func Test_signingKeyInteractor_ParseAccessToken_legacyRejected(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	now := time.Now()
+
+	// Токен, подписанный api-key после перехода на ключи подписи
+	issuedAfterCutoff, err := jwt.NewWithClaims(jwt.SigningMethodHS256, entity.NewAccessClaims(userId, uuid.Nil, now.Add(-time.Minute), time.Hour)).
+		SignedString([]byte("legacy-secret"))
+	assert.NoError(t, err)
+
+	// Токен со сроком действия больше, чем выдавались токены HS256
+	longLived, err := jwt.NewWithClaims(jwt.SigningMethodHS256, entity.NewAccessClaims(userId, uuid.Nil, now.Add(-2*time.Hour), 365*24*time.Hour)).
+		SignedString([]byte("legacy-secret"))
+	assert.NoError(t, err)
+
+	// Токен без iat
+	withoutIssuedAt, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &entity.AccessClaims{
+		StandardClaims: jwt.StandardClaims{Id: userId.String(), ExpiresAt: now.Add(time.Hour).Unix()},
+	}).SignedString([]byte("legacy-secret"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		token  string
+		cutoff time.Time
+	}{
+		{name: "issued after cutoff", token: issuedAfterCutoff, cutoff: now.Add(-time.Hour)},
+		{name: "lifetime longer than legacy ttl", token: longLived, cutoff: now.Add(time.Hour)},
+		{name: "no issued at", token: withoutIssuedAt, cutoff: now.Add(time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cfg := *testKeysConfig
+			cfg.LegacyCutoff = tt.cutoff
+
+			_, _, err := usecase.NewSigningKeyInteractor(repository.NewMockSigningKeyRepository(ctrl), &cfg).ParseAccessToken(context.Background(), tt.token)
+			assert.ErrorIs(t, err, entity.ErrInvalidToken)
+		})
+	}
+}
+
+func Test_signingKeyInteractor_ParseAccessToken_lookupError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := testSigningKey(t, entity.SigningAlgorithmEdDSA, time.Now().Add(-time.Hour))
+	dbErr := errors.New("connection refused")
+
+	repo := repository.NewMockSigningKeyRepository(ctrl)
+	repo.EXPECT().GetVerificationKey(gomock.Any(), key.ID, gomock.Any()).Return(nil, fmt.Errorf("/repository/signing_key.GetVerificationKey: %w", dbErr))
+
+	token := testSignToken(t, key, uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"), uuid.Nil, time.Minute)
+	_, _, err := usecase.NewSigningKeyInteractor(repo, testKeysConfig).ParseAccessToken(context.Background(), token)
+	// Ошибка бд не должна выглядеть как недействительный токен, иначе клиент получит 401 вместо 500
+	assert.ErrorIs(t, err, dbErr)
+	assert.False(t, errors.Is(err, entity.ErrInvalidToken))
+}
+
+func Test_signingKeyInteractor_Rotate(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		keys  func(t *testing.T) []*entity.SigningKey
+		check func(t *testing.T, keys []*entity.SigningKey, key *entity.SigningKeyDB, after time.Time)
+	}{
+		{
+			name: "no keys: key activates immediately",
+			keys: func(t *testing.T) []*entity.SigningKey { return nil },
+			check: func(t *testing.T, _ []*entity.SigningKey, key *entity.SigningKeyDB, after time.Time) {
+				assert.True(t, after.IsZero())
+				assert.WithinDuration(t, now, key.ActivatesAt, time.Minute)
+			},
+		},
+		{
+			name: "current key near retirement: successor activates when it retires",
+			keys: func(t *testing.T) []*entity.SigningKey {
+				return []*entity.SigningKey{testSigningKey(t, entity.SigningAlgorithmRS256, now.Add(-testKeysConfig.RotationInterval+time.Hour))}
+			},
+			check: func(t *testing.T, keys []*entity.SigningKey, key *entity.SigningKeyDB, after time.Time) {
+				assert.Equal(t, keys[0].ActivatesAt, after)
+				assert.Equal(t, keys[0].RetiresAt, key.ActivatesAt)
+				assert.Equal(t, key.ActivatesAt.Add(testKeysConfig.RotationInterval), key.RetiresAt)
+				assert.Equal(t, key.RetiresAt.Add(testKeysConfig.Overlap), key.ExpiresAt)
+				assert.Equal(t, testKeysConfig.Algorithm, key.Algorithm)
+			},
+		},
+		{
+			name: "current key retired: successor activates immediately",
+			keys: func(t *testing.T) []*entity.SigningKey {
+				return []*entity.SigningKey{testSigningKey(t, entity.SigningAlgorithmEdDSA, now.Add(-testKeysConfig.RotationInterval-time.Hour))}
+			},
+			check: func(t *testing.T, keys []*entity.SigningKey, key *entity.SigningKeyDB, after time.Time) {
+				assert.Equal(t, keys[0].ActivatesAt, after)
+				assert.WithinDuration(t, now, key.ActivatesAt, time.Minute)
+			},
+		},
+		{
+			name: "fresh key: nothing to do",
+			keys: func(t *testing.T) []*entity.SigningKey {
+				return []*entity.SigningKey{testSigningKey(t, entity.SigningAlgorithmEdDSA, now.Add(-time.Hour))}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			keys := tt.keys(t)
+			repo := repository.NewMockSigningKeyRepository(ctrl)
+			repo.EXPECT().DeleteExpired(gomock.Any(), gomock.Any()).Return(nil)
+			repo.EXPECT().GetKeys(gomock.Any(), gomock.Any()).Return(keys, nil)
+			if tt.check != nil {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, key *entity.SigningKeyDB, after time.Time, _ time.Time) (bool, error) {
+						tt.check(t, keys, key, after)
+						return true, nil
+					})
+			}
+
+			err := usecase.NewSigningKeyInteractor(repo, testKeysConfig).Rotate(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_smartPlaylistInteractor_Create(t *testing.T) {
+	userId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+
+	condition := func(field string, operator string, value interface{}) *entity.SmartRule {
+		return &entity.SmartRule{Field: field, Operator: operator, Value: value}
+	}
+	group := func(name string, rules ...*entity.SmartRule) *entity.SmartRule {
+		return &entity.SmartRule{Group: name, Rules: rules}
+	}
+	nested := condition(entity.SmartFieldLiked, entity.SmartOpEq, true)
+	for i := 0; i < entity.MaxSmartRuleDepth; i++ {
+		nested = group(entity.SmartGroupAnd, nested)
+	}
+
+	tests := []struct {
+		name    string
+		rules   *entity.SmartRule
+		sort    string
+		limit   int
+		wantErr bool
+	}{
+		{
+			name: "success: released this year, under 4 minutes, not liked",
+			rules: group(entity.SmartGroupAnd,
+				condition(entity.SmartFieldReleaseDate, entity.SmartOpInCurrentYear, nil),
+				condition(entity.SmartFieldDuration, entity.SmartOpLt, float64(240)),
+				condition(entity.SmartFieldLiked, entity.SmartOpEq, false),
+				group(entity.SmartGroupOr,
+					condition(entity.SmartFieldArtist, entity.SmartOpContains, "queen"),
+					condition(entity.SmartFieldLastPlayed, entity.SmartOpInLastDays, float64(30)),
+				),
+			),
+		},
+		{
+			name:  "success: single condition with sort",
+			rules: condition(entity.SmartFieldPublishedAt, entity.SmartOpGte, "2024-01-01"),
+			sort:  entity.SmartFieldRandom,
+		},
+		{name: "error: no rules", wantErr: true},
+		{name: "error: empty group", rules: group(entity.SmartGroupAnd), wantErr: true},
+		{name: "error: unknown group", rules: group("xor", nested), wantErr: true},
+		{name: "error: too deep", rules: group(entity.SmartGroupOr, nested), wantErr: true},
+		{name: "error: unknown field", rules: condition("genre", entity.SmartOpEq, "rock"), wantErr: true},
+		{name: "error: operator not allowed for field", rules: condition(entity.SmartFieldLiked, entity.SmartOpGt, true), wantErr: true},
+		{name: "error: number expected", rules: condition(entity.SmartFieldDuration, entity.SmartOpLt, "240"), wantErr: true},
+		{name: "error: bad date", rules: condition(entity.SmartFieldReleaseDate, entity.SmartOpGt, "01.01.2024"), wantErr: true},
+		{name: "error: fractional days", rules: condition(entity.SmartFieldLastPlayed, entity.SmartOpInLastDays, 1.5), wantErr: true},
+		{name: "error: value for in_current_year", rules: condition(entity.SmartFieldPublishedAt, entity.SmartOpInCurrentYear, "2024"), wantErr: true},
+		{name: "error: long value", rules: condition(entity.SmartFieldName, entity.SmartOpContains, strings.Repeat("a", entity.MaxSmartRuleValue+1)), wantErr: true},
+		{
+			name:    "error: unknown sort",
+			rules:   condition(entity.SmartFieldLiked, entity.SmartOpEq, true),
+			sort:    entity.SmartFieldLiked,
+			wantErr: true,
+		},
+		{
+			name:    "error: limit too large",
+			rules:   condition(entity.SmartFieldLiked, entity.SmartOpEq, true),
+			limit:   entity.MaxSmartPlaylistLimit + 1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockSmartPlaylistRepository(ctrl)
+			if !tt.wantErr {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			playlist := &entity.SmartPlaylistCreate{Name: "Fresh", Rules: tt.rules, Sort: tt.sort, Limit: tt.limit}
+			got, err := usecase.NewSmartPlaylistInteractor(repo).Create(context.Background(), userId, playlist)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entity.ErrInvalidSmartPlaylist)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, userId, got.UserID)
+			assert.NotEmpty(t, got.Sort)
+			assert.Equal(t, entity.SmartOrderDesc, got.Order)
+			assert.Equal(t, entity.DefaultSmartPlaylistLimit, got.Limit)
+		})
+	}
+}
+
+func Test_smartPlaylistInteractor_GetTracks(t *testing.T) {
+	ownerId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	viewerId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	playlistId := uuid.MustParse("6a1c3c8e-2f4b-4d3a-9f7e-0c2d8e5b4a77")
+
+	tests := []struct {
+		name     string
+		isPublic bool
+		wantErr  error
+	}{
+		{
+			name:     "success: public playlist is evaluated for the viewer",
+			isPublic: true,
+		},
+		{
+			name:    "error: private playlist of another user",
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			playlist := &entity.SmartPlaylistDB{ID: playlistId, UserID: ownerId, IsPublic: tt.isPublic}
+			repo := repository.NewMockSmartPlaylistRepository(ctrl)
+			repo.EXPECT().Get(gomock.Any(), playlistId).Return(playlist, nil)
+			if tt.wantErr == nil {
+				repo.EXPECT().GetTracks(gomock.Any(), playlist, viewerId, gomock.Any()).Return([]*entity.MusicDB{{Name: "Track"}}, nil)
+			}
+
+			got, err := usecase.NewSmartPlaylistInteractor(repo).GetTracks(context.Background(), viewerId, playlistId)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+		})
+	}
+}
+
+func Test_smartPlaylistInteractor_Update(t *testing.T) {
+	ownerId := uuid.MustParse("ff578289-cdca-406e-9a57-f8c773f0cd15")
+	viewerId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	playlistId := uuid.MustParse("6a1c3c8e-2f4b-4d3a-9f7e-0c2d8e5b4a77")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockSmartPlaylistRepository(ctrl)
+	repo.EXPECT().Get(gomock.Any(), playlistId).Return(&entity.SmartPlaylistDB{ID: playlistId, UserID: ownerId, IsPublic: true}, nil)
+
+	playlist := &entity.SmartPlaylistCreate{
+		Name:  "Mine now",
+		Rules: &entity.SmartRule{Field: entity.SmartFieldLiked, Operator: entity.SmartOpEq, Value: true},
+	}
+	_, err := usecase.NewSmartPlaylistInteractor(repo).Update(context.Background(), viewerId, playlistId, playlist)
+	assert.ErrorIs(t, err, entity.ErrPlaylistForbidden)
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_statsInteractor_GetStats(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	from := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		filter  *entity.StatsFilter
+		setup   func(repo *repository.MockStatsRepository)
+		wantErr error
+	}{
+		{
+			name:   "success: default top size",
+			filter: &entity.StatsFilter{From: from, To: to},
+			setup: func(repo *repository.MockStatsRepository) {
+				repo.EXPECT().GetStats(gomock.Any(), userId, &entity.StatsFilter{From: from, To: to, Limit: 10}).Return(&entity.ListeningStats{}, nil)
+			},
+		},
+		{
+			name:    "error: from after to",
+			filter:  &entity.StatsFilter{From: to, To: from},
+			setup:   func(repo *repository.MockStatsRepository) {},
+			wantErr: entity.ErrInvalidStatsPeriod,
+		},
+		{
+			name:    "error: period longer than a year",
+			filter:  &entity.StatsFilter{From: from, To: from.AddDate(2, 0, 0)},
+			setup:   func(repo *repository.MockStatsRepository) {},
+			wantErr: entity.ErrInvalidStatsPeriod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockStatsRepository(ctrl)
+			tt.setup(repo)
+
+			_, err := usecase.NewStatsInteractor(repo, &entity.StatsConfig{TopSize: 10}).GetStats(context.Background(), userId, tt.filter)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_statsInteractor_GenerateYearReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failedId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	userId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	year := time.Now().UTC().Year() - 1
+	yearFilter := entity.YearStatsFilter(year, 10)
+	stats := &entity.ListeningStats{}
+	statsErr := errors.New("statement timeout")
+
+	repo := repository.NewMockStatsRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().GetUsersWithoutReview(gomock.Any(), year, uuid.Nil, gomock.Any(), 2).Return([]uuid.UUID{failedId, userId}, nil),
+		repo.EXPECT().GetUsersWithoutReview(gomock.Any(), year, userId, gomock.Any(), 2).Return(nil, nil),
+		repo.EXPECT().GetUsersWithoutReview(gomock.Any(), year, uuid.Nil, gomock.Any(), 2).Return(nil, nil),
+	)
+	repo.EXPECT().GetStats(gomock.Any(), failedId, yearFilter).Return(nil, statsErr)
+	repo.EXPECT().RecordReviewFailure(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, failure *entity.YearReviewFailure) error {
+		assert.Equal(t, failedId, failure.UserID)
+		assert.Equal(t, year, failure.Year)
+		assert.Contains(t, failure.Error, statsErr.Error())
+		return nil
+	})
+	repo.EXPECT().GetStats(gomock.Any(), userId, yearFilter).Return(stats, nil)
+	repo.EXPECT().SaveReview(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, review *entity.YearReviewDB) error {
+		assert.Equal(t, userId, review.UserID)
+		assert.Equal(t, year, review.Year)
+		assert.Equal(t, stats, review.Stats)
+		return nil
+	})
+
+	interactor := usecase.NewStatsInteractor(repo, &entity.StatsConfig{TopSize: 10, ReviewBatchSize: 2})
+	err := interactor.GenerateYearReviews(context.Background())
+	assert.ErrorIs(t, err, statsErr)
+
+	// Следующий запуск продолжает после последнего пользователя страницы, а после неполной страницы начинает сначала
+	assert.NoError(t, interactor.GenerateYearReviews(context.Background()))
+	assert.NoError(t, interactor.GenerateYearReviews(context.Background()))
+}
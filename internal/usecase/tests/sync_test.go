@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSyncConfig = &entity.SyncConfig{
+	TokenTTL:        24 * time.Hour,
+	PageSize:        2,
+	CleanupInterval: time.Hour,
+}
+
+func Test_syncInteractor_Sync_full(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	playlistId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	tracks := []*entity.MusicDB{
+		{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001")},
+		{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002")},
+		{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003")},
+	}
+
+	repo := repository.NewMockSyncRepository(ctrl)
+	repo.EXPECT().GetXmin(gomock.Any()).Return(uint64(500), nil)
+	repo.EXPECT().GetAllLikes(gomock.Any(), userId).Return([]*entity.SyncLikeDB{{MusicID: tracks[0].Id}}, nil)
+	repo.EXPECT().GetAllPlaylists(gomock.Any(), userId).Return([]*entity.PlaylistDB{{ID: playlistId, UserID: userId}}, nil)
+	repo.EXPECT().GetPlaylistTracks(gomock.Any(), userId, []uuid.UUID{playlistId}).Return([]*entity.SyncPlaylistTrackDB{
+		{PlaylistID: playlistId, MusicID: tracks[1].Id},
+		{PlaylistID: playlistId, MusicID: tracks[0].Id},
+	}, nil)
+	gomock.InOrder(
+		repo.EXPECT().GetTrackPage(gomock.Any(), userId, uuid.Nil, 3).Return(tracks, nil),
+		repo.EXPECT().GetTrackPage(gomock.Any(), userId, tracks[1].Id, 3).Return(tracks[2:], nil),
+	)
+
+	interactor := usecase.NewSyncInteractor(repo, testSyncConfig)
+
+	delta, err := interactor.Sync(context.Background(), userId, "")
+	assert.NoError(t, err)
+	assert.True(t, delta.Full)
+	assert.True(t, delta.HasMore)
+	assert.Len(t, delta.Tracks, 2)
+	assert.Len(t, delta.Likes, 1)
+	assert.Equal(t, []uuid.UUID{tracks[1].Id, tracks[0].Id}, delta.Playlists[0].TrackIDs)
+	assert.Equal(t, tracks[1].Id, *delta.Cursor.After)
+
+	delta, err = interactor.Sync(context.Background(), userId, delta.Cursor.Encode())
+	assert.NoError(t, err)
+	assert.True(t, delta.Full)
+	assert.False(t, delta.HasMore)
+	assert.Equal(t, []*entity.MusicDB{tracks[2]}, delta.Tracks)
+	assert.Empty(t, delta.Likes)
+	assert.Nil(t, delta.Cursor.After)
+	assert.Equal(t, uint64(500), delta.Cursor.TxID)
+	assert.Equal(t, int64(0), delta.Cursor.ID)
+}
+
+func Test_syncInteractor_Sync_changes(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	trackId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	goneTrackId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	takenDownId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	playlistId := uuid.MustParse("b7e10b2c-8a0e-4fd8-9c5b-1e5f5a3d9a11")
+	cursor := &entity.SyncCursor{TxID: 100, ID: 7, IssuedAt: time.Now().Add(-time.Hour)}
+
+	tests := []struct {
+		name      string
+		token     string
+		setup     func(repo *repository.MockSyncRepository)
+		check     func(t *testing.T, delta *entity.SyncDelta)
+		wantError error
+	}{
+		{
+			name:  "success: changes with tombstones",
+			token: cursor.Encode(),
+			setup: func(repo *repository.MockSyncRepository) {
+				repo.EXPECT().GetXmin(gomock.Any()).Return(uint64(120), nil)
+				repo.EXPECT().GetChanges(gomock.Any(), userId, gomock.Any(), uint64(120), 3).Return([]*entity.SyncChangeDB{
+					{ID: 8, TxID: 101, Kind: entity.SyncKindTrack, EntityID: trackId},
+					{ID: 9, TxID: 101, Kind: entity.SyncKindTrack, EntityID: goneTrackId},
+				}, nil)
+				repo.EXPECT().GetTracks(gomock.Any(), userId, []uuid.UUID{trackId, goneTrackId}).Return([]*entity.MusicDB{{Id: trackId}}, nil)
+				repo.EXPECT().GetTakenDown(gomock.Any(), gomock.Any(), gomock.Any()).Return([]uuid.UUID{goneTrackId, takenDownId}, nil)
+			},
+			check: func(t *testing.T, delta *entity.SyncDelta) {
+				assert.False(t, delta.Full)
+				assert.False(t, delta.HasMore)
+				assert.Len(t, delta.Tracks, 1)
+				assert.Equal(t, []uuid.UUID{goneTrackId, takenDownId}, delta.DeletedTracks)
+				assert.Equal(t, uint64(120), delta.Cursor.TxID)
+				assert.Equal(t, int64(0), delta.Cursor.ID)
+			},
+		},
+		{
+			name:  "success: page of collapsed changes",
+			token: cursor.Encode(),
+			setup: func(repo *repository.MockSyncRepository) {
+				repo.EXPECT().GetXmin(gomock.Any()).Return(uint64(120), nil)
+				repo.EXPECT().GetChanges(gomock.Any(), userId, gomock.Any(), uint64(120), 3).Return([]*entity.SyncChangeDB{
+					{ID: 8, TxID: 101, Kind: entity.SyncKindPlaylist, EntityID: playlistId},
+					{ID: 3, TxID: 102, Kind: entity.SyncKindPlaylist, EntityID: playlistId},
+					{ID: 12, TxID: 103, Kind: entity.SyncKindLike, EntityID: trackId},
+				}, nil)
+				repo.EXPECT().GetTakenDown(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				repo.EXPECT().GetPlaylists(gomock.Any(), userId, []uuid.UUID{playlistId}).Return(nil, nil)
+			},
+			check: func(t *testing.T, delta *entity.SyncDelta) {
+				assert.True(t, delta.HasMore)
+				assert.Equal(t, []uuid.UUID{playlistId}, delta.DeletedPlaylists)
+				assert.Empty(t, delta.RemovedLikes)
+				assert.Equal(t, uint64(102), delta.Cursor.TxID)
+				assert.Equal(t, int64(3), delta.Cursor.ID)
+			},
+		},
+		{
+			name:  "success: removed like",
+			token: cursor.Encode(),
+			setup: func(repo *repository.MockSyncRepository) {
+				repo.EXPECT().GetXmin(gomock.Any()).Return(uint64(120), nil)
+				repo.EXPECT().GetChanges(gomock.Any(), userId, gomock.Any(), uint64(120), 3).Return([]*entity.SyncChangeDB{
+					{ID: 8, TxID: 101, Kind: entity.SyncKindLike, EntityID: trackId},
+				}, nil)
+				repo.EXPECT().GetTakenDown(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				repo.EXPECT().GetLikes(gomock.Any(), userId, []uuid.UUID{trackId}).Return(nil, nil)
+			},
+			check: func(t *testing.T, delta *entity.SyncDelta) {
+				assert.Equal(t, []uuid.UUID{trackId}, delta.RemovedLikes)
+			},
+		},
+		{
+			name:  "error: reset requires full sync",
+			token: cursor.Encode(),
+			setup: func(repo *repository.MockSyncRepository) {
+				repo.EXPECT().GetXmin(gomock.Any()).Return(uint64(120), nil)
+				repo.EXPECT().GetChanges(gomock.Any(), userId, gomock.Any(), uint64(120), 3).Return([]*entity.SyncChangeDB{
+					{ID: 8, TxID: 101, Kind: entity.SyncKindReset, EntityID: userId},
+				}, nil)
+			},
+			wantError: entity.ErrSyncTokenExpired,
+		},
+		{
+			name:      "error: expired token",
+			token:     (&entity.SyncCursor{TxID: 100, IssuedAt: time.Now().Add(-48 * time.Hour)}).Encode(),
+			setup:     func(repo *repository.MockSyncRepository) {},
+			wantError: entity.ErrSyncTokenExpired,
+		},
+		{
+			name:      "error: invalid token",
+			token:     "not-a-token",
+			setup:     func(repo *repository.MockSyncRepository) {},
+			wantError: entity.ErrInvalidSyncToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repository.NewMockSyncRepository(ctrl)
+			tt.setup(repo)
+
+			delta, err := usecase.NewSyncInteractor(repo, testSyncConfig).Sync(context.Background(), userId, tt.token)
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+				return
+			}
+			assert.NoError(t, err)
+			tt.check(t, delta)
+		})
+	}
+}
+
+func Test_syncInteractor_CleanupChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockSyncRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().DeleteChanges(gomock.Any(), gomock.Any(), entity.SyncCleanupBatch).Return(int64(entity.SyncCleanupBatch), nil),
+		repo.EXPECT().DeleteChanges(gomock.Any(), gomock.Any(), entity.SyncCleanupBatch).Return(int64(10), nil),
+	)
+
+	err := usecase.NewSyncInteractor(repo, testSyncConfig).CleanupChanges(context.Background())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"music-backend-test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_trashInteractor_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockTrashRepository(ctrl)
+	repo.EXPECT().Purge(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, before time.Time) error {
+			assert.WithinDuration(t, time.Now().Add(-72*time.Hour), before, time.Minute)
+			return nil
+		})
+
+	err := usecase.NewTrashInteractor(repo, &entity.TrashConfig{Retention: 72 * time.Hour}).Purge(context.Background())
+	assert.NoError(t, err)
+}
+
+func Test_trashInteractor_GetMusic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockTrashRepository(ctrl)
+	repo.EXPECT().GetMusic(gomock.Any(), entity.MaxTrashLimit, 0).Return(nil, nil)
+
+	_, err := usecase.NewTrashInteractor(repo, &entity.TrashConfig{}).GetMusic(context.Background(), 1000, -1)
+	assert.NoError(t, err)
+}
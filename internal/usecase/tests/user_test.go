@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"music-backend-test/internal/entity"
 	"music-backend-test/internal/repository"
@@ -14,6 +16,12 @@ import (
 	"github.com/google/uuid"
 )
 
+var testAuthConfig = &entity.AuthConfig{
+	AccessTokenTTL:  15 * time.Minute,
+	RefreshTokenTTL: 24 * time.Hour,
+	CleanupInterval: time.Hour,
+}
+
 func Test_userInteractor_Create(t *testing.T) {
 	type fields struct {
 		repo *repository.MockUserRepository
@@ -66,7 +74,7 @@ func Test_userInteractor_Create(t *testing.T) {
 			f := fields{
 				repo: repository.NewMockUserRepository(ctrl),
 			}
-			u := usecase.NewUserInteractor(f.repo)
+			u := usecase.NewUserInteractor(f.repo, testAuthConfig)
 			tt.setup(tt.args, f)
 
 			got, err := u.Create(tt.args.ctx, tt.args.user)
@@ -134,7 +142,7 @@ func Test_userInteractor_GetById(t *testing.T) {
 			f := fields{
 				repo: repository.NewMockUserRepository(ctrl),
 			}
-			u := usecase.NewUserInteractor(f.repo)
+			u := usecase.NewUserInteractor(f.repo, testAuthConfig)
 
 			tt.setup(tt.args, f)
 
@@ -202,7 +210,7 @@ func Test_userInteractor_GetByUsername(t *testing.T) {
 			f := fields{
 				repo: repository.NewMockUserRepository(ctrl),
 			}
-			u := usecase.NewUserInteractor(f.repo)
+			u := usecase.NewUserInteractor(f.repo, testAuthConfig)
 
 			tt.setup(tt.args, f)
 
@@ -218,6 +226,74 @@ func Test_userInteractor_GetByUsername(t *testing.T) {
 	}
 }
 
+func Test_userInteractor_SignIn(t *testing.T) {
+	type fields struct {
+		repo *repository.MockUserRepository
+	}
+	type args struct {
+		ctx  context.Context
+		user *entity.UserCreate
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *entity.UserDB
+		setup   func(a args, f fields)
+		wantErr error
+	}{
+		{
+			name: "success SignIn usecase",
+			args: args{
+				ctx:  context.Background(),
+				user: &entity.UserCreate{Username: "John", Password: "qwerty1234"},
+			},
+			want: &entity.UserDB{
+				ID:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				Username: "John",
+			},
+			setup: func(a args, f fields) {
+				user := &entity.UserDB{
+					ID:       uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+					Username: "John",
+				}
+				f.repo.EXPECT().Authenticate(a.ctx, a.user.Username, a.user.Password).Return(user, nil)
+			},
+		},
+		{
+			name: "error SignIn usecase: invalid credentials",
+			args: args{
+				ctx:  context.Background(),
+				user: &entity.UserCreate{Username: "John", Password: "wrong"},
+			},
+			want: nil,
+			setup: func(a args, f fields) {
+				f.repo.EXPECT().Authenticate(a.ctx, a.user.Username, a.user.Password).Return(nil, entity.ErrInvalidCredentials)
+			},
+			wantErr: entity.ErrInvalidCredentials,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			f := fields{
+				repo: repository.NewMockUserRepository(ctrl),
+			}
+			u := usecase.NewUserInteractor(f.repo, testAuthConfig)
+
+			tt.setup(tt.args, f)
+
+			got, err := u.SignIn(tt.args.ctx, tt.args.user)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("userInteractor.SignIn() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("userInteractor.SignIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_userInteractor_Update(t *testing.T) {
 	type fields struct {
 		repo *repository.MockUserRepository
@@ -279,7 +355,7 @@ func Test_userInteractor_Update(t *testing.T) {
 			f := fields{
 				repo: repository.NewMockUserRepository(ctrl),
 			}
-			u := usecase.NewUserInteractor(f.repo)
+			u := usecase.NewUserInteractor(f.repo, testAuthConfig)
 			tt.setup(tt.args, f)
 
 			got, err := u.Update(tt.args.ctx, tt.args.id, tt.args.user)
@@ -317,7 +393,7 @@ func Test_userInteractor_Delete(t *testing.T) {
 			},
 			want: nil,
 			setup: func(a args, f fields) {
-				f.repo.EXPECT().Delete(a.ctx, a.id).Return(nil)
+				f.repo.EXPECT().Delete(a.ctx, a.id, gomock.Any()).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -329,7 +405,7 @@ func Test_userInteractor_Delete(t *testing.T) {
 			},
 			want: nil,
 			setup: func(a args, f fields) {
-				f.repo.EXPECT().Delete(a.ctx, a.id).Return(fmt.Errorf("can't update user in repository"))
+				f.repo.EXPECT().Delete(a.ctx, a.id, gomock.Any()).Return(fmt.Errorf("can't update user in repository"))
 			},
 			wantErr: true,
 		},
@@ -340,7 +416,7 @@ func Test_userInteractor_Delete(t *testing.T) {
 			f := fields{
 				repo: repository.NewMockUserRepository(ctrl),
 			}
-			u := usecase.NewUserInteractor(f.repo)
+			u := usecase.NewUserInteractor(f.repo, testAuthConfig)
 			tt.setup(tt.args, f)
 
 			if err := u.Delete(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
@@ -397,7 +473,7 @@ func Test_userInteractor_LikeTrack(t *testing.T) {
 			f := fields{
 				repo: repository.NewMockUserRepository(ctrl),
 			}
-			u := usecase.NewUserInteractor(f.repo)
+			u := usecase.NewUserInteractor(f.repo, testAuthConfig)
 
 			tt.setup(tt.args, f)
 
@@ -458,7 +534,7 @@ func Test_userInteractor_DislikeTrack(t *testing.T) {
 			f := fields{
 				repo: repository.NewMockUserRepository(ctrl),
 			}
-			u := usecase.NewUserInteractor(f.repo)
+			u := usecase.NewUserInteractor(f.repo, testAuthConfig)
 
 			tt.setup(tt.args, f)
 
@@ -558,7 +634,7 @@ func Test_userInteractor_ShowLikedTracks(t *testing.T) {
 			f := fields{
 				repo: repository.NewMockUserRepository(ctrl),
 			}
-			u := usecase.NewUserInteractor(f.repo)
+			u := usecase.NewUserInteractor(f.repo, testAuthConfig)
 
 			tt.setup(tt.args, f)
 
@@ -572,3 +648,111 @@ func Test_userInteractor_ShowLikedTracks(t *testing.T) {
 		})
 	}
 }
+
+func Test_userInteractor_IssueTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repository.NewMockUserRepository(ctrl)
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+
+	device := entity.NewSessionDevice("Pixel 8", "okhttp/4.12", "203.0.113.7")
+
+	var saved *entity.RefreshTokenDB
+	var session *entity.SessionDB
+	repo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.SessionDB, token *entity.RefreshTokenDB) error {
+		session = s
+		saved = token
+		return nil
+	})
+	var claims *entity.AccessClaims
+	repo.EXPECT().SignAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c *entity.AccessClaims, _ time.Time) (string, error) {
+		claims = c
+		return "access-token", nil
+	})
+
+	tokens, err := usecase.NewUserInteractor(repo, testAuthConfig).IssueTokens(context.Background(), userId, device)
+	if err != nil {
+		t.Fatalf("userInteractor.IssueTokens() error = %v", err)
+	}
+	if saved.UserID != userId || saved.FamilyID == uuid.Nil {
+		t.Errorf("userInteractor.IssueTokens() saved token = %+v", saved)
+	}
+	if session.ID != saved.FamilyID || session.UserID != userId || session.DeviceName != "Pixel 8" || session.IP != "203.0.113.7" ||
+		!session.ExpiresAt.Equal(saved.ExpiresAt) || tokens.SessionID != session.ID {
+		t.Errorf("userInteractor.IssueTokens() session = %+v", session)
+	}
+	if tokens.Access != "access-token" || claims.Id != userId.String() || claims.SessionID != session.ID.String() {
+		t.Errorf("userInteractor.IssueTokens() access claims = %+v", claims)
+	}
+	if saved.TokenHash != entity.HashRefreshToken(tokens.Refresh) || saved.TokenHash == tokens.Refresh {
+		t.Errorf("userInteractor.IssueTokens() must store only the refresh token hash")
+	}
+	if got := tokens.RefreshExpiresAt.Sub(tokens.AccessExpiresAt); got != testAuthConfig.RefreshTokenTTL-testAuthConfig.AccessTokenTTL {
+		t.Errorf("userInteractor.IssueTokens() token lifetimes differ by %v", got)
+	}
+}
+
+func Test_userInteractor_Refresh(t *testing.T) {
+	userId := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	familyId := uuid.MustParse("0b7e3c1a-4a1f-4d5e-9c55-6f2f8c3e1d20")
+
+	tests := []struct {
+		name    string
+		token   string
+		setup   func(repo *repository.MockUserRepository)
+		wantErr error
+	}{
+		{
+			name:  "success Refresh usecase",
+			token: "old-token",
+			setup: func(repo *repository.MockUserRepository) {
+				repo.EXPECT().RotateRefreshToken(gomock.Any(), entity.HashRefreshToken("old-token"), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, next *entity.RefreshTokenDB, _ *entity.SessionDevice, _ time.Time) (*entity.RefreshTokenDB, error) {
+						rotated := *next
+						rotated.UserID = userId
+						rotated.FamilyID = familyId
+						return &rotated, nil
+					})
+				repo.EXPECT().SignAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).Return("access-token", nil)
+			},
+		},
+		{
+			name:    "error Refresh usecase: empty token",
+			token:   "",
+			setup:   func(repo *repository.MockUserRepository) {},
+			wantErr: entity.ErrInvalidRefreshToken,
+		},
+		{
+			name:  "error Refresh usecase: unknown token",
+			token: "unknown",
+			setup: func(repo *repository.MockUserRepository) {
+				repo.EXPECT().RotateRefreshToken(gomock.Any(), entity.HashRefreshToken("unknown"), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("/db/refresh_token.Rotate: %w", sql.ErrNoRows))
+			},
+			wantErr: entity.ErrInvalidRefreshToken,
+		},
+		{
+			name:  "error Refresh usecase: reused token",
+			token: "rotated",
+			setup: func(repo *repository.MockUserRepository) {
+				repo.EXPECT().RotateRefreshToken(gomock.Any(), entity.HashRefreshToken("rotated"), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("/db/refresh_token.Rotate: %w", entity.ErrRefreshTokenReused))
+			},
+			wantErr: entity.ErrRefreshTokenReused,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := repository.NewMockUserRepository(ctrl)
+			tt.setup(repo)
+
+			tokens, err := usecase.NewUserInteractor(repo, testAuthConfig).Refresh(context.Background(), tt.token, entity.NewSessionDevice("", "okhttp/4.12", "203.0.113.7"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("userInteractor.Refresh() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (tokens.Refresh == "" || tokens.Refresh == tt.token || tokens.Access == "" || tokens.SessionID != familyId) {
+				t.Errorf("userInteractor.Refresh() = %+v", tokens)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type trashInteractor struct {
+	repo repository.TrashRepository
+	cfg  *entity.TrashConfig
+}
+
+func NewTrashInteractor(repo repository.TrashRepository, cfg *entity.TrashConfig) *trashInteractor {
+	return &trashInteractor{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+func (t *trashInteractor) GetMusic(ctx context.Context, limit int, offset int) ([]*entity.MusicDB, error) {
+	limit, offset = entity.NormalizeTrashPage(limit, offset)
+
+	music, err := t.repo.GetMusic(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/trash.GetMusic: %w", err)
+	}
+
+	return music, nil
+}
+
+func (t *trashInteractor) GetUsers(ctx context.Context, limit int, offset int) ([]*entity.UserDB, error) {
+	limit, offset = entity.NormalizeTrashPage(limit, offset)
+
+	users, err := t.repo.GetUsers(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/trash.GetUsers: %w", err)
+	}
+
+	return users, nil
+}
+
+func (t *trashInteractor) RestoreMusic(ctx context.Context, id uuid.UUID) error {
+	err := t.repo.RestoreMusic(ctx, id)
+	if err != nil {
+		return fmt.Errorf("/repository/trash.RestoreMusic: %w", err)
+	}
+
+	return nil
+}
+
+func (t *trashInteractor) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	err := t.repo.RestoreUser(ctx, id)
+	if err != nil {
+		return fmt.Errorf("/repository/trash.RestoreUser: %w", err)
+	}
+
+	return nil
+}
+
+// Purge удаляет из корзины все, что лежит в ней дольше срока хранения. Вызывается планировщиком.
+func (t *trashInteractor) Purge(ctx context.Context) error {
+	err := t.repo.Purge(ctx, time.Now().Add(-t.cfg.Retention))
+	if err != nil {
+		return fmt.Errorf("/repository/trash.Purge: %w", err)
+	}
+
+	return nil
+}
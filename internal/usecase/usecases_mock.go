@@ -6,8 +6,10 @@ package usecase
 
 import (
 	context "context"
+	io "io"
 	entity "music-backend-test/internal/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,6 +38,20 @@ func (m *MockUserInteractor) EXPECT() *MockUserInteractorMockRecorder {
 	return m.recorder
 }
 
+// CleanupRefreshTokens mocks base method.
+func (m *MockUserInteractor) CleanupRefreshTokens(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupRefreshTokens", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupRefreshTokens indicates an expected call of CleanupRefreshTokens.
+func (mr *MockUserInteractorMockRecorder) CleanupRefreshTokens(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupRefreshTokens", reflect.TypeOf((*MockUserInteractor)(nil).CleanupRefreshTokens), ctx)
+}
+
 // Create mocks base method.
 func (m *MockUserInteractor) Create(ctx context.Context, user *entity.UserCreate) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +125,21 @@ func (mr *MockUserInteractorMockRecorder) GetByUsername(ctx, username interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUserInteractor)(nil).GetByUsername), ctx, username)
 }
 
+// IssueTokens mocks base method.
+func (m *MockUserInteractor) IssueTokens(ctx context.Context, userId uuid.UUID, device *entity.SessionDevice) (*entity.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueTokens", ctx, userId, device)
+	ret0, _ := ret[0].(*entity.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueTokens indicates an expected call of IssueTokens.
+func (mr *MockUserInteractorMockRecorder) IssueTokens(ctx, userId, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueTokens", reflect.TypeOf((*MockUserInteractor)(nil).IssueTokens), ctx, userId, device)
+}
+
 // LikeTrack mocks base method.
 func (m *MockUserInteractor) LikeTrack(ctx context.Context, userId, trackId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -123,6 +154,63 @@ func (mr *MockUserInteractorMockRecorder) LikeTrack(ctx, userId, trackId interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikeTrack", reflect.TypeOf((*MockUserInteractor)(nil).LikeTrack), ctx, userId, trackId)
 }
 
+// Logout mocks base method.
+func (m *MockUserInteractor) Logout(ctx context.Context, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUserInteractorMockRecorder) Logout(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUserInteractor)(nil).Logout), ctx, refreshToken)
+}
+
+// LogoutAll mocks base method.
+func (m *MockUserInteractor) LogoutAll(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockUserInteractorMockRecorder) LogoutAll(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockUserInteractor)(nil).LogoutAll), ctx, userId)
+}
+
+// Refresh mocks base method.
+func (m *MockUserInteractor) Refresh(ctx context.Context, refreshToken string, device *entity.SessionDevice) (*entity.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken, device)
+	ret0, _ := ret[0].(*entity.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockUserInteractorMockRecorder) Refresh(ctx, refreshToken, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockUserInteractor)(nil).Refresh), ctx, refreshToken, device)
+}
+
+// SetContentFilter mocks base method.
+func (m *MockUserInteractor) SetContentFilter(ctx context.Context, id uuid.UUID, filter *entity.ContentFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetContentFilter", ctx, id, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetContentFilter indicates an expected call of SetContentFilter.
+func (mr *MockUserInteractorMockRecorder) SetContentFilter(ctx, id, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContentFilter", reflect.TypeOf((*MockUserInteractor)(nil).SetContentFilter), ctx, id, filter)
+}
+
 // ShowLikedTracks mocks base method.
 func (m *MockUserInteractor) ShowLikedTracks(ctx context.Context, id uuid.UUID) ([]*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +226,21 @@ func (mr *MockUserInteractorMockRecorder) ShowLikedTracks(ctx, id interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowLikedTracks", reflect.TypeOf((*MockUserInteractor)(nil).ShowLikedTracks), ctx, id)
 }
 
+// SignIn mocks base method.
+func (m *MockUserInteractor) SignIn(ctx context.Context, user *entity.UserCreate) (*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", ctx, user)
+	ret0, _ := ret[0].(*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockUserInteractorMockRecorder) SignIn(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockUserInteractor)(nil).SignIn), ctx, user)
+}
+
 // Update mocks base method.
 func (m *MockUserInteractor) Update(ctx context.Context, id uuid.UUID, user *entity.UserCreate) (*entity.UserDB, error) {
 	m.ctrl.T.Helper()
@@ -177,11 +280,12 @@ func (m *MockMusicInteractor) EXPECT() *MockMusicInteractorMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockMusicInteractor) Create(ctx context.Context, musicCreate *entity.MusicParse) error {
+func (m *MockMusicInteractor) Create(ctx context.Context, musicCreate *entity.MusicParse) (*entity.MusicUploadReport, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", ctx, musicCreate)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*entity.MusicUploadReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
@@ -205,63 +309,107 @@ func (mr *MockMusicInteractorMockRecorder) Delete(ctx, id interface{}) *gomock.C
 }
 
 // Get mocks base method.
-func (m *MockMusicInteractor) Get(ctx context.Context, musicId uuid.UUID) (*entity.MusicDB, error) {
+func (m *MockMusicInteractor) Get(ctx context.Context, musicId uuid.UUID, filter entity.MusicFilter) (*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, musicId)
+	ret := m.ctrl.Call(m, "Get", ctx, musicId, filter)
 	ret0, _ := ret[0].(*entity.MusicDB)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockMusicInteractorMockRecorder) Get(ctx, musicId interface{}) *gomock.Call {
+func (mr *MockMusicInteractorMockRecorder) Get(ctx, musicId, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMusicInteractor)(nil).Get), ctx, musicId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMusicInteractor)(nil).Get), ctx, musicId, filter)
 }
 
 // GetAll mocks base method.
-func (m *MockMusicInteractor) GetAll(ctx context.Context) ([]*entity.MusicDB, error) {
+func (m *MockMusicInteractor) GetAll(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
 	ret0, _ := ret[0].([]*entity.MusicDB)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockMusicInteractorMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+func (mr *MockMusicInteractorMockRecorder) GetAll(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockMusicInteractor)(nil).GetAll), ctx, filter)
+}
+
+// GetAllSortByRating mocks base method.
+func (m *MockMusicInteractor) GetAllSortByRating(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSortByRating", ctx, filter)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSortByRating indicates an expected call of GetAllSortByRating.
+func (mr *MockMusicInteractorMockRecorder) GetAllSortByRating(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockMusicInteractor)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSortByRating", reflect.TypeOf((*MockMusicInteractor)(nil).GetAllSortByRating), ctx, filter)
 }
 
 // GetAllSortByTime mocks base method.
-func (m *MockMusicInteractor) GetAllSortByTime(ctx context.Context) ([]*entity.MusicDB, error) {
+func (m *MockMusicInteractor) GetAllSortByTime(ctx context.Context, filter entity.MusicFilter) ([]*entity.MusicDB, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllSortByTime", ctx)
+	ret := m.ctrl.Call(m, "GetAllSortByTime", ctx, filter)
 	ret0, _ := ret[0].([]*entity.MusicDB)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllSortByTime indicates an expected call of GetAllSortByTime.
-func (mr *MockMusicInteractorMockRecorder) GetAllSortByTime(ctx interface{}) *gomock.Call {
+func (mr *MockMusicInteractorMockRecorder) GetAllSortByTime(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSortByTime", reflect.TypeOf((*MockMusicInteractor)(nil).GetAllSortByTime), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSortByTime", reflect.TypeOf((*MockMusicInteractor)(nil).GetAllSortByTime), ctx, filter)
 }
 
-// GetAndSortByPopular mocks base method.
-func (m *MockMusicInteractor) GetAndSortByPopular(ctx context.Context) ([]*entity.MusicDB, error) {
+// GetDuplicates mocks base method.
+func (m *MockMusicInteractor) GetDuplicates(ctx context.Context, id uuid.UUID) ([]*entity.MusicDuplicateDB, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAndSortByPopular", ctx)
-	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret := m.ctrl.Call(m, "GetDuplicates", ctx, id)
+	ret0, _ := ret[0].([]*entity.MusicDuplicateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicates indicates an expected call of GetDuplicates.
+func (mr *MockMusicInteractorMockRecorder) GetDuplicates(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicates", reflect.TypeOf((*MockMusicInteractor)(nil).GetDuplicates), ctx, id)
+}
+
+// Merge mocks base method.
+func (m *MockMusicInteractor) Merge(ctx context.Context, id uuid.UUID, merge *entity.MusicMerge) (*entity.MusicMergeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, id, merge)
+	ret0, _ := ret[0].(*entity.MusicMergeResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAndSortByPopular indicates an expected call of GetAndSortByPopular.
-func (mr *MockMusicInteractorMockRecorder) GetAndSortByPopular(ctx interface{}) *gomock.Call {
+// Merge indicates an expected call of Merge.
+func (mr *MockMusicInteractorMockRecorder) Merge(ctx, id, merge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockMusicInteractor)(nil).Merge), ctx, id, merge)
+}
+
+// SetExplicit mocks base method.
+func (m *MockMusicInteractor) SetExplicit(ctx context.Context, id uuid.UUID, explicit bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExplicit", ctx, id, explicit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExplicit indicates an expected call of SetExplicit.
+func (mr *MockMusicInteractorMockRecorder) SetExplicit(ctx, id, explicit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAndSortByPopular", reflect.TypeOf((*MockMusicInteractor)(nil).GetAndSortByPopular), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExplicit", reflect.TypeOf((*MockMusicInteractor)(nil).SetExplicit), ctx, id, explicit)
 }
 
 // Update mocks base method.
@@ -277,3 +425,2072 @@ func (mr *MockMusicInteractorMockRecorder) Update(ctx, id, musicUpdate interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMusicInteractor)(nil).Update), ctx, id, musicUpdate)
 }
+
+// MockPlayInteractor is a mock of PlayInteractor interface.
+type MockPlayInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlayInteractorMockRecorder
+}
+
+// MockPlayInteractorMockRecorder is the mock recorder for MockPlayInteractor.
+type MockPlayInteractorMockRecorder struct {
+	mock *MockPlayInteractor
+}
+
+// NewMockPlayInteractor creates a new mock instance.
+func NewMockPlayInteractor(ctrl *gomock.Controller) *MockPlayInteractor {
+	mock := &MockPlayInteractor{ctrl: ctrl}
+	mock.recorder = &MockPlayInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlayInteractor) EXPECT() *MockPlayInteractorMockRecorder {
+	return m.recorder
+}
+
+// DeleteHistory mocks base method.
+func (m *MockPlayInteractor) DeleteHistory(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHistory", ctx, userId, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHistory indicates an expected call of DeleteHistory.
+func (mr *MockPlayInteractorMockRecorder) DeleteHistory(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHistory", reflect.TypeOf((*MockPlayInteractor)(nil).DeleteHistory), ctx, userId, filter)
+}
+
+// DeleteHistoryEvent mocks base method.
+func (m *MockPlayInteractor) DeleteHistoryEvent(ctx context.Context, userId, eventId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHistoryEvent", ctx, userId, eventId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHistoryEvent indicates an expected call of DeleteHistoryEvent.
+func (mr *MockPlayInteractorMockRecorder) DeleteHistoryEvent(ctx, userId, eventId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHistoryEvent", reflect.TypeOf((*MockPlayInteractor)(nil).DeleteHistoryEvent), ctx, userId, eventId)
+}
+
+// GetHistory mocks base method.
+func (m *MockPlayInteractor) GetHistory(ctx context.Context, userId uuid.UUID, filter *entity.PlayHistoryFilter) ([]*entity.PlayEventDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, userId, filter)
+	ret0, _ := ret[0].([]*entity.PlayEventDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockPlayInteractorMockRecorder) GetHistory(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockPlayInteractor)(nil).GetHistory), ctx, userId, filter)
+}
+
+// Record mocks base method.
+func (m *MockPlayInteractor) Record(ctx context.Context, userId uuid.UUID, event *entity.PlayEventCreate) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, userId, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockPlayInteractorMockRecorder) Record(ctx, userId, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockPlayInteractor)(nil).Record), ctx, userId, event)
+}
+
+// RecordBatch mocks base method.
+func (m *MockPlayInteractor) RecordBatch(ctx context.Context, userId uuid.UUID, events []*entity.PlayEventCreate) (*entity.PlayBatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordBatch", ctx, userId, events)
+	ret0, _ := ret[0].(*entity.PlayBatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordBatch indicates an expected call of RecordBatch.
+func (mr *MockPlayInteractorMockRecorder) RecordBatch(ctx, userId, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordBatch", reflect.TypeOf((*MockPlayInteractor)(nil).RecordBatch), ctx, userId, events)
+}
+
+// RecordStream mocks base method.
+func (m *MockPlayInteractor) RecordStream(ctx context.Context, userId, musicId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordStream", ctx, userId, musicId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordStream indicates an expected call of RecordStream.
+func (mr *MockPlayInteractorMockRecorder) RecordStream(ctx, userId, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordStream", reflect.TypeOf((*MockPlayInteractor)(nil).RecordStream), ctx, userId, musicId)
+}
+
+// MockPopularityInteractor is a mock of PopularityInteractor interface.
+type MockPopularityInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockPopularityInteractorMockRecorder
+}
+
+// MockPopularityInteractorMockRecorder is the mock recorder for MockPopularityInteractor.
+type MockPopularityInteractorMockRecorder struct {
+	mock *MockPopularityInteractor
+}
+
+// NewMockPopularityInteractor creates a new mock instance.
+func NewMockPopularityInteractor(ctrl *gomock.Controller) *MockPopularityInteractor {
+	mock := &MockPopularityInteractor{ctrl: ctrl}
+	mock.recorder = &MockPopularityInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPopularityInteractor) EXPECT() *MockPopularityInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetPopular mocks base method.
+func (m *MockPopularityInteractor) GetPopular(ctx context.Context, window string, limit, offset int, hideExplicit bool) ([]*entity.MusicPopularityDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPopular", ctx, window, limit, offset, hideExplicit)
+	ret0, _ := ret[0].([]*entity.MusicPopularityDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPopular indicates an expected call of GetPopular.
+func (mr *MockPopularityInteractorMockRecorder) GetPopular(ctx, window, limit, offset, hideExplicit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopular", reflect.TypeOf((*MockPopularityInteractor)(nil).GetPopular), ctx, window, limit, offset, hideExplicit)
+}
+
+// GetTrending mocks base method.
+func (m *MockPopularityInteractor) GetTrending(ctx context.Context, limit, offset int, hideExplicit bool) ([]*entity.MusicPopularityDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrending", ctx, limit, offset, hideExplicit)
+	ret0, _ := ret[0].([]*entity.MusicPopularityDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrending indicates an expected call of GetTrending.
+func (mr *MockPopularityInteractorMockRecorder) GetTrending(ctx, limit, offset, hideExplicit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrending", reflect.TypeOf((*MockPopularityInteractor)(nil).GetTrending), ctx, limit, offset, hideExplicit)
+}
+
+// Refresh mocks base method.
+func (m *MockPopularityInteractor) Refresh(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockPopularityInteractorMockRecorder) Refresh(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockPopularityInteractor)(nil).Refresh), ctx)
+}
+
+// MockChartInteractor is a mock of ChartInteractor interface.
+type MockChartInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockChartInteractorMockRecorder
+}
+
+// MockChartInteractorMockRecorder is the mock recorder for MockChartInteractor.
+type MockChartInteractorMockRecorder struct {
+	mock *MockChartInteractor
+}
+
+// NewMockChartInteractor creates a new mock instance.
+func NewMockChartInteractor(ctrl *gomock.Controller) *MockChartInteractor {
+	mock := &MockChartInteractor{ctrl: ctrl}
+	mock.recorder = &MockChartInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChartInteractor) EXPECT() *MockChartInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetMusicHistory mocks base method.
+func (m *MockChartInteractor) GetMusicHistory(ctx context.Context, musicId uuid.UUID) ([]*entity.ChartEntryDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMusicHistory", ctx, musicId)
+	ret0, _ := ret[0].([]*entity.ChartEntryDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMusicHistory indicates an expected call of GetMusicHistory.
+func (mr *MockChartInteractorMockRecorder) GetMusicHistory(ctx, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMusicHistory", reflect.TypeOf((*MockChartInteractor)(nil).GetMusicHistory), ctx, musicId)
+}
+
+// GetWeekly mocks base method.
+func (m *MockChartInteractor) GetWeekly(ctx context.Context, week time.Time, hideExplicit bool) ([]*entity.ChartEntryDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWeekly", ctx, week, hideExplicit)
+	ret0, _ := ret[0].([]*entity.ChartEntryDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWeekly indicates an expected call of GetWeekly.
+func (mr *MockChartInteractorMockRecorder) GetWeekly(ctx, week, hideExplicit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeekly", reflect.TypeOf((*MockChartInteractor)(nil).GetWeekly), ctx, week, hideExplicit)
+}
+
+// Snapshot mocks base method.
+func (m *MockChartInteractor) Snapshot(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockChartInteractorMockRecorder) Snapshot(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockChartInteractor)(nil).Snapshot), ctx)
+}
+
+// MockRecommendationInteractor is a mock of RecommendationInteractor interface.
+type MockRecommendationInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationInteractorMockRecorder
+}
+
+// MockRecommendationInteractorMockRecorder is the mock recorder for MockRecommendationInteractor.
+type MockRecommendationInteractorMockRecorder struct {
+	mock *MockRecommendationInteractor
+}
+
+// NewMockRecommendationInteractor creates a new mock instance.
+func NewMockRecommendationInteractor(ctrl *gomock.Controller) *MockRecommendationInteractor {
+	mock := &MockRecommendationInteractor{ctrl: ctrl}
+	mock.recorder = &MockRecommendationInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationInteractor) EXPECT() *MockRecommendationInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetForUser mocks base method.
+func (m *MockRecommendationInteractor) GetForUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.RecommendationDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.RecommendationDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUser indicates an expected call of GetForUser.
+func (mr *MockRecommendationInteractorMockRecorder) GetForUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUser", reflect.TypeOf((*MockRecommendationInteractor)(nil).GetForUser), ctx, userId, limit, offset)
+}
+
+// GetSimilar mocks base method.
+func (m *MockRecommendationInteractor) GetSimilar(ctx context.Context, userId, musicId uuid.UUID, limit, offset int) ([]*entity.SimilarMusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSimilar", ctx, userId, musicId, limit, offset)
+	ret0, _ := ret[0].([]*entity.SimilarMusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimilar indicates an expected call of GetSimilar.
+func (mr *MockRecommendationInteractorMockRecorder) GetSimilar(ctx, userId, musicId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimilar", reflect.TypeOf((*MockRecommendationInteractor)(nil).GetSimilar), ctx, userId, musicId, limit, offset)
+}
+
+// Refresh mocks base method.
+func (m *MockRecommendationInteractor) Refresh(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockRecommendationInteractorMockRecorder) Refresh(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockRecommendationInteractor)(nil).Refresh), ctx)
+}
+
+// MockRatingInteractor is a mock of RatingInteractor interface.
+type MockRatingInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingInteractorMockRecorder
+}
+
+// MockRatingInteractorMockRecorder is the mock recorder for MockRatingInteractor.
+type MockRatingInteractorMockRecorder struct {
+	mock *MockRatingInteractor
+}
+
+// NewMockRatingInteractor creates a new mock instance.
+func NewMockRatingInteractor(ctrl *gomock.Controller) *MockRatingInteractor {
+	mock := &MockRatingInteractor{ctrl: ctrl}
+	mock.recorder = &MockRatingInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingInteractor) EXPECT() *MockRatingInteractorMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockRatingInteractor) Clear(ctx context.Context, userId, musicId uuid.UUID) (*entity.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, userId, musicId)
+	ret0, _ := ret[0].(*entity.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockRatingInteractorMockRecorder) Clear(ctx, userId, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockRatingInteractor)(nil).Clear), ctx, userId, musicId)
+}
+
+// GetLibrary mocks base method.
+func (m *MockRatingInteractor) GetLibrary(ctx context.Context, userId uuid.UUID, sort string) ([]*entity.LibraryMusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLibrary", ctx, userId, sort)
+	ret0, _ := ret[0].([]*entity.LibraryMusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLibrary indicates an expected call of GetLibrary.
+func (mr *MockRatingInteractorMockRecorder) GetLibrary(ctx, userId, sort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLibrary", reflect.TypeOf((*MockRatingInteractor)(nil).GetLibrary), ctx, userId, sort)
+}
+
+// Set mocks base method.
+func (m *MockRatingInteractor) Set(ctx context.Context, userId, musicId uuid.UUID, rating *entity.RatingCreate) (*entity.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, userId, musicId, rating)
+	ret0, _ := ret[0].(*entity.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRatingInteractorMockRecorder) Set(ctx, userId, musicId, rating interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRatingInteractor)(nil).Set), ctx, userId, musicId, rating)
+}
+
+// MockCommentInteractor is a mock of CommentInteractor interface.
+type MockCommentInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentInteractorMockRecorder
+}
+
+// MockCommentInteractorMockRecorder is the mock recorder for MockCommentInteractor.
+type MockCommentInteractorMockRecorder struct {
+	mock *MockCommentInteractor
+}
+
+// NewMockCommentInteractor creates a new mock instance.
+func NewMockCommentInteractor(ctrl *gomock.Controller) *MockCommentInteractor {
+	mock := &MockCommentInteractor{ctrl: ctrl}
+	mock.recorder = &MockCommentInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentInteractor) EXPECT() *MockCommentInteractorMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentInteractor) Create(ctx context.Context, userId, musicId uuid.UUID, comment *entity.CommentCreate) (*entity.CommentDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, musicId, comment)
+	ret0, _ := ret[0].(*entity.CommentDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentInteractorMockRecorder) Create(ctx, userId, musicId, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentInteractor)(nil).Create), ctx, userId, musicId, comment)
+}
+
+// CreateForPlaylist mocks base method.
+func (m *MockCommentInteractor) CreateForPlaylist(ctx context.Context, userId, playlistId uuid.UUID, comment *entity.CommentCreate) (*entity.CommentDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForPlaylist", ctx, userId, playlistId, comment)
+	ret0, _ := ret[0].(*entity.CommentDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateForPlaylist indicates an expected call of CreateForPlaylist.
+func (mr *MockCommentInteractorMockRecorder) CreateForPlaylist(ctx, userId, playlistId, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForPlaylist", reflect.TypeOf((*MockCommentInteractor)(nil).CreateForPlaylist), ctx, userId, playlistId, comment)
+}
+
+// Delete mocks base method.
+func (m *MockCommentInteractor) Delete(ctx context.Context, userId, commentId uuid.UUID, isAdmin bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, commentId, isAdmin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentInteractorMockRecorder) Delete(ctx, userId, commentId, isAdmin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentInteractor)(nil).Delete), ctx, userId, commentId, isAdmin)
+}
+
+// DismissReports mocks base method.
+func (m *MockCommentInteractor) DismissReports(ctx context.Context, moderatorId, commentId uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissReports", ctx, moderatorId, commentId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DismissReports indicates an expected call of DismissReports.
+func (mr *MockCommentInteractorMockRecorder) DismissReports(ctx, moderatorId, commentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissReports", reflect.TypeOf((*MockCommentInteractor)(nil).DismissReports), ctx, moderatorId, commentId)
+}
+
+// GetByMusic mocks base method.
+func (m *MockCommentInteractor) GetByMusic(ctx context.Context, musicId uuid.UUID, filter *entity.CommentFilter) (*entity.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMusic", ctx, musicId, filter)
+	ret0, _ := ret[0].(*entity.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMusic indicates an expected call of GetByMusic.
+func (mr *MockCommentInteractorMockRecorder) GetByMusic(ctx, musicId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMusic", reflect.TypeOf((*MockCommentInteractor)(nil).GetByMusic), ctx, musicId, filter)
+}
+
+// GetByPlaylist mocks base method.
+func (m *MockCommentInteractor) GetByPlaylist(ctx context.Context, playlistId uuid.UUID, filter *entity.CommentFilter) (*entity.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPlaylist", ctx, playlistId, filter)
+	ret0, _ := ret[0].(*entity.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPlaylist indicates an expected call of GetByPlaylist.
+func (mr *MockCommentInteractorMockRecorder) GetByPlaylist(ctx, playlistId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPlaylist", reflect.TypeOf((*MockCommentInteractor)(nil).GetByPlaylist), ctx, playlistId, filter)
+}
+
+// GetModerationQueue mocks base method.
+func (m *MockCommentInteractor) GetModerationQueue(ctx context.Context, limit, offset int) ([]*entity.ModerationItemDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationQueue", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.ModerationItemDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationQueue indicates an expected call of GetModerationQueue.
+func (mr *MockCommentInteractorMockRecorder) GetModerationQueue(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationQueue", reflect.TypeOf((*MockCommentInteractor)(nil).GetModerationQueue), ctx, limit, offset)
+}
+
+// GetReplies mocks base method.
+func (m *MockCommentInteractor) GetReplies(ctx context.Context, commentId uuid.UUID, filter *entity.CommentFilter) (*entity.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplies", ctx, commentId, filter)
+	ret0, _ := ret[0].(*entity.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplies indicates an expected call of GetReplies.
+func (mr *MockCommentInteractorMockRecorder) GetReplies(ctx, commentId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplies", reflect.TypeOf((*MockCommentInteractor)(nil).GetReplies), ctx, commentId, filter)
+}
+
+// Hide mocks base method.
+func (m *MockCommentInteractor) Hide(ctx context.Context, moderatorId, commentId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hide", ctx, moderatorId, commentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Hide indicates an expected call of Hide.
+func (mr *MockCommentInteractorMockRecorder) Hide(ctx, moderatorId, commentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hide", reflect.TypeOf((*MockCommentInteractor)(nil).Hide), ctx, moderatorId, commentId)
+}
+
+// Report mocks base method.
+func (m *MockCommentInteractor) Report(ctx context.Context, userId, commentId uuid.UUID, report *entity.ReportCreate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, userId, commentId, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockCommentInteractorMockRecorder) Report(ctx, userId, commentId, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockCommentInteractor)(nil).Report), ctx, userId, commentId, report)
+}
+
+// Unhide mocks base method.
+func (m *MockCommentInteractor) Unhide(ctx context.Context, moderatorId, commentId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unhide", ctx, moderatorId, commentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unhide indicates an expected call of Unhide.
+func (mr *MockCommentInteractorMockRecorder) Unhide(ctx, moderatorId, commentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unhide", reflect.TypeOf((*MockCommentInteractor)(nil).Unhide), ctx, moderatorId, commentId)
+}
+
+// Update mocks base method.
+func (m *MockCommentInteractor) Update(ctx context.Context, userId, commentId uuid.UUID, comment *entity.CommentUpdate) (*entity.CommentDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userId, commentId, comment)
+	ret0, _ := ret[0].(*entity.CommentDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentInteractorMockRecorder) Update(ctx, userId, commentId, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommentInteractor)(nil).Update), ctx, userId, commentId, comment)
+}
+
+// MockLyricsInteractor is a mock of LyricsInteractor interface.
+type MockLyricsInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockLyricsInteractorMockRecorder
+}
+
+// MockLyricsInteractorMockRecorder is the mock recorder for MockLyricsInteractor.
+type MockLyricsInteractorMockRecorder struct {
+	mock *MockLyricsInteractor
+}
+
+// NewMockLyricsInteractor creates a new mock instance.
+func NewMockLyricsInteractor(ctrl *gomock.Controller) *MockLyricsInteractor {
+	mock := &MockLyricsInteractor{ctrl: ctrl}
+	mock.recorder = &MockLyricsInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLyricsInteractor) EXPECT() *MockLyricsInteractorMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockLyricsInteractor) Delete(ctx context.Context, musicId uuid.UUID, language string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, musicId, language)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLyricsInteractorMockRecorder) Delete(ctx, musicId, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLyricsInteractor)(nil).Delete), ctx, musicId, language)
+}
+
+// Get mocks base method.
+func (m *MockLyricsInteractor) Get(ctx context.Context, musicId uuid.UUID, language string, showUnpublished bool) (*entity.Lyrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, musicId, language, showUnpublished)
+	ret0, _ := ret[0].(*entity.Lyrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLyricsInteractorMockRecorder) Get(ctx, musicId, language, showUnpublished interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLyricsInteractor)(nil).Get), ctx, musicId, language, showUnpublished)
+}
+
+// Set mocks base method.
+func (m *MockLyricsInteractor) Set(ctx context.Context, musicId uuid.UUID, lyrics *entity.LyricsUpload) (*entity.Lyrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, musicId, lyrics)
+	ret0, _ := ret[0].(*entity.Lyrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockLyricsInteractorMockRecorder) Set(ctx, musicId, lyrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockLyricsInteractor)(nil).Set), ctx, musicId, lyrics)
+}
+
+// MockMusicRevisionInteractor is a mock of MusicRevisionInteractor interface.
+type MockMusicRevisionInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockMusicRevisionInteractorMockRecorder
+}
+
+// MockMusicRevisionInteractorMockRecorder is the mock recorder for MockMusicRevisionInteractor.
+type MockMusicRevisionInteractorMockRecorder struct {
+	mock *MockMusicRevisionInteractor
+}
+
+// NewMockMusicRevisionInteractor creates a new mock instance.
+func NewMockMusicRevisionInteractor(ctrl *gomock.Controller) *MockMusicRevisionInteractor {
+	mock := &MockMusicRevisionInteractor{ctrl: ctrl}
+	mock.recorder = &MockMusicRevisionInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMusicRevisionInteractor) EXPECT() *MockMusicRevisionInteractorMockRecorder {
+	return m.recorder
+}
+
+// Diff mocks base method.
+func (m *MockMusicRevisionInteractor) Diff(ctx context.Context, musicId uuid.UUID, from, to int) (*entity.MusicRevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", ctx, musicId, from, to)
+	ret0, _ := ret[0].(*entity.MusicRevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockMusicRevisionInteractorMockRecorder) Diff(ctx, musicId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockMusicRevisionInteractor)(nil).Diff), ctx, musicId, from, to)
+}
+
+// Get mocks base method.
+func (m *MockMusicRevisionInteractor) Get(ctx context.Context, musicId uuid.UUID, version int) (*entity.MusicRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, musicId, version)
+	ret0, _ := ret[0].(*entity.MusicRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMusicRevisionInteractorMockRecorder) Get(ctx, musicId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMusicRevisionInteractor)(nil).Get), ctx, musicId, version)
+}
+
+// GetByMusic mocks base method.
+func (m *MockMusicRevisionInteractor) GetByMusic(ctx context.Context, musicId uuid.UUID, limit, offset int) ([]*entity.MusicRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMusic", ctx, musicId, limit, offset)
+	ret0, _ := ret[0].([]*entity.MusicRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMusic indicates an expected call of GetByMusic.
+func (mr *MockMusicRevisionInteractorMockRecorder) GetByMusic(ctx, musicId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMusic", reflect.TypeOf((*MockMusicRevisionInteractor)(nil).GetByMusic), ctx, musicId, limit, offset)
+}
+
+// Revert mocks base method.
+func (m *MockMusicRevisionInteractor) Revert(ctx context.Context, userId, musicId uuid.UUID, version int) (*entity.MusicRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, userId, musicId, version)
+	ret0, _ := ret[0].(*entity.MusicRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockMusicRevisionInteractorMockRecorder) Revert(ctx, userId, musicId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockMusicRevisionInteractor)(nil).Revert), ctx, userId, musicId, version)
+}
+
+// MockTrashInteractor is a mock of TrashInteractor interface.
+type MockTrashInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashInteractorMockRecorder
+}
+
+// MockTrashInteractorMockRecorder is the mock recorder for MockTrashInteractor.
+type MockTrashInteractorMockRecorder struct {
+	mock *MockTrashInteractor
+}
+
+// NewMockTrashInteractor creates a new mock instance.
+func NewMockTrashInteractor(ctrl *gomock.Controller) *MockTrashInteractor {
+	mock := &MockTrashInteractor{ctrl: ctrl}
+	mock.recorder = &MockTrashInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashInteractor) EXPECT() *MockTrashInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetMusic mocks base method.
+func (m *MockTrashInteractor) GetMusic(ctx context.Context, limit, offset int) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMusic", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMusic indicates an expected call of GetMusic.
+func (mr *MockTrashInteractorMockRecorder) GetMusic(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMusic", reflect.TypeOf((*MockTrashInteractor)(nil).GetMusic), ctx, limit, offset)
+}
+
+// GetUsers mocks base method.
+func (m *MockTrashInteractor) GetUsers(ctx context.Context, limit, offset int) ([]*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockTrashInteractorMockRecorder) GetUsers(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockTrashInteractor)(nil).GetUsers), ctx, limit, offset)
+}
+
+// Purge mocks base method.
+func (m *MockTrashInteractor) Purge(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTrashInteractorMockRecorder) Purge(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTrashInteractor)(nil).Purge), ctx)
+}
+
+// RestoreMusic mocks base method.
+func (m *MockTrashInteractor) RestoreMusic(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMusic", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMusic indicates an expected call of RestoreMusic.
+func (mr *MockTrashInteractorMockRecorder) RestoreMusic(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMusic", reflect.TypeOf((*MockTrashInteractor)(nil).RestoreMusic), ctx, id)
+}
+
+// RestoreUser mocks base method.
+func (m *MockTrashInteractor) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockTrashInteractorMockRecorder) RestoreUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockTrashInteractor)(nil).RestoreUser), ctx, id)
+}
+
+// MockReleaseInteractor is a mock of ReleaseInteractor interface.
+type MockReleaseInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockReleaseInteractorMockRecorder
+}
+
+// MockReleaseInteractorMockRecorder is the mock recorder for MockReleaseInteractor.
+type MockReleaseInteractorMockRecorder struct {
+	mock *MockReleaseInteractor
+}
+
+// NewMockReleaseInteractor creates a new mock instance.
+func NewMockReleaseInteractor(ctrl *gomock.Controller) *MockReleaseInteractor {
+	mock := &MockReleaseInteractor{ctrl: ctrl}
+	mock.recorder = &MockReleaseInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReleaseInteractor) EXPECT() *MockReleaseInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetScheduled mocks base method.
+func (m *MockReleaseInteractor) GetScheduled(ctx context.Context, limit, offset int) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduled", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduled indicates an expected call of GetScheduled.
+func (mr *MockReleaseInteractorMockRecorder) GetScheduled(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduled", reflect.TypeOf((*MockReleaseInteractor)(nil).GetScheduled), ctx, limit, offset)
+}
+
+// Publish mocks base method.
+func (m *MockReleaseInteractor) Publish(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockReleaseInteractorMockRecorder) Publish(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockReleaseInteractor)(nil).Publish), ctx)
+}
+
+// SetSchedule mocks base method.
+func (m *MockReleaseInteractor) SetSchedule(ctx context.Context, id uuid.UUID, schedule *entity.MusicSchedule) (*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchedule", ctx, id, schedule)
+	ret0, _ := ret[0].(*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSchedule indicates an expected call of SetSchedule.
+func (mr *MockReleaseInteractorMockRecorder) SetSchedule(ctx, id, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedule", reflect.TypeOf((*MockReleaseInteractor)(nil).SetSchedule), ctx, id, schedule)
+}
+
+// MockArtistInteractor is a mock of ArtistInteractor interface.
+type MockArtistInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtistInteractorMockRecorder
+}
+
+// MockArtistInteractorMockRecorder is the mock recorder for MockArtistInteractor.
+type MockArtistInteractorMockRecorder struct {
+	mock *MockArtistInteractor
+}
+
+// NewMockArtistInteractor creates a new mock instance.
+func NewMockArtistInteractor(ctrl *gomock.Controller) *MockArtistInteractor {
+	mock := &MockArtistInteractor{ctrl: ctrl}
+	mock.recorder = &MockArtistInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtistInteractor) EXPECT() *MockArtistInteractorMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockArtistInteractor) Create(ctx context.Context, artist *entity.ArtistCreate) (*entity.ArtistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, artist)
+	ret0, _ := ret[0].(*entity.ArtistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArtistInteractorMockRecorder) Create(ctx, artist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArtistInteractor)(nil).Create), ctx, artist)
+}
+
+// Get mocks base method.
+func (m *MockArtistInteractor) Get(ctx context.Context, id uuid.UUID) (*entity.ArtistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.ArtistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockArtistInteractorMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArtistInteractor)(nil).Get), ctx, id)
+}
+
+// SetMusicArtist mocks base method.
+func (m *MockArtistInteractor) SetMusicArtist(ctx context.Context, musicId uuid.UUID, artist *entity.MusicArtist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMusicArtist", ctx, musicId, artist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMusicArtist indicates an expected call of SetMusicArtist.
+func (mr *MockArtistInteractorMockRecorder) SetMusicArtist(ctx, musicId, artist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMusicArtist", reflect.TypeOf((*MockArtistInteractor)(nil).SetMusicArtist), ctx, musicId, artist)
+}
+
+// MockPlaylistInteractor is a mock of PlaylistInteractor interface.
+type MockPlaylistInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaylistInteractorMockRecorder
+}
+
+// MockPlaylistInteractorMockRecorder is the mock recorder for MockPlaylistInteractor.
+type MockPlaylistInteractorMockRecorder struct {
+	mock *MockPlaylistInteractor
+}
+
+// NewMockPlaylistInteractor creates a new mock instance.
+func NewMockPlaylistInteractor(ctrl *gomock.Controller) *MockPlaylistInteractor {
+	mock := &MockPlaylistInteractor{ctrl: ctrl}
+	mock.recorder = &MockPlaylistInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaylistInteractor) EXPECT() *MockPlaylistInteractorMockRecorder {
+	return m.recorder
+}
+
+// AddTrack mocks base method.
+func (m *MockPlaylistInteractor) AddTrack(ctx context.Context, userId, id, musicId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTrack", ctx, userId, id, musicId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTrack indicates an expected call of AddTrack.
+func (mr *MockPlaylistInteractorMockRecorder) AddTrack(ctx, userId, id, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrack", reflect.TypeOf((*MockPlaylistInteractor)(nil).AddTrack), ctx, userId, id, musicId)
+}
+
+// Create mocks base method.
+func (m *MockPlaylistInteractor) Create(ctx context.Context, userId uuid.UUID, playlist *entity.PlaylistCreate) (*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, playlist)
+	ret0, _ := ret[0].(*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPlaylistInteractorMockRecorder) Create(ctx, userId, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPlaylistInteractor)(nil).Create), ctx, userId, playlist)
+}
+
+// Delete mocks base method.
+func (m *MockPlaylistInteractor) Delete(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPlaylistInteractorMockRecorder) Delete(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPlaylistInteractor)(nil).Delete), ctx, userId, id)
+}
+
+// Get mocks base method.
+func (m *MockPlaylistInteractor) Get(ctx context.Context, userId, id uuid.UUID) (*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userId, id)
+	ret0, _ := ret[0].(*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPlaylistInteractorMockRecorder) Get(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPlaylistInteractor)(nil).Get), ctx, userId, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockPlaylistInteractor) GetByUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockPlaylistInteractorMockRecorder) GetByUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockPlaylistInteractor)(nil).GetByUser), ctx, userId, limit, offset)
+}
+
+// GetTracks mocks base method.
+func (m *MockPlaylistInteractor) GetTracks(ctx context.Context, userId, id uuid.UUID) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracks", ctx, userId, id)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTracks indicates an expected call of GetTracks.
+func (mr *MockPlaylistInteractorMockRecorder) GetTracks(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracks", reflect.TypeOf((*MockPlaylistInteractor)(nil).GetTracks), ctx, userId, id)
+}
+
+// RemoveTrack mocks base method.
+func (m *MockPlaylistInteractor) RemoveTrack(ctx context.Context, userId, id, musicId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTrack", ctx, userId, id, musicId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTrack indicates an expected call of RemoveTrack.
+func (mr *MockPlaylistInteractorMockRecorder) RemoveTrack(ctx, userId, id, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrack", reflect.TypeOf((*MockPlaylistInteractor)(nil).RemoveTrack), ctx, userId, id, musicId)
+}
+
+// Update mocks base method.
+func (m *MockPlaylistInteractor) Update(ctx context.Context, userId, id uuid.UUID, playlist *entity.PlaylistCreate) (*entity.PlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userId, id, playlist)
+	ret0, _ := ret[0].(*entity.PlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPlaylistInteractorMockRecorder) Update(ctx, userId, id, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPlaylistInteractor)(nil).Update), ctx, userId, id, playlist)
+}
+
+// MockFollowInteractor is a mock of FollowInteractor interface.
+type MockFollowInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockFollowInteractorMockRecorder
+}
+
+// MockFollowInteractorMockRecorder is the mock recorder for MockFollowInteractor.
+type MockFollowInteractorMockRecorder struct {
+	mock *MockFollowInteractor
+}
+
+// NewMockFollowInteractor creates a new mock instance.
+func NewMockFollowInteractor(ctrl *gomock.Controller) *MockFollowInteractor {
+	mock := &MockFollowInteractor{ctrl: ctrl}
+	mock.recorder = &MockFollowInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFollowInteractor) EXPECT() *MockFollowInteractorMockRecorder {
+	return m.recorder
+}
+
+// FollowArtist mocks base method.
+func (m *MockFollowInteractor) FollowArtist(ctx context.Context, userId, artistId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowArtist", ctx, userId, artistId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowArtist indicates an expected call of FollowArtist.
+func (mr *MockFollowInteractorMockRecorder) FollowArtist(ctx, userId, artistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowArtist", reflect.TypeOf((*MockFollowInteractor)(nil).FollowArtist), ctx, userId, artistId)
+}
+
+// FollowUser mocks base method.
+func (m *MockFollowInteractor) FollowUser(ctx context.Context, followerId, followeeId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowUser", ctx, followerId, followeeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowUser indicates an expected call of FollowUser.
+func (mr *MockFollowInteractorMockRecorder) FollowUser(ctx, followerId, followeeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockFollowInteractor)(nil).FollowUser), ctx, followerId, followeeId)
+}
+
+// GetFeed mocks base method.
+func (m *MockFollowInteractor) GetFeed(ctx context.Context, userId uuid.UUID, filter *entity.FeedFilter) (*entity.FeedPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, userId, filter)
+	ret0, _ := ret[0].(*entity.FeedPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockFollowInteractorMockRecorder) GetFeed(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockFollowInteractor)(nil).GetFeed), ctx, userId, filter)
+}
+
+// GetFollowing mocks base method.
+func (m *MockFollowInteractor) GetFollowing(ctx context.Context, userId uuid.UUID) (*entity.Following, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", ctx, userId)
+	ret0, _ := ret[0].(*entity.Following)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *MockFollowInteractorMockRecorder) GetFollowing(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*MockFollowInteractor)(nil).GetFollowing), ctx, userId)
+}
+
+// SetPrivacy mocks base method.
+func (m *MockFollowInteractor) SetPrivacy(ctx context.Context, userId uuid.UUID, privacy *entity.FeedPrivacy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrivacy", ctx, userId, privacy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrivacy indicates an expected call of SetPrivacy.
+func (mr *MockFollowInteractorMockRecorder) SetPrivacy(ctx, userId, privacy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrivacy", reflect.TypeOf((*MockFollowInteractor)(nil).SetPrivacy), ctx, userId, privacy)
+}
+
+// UnfollowArtist mocks base method.
+func (m *MockFollowInteractor) UnfollowArtist(ctx context.Context, userId, artistId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowArtist", ctx, userId, artistId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowArtist indicates an expected call of UnfollowArtist.
+func (mr *MockFollowInteractorMockRecorder) UnfollowArtist(ctx, userId, artistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowArtist", reflect.TypeOf((*MockFollowInteractor)(nil).UnfollowArtist), ctx, userId, artistId)
+}
+
+// UnfollowUser mocks base method.
+func (m *MockFollowInteractor) UnfollowUser(ctx context.Context, followerId, followeeId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowUser", ctx, followerId, followeeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowUser indicates an expected call of UnfollowUser.
+func (mr *MockFollowInteractorMockRecorder) UnfollowUser(ctx, followerId, followeeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowUser", reflect.TypeOf((*MockFollowInteractor)(nil).UnfollowUser), ctx, followerId, followeeId)
+}
+
+// MockNotificationInteractor is a mock of NotificationInteractor interface.
+type MockNotificationInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationInteractorMockRecorder
+}
+
+// MockNotificationInteractorMockRecorder is the mock recorder for MockNotificationInteractor.
+type MockNotificationInteractorMockRecorder struct {
+	mock *MockNotificationInteractor
+}
+
+// NewMockNotificationInteractor creates a new mock instance.
+func NewMockNotificationInteractor(ctrl *gomock.Controller) *MockNotificationInteractor {
+	mock := &MockNotificationInteractor{ctrl: ctrl}
+	mock.recorder = &MockNotificationInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationInteractor) EXPECT() *MockNotificationInteractorMockRecorder {
+	return m.recorder
+}
+
+// Deliver mocks base method.
+func (m *MockNotificationInteractor) Deliver(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockNotificationInteractorMockRecorder) Deliver(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockNotificationInteractor)(nil).Deliver), ctx)
+}
+
+// GetByUser mocks base method.
+func (m *MockNotificationInteractor) GetByUser(ctx context.Context, userId uuid.UUID, filter *entity.NotificationFilter) (*entity.NotificationPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, filter)
+	ret0, _ := ret[0].(*entity.NotificationPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockNotificationInteractorMockRecorder) GetByUser(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockNotificationInteractor)(nil).GetByUser), ctx, userId, filter)
+}
+
+// GetSettings mocks base method.
+func (m *MockNotificationInteractor) GetSettings(ctx context.Context, userId uuid.UUID) (*entity.NotificationSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, userId)
+	ret0, _ := ret[0].(*entity.NotificationSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockNotificationInteractorMockRecorder) GetSettings(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockNotificationInteractor)(nil).GetSettings), ctx, userId)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotificationInteractor) MarkAllRead(ctx context.Context, userId uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx, userId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationInteractorMockRecorder) MarkAllRead(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotificationInteractor)(nil).MarkAllRead), ctx, userId)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationInteractor) MarkRead(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationInteractorMockRecorder) MarkRead(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationInteractor)(nil).MarkRead), ctx, userId, id)
+}
+
+// SetSettings mocks base method.
+func (m *MockNotificationInteractor) SetSettings(ctx context.Context, userId uuid.UUID, settings *entity.NotificationSettings) (*entity.NotificationSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSettings", ctx, userId, settings)
+	ret0, _ := ret[0].(*entity.NotificationSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSettings indicates an expected call of SetSettings.
+func (mr *MockNotificationInteractorMockRecorder) SetSettings(ctx, userId, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSettings", reflect.TypeOf((*MockNotificationInteractor)(nil).SetSettings), ctx, userId, settings)
+}
+
+// MockShareInteractor is a mock of ShareInteractor interface.
+type MockShareInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareInteractorMockRecorder
+}
+
+// MockShareInteractorMockRecorder is the mock recorder for MockShareInteractor.
+type MockShareInteractorMockRecorder struct {
+	mock *MockShareInteractor
+}
+
+// NewMockShareInteractor creates a new mock instance.
+func NewMockShareInteractor(ctrl *gomock.Controller) *MockShareInteractor {
+	mock := &MockShareInteractor{ctrl: ctrl}
+	mock.recorder = &MockShareInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareInteractor) EXPECT() *MockShareInteractorMockRecorder {
+	return m.recorder
+}
+
+// CreateForMusic mocks base method.
+func (m *MockShareInteractor) CreateForMusic(ctx context.Context, userId, musicId uuid.UUID, share *entity.ShareLinkCreate) (*entity.ShareLinkCreated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForMusic", ctx, userId, musicId, share)
+	ret0, _ := ret[0].(*entity.ShareLinkCreated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateForMusic indicates an expected call of CreateForMusic.
+func (mr *MockShareInteractorMockRecorder) CreateForMusic(ctx, userId, musicId, share interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForMusic", reflect.TypeOf((*MockShareInteractor)(nil).CreateForMusic), ctx, userId, musicId, share)
+}
+
+// CreateForPlaylist mocks base method.
+func (m *MockShareInteractor) CreateForPlaylist(ctx context.Context, userId, playlistId uuid.UUID, share *entity.ShareLinkCreate) (*entity.ShareLinkCreated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForPlaylist", ctx, userId, playlistId, share)
+	ret0, _ := ret[0].(*entity.ShareLinkCreated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateForPlaylist indicates an expected call of CreateForPlaylist.
+func (mr *MockShareInteractorMockRecorder) CreateForPlaylist(ctx, userId, playlistId, share interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForPlaylist", reflect.TypeOf((*MockShareInteractor)(nil).CreateForPlaylist), ctx, userId, playlistId, share)
+}
+
+// GetByUser mocks base method.
+func (m *MockShareInteractor) GetByUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.ShareLinkDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.ShareLinkDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockShareInteractorMockRecorder) GetByUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockShareInteractor)(nil).GetByUser), ctx, userId, limit, offset)
+}
+
+// GetContent mocks base method.
+func (m *MockShareInteractor) GetContent(ctx context.Context, link *entity.ShareLinkDB) (*entity.SharedContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContent", ctx, link)
+	ret0, _ := ret[0].(*entity.SharedContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContent indicates an expected call of GetContent.
+func (mr *MockShareInteractorMockRecorder) GetContent(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContent", reflect.TypeOf((*MockShareInteractor)(nil).GetContent), ctx, link)
+}
+
+// RecordAccess mocks base method.
+func (m *MockShareInteractor) RecordAccess(ctx context.Context, access *entity.ShareAccessDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAccess", ctx, access)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAccess indicates an expected call of RecordAccess.
+func (mr *MockShareInteractorMockRecorder) RecordAccess(ctx, access interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAccess", reflect.TypeOf((*MockShareInteractor)(nil).RecordAccess), ctx, access)
+}
+
+// Resolve mocks base method.
+func (m *MockShareInteractor) Resolve(ctx context.Context, token string) (*entity.ShareLinkDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, token)
+	ret0, _ := ret[0].(*entity.ShareLinkDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockShareInteractorMockRecorder) Resolve(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockShareInteractor)(nil).Resolve), ctx, token)
+}
+
+// Revoke mocks base method.
+func (m *MockShareInteractor) Revoke(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockShareInteractorMockRecorder) Revoke(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockShareInteractor)(nil).Revoke), ctx, userId, id)
+}
+
+// Stream mocks base method.
+func (m *MockShareInteractor) Stream(ctx context.Context, link *entity.ShareLinkDB, musicId *uuid.UUID) (*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", ctx, link, musicId)
+	ret0, _ := ret[0].(*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockShareInteractorMockRecorder) Stream(ctx, link, musicId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockShareInteractor)(nil).Stream), ctx, link, musicId)
+}
+
+// MockStatsInteractor is a mock of StatsInteractor interface.
+type MockStatsInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsInteractorMockRecorder
+}
+
+// MockStatsInteractorMockRecorder is the mock recorder for MockStatsInteractor.
+type MockStatsInteractorMockRecorder struct {
+	mock *MockStatsInteractor
+}
+
+// NewMockStatsInteractor creates a new mock instance.
+func NewMockStatsInteractor(ctrl *gomock.Controller) *MockStatsInteractor {
+	mock := &MockStatsInteractor{ctrl: ctrl}
+	mock.recorder = &MockStatsInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsInteractor) EXPECT() *MockStatsInteractorMockRecorder {
+	return m.recorder
+}
+
+// GenerateYearReviews mocks base method.
+func (m *MockStatsInteractor) GenerateYearReviews(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateYearReviews", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateYearReviews indicates an expected call of GenerateYearReviews.
+func (mr *MockStatsInteractorMockRecorder) GenerateYearReviews(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateYearReviews", reflect.TypeOf((*MockStatsInteractor)(nil).GenerateYearReviews), ctx)
+}
+
+// GetStats mocks base method.
+func (m *MockStatsInteractor) GetStats(ctx context.Context, userId uuid.UUID, filter *entity.StatsFilter) (*entity.ListeningStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, userId, filter)
+	ret0, _ := ret[0].(*entity.ListeningStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockStatsInteractorMockRecorder) GetStats(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockStatsInteractor)(nil).GetStats), ctx, userId, filter)
+}
+
+// GetYearReview mocks base method.
+func (m *MockStatsInteractor) GetYearReview(ctx context.Context, userId uuid.UUID, year int) (*entity.YearReviewDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetYearReview", ctx, userId, year)
+	ret0, _ := ret[0].(*entity.YearReviewDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetYearReview indicates an expected call of GetYearReview.
+func (mr *MockStatsInteractorMockRecorder) GetYearReview(ctx, userId, year interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYearReview", reflect.TypeOf((*MockStatsInteractor)(nil).GetYearReview), ctx, userId, year)
+}
+
+// MockExportInteractor is a mock of ExportInteractor interface.
+type MockExportInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportInteractorMockRecorder
+}
+
+// MockExportInteractorMockRecorder is the mock recorder for MockExportInteractor.
+type MockExportInteractorMockRecorder struct {
+	mock *MockExportInteractor
+}
+
+// NewMockExportInteractor creates a new mock instance.
+func NewMockExportInteractor(ctrl *gomock.Controller) *MockExportInteractor {
+	mock := &MockExportInteractor{ctrl: ctrl}
+	mock.recorder = &MockExportInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportInteractor) EXPECT() *MockExportInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetDownload mocks base method.
+func (m *MockExportInteractor) GetDownload(ctx context.Context, userId, id uuid.UUID) (*entity.ExportJobDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDownload", ctx, userId, id)
+	ret0, _ := ret[0].(*entity.ExportJobDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDownload indicates an expected call of GetDownload.
+func (mr *MockExportInteractorMockRecorder) GetDownload(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDownload", reflect.TypeOf((*MockExportInteractor)(nil).GetDownload), ctx, userId, id)
+}
+
+// GetJob mocks base method.
+func (m *MockExportInteractor) GetJob(ctx context.Context, userId, id uuid.UUID) (*entity.ExportJobDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, userId, id)
+	ret0, _ := ret[0].(*entity.ExportJobDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockExportInteractorMockRecorder) GetJob(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockExportInteractor)(nil).GetJob), ctx, userId, id)
+}
+
+// ProcessJobs mocks base method.
+func (m *MockExportInteractor) ProcessJobs(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessJobs", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessJobs indicates an expected call of ProcessJobs.
+func (mr *MockExportInteractorMockRecorder) ProcessJobs(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessJobs", reflect.TypeOf((*MockExportInteractor)(nil).ProcessJobs), ctx)
+}
+
+// Start mocks base method.
+func (m *MockExportInteractor) Start(ctx context.Context, userId uuid.UUID, request *entity.ExportRequest) (*entity.ExportJobDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, userId, request)
+	ret0, _ := ret[0].(*entity.ExportJobDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockExportInteractorMockRecorder) Start(ctx, userId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockExportInteractor)(nil).Start), ctx, userId, request)
+}
+
+// Write mocks base method.
+func (m *MockExportInteractor) Write(ctx context.Context, userId uuid.UUID, request *entity.ExportRequest, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", ctx, userId, request, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockExportInteractorMockRecorder) Write(ctx, userId, request, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockExportInteractor)(nil).Write), ctx, userId, request, w)
+}
+
+// MockPlaylistImportInteractor is a mock of PlaylistImportInteractor interface.
+type MockPlaylistImportInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaylistImportInteractorMockRecorder
+}
+
+// MockPlaylistImportInteractorMockRecorder is the mock recorder for MockPlaylistImportInteractor.
+type MockPlaylistImportInteractorMockRecorder struct {
+	mock *MockPlaylistImportInteractor
+}
+
+// NewMockPlaylistImportInteractor creates a new mock instance.
+func NewMockPlaylistImportInteractor(ctrl *gomock.Controller) *MockPlaylistImportInteractor {
+	mock := &MockPlaylistImportInteractor{ctrl: ctrl}
+	mock.recorder = &MockPlaylistImportInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaylistImportInteractor) EXPECT() *MockPlaylistImportInteractorMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockPlaylistImportInteractor) Import(ctx context.Context, userId uuid.UUID, request *entity.PlaylistImport, r io.Reader) (*entity.PlaylistImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, userId, request, r)
+	ret0, _ := ret[0].(*entity.PlaylistImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockPlaylistImportInteractorMockRecorder) Import(ctx, userId, request, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockPlaylistImportInteractor)(nil).Import), ctx, userId, request, r)
+}
+
+// MockSmartPlaylistInteractor is a mock of SmartPlaylistInteractor interface.
+type MockSmartPlaylistInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmartPlaylistInteractorMockRecorder
+}
+
+// MockSmartPlaylistInteractorMockRecorder is the mock recorder for MockSmartPlaylistInteractor.
+type MockSmartPlaylistInteractorMockRecorder struct {
+	mock *MockSmartPlaylistInteractor
+}
+
+// NewMockSmartPlaylistInteractor creates a new mock instance.
+func NewMockSmartPlaylistInteractor(ctrl *gomock.Controller) *MockSmartPlaylistInteractor {
+	mock := &MockSmartPlaylistInteractor{ctrl: ctrl}
+	mock.recorder = &MockSmartPlaylistInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmartPlaylistInteractor) EXPECT() *MockSmartPlaylistInteractorMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSmartPlaylistInteractor) Create(ctx context.Context, userId uuid.UUID, playlist *entity.SmartPlaylistCreate) (*entity.SmartPlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, playlist)
+	ret0, _ := ret[0].(*entity.SmartPlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSmartPlaylistInteractorMockRecorder) Create(ctx, userId, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSmartPlaylistInteractor)(nil).Create), ctx, userId, playlist)
+}
+
+// Delete mocks base method.
+func (m *MockSmartPlaylistInteractor) Delete(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSmartPlaylistInteractorMockRecorder) Delete(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSmartPlaylistInteractor)(nil).Delete), ctx, userId, id)
+}
+
+// Get mocks base method.
+func (m *MockSmartPlaylistInteractor) Get(ctx context.Context, userId, id uuid.UUID) (*entity.SmartPlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userId, id)
+	ret0, _ := ret[0].(*entity.SmartPlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSmartPlaylistInteractorMockRecorder) Get(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSmartPlaylistInteractor)(nil).Get), ctx, userId, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockSmartPlaylistInteractor) GetByUser(ctx context.Context, userId uuid.UUID, limit, offset int) ([]*entity.SmartPlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.SmartPlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockSmartPlaylistInteractorMockRecorder) GetByUser(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockSmartPlaylistInteractor)(nil).GetByUser), ctx, userId, limit, offset)
+}
+
+// GetTracks mocks base method.
+func (m *MockSmartPlaylistInteractor) GetTracks(ctx context.Context, userId, id uuid.UUID) ([]*entity.MusicDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracks", ctx, userId, id)
+	ret0, _ := ret[0].([]*entity.MusicDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTracks indicates an expected call of GetTracks.
+func (mr *MockSmartPlaylistInteractorMockRecorder) GetTracks(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracks", reflect.TypeOf((*MockSmartPlaylistInteractor)(nil).GetTracks), ctx, userId, id)
+}
+
+// Update mocks base method.
+func (m *MockSmartPlaylistInteractor) Update(ctx context.Context, userId, id uuid.UUID, playlist *entity.SmartPlaylistCreate) (*entity.SmartPlaylistDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userId, id, playlist)
+	ret0, _ := ret[0].(*entity.SmartPlaylistDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSmartPlaylistInteractorMockRecorder) Update(ctx, userId, id, playlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSmartPlaylistInteractor)(nil).Update), ctx, userId, id, playlist)
+}
+
+// MockSyncInteractor is a mock of SyncInteractor interface.
+type MockSyncInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncInteractorMockRecorder
+}
+
+// MockSyncInteractorMockRecorder is the mock recorder for MockSyncInteractor.
+type MockSyncInteractorMockRecorder struct {
+	mock *MockSyncInteractor
+}
+
+// NewMockSyncInteractor creates a new mock instance.
+func NewMockSyncInteractor(ctrl *gomock.Controller) *MockSyncInteractor {
+	mock := &MockSyncInteractor{ctrl: ctrl}
+	mock.recorder = &MockSyncInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncInteractor) EXPECT() *MockSyncInteractorMockRecorder {
+	return m.recorder
+}
+
+// CleanupChanges mocks base method.
+func (m *MockSyncInteractor) CleanupChanges(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupChanges", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupChanges indicates an expected call of CleanupChanges.
+func (mr *MockSyncInteractorMockRecorder) CleanupChanges(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupChanges", reflect.TypeOf((*MockSyncInteractor)(nil).CleanupChanges), ctx)
+}
+
+// Sync mocks base method.
+func (m *MockSyncInteractor) Sync(ctx context.Context, userId uuid.UUID, token string) (*entity.SyncDelta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx, userId, token)
+	ret0, _ := ret[0].(*entity.SyncDelta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockSyncInteractorMockRecorder) Sync(ctx, userId, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockSyncInteractor)(nil).Sync), ctx, userId, token)
+}
+
+// MockSessionInteractor is a mock of SessionInteractor interface.
+type MockSessionInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionInteractorMockRecorder
+}
+
+// MockSessionInteractorMockRecorder is the mock recorder for MockSessionInteractor.
+type MockSessionInteractorMockRecorder struct {
+	mock *MockSessionInteractor
+}
+
+// NewMockSessionInteractor creates a new mock instance.
+func NewMockSessionInteractor(ctrl *gomock.Controller) *MockSessionInteractor {
+	mock := &MockSessionInteractor{ctrl: ctrl}
+	mock.recorder = &MockSessionInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionInteractor) EXPECT() *MockSessionInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetByUser mocks base method.
+func (m *MockSessionInteractor) GetByUser(ctx context.Context, userId uuid.UUID) ([]*entity.SessionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId)
+	ret0, _ := ret[0].([]*entity.SessionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockSessionInteractorMockRecorder) GetByUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockSessionInteractor)(nil).GetByUser), ctx, userId)
+}
+
+// IsRevoked mocks base method.
+func (m *MockSessionInteractor) IsRevoked(ctx context.Context, userId, sessionId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, userId, sessionId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockSessionInteractorMockRecorder) IsRevoked(ctx, userId, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockSessionInteractor)(nil).IsRevoked), ctx, userId, sessionId)
+}
+
+// Revoke mocks base method.
+func (m *MockSessionInteractor) Revoke(ctx context.Context, userId, sessionId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userId, sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionInteractorMockRecorder) Revoke(ctx, userId, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSessionInteractor)(nil).Revoke), ctx, userId, sessionId)
+}
+
+// RevokeAll mocks base method.
+func (m *MockSessionInteractor) RevokeAll(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockSessionInteractorMockRecorder) RevokeAll(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockSessionInteractor)(nil).RevokeAll), ctx, userId)
+}
+
+// MockSigningKeyInteractor is a mock of SigningKeyInteractor interface.
+type MockSigningKeyInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockSigningKeyInteractorMockRecorder
+}
+
+// MockSigningKeyInteractorMockRecorder is the mock recorder for MockSigningKeyInteractor.
+type MockSigningKeyInteractorMockRecorder struct {
+	mock *MockSigningKeyInteractor
+}
+
+// NewMockSigningKeyInteractor creates a new mock instance.
+func NewMockSigningKeyInteractor(ctrl *gomock.Controller) *MockSigningKeyInteractor {
+	mock := &MockSigningKeyInteractor{ctrl: ctrl}
+	mock.recorder = &MockSigningKeyInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigningKeyInteractor) EXPECT() *MockSigningKeyInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetKeys mocks base method.
+func (m *MockSigningKeyInteractor) GetKeys(ctx context.Context) ([]*entity.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeys", ctx)
+	ret0, _ := ret[0].([]*entity.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeys indicates an expected call of GetKeys.
+func (mr *MockSigningKeyInteractorMockRecorder) GetKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeys", reflect.TypeOf((*MockSigningKeyInteractor)(nil).GetKeys), ctx)
+}
+
+// ParseAccessToken mocks base method.
+func (m *MockSigningKeyInteractor) ParseAccessToken(ctx context.Context, tokenString string) (uuid.UUID, uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseAccessToken", ctx, tokenString)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(uuid.UUID)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ParseAccessToken indicates an expected call of ParseAccessToken.
+func (mr *MockSigningKeyInteractorMockRecorder) ParseAccessToken(ctx, tokenString interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseAccessToken", reflect.TypeOf((*MockSigningKeyInteractor)(nil).ParseAccessToken), ctx, tokenString)
+}
+
+// Rotate mocks base method.
+func (m *MockSigningKeyInteractor) Rotate(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockSigningKeyInteractorMockRecorder) Rotate(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockSigningKeyInteractor)(nil).Rotate), ctx)
+}
+
+// MockIdentityInteractor is a mock of IdentityInteractor interface.
+type MockIdentityInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityInteractorMockRecorder
+}
+
+// MockIdentityInteractorMockRecorder is the mock recorder for MockIdentityInteractor.
+type MockIdentityInteractorMockRecorder struct {
+	mock *MockIdentityInteractor
+}
+
+// NewMockIdentityInteractor creates a new mock instance.
+func NewMockIdentityInteractor(ctrl *gomock.Controller) *MockIdentityInteractor {
+	mock := &MockIdentityInteractor{ctrl: ctrl}
+	mock.recorder = &MockIdentityInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityInteractor) EXPECT() *MockIdentityInteractorMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockIdentityInteractor) Begin(ctx context.Context, userId *uuid.UUID) (*entity.OIDCAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx, userId)
+	ret0, _ := ret[0].(*entity.OIDCAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIdentityInteractorMockRecorder) Begin(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdentityInteractor)(nil).Begin), ctx, userId)
+}
+
+// CleanupLogins mocks base method.
+func (m *MockIdentityInteractor) CleanupLogins(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupLogins", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupLogins indicates an expected call of CleanupLogins.
+func (mr *MockIdentityInteractorMockRecorder) CleanupLogins(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupLogins", reflect.TypeOf((*MockIdentityInteractor)(nil).CleanupLogins), ctx)
+}
+
+// Complete mocks base method.
+func (m *MockIdentityInteractor) Complete(ctx context.Context, state, code string, userId *uuid.UUID) (*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, state, code, userId)
+	ret0, _ := ret[0].(*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdentityInteractorMockRecorder) Complete(ctx, state, code, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdentityInteractor)(nil).Complete), ctx, state, code, userId)
+}
+
+// GetByUser mocks base method.
+func (m *MockIdentityInteractor) GetByUser(ctx context.Context, userId uuid.UUID) ([]*entity.UserIdentityDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId)
+	ret0, _ := ret[0].([]*entity.UserIdentityDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockIdentityInteractorMockRecorder) GetByUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockIdentityInteractor)(nil).GetByUser), ctx, userId)
+}
@@ -3,20 +3,24 @@ package usecase
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"music-backend-test/internal/entity"
 	"music-backend-test/internal/repository"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type userInteractor struct {
 	repo repository.UserRepository
+	cfg  *entity.AuthConfig
 }
 
-func NewUserInteractor(repo repository.UserRepository) *userInteractor {
+func NewUserInteractor(repo repository.UserRepository, cfg *entity.AuthConfig) *userInteractor {
 	return &userInteractor{
 		repo: repo,
+		cfg:  cfg,
 	}
 }
 
@@ -47,6 +51,17 @@ func (u *userInteractor) GetByUsername(ctx context.Context, email string) (*enti
 	return user, nil
 }
 
+// SignIn проверяет имя пользователя и пароль. Для неизвестного пользователя и неверного пароля
+// возвращается одна и та же ошибка entity.ErrInvalidCredentials.
+func (u *userInteractor) SignIn(ctx context.Context, user *entity.UserCreate) (*entity.UserDB, error) {
+	dbUser, err := u.repo.Authenticate(ctx, user.Username, user.Password)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/user.Authenticate: %w", err)
+	}
+
+	return dbUser, nil
+}
+
 func (u *userInteractor) Update(ctx context.Context, id uuid.UUID, user *entity.UserCreate) (*entity.UserDB, error) {
 	dbUser, err := u.repo.Update(ctx, id, user)
 	if err != nil {
@@ -57,7 +72,7 @@ func (u *userInteractor) Update(ctx context.Context, id uuid.UUID, user *entity.
 }
 
 func (u *userInteractor) Delete(ctx context.Context, id uuid.UUID) error {
-	err := u.repo.Delete(ctx, id)
+	err := u.repo.Delete(ctx, id, time.Now())
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return err
@@ -94,3 +109,113 @@ func (u *userInteractor) ShowLikedTracks(ctx context.Context, id uuid.UUID) ([]*
 
 	return data, nil
 }
+
+func (u *userInteractor) SetContentFilter(ctx context.Context, id uuid.UUID, filter *entity.ContentFilter) error {
+	err := u.repo.SetContentFilter(ctx, id, filter)
+	if err != nil {
+		return fmt.Errorf("/repository/user.SetContentFilter: %w", err)
+	}
+
+	return nil
+}
+
+// IssueTokens начинает новую сессию на устройстве device и выдает access-токен и refresh-токен ее семейства.
+// Вызывается после входа или регистрации.
+func (u *userInteractor) IssueTokens(ctx context.Context, userId uuid.UUID, device *entity.SessionDevice) (*entity.TokenPair, error) {
+	now := time.Now()
+	refresh, token, err := entity.NewRefreshToken(userId, uuid.New(), now, u.cfg.RefreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &entity.SessionDB{
+		ID:         token.FamilyID,
+		UserID:     userId,
+		DeviceName: device.Name,
+		UserAgent:  device.UserAgent,
+		IP:         device.IP,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  token.ExpiresAt,
+	}
+
+	err = u.repo.CreateRefreshToken(ctx, session, token)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/user.CreateRefreshToken: %w", err)
+	}
+
+	return u.tokenPair(ctx, token, refresh, now)
+}
+
+// Refresh обменивает refresh-токен на новую пару токенов. Предъявленный токен становится недействительным;
+// его повторное предъявление отзывает все токены, выданные после того же входа. Сессия запоминает устройство device.
+func (u *userInteractor) Refresh(ctx context.Context, refreshToken string, device *entity.SessionDevice) (*entity.TokenPair, error) {
+	if refreshToken == "" {
+		return nil, entity.ErrInvalidRefreshToken
+	}
+
+	now := time.Now()
+	refresh, next, err := entity.NewRefreshToken(uuid.Nil, uuid.Nil, now, u.cfg.RefreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := u.repo.RotateRefreshToken(ctx, entity.HashRefreshToken(refreshToken), next, device, now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("/repository/user.RotateRefreshToken: %w", err)
+	}
+
+	return u.tokenPair(ctx, token, refresh, now)
+}
+
+// Logout отзывает сессию, к которой относится refresh-токен. Неизвестный токен не считается ошибкой.
+func (u *userInteractor) Logout(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return entity.ErrInvalidRefreshToken
+	}
+
+	err := u.repo.RevokeRefreshTokenFamily(ctx, entity.HashRefreshToken(refreshToken), time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/user.RevokeRefreshTokenFamily: %w", err)
+	}
+
+	return nil
+}
+
+// LogoutAll отзывает все сессии пользователя
+func (u *userInteractor) LogoutAll(ctx context.Context, userId uuid.UUID) error {
+	err := u.repo.RevokeRefreshTokens(ctx, userId, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/user.RevokeRefreshTokens: %w", err)
+	}
+
+	return nil
+}
+
+// CleanupRefreshTokens удаляет истекшие refresh-токены
+func (u *userInteractor) CleanupRefreshTokens(ctx context.Context) error {
+	err := u.repo.DeleteExpiredRefreshTokens(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("/repository/user.DeleteExpiredRefreshTokens: %w", err)
+	}
+
+	return nil
+}
+
+func (u *userInteractor) tokenPair(ctx context.Context, token *entity.RefreshTokenDB, refresh string, now time.Time) (*entity.TokenPair, error) {
+	access, err := u.repo.SignAccessToken(ctx, entity.NewAccessClaims(token.UserID, token.FamilyID, now, u.cfg.AccessTokenTTL), now)
+	if err != nil {
+		return nil, fmt.Errorf("/repository/user.SignAccessToken: %w", err)
+	}
+
+	return &entity.TokenPair{
+		SessionID:        token.FamilyID,
+		Access:           access,
+		AccessExpiresAt:  now.Add(u.cfg.AccessTokenTTL),
+		Refresh:          refresh,
+		RefreshExpiresAt: token.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/tcolgate/mp3"
+)
+
+const (
+	FingerprintWindow  = 500 * time.Millisecond // длительность окна огибающей громкости
+	MaxFingerprintBits = 1024                   // количество битов отпечатка, около 8,5 минут звука
+)
+
+func (mu *musicUtils) GetFingerprint(fileType FileType, filePath string, os FileSystem) ([]byte, error) {
+	switch fileType {
+	case MP3:
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("can't open file: %w", err)
+		}
+		defer file.Close()
+
+		envelope, err := mp3Envelope(file)
+		if err != nil {
+			return nil, fmt.Errorf("can't get audio envelope: %w", err)
+		}
+
+		return envelopeFingerprint(envelope), nil
+	default:
+		return nil, fmt.Errorf("unsupported file type: %s", fileType)
+	}
+}
+
+// mp3Envelope возвращает среднюю громкость окон FingerprintWindow. Звук не декодируется:
+// громкость оценивается по global_gain гранул из побочной информации кадров Layer III.
+func mp3Envelope(r io.Reader) ([]float64, error) {
+	decoder := mp3.NewDecoder(r)
+
+	var envelope []float64
+	var frame mp3.Frame
+	var sum time.Duration
+	var gains, count float64
+	skipped := 0
+
+	for len(envelope) <= MaxFingerprintBits {
+		if err := decoder.Decode(&frame, &skipped); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("can't decode file: %w", err)
+		}
+		if frame.Header().Layer() != mp3.Layer3 {
+			continue
+		}
+
+		gain, ok := frameGain(&frame)
+		if ok {
+			gains += gain
+			count++
+		}
+
+		sum += frame.Duration()
+		if sum >= FingerprintWindow {
+			if count > 0 {
+				envelope = append(envelope, gains/count)
+			}
+			sum, gains, count = 0, 0, 0
+		}
+	}
+
+	return envelope, nil
+}
+
+// frameGain возвращает среднее значение global_gain гранул и каналов кадра Layer III
+func frameGain(frame *mp3.Frame) (float64, bool) {
+	header := frame.Header()
+	channels, privateBits := 2, 3
+	if header.ChannelMode() == mp3.SingleChannel {
+		channels, privateBits = 1, 5
+	}
+
+	// Размеры полей побочной информации из ISO/IEC 11172-3 и 13818-3
+	var offset, granules, granuleBits int
+	switch header.Version() {
+	case mp3.MPEG1:
+		offset = 9 + privateBits + 4*channels // main_data_begin, private_bits, scfsi
+		granules, granuleBits = 2, 59
+	case mp3.MPEG2, mp3.MPEG25:
+		offset = 8 + channels // main_data_begin, private_bits: 1 бит для моно, 2 для стерео
+		granules, granuleBits = 1, 63
+	default:
+		return 0, false
+	}
+
+	sideInfo := frame.SideInfo()
+	total, values := 0, 0
+	for granule := 0; granule < granules; granule++ {
+		for channel := 0; channel < channels; channel++ {
+			// global_gain следует за part2_3_length (12 бит) и big_values (9 бит)
+			value, ok := readBits(sideInfo, offset+21, 8)
+			if !ok {
+				return 0, false
+			}
+			total += value
+			values++
+			offset += granuleBits
+		}
+	}
+
+	return float64(total) / float64(values), true
+}
+
+// readBits читает n битов data, начиная с бита offset, от старшего к младшему
+func readBits(data []byte, offset int, n int) (int, bool) {
+	if (offset+n+7)/8 > len(data) {
+		return 0, false
+	}
+	value := 0
+	for i := offset; i < offset+n; i++ {
+		value = value<<1 | int(data[i/8]>>(7-i%8)&1)
+	}
+	return value, true
+}
+
+// envelopeFingerprint кодирует огибающую битами: бит равен 1, если громкость следующего окна выше текущего.
+// Отпечаток не зависит от общей громкости и битрейта файла.
+func envelopeFingerprint(envelope []float64) []byte {
+	if len(envelope) < 2 {
+		return nil
+	}
+	size := min(len(envelope)-1, MaxFingerprintBits)
+
+	fingerprint := make([]byte, (size+7)/8)
+	for i := 0; i < size; i++ {
+		if envelope[i+1] > envelope[i] {
+			fingerprint[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return fingerprint
+}
@@ -0,0 +1,328 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Формат меток времени SYLT: абсолютное время в миллисекундах
+const sylTimestampMilliseconds = 2
+
+// Отметка iTunes о ненормативном контенте во фрейме TXXX
+const (
+	id3AdvisoryDescription = "ITUNESADVISORY"
+	id3AdvisoryExplicit    = "1"
+)
+
+const (
+	id3EncodingISO88591 = 0
+	id3EncodingUTF16    = 1
+	id3EncodingUTF16BE  = 2
+	id3EncodingUTF8     = 3
+)
+
+// Текст песни из тега ID3
+type EmbeddedLyrics struct {
+	Language string        // код языка ISO 639-2 из фрейма
+	Text     string        // текст из фрейма USLT
+	Synced   []SyncedLyric // строки из фрейма SYLT
+}
+
+type SyncedLyric struct {
+	Time time.Duration // время начала строки
+	Text string        // текст строки
+}
+
+func (mu *musicUtils) GetLyrics(fileType FileType, filePath string, os FileSystem) ([]*EmbeddedLyrics, error) {
+	switch fileType {
+	case MP3:
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("can't open file: %w", err)
+		}
+		defer file.Close()
+
+		lyrics, err := ReadID3Lyrics(file)
+		if err != nil {
+			return nil, fmt.Errorf("can't read id3 lyrics: %w", err)
+		}
+		return lyrics, nil
+	default:
+		return nil, fmt.Errorf("unsupported file type: %s", fileType)
+	}
+}
+
+func (mu *musicUtils) GetExplicit(fileType FileType, filePath string, os FileSystem) (bool, error) {
+	switch fileType {
+	case MP3:
+		file, err := os.Open(filePath)
+		if err != nil {
+			return false, fmt.Errorf("can't open file: %w", err)
+		}
+		defer file.Close()
+
+		explicit, err := ReadID3Advisory(file)
+		if err != nil {
+			return false, fmt.Errorf("can't read id3 advisory: %w", err)
+		}
+		return explicit, nil
+	default:
+		return false, fmt.Errorf("unsupported file type: %s", fileType)
+	}
+}
+
+// ReadID3Lyrics читает фреймы USLT и SYLT из тега ID3v2.3/2.4 в начале файла.
+// Фреймы одного языка объединяются. Если тега нет, возвращается пустой список.
+func ReadID3Lyrics(r io.Reader) ([]*EmbeddedLyrics, error) {
+	frames, err := readID3Frames(r)
+	if err != nil {
+		return nil, err
+	}
+
+	byLanguage := map[string]*EmbeddedLyrics{}
+	var result []*EmbeddedLyrics
+	get := func(language string) *EmbeddedLyrics {
+		if lyrics, ok := byLanguage[language]; ok {
+			return lyrics
+		}
+		lyrics := &EmbeddedLyrics{Language: language}
+		byLanguage[language] = lyrics
+		result = append(result, lyrics)
+		return lyrics
+	}
+
+	for _, frame := range frames {
+		if frame.id != "USLT" && frame.id != "SYLT" {
+			continue
+		}
+
+		data := frame.data
+		if len(data) < 4 {
+			continue
+		}
+		encoding, language := data[0], strings.ToLower(string(data[1:4]))
+
+		switch frame.id {
+		case "USLT":
+			_, rest := splitID3String(encoding, data[4:])
+			text := strings.TrimSpace(decodeID3String(encoding, rest))
+			if text == "" {
+				continue
+			}
+			lyrics := get(language)
+			if lyrics.Text != "" {
+				lyrics.Text += "\n"
+			}
+			lyrics.Text += text
+		case "SYLT":
+			if len(data) < 6 || data[4] != sylTimestampMilliseconds {
+				continue
+			}
+			_, rest := splitID3String(encoding, data[6:])
+			lines := parseSYLT(encoding, rest)
+			if len(lines) == 0 {
+				continue
+			}
+			lyrics := get(language)
+			lyrics.Synced = append(lyrics.Synced, lines...)
+		}
+	}
+
+	return result, nil
+}
+
+// ReadID3Advisory читает отметку о ненормативном контенте из фрейма TXXX:ITUNESADVISORY.
+// Значение 1 означает explicit, 2 — clean. Если отметки нет, возвращается false.
+func ReadID3Advisory(r io.Reader) (bool, error) {
+	frames, err := readID3Frames(r)
+	if err != nil {
+		return false, err
+	}
+
+	for _, frame := range frames {
+		if frame.id != "TXXX" || len(frame.data) < 1 {
+			continue
+		}
+		encoding := frame.data[0]
+		description, value := splitID3String(encoding, frame.data[1:])
+		if !strings.EqualFold(decodeID3String(encoding, description), id3AdvisoryDescription) {
+			continue
+		}
+		value, _ = splitID3String(encoding, value)
+		return strings.TrimSpace(decodeID3String(encoding, value)) == id3AdvisoryExplicit, nil
+	}
+
+	return false, nil
+}
+
+// Фрейм тега ID3 с данными без служебных байтов формата
+type id3Frame struct {
+	id   string
+	data []byte
+}
+
+// readID3Frames читает фреймы тега ID3v2.3/2.4 в начале файла. Сжатые и зашифрованные фреймы пропускаются.
+// Если тега нет, возвращается пустой список.
+func readID3Frames(r io.Reader) ([]id3Frame, error) {
+	header := make([]byte, 10)
+	if _, err := io.ReadFull(r, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if string(header[:3]) != "ID3" {
+		return nil, nil
+	}
+
+	version, flags := header[3], header[5]
+	if version != 3 && version != 4 {
+		return nil, nil
+	}
+
+	tag := make([]byte, syncsafe(header[6:10]))
+	if _, err := io.ReadFull(r, tag); err != nil {
+		return nil, fmt.Errorf("can't read tag: %w", err)
+	}
+
+	if version == 3 && flags&0x80 != 0 {
+		tag = removeUnsynchronisation(tag)
+	}
+
+	if flags&0x40 != 0 && len(tag) >= 4 {
+		// Расширенный заголовок: в 2.4 размер включает сам заголовок, в 2.3 — нет
+		size := int(binary.BigEndian.Uint32(tag[:4])) + 4
+		if version == 4 {
+			size = syncsafe(tag[:4])
+		}
+		if size > len(tag) {
+			return nil, fmt.Errorf("invalid extended header size")
+		}
+		tag = tag[size:]
+	}
+
+	var frames []id3Frame
+	for len(tag) >= 10 && tag[0] != 0 {
+		id := string(tag[:4])
+		size := int(binary.BigEndian.Uint32(tag[4:8]))
+		if version == 4 {
+			size = syncsafe(tag[4:8])
+		}
+		formatFlags := tag[9]
+		if size < 0 || 10+size > len(tag) {
+			return nil, fmt.Errorf("invalid frame %s size", id)
+		}
+		data := tag[10 : 10+size]
+		tag = tag[10+size:]
+
+		// Сжатые и зашифрованные фреймы пропускаются, идентификатор группы и размер данных отбрасываются
+		if version == 4 {
+			if formatFlags&0x0C != 0 {
+				continue
+			}
+			if formatFlags&0x40 != 0 && len(data) >= 1 {
+				data = data[1:]
+			}
+			if formatFlags&0x01 != 0 && len(data) >= 4 {
+				data = data[4:]
+			}
+			if formatFlags&0x02 != 0 {
+				data = removeUnsynchronisation(data)
+			}
+		} else {
+			if formatFlags&0xC0 != 0 {
+				continue
+			}
+			if formatFlags&0x20 != 0 && len(data) >= 1 {
+				data = data[1:]
+			}
+		}
+
+		frames = append(frames, id3Frame{id: id, data: data})
+	}
+
+	return frames, nil
+}
+
+func parseSYLT(encoding byte, data []byte) []SyncedLyric {
+	var lines []SyncedLyric
+	for len(data) > 0 {
+		text, rest := splitID3String(encoding, data)
+		if len(rest) < 4 {
+			break
+		}
+		ms := binary.BigEndian.Uint32(rest[:4])
+		data = rest[4:]
+
+		// Строки могут начинаться с перевода строки, который отделяет их от предыдущей
+		lines = append(lines, SyncedLyric{
+			Time: time.Duration(ms) * time.Millisecond,
+			Text: strings.TrimSpace(decodeID3String(encoding, text)),
+		})
+	}
+	return lines
+}
+
+// splitID3String отделяет строку, завершенную нулевым символом, от остатка данных
+func splitID3String(encoding byte, data []byte) ([]byte, []byte) {
+	if encoding == id3EncodingUTF16 || encoding == id3EncodingUTF16BE {
+		for i := 0; i+1 < len(data); i += 2 {
+			if data[i] == 0 && data[i+1] == 0 {
+				return data[:i], data[i+2:]
+			}
+		}
+		return data, nil
+	}
+
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return data[:i], data[i+1:]
+	}
+	return data, nil
+}
+
+func decodeID3String(encoding byte, data []byte) string {
+	switch encoding {
+	case id3EncodingUTF16, id3EncodingUTF16BE:
+		bigEndian := encoding == id3EncodingUTF16BE
+		if len(data) >= 2 {
+			switch {
+			case data[0] == 0xFE && data[1] == 0xFF:
+				bigEndian, data = true, data[2:]
+			case data[0] == 0xFF && data[1] == 0xFE:
+				bigEndian, data = false, data[2:]
+			}
+		}
+		units := make([]uint16, len(data)/2)
+		for i := range units {
+			if bigEndian {
+				units[i] = binary.BigEndian.Uint16(data[2*i:])
+			} else {
+				units[i] = binary.LittleEndian.Uint16(data[2*i:])
+			}
+		}
+		return string(utf16.Decode(units))
+	case id3EncodingUTF8:
+		return string(data)
+	default:
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+}
+
+// removeUnsynchronisation восстанавливает данные, в которых после каждого 0xFF вставлен 0x00
+func removeUnsynchronisation(data []byte) []byte {
+	return bytes.ReplaceAll(data, []byte{0xFF, 0x00}, []byte{0xFF})
+}
+
+func syncsafe(b []byte) int {
+	return int(b[0]&0x7F)<<21 | int(b[1]&0x7F)<<14 | int(b[2]&0x7F)<<7 | int(b[3]&0x7F)
+}
@@ -8,6 +8,9 @@ import (
 type MusicUtils interface {
 	GetSupportedFileType(filename string) (FileType, error)
 	GetAudioDuration(fileType FileType, filePath string, os FileSystem) (string, error)
+	GetLyrics(fileType FileType, filePath string, os FileSystem) ([]*EmbeddedLyrics, error)
+	GetExplicit(fileType FileType, filePath string, os FileSystem) (bool, error)
+	GetFingerprint(fileType FileType, filePath string, os FileSystem) ([]byte, error)
 }
 
 type FileSystem interface {
@@ -15,4 +18,11 @@ type FileSystem interface {
 	Create(string) (*os.File, error)
 	Copy(writer io.Writer, reader io.Reader) (int64, error)
 	Remove(string) error
+	Rename(oldPath string, newPath string) error
+	MkdirAll(path string) error
+}
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(encoded string, password string) (ok bool, rehash bool)
 }
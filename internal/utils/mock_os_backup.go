@@ -40,3 +40,17 @@ func (mockOs *MockOS) Remove(file string) error {
 	}
 	return nil
 }
+
+func (mockOs *MockOS) Rename(oldPath string, newPath string) error {
+	if oldPath == "" || newPath == "" {
+		return fmt.Errorf("Error in os Rename")
+	}
+	return nil
+}
+
+func (mockOs *MockOS) MkdirAll(path string) error {
+	if path == "" {
+		return fmt.Errorf("Error in os MkdirAll")
+	}
+	return nil
+}
@@ -42,3 +42,19 @@ func (fileSystem *fileSystem) Remove(file string) error {
 	}
 	return nil
 }
+
+func (fileSystem *fileSystem) Rename(oldPath string, newPath string) error {
+	err := os.Rename(oldPath, newPath)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (fileSystem *fileSystem) MkdirAll(path string) error {
+	err := os.MkdirAll(path, 0o755)
+	if err != nil {
+		return err
+	}
+	return nil
+}
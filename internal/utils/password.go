@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Параметры Argon2id по рекомендации OWASP: 19 МиБ памяти, 2 прохода, 1 поток
+const (
+	Argon2Memory  uint32 = 19 * 1024 // память в КиБ
+	Argon2Time    uint32 = 2         // количество проходов
+	Argon2Threads uint8  = 1         // количество потоков
+	Argon2KeyLen  uint32 = 32        // длина хэша в байтах
+	Argon2SaltLen        = 16        // длина соли в байтах
+)
+
+const argon2idPrefix = "$argon2id$"
+
+// DummyPasswordHash — хэш Argon2id с текущими параметрами, с которым сравнивается пароль при входе
+// несуществующего пользователя, чтобы по времени ответа нельзя было узнать, зарегистрировано ли имя
+const DummyPasswordHash = "$argon2id$v=19$m=19456,t=2,p=1$62dgXwHa3KOkrugnb1jL4w$xPTtiSK9tP5KCGssuuz1CzL04psdSWUsZyutSdcogBE"
+
+type passwordHasher struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+}
+
+func NewPasswordHasher() *passwordHasher {
+	return &passwordHasher{
+		memory:  Argon2Memory,
+		time:    Argon2Time,
+		threads: Argon2Threads,
+	}
+}
+
+// Hash возвращает хэш Argon2id в формате PHC: $argon2id$v=19$m=...,t=...,p=...$<соль>$<хэш>
+func (p *passwordHasher) Hash(password string) (string, error) {
+	salt := make([]byte, Argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("can't generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, p.time, p.memory, p.threads, Argon2KeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version, p.memory, p.time, p.threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify проверяет пароль по сохраненному значению за время, не зависящее от совпадения.
+// Поддерживаются Argon2id, bcrypt и пароли, сохраненные открытым текстом до появления хэширования.
+// rehash — true, если пароль верный, но сохранен не Argon2id с текущими параметрами.
+func (p *passwordHasher) Verify(encoded string, password string) (ok bool, rehash bool) {
+	switch {
+	case strings.HasPrefix(encoded, argon2idPrefix):
+		if ok, current, valid := p.verifyArgon2id(encoded, password); valid {
+			return ok, ok && !current
+		}
+	case isBcrypt(encoded):
+		if _, err := bcrypt.Cost([]byte(encoded)); err == nil {
+			ok = bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) == nil
+			return ok, ok
+		}
+	}
+
+	// Значение не разбирается как хэш, значит это пароль, сохраненный открытым текстом
+	ok = subtle.ConstantTimeCompare([]byte(encoded), []byte(password)) == 1
+	return ok, ok
+}
+
+// verifyArgon2id сравнивает пароль с хэшем Argon2id. current — хэш вычислен с текущими параметрами,
+// valid — false, если значение не удалось разобрать.
+func (p *passwordHasher) verifyArgon2id(encoded string, password string) (ok bool, current bool, valid bool) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return false, false, false
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false, false
+	}
+
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil || memory == 0 || time == 0 || threads == 0 {
+		return false, false, false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return false, false, false
+	}
+
+	computed := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	ok = subtle.ConstantTimeCompare(key, computed) == 1
+	current = memory == p.memory && time == p.time && threads == p.threads && len(key) == int(Argon2KeyLen)
+
+	return ok, current, true
+}
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"bytes"
+	"math/rand"
+	"music-backend-test/internal/entity"
+	"music-backend-test/internal/utils"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Кадр MPEG-1 Layer III, моно, 128 кбит/с, 44,1 кГц: 417 байт, около 26 мс
+const testFrameSize = 417
+
+// testMP3 возвращает файл из кадров, по framesPerGain кадров на каждое значение global_gain
+func testMP3(gains []int, framesPerGain int) []byte {
+	var buf bytes.Buffer
+	for _, gain := range gains {
+		for i := 0; i < framesPerGain; i++ {
+			frame := make([]byte, testFrameSize)
+			copy(frame, []byte{0xFF, 0xFB, 0x90, 0xC0})
+			// global_gain гранул 0 и 1 в побочной информации моно-кадра
+			setBits(frame[4:], 39, 8, gain)
+			setBits(frame[4:], 98, 8, gain)
+			buf.Write(frame)
+		}
+	}
+	return buf.Bytes()
+}
+
+func setBits(data []byte, offset int, n int, value int) {
+	for i := 0; i < n; i++ {
+		if value>>(n-1-i)&1 == 1 {
+			bit := offset + i
+			data[bit/8] |= 0x80 >> (bit % 8)
+		}
+	}
+}
+
+func writeTestMP3(t *testing.T, name string, data []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, data, 0o644))
+	return path
+}
+
+func Test_GetFingerprint(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	gains := make([]int, 80)
+	other := make([]int, 80)
+	for i := range gains {
+		gains[i] = 120 + random.Intn(60)
+		other[i] = 120 + random.Intn(60)
+	}
+
+	// Та же запись громче и с лишним окном тишины в начале
+	louder := []int{100}
+	for _, gain := range gains {
+		louder = append(louder, gain+10)
+	}
+
+	musicUtils := utils.NewmusicUtils()
+	fingerprint := func(name string, gains []int) []byte {
+		path := writeTestMP3(t, name, testMP3(gains, 20))
+		data, err := musicUtils.GetFingerprint(utils.MP3, path, utils.NewFileSystem())
+		assert.NoError(t, err)
+		return data
+	}
+
+	original := fingerprint("original.mp3", gains)
+	assert.Len(t, original, 10)
+
+	similarity, ok := entity.FingerprintSimilarity(original, fingerprint("louder.mp3", louder))
+	assert.True(t, ok)
+	assert.GreaterOrEqual(t, similarity, entity.DuplicateFingerprintThreshold)
+
+	similarity, ok = entity.FingerprintSimilarity(original, fingerprint("other.mp3", other))
+	assert.True(t, ok)
+	assert.Less(t, similarity, entity.DuplicateFingerprintThreshold)
+}
+
+func Test_GetFingerprint_unsupported(t *testing.T) {
+	_, err := utils.NewmusicUtils().GetFingerprint(utils.Invalid, "test.ogg", utils.NewFileSystem())
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"music-backend-test/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func id3Frame(id string, data []byte) []byte {
+	frame := make([]byte, 10, 10+len(data))
+	copy(frame, id)
+	binary.BigEndian.PutUint32(frame[4:8], uint32(len(data)))
+	return append(frame, data...)
+}
+
+func id3Tag(frames ...[]byte) []byte {
+	body := bytes.Join(frames, nil)
+	size := len(body)
+	header := []byte{'I', 'D', '3', 3, 0, 0,
+		byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}
+	return append(header, body...)
+}
+
+func sylt(language string, lines ...utils.SyncedLyric) []byte {
+	data := []byte{3}
+	data = append(data, language...)
+	data = append(data, 2, 1, 0)
+	for _, line := range lines {
+		data = append(data, line.Text...)
+		data = append(data, 0)
+		data = binary.BigEndian.AppendUint32(data, uint32(line.Time.Milliseconds()))
+	}
+	return data
+}
+
+func Test_ReadID3Lyrics(t *testing.T) {
+	uslt := append([]byte{3}, "eng"...)
+	uslt = append(uslt, 0)
+	uslt = append(uslt, "Hello\nWorld"...)
+
+	// UTF-16 с BOM, дескриптор пустой
+	utf16 := append([]byte{1}, "rus"...)
+	utf16 = append(utf16, 0xFF, 0xFE, 0, 0, 0xFF, 0xFE, 0x1F, 0x04, 0x40, 0x04, 0x38, 0x04)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []*utils.EmbeddedLyrics
+		wantErr bool
+	}{
+		{
+			name: "USLT and SYLT frames",
+			data: id3Tag(
+				id3Frame("TIT2", []byte{3, 'S', 'o', 'n', 'g'}),
+				id3Frame("USLT", uslt),
+				id3Frame("SYLT", sylt("eng",
+					utils.SyncedLyric{Time: 1500 * time.Millisecond, Text: "Hello"},
+					utils.SyncedLyric{Time: 3 * time.Second, Text: "\nWorld"},
+				)),
+				id3Frame("USLT", utf16),
+			),
+			want: []*utils.EmbeddedLyrics{
+				{
+					Language: "eng",
+					Text:     "Hello\nWorld",
+					Synced: []utils.SyncedLyric{
+						{Time: 1500 * time.Millisecond, Text: "Hello"},
+						{Time: 3 * time.Second, Text: "World"},
+					},
+				},
+				{Language: "rus", Text: "При"},
+			},
+		},
+		{
+			name: "No lyrics frames",
+			data: id3Tag(id3Frame("TIT2", []byte{3, 'S', 'o', 'n', 'g'})),
+		},
+		{
+			name: "No tag",
+			data: []byte{0xFF, 0xFB, 0x90, 0x64, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:    "Frame larger than tag",
+			data:    id3Tag(append(id3Frame("USLT", uslt)[:10], 'x')),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.ReadID3Lyrics(bytes.NewReader(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func txxx(description string, value string) []byte {
+	data := append([]byte{3}, description...)
+	data = append(data, 0)
+	return append(data, value...)
+}
+
+func Test_ReadID3Advisory(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Explicit",
+			data: id3Tag(
+				id3Frame("TXXX", txxx("REPLAYGAIN_TRACK_GAIN", "-6.5 dB")),
+				id3Frame("TXXX", txxx("ITUNESADVISORY", "1")),
+			),
+			want: true,
+		},
+		{
+			name: "Clean",
+			data: id3Tag(id3Frame("TXXX", txxx("iTunesAdvisory", "2"))),
+			want: false,
+		},
+		{
+			name: "No advisory frame",
+			data: id3Tag(id3Frame("TIT2", []byte{3, 'S', 'o', 'n', 'g'})),
+			want: false,
+		},
+		{
+			name: "No tag",
+			data: []byte{0xFF, 0xFB, 0x90, 0x64, 0, 0, 0, 0, 0, 0, 0, 0},
+			want: false,
+		},
+		{
+			name:    "Frame larger than tag",
+			data:    id3Tag(append(id3Frame("TXXX", txxx("ITUNESADVISORY", "1"))[:10], 'x')),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.ReadID3Advisory(bytes.NewReader(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
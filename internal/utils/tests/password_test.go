@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"music-backend-test/internal/utils"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func Test_passwordHasher_Hash(t *testing.T) {
+	hasher := utils.NewPasswordHasher()
+
+	hash, err := hasher.Hash("qwerty1234")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=19456,t=2,p=1$"))
+
+	other, err := hasher.Hash("qwerty1234")
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, other, "соль должна быть случайной")
+
+	ok, rehash := hasher.Verify(hash, "qwerty1234")
+	assert.True(t, ok)
+	assert.False(t, rehash)
+
+	ok, rehash = hasher.Verify(hash, "qwerty12345")
+	assert.False(t, ok)
+	assert.False(t, rehash)
+}
+
+func Test_passwordHasher_Verify(t *testing.T) {
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("qwerty1234"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	salt := []byte("0123456789abcdef")
+	outdated := fmt.Sprintf("$argon2id$v=19$m=8192,t=1,p=1$%s$%s", base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(argon2.IDKey([]byte("qwerty1234"), salt, 1, 8192, 1, 32)))
+
+	tests := []struct {
+		name       string
+		encoded    string
+		password   string
+		wantOk     bool
+		wantRehash bool
+	}{
+		{
+			name:       "plaintext",
+			encoded:    "qwerty1234",
+			password:   "qwerty1234",
+			wantOk:     true,
+			wantRehash: true,
+		},
+		{
+			name:     "plaintext: wrong password",
+			encoded:  "qwerty1234",
+			password: "qwerty",
+		},
+		{
+			name:       "bcrypt",
+			encoded:    string(bcryptHash),
+			password:   "qwerty1234",
+			wantOk:     true,
+			wantRehash: true,
+		},
+		{
+			name:     "bcrypt: wrong password",
+			encoded:  string(bcryptHash),
+			password: "qwerty",
+		},
+		{
+			name:       "argon2id with outdated parameters",
+			encoded:    outdated,
+			password:   "qwerty1234",
+			wantOk:     true,
+			wantRehash: true,
+		},
+		{
+			name:     "argon2id with outdated parameters: wrong password",
+			encoded:  outdated,
+			password: "qwerty",
+		},
+		{
+			name:       "plaintext that looks like a hash",
+			encoded:    "$argon2id$secret",
+			password:   "$argon2id$secret",
+			wantOk:     true,
+			wantRehash: true,
+		},
+	}
+	hasher := utils.NewPasswordHasher()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, rehash := hasher.Verify(tt.encoded, tt.password)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantRehash, rehash)
+		})
+	}
+}
+
+func Test_DummyPasswordHash(t *testing.T) {
+	// Проверка по фиктивному хэшу должна стоить столько же, сколько по хэшу с текущими параметрами
+	params := fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$", argon2.Version, utils.Argon2Memory, utils.Argon2Time, utils.Argon2Threads)
+	assert.True(t, strings.HasPrefix(utils.DummyPasswordHash, params))
+
+	ok, rehash := utils.NewPasswordHasher().Verify(utils.DummyPasswordHash, "qwerty1234")
+	assert.False(t, ok)
+	assert.False(t, rehash)
+}
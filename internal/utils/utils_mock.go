@@ -58,3 +58,101 @@ func (mr *MockMusicUtilsMockRecorder) GetSupportedFileType(filename string) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupportedFileType", reflect.TypeOf((*MockMusicUtils)(nil).GetSupportedFileType), filename)
 }
+
+// GetExplicit mocks base method.
+func (m *MockMusicUtils) GetExplicit(fileType FileType, filePath string, filesystem FileSystem) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExplicit", fileType, filePath, filesystem)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExplicit indicates an expected call of GetExplicit.
+func (mr *MockMusicUtilsMockRecorder) GetExplicit(fileType FileType, filePath string, filesystem FileSystem) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExplicit", reflect.TypeOf((*MockMusicUtils)(nil).GetExplicit), fileType, filePath, filesystem)
+}
+
+// GetFingerprint mocks base method.
+func (m *MockMusicUtils) GetFingerprint(fileType FileType, filePath string, filesystem FileSystem) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFingerprint", fileType, filePath, filesystem)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFingerprint indicates an expected call of GetFingerprint.
+func (mr *MockMusicUtilsMockRecorder) GetFingerprint(fileType FileType, filePath string, filesystem FileSystem) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFingerprint", reflect.TypeOf((*MockMusicUtils)(nil).GetFingerprint), fileType, filePath, filesystem)
+}
+
+// GetLyrics mocks base method.
+func (m *MockMusicUtils) GetLyrics(fileType FileType, filePath string, filesystem FileSystem) ([]*EmbeddedLyrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLyrics", fileType, filePath, filesystem)
+	ret0, _ := ret[0].([]*EmbeddedLyrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLyrics indicates an expected call of GetLyrics.
+func (mr *MockMusicUtilsMockRecorder) GetLyrics(fileType FileType, filePath string, filesystem FileSystem) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLyrics", reflect.TypeOf((*MockMusicUtils)(nil).GetLyrics), fileType, filePath, filesystem)
+}
+
+// MockPasswordHasher is a mock of PasswordHasher interface.
+type MockPasswordHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHasherMockRecorder
+}
+
+// MockPasswordHasherMockRecorder is the mock recorder for MockPasswordHasher.
+type MockPasswordHasherMockRecorder struct {
+	mock *MockPasswordHasher
+}
+
+// NewMockPasswordHasher creates a new mock instance.
+func NewMockPasswordHasher(ctrl *gomock.Controller) *MockPasswordHasher {
+	mock := &MockPasswordHasher{ctrl: ctrl}
+	mock.recorder = &MockPasswordHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordHasher) EXPECT() *MockPasswordHasherMockRecorder {
+	return m.recorder
+}
+
+// Hash mocks base method.
+func (m *MockPasswordHasher) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockPasswordHasherMockRecorder) Hash(password string) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockPasswordHasher)(nil).Hash), password)
+}
+
+// Verify mocks base method.
+func (m *MockPasswordHasher) Verify(encoded string, password string) (bool, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", encoded, password)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockPasswordHasherMockRecorder) Verify(encoded string, password string) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockPasswordHasher)(nil).Verify), encoded, password)
+}